@@ -0,0 +1,276 @@
+package horizonclient
+
+import (
+	"context"
+	"sort"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+)
+
+// AccountEvent is a change of an account detected by WatchAccount or
+// DiffAccounts. It is one of BalanceChangedEvent, SignerChangedEvent,
+// DataChangedEvent, ThresholdsChangedEvent or FlagsChangedEvent.
+type AccountEvent interface {
+	GetAccountID() string
+}
+
+// BalanceChangedEvent is emitted when a balance (including its limit,
+// liabilities and authorization flags) changes. Previous is nil when the
+// trustline was added and Current is nil when it was removed.
+type BalanceChangedEvent struct {
+	AccountID string
+	// Asset is "native", "CODE:ISSUER" or the liquidity pool id of a pool share.
+	Asset    string
+	Previous *hProtocol.Balance
+	Current  *hProtocol.Balance
+}
+
+// SignerChangedEvent is emitted when a signer is added, removed or has its
+// weight or sponsor changed. Previous is nil when the signer was added and
+// Current is nil when it was removed.
+type SignerChangedEvent struct {
+	AccountID string
+	Key       string
+	Previous  *hProtocol.Signer
+	Current   *hProtocol.Signer
+}
+
+// DataChangedEvent is emitted when a data entry is added, removed or updated.
+// The values are base64 encoded. Previous is nil when the entry was added and
+// Current is nil when it was removed.
+type DataChangedEvent struct {
+	AccountID string
+	Key       string
+	Previous  *string
+	Current   *string
+}
+
+// ThresholdsChangedEvent is emitted when the thresholds of an account change.
+type ThresholdsChangedEvent struct {
+	AccountID string
+	Previous  hProtocol.AccountThresholds
+	Current   hProtocol.AccountThresholds
+}
+
+// FlagsChangedEvent is emitted when the flags of an account change.
+type FlagsChangedEvent struct {
+	AccountID string
+	Previous  hProtocol.AccountFlags
+	Current   hProtocol.AccountFlags
+}
+
+// GetAccountID returns the id of the account which changed.
+func (e BalanceChangedEvent) GetAccountID() string { return e.AccountID }
+
+// GetAccountID returns the id of the account which changed.
+func (e SignerChangedEvent) GetAccountID() string { return e.AccountID }
+
+// GetAccountID returns the id of the account which changed.
+func (e DataChangedEvent) GetAccountID() string { return e.AccountID }
+
+// GetAccountID returns the id of the account which changed.
+func (e ThresholdsChangedEvent) GetAccountID() string { return e.AccountID }
+
+// GetAccountID returns the id of the account which changed.
+func (e FlagsChangedEvent) GetAccountID() string { return e.AccountID }
+
+// AccountEventHandler is a function that is called for every change of a
+// watched account.
+type AccountEventHandler func(AccountEvent)
+
+// WatchAccount watches the given account and calls handler for every change
+// of its balances, signers, data entries, thresholds and flags. The account is
+// fetched again every time Horizon streams a new transaction involving it, and
+// compared to its previous state (see DiffAccounts). The transactions are
+// streamed from the latest ledger ingested by Horizon before the account is
+// first fetched, so that a transaction applied in between is not missed.
+//
+// Use context.WithCancel to stop watching or context.Background() to watch
+// indefinitely.
+func (c *Client) WatchAccount(ctx context.Context, accountID string, handler AccountEventHandler) error {
+	root, err := c.Root()
+	if err != nil {
+		return errors.Wrap(err, "error fetching root")
+	}
+	cursor := toid.New(root.HorizonSequence, 0, 0).String()
+
+	request := AccountRequest{AccountID: accountID}
+	previous, err := c.AccountDetail(request)
+	if err != nil {
+		return errors.Wrap(err, "error fetching account")
+	}
+
+	// The handler cannot return an error, so a failure to fetch the account
+	// stops the stream by cancelling its context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var fetchErr error
+	err = c.StreamTransactions(ctx, TransactionRequest{ForAccount: accountID, Cursor: cursor}, func(hProtocol.Transaction) {
+		current, err := c.AccountDetail(request)
+		if err != nil {
+			fetchErr = err
+			cancel()
+			return
+		}
+		for _, event := range DiffAccounts(previous, current) {
+			handler(event)
+		}
+		previous = current
+	})
+	if fetchErr != nil {
+		return errors.Wrap(fetchErr, "error fetching account")
+	}
+	return err
+}
+
+// DiffAccounts compares two states of the same account and returns the
+// events describing the changes, in a deterministic order: balances, signers,
+// data entries, thresholds and flags.
+func DiffAccounts(previous, current hProtocol.Account) []AccountEvent {
+	accountID := current.AccountID
+	var events []AccountEvent
+
+	previousBalances := map[string]hProtocol.Balance{}
+	for _, balance := range previous.Balances {
+		previousBalances[balanceKey(balance)] = balance
+	}
+	currentBalances := map[string]hProtocol.Balance{}
+	for _, balance := range current.Balances {
+		currentBalances[balanceKey(balance)] = balance
+	}
+	for _, key := range sortedKeys(previousBalances, currentBalances) {
+		p, inPrevious := previousBalances[key]
+		c, inCurrent := currentBalances[key]
+		if inPrevious && inCurrent && balancesEqual(p, c) {
+			continue
+		}
+		event := BalanceChangedEvent{AccountID: accountID, Asset: key}
+		if inPrevious {
+			event.Previous = &p
+		}
+		if inCurrent {
+			event.Current = &c
+		}
+		events = append(events, event)
+	}
+
+	previousSigners := map[string]hProtocol.Signer{}
+	for _, signer := range previous.Signers {
+		previousSigners[signer.Key] = signer
+	}
+	currentSigners := map[string]hProtocol.Signer{}
+	for _, signer := range current.Signers {
+		currentSigners[signer.Key] = signer
+	}
+	for _, key := range sortedKeys(previousSigners, currentSigners) {
+		p, inPrevious := previousSigners[key]
+		c, inCurrent := currentSigners[key]
+		if inPrevious && inCurrent && p == c {
+			continue
+		}
+		event := SignerChangedEvent{AccountID: accountID, Key: key}
+		if inPrevious {
+			event.Previous = &p
+		}
+		if inCurrent {
+			event.Current = &c
+		}
+		events = append(events, event)
+	}
+
+	for _, key := range sortedKeys(previous.Data, current.Data) {
+		p, inPrevious := previous.Data[key]
+		c, inCurrent := current.Data[key]
+		if inPrevious && inCurrent && p == c {
+			continue
+		}
+		event := DataChangedEvent{AccountID: accountID, Key: key}
+		if inPrevious {
+			event.Previous = &p
+		}
+		if inCurrent {
+			event.Current = &c
+		}
+		events = append(events, event)
+	}
+
+	if previous.Thresholds != current.Thresholds {
+		events = append(events, ThresholdsChangedEvent{
+			AccountID: accountID,
+			Previous:  previous.Thresholds,
+			Current:   current.Thresholds,
+		})
+	}
+
+	if previous.Flags != current.Flags {
+		events = append(events, FlagsChangedEvent{
+			AccountID: accountID,
+			Previous:  previous.Flags,
+			Current:   current.Flags,
+		})
+	}
+
+	return events
+}
+
+// balanceKey returns the key identifying a balance in BalanceChangedEvent.
+func balanceKey(balance hProtocol.Balance) string {
+	if balance.LiquidityPoolId != "" {
+		return balance.LiquidityPoolId
+	}
+	if balance.Type == string(AssetTypeNative) {
+		return string(AssetTypeNative)
+	}
+	return balance.Code + ":" + balance.Issuer
+}
+
+// balancesEqual compares two balances, ignoring the ledger they were last
+// modified in.
+func balancesEqual(a, b hProtocol.Balance) bool {
+	boolEqual := func(x, y *bool) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return *x == *y
+	}
+	return a.Balance == b.Balance &&
+		a.Limit == b.Limit &&
+		a.BuyingLiabilities == b.BuyingLiabilities &&
+		a.SellingLiabilities == b.SellingLiabilities &&
+		a.Sponsor == b.Sponsor &&
+		boolEqual(a.IsAuthorized, b.IsAuthorized) &&
+		boolEqual(a.IsAuthorizedToMaintainLiabilities, b.IsAuthorizedToMaintainLiabilities) &&
+		boolEqual(a.IsClawbackEnabled, b.IsClawbackEnabled)
+}
+
+// sortedKeys returns the sorted union of the keys of the given maps, which
+// must be of type map[string]T.
+func sortedKeys(maps ...interface{}) []string {
+	set := map[string]struct{}{}
+	for _, m := range maps {
+		switch m := m.(type) {
+		case map[string]hProtocol.Balance:
+			for key := range m {
+				set[key] = struct{}{}
+			}
+		case map[string]hProtocol.Signer:
+			for key := range m {
+				set[key] = struct{}{}
+			}
+		case map[string]string:
+			for key := range m {
+				set[key] = struct{}{}
+			}
+		default:
+			panic("Unknown map type")
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
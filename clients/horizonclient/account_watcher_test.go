@@ -0,0 +1,187 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const watchedAccountID = "GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR"
+
+func watchedAccountStates() (hProtocol.Account, hProtocol.Account) {
+	authorized := true
+	usd := hProtocol.Balance{
+		Balance:      "10.0000000",
+		Limit:        "100.0000000",
+		IsAuthorized: &authorized,
+		Asset: base.Asset{
+			Type:   "credit_alphanum4",
+			Code:   "USD",
+			Issuer: "GBM4HXXNDBWWQBXOL4QCTZIUQAP6XFUI3FPINUGUPBMULMTEHJPIKX6T",
+		},
+	}
+	previous := hProtocol.Account{
+		AccountID: watchedAccountID,
+		Balances: []hProtocol.Balance{
+			{Balance: "100.0000000", Asset: base.Asset{Type: "native"}},
+			usd,
+		},
+		Signers: []hProtocol.Signer{
+			{Key: watchedAccountID, Weight: 1, Type: "ed25519_public_key"},
+		},
+		Data:       map[string]string{"unchanged": "MQ==", "removed": "Mg=="},
+		Thresholds: hProtocol.AccountThresholds{LowThreshold: 1},
+	}
+
+	usdRemovedAuthorization := usd
+	notAuthorized := false
+	usdRemovedAuthorization.IsAuthorized = &notAuthorized
+	usdRemovedAuthorization.LastModifiedLedger = 2
+	current := hProtocol.Account{
+		AccountID: watchedAccountID,
+		Balances: []hProtocol.Balance{
+			{Balance: "99.0000000", Asset: base.Asset{Type: "native"}},
+			usdRemovedAuthorization,
+		},
+		Signers: []hProtocol.Signer{
+			{Key: watchedAccountID, Weight: 1, Type: "ed25519_public_key"},
+			{Key: "GBM4HXXNDBWWQBXOL4QCTZIUQAP6XFUI3FPINUGUPBMULMTEHJPIKX6T", Weight: 2, Type: "ed25519_public_key"},
+		},
+		Data:       map[string]string{"unchanged": "MQ=="},
+		Thresholds: hProtocol.AccountThresholds{LowThreshold: 2},
+	}
+	return previous, current
+}
+
+func TestDiffAccounts(t *testing.T) {
+	previous, current := watchedAccountStates()
+
+	assert.Empty(t, DiffAccounts(previous, previous))
+
+	events := DiffAccounts(previous, current)
+	require.Len(t, events, 5)
+
+	native := events[1].(BalanceChangedEvent)
+	assert.Equal(t, "native", native.Asset)
+	assert.Equal(t, "100.0000000", native.Previous.Balance)
+	assert.Equal(t, "99.0000000", native.Current.Balance)
+
+	usd := events[0].(BalanceChangedEvent)
+	assert.Equal(t, "USD:GBM4HXXNDBWWQBXOL4QCTZIUQAP6XFUI3FPINUGUPBMULMTEHJPIKX6T", usd.Asset)
+	assert.True(t, *usd.Previous.IsAuthorized)
+	assert.False(t, *usd.Current.IsAuthorized)
+
+	signer := events[2].(SignerChangedEvent)
+	assert.Equal(t, "GBM4HXXNDBWWQBXOL4QCTZIUQAP6XFUI3FPINUGUPBMULMTEHJPIKX6T", signer.Key)
+	assert.Nil(t, signer.Previous)
+	assert.Equal(t, int32(2), signer.Current.Weight)
+
+	data := events[3].(DataChangedEvent)
+	assert.Equal(t, "removed", data.Key)
+	assert.Equal(t, "Mg==", *data.Previous)
+	assert.Nil(t, data.Current)
+
+	thresholds := events[4].(ThresholdsChangedEvent)
+	assert.Equal(t, byte(1), thresholds.Previous.LowThreshold)
+	assert.Equal(t, byte(2), thresholds.Current.LowThreshold)
+
+	for _, event := range events {
+		assert.Equal(t, watchedAccountID, event.GetAccountID())
+	}
+}
+
+func TestWatchAccount(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	previous, current := watchedAccountStates()
+	hmock.On("GET", "https://localhost/").
+		ReturnJSON(200, hProtocol.Root{HorizonSequence: 100})
+
+	fetches := 0
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/"+watchedAccountID,
+	).Return(func(*http.Request) (*http.Response, error) {
+		fetches++
+		if fetches == 1 {
+			return httpmock.NewJsonResponse(200, previous)
+		}
+		return httpmock.NewJsonResponse(200, current)
+	})
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/"+watchedAccountID+"/transactions?cursor=429496729600",
+	).ReturnString(200, txStreamResponse)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var events []AccountEvent
+	err := client.WatchAccount(ctx, watchedAccountID, func(event AccountEvent) {
+		events = append(events, event)
+		if len(events) == 5 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DiffAccounts(previous, current), events)
+}
+
+func TestWatchAccountFetchError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	previous, _ := watchedAccountStates()
+	hmock.On("GET", "https://localhost/").
+		ReturnJSON(200, hProtocol.Root{HorizonSequence: 100})
+
+	fetches := 0
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/"+watchedAccountID,
+	).Return(func(*http.Request) (*http.Response, error) {
+		fetches++
+		if fetches == 1 {
+			return httpmock.NewJsonResponse(200, previous)
+		}
+		return httpmock.NewStringResponse(404, notFoundResponse), nil
+	})
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/"+watchedAccountID+"/transactions?cursor=429496729600",
+	).ReturnString(200, txStreamResponse)
+
+	err := client.WatchAccount(context.Background(), watchedAccountID, func(event AccountEvent) {
+		assert.Fail(t, "unexpected event")
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "error fetching account")
+		assert.True(t, IsNotFoundError(err))
+	}
+}
+
+func TestWatchAccountRootError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/").ReturnString(500, "")
+
+	err := client.WatchAccount(context.Background(), watchedAccountID, func(event AccountEvent) {
+		assert.Fail(t, "unexpected event")
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "error fetching root")
+	}
+}
@@ -0,0 +1,207 @@
+package horizonclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	// DefaultAwaitPollInterval is the delay between two polls of
+	// AwaitTransaction.
+	DefaultAwaitPollInterval = 2 * time.Second
+	// DefaultAwaitMaxPolls is the number of polls of AwaitTransaction, enough
+	// to cover the time bounds of a transaction built with a 60 seconds
+	// timeout.
+	DefaultAwaitMaxPolls = 30
+	// DefaultAwaitStreamTimeout is the time AwaitTransaction streams the
+	// transactions for when its context has no deadline, the same as the
+	// polls cover.
+	DefaultAwaitStreamTimeout = DefaultAwaitMaxPolls * DefaultAwaitPollInterval
+)
+
+// ErrAwaitTimeout is returned by AwaitTransaction when the transaction is
+// still not found after the last poll, or at the end of the stream timeout.
+var ErrAwaitTimeout = errors.New("transaction not found before the end of polling")
+
+// AwaitOptions are the options of AwaitTransactionWithOptions.
+type AwaitOptions struct {
+	// SourceAccount is the source account of the transaction. If it is set
+	// only the transactions of the account are streamed, instead of all the
+	// transactions of the network.
+	SourceAccount string
+	// PollInterval is the delay between two polls once streaming failed,
+	// DefaultAwaitPollInterval if 0.
+	PollInterval time.Duration
+	// MaxPolls is the number of polls once streaming failed,
+	// DefaultAwaitMaxPolls if 0.
+	MaxPolls int
+	// StreamTimeout is the time the transactions are streamed for when the
+	// context has no deadline, DefaultAwaitStreamTimeout if 0.
+	StreamTimeout time.Duration
+}
+
+// TransactionFailedError is returned by AwaitTransaction when the transaction
+// was included in a ledger but failed.
+type TransactionFailedError struct {
+	Transaction hProtocol.Transaction
+	Result      xdr.TransactionResult
+}
+
+// Error returns the result code of the transaction.
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed: %s", e.Transaction.Hash, e.Result.Result.Code)
+}
+
+// AwaitTransaction waits for the transaction with the given hash, submitted
+// asynchronously or by someone else, to be included in a ledger, see
+// AwaitTransactionWithOptions.
+func (c *Client) AwaitTransaction(ctx context.Context, hash string) (hProtocol.Transaction, error) {
+	return c.AwaitTransactionWithOptions(ctx, hash, AwaitOptions{})
+}
+
+// AwaitTransactionWithOptions waits for the transaction with the given hash
+// to be included in a ledger and returns it. If the transaction failed a
+// *TransactionFailedError with its result is returned along with it.
+//
+// Horizon cannot stream a single transaction so, unless the transaction is
+// found right away, the transactions of the network, or of opts.SourceAccount,
+// are streamed from the latest ledger until the transaction is found. If
+// streaming fails, e.g. behind a proxy not supporting server-sent events, the
+// transaction is polled instead, and ErrAwaitTimeout is returned after
+// opts.MaxPolls polls. Not found, rate limited and server errors do not stop
+// the polling. The hash of a fee bump transaction or of its inner transaction
+// can be awaited.
+//
+// Use context.WithTimeout to stop waiting before the transaction expires. If
+// ctx has no deadline ErrAwaitTimeout is returned once the transactions were
+// streamed for opts.StreamTimeout.
+func (c *Client) AwaitTransactionWithOptions(ctx context.Context, hash string, opts AwaitOptions) (hProtocol.Transaction, error) {
+	if hash == "" {
+		return hProtocol.Transaction{}, errors.New("no transaction hash provided")
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = DefaultAwaitPollInterval
+	}
+	if opts.MaxPolls == 0 {
+		opts.MaxPolls = DefaultAwaitMaxPolls
+	}
+	if opts.StreamTimeout == 0 {
+		opts.StreamTimeout = DefaultAwaitStreamTimeout
+	}
+
+	// The cursor of the stream is taken before looking the transaction up so
+	// that a transaction ingested in between is streamed.
+	cursor := ""
+	if root, err := c.Root(); err == nil {
+		cursor = toid.New(root.HorizonSequence, 0, 0).String()
+	}
+
+	tx, found, err := c.lookupTransaction(ctx, hash)
+	if err != nil && !retryAwaitError(err) {
+		return tx, err
+	}
+	if found {
+		return awaitedTransaction(tx)
+	}
+
+	if cursor != "" {
+		streamCtx := ctx
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			streamCtx, cancel = context.WithTimeout(ctx, opts.StreamTimeout)
+			defer cancel()
+		}
+		if tx, found = c.streamTransaction(streamCtx, hash, cursor, opts.SourceAccount); found {
+			return awaitedTransaction(tx)
+		}
+		if ctx.Err() != nil {
+			return tx, ctx.Err()
+		}
+		if streamCtx.Err() != nil {
+			return tx, ErrAwaitTimeout
+		}
+	}
+
+	for i := 0; i < opts.MaxPolls; i++ {
+		select {
+		case <-ctx.Done():
+			return tx, ctx.Err()
+		case <-time.After(opts.PollInterval):
+		}
+
+		tx, found, err = c.lookupTransaction(ctx, hash)
+		if err != nil && !retryAwaitError(err) {
+			return tx, err
+		}
+		if found {
+			return awaitedTransaction(tx)
+		}
+	}
+	return tx, ErrAwaitTimeout
+}
+
+// lookupTransaction fetches the transaction, returning false if it is not
+// found.
+func (c *Client) lookupTransaction(ctx context.Context, hash string) (hProtocol.Transaction, bool, error) {
+	var tx hProtocol.Transaction
+	if err := c.sendRequestWithContext(ctx, TransactionRequest{forTransactionHash: hash}, &tx); err != nil {
+		if IsNotFoundError(err) {
+			return tx, false, nil
+		}
+		return tx, false, errors.Wrap(err, "error fetching transaction")
+	}
+	return tx, true, nil
+}
+
+// streamTransaction streams the transactions from the cursor until the one
+// with the given hash is found. It returns false if the stream fails or the
+// context is cancelled first.
+func (c *Client) streamTransaction(ctx context.Context, hash, cursor, sourceAccount string) (hProtocol.Transaction, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var awaited hProtocol.Transaction
+	found := false
+	request := TransactionRequest{ForAccount: sourceAccount, Cursor: cursor, IncludeFailed: true}
+	// The stream only returns when it fails or is cancelled, the error is
+	// not needed to tell between both.
+	_ = c.StreamTransactions(ctx, request, func(tx hProtocol.Transaction) {
+		if tx.Hash == hash || (tx.InnerTransaction != nil && tx.InnerTransaction.Hash == hash) {
+			awaited = tx
+			found = true
+			cancel()
+		}
+	})
+	return awaited, found
+}
+
+// retryAwaitError returns true if the error may be transient: the polling
+// carries on after rate limited, server and network errors.
+func retryAwaitError(err error) bool {
+	hErr := GetError(err)
+	if hErr == nil {
+		return true
+	}
+	status := hErr.Problem.Status
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// awaitedTransaction returns the transaction, with a TransactionFailedError if
+// it failed.
+func awaitedTransaction(tx hProtocol.Transaction) (hProtocol.Transaction, error) {
+	if tx.Successful {
+		return tx, nil
+	}
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(tx.ResultXdr, &result); err != nil {
+		return tx, errors.Wrap(err, "error decoding transaction result")
+	}
+	return tx, &TransactionFailedError{Transaction: tx, Result: result}
+}
@@ -0,0 +1,159 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/xdr"
+)
+
+const awaitedHash = "1534f6507420c6871b557cc2fc800c29fb1ed1e012e694993ffe7a39c824056e"
+
+func mockAwaitRoot(hmock *httptest.Client) {
+	hmock.On("GET", "https://localhost/").
+		ReturnJSON(200, hProtocol.Root{HorizonSequence: 100})
+}
+
+func TestAwaitTransactionFound(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	mockAwaitRoot(hmock)
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		ReturnJSON(200, hProtocol.Transaction{Hash: awaitedHash, Successful: true})
+
+	tx, err := client.AwaitTransaction(context.Background(), awaitedHash)
+	require.NoError(t, err)
+	assert.Equal(t, awaitedHash, tx.Hash)
+
+	_, err = client.AwaitTransaction(context.Background(), "")
+	assert.EqualError(t, err, "no transaction hash provided")
+}
+
+func TestAwaitTransactionStream(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	mockAwaitRoot(hmock)
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		ReturnString(404, notFoundResponse)
+	// the stream starts at the latest ledger and includes failed transactions
+	hmock.On("GET", "https://localhost/accounts/GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR/transactions?cursor=429496729600&include_failed=true").
+		ReturnString(200, txStreamResponse)
+
+	tx, err := client.AwaitTransactionWithOptions(context.Background(), awaitedHash, AwaitOptions{
+		SourceAccount: "GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, awaitedHash, tx.Hash)
+	assert.Equal(t, int32(607387), tx.Ledger)
+}
+
+func TestAwaitTransactionStreamInnerHash(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	mockAwaitRoot(hmock)
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		ReturnString(404, notFoundResponse)
+	// the awaited transaction was fee bumped
+	hmock.On("GET", "https://localhost/transactions?cursor=429496729600&include_failed=true").
+		Return(streamResponder(
+			"id: 1\ndata: {\"hash\":\"a1b2\",\"paging_token\":\"1\",\"successful\":true}\n\n"+
+				"id: 2\ndata: {\"hash\":\"c3d4\",\"paging_token\":\"2\",\"successful\":true,"+
+				"\"inner_transaction\":{\"hash\":\""+awaitedHash+"\"}}\n\n",
+			false,
+		))
+
+	tx, err := client.AwaitTransaction(context.Background(), awaitedHash)
+	require.NoError(t, err)
+	assert.Equal(t, "c3d4", tx.Hash)
+	assert.Equal(t, awaitedHash, tx.InnerTransaction.Hash)
+}
+
+func TestAwaitTransactionStreamTimeout(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	mockAwaitRoot(hmock)
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		ReturnString(404, notFoundResponse)
+	// the stream never returns the awaited transaction
+	hmock.On("GET", "https://localhost/transactions?cursor=429496729600&include_failed=true").
+		Return(streamResponder("id: 1\ndata: {\"hash\":\"a1b2\",\"paging_token\":\"1\"}\n\n", false))
+
+	// without a deadline the stream is bounded by the stream timeout
+	_, err := client.AwaitTransactionWithOptions(context.Background(), awaitedHash, AwaitOptions{
+		StreamTimeout: 10 * time.Millisecond,
+	})
+	assert.Equal(t, ErrAwaitTimeout, err)
+
+	// with a deadline the stream timeout is ignored
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.AwaitTransactionWithOptions(ctx, awaitedHash, AwaitOptions{
+		StreamTimeout: time.Millisecond,
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestAwaitTransactionPollingFallback(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	mockAwaitRoot(hmock)
+	hmock.On("GET", "https://localhost/transactions?cursor=429496729600&include_failed=true").
+		ReturnString(500, "")
+
+	result, err := xdr.MarshalBase64(xdr.TransactionResult{
+		FeeCharged: 100,
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxFailed,
+			Results: &[]xdr.OperationResult{},
+		},
+	})
+	require.NoError(t, err)
+	fetches := 0
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		Return(func(*http.Request) (*http.Response, error) {
+			fetches++
+			switch fetches {
+			case 1:
+				return httpmock.NewStringResponse(404, notFoundResponse), nil
+			case 2:
+				return httpmock.NewStringResponse(503, `{"type":"https://stellar.org/horizon-errors/timeout","status":503}`), nil
+			}
+			return httpmock.NewJsonResponse(200, hProtocol.Transaction{Hash: awaitedHash, ResultXdr: result})
+		})
+
+	tx, err := client.AwaitTransactionWithOptions(context.Background(), awaitedHash, AwaitOptions{PollInterval: time.Millisecond})
+	assert.Equal(t, awaitedHash, tx.Hash)
+	assert.Equal(t, 3, fetches)
+	var failed *TransactionFailedError
+	require.ErrorAs(t, err, &failed)
+	assert.Equal(t, xdr.TransactionResultCodeTxFailed, failed.Result.Result.Code)
+	assert.EqualError(t, err, "transaction "+awaitedHash+" failed: TransactionResultCodeTxFailed")
+}
+
+func TestAwaitTransactionTimeout(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	hmock.On("GET", "https://localhost/").ReturnString(500, "")
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		ReturnString(404, notFoundResponse)
+
+	_, err := client.AwaitTransactionWithOptions(context.Background(), awaitedHash, AwaitOptions{
+		PollInterval: time.Millisecond,
+		MaxPolls:     2,
+	})
+	assert.Equal(t, ErrAwaitTimeout, err)
+
+	hmock.On("GET", "https://localhost/transactions/"+awaitedHash).
+		ReturnString(400, `{"type":"https://stellar.org/horizon-errors/bad_request","status":400}`)
+	_, err = client.AwaitTransaction(context.Background(), awaitedHash)
+	require.Error(t, err)
+	assert.Equal(t, 400, GetError(err).Problem.Status)
+}
@@ -0,0 +1,145 @@
+package horizonclient
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stellar/go/amount"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+)
+
+// PathFindingRequest is a path finding request of FindBestPaths, a
+// PathsRequest (strict receive) or a StrictSendPathsRequest (strict send).
+type PathFindingRequest interface {
+	HorizonRequest
+	pathFindingRequest()
+}
+
+func (PathsRequest) pathFindingRequest()           {}
+func (StrictSendPathsRequest) pathFindingRequest() {}
+
+// FindBestPaths sends the path finding requests concurrently and merges
+// their paths, e.g. to list the assets an account can receive for a given
+// amount, one strict send request per destination asset.
+//
+// The paths are deduplicated: when several requests find the same path, the
+// same source and destination assets through the same intermediate assets,
+// the one with the best rate (destination amount per source amount) is kept.
+// The paths returned are sorted by destination asset, then source asset,
+// then best rate first.
+//
+// The first error, if any, is returned and the requests still running are
+// cancelled, so are they when ctx is done.
+func (c *Client) FindBestPaths(ctx context.Context, requests ...PathFindingRequest) ([]hProtocol.Path, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]hProtocol.PathsPage, len(requests))
+	var once sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request PathFindingRequest) {
+			defer wg.Done()
+			if err := c.sendRequestWithContext(ctx, request, &pages[i]); err != nil {
+				once.Do(func() {
+					firstErr = errors.Wrapf(err, "error finding paths of request %d", i)
+					cancel()
+				})
+			}
+		}(i, request)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	best := map[string]hProtocol.Path{}
+	var keys []string
+	for _, page := range pages {
+		for _, path := range page.Embedded.Records {
+			key := pathKey(path)
+			kept, ok := best[key]
+			if !ok {
+				keys = append(keys, key)
+			} else if compareRates(path, kept) <= 0 {
+				continue
+			}
+			best[key] = path
+		}
+	}
+
+	paths := make([]hProtocol.Path, 0, len(keys))
+	for _, key := range keys {
+		paths = append(paths, best[key])
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		if a, b := destinationAssetKey(paths[i]), destinationAssetKey(paths[j]); a != b {
+			return a < b
+		}
+		if a, b := sourceAssetKey(paths[i]), sourceAssetKey(paths[j]); a != b {
+			return a < b
+		}
+		return compareRates(paths[i], paths[j]) > 0
+	})
+	return paths, nil
+}
+
+func assetKey(assetType, code, issuer string) string {
+	if assetType == "native" {
+		return "native"
+	}
+	return code + ":" + issuer
+}
+
+func sourceAssetKey(path hProtocol.Path) string {
+	return assetKey(path.SourceAssetType, path.SourceAssetCode, path.SourceAssetIssuer)
+}
+
+func destinationAssetKey(path hProtocol.Path) string {
+	return assetKey(path.DestinationAssetType, path.DestinationAssetCode, path.DestinationAssetIssuer)
+}
+
+// pathKey identifies the assets of a path, regardless of its amounts.
+func pathKey(path hProtocol.Path) string {
+	keys := []string{sourceAssetKey(path)}
+	for _, asset := range path.Path {
+		keys = append(keys, assetKey(asset.Type, asset.Code, asset.Issuer))
+	}
+	keys = append(keys, destinationAssetKey(path))
+	return strings.Join(keys, "/")
+}
+
+// compareRates returns 1 if the rate of a is better than the rate of b, -1
+// if it is worse and 0 if they are equal. The rate of a path whose amounts
+// cannot be parsed is the worst.
+func compareRates(a, b hProtocol.Path) int {
+	rateA, okA := pathRate(a)
+	rateB, okB := pathRate(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	return rateA.Cmp(rateB)
+}
+
+func pathRate(path hProtocol.Path) (*big.Rat, bool) {
+	source, err := amount.ParseInt64(path.SourceAmount)
+	if err != nil || source <= 0 {
+		return nil, false
+	}
+	destination, err := amount.ParseInt64(path.DestinationAmount)
+	if err != nil {
+		return nil, false
+	}
+	return big.NewRat(destination, source), true
+}
@@ -0,0 +1,116 @@
+package horizonclient
+
+import (
+	"context"
+	"testing"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bestPathsIssuer = "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN"
+
+func testPath(sourceAmount, destinationCode, destinationAmount string, hops ...string) hProtocol.Path {
+	path := hProtocol.Path{
+		SourceAssetType:   "credit_alphanum4",
+		SourceAssetCode:   "USD",
+		SourceAssetIssuer: bestPathsIssuer,
+		SourceAmount:      sourceAmount,
+		DestinationAmount: destinationAmount,
+		Path:              []hProtocol.Asset{},
+	}
+	if destinationCode == "" {
+		path.DestinationAssetType = "native"
+	} else {
+		path.DestinationAssetType = "credit_alphanum4"
+		path.DestinationAssetCode = destinationCode
+		path.DestinationAssetIssuer = bestPathsIssuer
+	}
+	for _, hop := range hops {
+		path.Path = append(path.Path, hProtocol.Asset{Type: "credit_alphanum4", Code: hop, Issuer: bestPathsIssuer})
+	}
+	return path
+}
+
+func mockPaths(t *testing.T, hmock *httptest.Client, request PathFindingRequest, paths ...hProtocol.Path) {
+	endpoint, err := request.BuildURL()
+	require.NoError(t, err)
+	page := hProtocol.PathsPage{}
+	page.Embedded.Records = paths
+	hmock.On("GET", "https://localhost/"+endpoint).ReturnJSON(200, page)
+}
+
+func TestFindBestPaths(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	toEUR := StrictSendPathsRequest{
+		SourceAssetType:   AssetType4,
+		SourceAssetCode:   "USD",
+		SourceAssetIssuer: bestPathsIssuer,
+		SourceAmount:      "10",
+		DestinationAssets: "EUR:" + bestPathsIssuer,
+	}
+	toNative := toEUR
+	toNative.DestinationAssets = "native"
+	receiveEUR := PathsRequest{
+		DestinationAssetType:   AssetType4,
+		DestinationAssetCode:   "EUR",
+		DestinationAssetIssuer: bestPathsIssuer,
+		DestinationAmount:      "9",
+		SourceAssets:           "USD:" + bestPathsIssuer,
+	}
+
+	mockPaths(t, hmock, toEUR,
+		testPath("10.0000000", "EUR", "8.0000000", "BTC"),
+		testPath("10.0000000", "EUR", "9.0000000"),
+	)
+	mockPaths(t, hmock, toNative, testPath("10.0000000", "", "50.0000000"))
+	// the direct path is found by both requests, this rate is better
+	mockPaths(t, hmock, receiveEUR,
+		testPath("9.5000000", "EUR", "9.0000000"),
+		testPath("9.0000000", "EUR", "9.0000000", "ETH"),
+	)
+
+	paths, err := client.FindBestPaths(context.Background(), toEUR, toNative, receiveEUR)
+	require.NoError(t, err)
+	assert.Equal(t, []hProtocol.Path{
+		testPath("9.0000000", "EUR", "9.0000000", "ETH"),
+		testPath("9.5000000", "EUR", "9.0000000"),
+		testPath("10.0000000", "EUR", "8.0000000", "BTC"),
+		testPath("10.0000000", "", "50.0000000"),
+	}, paths)
+
+	paths, err = client.FindBestPaths(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestFindBestPathsError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	request := StrictSendPathsRequest{SourceAssetType: AssetTypeNative, SourceAmount: "10", DestinationAssets: "native"}
+	mockPaths(t, hmock, request, testPath("10.0000000", "", "10.0000000"))
+	invalid := StrictSendPathsRequest{SourceAssetType: AssetTypeNative, SourceAmount: "-10", DestinationAssets: "native"}
+	endpoint, err := invalid.BuildURL()
+	require.NoError(t, err)
+	hmock.On("GET", "https://localhost/"+endpoint).ReturnString(400, badRequestResponse)
+
+	_, err = client.FindBestPaths(context.Background(), request, invalid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error finding paths of request 1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.FindBestPaths(ctx, request)
+	assert.Error(t, err)
+}
@@ -0,0 +1,283 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// ErrCircuitOpen is the cause of the errors returned, without sending the
+// request, when the circuit of the endpoint class of a request is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit of an endpoint class.
+type CircuitState string
+
+const (
+	// CircuitClosed lets the requests through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects the requests until CircuitBreaker.OpenDuration
+	// elapses.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets one probe request through at a time, closing the
+	// circuit after enough successful probes and opening it again after a
+	// failed one.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreaker stops sending requests to the endpoints of a degraded
+// Horizon, e.g. when a slow database makes all the requests for the
+// operations of accounts time out, instead of tying up the workers sending
+// them. Requests are grouped by endpoint class, so that the failures of an
+// endpoint do not stop the requests to the others.
+//
+// The circuit of a class opens when, within Window, at least MinRequests
+// requests were sent and the ratio of failed requests reaches FailureRatio.
+// Requests fail when they cannot be sent or Horizon responds with a 5xx
+// status code; 4xx responses, including 429, are successes. A CircuitBreaker
+// is safe for concurrent use and can be shared by many clients (see
+// Client.CircuitBreaker).
+type CircuitBreaker struct {
+	// FailureRatio is the ratio of failed requests opening the circuit, 0.5
+	// if 0.
+	FailureRatio float64
+	// MinRequests is the minimum number of requests within Window for the
+	// circuit to open, 10 if 0.
+	MinRequests int
+	// Window is the period over which the failures are counted, 1 minute if
+	// 0.
+	Window time.Duration
+	// OpenDuration is how long the circuit stays open before probe requests
+	// are let through, 30 seconds if 0.
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of consecutive successful probes closing
+	// the circuit, 1 if 0.
+	HalfOpenProbes int
+	// Classify returns the endpoint class of a request from its method and
+	// its path relative to the Horizon URL, DefaultEndpointClass if nil.
+	Classify func(method, path string) string
+
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+
+	// now replaces time.Now in tests
+	now func() time.Time
+}
+
+// circuit is the state of the circuit of an endpoint class.
+type circuit struct {
+	state CircuitState
+	// windowStart is the start of the window the requests and failures are
+	// counted in
+	windowStart time.Time
+	requests    int
+	failures    int
+	// openedAt is the time the circuit last opened
+	openedAt time.Time
+	// probing is true while a probe request is in flight, and successes is
+	// the number of successful probes
+	probing   bool
+	successes int
+}
+
+// DefaultEndpointClass returns the first segment of the path, e.g. "accounts"
+// for "accounts/G.../payments", "root" for the root endpoint, and "submit"
+// for transaction submissions.
+func DefaultEndpointClass(method, path string) string {
+	segment := strings.SplitN(strings.Trim(path, "/"), "/", 2)[0]
+	if segment == "" {
+		return "root"
+	}
+	if method == http.MethodPost && segment == "transactions" {
+		return "submit"
+	}
+	return segment
+}
+
+func (b *CircuitBreaker) timeNow() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+func (b *CircuitBreaker) circuit(class string) *circuit {
+	if b.circuits == nil {
+		b.circuits = map[string]*circuit{}
+	}
+	c, ok := b.circuits[class]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[class] = c
+	}
+	return c
+}
+
+// State returns the state of the circuit of the endpoint class.
+func (b *CircuitBreaker) State(class string) CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.circuit(class)
+	if c.state == CircuitOpen && b.timeNow().Sub(c.openedAt) >= b.openDuration() {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Allow returns an error whose cause is ErrCircuitOpen if a request of the
+// endpoint class must not be sent. Otherwise the outcome of the request must
+// be reported with Record, or the request released with Release if it has
+// no outcome.
+func (b *CircuitBreaker) Allow(class string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.circuit(class)
+	switch c.state {
+	case CircuitOpen:
+		if b.timeNow().Sub(c.openedAt) < b.openDuration() {
+			return errors.Wrapf(ErrCircuitOpen, "endpoint class %s", class)
+		}
+		c.state = CircuitHalfOpen
+		c.successes = 0
+		fallthrough
+	case CircuitHalfOpen:
+		if c.probing {
+			return errors.Wrapf(ErrCircuitOpen, "endpoint class %s", class)
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+// Record reports the outcome of a request of the endpoint class allowed by
+// Allow.
+func (b *CircuitBreaker) Record(class string, success bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.timeNow()
+	c := b.circuit(class)
+	switch c.state {
+	case CircuitHalfOpen:
+		c.probing = false
+		if !success {
+			b.open(c, now)
+			return
+		}
+		c.successes++
+		if c.successes >= b.halfOpenProbes() {
+			c.state = CircuitClosed
+			c.windowStart = now
+			c.requests, c.failures = 0, 0
+		}
+	case CircuitClosed:
+		if now.Sub(c.windowStart) >= b.window() {
+			c.windowStart = now
+			c.requests, c.failures = 0, 0
+		}
+		c.requests++
+		if !success {
+			c.failures++
+		}
+		if c.requests >= b.minRequests() && float64(c.failures) >= b.failureRatio()*float64(c.requests) {
+			b.open(c, now)
+		}
+	}
+}
+
+// Release reports that a request of the endpoint class allowed by Allow has
+// no outcome, e.g. it was canceled by its caller: it is neither a success
+// nor a failure, and another probe can be let through if the circuit is
+// half-open.
+func (b *CircuitBreaker) Release(class string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.circuit(class)
+	if c.state == CircuitHalfOpen {
+		c.probing = false
+	}
+}
+
+func (b *CircuitBreaker) open(c *circuit, now time.Time) {
+	c.state = CircuitOpen
+	c.openedAt = now
+	c.probing = false
+}
+
+func (b *CircuitBreaker) failureRatio() float64 {
+	if b.FailureRatio <= 0 {
+		return 0.5
+	}
+	return b.FailureRatio
+}
+
+func (b *CircuitBreaker) minRequests() int {
+	if b.MinRequests <= 0 {
+		return 10
+	}
+	return b.MinRequests
+}
+
+func (b *CircuitBreaker) window() time.Duration {
+	if b.Window <= 0 {
+		return time.Minute
+	}
+	return b.Window
+}
+
+func (b *CircuitBreaker) openDuration() time.Duration {
+	if b.OpenDuration <= 0 {
+		return 30 * time.Second
+	}
+	return b.OpenDuration
+}
+
+func (b *CircuitBreaker) halfOpenProbes() int {
+	if b.HalfOpenProbes <= 0 {
+		return 1
+	}
+	return b.HalfOpenProbes
+}
+
+func (b *CircuitBreaker) classify(method, path string) string {
+	if b.Classify != nil {
+		return b.Classify(method, path)
+	}
+	return DefaultEndpointClass(method, path)
+}
+
+// allowCircuitBreaker returns the endpoint class of the request and an error
+// if the CircuitBreaker of the client, if any, does not allow it.
+func (c *Client) allowCircuitBreaker(req *http.Request) (string, error) {
+	if c.CircuitBreaker == nil {
+		return "", nil
+	}
+	path := req.URL.Path
+	if base, err := url.Parse(c.HorizonURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	class := c.CircuitBreaker.classify(req.Method, path)
+	return class, c.CircuitBreaker.Allow(class)
+}
+
+// recordCircuitBreaker reports the outcome of a request allowed by
+// allowCircuitBreaker. Requests canceled by their caller are released
+// instead, they are neither successes nor failures.
+func (c *Client) recordCircuitBreaker(ctx context.Context, class string, resp *http.Response, err error) {
+	if c.CircuitBreaker == nil {
+		return
+	}
+	if err != nil && ctx.Err() == context.Canceled {
+		c.CircuitBreaker.Release(class)
+		return
+	}
+	c.CircuitBreaker.Record(class, err == nil && resp.StatusCode < 500)
+}
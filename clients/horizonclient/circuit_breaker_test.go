@@ -0,0 +1,138 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCircuitBreaker returns a CircuitBreaker whose clock is moved with
+// the returned function.
+func newTestCircuitBreaker(breaker *CircuitBreaker) (*CircuitBreaker, func(time.Duration)) {
+	now := time.Unix(1600000000, 0)
+	breaker.now = func() time.Time { return now }
+	return breaker, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker, advance := newTestCircuitBreaker(&CircuitBreaker{
+		MinRequests:    4,
+		OpenDuration:   10 * time.Second,
+		HalfOpenProbes: 2,
+	})
+
+	// 2 failures out of 3 requests are not enough requests
+	for _, success := range []bool{true, false, false} {
+		require.NoError(t, breaker.Allow("accounts"))
+		breaker.Record("accounts", success)
+	}
+	assert.Equal(t, CircuitClosed, breaker.State("accounts"))
+	require.NoError(t, breaker.Allow("accounts"))
+	breaker.Record("accounts", false)
+	assert.Equal(t, CircuitOpen, breaker.State("accounts"))
+
+	err := breaker.Allow("accounts")
+	assert.Equal(t, ErrCircuitOpen, errors.Cause(err))
+	assert.EqualError(t, err, "endpoint class accounts: circuit breaker is open")
+	// the other classes are not affected
+	assert.NoError(t, breaker.Allow("ledgers"))
+	breaker.Record("ledgers", true)
+
+	// one probe at a time is let through once the circuit is half-open, and
+	// a failed probe opens it again
+	advance(10 * time.Second)
+	assert.Equal(t, CircuitHalfOpen, breaker.State("accounts"))
+	require.NoError(t, breaker.Allow("accounts"))
+	assert.Equal(t, ErrCircuitOpen, errors.Cause(breaker.Allow("accounts")))
+	breaker.Record("accounts", false)
+	assert.Equal(t, CircuitOpen, breaker.State("accounts"))
+
+	// a released probe is neither a success nor a failure
+	advance(10 * time.Second)
+	require.NoError(t, breaker.Allow("accounts"))
+	breaker.Release("accounts")
+	assert.Equal(t, CircuitHalfOpen, breaker.State("accounts"))
+
+	// two successful probes close it
+	for i := 0; i < 2; i++ {
+		require.NoError(t, breaker.Allow("accounts"))
+		breaker.Record("accounts", true)
+	}
+	assert.Equal(t, CircuitClosed, breaker.State("accounts"))
+
+	// the failures of previous windows are forgotten
+	for i := 0; i < 3; i++ {
+		require.NoError(t, breaker.Allow("accounts"))
+		breaker.Record("accounts", false)
+	}
+	advance(time.Minute)
+	require.NoError(t, breaker.Allow("accounts"))
+	breaker.Record("accounts", false)
+	assert.Equal(t, CircuitClosed, breaker.State("accounts"))
+}
+
+func TestDefaultEndpointClass(t *testing.T) {
+	assert.Equal(t, "root", DefaultEndpointClass(http.MethodGet, "/"))
+	assert.Equal(t, "accounts", DefaultEndpointClass(http.MethodGet, "/accounts/GABC/payments"))
+	assert.Equal(t, "transactions", DefaultEndpointClass(http.MethodGet, "/transactions"))
+	assert.Equal(t, "submit", DefaultEndpointClass(http.MethodPost, "/transactions"))
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	hmock := httptest.NewClient()
+	breaker, advance := newTestCircuitBreaker(&CircuitBreaker{MinRequests: 4, FailureRatio: 0.75})
+	client := &Client{
+		HorizonURL:     "https://localhost/horizon/",
+		HTTP:           hmock,
+		CircuitBreaker: breaker,
+	}
+
+	var paths []string
+	breaker.Classify = func(method, path string) string {
+		paths = append(paths, path)
+		return DefaultEndpointClass(method, path)
+	}
+
+	hmock.On("GET", "https://localhost/horizon/ledgers/1").ReturnString(500, "{}")
+	_, err := client.LedgerDetail(1)
+	assert.Error(t, err)
+	// 404 responses are not failures
+	hmock.On("GET", "https://localhost/horizon/ledgers/2").ReturnString(404, notFoundResponse)
+	_, err = client.LedgerDetail(2)
+	assert.Error(t, err)
+	hmock.On("GET", "https://localhost/horizon/ledgers/3").ReturnString(503, "{}")
+	_, err = client.LedgerDetail(3)
+	assert.Error(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State("ledgers"))
+	hmock.On("GET", "https://localhost/horizon/ledgers/4").ReturnString(503, "{}")
+	_, err = client.LedgerDetail(4)
+	assert.Error(t, err)
+	assert.Equal(t, CircuitOpen, breaker.State("ledgers"))
+	assert.Equal(t, []string{"/ledgers/1", "/ledgers/2", "/ledgers/3", "/ledgers/4"}, paths)
+
+	// the request is not sent
+	_, err = client.LedgerDetail(5)
+	assert.Equal(t, ErrCircuitOpen, errors.Cause(err))
+
+	// a probe canceled by its caller does not close the circuit
+	advance(30 * time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	hmock.On("GET", "https://localhost/horizon/ledgers/6").Return(func(*http.Request) (*http.Response, error) {
+		cancel()
+		return nil, context.Canceled
+	})
+	err = client.sendRequestWithContext(ctx, LedgerRequest{forSequence: 6}, &hProtocol.Ledger{})
+	assert.Error(t, err)
+	assert.Equal(t, CircuitHalfOpen, breaker.State("ledgers"))
+	hmock.On("GET", "https://localhost/horizon/ledgers/7").ReturnString(503, "{}")
+	_, err = client.LedgerDetail(7)
+	assert.Error(t, err)
+	assert.Equal(t, CircuitOpen, breaker.State("ledgers"))
+}
@@ -1,8 +1,6 @@
 package horizonclient
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,8 +14,6 @@ import (
 	"github.com/stellar/go/txnbuild"
 	"github.com/stellar/go/xdr"
 
-	"github.com/manucorporat/sse"
-
 	hProtocol "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/protocols/horizon/effects"
 	"github.com/stellar/go/protocols/horizon/operations"
@@ -34,6 +30,16 @@ func (c *Client) sendRequest(hr HorizonRequest, resp interface{}) (err error) {
 	return c.sendHTTPRequest(req, resp)
 }
 
+// sendRequestWithContext is like sendRequest but cancels the request once ctx
+// is done.
+func (c *Client) sendRequestWithContext(ctx context.Context, hr HorizonRequest, resp interface{}) error {
+	req, err := hr.HTTPRequest(c.fixHorizonURL())
+	if err != nil {
+		return err
+	}
+	return c.sendHTTPRequest(req.WithContext(ctx), resp)
+}
+
 // checkMemoRequired implements a memo required check as defined in
 // https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0029.md
 func (c *Client) checkMemoRequired(transaction *txnbuild.Transaction) error {
@@ -111,22 +117,57 @@ func (c *Client) sendGetRequest(requestURL string, a interface{}) error {
 
 func (c *Client) sendHTTPRequest(req *http.Request, a interface{}) error {
 	c.setClientAppHeaders(req)
+	if c.ResponseFormat != nil {
+		req.Header.Set("Accept", c.ResponseFormat.MediaType())
+	}
 	c.setDefaultClient()
 
-	if c.horizonTimeout == 0 {
-		c.horizonTimeout = HorizonTimeout
+	for retry := 1; ; retry++ {
+		if err := c.signRequest(req); err != nil {
+			return err
+		}
+		resp, err := c.sendHTTPRequestOnce(req, a)
+		delay, ok := c.RetryPolicy.delay(req.Context(), req, retry, resp, err)
+		if !ok {
+			return err
+		}
+		if c.RetryPolicy.wait(req.Context(), req, retry, delay, err) != nil {
+			return err
+		}
+		if rewindErr := rewindBody(req); rewindErr != nil {
+			return err
+		}
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), c.horizonTimeout)
+}
+
+// sendHTTPRequestOnce sends the request and decodes its response, returning
+// the response, whose body is closed, if any, for the RetryPolicy to decide
+// whether to retry.
+func (c *Client) sendHTTPRequestOnce(req *http.Request, a interface{}) (*http.Response, error) {
+	// the context of the request, if any, can cancel it before the timeout
+	ctx, cancel := context.WithTimeout(req.Context(), c.HorizonTimeout())
 	defer cancel()
 
-	if resp, err := c.HTTP.Do(req.WithContext(ctx)); err != nil {
-		return err
-	} else {
-		return decodeResponse(resp, &a, c)
+	if err := c.waitRateLimiter(ctx); err != nil {
+		return nil, err
+	}
+	class, err := c.allowCircuitBreaker(req)
+	if err != nil {
+		return nil, err
 	}
+	resp, err := c.HTTP.Do(req.WithContext(ctx))
+	c.recordCircuitBreaker(ctx, class, resp, err)
+	if err != nil {
+		return nil, err
+	}
+	c.observeRateLimiter(resp)
+	return resp, decodeResponse(resp, &a, c)
 }
 
-// stream handles connections to endpoints that support streaming on a horizon server
+// stream handles connections to endpoints that support streaming on a horizon server.
+// Once connected, it reconnects from the cursor of the last record received
+// as configured by the StreamReconnect of the client, unless an event cannot
+// be decoded, its error being returned.
 func (c *Client) stream(
 	ctx context.Context,
 	streamURL string,
@@ -142,6 +183,9 @@ func (c *Client) stream(
 		query.Set("cursor", "now")
 	}
 
+	reconnect := c.streamReconnect()
+	connected := false
+	failures := 0
 	for {
 		// updates the url with new cursor
 		su.RawQuery = query.Encode()
@@ -149,108 +193,121 @@ func (c *Client) stream(
 		if err != nil {
 			return errors.Wrap(err, "error creating HTTP request")
 		}
-		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set("Accept", c.responseFormat().StreamMediaType())
 		c.setDefaultClient()
 		c.setClientAppHeaders(req)
 
-		// We can use c.HTTP here because we set Timeout per request not on the client. See sendRequest()
-		resp, err := c.HTTP.Do(req)
-		if err != nil {
-			return errors.Wrap(err, "error sending HTTP request")
-		}
-
-		// Expected statusCode are 200-299
-		if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-			return fmt.Errorf("got bad HTTP status code %d", resp.StatusCode)
-		}
-		defer resp.Body.Close()
-
-		reader := bufio.NewReader(resp.Body)
-
-		// Read events one by one. Break this loop when there is no more data to be
-		// read from resp.Body (io.EOF).
-	Events:
-		for {
-			// Read until empty line = event delimiter. The perfect solution would be to read
-			// as many bytes as possible and forward them to sse.Decode. However this
-			// requires much more complicated code.
-			// We could also write our own `sse` package that works fine with streams directly
-			// (github.com/manucorporat/sse is just using io/ioutils.ReadAll).
-			var buffer bytes.Buffer
-			nonEmptylinesRead := 0
-			for {
-				// Check if ctx is not cancelled
-				select {
-				case <-ctx.Done():
-					return nil
-				default:
-					// Continue
-				}
-
-				line, err := reader.ReadString('\n')
-				if err != nil {
-					if err == io.EOF || err == io.ErrUnexpectedEOF {
-						// We catch EOF errors to handle two possible situations:
-						// - The last line before closing the stream was not empty. This should never
-						//   happen in Horizon as it always sends an empty line after each event.
-						// - The stream was closed by the server/proxy because the connection was idle.
-						//
-						// In the former case, that (again) should never happen in Horizon, we need to
-						// check if there are any events we need to decode. We do this in the `if`
-						// statement below just in case if Horizon behaviour changes in a future.
-						//
-						// From spec:
-						// > Once the end of the file is reached, the user agent must dispatch the
-						// > event one final time, as defined below.
-						if nonEmptylinesRead == 0 {
-							break Events
-						}
-					} else {
-						return errors.Wrap(err, "error reading line")
-					}
+		resp, err := c.connectStreamWithRetries(ctx, req)
+		readErr := false
+		if err == nil {
+			connected = true
+			received, handlerFailed := false, false
+			body := &streamBody{reader: resp.Body}
+			err = c.responseFormat().ReadStream(ctx, body, func(record []byte, cursor string) error {
+				received = true
+				// Update cursor with the cursor of the record
+				if cursor != "" {
+					query.Set("cursor", cursor)
 				}
-				buffer.WriteString(line)
-
-				if strings.TrimRight(line, "\n\r") == "" {
-					break
+				if handlerErr := handler(record); handlerErr != nil {
+					handlerFailed = true
+					return errors.Wrap(handlerErr, "handler error")
 				}
-
-				nonEmptylinesRead++
+				return nil
+			})
+			resp.Body.Close()
+			if received {
+				failures = 0
+			}
+			// only the errors reading the body, e.g. a dropped connection, are
+			// reconnected, the events which cannot be decoded are not
+			if err != nil && (handlerFailed || ctx.Err() != nil || body.err == nil) {
+				return err
 			}
+			readErr = err != nil
+		}
+		if err != nil && !connected {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
 
-			events, err := sse.Decode(strings.NewReader(buffer.String()))
-			if err != nil {
-				return errors.Wrap(err, "error decoding event")
+		// the stream was ended by Horizon, or its connection dropped
+		event := StreamReconnectEvent{Cursor: query.Get("cursor"), Err: err}
+		if err != nil {
+			failures++
+			delay, ok := reconnect.reconnect(failures, resp, err, readErr)
+			if !ok {
+				return err
 			}
+			event.Failures, event.Delay = failures, delay
+		}
+		su.RawQuery = query.Encode()
+		event.URL = su.String()
+		if reconnect.wait(ctx, event) != nil {
+			return nil
+		}
+	}
+}
 
-			// Right now len(events) should always be 1. This loop will be helpful after writing
-			// new SSE decoder that can handle io.Reader without using ioutils.ReadAll().
-			for _, event := range events {
-				if event.Event != "message" {
-					continue
-				}
+// streamBody records the error reading the body of a stream, telling the
+// transport errors from the errors decoding its events.
+type streamBody struct {
+	reader io.Reader
+	err    error
+}
 
-				// Update cursor with event ID
-				if event.Id != "" {
-					query.Set("cursor", event.Id)
-				}
+func (b *streamBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
 
-				switch data := event.Data.(type) {
-				case string:
-					err = handler([]byte(data))
-					err = errors.Wrap(err, "handler error")
-				case []byte:
-					err = handler(data)
-					err = errors.Wrap(err, "handler error")
-				default:
-					err = errors.New("invalid event.Data type")
-				}
-				if err != nil {
-					return err
-				}
-			}
+// connectStreamWithRetries connects to a stream, retrying as configured by
+// the RetryPolicy of the client.
+func (c *Client) connectStreamWithRetries(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for retry := 1; ; retry++ {
+		if err := c.signRequest(req); err != nil {
+			return nil, err
 		}
+		resp, err := c.connectStream(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		delay, ok := c.RetryPolicy.delay(ctx, req, retry, resp, err)
+		if !ok || c.RetryPolicy.wait(ctx, req, retry, delay, err) != nil {
+			return resp, err
+		}
+	}
+}
+
+// connectStream sends the request of a stream, returning the response, whose
+// body is closed, and an error if its status code is not 2xx.
+func (c *Client) connectStream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.waitRateLimiter(ctx); err != nil {
+		return nil, err
+	}
+	class, err := c.allowCircuitBreaker(req)
+	if err != nil {
+		return nil, err
 	}
+	// We can use c.HTTP here because we set Timeout per request not on the client. See sendRequest()
+	resp, err := c.HTTP.Do(req)
+	c.recordCircuitBreaker(ctx, class, resp, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending HTTP request")
+	}
+	c.observeRateLimiter(resp)
+
+	// Expected statusCode are 200-299
+	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		resp.Body.Close()
+		return resp, fmt.Errorf("got bad HTTP status code %d", resp.StatusCode)
+	}
+	return resp, nil
 }
 
 func (c *Client) setClientAppHeaders(req *http.Request) {
@@ -260,6 +317,15 @@ func (c *Client) setClientAppHeaders(req *http.Request) {
 	req.Header.Set("X-App-Version", c.AppVersion)
 }
 
+// responseFormat returns the format of the responses, HALFormat if none is
+// provided.
+func (c *Client) responseFormat() ResponseFormat {
+	if c.ResponseFormat == nil {
+		return HALFormat{}
+	}
+	return c.ResponseFormat
+}
+
 // setDefaultClient sets the default HTTP client when none is provided.
 func (c *Client) setDefaultClient() {
 	if c.HTTP == nil {
@@ -284,6 +350,9 @@ func (c *Client) SetHorizonTimeout(t time.Duration) *Client {
 
 // HorizonTimeout returns the current timeout for a horizon client
 func (c *Client) HorizonTimeout() time.Duration {
+	if c.horizonTimeout == 0 {
+		return HorizonTimeout
+	}
 	return c.horizonTimeout
 }
 
@@ -325,6 +394,136 @@ func (c *Client) AccountData(request AccountRequest) (accountData hProtocol.Acco
 	return
 }
 
+// LoadTrustlines returns the credit assets the account has a trustline for,
+// implementing txnbuild.TrustlineLoader.
+func (c *Client) LoadTrustlines(accountID string) ([]txnbuild.CreditAsset, error) {
+	account, err := c.AccountDetail(AccountRequest{AccountID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []txnbuild.CreditAsset
+	for _, balance := range account.Balances {
+		if balance.Type == string(AssetType4) || balance.Type == string(AssetType12) {
+			assets = append(assets, txnbuild.CreditAsset{Code: balance.Code, Issuer: balance.Issuer})
+		}
+	}
+	return assets, nil
+}
+
+// LoadTrustlineFlags returns the flags of the trustline of the account for
+// the asset, and false if there is none, implementing
+// txnbuild.TrustlineFlagsLoader.
+func (c *Client) LoadTrustlineFlags(accountID string, asset txnbuild.CreditAsset) ([]txnbuild.TrustLineFlag, bool, error) {
+	account, err := c.AccountDetail(AccountRequest{AccountID: accountID})
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, balance := range account.Balances {
+		if balance.Code != asset.Code || balance.Issuer != asset.Issuer || balance.LiquidityPoolId != "" {
+			continue
+		}
+		var flags []txnbuild.TrustLineFlag
+		if balance.IsAuthorized != nil && *balance.IsAuthorized {
+			flags = append(flags, txnbuild.TrustLineAuthorized)
+		}
+		if balance.IsAuthorizedToMaintainLiabilities != nil && *balance.IsAuthorizedToMaintainLiabilities {
+			flags = append(flags, txnbuild.TrustLineAuthorizedToMaintainLiabilities)
+		}
+		if balance.IsClawbackEnabled != nil && *balance.IsClawbackEnabled {
+			flags = append(flags, txnbuild.TrustLineClawbackEnabled)
+		}
+		return flags, true, nil
+	}
+	return nil, false, nil
+}
+
+// LoadSubAccounts returns the accounts sponsored by parent whose
+// txnbuild.SubAccountParentDataKey data entry is parent, implementing
+// txnbuild.SubAccountLoader.
+func (c *Client) LoadSubAccounts(parent string) ([]string, error) {
+	page, err := c.Accounts(AccountsRequest{Sponsor: parent, Limit: 200})
+	var subAccounts []string
+	for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextAccountsPage(page) {
+		for _, account := range page.Embedded.Records {
+			value, decodeErr := account.GetData(txnbuild.SubAccountParentDataKey)
+			if decodeErr != nil {
+				return nil, errors.Wrapf(decodeErr, "invalid %s data entry of %s", txnbuild.SubAccountParentDataKey, account.AccountID)
+			}
+			if string(value) == parent {
+				subAccounts = append(subAccounts, account.AccountID)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return subAccounts, nil
+}
+
+// LoadWindDownState returns the trustlines, offers, data entries and signers
+// of the account, implementing txnbuild.WindDownLoader.
+func (c *Client) LoadWindDownState(accountID string) (txnbuild.WindDownState, error) {
+	account, err := c.AccountDetail(AccountRequest{AccountID: accountID})
+	if err != nil {
+		return txnbuild.WindDownState{}, err
+	}
+	sequence, err := account.GetSequenceNumber()
+	if err != nil {
+		return txnbuild.WindDownState{}, err
+	}
+
+	state := txnbuild.WindDownState{
+		AccountID:     accountID,
+		Sequence:      sequence,
+		NumSponsoring: account.NumSponsoring,
+	}
+	for _, balance := range account.Balances {
+		switch {
+		case balance.LiquidityPoolId != "":
+			state.LiquidityPools = append(state.LiquidityPools, balance.LiquidityPoolId)
+		case balance.Type != string(AssetTypeNative):
+			state.Trustlines = append(state.Trustlines, txnbuild.WindDownTrustline{
+				Asset:      txnbuild.CreditAsset{Code: balance.Code, Issuer: balance.Issuer},
+				Balance:    balance.Balance,
+				Authorized: balance.IsAuthorized == nil || *balance.IsAuthorized,
+			})
+		}
+	}
+	for name := range account.Data {
+		state.DataNames = append(state.DataNames, name)
+	}
+	for _, signer := range account.Signers {
+		if signer.Key != accountID {
+			state.Signers = append(state.Signers, signer.Key)
+		}
+	}
+
+	page, err := c.Offers(OfferRequest{ForAccount: accountID, Limit: 200})
+	for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextOffersPage(page) {
+		for _, offer := range page.Embedded.Records {
+			state.Offers = append(state.Offers, txnbuild.WindDownOffer{
+				ID:      offer.ID,
+				Selling: windDownAsset(offer.Selling),
+				Buying:  windDownAsset(offer.Buying),
+				Price:   xdr.Price{N: xdr.Int32(offer.PriceR.N), D: xdr.Int32(offer.PriceR.D)},
+			})
+		}
+	}
+	if err != nil {
+		return txnbuild.WindDownState{}, err
+	}
+	return state, nil
+}
+
+func windDownAsset(asset hProtocol.Asset) txnbuild.Asset {
+	if asset.Type == string(AssetTypeNative) {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: asset.Code, Issuer: asset.Issuer}
+}
+
 // Effects returns effects (https://developers.stellar.org/api/resources/effects/)
 // It can be used to return effects for an account, a ledger, an operation, a transaction and all effects on the network.
 func (c *Client) Effects(request EffectRequest) (effects effects.EffectsPage, err error) {
@@ -475,7 +674,8 @@ func (c *Client) SubmitFeeBumpTransactionWithOptions(transaction *txnbuild.FeeBu
 		return
 	}
 
-	return c.SubmitTransactionXDR(txeBase64)
+	inner := transaction.InnerTransaction()
+	return c.submitAnnotatedTransactionXDR(txeBase64, transaction.Annotations(), inner.AllOperationAnnotations())
 }
 
 // SubmitTransaction submits a transaction to the network. err can be either an
@@ -511,7 +711,31 @@ func (c *Client) SubmitTransactionWithOptions(transaction *txnbuild.Transaction,
 		return
 	}
 
-	return c.SubmitTransactionXDR(txeBase64)
+	return c.submitAnnotatedTransactionXDR(txeBase64, transaction.Annotations(), transaction.AllOperationAnnotations())
+}
+
+// submitAnnotatedTransactionXDR submits a transaction and reports the
+// submission, along with the annotations of the transaction, to the
+// SubmitHook of the client.
+func (c *Client) submitAnnotatedTransactionXDR(
+	transactionXdr string,
+	annotations txnbuild.Annotations,
+	operationAnnotations map[int]txnbuild.Annotations,
+) (tx hProtocol.Transaction, err error) {
+	if c.SubmitHook == nil {
+		return c.SubmitTransactionXDR(transactionXdr)
+	}
+
+	start := c.clock.Now()
+	tx, err = c.SubmitTransactionXDR(transactionXdr)
+	c.SubmitHook(SubmissionEvent{
+		Annotations:          annotations,
+		OperationAnnotations: operationAnnotations,
+		Duration:             c.clock.Now().Sub(start),
+		Response:             tx,
+		Err:                  err,
+	})
+	return
 }
 
 // Transactions returns stellar transactions (https://developers.stellar.org/api/resources/transactions/list/)
@@ -0,0 +1,373 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+)
+
+// TransactionExportColumns are the columns of the records exported for a
+// TransactionRequest. They are the JSON fields of the transactions of the same
+// name.
+var TransactionExportColumns = []string{
+	"id",
+	"paging_token",
+	"successful",
+	"hash",
+	"ledger",
+	"created_at",
+	"source_account",
+	"source_account_sequence",
+	"fee_account",
+	"fee_charged",
+	"max_fee",
+	"operation_count",
+	"memo_type",
+	"memo",
+}
+
+// OperationExportColumns are the columns of the records exported for an
+// OperationRequest. "details" contains the full JSON of the operation, whose
+// fields depend on its type.
+var OperationExportColumns = []string{
+	"id",
+	"paging_token",
+	"transaction_hash",
+	"transaction_successful",
+	"created_at",
+	"type",
+	"source_account",
+	"details",
+}
+
+// PaymentExportColumns are the columns of the records exported for an
+// OperationRequest of the payments endpoint (see
+// OperationRequest.SetPaymentsEndpoint). The payments are flattened as follows:
+//   - payment and path payments: amount, asset and from/to as in Horizon
+//   - create_account: "from" is the funder, "to" the created account and
+//     "amount" the starting balance of native asset
+//   - account_merge: "from" is the merged account and "to" the account it was
+//     merged into, the amount is not known
+var PaymentExportColumns = []string{
+	"id",
+	"paging_token",
+	"transaction_hash",
+	"transaction_successful",
+	"created_at",
+	"type",
+	"source_account",
+	"from",
+	"to",
+	"amount",
+	"asset_type",
+	"asset_code",
+	"asset_issuer",
+}
+
+// TradeExportColumns are the columns of the records exported for a
+// TradeRequest. They are the JSON fields of the trades of the same name,
+// "price_n" and "price_d" being the numerator and denominator of the price.
+var TradeExportColumns = []string{
+	"id",
+	"paging_token",
+	"ledger_close_time",
+	"trade_type",
+	"base_offer_id",
+	"base_account",
+	"base_liquidity_pool_id",
+	"base_amount",
+	"base_asset_type",
+	"base_asset_code",
+	"base_asset_issuer",
+	"counter_offer_id",
+	"counter_account",
+	"counter_liquidity_pool_id",
+	"counter_amount",
+	"counter_asset_type",
+	"counter_asset_code",
+	"counter_asset_issuer",
+	"base_is_seller",
+	"price_n",
+	"price_d",
+}
+
+// ExportFormat is the format of the records written by an export.
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes the records as CSV, starting with a header row.
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSONL writes the records as JSON lines: one object per
+	// record, whose keys are the export columns.
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// ExportCSV fetches all the records of a history endpoint, page after page,
+// and writes them to w as CSV, starting with a header row. The request must
+// be a TransactionRequest, an OperationRequest (of the operations or payments
+// endpoint) or a TradeRequest, whose records are flattened to
+// TransactionExportColumns, OperationExportColumns, PaymentExportColumns or
+// TradeExportColumns respectively.
+//
+// The export starts at the cursor of the request and stops once a page is
+// empty or ctx is cancelled, in which case ctx.Err() is returned.
+func (c *Client) ExportCSV(ctx context.Context, request HorizonRequest, w io.Writer) error {
+	return c.exportTo(ctx, request, ExportFormatCSV, w)
+}
+
+// ExportJSONL is like ExportCSV but writes the records as JSON lines: one
+// object per record, whose keys are the export columns.
+func (c *Client) ExportJSONL(ctx context.Context, request HorizonRequest, w io.Writer) error {
+	return c.exportTo(ctx, request, ExportFormatJSONL, w)
+}
+
+func (c *Client) exportTo(ctx context.Context, request HorizonRequest, format ExportFormat, w io.Writer) error {
+	columns, err := exportColumns(request)
+	if err != nil {
+		return err
+	}
+	write, flush, err := newExportWriter(format, columns, w)
+	if err != nil {
+		return err
+	}
+	err = c.export(ctx, request, write)
+	if flushErr := flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// newExportWriter returns the function writing the records to w in the given
+// format, and the function to call once all the records are written.
+func newExportWriter(format ExportFormat, columns []string, w io.Writer) (func([]string) error, func() error, error) {
+	switch format {
+	case ExportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return nil, nil, errors.Wrap(err, "error writing csv")
+		}
+		flush := func() error {
+			writer.Flush()
+			return errors.Wrap(writer.Error(), "error writing csv")
+		}
+		return writer.Write, flush, nil
+	case ExportFormatJSONL:
+		encoder := json.NewEncoder(w)
+		write := func(record []string) error {
+			object := make(map[string]string, len(columns))
+			for i, column := range columns {
+				object[column] = record[i]
+			}
+			return errors.Wrap(encoder.Encode(object), "error writing json")
+		}
+		return write, func() error { return nil }, nil
+	default:
+		return nil, nil, errors.Errorf("unknown export format %q", format)
+	}
+}
+
+func exportColumns(request HorizonRequest) ([]string, error) {
+	switch request := request.(type) {
+	case TransactionRequest, *TransactionRequest:
+		return TransactionExportColumns, nil
+	case OperationRequest:
+		if request.endpoint == "payments" {
+			return PaymentExportColumns, nil
+		}
+		return OperationExportColumns, nil
+	case *OperationRequest:
+		return exportColumns(*request)
+	case TradeRequest, *TradeRequest:
+		return TradeExportColumns, nil
+	default:
+		return nil, errors.Errorf("%T cannot be exported", request)
+	}
+}
+
+// export fetches all the pages of the request and calls write with every
+// flattened record.
+func (c *Client) export(ctx context.Context, request HorizonRequest, write func([]string) error) error {
+	switch request := request.(type) {
+	case *TransactionRequest:
+		return c.export(ctx, *request, write)
+	case *OperationRequest:
+		return c.export(ctx, *request, write)
+	case *TradeRequest:
+		return c.export(ctx, *request, write)
+
+	case TransactionRequest:
+		page, err := c.Transactions(request)
+		for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextTransactionsPage(page) {
+			for _, tx := range page.Embedded.Records {
+				if err = write(transactionExportRecord(tx)); err != nil {
+					return err
+				}
+			}
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(err, "error fetching transactions")
+
+	case OperationRequest:
+		payments := request.endpoint == "payments"
+		var page operations.OperationsPage
+		var err error
+		if payments {
+			page, err = c.Payments(request)
+		} else {
+			page, err = c.Operations(request)
+		}
+		for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextOperationsPage(page) {
+			for _, op := range page.Embedded.Records {
+				var record []string
+				if payments {
+					record, err = paymentExportRecord(op)
+				} else {
+					record, err = operationExportRecord(op)
+				}
+				if err != nil {
+					return err
+				}
+				if err = write(record); err != nil {
+					return err
+				}
+			}
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(err, "error fetching operations")
+
+	case TradeRequest:
+		page, err := c.Trades(request)
+		for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextTradesPage(page) {
+			for _, trade := range page.Embedded.Records {
+				if err = write(tradeExportRecord(trade)); err != nil {
+					return err
+				}
+			}
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(err, "error fetching trades")
+
+	default:
+		return errors.Errorf("%T cannot be exported", request)
+	}
+}
+
+func transactionExportRecord(tx hProtocol.Transaction) []string {
+	return []string{
+		tx.ID,
+		tx.PT,
+		strconv.FormatBool(tx.Successful),
+		tx.Hash,
+		strconv.FormatInt(int64(tx.Ledger), 10),
+		exportTime(tx.LedgerCloseTime),
+		tx.Account,
+		tx.AccountSequence,
+		tx.FeeAccount,
+		strconv.FormatInt(tx.FeeCharged, 10),
+		strconv.FormatInt(tx.MaxFee, 10),
+		strconv.FormatInt(int64(tx.OperationCount), 10),
+		tx.MemoType,
+		tx.Memo,
+	}
+}
+
+// exportOperationBase returns the columns shared by operations and payments.
+func exportOperationBase(op operations.Operation) ([]string, error) {
+	withBase, ok := op.(interface{ GetBase() operations.Base })
+	if !ok {
+		return nil, errors.Errorf("unexpected operation type %T", op)
+	}
+	base := withBase.GetBase()
+	return []string{
+		base.ID,
+		base.PT,
+		base.TransactionHash,
+		strconv.FormatBool(base.TransactionSuccessful),
+		exportTime(base.LedgerCloseTime),
+		base.Type,
+		base.SourceAccount,
+	}, nil
+}
+
+func operationExportRecord(op operations.Operation) ([]string, error) {
+	record, err := exportOperationBase(op)
+	if err != nil {
+		return nil, err
+	}
+	details, err := json.Marshal(op)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling operation")
+	}
+	return append(record, string(details)), nil
+}
+
+func paymentExportRecord(op operations.Operation) ([]string, error) {
+	record, err := exportOperationBase(op)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to, amount string
+	var asset base.Asset
+	switch op := op.(type) {
+	case operations.Payment:
+		from, to, amount, asset = op.From, op.To, op.Amount, op.Asset
+	case operations.PathPayment:
+		from, to, amount, asset = op.From, op.To, op.Amount, op.Asset
+	case operations.PathPaymentStrictSend:
+		from, to, amount, asset = op.From, op.To, op.Amount, op.Asset
+	case operations.CreateAccount:
+		from, to, amount = op.Funder, op.Account, op.StartingBalance
+		asset.Type = string(AssetTypeNative)
+	case operations.AccountMerge:
+		from, to = op.Account, op.Into
+		asset.Type = string(AssetTypeNative)
+	default:
+		return nil, errors.Errorf("unexpected %s operation in payments", op.GetType())
+	}
+	return append(record, from, to, amount, asset.Type, asset.Code, asset.Issuer), nil
+}
+
+func tradeExportRecord(trade hProtocol.Trade) []string {
+	return []string{
+		trade.ID,
+		trade.PT,
+		exportTime(trade.LedgerCloseTime),
+		trade.TradeType,
+		trade.BaseOfferID,
+		trade.BaseAccount,
+		trade.BaseLiquidityPoolID,
+		trade.BaseAmount,
+		trade.BaseAssetType,
+		trade.BaseAssetCode,
+		trade.BaseAssetIssuer,
+		trade.CounterOfferID,
+		trade.CounterAccount,
+		trade.CounterLiquidityPoolID,
+		trade.CounterAmount,
+		trade.CounterAssetType,
+		trade.CounterAssetCode,
+		trade.CounterAssetIssuer,
+		strconv.FormatBool(trade.BaseIsSeller),
+		strconv.FormatInt(trade.Price.N, 10),
+		strconv.FormatInt(trade.Price.D, 10),
+	}
+}
+
+func exportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,312 @@
+package horizonclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// ExportManifestFile is the name of the manifest written by ExportManifested
+// in its directory.
+const ExportManifestFile = "manifest.json"
+
+// ExportManifest records the progress of an export run by
+// ExportManifested: the ledger range it covers, how it is split into shards
+// and the shards whose file is complete.
+type ExportManifest struct {
+	From      uint32                `json:"from"`
+	To        uint32                `json:"to"`
+	ShardSize uint32                `json:"shard_size"`
+	Format    ExportFormat          `json:"format"`
+	Completed []ExportedLedgerShard `json:"completed"`
+}
+
+// ExportedLedgerShard is a shard whose records are all written to File,
+// relative to the directory of the manifest.
+type ExportedLedgerShard struct {
+	LedgerShard
+	File    string `json:"file"`
+	SHA256  string `json:"sha256"`
+	Records int    `json:"records"`
+}
+
+// ExportProgress is passed to ManifestExportOptions.Progress once a shard is
+// exported, or skipped because an earlier run exported it.
+type ExportProgress struct {
+	Shard   ExportedLedgerShard
+	Skipped bool
+	// Completed is the number of shards completed so far, out of Total.
+	Completed int
+	Total     int
+}
+
+// ManifestExportOptions configures ExportManifested.
+type ManifestExportOptions struct {
+	// Dir is the directory the shard files and the manifest are written to.
+	// It is created if it does not exist.
+	Dir string
+	// From and To are the ledgers, included, to export.
+	From uint32
+	To   uint32
+	// ShardSize is the number of ledgers of every file, see ShardLedgerRange.
+	ShardSize uint32
+	// Format is the format of the files, ExportFormatCSV if empty.
+	Format ExportFormat
+	// Progress, if set, is called after every shard.
+	Progress func(ExportProgress)
+}
+
+// ExportManifested exports the records of request in the ledgers From to To
+// like ExportCSV or ExportJSONL, but into one file per shard of ShardSize
+// ledgers, so that an export of a large part of the history can be
+// interrupted and resumed. The Cursor and Order of request are ignored.
+//
+// Once the file of a shard is complete, the shard and the checksum of its
+// file are recorded in the manifest of the directory. Running the export
+// again with the same options skips the shards of the manifest whose file is
+// unchanged and exports the others. It is an error to resume an export with a
+// different range, shard size or format.
+//
+// The export stops at the first error, or once ctx is cancelled, in which case
+// ctx.Err() is returned. The manifest, as completed so far, is returned in
+// both cases.
+func (c *Client) ExportManifested(ctx context.Context, request HorizonRequest, options ManifestExportOptions) (ExportManifest, error) {
+	format := options.Format
+	if format == "" {
+		format = ExportFormatCSV
+	}
+	columns, err := exportColumns(request)
+	if err != nil {
+		return ExportManifest{}, err
+	}
+	if _, _, err = newExportWriter(format, nil, ioutil.Discard); err != nil {
+		return ExportManifest{}, err
+	}
+	shards, err := ShardLedgerRange(options.From, options.To, options.ShardSize)
+	if err != nil {
+		return ExportManifest{}, err
+	}
+	if err = os.MkdirAll(options.Dir, 0755); err != nil {
+		return ExportManifest{}, errors.Wrap(err, "error creating export directory")
+	}
+
+	manifest := ExportManifest{
+		From:      options.From,
+		To:        options.To,
+		ShardSize: options.ShardSize,
+		Format:    format,
+	}
+	previous, err := readExportManifest(options.Dir)
+	if err != nil {
+		return ExportManifest{}, err
+	}
+	if previous != nil {
+		if previous.From != manifest.From || previous.To != manifest.To ||
+			previous.ShardSize != manifest.ShardSize || previous.Format != manifest.Format {
+			return ExportManifest{}, errors.Errorf(
+				"%s is the manifest of the %s export of ledgers %d-%d in shards of %d ledgers",
+				filepath.Join(options.Dir, ExportManifestFile),
+				previous.Format, previous.From, previous.To, previous.ShardSize,
+			)
+		}
+	}
+
+	for _, shard := range shards {
+		if err = ctx.Err(); err != nil {
+			return manifest, err
+		}
+
+		exported, ok := previous.completed(shard)
+		skipped := ok && exportFileMatches(options.Dir, exported)
+		if !skipped {
+			exported, err = c.exportShard(ctx, request, format, columns, options.Dir, shard)
+			if err != nil {
+				return manifest, err
+			}
+		}
+		manifest.Completed = append(manifest.Completed, exported)
+		if err = writeExportManifest(options.Dir, manifest); err != nil {
+			return manifest, err
+		}
+
+		if options.Progress != nil {
+			options.Progress(ExportProgress{
+				Shard:     exported,
+				Skipped:   skipped,
+				Completed: len(manifest.Completed),
+				Total:     len(shards),
+			})
+		}
+	}
+	return manifest, nil
+}
+
+// completed returns the shard of the manifest, if it is completed.
+func (m *ExportManifest) completed(shard LedgerShard) (ExportedLedgerShard, bool) {
+	if m == nil {
+		return ExportedLedgerShard{}, false
+	}
+	for _, exported := range m.Completed {
+		if exported.LedgerShard == shard {
+			return exported, true
+		}
+	}
+	return ExportedLedgerShard{}, false
+}
+
+// exportShard writes the records of the shard to a temporary file, renamed
+// once complete so that an interrupted shard never looks complete.
+func (c *Client) exportShard(
+	ctx context.Context,
+	request HorizonRequest,
+	format ExportFormat,
+	columns []string,
+	dir string,
+	shard LedgerShard,
+) (ExportedLedgerShard, error) {
+	cursor, end, err := shard.pagingTokens()
+	if err != nil {
+		return ExportedLedgerShard{}, err
+	}
+	request, err = exportRequestFrom(request, cursor)
+	if err != nil {
+		return ExportedLedgerShard{}, err
+	}
+
+	exported := ExportedLedgerShard{
+		LedgerShard: shard,
+		File:        fmt.Sprintf("%010d-%010d.%s", shard.From, shard.To, format),
+	}
+	path := filepath.Join(dir, exported.File)
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return ExportedLedgerShard{}, errors.Wrap(err, "error creating export file")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	checksum := sha256.New()
+	write, flush, err := newExportWriter(format, columns, io.MultiWriter(file, checksum))
+	if err != nil {
+		return ExportedLedgerShard{}, err
+	}
+	err = c.export(ctx, request, func(record []string) error {
+		// the paging token is the second column of all the records
+		done, err := afterShard(record[1], end)
+		if err != nil {
+			return err
+		}
+		if done {
+			return errShardExported
+		}
+		exported.Records++
+		return write(record)
+	})
+	if err != nil && err != errShardExported {
+		return ExportedLedgerShard{}, err
+	}
+	if err = flush(); err != nil {
+		return ExportedLedgerShard{}, err
+	}
+	if err = file.Sync(); err != nil {
+		return ExportedLedgerShard{}, errors.Wrap(err, "error writing export file")
+	}
+	if err = file.Close(); err != nil {
+		return ExportedLedgerShard{}, errors.Wrap(err, "error writing export file")
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return ExportedLedgerShard{}, errors.Wrap(err, "error renaming export file")
+	}
+	exported.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	return exported, nil
+}
+
+// errShardExported stops the export of a shard once its last record is
+// written.
+var errShardExported = errors.New("shard exported")
+
+// exportRequestFrom returns the request in ascending order from the cursor,
+// with the maximum of 200 records per page if no limit is set.
+func exportRequestFrom(request HorizonRequest, cursor string) (HorizonRequest, error) {
+	switch r := request.(type) {
+	case *TransactionRequest:
+		return exportRequestFrom(*r, cursor)
+	case *OperationRequest:
+		return exportRequestFrom(*r, cursor)
+	case *TradeRequest:
+		return exportRequestFrom(*r, cursor)
+	case TransactionRequest:
+		r.Cursor, r.Order = cursor, OrderAsc
+		if r.Limit == 0 {
+			r.Limit = 200
+		}
+		return r, nil
+	case OperationRequest:
+		r.Cursor, r.Order = cursor, OrderAsc
+		if r.Limit == 0 {
+			r.Limit = 200
+		}
+		return r, nil
+	case TradeRequest:
+		r.Cursor, r.Order = cursor, OrderAsc
+		if r.Limit == 0 {
+			r.Limit = 200
+		}
+		return r, nil
+	default:
+		return nil, errors.Errorf("%T cannot be exported", request)
+	}
+}
+
+// exportFileMatches returns true if the file of the shard still has the
+// checksum recorded in the manifest.
+func exportFileMatches(dir string, exported ExportedLedgerShard) bool {
+	file, err := os.Open(filepath.Join(dir, exported.File))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	checksum := sha256.New()
+	if _, err = io.Copy(checksum, file); err != nil {
+		return false
+	}
+	return hex.EncodeToString(checksum.Sum(nil)) == exported.SHA256
+}
+
+// readExportManifest returns the manifest of the directory, or nil if there
+// is none.
+func readExportManifest(dir string) (*ExportManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ExportManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading export manifest")
+	}
+	var manifest ExportManifest
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "error decoding export manifest")
+	}
+	return &manifest, nil
+}
+
+// writeExportManifest replaces the manifest of the directory atomically.
+func writeExportManifest(dir string, manifest ExportManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error encoding export manifest")
+	}
+	path := filepath.Join(dir, ExportManifestFile)
+	if err = ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return errors.Wrap(err, "error writing export manifest")
+	}
+	return errors.Wrap(os.Rename(path+".tmp", path), "error writing export manifest")
+}
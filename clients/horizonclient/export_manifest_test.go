@@ -0,0 +1,153 @@
+package horizonclient
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportManifested(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	dir := t.TempDir()
+	options := ManifestExportOptions{Dir: dir, From: 2, To: 4, ShardSize: 2}
+
+	// the shard of ledgers 2 and 3 stops at the first transaction of ledger 4
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=8589934592&limit=200&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=17179873280&limit=200&order=asc"}},
+  "_embedded": {"records": [
+    {"id": "b", "paging_token": "8589938688", "ledger": 2},
+    {"id": "c", "paging_token": "12884905984", "ledger": 3},
+    {"id": "d", "paging_token": "17179873280", "ledger": 4}
+  ]}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=17179869184&limit=200&order=asc",
+	).ReturnString(503, `{"status": 503}`)
+
+	manifest, err := client.ExportManifested(context.Background(), TransactionRequest{}, options)
+	require.Error(t, err)
+	require.Len(t, manifest.Completed, 1)
+	first := manifest.Completed[0]
+	assert.Equal(t, LedgerShard{From: 2, To: 3}, first.LedgerShard)
+	assert.Equal(t, "0000000002-0000000003.csv", first.File)
+	assert.Equal(t, 2, first.Records)
+	data, err := ioutil.ReadFile(filepath.Join(dir, first.File))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "\nb,8589938688,false,,2,")
+	assert.Contains(t, string(data), "\nc,12884905984,false,,3,")
+	assert.NotContains(t, string(data), "\nd,")
+	_, err = ioutil.ReadFile(filepath.Join(dir, "0000000004-0000000004.csv.tmp"))
+	assert.Error(t, err, "the file of the failed shard must be removed")
+
+	// resuming skips the completed shard
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=17179869184&limit=200&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=17179873280&limit=200&order=asc"}},
+  "_embedded": {"records": [{"id": "d", "paging_token": "17179873280", "ledger": 4}]}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=17179873280&limit=200&order=asc",
+	).ReturnString(200, exportEmptyPage)
+
+	var progress []ExportProgress
+	options.Progress = func(p ExportProgress) {
+		progress = append(progress, p)
+	}
+	manifest, err = client.ExportManifested(context.Background(), TransactionRequest{}, options)
+	require.NoError(t, err)
+	require.Len(t, manifest.Completed, 2)
+	assert.Equal(t, first, manifest.Completed[0])
+	second := manifest.Completed[1]
+	assert.Equal(t, 1, second.Records)
+	assert.Equal(t, []ExportProgress{
+		{Shard: first, Skipped: true, Completed: 1, Total: 2},
+		{Shard: second, Completed: 2, Total: 2},
+	}, progress)
+
+	onDisk, err := readExportManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, *onDisk)
+
+	// a modified file is exported again
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, first.File), []byte("truncated"), 0644))
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=8589934592&limit=200&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=17179873280&limit=200&order=asc"}},
+  "_embedded": {"records": [
+    {"id": "b", "paging_token": "8589938688", "ledger": 2},
+    {"id": "c", "paging_token": "12884905984", "ledger": 3},
+    {"id": "d", "paging_token": "17179873280", "ledger": 4}
+  ]}
+}`)
+	progress = nil
+	_, err = client.ExportManifested(context.Background(), TransactionRequest{}, options)
+	require.NoError(t, err)
+	assert.False(t, progress[0].Skipped)
+	assert.True(t, progress[1].Skipped)
+	assert.Equal(t, first.SHA256, progress[0].Shard.SHA256)
+
+	// the options of the export cannot change
+	options.Format = ExportFormatJSONL
+	_, err = client.ExportManifested(context.Background(), TransactionRequest{}, options)
+	assert.EqualError(t, err, filepath.Join(dir, ExportManifestFile)+" is the manifest of the csv export of ledgers 2-4 in shards of 2 ledgers")
+
+	_, err = client.ExportManifested(context.Background(), TransactionRequest{}, ManifestExportOptions{
+		Dir: t.TempDir(), From: 2, To: 4, ShardSize: 2, Format: "xml",
+	})
+	assert.EqualError(t, err, `unknown export format "xml"`)
+}
+
+func TestExportManifestedTrades(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	dir := t.TempDir()
+
+	// the paging tokens of trades are suffixed with their order
+	hmock.On(
+		"GET",
+		"https://localhost/trades?cursor=8589934592&limit=200&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/trades?cursor=12884905985-0&limit=200&order=asc"}},
+  "_embedded": {"records": [
+    {"id": "8589938689-0", "paging_token": "8589938689-0"},
+    {"id": "8589938689-1", "paging_token": "8589938689-1"},
+    {"id": "12884905985-0", "paging_token": "12884905985-0"}
+  ]}
+}`)
+
+	manifest, err := client.ExportManifested(context.Background(), TradeRequest{}, ManifestExportOptions{
+		Dir: dir, From: 2, To: 2, ShardSize: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, manifest.Completed, 1)
+	assert.Equal(t, 2, manifest.Completed[0].Records)
+	data, err := ioutil.ReadFile(filepath.Join(dir, manifest.Completed[0].File))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "\n8589938689-0,8589938689-0,")
+	assert.Contains(t, string(data), "\n8589938689-1,8589938689-1,")
+	assert.NotContains(t, string(data), "12884905985-0")
+
+	_, err = afterShard("x-0", 1)
+	assert.EqualError(t, err, `invalid paging token x-0: strconv.ParseInt: parsing "x": invalid syntax`)
+}
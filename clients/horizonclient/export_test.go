@@ -0,0 +1,186 @@
+package horizonclient
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCSVTransactions(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?limit=1&order=asc",
+	).ReturnString(200, exportTransactionsPage)
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=1881771201282048&limit=1&order=asc",
+	).ReturnString(200, exportEmptyPage)
+
+	var buf bytes.Buffer
+	err := client.ExportCSV(context.Background(), TransactionRequest{Limit: 1, Order: OrderAsc}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"id,paging_token,successful,hash,ledger,created_at,source_account,source_account_sequence,fee_account,fee_charged,max_fee,operation_count,memo_type,memo\n"+
+			"bcc7a972,1881771201282048,true,bcc7a972,354811,2019-03-25T10:27:53Z,GC3IMK2BSHNZZ4WAC3AXQYA7HQTZKUUDJ7UYSA2HTNCIX5S5A5NVD3FD,1,GC3IMK2BSHNZZ4WAC3AXQYA7HQTZKUUDJ7UYSA2HTNCIX5S5A5NVD3FD,100,200,1,text,\"hello, world\"\n",
+		buf.String(),
+	)
+}
+
+func TestExportJSONLPayments(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/payments",
+	).ReturnString(200, exportPaymentsPage)
+	hmock.On(
+		"GET",
+		"https://localhost/payments?cursor=2&limit=2&order=asc",
+	).ReturnString(200, exportEmptyPage)
+
+	request := OperationRequest{}
+	var buf bytes.Buffer
+	err := client.ExportJSONL(context.Background(), request.SetPaymentsEndpoint(), &buf)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"amount":"10.0000000","asset_code":"USD","asset_issuer":"GBZ","asset_type":"credit_alphanum4","created_at":"2019-03-25T10:27:53Z","from":"GA","id":"1","paging_token":"1","source_account":"GA","to":"GB","transaction_hash":"abc","transaction_successful":"true","type":"payment"}`+"\n"+
+			`{"amount":"10000.0000000","asset_code":"","asset_issuer":"","asset_type":"native","created_at":"2019-03-25T10:27:53Z","from":"GA","id":"2","paging_token":"2","source_account":"GA","to":"GC","transaction_hash":"def","transaction_successful":"false","type":"create_account"}`+"\n",
+		buf.String(),
+	)
+
+	// the operations endpoint keeps the details of the operations
+	hmock.On(
+		"GET",
+		"https://localhost/operations",
+	).ReturnString(200, exportPaymentsPage)
+	hmock.On(
+		"GET",
+		"https://localhost/payments?cursor=2&limit=2&order=asc",
+	).ReturnString(200, exportEmptyPage)
+
+	buf.Reset()
+	err = client.ExportCSV(context.Background(), OperationRequest{}, &buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "id,paging_token,transaction_hash,transaction_successful,created_at,type,source_account,details\n")
+	assert.Contains(t, buf.String(), `2,2,def,false,2019-03-25T10:27:53Z,create_account,GA,"{""_links""`)
+}
+
+func TestExportErrors(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	var buf bytes.Buffer
+	err := client.ExportCSV(context.Background(), LedgerRequest{}, &buf)
+	assert.EqualError(t, err, "horizonclient.LedgerRequest cannot be exported")
+
+	hmock.On(
+		"GET",
+		"https://localhost/trades",
+	).ReturnString(404, notFoundResponse)
+	err = client.ExportCSV(context.Background(), TradeRequest{}, &buf)
+	assert.EqualError(t, err, "error fetching trades: horizon error: \"Resource Missing\" - check horizon.Error.Problem for more information")
+
+	// the export stops once the context is cancelled
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?limit=1&order=asc",
+	).ReturnString(200, exportTransactionsPage)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	err = client.ExportJSONL(ctx, TransactionRequest{Limit: 1, Order: OrderAsc}, &buf)
+	assert.Equal(t, context.Canceled, err)
+	assert.Contains(t, buf.String(), `"hash":"bcc7a972"`)
+}
+
+var exportEmptyPage = `{
+  "_links": {
+    "next": {"href": "https://localhost/transactions?cursor=1881771201282048&limit=1&order=asc"},
+    "prev": {"href": "https://localhost/transactions?cursor=1881771201282048&limit=1&order=desc"}
+  },
+  "_embedded": {"records": []}
+}`
+
+var exportTransactionsPage = `{
+  "_links": {
+    "next": {"href": "https://localhost/transactions?cursor=1881771201282048&limit=1&order=asc"},
+    "prev": {"href": "https://localhost/transactions?cursor=1881771201282048&limit=1&order=desc"}
+  },
+  "_embedded": {
+    "records": [
+      {
+        "id": "bcc7a972",
+        "paging_token": "1881771201282048",
+        "successful": true,
+        "hash": "bcc7a972",
+        "ledger": 354811,
+        "created_at": "2019-03-25T10:27:53Z",
+        "source_account": "GC3IMK2BSHNZZ4WAC3AXQYA7HQTZKUUDJ7UYSA2HTNCIX5S5A5NVD3FD",
+        "source_account_sequence": "1",
+        "fee_account": "GC3IMK2BSHNZZ4WAC3AXQYA7HQTZKUUDJ7UYSA2HTNCIX5S5A5NVD3FD",
+        "fee_charged": "100",
+        "max_fee": "200",
+        "operation_count": 1,
+        "memo_type": "text",
+        "memo": "hello, world"
+      }
+    ]
+  }
+}`
+
+var exportPaymentsPage = `{
+  "_links": {
+    "next": {"href": "https://localhost/payments?cursor=2&limit=2&order=asc"},
+    "prev": {"href": "https://localhost/payments?cursor=1&limit=2&order=desc"}
+  },
+  "_embedded": {
+    "records": [
+      {
+        "id": "1",
+        "paging_token": "1",
+        "transaction_successful": true,
+        "source_account": "GA",
+        "type": "payment",
+        "type_i": 1,
+        "created_at": "2019-03-25T10:27:53Z",
+        "transaction_hash": "abc",
+        "asset_type": "credit_alphanum4",
+        "asset_code": "USD",
+        "asset_issuer": "GBZ",
+        "from": "GA",
+        "to": "GB",
+        "amount": "10.0000000"
+      },
+      {
+        "id": "2",
+        "paging_token": "2",
+        "transaction_successful": false,
+        "source_account": "GA",
+        "type": "create_account",
+        "type_i": 0,
+        "created_at": "2019-03-25T10:27:53Z",
+        "transaction_hash": "def",
+        "starting_balance": "10000.0000000",
+        "funder": "GA",
+        "account": "GC"
+      }
+    ]
+  }
+}`
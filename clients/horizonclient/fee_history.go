@@ -0,0 +1,251 @@
+package horizonclient
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+)
+
+// maxFeeHistoryLedgers is the maximum number of ledgers of FeeHistory, the
+// maximum limit of a page of ledgers.
+const maxFeeHistoryLedgers = 200
+
+// LedgerFees are the fees, per operation, of the transactions of a ledger,
+// successful or not.
+type LedgerFees struct {
+	Sequence int32
+	ClosedAt time.Time
+	BaseFee  int64
+	// CapacityUsage is the ratio of the operations of the transaction set of
+	// the ledger to the maximum.
+	CapacityUsage float64
+	Transactions  int
+	// FeeCharged and MaxFee are the distributions of the fees charged and
+	// bid per operation, like the ones of the fee_stats endpoint.
+	FeeCharged hProtocol.FeeDistribution
+	MaxFee     hProtocol.FeeDistribution
+
+	feesCharged []int64
+	maxFees     []int64
+}
+
+// Surging returns true if the ledger was closed in surge pricing: its
+// capacity was exhausted, so its transactions were charged, per operation,
+// the lowest bid of the ones included instead of the base fee.
+func (l LedgerFees) Surging() bool {
+	return l.Transactions > 0 && l.FeeCharged.Min > l.BaseFee
+}
+
+// FeeHistory returns the fees of the n most recent ledgers, newest first, up
+// to 200, when the fee_stats endpoint, which covers the last 5 ledgers, is
+// too short-sighted, e.g. to detect surge pricing episodes or to choose the
+// fee of transactions which are not urgent. The fees of the ledgers can be
+// aggregated with AggregateFeeHistory.
+//
+// The transactions of the ledgers are paged through, so it sends one request
+// per 200 transactions in addition to the request of the ledgers.
+func (c *Client) FeeHistory(n int) ([]LedgerFees, error) {
+	if n <= 0 || n > maxFeeHistoryLedgers {
+		return nil, errors.Errorf("the number of ledgers must be between 1 and %d", maxFeeHistoryLedgers)
+	}
+	ledgers, err := c.Ledgers(LedgerRequest{Order: OrderDesc, Limit: uint(n)})
+	if err != nil {
+		return nil, errors.Wrap(err, "error fetching ledgers")
+	}
+	records := ledgers.Embedded.Records
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	history := make([]LedgerFees, len(records))
+	index := map[int32]int{}
+	for i, ledger := range records {
+		operations := ledger.OperationCount
+		if ledger.TxSetOperationCount != nil {
+			operations = *ledger.TxSetOperationCount
+		}
+		history[i] = LedgerFees{
+			Sequence: ledger.Sequence,
+			ClosedAt: ledger.ClosedAt,
+			BaseFee:  int64(ledger.BaseFee),
+		}
+		if ledger.MaxTxSetSize > 0 {
+			history[i].CapacityUsage = float64(operations) / float64(ledger.MaxTxSetSize)
+		}
+		index[ledger.Sequence] = i
+	}
+
+	newest, oldest := records[0].Sequence, records[len(records)-1].Sequence
+	page, err := c.Transactions(TransactionRequest{
+		Order:         OrderDesc,
+		Cursor:        toid.AfterLedger(newest).String(),
+		Limit:         maxFeeHistoryLedgers,
+		IncludeFailed: true,
+	})
+	for {
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching transactions")
+		}
+		for _, tx := range page.Embedded.Records {
+			if tx.Ledger < oldest {
+				return finishFeeHistory(history), nil
+			}
+			i, ok := index[tx.Ledger]
+			if !ok {
+				continue
+			}
+			operations := int64(tx.OperationCount)
+			if tx.FeeBumpTransaction != nil {
+				// the fee bump counts as an operation
+				operations++
+			}
+			if operations == 0 {
+				continue
+			}
+			history[i].Transactions++
+			history[i].feesCharged = append(history[i].feesCharged, tx.FeeCharged/operations)
+			history[i].maxFees = append(history[i].maxFees, tx.MaxFee/operations)
+		}
+		if len(page.Embedded.Records) < maxFeeHistoryLedgers {
+			return finishFeeHistory(history), nil
+		}
+		page, err = c.NextTransactionsPage(page)
+	}
+}
+
+func finishFeeHistory(history []LedgerFees) []LedgerFees {
+	for i := range history {
+		history[i].FeeCharged = feeDistribution(history[i].feesCharged)
+		history[i].MaxFee = feeDistribution(history[i].maxFees)
+	}
+	return history
+}
+
+// feeDistribution returns the distribution of fees, nearest-rank
+// percentiles, sorting them.
+func feeDistribution(fees []int64) hProtocol.FeeDistribution {
+	if len(fees) == 0 {
+		return hProtocol.FeeDistribution{}
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	percentile := func(p float64) int64 {
+		rank := int(math.Ceil(p / 100 * float64(len(fees))))
+		if rank < 1 {
+			rank = 1
+		}
+		return fees[rank-1]
+	}
+
+	// the mode is the most frequent fee, the lowest one in case of a tie
+	mode, modeCount := fees[0], 0
+	for i := 0; i < len(fees); {
+		j := i
+		for j < len(fees) && fees[j] == fees[i] {
+			j++
+		}
+		if j-i > modeCount {
+			mode, modeCount = fees[i], j-i
+		}
+		i = j
+	}
+
+	return hProtocol.FeeDistribution{
+		Max:  fees[len(fees)-1],
+		Min:  fees[0],
+		Mode: mode,
+		P10:  percentile(10),
+		P20:  percentile(20),
+		P30:  percentile(30),
+		P40:  percentile(40),
+		P50:  percentile(50),
+		P60:  percentile(60),
+		P70:  percentile(70),
+		P80:  percentile(80),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+	}
+}
+
+// AggregateFeeHistory aggregates the fees of ledgers returned by FeeHistory
+// in the form of the response of the fee_stats endpoint: the last ledger and
+// base fee are the ones of the newest ledger, the capacity usage is the
+// average of the ledgers and the distributions are the ones of all their
+// transactions.
+func AggregateFeeHistory(history []LedgerFees) hProtocol.FeeStats {
+	var stats hProtocol.FeeStats
+	if len(history) == 0 {
+		return stats
+	}
+	newest := history[0]
+	for _, ledger := range history[1:] {
+		if ledger.Sequence > newest.Sequence {
+			newest = ledger
+		}
+	}
+	stats.LastLedger = uint32(newest.Sequence)
+	stats.LastLedgerBaseFee = newest.BaseFee
+
+	var feesCharged, maxFees []int64
+	for _, ledger := range history {
+		stats.LedgerCapacityUsage += ledger.CapacityUsage
+		feesCharged = append(feesCharged, ledger.feesCharged...)
+		maxFees = append(maxFees, ledger.maxFees...)
+	}
+	stats.LedgerCapacityUsage /= float64(len(history))
+	stats.FeeCharged = feeDistribution(feesCharged)
+	stats.MaxFee = feeDistribution(maxFees)
+	return stats
+}
+
+// SurgeDetector detects surge pricing from the fees of the recent ledgers.
+type SurgeDetector struct {
+	Client *Client
+	// Ledgers is the number of recent ledgers examined, 5 if 0.
+	Ledgers int
+	// Threshold is the minimum ratio of surging ledgers (see
+	// LedgerFees.Surging) among the ledgers examined for the network to be in
+	// surge pricing, 0.5 if 0.
+	Threshold float64
+}
+
+// SurgeStatus is the status returned by SurgeDetector.Detect.
+type SurgeStatus struct {
+	Surging bool
+	// SurgingLedgers is the number of surging ledgers among History.
+	SurgingLedgers int
+	History        []LedgerFees
+	// FeeStats aggregates the fees of History.
+	FeeStats hProtocol.FeeStats
+}
+
+// Detect fetches the fee history of the recent ledgers and returns whether
+// the network is in surge pricing. When it is, FeeStats.FeeCharged gives the
+// fees a transaction must bid per operation to be included, e.g. P90.
+func (d *SurgeDetector) Detect() (SurgeStatus, error) {
+	ledgers := d.Ledgers
+	if ledgers == 0 {
+		ledgers = 5
+	}
+	threshold := d.Threshold
+	if threshold == 0 {
+		threshold = 0.5
+	}
+
+	history, err := d.Client.FeeHistory(ledgers)
+	if err != nil {
+		return SurgeStatus{}, err
+	}
+	status := SurgeStatus{History: history, FeeStats: AggregateFeeHistory(history)}
+	for _, ledger := range history {
+		if ledger.Surging() {
+			status.SurgingLedgers++
+		}
+	}
+	status.Surging = len(history) > 0 && float64(status.SurgingLedgers)/float64(len(history)) >= threshold
+	return status, nil
+}
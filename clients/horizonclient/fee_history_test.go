@@ -0,0 +1,109 @@
+package horizonclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/clients/horizonclient/horizontest"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+)
+
+func feeHistoryServer(t *testing.T) *Client {
+	operations := func(n int32) *int32 { return &n }
+	server, err := horizontest.NewServer(horizontest.Fixtures{
+		Ledgers: []hProtocol.Ledger{
+			{Sequence: 1, BaseFee: 100, MaxTxSetSize: 10, TxSetOperationCount: operations(2)},
+			{Sequence: 2, BaseFee: 100, MaxTxSetSize: 10, OperationCount: 3},
+			{Sequence: 3, BaseFee: 100, MaxTxSetSize: 10, TxSetOperationCount: operations(10)},
+		},
+		Transactions: []hProtocol.Transaction{
+			{Hash: "a", Ledger: 1, OperationCount: 2, FeeCharged: 200, MaxFee: 1000},
+			{Hash: "b", Ledger: 2, OperationCount: 1, FeeCharged: 100, MaxFee: 100},
+			{Hash: "c", Ledger: 2, OperationCount: 2, FeeCharged: 200, MaxFee: 400},
+			{Hash: "d", Ledger: 3, OperationCount: 4, FeeCharged: 1200, MaxFee: 1200},
+			{Hash: "e", Ledger: 3, OperationCount: 5, FeeCharged: 1500, MaxFee: 5000},
+			// the fee bump counts as an operation
+			{Hash: "f", Ledger: 3, OperationCount: 1, FeeCharged: 600, MaxFee: 2000, FeeBumpTransaction: &hProtocol.FeeBumpTransaction{}},
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	return &Client{HorizonURL: server.URL}
+}
+
+func TestFeeHistory(t *testing.T) {
+	client := feeHistoryServer(t)
+
+	history, err := client.FeeHistory(2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	assert.Equal(t, int32(3), history[0].Sequence)
+	assert.Equal(t, 3, history[0].Transactions)
+	assert.Equal(t, 1.0, history[0].CapacityUsage)
+	assert.Equal(t, int64(300), history[0].FeeCharged.Min)
+	assert.Equal(t, int64(300), history[0].FeeCharged.P99)
+	assert.Equal(t, int64(300), history[0].MaxFee.Min)
+	assert.Equal(t, int64(1000), history[0].MaxFee.Max)
+	assert.True(t, history[0].Surging())
+
+	assert.Equal(t, int32(2), history[1].Sequence)
+	assert.Equal(t, 2, history[1].Transactions)
+	assert.Equal(t, 0.3, history[1].CapacityUsage)
+	assert.Equal(t, int64(100), history[1].FeeCharged.Max)
+	assert.Equal(t, int64(100), history[1].MaxFee.P10)
+	assert.Equal(t, int64(200), history[1].MaxFee.P90)
+	assert.False(t, history[1].Surging())
+
+	stats := AggregateFeeHistory(history)
+	assert.Equal(t, uint32(3), stats.LastLedger)
+	assert.Equal(t, int64(100), stats.LastLedgerBaseFee)
+	assert.Equal(t, 0.65, stats.LedgerCapacityUsage)
+	assert.Equal(t, int64(100), stats.FeeCharged.Min)
+	assert.Equal(t, int64(300), stats.FeeCharged.Mode)
+	assert.Equal(t, int64(100), stats.FeeCharged.P20)
+	assert.Equal(t, int64(300), stats.FeeCharged.P50)
+
+	_, err = client.FeeHistory(201)
+	assert.EqualError(t, err, "the number of ledgers must be between 1 and 200")
+}
+
+func TestFeeDistribution(t *testing.T) {
+	assert.Equal(t, hProtocol.FeeDistribution{}, feeDistribution(nil))
+
+	var fees []int64
+	for fee := int64(100); fee > 0; fee-- {
+		fees = append(fees, fee)
+	}
+	distribution := feeDistribution(fees)
+	assert.Equal(t, int64(1), distribution.Min)
+	assert.Equal(t, int64(100), distribution.Max)
+	assert.Equal(t, int64(1), distribution.Mode)
+	assert.Equal(t, int64(10), distribution.P10)
+	assert.Equal(t, int64(50), distribution.P50)
+	assert.Equal(t, int64(95), distribution.P95)
+	assert.Equal(t, int64(99), distribution.P99)
+
+	assert.Equal(t, int64(7), feeDistribution([]int64{9, 7, 3, 7}).Mode)
+}
+
+func TestSurgeDetector(t *testing.T) {
+	client := feeHistoryServer(t)
+
+	status, err := (&SurgeDetector{Client: client}).Detect()
+	require.NoError(t, err)
+	assert.Len(t, status.History, 3)
+	assert.Equal(t, 1, status.SurgingLedgers)
+	assert.False(t, status.Surging)
+
+	status, err = (&SurgeDetector{Client: client, Ledgers: 1}).Detect()
+	require.NoError(t, err)
+	assert.True(t, status.Surging)
+	assert.Equal(t, int64(300), status.FeeStats.FeeCharged.P90)
+
+	status, err = (&SurgeDetector{Client: client, Threshold: 0.3}).Detect()
+	require.NoError(t, err)
+	assert.True(t, status.Surging)
+}
@@ -0,0 +1,482 @@
+package horizontest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 200
+	// baseFee and baseReserve are the fee and reserve, in stroops, of the
+	// ledgers of the submitted transactions.
+	baseFee     = 100
+	baseReserve = 5000000
+)
+
+func (s *Server) router() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.getRoot)
+	r.Get("/fee_stats", s.getFeeStats)
+
+	r.Get("/accounts/{account_id}", s.getAccount)
+	r.Get("/accounts/{account_id}/data/{key}", s.getAccountData)
+	r.Get("/accounts/{account_id}/transactions", s.list(transactions, byParam("account_id", accountOf)))
+	r.Get("/accounts/{account_id}/operations", s.list(operationsOf, byParam("account_id", accountOf)))
+
+	r.Get("/ledgers", s.list(ledgers, nil))
+	r.Get("/ledgers/{sequence}", s.getLedger)
+	r.Get("/ledgers/{sequence}/transactions", s.list(transactions, byParam("sequence", ledgerOf)))
+	r.Get("/ledgers/{sequence}/operations", s.list(operationsOf, byParam("sequence", ledgerOf)))
+
+	r.Get("/transactions", s.list(transactions, nil))
+	r.Post("/transactions", s.submit)
+	r.Get("/transactions/{hash}", s.getTransaction)
+	r.Get("/transactions/{hash}/operations", s.list(operationsOf, byParam("hash", hashOf)))
+
+	r.Get("/operations", s.list(operationsOf, nil))
+	r.Get("/operations/{id}", s.getOperation)
+
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		renderProblem(w, problem.NotFound)
+	})
+	return r
+}
+
+func ledgers(s *Server) collection      { return s.ledgers }
+func transactions(s *Server) collection { return s.transactions }
+func operationsOf(s *Server) collection { return s.operations }
+
+func accountOf(r record) string { return r.account }
+func ledgerOf(r record) string  { return strconv.FormatInt(int64(r.ledger), 10) }
+func hashOf(r record) string    { return r.hash }
+
+// byParam returns a filter of the records whose field matches the URL
+// parameter of the request.
+func byParam(param string, field func(record) string) func(*http.Request) func(record) bool {
+	return func(r *http.Request) func(record) bool {
+		value := chi.URLParam(r, param)
+		return func(rec record) bool {
+			return field(rec) == value
+		}
+	}
+}
+
+func render(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+	json.NewEncoder(w).Encode(value)
+}
+
+func renderProblem(w http.ResponseWriter, p problem.P) {
+	if !strings.HasPrefix(p.Type, "https://") {
+		p.Type = "https://stellar.org/horizon-errors/" + p.Type
+	}
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+func badRequest(field, reason string) problem.P {
+	p := problem.BadRequest
+	p.Extras = map[string]interface{}{"invalid_field": field, "reason": reason}
+	return p
+}
+
+func (s *Server) getRoot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	render(w, s.root)
+}
+
+func (s *Server) getFeeStats(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	render(w, s.feeStats)
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	account, ok := s.Account(chi.URLParam(r, "account_id"))
+	if !ok {
+		renderProblem(w, problem.NotFound)
+		return
+	}
+	render(w, account)
+}
+
+func (s *Server) getAccountData(w http.ResponseWriter, r *http.Request) {
+	account, ok := s.Account(chi.URLParam(r, "account_id"))
+	value, found := account.Data[chi.URLParam(r, "key")]
+	if !ok || !found {
+		renderProblem(w, problem.NotFound)
+		return
+	}
+	render(w, hProtocol.AccountData{Value: value})
+}
+
+// find renders the first record of the collection matching the URL
+// parameter.
+func (s *Server) find(w http.ResponseWriter, r *http.Request, list func(*Server) collection, param string, field func(record) string) {
+	value := chi.URLParam(r, param)
+	s.mu.Lock()
+	records := list(s)
+	s.mu.Unlock()
+	for _, rec := range records {
+		if field(rec) == value {
+			render(w, rec.value)
+			return
+		}
+	}
+	renderProblem(w, problem.NotFound)
+}
+
+func (s *Server) getLedger(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, ledgers, "sequence", ledgerOf)
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, transactions, "hash", hashOf)
+}
+
+func (s *Server) getOperation(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, operationsOf, "id", func(rec record) string {
+		return rec.value.(operations.Operation).GetID()
+	})
+}
+
+// list returns the handler of a collection, filtered by the filter of the
+// request if not nil, rendering a page or a stream.
+func (s *Server) list(
+	list func(*Server) collection,
+	filter func(*http.Request) func(record) bool,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		match := func(record) bool { return true }
+		if filter != nil {
+			match = filter(r)
+		}
+
+		query := r.URL.Query()
+		s.mu.Lock()
+		records := list(s)
+		s.mu.Unlock()
+
+		var cursor int64
+		hasCursor := true
+		switch c := query.Get("cursor"); c {
+		case "":
+			hasCursor = false
+		case "now":
+			cursor = records.last()
+		default:
+			var err error
+			if cursor, err = strconv.ParseInt(c, 10, 64); err != nil || cursor < 0 {
+				renderProblem(w, badRequest("cursor", "the cursor must be a positive integer or now"))
+				return
+			}
+		}
+
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			s.stream(w, r, list, match, cursor)
+			return
+		}
+
+		limit := defaultLimit
+		if l := query.Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit <= 0 || limit > maxLimit {
+				renderProblem(w, badRequest("limit", fmt.Sprintf("the limit must be between 1 and %d", maxLimit)))
+				return
+			}
+		}
+		desc := false
+		switch query.Get("order") {
+		case "", "asc":
+		case "desc":
+			desc = true
+		default:
+			renderProblem(w, badRequest("order", "the order must be asc or desc"))
+			return
+		}
+
+		page := []interface{}{}
+		var first, last string
+		for i := range records {
+			rec := records[i]
+			if desc {
+				rec = records[len(records)-1-i]
+			}
+			if hasCursor && ((!desc && rec.token <= cursor) || (desc && rec.token >= cursor)) {
+				continue
+			}
+			if !match(rec) {
+				continue
+			}
+			token := strconv.FormatInt(rec.token, 10)
+			if first == "" {
+				first = token
+			}
+			last = token
+			page = append(page, rec.value)
+			if len(page) == limit {
+				break
+			}
+		}
+		if first == "" {
+			first, last = query.Get("cursor"), query.Get("cursor")
+		}
+
+		order, reverse := "asc", "desc"
+		if desc {
+			order, reverse = reverse, order
+		}
+		render(w, map[string]interface{}{
+			"_links": map[string]interface{}{
+				"self": link(s.URL, r.URL, query.Get("cursor"), limit, order),
+				"next": link(s.URL, r.URL, last, limit, order),
+				"prev": link(s.URL, r.URL, first, limit, reverse),
+			},
+			"_embedded": map[string]interface{}{"records": page},
+		})
+	}
+}
+
+func link(base string, u *url.URL, cursor string, limit int, order string) map[string]string {
+	query := url.Values{}
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("order", order)
+	return map[string]string{"href": base + u.Path + "?" + query.Encode()}
+}
+
+// stream sends the records after cursor matching the filter as Server Sent
+// Events, then the records added, until the client disconnects or the server
+// is closed.
+func (s *Server) stream(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func(*Server) collection,
+	match func(record) bool,
+	cursor int64,
+) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		renderProblem(w, problem.ServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 1000\nevent: open\ndata: \"hello\"\n\n")
+	flusher.Flush()
+
+	for {
+		s.mu.Lock()
+		records := list(s)
+		updated := s.updated
+		s.mu.Unlock()
+
+		for _, rec := range records {
+			if rec.token <= cursor {
+				continue
+			}
+			cursor = rec.token
+			if !match(rec) {
+				continue
+			}
+			data, err := json.Marshal(rec.value)
+			if err != nil {
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+				flusher.Flush()
+				return
+			}
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", rec.token, data)
+		}
+		flusher.Flush()
+
+		select {
+		case <-updated:
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func transactionFailed(envelope string, codes hProtocol.TransactionResultCodes) problem.P {
+	return problem.P{
+		Type:   "https://stellar.org/horizon-errors/transaction_failed",
+		Title:  "Transaction Failed",
+		Status: http.StatusBadRequest,
+		Detail: "The transaction failed when submitted to the stellar network. " +
+			"The `extras.result_codes` field on this response contains further details.",
+		Extras: map[string]interface{}{
+			"envelope_xdr": envelope,
+			"result_codes": codes,
+		},
+	}
+}
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderProblem(w, badRequest("tx", err.Error()))
+		return
+	}
+	encoded := r.PostForm.Get("tx")
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(encoded, &envelope); err != nil {
+		renderProblem(w, problem.P{
+			Type:   "https://stellar.org/horizon-errors/transaction_malformed",
+			Title:  "Transaction Malformed",
+			Status: http.StatusBadRequest,
+			Detail: "Horizon could not decode the transaction envelope in this request.",
+			Extras: map[string]interface{}{"envelope_xdr": encoded},
+		})
+		return
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, s.passphrase)
+	if err != nil {
+		renderProblem(w, badRequest("tx", err.Error()))
+		return
+	}
+
+	s.mu.Lock()
+	s.submissions = append(s.submissions, encoded)
+	source := envelope.SourceAccount().ToAccountId().Address()
+	account, known := s.accounts[source]
+	if known {
+		sequence, err := strconv.ParseInt(account.Sequence, 10, 64)
+		if err == nil && envelope.SeqNum() != sequence+1 {
+			s.mu.Unlock()
+			renderProblem(w, transactionFailed(encoded, hProtocol.TransactionResultCodes{TransactionCode: "tx_bad_seq"}))
+			return
+		}
+	}
+	s.mu.Unlock()
+
+	if s.Submit != nil {
+		if codes := s.Submit(envelope); codes != nil {
+			renderProblem(w, transactionFailed(encoded, *codes))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.close(envelope, encoded, hex.EncodeToString(hash[:]))
+	if err != nil {
+		renderProblem(w, problem.ServerError)
+		return
+	}
+	if account, known = s.accounts[source]; known {
+		account.Sequence = strconv.FormatInt(envelope.SeqNum(), 10)
+		s.accounts[source] = account
+	}
+	s.notify()
+	render(w, tx)
+}
+
+// close closes a ledger, after the ledgers and the ledgers of the
+// transactions of the server, with the transaction. s.mu must be held.
+func (s *Server) close(envelope xdr.TransactionEnvelope, encoded, hash string) (hProtocol.Transaction, error) {
+	sequence := s.root.HorizonSequence
+	if last := toid.Parse(s.transactions.last()).LedgerSequence; last > sequence {
+		sequence = last
+	}
+	sequence++
+	closedAt := time.Now().UTC().Truncate(time.Second)
+	operationCount := int32(len(envelope.Operations()))
+
+	var seed [4]byte
+	binary.BigEndian.PutUint32(seed[:], uint32(sequence))
+	ledgerHash := sha256.Sum256(seed[:])
+	ledger := hProtocol.Ledger{
+		Hash:                       hex.EncodeToString(ledgerHash[:]),
+		Sequence:                   sequence,
+		SuccessfulTransactionCount: 1,
+		OperationCount:             operationCount,
+		ClosedAt:                   closedAt,
+		BaseFee:                    baseFee,
+		BaseReserve:                baseReserve,
+		MaxTxSetSize:               100,
+		ProtocolVersion:            s.root.CurrentProtocolVersion,
+	}
+	if len(s.ledgers) > 0 {
+		ledger.PrevHash = s.ledgers[len(s.ledgers)-1].value.(hProtocol.Ledger).Hash
+	}
+	if err := s.addLedger(ledger); err != nil {
+		return hProtocol.Transaction{}, err
+	}
+
+	source := envelope.SourceAccount().ToAccountId().Address()
+	tx := hProtocol.Transaction{
+		Hash:            hash,
+		Successful:      true,
+		Ledger:          sequence,
+		LedgerCloseTime: closedAt,
+		Account:         source,
+		AccountSequence: strconv.FormatInt(envelope.SeqNum(), 10),
+		FeeAccount:      source,
+		MaxFee:          int64(envelope.Fee()),
+		FeeCharged:      baseFee * int64(operationCount),
+		OperationCount:  operationCount,
+		EnvelopeXdr:     encoded,
+	}
+	switch memo := envelope.Memo(); memo.Type {
+	case xdr.MemoTypeMemoText:
+		tx.MemoType, tx.Memo = "text", memo.MustText()
+	case xdr.MemoTypeMemoId:
+		tx.MemoType, tx.Memo = "id", strconv.FormatUint(uint64(memo.MustId()), 10)
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		tx.MemoType, tx.Memo = "hash", base64.StdEncoding.EncodeToString(hash[:])
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		tx.MemoType, tx.Memo = "return", base64.StdEncoding.EncodeToString(hash[:])
+	default:
+		tx.MemoType = "none"
+	}
+	if envelope.IsFeeBump() {
+		tx.FeeAccount = envelope.FeeBumpAccount().ToAccountId().Address()
+		tx.MaxFee = envelope.FeeBumpFee()
+		tx.FeeCharged++
+	}
+	if tx.FeeCharged > tx.MaxFee {
+		tx.FeeCharged = tx.MaxFee
+	}
+	signatures := envelope.Signatures()
+	if envelope.IsFeeBump() {
+		signatures = envelope.FeeBumpSignatures()
+	}
+	for _, signature := range signatures {
+		tx.Signatures = append(tx.Signatures, base64.StdEncoding.EncodeToString(signature.Signature))
+	}
+	if tb := envelope.TimeBounds(); tb != nil {
+		tx.ValidAfter = time.Unix(int64(tb.MinTime), 0).UTC().Format(time.RFC3339)
+		if tb.MaxTime != 0 {
+			tx.ValidBefore = time.Unix(int64(tb.MaxTime), 0).UTC().Format(time.RFC3339)
+		}
+	}
+	if err := s.addTransaction(tx); err != nil {
+		return hProtocol.Transaction{}, err
+	}
+	return s.transactions[len(s.transactions)-1].value.(hProtocol.Transaction), nil
+}
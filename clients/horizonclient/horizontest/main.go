@@ -0,0 +1,391 @@
+/*
+Package horizontest provides an in-memory fake Horizon server, seeded with
+fixtures, to run integration style tests of code using horizonclient without
+a network or a Stellar node.
+
+The server serves the subset of the Horizon API below, with the cursor, limit
+and order parameters of the collections and streaming with Server Sent
+Events when the client accepts text/event-stream (StreamLedgers,
+StreamTransactions, StreamOperations...):
+
+	GET  /
+	GET  /accounts/{account_id}
+	GET  /accounts/{account_id}/data/{key}
+	GET  /accounts/{account_id}/transactions
+	GET  /accounts/{account_id}/operations
+	GET  /ledgers
+	GET  /ledgers/{sequence}
+	GET  /ledgers/{sequence}/transactions
+	GET  /ledgers/{sequence}/operations
+	GET  /transactions
+	GET  /transactions/{hash}
+	GET  /transactions/{hash}/operations
+	POST /transactions
+	GET  /operations
+	GET  /operations/{id}
+	GET  /fee_stats
+
+Submitted transactions are not applied: their sequence number is checked and
+bumped if their source account is known, and they are added, successful, to
+a new ledger, unless Server.Submit rejects them. Records added with
+AddLedger, AddTransaction and AddOperation are sent to the open streams.
+
+	server, err := horizontest.NewServer(horizontest.Fixtures{
+		Accounts: []hProtocol.Account{account},
+	})
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+	client := &horizonclient.Client{HorizonURL: server.URL}
+*/
+package horizontest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// Fixtures are the records served by a Server. The ledgers, transactions
+// and operations must be in the order they would be returned by Horizon
+// (ascending paging tokens). Their paging tokens, when empty, are derived
+// from their ledgers like Horizon does.
+type Fixtures struct {
+	// Root is returned by GET /. The network passphrase of the transactions
+	// submitted is Root.NetworkPassphrase, network.TestNetworkPassphrase if
+	// empty.
+	Root         hProtocol.Root
+	Accounts     []hProtocol.Account
+	Ledgers      []hProtocol.Ledger
+	Transactions []hProtocol.Transaction
+	Operations   []operations.Operation
+	FeeStats     hProtocol.FeeStats
+}
+
+// fixturesJSON is the JSON form of Fixtures, operations being decoded based
+// on their type.
+type fixturesJSON struct {
+	Root         hProtocol.Root          `json:"root"`
+	Accounts     []hProtocol.Account     `json:"accounts"`
+	Ledgers      []hProtocol.Ledger      `json:"ledgers"`
+	Transactions []hProtocol.Transaction `json:"transactions"`
+	Operations   []json.RawMessage       `json:"operations"`
+	FeeStats     hProtocol.FeeStats      `json:"fee_stats"`
+}
+
+// LoadFixtures reads fixtures from a JSON document with the root, accounts,
+// ledgers, transactions, operations and fee_stats fields, whose values are
+// Horizon responses, e.g. captured from a real Horizon server.
+func LoadFixtures(r io.Reader) (Fixtures, error) {
+	var doc fixturesJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return Fixtures{}, errors.Wrap(err, "could not decode fixtures")
+	}
+	fixtures := Fixtures{
+		Root:         doc.Root,
+		Accounts:     doc.Accounts,
+		Ledgers:      doc.Ledgers,
+		Transactions: doc.Transactions,
+		FeeStats:     doc.FeeStats,
+	}
+	for i, data := range doc.Operations {
+		var base operations.Base
+		if err := json.Unmarshal(data, &base); err != nil {
+			return Fixtures{}, errors.Wrapf(err, "could not decode operation %d", i)
+		}
+		op, err := operations.UnmarshalOperation(base.TypeI, data)
+		if err != nil {
+			return Fixtures{}, errors.Wrapf(err, "could not decode operation %d", i)
+		}
+		fixtures.Operations = append(fixtures.Operations, op)
+	}
+	return fixtures, nil
+}
+
+// SubmitFunc decides the outcome of a transaction submitted to a Server,
+// after its sequence number was checked. It returns nil to accept the
+// transaction or the result codes of its failure.
+type SubmitFunc func(envelope xdr.TransactionEnvelope) *hProtocol.TransactionResultCodes
+
+// record is a record of a collection, with the accounts and ledger it can be
+// filtered by.
+type record struct {
+	token   int64
+	ledger  int32
+	account string
+	hash    string
+	value   interface{}
+}
+
+// collection is a list of records sorted by paging token.
+type collection []record
+
+func (c collection) last() int64 {
+	if len(c) == 0 {
+		return 0
+	}
+	return c[len(c)-1].token
+}
+
+func (c *collection) add(r record) error {
+	if r.token <= c.last() {
+		return errors.Errorf("paging token %d is not after the last paging token %d", r.token, c.last())
+	}
+	*c = append(*c, r)
+	return nil
+}
+
+// Server is a fake Horizon server. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Submit, if not nil, decides the outcome of the transactions submitted.
+	// It must be set before the server is used.
+	Submit SubmitFunc
+
+	passphrase string
+
+	mu           sync.Mutex
+	root         hProtocol.Root
+	feeStats     hProtocol.FeeStats
+	accounts     map[string]hProtocol.Account
+	ledgers      collection
+	transactions collection
+	operations   collection
+	submissions  []string
+	done         chan struct{}
+	// updated is closed, and replaced, when records are added, to wake up
+	// the streams.
+	updated chan struct{}
+}
+
+// NewServer starts a server serving fixtures. It must be closed with Close.
+func NewServer(fixtures Fixtures) (*Server, error) {
+	s := &Server{
+		passphrase: fixtures.Root.NetworkPassphrase,
+		root:       fixtures.Root,
+		feeStats:   fixtures.FeeStats,
+		accounts:   map[string]hProtocol.Account{},
+		updated:    make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	if s.passphrase == "" {
+		s.passphrase = network.TestNetworkPassphrase
+		s.root.NetworkPassphrase = s.passphrase
+	}
+	for _, account := range fixtures.Accounts {
+		s.SetAccount(account)
+	}
+	for i, ledger := range fixtures.Ledgers {
+		if err := s.AddLedger(ledger); err != nil {
+			return nil, errors.Wrapf(err, "invalid ledger %d", i)
+		}
+	}
+	for i, tx := range fixtures.Transactions {
+		if err := s.AddTransaction(tx); err != nil {
+			return nil, errors.Wrapf(err, "invalid transaction %d", i)
+		}
+	}
+	for i, op := range fixtures.Operations {
+		if err := s.AddOperation(op); err != nil {
+			return nil, errors.Wrapf(err, "invalid operation %d", i)
+		}
+	}
+	s.Server = httptest.NewServer(s.router())
+	return s, nil
+}
+
+// Close closes the open streams and shuts down the server.
+func (s *Server) Close() {
+	close(s.done)
+	s.Server.Close()
+}
+
+// SetAccount adds or replaces an account.
+func (s *Server) SetAccount(account hProtocol.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[account.AccountID] = account
+}
+
+// Account returns an account, e.g. to check its sequence number was bumped
+// by a submission, and false if it is unknown.
+func (s *Server) Account(accountID string) (hProtocol.Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account, ok := s.accounts[accountID]
+	return account, ok
+}
+
+// Submissions returns the base64 XDR envelopes of the transactions submitted
+// to the server, accepted or not, in order.
+func (s *Server) Submissions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.submissions...)
+}
+
+func parseToken(pt string) (int64, bool, error) {
+	if pt == "" {
+		return 0, false, nil
+	}
+	token, err := strconv.ParseInt(pt, 10, 64)
+	if err != nil {
+		return 0, false, errors.Errorf("invalid paging token %q", pt)
+	}
+	return token, true, nil
+}
+
+// AddLedger adds a ledger after the existing ones and sends it to the
+// ledger streams.
+func (s *Server) AddLedger(ledger hProtocol.Ledger) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.addLedger(ledger); err != nil {
+		return err
+	}
+	s.notify()
+	return nil
+}
+
+func (s *Server) addLedger(ledger hProtocol.Ledger) error {
+	token, ok, err := parseToken(ledger.PT)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		token = toid.New(ledger.Sequence, 0, 0).ToInt64()
+		ledger.PT = strconv.FormatInt(token, 10)
+	}
+	if ledger.ID == "" {
+		ledger.ID = ledger.Hash
+	}
+	if err = s.ledgers.add(record{token: token, ledger: ledger.Sequence, value: ledger}); err != nil {
+		return err
+	}
+	if ledger.Sequence > s.root.HorizonSequence {
+		s.root.HorizonSequence = ledger.Sequence
+		s.root.CoreSequence = ledger.Sequence
+		s.root.IngestSequence = uint32(ledger.Sequence)
+		s.root.HorizonLatestClosedAt = ledger.ClosedAt
+	}
+	return nil
+}
+
+// AddTransaction adds a transaction after the existing ones and sends it to
+// the transaction streams.
+func (s *Server) AddTransaction(tx hProtocol.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.addTransaction(tx); err != nil {
+		return err
+	}
+	s.notify()
+	return nil
+}
+
+func (s *Server) addTransaction(tx hProtocol.Transaction) error {
+	token, ok, err := parseToken(tx.PT)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		next := toid.New(tx.Ledger, 1, 0)
+		if last := toid.Parse(s.transactions.last()); last.LedgerSequence == tx.Ledger {
+			next.TransactionOrder = last.TransactionOrder + 1
+		}
+		token = next.ToInt64()
+		tx.PT = strconv.FormatInt(token, 10)
+	}
+	if tx.ID == "" {
+		tx.ID = tx.Hash
+	}
+	return s.transactions.add(record{
+		token:   token,
+		ledger:  tx.Ledger,
+		account: tx.Account,
+		hash:    tx.Hash,
+		value:   tx,
+	})
+}
+
+// AddOperation adds an operation after the existing ones and sends it to the
+// operation streams. Its transaction, if it has no paging token, must have
+// been added before.
+func (s *Server) AddOperation(op operations.Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the fields of the operation are read and written through the JSON of
+	// its base, which all the operation types embed
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	var base operations.Base
+	if err = json.Unmarshal(data, &base); err != nil {
+		return err
+	}
+
+	r := record{account: base.SourceAccount, hash: base.TransactionHash}
+	var transaction *record
+	for i := range s.transactions {
+		if s.transactions[i].hash == base.TransactionHash {
+			transaction = &s.transactions[i]
+			r.ledger = transaction.ledger
+			break
+		}
+	}
+	token, ok, err := parseToken(base.PT)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if transaction == nil {
+			return errors.Errorf("operation %s has no paging token and its transaction is unknown", base.ID)
+		}
+		// the operations of a transaction are numbered from 1, after the
+		// token of the transaction
+		token = transaction.token + 1
+		if last := s.operations.last(); last >= token && last < transaction.token+(1<<12) {
+			token = last + 1
+		}
+		fields := map[string]interface{}{}
+		if err = json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		fields["paging_token"] = strconv.FormatInt(token, 10)
+		if base.ID == "" {
+			fields["id"] = fields["paging_token"]
+		}
+		if data, err = json.Marshal(fields); err != nil {
+			return err
+		}
+		if op, err = operations.UnmarshalOperation(base.TypeI, data); err != nil {
+			return err
+		}
+	}
+	r.token = token
+	r.value = op
+	if err = s.operations.add(r); err != nil {
+		return err
+	}
+	s.notify()
+	return nil
+}
+
+// notify wakes up the streams, s.mu must be held.
+func (s *Server) notify() {
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
@@ -0,0 +1,238 @@
+package horizontest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+func ledgerFixtures(n int32) []hProtocol.Ledger {
+	var ledgers []hProtocol.Ledger
+	for seq := int32(1); seq <= n; seq++ {
+		ledgers = append(ledgers, hProtocol.Ledger{Sequence: seq, Hash: strings.Repeat("0", 63) + string(rune('0'+seq))})
+	}
+	return ledgers
+}
+
+func newServer(t *testing.T, fixtures Fixtures) (*Server, *horizonclient.Client) {
+	server, err := NewServer(fixtures)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	return server, &horizonclient.Client{HorizonURL: server.URL}
+}
+
+func payment(t *testing.T, kp *keypair.Full, account *hProtocol.Account) *txnbuild.Transaction {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        account,
+		IncrementSequenceNum: true,
+		Operations: []txnbuild.Operation{&txnbuild.Payment{
+			Destination: keypair.MustRandom().Address(),
+			Amount:      "10",
+			Asset:       txnbuild.NativeAsset{},
+		}},
+		BaseFee:    txnbuild.MinBaseFee,
+		Memo:       txnbuild.MemoText("test"),
+		Timebounds: txnbuild.NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestServerSubmit(t *testing.T) {
+	kp := keypair.MustRandom()
+	server, client := newServer(t, Fixtures{
+		Accounts: []hProtocol.Account{{AccountID: kp.Address(), Sequence: "100"}},
+		Ledgers:  ledgerFixtures(2),
+	})
+
+	account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: kp.Address()})
+	require.NoError(t, err)
+	tx := payment(t, kp, &account)
+	hash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	submitted, err := client.SubmitTransactionWithOptions(tx, horizonclient.SubmitTxOpts{SkipMemoRequiredCheck: true})
+	require.NoError(t, err)
+	assert.Equal(t, hash, submitted.Hash)
+	assert.True(t, submitted.Successful)
+	assert.Equal(t, int32(3), submitted.Ledger)
+	assert.Equal(t, kp.Address(), submitted.Account)
+	assert.Equal(t, "101", submitted.AccountSequence)
+	assert.Equal(t, "text", submitted.MemoType)
+	assert.Equal(t, "test", submitted.Memo)
+	assert.Equal(t, int64(100), submitted.FeeCharged)
+
+	updated, ok := server.Account(kp.Address())
+	require.True(t, ok)
+	assert.Equal(t, "101", updated.Sequence)
+
+	detail, err := client.TransactionDetail(hash)
+	require.NoError(t, err)
+	assert.Equal(t, submitted.PT, detail.PT)
+	ledger, err := client.LedgerDetail(3)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), ledger.SuccessfulTransactionCount)
+
+	// the sequence number was consumed
+	_, err = client.SubmitTransactionWithOptions(tx, horizonclient.SubmitTxOpts{SkipMemoRequiredCheck: true})
+	require.Error(t, err)
+	codes, err := err.(*horizonclient.Error).ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_seq", codes.TransactionCode)
+
+	server.Submit = func(envelope xdr.TransactionEnvelope) *hProtocol.TransactionResultCodes {
+		return &hProtocol.TransactionResultCodes{TransactionCode: "tx_failed", OperationCodes: []string{"op_underfunded"}}
+	}
+	_, err = client.SubmitTransactionWithOptions(payment(t, kp, &account), horizonclient.SubmitTxOpts{SkipMemoRequiredCheck: true})
+	require.Error(t, err)
+	codes, err = err.(*horizonclient.Error).ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_underfunded"}, codes.OperationCodes)
+	assert.Len(t, server.Submissions(), 3)
+
+	updated, _ = server.Account(kp.Address())
+	assert.Equal(t, "101", updated.Sequence)
+}
+
+func TestServerPages(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	_, client := newServer(t, Fixtures{
+		Ledgers: ledgerFixtures(3),
+		Transactions: []hProtocol.Transaction{
+			{Hash: "a", Ledger: 2, Account: source},
+			{Hash: "b", Ledger: 2},
+			{Hash: "c", Ledger: 3, Account: source},
+		},
+		Operations: []operations.Operation{
+			operations.BumpSequence{Base: operations.Base{TransactionHash: "a", Type: "bump_sequence", TypeI: 11}, BumpTo: "5"},
+			operations.BumpSequence{Base: operations.Base{TransactionHash: "a", Type: "bump_sequence", TypeI: 11}, BumpTo: "6"},
+		},
+	})
+
+	ledgers, err := client.Ledgers(horizonclient.LedgerRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, ledgers.Embedded.Records, 2)
+	assert.Equal(t, int32(1), ledgers.Embedded.Records[0].Sequence)
+	assert.Equal(t, "4294967296", ledgers.Embedded.Records[0].PT)
+
+	ledgers, err = client.NextLedgersPage(ledgers)
+	require.NoError(t, err)
+	require.Len(t, ledgers.Embedded.Records, 1)
+	assert.Equal(t, int32(3), ledgers.Embedded.Records[0].Sequence)
+
+	ledgers, err = client.PrevLedgersPage(ledgers)
+	require.NoError(t, err)
+	require.Len(t, ledgers.Embedded.Records, 2)
+	assert.Equal(t, int32(2), ledgers.Embedded.Records[0].Sequence)
+
+	ledgers, err = client.Ledgers(horizonclient.LedgerRequest{Order: horizonclient.OrderDesc, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, ledgers.Embedded.Records, 1)
+	assert.Equal(t, int32(3), ledgers.Embedded.Records[0].Sequence)
+
+	_, err = client.LedgerDetail(9)
+	assert.True(t, horizonclient.IsNotFoundError(err))
+
+	txs, err := client.Transactions(horizonclient.TransactionRequest{ForAccount: source})
+	require.NoError(t, err)
+	require.Len(t, txs.Embedded.Records, 2)
+	assert.Equal(t, "a", txs.Embedded.Records[0].Hash)
+	assert.Equal(t, "c", txs.Embedded.Records[1].Hash)
+
+	txs, err = client.Transactions(horizonclient.TransactionRequest{ForLedger: 2})
+	require.NoError(t, err)
+	require.Len(t, txs.Embedded.Records, 2)
+	assert.Equal(t, "8589942784", txs.Embedded.Records[1].PT)
+
+	ops, err := client.Operations(horizonclient.OperationRequest{ForTransaction: "a"})
+	require.NoError(t, err)
+	require.Len(t, ops.Embedded.Records, 2)
+	assert.Equal(t, "8589938690", ops.Embedded.Records[1].PagingToken())
+	op, err := client.OperationDetail("8589938689")
+	require.NoError(t, err)
+	assert.Equal(t, "5", op.(operations.BumpSequence).BumpTo)
+
+	_, err = client.Ledgers(horizonclient.LedgerRequest{Cursor: "tomorrow"})
+	require.Error(t, err)
+	assert.Equal(t, "cursor", err.(*horizonclient.Error).Problem.Extras["invalid_field"])
+}
+
+func TestServerStream(t *testing.T) {
+	server, client := newServer(t, Fixtures{Ledgers: ledgerFixtures(2)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var sequences []int32
+	done := make(chan error)
+	go func() {
+		done <- client.StreamLedgers(ctx, horizonclient.LedgerRequest{Cursor: "0"}, func(ledger hProtocol.Ledger) {
+			sequences = append(sequences, ledger.Sequence)
+			switch len(sequences) {
+			case 2:
+				require.NoError(t, server.AddLedger(hProtocol.Ledger{Sequence: 3}))
+			case 3:
+				cancel()
+			}
+		})
+	}()
+	require.NoError(t, <-done)
+	assert.Equal(t, []int32{1, 2, 3}, sequences)
+	assert.Error(t, server.AddLedger(hProtocol.Ledger{Sequence: 3}))
+
+	// the streams without cursor start after the last record
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sequences = nil
+	go func() {
+		done <- client.StreamLedgers(ctx, horizonclient.LedgerRequest{}, func(ledger hProtocol.Ledger) {
+			sequences = append(sequences, ledger.Sequence)
+			cancel()
+		})
+	}()
+	// the stream may not be open yet, so ledgers are added until it gets one
+	for seq := int32(4); ; seq++ {
+		require.NoError(t, server.AddLedger(hProtocol.Ledger{Sequence: seq}))
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+			require.Len(t, sequences, 1)
+			assert.Greater(t, sequences[0], int32(3))
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := LoadFixtures(strings.NewReader(`{
+		"root": {"network_passphrase": "Public Global Stellar Network ; September 2015"},
+		"ledgers": [{"sequence": 7, "paging_token": "30064771072"}],
+		"operations": [{"id": "30064775169", "paging_token": "30064775169", "type": "bump_sequence", "type_i": 11, "bump_to": "9"}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, network.PublicNetworkPassphrase, fixtures.Root.NetworkPassphrase)
+	require.Len(t, fixtures.Operations, 1)
+	assert.Equal(t, "9", fixtures.Operations[0].(operations.BumpSequence).BumpTo)
+
+	_, client := newServer(t, fixtures)
+	op, err := client.OperationDetail("30064775169")
+	require.NoError(t, err)
+	assert.Equal(t, "bump_sequence", op.GetType())
+
+	_, err = LoadFixtures(strings.NewReader(`{"operations": [{"type_i": 1000}]}`))
+	assert.Error(t, err)
+}
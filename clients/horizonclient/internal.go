@@ -1,7 +1,9 @@
 package horizonclient
 
 import (
+	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -33,7 +35,20 @@ func decodeResponse(resp *http.Response, object interface{}, hc *Client) (err er
 		return horizonError
 	}
 
-	err = decoder.Decode(&object)
+	if _, hal := hc.responseFormat().(HALFormat); hal && hc.StrictDecoding != nil {
+		// the body is checked before it is decoded
+		var body []byte
+		body, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "error reading response")
+		}
+		if err = hc.StrictDecoding.Check(body, object); err != nil {
+			return err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	err = hc.responseFormat().Decode(resp, &object)
 	if err != nil {
 		return errors.Wrap(err, "error decoding response")
 	}
@@ -0,0 +1,165 @@
+package horizonclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/clients/stellarcore"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+)
+
+// LedgerSource returns the latest ledger of a server, compared by a Monitor.
+type LedgerSource interface {
+	LatestLedger(ctx context.Context) (uint32, error)
+}
+
+// HorizonLedgerSource is the latest ledger ingested by a Horizon server, the
+// history_latest_ledger of its root resource.
+type HorizonLedgerSource struct {
+	Client *Client
+}
+
+// LatestLedger returns the latest ledger ingested by the Horizon server.
+func (s HorizonLedgerSource) LatestLedger(ctx context.Context) (uint32, error) {
+	var root hProtocol.Root
+	if err := s.Client.sendRequestWithContext(ctx, rootRequest{}, &root); err != nil {
+		return 0, errors.Wrap(err, "error fetching root")
+	}
+	return uint32(root.HorizonSequence), nil
+}
+
+// CoreLedgerSource is the latest ledger closed by a stellar-core server,
+// reported by its info endpoint.
+type CoreLedgerSource struct {
+	Client *stellarcore.Client
+}
+
+// LatestLedger returns the latest ledger closed by the stellar-core server.
+func (s CoreLedgerSource) LatestLedger(ctx context.Context) (uint32, error) {
+	info, err := s.Client.Info(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "error fetching info")
+	}
+	return uint32(info.Info.Ledger.Num), nil
+}
+
+// LedgerLag is a comparison of the latest ledger of the monitored Horizon
+// server with the latest ledger of the reference.
+type LedgerLag struct {
+	// Time is the time of the comparison.
+	Time time.Time
+	// Ledger is the latest ledger of the monitored server.
+	Ledger uint32
+	// ReferenceLedger is the latest ledger of the reference.
+	ReferenceLedger uint32
+	// Lag is the number of ledgers the monitored server is behind the
+	// reference, negative if it is ahead.
+	Lag int64
+	// Exceeded is true if Lag is above Monitor.MaxLag.
+	Exceeded bool
+}
+
+// Monitor periodically compares the latest ledger ingested by a Horizon
+// server with a reference, e.g. another Horizon server or the stellar-core
+// server it ingests from, so that the operators of a Horizon server are
+// alerted when its ingestion falls behind the network.
+//
+// The latest comparison is returned by Lag, and exported as prometheus
+// gauges by the horizonclient/ledgerlagmetrics package. OnLagExceeded is called when the lag goes above MaxLag, and
+// OnLagRecovered when it goes back to MaxLag or below, once per transition.
+type Monitor struct {
+	// Horizon is the monitored server, e.g. a HorizonLedgerSource.
+	Horizon LedgerSource
+	// Reference is the server the monitored server is compared with, e.g. a
+	// HorizonLedgerSource or a CoreLedgerSource.
+	Reference LedgerSource
+	// Interval is the period of the comparisons, 5 seconds if 0.
+	Interval time.Duration
+	// MaxLag is the number of ledgers the monitored server may be behind the
+	// reference.
+	MaxLag int64
+	// OnLagExceeded is called, if set, when the lag goes above MaxLag.
+	OnLagExceeded func(LedgerLag)
+	// OnLagRecovered is called, if set, when the lag goes back to MaxLag or
+	// below.
+	OnLagRecovered func(LedgerLag)
+	// OnError is called, if set, when a comparison fails. Run carries on with
+	// the next comparison.
+	OnError func(error)
+
+	mutex sync.Mutex
+	last  LedgerLag
+
+	// now replaces time.Now in tests
+	now func() time.Time
+}
+
+// Check compares the latest ledgers of the monitored server and of the
+// reference, and calls OnLagExceeded or OnLagRecovered if the lag crossed
+// MaxLag since the previous comparison.
+func (m *Monitor) Check(ctx context.Context) (LedgerLag, error) {
+	ledger, err := m.Horizon.LatestLedger(ctx)
+	if err != nil {
+		return LedgerLag{}, errors.Wrap(err, "error fetching latest ledger")
+	}
+	referenceLedger, err := m.Reference.LatestLedger(ctx)
+	if err != nil {
+		return LedgerLag{}, errors.Wrap(err, "error fetching latest reference ledger")
+	}
+
+	now := time.Now
+	if m.now != nil {
+		now = m.now
+	}
+	lag := LedgerLag{
+		Time:            now(),
+		Ledger:          ledger,
+		ReferenceLedger: referenceLedger,
+		Lag:             int64(referenceLedger) - int64(ledger),
+	}
+	lag.Exceeded = lag.Lag > m.MaxLag
+
+	m.mutex.Lock()
+	wasExceeded := m.last.Exceeded
+	m.last = lag
+	m.mutex.Unlock()
+
+	if lag.Exceeded && !wasExceeded && m.OnLagExceeded != nil {
+		m.OnLagExceeded(lag)
+	}
+	if !lag.Exceeded && wasExceeded && m.OnLagRecovered != nil {
+		m.OnLagRecovered(lag)
+	}
+	return lag, nil
+}
+
+// Run compares the latest ledgers every Interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx); err != nil && ctx.Err() == nil && m.OnError != nil {
+			m.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Lag returns the latest successful comparison, the zero LedgerLag if there
+// was none yet.
+func (m *Monitor) Lag() LedgerLag {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.last
+}
@@ -0,0 +1,107 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+)
+
+type fakeLedgerSource struct {
+	ledger uint32
+	err    error
+}
+
+func (s *fakeLedgerSource) LatestLedger(ctx context.Context) (uint32, error) {
+	return s.ledger, s.err
+}
+
+func TestMonitorCheck(t *testing.T) {
+	horizon := &fakeLedgerSource{ledger: 100}
+	reference := &fakeLedgerSource{ledger: 102}
+	var exceeded, recovered []LedgerLag
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	monitor := &Monitor{
+		Horizon:        horizon,
+		Reference:      reference,
+		MaxLag:         5,
+		OnLagExceeded:  func(lag LedgerLag) { exceeded = append(exceeded, lag) },
+		OnLagRecovered: func(lag LedgerLag) { recovered = append(recovered, lag) },
+		now:            func() time.Time { return now },
+	}
+	assert.Equal(t, LedgerLag{}, monitor.Lag())
+
+	lag, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, LedgerLag{Time: now, Ledger: 100, ReferenceLedger: 102, Lag: 2}, lag)
+	assert.Equal(t, lag, monitor.Lag())
+
+	// the callbacks are called once per transition
+	reference.ledger = 110
+	for i := 0; i < 2; i++ {
+		lag, err = monitor.Check(context.Background())
+		require.NoError(t, err)
+		assert.True(t, lag.Exceeded)
+	}
+	require.Len(t, exceeded, 1)
+	assert.Equal(t, int64(10), exceeded[0].Lag)
+	assert.Empty(t, recovered)
+
+	horizon.ledger = 111
+	_, err = monitor.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recovered, 1)
+	assert.Equal(t, int64(-1), recovered[0].Lag)
+	assert.Len(t, exceeded, 1)
+
+	// failed comparisons keep the latest successful one
+	reference.err = errors.New("timeout")
+	_, err = monitor.Check(context.Background())
+	assert.EqualError(t, err, "error fetching latest reference ledger: timeout")
+	assert.Equal(t, uint32(111), monitor.Lag().Ledger)
+}
+
+func TestMonitorRun(t *testing.T) {
+	horizon := &fakeLedgerSource{err: errors.New("unavailable")}
+	ctx, cancel := context.WithCancel(context.Background())
+	var errs []error
+	monitor := &Monitor{
+		Horizon:   horizon,
+		Reference: &fakeLedgerSource{ledger: 10},
+		Interval:  time.Millisecond,
+		OnError: func(err error) {
+			errs = append(errs, err)
+			if len(errs) == 3 {
+				cancel()
+			}
+		},
+	}
+	require.NoError(t, monitor.Run(ctx))
+	require.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "error fetching latest ledger: unavailable")
+}
+
+func TestHorizonLedgerSource(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	hmock.On("GET", "https://localhost/").ReturnJSON(200, hProtocol.Root{HorizonSequence: 42})
+	ledger, err := HorizonLedgerSource{Client: client}.LatestLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(42), ledger)
+
+	// the request is sent with ctx
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hmock.On("GET", "https://localhost/").Return(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	})
+	_, err = HorizonLedgerSource{Client: client}.LatestLedger(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -2,7 +2,6 @@ package horizonclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -67,7 +66,7 @@ func (lr LedgerRequest) StreamLedgers(ctx context.Context, client *Client,
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
 	return client.stream(ctx, url, func(data []byte) error {
 		var ledger hProtocol.Ledger
-		err = json.Unmarshal(data, &ledger)
+		err = client.StrictDecoding.unmarshal(data, &ledger)
 		if err != nil {
 			return errors.Wrap(err, "error unmarshaling data for ledger request")
 		}
@@ -0,0 +1,33 @@
+// Package ledgerlagmetrics exports the comparisons of a
+// horizonclient.Monitor as prometheus gauges, so that the horizonclient
+// package does not depend on prometheus.
+package ledgerlagmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stellar/go/clients/horizonclient"
+)
+
+// Collectors returns the gauges of the latest comparison of the monitor, to
+// be registered in a prometheus registry: the latest ledger of the monitored
+// server, of the reference, and the lag, named
+// <namespace>_ledger_lag_monitor_*.
+func Collectors(monitor *horizonclient.Monitor, namespace string) []prometheus.Collector {
+	gauge := func(name, help string, value func(horizonclient.LedgerLag) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: namespace, Subsystem: "ledger_lag_monitor", Name: name, Help: help,
+			},
+			func() float64 { return value(monitor.Lag()) },
+		)
+	}
+	return []prometheus.Collector{
+		gauge("latest_ledger", "latest ledger of the monitored Horizon server",
+			func(lag horizonclient.LedgerLag) float64 { return float64(lag.Ledger) }),
+		gauge("reference_latest_ledger", "latest ledger of the reference server",
+			func(lag horizonclient.LedgerLag) float64 { return float64(lag.ReferenceLedger) }),
+		gauge("lag", "number of ledgers the monitored Horizon server is behind the reference",
+			func(lag horizonclient.LedgerLag) float64 { return float64(lag.Lag) }),
+	}
+}
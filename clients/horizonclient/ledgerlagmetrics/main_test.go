@@ -0,0 +1,43 @@
+package ledgerlagmetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/clients/horizonclient"
+)
+
+type ledgerSource uint32
+
+func (s ledgerSource) LatestLedger(ctx context.Context) (uint32, error) {
+	return uint32(s), nil
+}
+
+func TestCollectors(t *testing.T) {
+	monitor := &horizonclient.Monitor{
+		Horizon:   ledgerSource(100),
+		Reference: ledgerSource(103),
+	}
+	_, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	for _, collector := range Collectors(monitor, "horizon") {
+		require.NoError(t, registry.Register(collector))
+	}
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, family := range families {
+		values[family.GetName()] = family.GetMetric()[0].GetGauge().GetValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"horizon_ledger_lag_monitor_latest_ledger":           100,
+		"horizon_ledger_lag_monitor_reference_latest_ledger": 103,
+		"horizon_ledger_lag_monitor_lag":                     3,
+	}, values)
+}
@@ -147,6 +147,47 @@ type Client struct {
 
 	// clock is a Clock returning the current time.
 	clock *clock.Clock
+
+	// SubmitHook, if set, is called after every submission of a
+	// txnbuild.Transaction or txnbuild.FeeBumpTransaction, e.g. to log or
+	// measure submissions along with the annotations of the transactions.
+	SubmitHook SubmitHook
+
+	// RequestSigner, if set, is called with every request before it is sent,
+	// e.g. to authenticate the requests to a private Horizon deployment (see
+	// HMACRequestSigner and BearerTokenRequestSigner).
+	RequestSigner RequestSigner
+
+	// RateLimiter, if set, throttles the requests to stay under the rate
+	// limit of Horizon. The same RateLimiter can be set on all the clients
+	// sending requests to the same host.
+	RateLimiter *RateLimiter
+
+	// CircuitBreaker, if set, stops sending the requests of the endpoint
+	// classes failing too often, returning errors whose cause is
+	// ErrCircuitOpen instead. The same CircuitBreaker can be set on all the
+	// clients sending requests to the same host.
+	CircuitBreaker *CircuitBreaker
+
+	// RetryPolicy, if set, retries the requests failing transiently, and the
+	// connections of the streams, waiting for the delays Horizon asks for.
+	// The same RetryPolicy can be set on many clients.
+	RetryPolicy *RetryPolicy
+
+	// StreamReconnect, if set, configures the reconnection of the streams
+	// whose connection drops, from the cursor of the last record received.
+	// The streams reconnect with the defaults of StreamReconnect if nil.
+	StreamReconnect *StreamReconnect
+
+	// ResponseFormat, if set, decodes the responses of a Horizon compatible
+	// server serving the resources of Horizon in another format, e.g.
+	// JSONLinesFormat. It is HALFormat by default.
+	ResponseFormat ResponseFormat
+
+	// StrictDecoding, if set, makes the client return a *StrictDecodingError
+	// for the responses with fields unknown to the SDK, mapped ambiguously or
+	// deprecated by Horizon, instead of decoding them.
+	StrictDecoding *StrictDecoding
 }
 
 // SubmitTxOpts represents the submit transaction options
@@ -154,6 +195,27 @@ type SubmitTxOpts struct {
 	SkipMemoRequiredCheck bool
 }
 
+// SubmissionEvent describes a transaction submission reported to a
+// SubmitHook.
+type SubmissionEvent struct {
+	// Annotations are the annotations the transaction was built with (see
+	// txnbuild.Annotations). For fee bump transactions they include the
+	// annotations of the inner transaction.
+	Annotations txnbuild.Annotations
+	// OperationAnnotations are the annotations of the operations of the
+	// transaction, keyed by their index.
+	OperationAnnotations map[int]txnbuild.Annotations
+	// Duration is the time the submission took.
+	Duration time.Duration
+	// Response is the transaction returned by Horizon if Err is nil.
+	Response hProtocol.Transaction
+	// Err is the error returned by the submission, if any.
+	Err error
+}
+
+// SubmitHook is a function that is called after every transaction submission.
+type SubmitHook func(SubmissionEvent)
+
 // ClientInterface contains methods implemented by the horizon client
 type ClientInterface interface {
 	Accounts(request AccountsRequest) (hProtocol.AccountsPage, error)
@@ -297,6 +359,9 @@ type feeStatsRequest struct {
 	endpoint string
 }
 
+// rootRequest is the request of the root resource.
+type rootRequest struct{}
+
 // OfferRequest struct contains data for getting offers made by an account from a horizon server.
 // The query parameters (Order, Cursor and Limit) are optional. All or none can be set.
 type OfferRequest struct {
@@ -343,6 +343,193 @@ func TestAccountDetail(t *testing.T) {
 	}
 }
 
+func TestLoadTrustlines(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(200, `{
+  "id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+  "account_id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+  "balances": [
+    {"balance": "1.0000000", "asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+    {"balance": "1.0000000", "asset_type": "liquidity_pool_shares", "liquidity_pool_id": "abcdef"},
+    {"balance": "2.0000000", "asset_type": "credit_alphanum12", "asset_code": "LONGCODE", "asset_issuer": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+    {"balance": "9999.9999900", "asset_type": "native"}
+  ]
+}`)
+
+	assets, err := client.LoadTrustlines("GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU")
+	assert.NoError(t, err)
+	assert.Equal(t, []txnbuild.CreditAsset{
+		{Code: "USD", Issuer: "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+		{Code: "LONGCODE", Issuer: "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+	}, assets)
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(404, notFoundResponse)
+	_, err = client.LoadTrustlines("GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU")
+	assert.EqualError(t, err, "horizon error: \"Resource Missing\" - check horizon.Error.Problem for more information")
+}
+
+func TestLoadTrustlineFlags(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	issuer := "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"
+
+	account := `{
+  "id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+  "account_id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+  "balances": [
+    {"balance": "1.0000000", "asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI", "is_authorized": true, "is_authorized_to_maintain_liabilities": true, "is_clawback_enabled": true},
+    {"balance": "2.0000000", "asset_type": "credit_alphanum4", "asset_code": "EUR", "asset_issuer": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI", "is_authorized": false, "is_authorized_to_maintain_liabilities": false},
+    {"balance": "9999.9999900", "asset_type": "native"}
+  ]
+}`
+	load := func(code string) ([]txnbuild.TrustLineFlag, bool, error) {
+		hmock.On(
+			"GET",
+			"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		).ReturnString(200, account)
+		return client.LoadTrustlineFlags("GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", txnbuild.CreditAsset{Code: code, Issuer: issuer})
+	}
+
+	flags, ok, err := load("USD")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []txnbuild.TrustLineFlag{
+		txnbuild.TrustLineAuthorized,
+		txnbuild.TrustLineAuthorizedToMaintainLiabilities,
+		txnbuild.TrustLineClawbackEnabled,
+	}, flags)
+
+	flags, ok, err = load("EUR")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, flags)
+
+	_, ok, err = load("GBP")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLoadSubAccounts(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	// only the sponsored accounts pointing to the parent are sub-accounts
+	hmock.On(
+		"GET",
+		"https://localhost/accounts?sponsor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=200",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/accounts?cursor=GC&limit=200&sponsor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"}},
+  "_embedded": {"records": [
+    {"id": "GA", "account_id": "GA", "data": {"subaccount.parent": "R0NMV0dRUE1LWFFTUEY3NzZJVTMzQUg0UFpOT09XTkFXR0dLVlRCUU1JQzVJTUtVTlAzRTZOVlU="}},
+    {"id": "GB", "account_id": "GB", "data": {}},
+    {"id": "GC", "account_id": "GC", "data": {"subaccount.parent": "R0JaWE43UElSWkdOTUhHQTdNVVVVRjRHV1BZNUFZUFY2TFk0VVYyR0w2VkpHSVFSWEZETk1BREk="}}
+  ]}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/accounts?cursor=GC&limit=200&sponsor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/accounts?cursor=GD&limit=200&sponsor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"}},
+  "_embedded": {"records": [
+    {"id": "GD", "account_id": "GD", "data": {"subaccount.parent": "R0NMV0dRUE1LWFFTUEY3NzZJVTMzQUg0UFpOT09XTkFXR0dLVlRCUU1JQzVJTUtVTlAzRTZOVlU="}}
+  ]}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/accounts?cursor=GD&limit=200&sponsor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(200, `{"_embedded": {"records": []}}`)
+
+	subAccounts, err := client.LoadSubAccounts("GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GA", "GD"}, subAccounts)
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts?sponsor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=200",
+	).ReturnString(200, `{"_embedded": {"records": [{"id": "GA", "account_id": "GA", "data": {"subaccount.parent": "!"}}]}}`)
+	_, err = client.LoadSubAccounts("GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU")
+	assert.EqualError(t, err, "invalid subaccount.parent data entry of GA: illegal base64 data at input byte 0")
+}
+
+func TestLoadWindDownState(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(200, `{
+  "id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+  "account_id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+  "sequence": "42",
+  "num_sponsoring": 1,
+  "balances": [
+    {"balance": "1.0000000", "asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI", "is_authorized": false},
+    {"balance": "1.0000000", "asset_type": "liquidity_pool_shares", "liquidity_pool_id": "abcdef"},
+    {"balance": "9999.9999900", "asset_type": "native"}
+  ],
+  "signers": [
+    {"key": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI", "weight": 1, "type": "ed25519_public_key"},
+    {"key": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", "weight": 1, "type": "ed25519_public_key"}
+  ],
+  "data": {"name": "dmFsdWU="}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/offers?limit=200",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/offers?cursor=7&limit=200"}},
+  "_embedded": {"records": [
+    {"id": "7", "selling": {"asset_type": "native"}, "buying": {"asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"}, "price_r": {"n": 1, "d": 2}}
+  ]}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/offers?cursor=7&limit=200",
+	).ReturnString(200, `{"_embedded": {"records": []}}`)
+
+	state, err := client.LoadWindDownState("GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU")
+	assert.NoError(t, err)
+	assert.Equal(t, txnbuild.WindDownState{
+		AccountID: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		Sequence:  42,
+		Trustlines: []txnbuild.WindDownTrustline{{
+			Asset:   txnbuild.CreditAsset{Code: "USD", Issuer: "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+			Balance: "1.0000000",
+		}},
+		LiquidityPools: []string{"abcdef"},
+		Offers: []txnbuild.WindDownOffer{{
+			ID:      7,
+			Selling: txnbuild.NativeAsset{},
+			Buying:  txnbuild.CreditAsset{Code: "USD", Issuer: "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+			Price:   xdr.Price{N: 1, D: 2},
+		}},
+		DataNames:     []string{"name"},
+		Signers:       []string{"GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"},
+		NumSponsoring: 1,
+	}, state)
+}
+
 func TestAccountData(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
@@ -916,6 +1103,81 @@ func TestSubmitTransactionRequest(t *testing.T) {
 	assert.Equal(t, ErrAccountRequiresMemo, errors.Cause(err))
 }
 
+func TestSubmitTransactionHook(t *testing.T) {
+	hmock := httptest.NewClient()
+	var events []SubmissionEvent
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		SubmitHook: func(event SubmissionEvent) {
+			events = append(events, event)
+		},
+	}
+
+	kp := keypair.MustParseFull("SA26PHIKZM6CXDGR472SSGUQQRYXM6S437ZNHZGRM6QA4FOPLLLFRGDX")
+	sourceAccount := txnbuild.NewSimpleAccount(kp.Address(), int64(0))
+
+	tx, err := txnbuild.NewTransaction(
+		txnbuild.TransactionParams{
+			SourceAccount:        &sourceAccount,
+			IncrementSequenceNum: true,
+			Operations: []txnbuild.Operation{&txnbuild.Payment{
+				Destination: kp.Address(),
+				Amount:      "10",
+				Asset:       txnbuild.NativeAsset{},
+			}},
+			BaseFee:              txnbuild.MinBaseFee,
+			Timebounds:           txnbuild.NewTimebounds(0, 10),
+			Annotations:          txnbuild.Annotations{"correlation_id": "payment-1"},
+			OperationAnnotations: map[int]txnbuild.Annotations{0: {"invoice": "42"}},
+		},
+	)
+	assert.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp)
+	assert.NoError(t, err)
+
+	hmock.On(
+		"POST",
+		"https://localhost/transactions",
+	).ReturnString(http.StatusOK, txSuccess)
+
+	_, err = client.SubmitTransactionWithOptions(tx, SubmitTxOpts{SkipMemoRequiredCheck: true})
+	assert.NoError(t, err)
+
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, txnbuild.Annotations{"correlation_id": "payment-1"}, events[0].Annotations)
+		assert.Equal(t, map[int]txnbuild.Annotations{0: {"invoice": "42"}}, events[0].OperationAnnotations)
+		assert.NoError(t, events[0].Err)
+		assert.Equal(t, "bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca", events[0].Response.Hash)
+	}
+
+	feeBumpTx, err := txnbuild.NewFeeBumpTransaction(
+		txnbuild.FeeBumpTransactionParams{
+			Inner:       tx,
+			FeeAccount:  kp.Address(),
+			BaseFee:     txnbuild.MinBaseFee,
+			Annotations: txnbuild.Annotations{"fee": "sponsored"},
+		},
+	)
+	assert.NoError(t, err)
+	feeBumpTx, err = feeBumpTx.Sign(network.TestNetworkPassphrase, kp)
+	assert.NoError(t, err)
+
+	hmock.On(
+		"POST",
+		"https://localhost/transactions",
+	).ReturnString(http.StatusBadRequest, transactionFailure)
+
+	_, err = client.SubmitFeeBumpTransactionWithOptions(feeBumpTx, SubmitTxOpts{SkipMemoRequiredCheck: true})
+	assert.Error(t, err)
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, txnbuild.Annotations{"correlation_id": "payment-1", "fee": "sponsored"}, events[1].Annotations)
+		assert.Equal(t, map[int]txnbuild.Annotations{0: {"invoice": "42"}}, events[1].OperationAnnotations)
+		assert.Equal(t, err, events[1].Err)
+	}
+}
+
 func TestSubmitTransactionRequestMuxedAccounts(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
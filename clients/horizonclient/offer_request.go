@@ -2,7 +2,6 @@ package horizonclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -77,7 +76,7 @@ func (or OfferRequest) StreamOffers(ctx context.Context, client *Client, handler
 
 	return client.stream(ctx, url, func(data []byte) error {
 		var offer hProtocol.Offer
-		err = json.Unmarshal(data, &offer)
+		err = client.StrictDecoding.unmarshal(data, &offer)
 		if err != nil {
 			return errors.Wrap(err, "error unmarshaling data for offers request")
 		}
@@ -2,7 +2,6 @@ package horizonclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -63,7 +62,7 @@ func (obr OrderBookRequest) StreamOrderBooks(ctx context.Context, client *Client
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
 	return client.stream(ctx, url, func(data []byte) error {
 		var orderbook hProtocol.OrderBookSummary
-		err = json.Unmarshal(data, &orderbook)
+		err = client.StrictDecoding.unmarshal(data, &orderbook)
 		if err != nil {
 			return errors.Wrap(err, "error unmarshaling data for orderbook request")
 		}
@@ -0,0 +1,216 @@
+package horizonclient
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/stellar/go/amount"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	defaultPortfolioResolution = time.Hour
+	defaultPortfolioLookback   = 24 * time.Hour
+)
+
+// PortfolioOptions configure the valuation of a Portfolio.
+type PortfolioOptions struct {
+	// Quote is the asset the holdings are valued in, e.g. a stablecoin.
+	Quote base.Asset
+	// Resolution is the resolution of the trade aggregations the prices are
+	// taken from, one hour if 0. It must be one of the resolutions supported
+	// by Horizon.
+	Resolution time.Duration
+	// Lookback is how far back the last trade of an asset is looked for, one
+	// day if 0. The assets which did not trade against the quote asset
+	// within it are not priced.
+	Lookback time.Duration
+	// At is the time of the valuation, now if zero.
+	At time.Time
+}
+
+// PortfolioHolding is the total balance of an asset held by the accounts of
+// a Portfolio.
+type PortfolioHolding struct {
+	// Asset is the asset held. The shares of a liquidity pool have the type
+	// "liquidity_pool_shares" and the id of the pool in LiquidityPoolID.
+	Asset           base.Asset
+	LiquidityPoolID string
+	// Amount is the sum of the balances of the accounts.
+	Amount string
+	// Balances are the balances of the accounts holding the asset, by
+	// account id.
+	Balances map[string]string
+	// Stat are the statistics of the asset published by Horizon, nil for
+	// the native asset, liquidity pool shares and unknown assets.
+	Stat *hProtocol.AssetStat
+	// Priced is false when the asset did not trade against the quote asset
+	// within the lookback period, in which case Price and Value are empty.
+	Priced bool
+	// Price is the closing price of the asset in the quote asset.
+	Price string
+	// Value is the value of Amount in the quote asset.
+	Value string
+}
+
+// Portfolio is the consolidated balance of many accounts returned by
+// Client.Portfolio.
+type Portfolio struct {
+	Quote base.Asset
+	At    time.Time
+	// Holdings are sorted by asset, the native asset first.
+	Holdings []PortfolioHolding
+	// Value is the sum of the values of the priced holdings in the quote
+	// asset.
+	Value string
+}
+
+// Portfolio aggregates the balances of the accounts by asset, for
+// dashboards: the balances are summed, the assets are described by their
+// statistics and valued in the quote asset at the closing price of the last
+// trade aggregation in which they traded against it, in either direction.
+func (c *Client) Portfolio(ctx context.Context, accounts []string, options PortfolioOptions) (Portfolio, error) {
+	if options.Resolution == 0 {
+		options.Resolution = defaultPortfolioResolution
+	}
+	if options.Lookback == 0 {
+		options.Lookback = defaultPortfolioLookback
+	}
+	if options.At.IsZero() {
+		options.At = time.Now()
+	}
+	portfolio := Portfolio{Quote: options.Quote, At: options.At}
+
+	holdings := map[string]*PortfolioHolding{}
+	totals := map[string]int64{}
+	for _, accountID := range accounts {
+		var account hProtocol.Account
+		if err := c.sendRequestWithContext(ctx, AccountRequest{AccountID: accountID}, &account); err != nil {
+			return portfolio, errors.Wrapf(err, "could not load account %s", accountID)
+		}
+		for _, balance := range account.Balances {
+			key := portfolioKey(balance.Asset, balance.LiquidityPoolId)
+			holding, ok := holdings[key]
+			if !ok {
+				holding = &PortfolioHolding{
+					Asset:           balance.Asset,
+					LiquidityPoolID: balance.LiquidityPoolId,
+					Balances:        map[string]string{},
+				}
+				holdings[key] = holding
+			}
+			stroops, err := amount.ParseInt64(balance.Balance)
+			if err != nil {
+				return portfolio, errors.Wrapf(err, "invalid balance of %s in account %s", key, accountID)
+			}
+			if totals[key]+stroops < totals[key] {
+				return portfolio, errors.Errorf("the total balance of %s overflows", key)
+			}
+			totals[key] += stroops
+			holding.Balances[accountID] = balance.Balance
+		}
+	}
+
+	keys := make([]string, 0, len(holdings))
+	for key := range holdings {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i] == "native") != (keys[j] == "native") {
+			return keys[i] == "native"
+		}
+		return keys[i] < keys[j]
+	})
+
+	total := new(big.Rat)
+	for _, key := range keys {
+		holding := holdings[key]
+		holding.Amount = amount.StringFromInt64(totals[key])
+		if holding.LiquidityPoolID != "" {
+			portfolio.Holdings = append(portfolio.Holdings, *holding)
+			continue
+		}
+
+		if holding.Asset.Type != string(AssetTypeNative) {
+			var page hProtocol.AssetsPage
+			request := AssetRequest{ForAssetCode: holding.Asset.Code, ForAssetIssuer: holding.Asset.Issuer, Limit: 1}
+			if err := c.sendRequestWithContext(ctx, request, &page); err != nil {
+				return portfolio, errors.Wrapf(err, "could not load asset %s", key)
+			}
+			if len(page.Embedded.Records) > 0 {
+				holding.Stat = &page.Embedded.Records[0]
+			}
+		}
+
+		price, err := c.portfolioPrice(ctx, holding.Asset, options)
+		if err != nil {
+			return portfolio, errors.Wrapf(err, "could not price %s", key)
+		}
+		if price != nil {
+			value := new(big.Rat).Mul(big.NewRat(totals[key], amount.One), price)
+			total.Add(total, value)
+			holding.Priced = true
+			holding.Price = price.FloatString(7)
+			holding.Value = value.FloatString(7)
+		}
+		portfolio.Holdings = append(portfolio.Holdings, *holding)
+	}
+	portfolio.Value = total.FloatString(7)
+	return portfolio, nil
+}
+
+// portfolioPrice returns the price of an asset in the quote asset, or nil if
+// it did not trade against it within the lookback period.
+func (c *Client) portfolioPrice(ctx context.Context, asset base.Asset, options PortfolioOptions) (*big.Rat, error) {
+	quote := options.Quote
+	if portfolioKey(asset, "") == portfolioKey(quote, "") {
+		return big.NewRat(1, 1), nil
+	}
+
+	for _, inverted := range []bool{false, true} {
+		baseAsset, counterAsset := asset, quote
+		if inverted {
+			baseAsset, counterAsset = quote, asset
+		}
+		var page hProtocol.TradeAggregationsPage
+		request := TradeAggregationRequest{
+			StartTime:          options.At.Add(-options.Lookback),
+			EndTime:            options.At,
+			Resolution:         options.Resolution,
+			BaseAssetType:      AssetType(baseAsset.Type),
+			BaseAssetCode:      baseAsset.Code,
+			BaseAssetIssuer:    baseAsset.Issuer,
+			CounterAssetType:   AssetType(counterAsset.Type),
+			CounterAssetCode:   counterAsset.Code,
+			CounterAssetIssuer: counterAsset.Issuer,
+			Order:              OrderDesc,
+			Limit:              1,
+		}
+		if err := c.sendRequestWithContext(ctx, request, &page); err != nil {
+			return nil, err
+		}
+		if len(page.Embedded.Records) == 0 {
+			continue
+		}
+		closing := page.Embedded.Records[0].CloseR
+		if closing.N <= 0 || closing.D <= 0 {
+			continue
+		}
+		if inverted {
+			return big.NewRat(closing.D, closing.N), nil
+		}
+		return big.NewRat(closing.N, closing.D), nil
+	}
+	return nil, nil
+}
+
+func portfolioKey(asset base.Asset, liquidityPoolID string) string {
+	if liquidityPoolID != "" {
+		return "pool:" + liquidityPoolID
+	}
+	return assetKey(asset.Type, asset.Code, asset.Issuer)
+}
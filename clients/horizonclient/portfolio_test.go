@@ -0,0 +1,127 @@
+package horizonclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/http/httptest"
+)
+
+func mockTradeAggregations(t *testing.T, hmock *httptest.Client, at time.Time, baseAsset, counterAsset base.Asset, records ...hProtocol.TradeAggregation) {
+	endpoint, err := TradeAggregationRequest{
+		StartTime:          at.Add(-defaultPortfolioLookback),
+		EndTime:            at,
+		Resolution:         defaultPortfolioResolution,
+		BaseAssetType:      AssetType(baseAsset.Type),
+		BaseAssetCode:      baseAsset.Code,
+		BaseAssetIssuer:    baseAsset.Issuer,
+		CounterAssetType:   AssetType(counterAsset.Type),
+		CounterAssetCode:   counterAsset.Code,
+		CounterAssetIssuer: counterAsset.Issuer,
+		Order:              OrderDesc,
+		Limit:              1,
+	}.BuildURL()
+	require.NoError(t, err)
+	page := hProtocol.TradeAggregationsPage{}
+	page.Embedded.Records = records
+	hmock.On("GET", "https://localhost/"+endpoint).ReturnJSON(200, page)
+}
+
+func mockAssetStat(hmock *httptest.Client, asset base.Asset) {
+	page := hProtocol.AssetsPage{}
+	page.Embedded.Records = []hProtocol.AssetStat{{Asset: asset, NumAccounts: 7}}
+	hmock.On("GET", "https://localhost/assets?asset_code="+asset.Code+"&asset_issuer="+asset.Issuer+"&limit=1").
+		ReturnJSON(200, page)
+}
+
+func TestPortfolio(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	at := time.Unix(1600000000, 0)
+	issuer := keypair.MustRandom().Address()
+	native := base.Asset{Type: "native"}
+	usd := base.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: issuer}
+	eur := base.Asset{Type: "credit_alphanum4", Code: "EUR", Issuer: issuer}
+	gbp := base.Asset{Type: "credit_alphanum4", Code: "GBP", Issuer: issuer}
+
+	a := sweepAccount(keypair.MustRandom().Address(), "100.0000000",
+		hProtocol.Balance{Balance: "10.0000000", Asset: usd},
+	)
+	b := sweepAccount(keypair.MustRandom().Address(), "50.0000000",
+		hProtocol.Balance{Balance: "5.0000000", Asset: eur},
+		hProtocol.Balance{Balance: "1.0000000", Asset: gbp},
+		hProtocol.Balance{Balance: "3.0000000", LiquidityPoolId: "abcdef", Asset: base.Asset{Type: "liquidity_pool_shares"}},
+	)
+	mockSweepAccount(hmock, a)
+	mockSweepAccount(hmock, b)
+	mockAssetStat(hmock, usd)
+	mockAssetStat(hmock, eur)
+	mockAssetStat(hmock, gbp)
+
+	// the native asset trades with USD as the counter asset
+	mockTradeAggregations(t, hmock, at, native, usd, hProtocol.TradeAggregation{CloseR: hProtocol.TradePrice{N: 1, D: 10}})
+	// EUR only trades with USD as the base asset
+	mockTradeAggregations(t, hmock, at, eur, usd)
+	mockTradeAggregations(t, hmock, at, usd, eur, hProtocol.TradeAggregation{CloseR: hProtocol.TradePrice{N: 1, D: 2}})
+	// GBP does not trade with USD
+	mockTradeAggregations(t, hmock, at, gbp, usd)
+	mockTradeAggregations(t, hmock, at, usd, gbp)
+
+	portfolio, err := client.Portfolio(context.Background(), []string{a.AccountID, b.AccountID}, PortfolioOptions{
+		Quote: usd,
+		At:    at,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, usd, portfolio.Quote)
+	assert.Equal(t, "35.0000000", portfolio.Value)
+	require.Len(t, portfolio.Holdings, 5)
+
+	xlm := portfolio.Holdings[0]
+	assert.Equal(t, native, xlm.Asset)
+	assert.Equal(t, "150.0000000", xlm.Amount)
+	assert.Equal(t, map[string]string{a.AccountID: "100.0000000", b.AccountID: "50.0000000"}, xlm.Balances)
+	assert.Nil(t, xlm.Stat)
+	assert.True(t, xlm.Priced)
+	assert.Equal(t, "0.1000000", xlm.Price)
+	assert.Equal(t, "15.0000000", xlm.Value)
+
+	eurHolding := portfolio.Holdings[1]
+	assert.Equal(t, eur, eurHolding.Asset)
+	require.NotNil(t, eurHolding.Stat)
+	assert.Equal(t, int32(7), eurHolding.Stat.NumAccounts)
+	assert.Equal(t, "2.0000000", eurHolding.Price)
+	assert.Equal(t, "10.0000000", eurHolding.Value)
+
+	gbpHolding := portfolio.Holdings[2]
+	assert.Equal(t, gbp, gbpHolding.Asset)
+	assert.False(t, gbpHolding.Priced)
+	assert.Empty(t, gbpHolding.Value)
+
+	usdHolding := portfolio.Holdings[3]
+	assert.Equal(t, usd, usdHolding.Asset)
+	assert.Equal(t, "1.0000000", usdHolding.Price)
+	assert.Equal(t, "10.0000000", usdHolding.Value)
+
+	pool := portfolio.Holdings[4]
+	assert.Equal(t, "abcdef", pool.LiquidityPoolID)
+	assert.Equal(t, "3.0000000", pool.Amount)
+	assert.False(t, pool.Priced)
+}
+
+func TestPortfolioAccountError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	accountID := keypair.MustRandom().Address()
+	hmock.On("GET", "https://localhost/accounts/"+accountID).ReturnString(404, notFoundResponse)
+
+	_, err := client.Portfolio(context.Background(), []string{accountID}, PortfolioOptions{Quote: base.Asset{Type: "native"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not load account "+accountID)
+}
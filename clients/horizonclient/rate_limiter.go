@@ -0,0 +1,180 @@
+package horizonclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// RateLimiter is a client-side token bucket throttling the requests sent to
+// Horizon so that bursts stay under the rate limit of the server instead of
+// failing with 429 Too Many Requests. It has no limit until the first
+// response: the size and the refill rate of the bucket are derived from the
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers
+// of every response, and a 429 response stops the requests until its
+// Retry-After delay elapses.
+//
+// The rate limit of Horizon applies per IP address, so a RateLimiter can, and
+// should, be shared by all the clients sending requests to the same host
+// (see Client.RateLimiter). It is safe for concurrent use.
+type RateLimiter struct {
+	mutex sync.Mutex
+	// limit is the size of the bucket, 0 when unknown
+	limit float64
+	// tokens is the number of requests which can be sent at updated
+	tokens float64
+	// rate is the number of tokens refilled per second, 0 when unknown
+	rate    float64
+	updated time.Time
+	// resetAt is the time the bucket is full again, used when rate is unknown,
+	// and resetPeriod the time it takes to refill it entirely
+	resetAt     time.Time
+	resetPeriod time.Duration
+	// blockedUntil is the time until which a 429 response asked to wait
+	blockedUntil time.Time
+
+	// now and sleep replace time.Now and sleepContext in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter returns a RateLimiter with no limit until it observes the
+// headers of a response. The zero value is ready to use too.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) timeNow() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+// Wait blocks until a request can be sent or ctx is done, in which case
+// ctx.Err() is returned.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	sleep := sleepContext
+	if l.sleep != nil {
+		sleep = l.sleep
+	}
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token from the bucket and returns 0, or returns the time
+// to wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.timeNow()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.limit == 0 {
+		return 0
+	}
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if l.rate > 0 {
+		return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	if now.Before(l.resetAt) {
+		return l.resetAt.Sub(now)
+	}
+	// the bucket is full again but no response told so yet
+	l.tokens = l.limit - 1
+	l.updated = now
+	l.resetAt = now.Add(l.resetPeriod)
+	return 0
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.updated).Seconds(); elapsed > 0 {
+		l.tokens = math.Min(l.limit, l.tokens+elapsed*l.rate)
+		l.updated = now
+	}
+}
+
+// Observe updates the limiter with the headers of a response of the server.
+// It is called by the clients the limiter is set on.
+func (l *RateLimiter) Observe(resp *http.Response) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.timeNow()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+		if err != nil || retryAfter <= 0 {
+			retryAfter = 1
+		}
+		if until := now.Add(time.Duration(retryAfter * float64(time.Second))); until.After(l.blockedUntil) {
+			l.blockedUntil = until
+		}
+	}
+
+	limit, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Limit"), 64)
+	if err != nil || limit <= 0 {
+		return
+	}
+	remaining, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Remaining"), 64)
+	if err != nil || remaining < 0 {
+		return
+	}
+	reset, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Reset"), 64)
+	if err != nil || reset < 0 {
+		return
+	}
+
+	l.limit = math.Max(limit, remaining)
+	l.tokens = remaining
+	l.updated = now
+	l.resetPeriod = time.Duration(reset * float64(time.Second))
+	l.resetAt = now.Add(l.resetPeriod)
+	// X-RateLimit-Reset is the time it takes to refill the bucket entirely,
+	// which tells the rate as long as the bucket is not full already
+	if reset > 0 && remaining < limit {
+		l.rate = (limit - remaining) / reset
+	}
+}
+
+// waitRateLimiter waits for the RateLimiter of the client, if any.
+func (c *Client) waitRateLimiter(ctx context.Context) error {
+	if c.RateLimiter == nil {
+		return nil
+	}
+	return errors.Wrap(c.RateLimiter.Wait(ctx), "error waiting for rate limiter")
+}
+
+func (c *Client) observeRateLimiter(resp *http.Response) {
+	if c.RateLimiter != nil {
+		c.RateLimiter.Observe(resp)
+	}
+}
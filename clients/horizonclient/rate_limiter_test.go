@@ -0,0 +1,125 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimiter returns a RateLimiter whose clock only moves when it
+// sleeps, and the list of its sleeps.
+func newTestRateLimiter() (*RateLimiter, *[]time.Duration) {
+	now := time.Unix(1600000000, 0)
+	var sleeps []time.Duration
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+		return nil
+	}
+	return limiter, &sleeps
+}
+
+func rateLimitResponse(status int, limit, remaining, reset string) *http.Response {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", limit)
+	header.Set("X-RateLimit-Remaining", remaining)
+	header.Set("X-RateLimit-Reset", reset)
+	return &http.Response{StatusCode: status, Header: header}
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter, sleeps := newTestRateLimiter()
+
+	// there is no limit until a response is observed
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Wait(ctx))
+	}
+	assert.Empty(t, *sleeps)
+
+	// 8 requests are refilled in 80 seconds
+	limiter.Observe(rateLimitResponse(http.StatusOK, "10", "2", "80"))
+	require.NoError(t, limiter.Wait(ctx))
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Empty(t, *sleeps)
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Equal(t, []time.Duration{10 * time.Second}, *sleeps)
+
+	// a full bucket does not tell the rate, the previous one is kept
+	limiter.Observe(rateLimitResponse(http.StatusOK, "10", "10", "0"))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Wait(ctx))
+	}
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Equal(t, []time.Duration{10 * time.Second, 10 * time.Second}, *sleeps)
+
+	// 429 responses stop the requests for Retry-After seconds
+	response := rateLimitResponse(http.StatusTooManyRequests, "10", "0", "100")
+	response.Header.Set("Retry-After", "30")
+	limiter.Observe(response)
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Equal(t, []time.Duration{10 * time.Second, 10 * time.Second, 30 * time.Second}, *sleeps)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	limiter.Observe(rateLimitResponse(http.StatusOK, "10", "0", "100"))
+	assert.Equal(t, context.Canceled, limiter.Wait(cancelled))
+}
+
+func TestRateLimiterUnknownRate(t *testing.T) {
+	ctx := context.Background()
+	limiter, sleeps := newTestRateLimiter()
+
+	// the bucket is full, the requests wait for the reset once it is empty
+	limiter.Observe(rateLimitResponse(http.StatusOK, "2", "2", "60"))
+	require.NoError(t, limiter.Wait(ctx))
+	require.NoError(t, limiter.Wait(ctx))
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Equal(t, []time.Duration{60 * time.Second}, *sleeps)
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Len(t, *sleeps, 1)
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Equal(t, []time.Duration{60 * time.Second, 60 * time.Second}, *sleeps)
+
+	// responses without the headers are ignored
+	limiter.Observe(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	require.NoError(t, limiter.Wait(ctx))
+	require.NoError(t, limiter.Wait(ctx))
+	assert.Len(t, *sleeps, 3)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	hmock := httptest.NewClient()
+	limiter, sleeps := newTestRateLimiter()
+	// the limiter is shared by the clients of the same host
+	clients := []*Client{
+		{HorizonURL: "https://localhost/", HTTP: hmock, RateLimiter: limiter},
+		{HorizonURL: "https://localhost/", HTTP: hmock, RateLimiter: limiter},
+	}
+
+	hmock.On("GET", "https://localhost/ledgers/69859").Return(func(req *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(200, ledgerResponse)
+		response.Header.Set("X-RateLimit-Limit", "3600")
+		response.Header.Set("X-RateLimit-Remaining", "0")
+		response.Header.Set("X-RateLimit-Reset", "3600")
+		return response, nil
+	})
+
+	_, err := clients[0].LedgerDetail(69859)
+	require.NoError(t, err)
+	assert.Empty(t, *sleeps)
+	_, err = clients[1].LedgerDetail(69859)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second}, *sleeps)
+}
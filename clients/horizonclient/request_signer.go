@@ -0,0 +1,98 @@
+package horizonclient
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// RequestSigner is called with every request sent to Horizon, including
+// streaming requests, after the client headers are set and before the request
+// is sent. It can add headers authenticating the request, e.g. for private
+// Horizon deployments behind an authentication proxy, without a custom
+// http.RoundTripper. A non-nil error aborts the request.
+type RequestSigner func(req *http.Request) error
+
+const (
+	// HMACSignatureTimestampHeader is the header set by HMACRequestSigner
+	// with the unix time, in seconds, at which the request was signed.
+	HMACSignatureTimestampHeader = "X-Signature-Timestamp"
+	// hmacAuthorizationScheme is the scheme of the Authorization header set
+	// by HMACRequestSigner.
+	hmacAuthorizationScheme = "HMAC-SHA256"
+)
+
+// HMACRequestSigner returns a RequestSigner which authenticates the requests
+// with a HMAC-SHA256 of the request, keyed by secret. The signature covers,
+// separated by newlines, the method, the request URI (path and query), the
+// HMACSignatureTimestampHeader header and the hex encoded SHA-256 of the body:
+//
+//	Authorization: HMAC-SHA256 Credential=<keyID>, Signature=<hex signature>
+//
+// The proxy in front of Horizon is expected to compute the same signature and
+// to reject requests with a stale timestamp.
+func HMACRequestSigner(keyID string, secret []byte) RequestSigner {
+	return func(req *http.Request) error {
+		var body []byte
+		if req.Body != nil && req.Body != http.NoBody {
+			var err error
+			body, err = ioutil.ReadAll(req.Body)
+			if err != nil {
+				return errors.Wrap(err, "error reading request body")
+			}
+			req.Body.Close()
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		signature := HMACRequestSignature(secret, req.Method, req.URL.RequestURI(), timestamp, body)
+		req.Header.Set(HMACSignatureTimestampHeader, timestamp)
+		req.Header.Set("Authorization", fmt.Sprintf(
+			"%s Credential=%s, Signature=%s", hmacAuthorizationScheme, keyID, signature,
+		))
+		return nil
+	}
+}
+
+// HMACRequestSignature returns the hex encoded signature HMACRequestSigner
+// sets for a request, e.g. to verify requests on the server side.
+func HMACRequestSignature(secret []byte, method, requestURI, timestamp string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s", method, requestURI, timestamp, hex.EncodeToString(bodyHash[:]))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BearerTokenRequestSigner returns a RequestSigner which attaches the token
+// returned by token, e.g. a JWT, to the requests as a bearer token. token is
+// called for every request so that it can refresh tokens about to expire.
+func BearerTokenRequestSigner(token func() (string, error)) RequestSigner {
+	return func(req *http.Request) error {
+		t, err := token()
+		if err != nil {
+			return errors.Wrap(err, "error getting token")
+		}
+		req.Header.Set("Authorization", "Bearer "+t)
+		return nil
+	}
+}
+
+// signRequest calls the RequestSigner of the client, if any.
+func (c *Client) signRequest(req *http.Request) error {
+	if c.RequestSigner == nil {
+		return nil
+	}
+	return errors.Wrap(c.RequestSigner(req), "error signing request")
+}
@@ -0,0 +1,91 @@
+package horizonclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACRequestSigner(t *testing.T) {
+	hmock := httptest.NewClient()
+	secret := []byte("secret")
+	client := &Client{
+		HorizonURL:    "https://localhost/",
+		HTTP:          hmock,
+		RequestSigner: HMACRequestSigner("key-1", secret),
+	}
+
+	hmock.On("GET", "https://localhost/ledgers/69859").Return(func(req *http.Request) (*http.Response, error) {
+		timestamp := req.Header.Get(HMACSignatureTimestampHeader)
+		assert.NotEmpty(t, timestamp)
+		assert.Equal(
+			t,
+			"HMAC-SHA256 Credential=key-1, Signature="+HMACRequestSignature(secret, "GET", "/ledgers/69859", timestamp, nil),
+			req.Header.Get("Authorization"),
+		)
+		return httpmock.NewStringResponse(200, ledgerResponse), nil
+	})
+	ledger, err := client.LedgerDetail(69859)
+	require.NoError(t, err)
+	assert.Equal(t, int32(69859), ledger.Sequence)
+
+	// the body of the request is signed and still sent
+	hmock.On("POST", "https://localhost/transactions").Return(func(req *http.Request) (*http.Response, error) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "tx=AAAA", string(body))
+		timestamp := req.Header.Get(HMACSignatureTimestampHeader)
+		assert.True(t, strings.HasSuffix(
+			req.Header.Get("Authorization"),
+			"Signature="+HMACRequestSignature(secret, "POST", "/transactions", timestamp, body),
+		))
+		return httpmock.NewStringResponse(200, txSuccess), nil
+	})
+	_, err = client.SubmitTransactionXDR("AAAA")
+	assert.NoError(t, err)
+}
+
+func TestBearerTokenRequestSigner(t *testing.T) {
+	hmock := httptest.NewClient()
+	tokens := []string{"first", "second"}
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		RequestSigner: BearerTokenRequestSigner(func() (string, error) {
+			if len(tokens) == 0 {
+				return "", errors.New("token expired")
+			}
+			token := tokens[0]
+			tokens = tokens[1:]
+			return token, nil
+		}),
+	}
+
+	var authorizations []string
+	hmock.On("GET", "https://localhost/ledgers/69859").Return(func(req *http.Request) (*http.Response, error) {
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+		return httpmock.NewStringResponse(200, ledgerResponse), nil
+	})
+	for i := 0; i < 2; i++ {
+		_, err := client.LedgerDetail(69859)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"Bearer first", "Bearer second"}, authorizations)
+
+	_, err := client.LedgerDetail(69859)
+	assert.EqualError(t, err, "error signing request: error getting token: token expired")
+	assert.Len(t, authorizations, 2)
+
+	// streams are signed too
+	err = client.StreamLedgers(context.Background(), LedgerRequest{}, func(ledger hProtocol.Ledger) {})
+	assert.EqualError(t, err, "error signing request: error getting token: token expired")
+}
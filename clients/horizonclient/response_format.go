@@ -0,0 +1,331 @@
+package horizonclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/manucorporat/sse"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/hal"
+)
+
+// ResponseFormat decodes the responses of a server serving the resources of
+// Horizon, so that the client can consume Horizon compatible servers which
+// serve them in another format than Horizon, e.g. JSON lines, behind the
+// same typed API. The format of a Client is set by Client.ResponseFormat,
+// HALFormat by default.
+//
+// The error responses are always decoded as JSON problems.
+type ResponseFormat interface {
+	// MediaType is the media type requested, in the Accept header, by the
+	// requests which are not streaming.
+	MediaType() string
+	// StreamMediaType is the media type requested, in the Accept header, by
+	// the streaming requests.
+	StreamMediaType() string
+	// Decode decodes the body of a successful response into object, a
+	// pointer to a resource or to a page of resources of the
+	// protocols/horizon package.
+	Decode(resp *http.Response, object interface{}) error
+	// ReadStream reads the records of a successful streaming response from
+	// body until its end or until ctx is done, calling handler with the
+	// JSON encoding of every record, as served by Horizon, and the cursor to
+	// resume the stream after it.
+	ReadStream(ctx context.Context, body io.Reader, handler func(record []byte, cursor string) error) error
+}
+
+// HALFormat is the format of the responses of Horizon: the resources and
+// pages are HAL JSON documents, and the streams are server-sent events.
+type HALFormat struct{}
+
+// MediaType returns the media type of HAL JSON documents.
+func (HALFormat) MediaType() string {
+	return "application/hal+json"
+}
+
+// StreamMediaType returns the media type of server-sent events.
+func (HALFormat) StreamMediaType() string {
+	return "text/event-stream"
+}
+
+// Decode decodes a HAL JSON document into object.
+func (HALFormat) Decode(resp *http.Response, object interface{}) error {
+	return json.NewDecoder(resp.Body).Decode(object)
+}
+
+// ReadStream reads the server-sent events of body, the id of the events
+// being their cursor.
+func (HALFormat) ReadStream(ctx context.Context, body io.Reader, handler func(record []byte, cursor string) error) error {
+	reader := bufio.NewReader(body)
+
+	// Read events one by one. Break this loop when there is no more data to be
+	// read from body (io.EOF).
+	for {
+		// Read until empty line = event delimiter. The perfect solution would be to read
+		// as many bytes as possible and forward them to sse.Decode. However this
+		// requires much more complicated code.
+		// We could also write our own `sse` package that works fine with streams directly
+		// (github.com/manucorporat/sse is just using io/ioutils.ReadAll).
+		var buffer bytes.Buffer
+		nonEmptylinesRead := 0
+		for {
+			// Check if ctx is not cancelled
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				// Continue
+			}
+
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				if err == io.EOF || err == io.ErrUnexpectedEOF {
+					// We catch EOF errors to handle two possible situations:
+					// - The last line before closing the stream was not empty. This should never
+					//   happen in Horizon as it always sends an empty line after each event.
+					// - The stream was closed by the server/proxy because the connection was idle.
+					//
+					// In the former case, that (again) should never happen in Horizon, we need to
+					// check if there are any events we need to decode. We do this in the `if`
+					// statement below just in case if Horizon behaviour changes in a future.
+					//
+					// From spec:
+					// > Once the end of the file is reached, the user agent must dispatch the
+					// > event one final time, as defined below.
+					if nonEmptylinesRead == 0 {
+						return nil
+					}
+				} else {
+					return errors.Wrap(err, "error reading line")
+				}
+			}
+			buffer.WriteString(line)
+
+			if strings.TrimRight(line, "\n\r") == "" {
+				break
+			}
+
+			nonEmptylinesRead++
+		}
+
+		events, err := sse.Decode(strings.NewReader(buffer.String()))
+		if err != nil {
+			return errors.Wrap(err, "error decoding event")
+		}
+
+		// Right now len(events) should always be 1. This loop will be helpful after writing
+		// new SSE decoder that can handle io.Reader without using ioutils.ReadAll().
+		for _, event := range events {
+			if event.Event != "message" {
+				continue
+			}
+
+			switch data := event.Data.(type) {
+			case string:
+				err = handler([]byte(data), event.Id)
+			case []byte:
+				err = handler(data, event.Id)
+			default:
+				err = errors.New("invalid event.Data type")
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// JSONLinesFormat is the format of the servers serving the resources of
+// Horizon as JSON lines (https://jsonlines.org), e.g. firehose endpoints:
+//
+//   - a resource is a JSON object, as served by Horizon
+//   - a page is a JSON object per line, the records of the page, its links
+//     being given by the Link header of the response (RFC 8288), e.g.
+//     `<https://example.com/ledgers?cursor=12>; rel="next"`
+//   - a stream is a JSON object per line, whose paging_token is the cursor
+//     to resume the stream after it
+type JSONLinesFormat struct{}
+
+// MediaType returns the media type of JSON lines.
+func (JSONLinesFormat) MediaType() string {
+	return "application/x-ndjson"
+}
+
+// StreamMediaType returns the media type of JSON lines.
+func (JSONLinesFormat) StreamMediaType() string {
+	return "application/x-ndjson"
+}
+
+// Decode decodes a page from the JSON lines of its records, or a resource
+// from its JSON object.
+func (JSONLinesFormat) Decode(resp *http.Response, object interface{}) error {
+	if !isPage(reflect.ValueOf(object)) {
+		return json.NewDecoder(resp.Body).Decode(object)
+	}
+
+	// the page is decoded from the HAL JSON document of the records, so that
+	// pages with custom decoders, e.g. operations.OperationsPage, decode the
+	// records as they do for Horizon
+	var page struct {
+		Links    hal.Links `json:"_links"`
+		Embedded struct {
+			Records []json.RawMessage `json:"records"`
+		} `json:"_embedded"`
+	}
+	page.Links = linksFromHeader(resp.Header)
+	page.Embedded.Records = []json.RawMessage{}
+	err := readJSONLines(context.Background(), resp.Body, func(line []byte) error {
+		page.Embedded.Records = append(page.Embedded.Records, line)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		return errors.Wrap(err, "error encoding page")
+	}
+	return json.Unmarshal(data, object)
+}
+
+// ReadStream reads the JSON lines of body, the paging_token of the records
+// being their cursor.
+func (JSONLinesFormat) ReadStream(ctx context.Context, body io.Reader, handler func(record []byte, cursor string) error) error {
+	return readJSONLines(ctx, body, func(line []byte) error {
+		var record struct {
+			PagingToken string `json:"paging_token"`
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return errors.Wrap(err, "error decoding record")
+		}
+		return handler(line, record.PagingToken)
+	})
+}
+
+// readJSONLines calls handler with every non empty line of body, until its
+// end or until ctx is done.
+func readJSONLines(ctx context.Context, body io.Reader, handler func(line []byte) error) error {
+	reader := bufio.NewReader(body)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return errors.Wrap(err, "error reading line")
+		}
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if handlerErr := handler(trimmed); handlerErr != nil {
+				return handlerErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// isPage returns true if v is, or points to, a page of the protocols/horizon
+// package, i.e. a struct with an Embedded.Records slice.
+func isPage(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	embedded := v.FieldByName("Embedded")
+	if !embedded.IsValid() || embedded.Kind() != reflect.Struct {
+		return false
+	}
+	records := embedded.FieldByName("Records")
+	return records.IsValid() && records.Kind() == reflect.Slice
+}
+
+// linksFromHeader returns the self, next and prev links of the Link header.
+// The header is split on the commas and semicolons outside of the <...> of
+// the URIs and of quoted strings, which may contain both.
+func linksFromHeader(header http.Header) hal.Links {
+	var links hal.Links
+	for _, value := range header.Values("Link") {
+		for _, link := range splitLinkHeader(value, ',') {
+			parts := splitLinkHeader(link, ';')
+			href := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(href, "<") || !strings.HasSuffix(href, ">") {
+				continue
+			}
+			href = href[1 : len(href)-1]
+			for _, param := range parts[1:] {
+				name, value := splitParam(param)
+				if name != "rel" {
+					continue
+				}
+				for _, rel := range strings.Fields(value) {
+					switch rel {
+					case "self":
+						links.Self = hal.NewLink(href)
+					case "next":
+						links.Next = hal.NewLink(href)
+					case "prev":
+						links.Prev = hal.NewLink(href)
+					}
+				}
+			}
+		}
+	}
+	return links
+}
+
+// splitLinkHeader splits s on sep, except within a <...> URI or a quoted
+// string.
+func splitLinkHeader(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	inURI, inQuotes := false, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inQuotes:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inQuotes = false
+			}
+		case inURI:
+			inURI = c != '>'
+		case c == '"':
+			inQuotes = true
+		case c == '<':
+			inURI = true
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// splitParam splits a link parameter, e.g. `rel="next"`, into its name and
+// unquoted value.
+func splitParam(param string) (string, string) {
+	i := strings.Index(param, "=")
+	if i < 0 {
+		return strings.ToLower(strings.TrimSpace(param)), ""
+	}
+	name := strings.ToLower(strings.TrimSpace(param[:i]))
+	value := strings.Trim(strings.TrimSpace(param[i+1:]), `"`)
+	return name, value
+}
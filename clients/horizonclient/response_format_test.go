@@ -0,0 +1,155 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/http/httptest"
+)
+
+func TestJSONLinesFormatPages(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL:     "https://localhost/",
+		HTTP:           hmock,
+		ResponseFormat: JSONLinesFormat{},
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/ledgers?limit=2",
+	).Return(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "application/x-ndjson", req.Header.Get("Accept"))
+		resp := httpmock.NewStringResponse(200, `{"sequence":2,"paging_token":"8589934592"}
+
+{"sequence":3,"paging_token":"12884901888"}
+`)
+		resp.Header.Set("Link", `<https://localhost/ledgers?cursor=12884901888&limit=2>; rel="next", <https://localhost/ledgers?cursor=8589934592&limit=2&order=desc>; rel="prev"`)
+		return resp, nil
+	})
+
+	ledgers, err := client.Ledgers(LedgerRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, ledgers.Embedded.Records, 2)
+	assert.Equal(t, int32(2), ledgers.Embedded.Records[0].Sequence)
+	assert.Equal(t, int32(3), ledgers.Embedded.Records[1].Sequence)
+	assert.Equal(t, "https://localhost/ledgers?cursor=12884901888&limit=2", ledgers.Links.Next.Href)
+	assert.Equal(t, "https://localhost/ledgers?cursor=8589934592&limit=2&order=desc", ledgers.Links.Prev.Href)
+
+	hmock.On(
+		"GET",
+		"https://localhost/ledgers?cursor=12884901888&limit=2",
+	).ReturnString(200, "")
+
+	nextPage, err := client.NextLedgersPage(ledgers)
+	require.NoError(t, err)
+	assert.Empty(t, nextPage.Embedded.Records)
+	assert.Empty(t, nextPage.Links.Next.Href)
+
+	// the records of operations pages are decoded by their type
+	hmock.On(
+		"GET",
+		"https://localhost/operations",
+	).ReturnString(200, `{"id":"1","type":"bump_sequence","type_i":11,"bump_to":"10"}
+{"id":"2","type":"inflation","type_i":9}
+`)
+
+	ops, err := client.Operations(OperationRequest{})
+	require.NoError(t, err)
+	require.Len(t, ops.Embedded.Records, 2)
+	assert.Equal(t, "10", ops.Embedded.Records[0].(operations.BumpSequence).BumpTo)
+	assert.IsType(t, operations.Inflation{}, ops.Embedded.Records[1])
+}
+
+func TestJSONLinesFormatResource(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL:     "https://localhost/",
+		HTTP:           hmock,
+		ResponseFormat: JSONLinesFormat{},
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/ledgers/3",
+	).ReturnString(200, `{"sequence":3,"paging_token":"12884901888"}
+`)
+
+	ledger, err := client.LedgerDetail(3)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), ledger.Sequence)
+
+	// errors are JSON problems whatever the format
+	hmock.On(
+		"GET",
+		"https://localhost/ledgers/4",
+	).ReturnString(404, notFoundResponse)
+
+	_, err = client.LedgerDetail(4)
+	require.Error(t, err)
+	horizonError, ok := err.(*Error)
+	require.True(t, ok)
+	assert.Equal(t, "Resource Missing", horizonError.Problem.Title)
+}
+
+func TestJSONLinesFormatStream(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL:     "https://localhost/",
+		HTTP:           hmock,
+		ResponseFormat: JSONLinesFormat{},
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/ledgers?cursor=now",
+	).Return(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "application/x-ndjson", req.Header.Get("Accept"))
+		return httpmock.NewStringResponse(200, `{"sequence":2,"paging_token":"8589934592"}
+`), nil
+	})
+	// the stream is resumed after the last record once the response ends
+	hmock.On(
+		"GET",
+		"https://localhost/ledgers?cursor=8589934592",
+	).ReturnString(200, `{"sequence":3,"paging_token":"12884901888"}
+{"sequence":4,"paging_token":"17179869184"}
+`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var sequences []int32
+	err := client.StreamLedgers(ctx, LedgerRequest{}, func(ledger hProtocol.Ledger) {
+		sequences = append(sequences, ledger.Sequence)
+		if ledger.Sequence == 3 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{2, 3}, sequences)
+}
+
+func TestLinksFromHeader(t *testing.T) {
+	header := http.Header{}
+	header.Add("Link", `<https://localhost/a>; rel="self next"`)
+	header.Add("Link", `<https://localhost/b>; title="previous"; rel=prev, invalid; rel="next"`)
+	links := linksFromHeader(header)
+	assert.Equal(t, "https://localhost/a", links.Self.Href)
+	assert.Equal(t, "https://localhost/a", links.Next.Href)
+	assert.Equal(t, "https://localhost/b", links.Prev.Href)
+
+	assert.Empty(t, linksFromHeader(http.Header{}).Next.Href)
+
+	// commas and semicolons of the URIs and quoted strings are not separators
+	header = http.Header{}
+	header.Set("Link", `<https://localhost/trades?base=a,b;c>; title="first, \"second\"; third"; rel="next", <https://localhost/c>; rel=prev`)
+	links = linksFromHeader(header)
+	assert.Equal(t, "https://localhost/trades?base=a,b;c", links.Next.Href)
+	assert.Equal(t, "https://localhost/c", links.Prev.Href)
+}
@@ -0,0 +1,217 @@
+package horizonclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// RetryPolicy retries the requests failing transiently, with an exponential
+// backoff randomized by a jitter so that the clients failing at the same
+// time do not retry at the same time.
+//
+// The delay before a retry is taken from the Retry-After header of the
+// response if any, or from its X-RateLimit-Reset header when no request is
+// remaining in the rate limit window, so that rate limited requests are not
+// retried before Horizon accepts them again. Requests whose circuit is open
+// (see CircuitBreaker) are not retried, and the retries of the streams only
+// retry their connection, a stream failing once connected is reconnected as
+// configured by StreamReconnect.
+//
+// A RetryPolicy is safe for concurrent use and can be shared by many clients
+// (see Client.RetryPolicy).
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a request, 3 if 0.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry, 500 milliseconds
+	// if 0. It is multiplied by Multiplier before every other retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries computed from
+	// InitialBackoff, 30 seconds if 0.
+	MaxBackoff time.Duration
+	// Multiplier is the factor of the delay between two retries, 2 if 0.
+	Multiplier float64
+	// Jitter is the fraction of the delay randomized, between 0 and 1, 0.5
+	// if 0: with 0.5, the delays are between half the backoff and the
+	// backoff.
+	Jitter float64
+	// MaxRetryAfter is the maximum delay honored from the headers of a
+	// response, 1 minute if 0. The request is not retried if Horizon asks to
+	// wait longer.
+	MaxRetryAfter time.Duration
+	// Retryable returns true if a request can be retried after the response,
+	// or the error sending it, DefaultRetryable if nil.
+	Retryable func(resp *http.Response, err error) bool
+	// OnRetry is called, if set, before every retry, e.g. to log or measure
+	// retries, with the number of the retry, starting from 1, the delay
+	// before it and the error of the previous attempt.
+	OnRetry func(req *http.Request, retry int, delay time.Duration, err error)
+
+	mutex  sync.Mutex
+	random *rand.Rand
+
+	// sleep replaces sleepContext in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// DefaultRetryable returns true for the errors sending a request, e.g. a
+// connection reset or a timeout, and for the 429, 502, 503 and 504 responses.
+func DefaultRetryable(resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (p *RetryPolicy) maxRetries() int {
+	if p.MaxRetries <= 0 {
+		return 3
+	}
+	return p.MaxRetries
+}
+
+func (p *RetryPolicy) initialBackoff() time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 500 * time.Millisecond
+	}
+	return p.InitialBackoff
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return 30 * time.Second
+	}
+	return p.MaxBackoff
+}
+
+func (p *RetryPolicy) multiplier() float64 {
+	if p.Multiplier <= 0 {
+		return 2
+	}
+	return p.Multiplier
+}
+
+func (p *RetryPolicy) jitter() float64 {
+	if p.Jitter <= 0 {
+		return 0.5
+	}
+	return math.Min(p.Jitter, 1)
+}
+
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter <= 0 {
+		return time.Minute
+	}
+	return p.MaxRetryAfter
+}
+
+func (p *RetryPolicy) float64() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.random == nil {
+		p.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return p.random.Float64()
+}
+
+// Backoff returns the delay before the retry of the given number, starting
+// from 1, of a request which failed without telling when to retry it.
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := float64(p.initialBackoff()) * math.Pow(p.multiplier(), float64(retry-1))
+	backoff = math.Min(backoff, float64(p.maxBackoff()))
+	return time.Duration(backoff * (1 - p.jitter()*p.float64()))
+}
+
+// retryAfter returns the delay Horizon asks to wait before retrying, from the
+// Retry-After header, in seconds or as a date, or from the X-RateLimit-Reset
+// header when no request is remaining, and false if it does not tell.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			if date.Before(now) {
+				return 0, true
+			}
+			return date.Sub(now), true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if seconds, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Reset"), 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+	}
+	return 0, false
+}
+
+// delay returns the delay before the retry of the given number, starting
+// from 1, of a request, and false if it must not be retried.
+func (p *RetryPolicy) delay(ctx context.Context, req *http.Request, retry int, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || err == nil || retry > p.maxRetries() || ctx.Err() != nil {
+		return 0, false
+	}
+	if errors.Cause(err) == ErrCircuitOpen {
+		return 0, false
+	}
+	// the body of the request cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryable
+	}
+	if !retryable(resp, err) {
+		return 0, false
+	}
+
+	if resp != nil {
+		if delay, ok := retryAfter(resp, time.Now()); ok {
+			if delay > p.maxRetryAfter() {
+				return 0, false
+			}
+			// the clients told to wait the same delay do not all retry at
+			// once
+			return delay + time.Duration(p.jitter()*p.float64()*float64(p.initialBackoff())), true
+		}
+	}
+	return p.Backoff(retry), true
+}
+
+// wait calls OnRetry and waits for delay, returning ctx.Err() if ctx is done
+// before.
+func (p *RetryPolicy) wait(ctx context.Context, req *http.Request, retry int, delay time.Duration, err error) error {
+	if p.OnRetry != nil {
+		p.OnRetry(req, retry, delay, err)
+	}
+	sleep := sleepContext
+	if p.sleep != nil {
+		sleep = p.sleep
+	}
+	return sleep(ctx, delay)
+}
+
+// rewindBody restores the body of a request to be sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return errors.Wrap(err, "error rewinding request body")
+	}
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,236 @@
+package horizonclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+)
+
+// newTestRetryPolicy returns the policy recording its sleeps instead of
+// sleeping.
+func newTestRetryPolicy(policy *RetryPolicy) (*RetryPolicy, *[]time.Duration) {
+	var sleeps []time.Duration
+	policy.sleep = func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return policy, &sleeps
+}
+
+// sequenceResponder returns the responses of responders in turn, repeating
+// the last one.
+func sequenceResponder(responders ...httpmock.Responder) httpmock.Responder {
+	i := 0
+	return func(req *http.Request) (*http.Response, error) {
+		responder := responders[i]
+		if i < len(responders)-1 {
+			i++
+		}
+		return responder(req)
+	}
+}
+
+func errorResponder(err error) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		return nil, err
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	for _, c := range []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 500 * time.Millisecond, time.Second},
+		{2, time.Second, 2 * time.Second},
+		{3, 2 * time.Second, 4 * time.Second},
+		{4, 4 * time.Second, 8 * time.Second},
+		{5, 5 * time.Second, 10 * time.Second},
+		{10, 5 * time.Second, 10 * time.Second},
+	} {
+		for i := 0; i < 20; i++ {
+			backoff := policy.Backoff(c.retry)
+			assert.True(t, backoff >= c.min && backoff <= c.max, "retry %d backoff %v", c.retry, backoff)
+		}
+	}
+
+	policy = &RetryPolicy{InitialBackoff: time.Second, Jitter: 1e-9}
+	assert.InDelta(t, float64(4*time.Second), float64(policy.Backoff(3)), float64(time.Microsecond))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	response := func(headers map[string]string) *http.Response {
+		header := http.Header{}
+		for key, value := range headers {
+			header.Set(key, value)
+		}
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}
+	}
+
+	delay, ok := retryAfter(response(map[string]string{"Retry-After": "3"}), now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	delay, ok = retryAfter(response(map[string]string{"Retry-After": "Tue, 01 Jun 2021 12:00:10 GMT"}), now)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, delay)
+
+	delay, ok = retryAfter(response(map[string]string{"Retry-After": "Tue, 01 Jun 2021 11:00:00 GMT"}), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	delay, ok = retryAfter(response(map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "42"}), now)
+	assert.True(t, ok)
+	assert.Equal(t, 42*time.Second, delay)
+
+	// requests are remaining, the reset does not tell when to retry
+	_, ok = retryAfter(response(map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Reset": "42"}), now)
+	assert.False(t, ok)
+	_, ok = retryAfter(response(map[string]string{"Retry-After": "soon"}), now)
+	assert.False(t, ok)
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	hmock := httptest.NewClient()
+	policy, sleeps := newTestRetryPolicy(&RetryPolicy{InitialBackoff: time.Second})
+	var retries []int
+	policy.OnRetry = func(req *http.Request, retry int, delay time.Duration, err error) {
+		retries = append(retries, retry)
+	}
+	client := &Client{
+		HorizonURL:  "https://localhost/",
+		HTTP:        hmock,
+		RetryPolicy: policy,
+	}
+
+	rateLimited := httpmock.NewStringResponse(http.StatusTooManyRequests, "{}")
+	rateLimited.Header.Set("Retry-After", "5")
+	hmock.On("GET", "https://localhost/ledgers/69859").Return(sequenceResponder(
+		httpmock.ResponderFromResponse(rateLimited),
+		errorResponder(errors.New("connection reset")),
+		httpmock.NewStringResponder(http.StatusOK, ledgerResponse),
+	))
+	ledger, err := client.LedgerDetail(69859)
+	require.NoError(t, err)
+	assert.Equal(t, int32(69859), ledger.Sequence)
+	assert.Equal(t, []int{1, 2}, retries)
+	require.Len(t, *sleeps, 2)
+	// the delay asked for, with a jitter
+	assert.True(t, (*sleeps)[0] >= 5*time.Second && (*sleeps)[0] <= 5500*time.Millisecond)
+	// the backoff of the second retry
+	assert.True(t, (*sleeps)[1] >= time.Second && (*sleeps)[1] <= 2*time.Second)
+
+	// 4xx responses are not retried
+	*sleeps = nil
+	hmock.On("GET", "https://localhost/ledgers/1").ReturnString(http.StatusNotFound, notFoundResponse)
+	_, err = client.LedgerDetail(1)
+	assert.Error(t, err)
+	assert.Empty(t, *sleeps)
+
+	// the error of the last attempt is returned
+	hmock.On("GET", "https://localhost/ledgers/2").Return(func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusServiceUnavailable, "{}"), nil
+	})
+	_, err = client.LedgerDetail(2)
+	if assert.Error(t, err) {
+		horizonError, ok := errors.Cause(err).(*Error)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, horizonError.Response.StatusCode)
+	}
+	assert.Len(t, *sleeps, 3)
+
+	// Horizon asks to wait longer than MaxRetryAfter
+	*sleeps = nil
+	rateLimited = httpmock.NewStringResponse(http.StatusTooManyRequests, "{}")
+	rateLimited.Header.Set("Retry-After", "3600")
+	hmock.On("GET", "https://localhost/ledgers/3").Return(httpmock.ResponderFromResponse(rateLimited))
+	_, err = client.LedgerDetail(3)
+	assert.Error(t, err)
+	assert.Empty(t, *sleeps)
+}
+
+func TestClientRetryPolicySubmission(t *testing.T) {
+	hmock := httptest.NewClient()
+	policy, sleeps := newTestRetryPolicy(&RetryPolicy{})
+	client := &Client{
+		HorizonURL:  "https://localhost/",
+		HTTP:        hmock,
+		RetryPolicy: policy,
+	}
+
+	txXdr := `AAAAABB90WssODNIgi6BHveqzxTRmIpvAFRyVNM+Hm2GVuCcAAAAZAAABD0AAuV/AAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAyTBGxOgfSApppsTnb/YRr6gOR8WT0LZNrhLh4y3FCgoAAAAXSHboAAAAAAAAAAABhlbgnAAAAEAivKe977CQCxMOKTuj+cWTFqc2OOJU8qGr9afrgu2zDmQaX5Q0cNshc3PiBwe0qw/+D/qJk5QqM5dYeSUGeDQP`
+	var bodies []string
+	record := func(responder httpmock.Responder) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(body))
+			return responder(req)
+		}
+	}
+	hmock.On("POST", "https://localhost/transactions").Return(sequenceResponder(
+		record(httpmock.NewStringResponder(http.StatusGatewayTimeout, "{}")),
+		record(httpmock.NewStringResponder(http.StatusOK, txSuccess)),
+	))
+
+	_, err := client.SubmitTransactionXDR(txXdr)
+	require.NoError(t, err)
+	assert.Len(t, *sleeps, 1)
+	// the body is sent again
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Contains(t, bodies[0], "tx=")
+}
+
+func TestClientRetryPolicyStream(t *testing.T) {
+	hmock := httptest.NewClient()
+	policy, sleeps := newTestRetryPolicy(&RetryPolicy{})
+	client := &Client{
+		HorizonURL:  "https://localhost/",
+		HTTP:        hmock,
+		RetryPolicy: policy,
+	}
+
+	hmock.On("GET", "https://localhost/ledgers?cursor=1").Return(sequenceResponder(
+		errorResponder(errors.New("connection refused")),
+		httpmock.NewStringResponder(http.StatusBadGateway, ""),
+		httpmock.NewStringResponder(http.StatusOK, ledgerStreamResponse),
+	))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var ledgers []hProtocol.Ledger
+	err := client.StreamLedgers(ctx, LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {
+		ledgers = append(ledgers, ledger)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, ledgers, 1)
+	assert.Equal(t, int32(560339), ledgers[0].Sequence)
+	assert.Len(t, *sleeps, 2)
+
+	// the connection is not retried once the context is done
+	*sleeps = nil
+	hmock.On("GET", "https://localhost/ledgers?cursor=2").ReturnString(http.StatusServiceUnavailable, "")
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = client.StreamLedgers(ctx, LedgerRequest{Cursor: "2"}, func(ledger hProtocol.Ledger) {})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "got bad HTTP status code 503")
+	}
+	assert.Empty(t, *sleeps)
+}
@@ -0,0 +1,20 @@
+package horizonclient
+
+import (
+	"net/http"
+)
+
+// BuildURL returns the url of the root resource, relative to the horizon url.
+func (rootRequest) BuildURL() (string, error) {
+	return "", nil
+}
+
+// HTTPRequest returns the http request for the root resource
+func (rr rootRequest) HTTPRequest(horizonURL string) (*http.Request, error) {
+	endpoint, err := rr.BuildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return http.NewRequest("GET", horizonURL+endpoint, nil)
+}
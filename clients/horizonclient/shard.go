@@ -0,0 +1,234 @@
+package horizonclient
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+)
+
+// LedgerShard is a range of ledgers, From and To included, of a history
+// query split by ShardLedgerRange.
+type LedgerShard struct {
+	From uint32
+	To   uint32
+}
+
+// ShardLedgerRange splits the ledgers from from to to (included) into
+// consecutive shards of at most size ledgers, which can be fetched in parallel
+// by BackfillTransactions and BackfillOperations.
+func ShardLedgerRange(from, to, size uint32) ([]LedgerShard, error) {
+	if from == 0 || from > to {
+		return nil, errors.Errorf("invalid ledger range %d-%d", from, to)
+	}
+	// The toid of the ledger after to must fit in an int64
+	if to >= math.MaxInt32 {
+		return nil, errors.Errorf("ledger %d is out of range", to)
+	}
+	if size == 0 {
+		return nil, errors.New("shard size must be positive")
+	}
+
+	var shards []LedgerShard
+	for start := uint64(from); start <= uint64(to); start += uint64(size) {
+		end := start + uint64(size) - 1
+		if end > uint64(to) {
+			end = uint64(to)
+		}
+		shards = append(shards, LedgerShard{From: uint32(start), To: uint32(end)})
+	}
+	return shards, nil
+}
+
+// pagingTokens returns the cursor from which the records of the shard are
+// fetched and the paging token of the first record after the shard, built
+// with the toid scheme.
+func (s LedgerShard) pagingTokens() (string, int64, error) {
+	if s.From == 0 || s.From > s.To || s.To >= math.MaxInt32 {
+		return "", 0, errors.Errorf("invalid ledger shard %d-%d", s.From, s.To)
+	}
+	start, end, err := toid.LedgerRangeInclusive(int32(s.From), int32(s.To))
+	if err != nil {
+		return "", 0, err
+	}
+	return strconv.FormatInt(start, 10), end, nil
+}
+
+// afterShard returns true if the record of the paging token comes after the
+// shard and the iteration must stop. The paging tokens of trades are the toid
+// of their operation followed by "-" and their order in the operation.
+func afterShard(pagingToken string, end int64) (bool, error) {
+	id, err := strconv.ParseInt(strings.SplitN(pagingToken, "-", 2)[0], 10, 64)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid paging token %s", pagingToken)
+	}
+	return id >= end, nil
+}
+
+// TransactionShardHandler is called by BackfillTransactions with every
+// transaction of a shard.
+type TransactionShardHandler func(shard LedgerShard, transaction hProtocol.Transaction) error
+
+// OperationShardHandler is called by BackfillOperations with every operation
+// of a shard.
+type OperationShardHandler func(shard LedgerShard, operation operations.Operation) error
+
+// BackfillTransactions fetches the transactions of request in the ledgers of
+// the shards, walking concurrency shards in parallel instead of paging
+// through the whole history sequentially. The Cursor and Order of request are
+// ignored: each shard starts at the cursor of its first ledger and is walked
+// in ascending order. A Limit of 0 means the maximum of 200 records per page.
+//
+// handler is called with the transactions of a shard in order, but with the
+// shards concurrently. The first error returned by handler or by Horizon
+// stops the backfill and is returned; ctx.Err() is returned if ctx is
+// cancelled.
+func (c *Client) BackfillTransactions(
+	ctx context.Context,
+	request TransactionRequest,
+	shards []LedgerShard,
+	concurrency int,
+	handler TransactionShardHandler,
+) error {
+	return backfillShards(ctx, shards, concurrency, func(ctx context.Context, shard LedgerShard) error {
+		cursor, end, err := shard.pagingTokens()
+		if err != nil {
+			return err
+		}
+		request := request
+		request.Cursor, request.Order = cursor, OrderAsc
+		if request.Limit == 0 {
+			request.Limit = 200
+		}
+
+		page, err := c.Transactions(request)
+		for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextTransactionsPage(page) {
+			for _, tx := range page.Embedded.Records {
+				done, err := afterShard(tx.PT, end)
+				if err != nil || done {
+					return err
+				}
+				if err = handler(shard, tx); err != nil {
+					return err
+				}
+			}
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(err, "error fetching transactions")
+	})
+}
+
+// BackfillOperations is like BackfillTransactions for the operations, or the
+// payments (see OperationRequest.SetPaymentsEndpoint), of request.
+func (c *Client) BackfillOperations(
+	ctx context.Context,
+	request OperationRequest,
+	shards []LedgerShard,
+	concurrency int,
+	handler OperationShardHandler,
+) error {
+	return backfillShards(ctx, shards, concurrency, func(ctx context.Context, shard LedgerShard) error {
+		cursor, end, err := shard.pagingTokens()
+		if err != nil {
+			return err
+		}
+		request := request
+		request.Cursor, request.Order = cursor, OrderAsc
+		if request.Limit == 0 {
+			request.Limit = 200
+		}
+
+		var page operations.OperationsPage
+		if request.endpoint == "payments" {
+			page, err = c.Payments(request)
+		} else {
+			page, err = c.Operations(request)
+		}
+		for ; err == nil && len(page.Embedded.Records) > 0; page, err = c.NextOperationsPage(page) {
+			for _, op := range page.Embedded.Records {
+				done, err := afterShard(op.PagingToken(), end)
+				if err != nil || done {
+					return err
+				}
+				if err = handler(shard, op); err != nil {
+					return err
+				}
+			}
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(err, "error fetching operations")
+	})
+}
+
+// backfillShards calls fetch with every shard, from concurrency goroutines,
+// until all the shards are fetched or one of them fails.
+func backfillShards(
+	ctx context.Context,
+	shards []LedgerShard,
+	concurrency int,
+	fetch func(ctx context.Context, shard LedgerShard) error,
+) error {
+	if concurrency <= 0 {
+		return errors.New("concurrency must be positive")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	queue := make(chan LedgerShard)
+	var once sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for shard := range queue {
+				if err := fetch(ctx, shard); err != nil {
+					once.Do(func() {
+						if parent.Err() != nil {
+							firstErr = parent.Err()
+						} else {
+							firstErr = errors.Wrapf(err, "error backfilling ledgers %d-%d", shard.From, shard.To)
+						}
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	interrupted := false
+	for _, shard := range shards {
+		select {
+		case queue <- shard:
+			continue
+		case <-ctx.Done():
+			interrupted = true
+		}
+		break
+	}
+	close(queue)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if interrupted {
+		return parent.Err()
+	}
+	return nil
+}
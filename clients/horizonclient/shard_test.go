@@ -0,0 +1,141 @@
+package horizonclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardLedgerRange(t *testing.T) {
+	shards, err := ShardLedgerRange(1, 10, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []LedgerShard{{From: 1, To: 4}, {From: 5, To: 8}, {From: 9, To: 10}}, shards)
+
+	shards, err = ShardLedgerRange(7, 7, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []LedgerShard{{From: 7, To: 7}}, shards)
+
+	_, err = ShardLedgerRange(0, 10, 4)
+	assert.EqualError(t, err, "invalid ledger range 0-10")
+	_, err = ShardLedgerRange(10, 9, 4)
+	assert.EqualError(t, err, "invalid ledger range 10-9")
+	_, err = ShardLedgerRange(1, 1<<31, 4)
+	assert.EqualError(t, err, "ledger 2147483648 is out of range")
+	_, err = ShardLedgerRange(1, math.MaxInt32, 4)
+	assert.EqualError(t, err, "ledger 2147483647 is out of range")
+	_, err = ShardLedgerRange(1, 10, 0)
+	assert.EqualError(t, err, "shard size must be positive")
+}
+
+func TestBackfillTransactions(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	// ledger 1 is fetched from the very beginning
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=0&limit=200&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=4294971392&limit=200&order=asc"}},
+  "_embedded": {"records": [{"id": "a", "paging_token": "4294971392", "ledger": 1}]}
+}`)
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=4294971392&limit=200&order=asc",
+	).ReturnString(200, exportEmptyPage)
+	// the shard of ledgers 2 and 3 stops at the first transaction of ledger 4
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=8589934592&limit=200&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=17179873280&limit=200&order=asc"}},
+  "_embedded": {"records": [
+    {"id": "b", "paging_token": "8589938688", "ledger": 2},
+    {"id": "c", "paging_token": "12884905984", "ledger": 3},
+    {"id": "d", "paging_token": "17179873280", "ledger": 4}
+  ]}
+}`)
+
+	var mutex sync.Mutex
+	fetched := map[LedgerShard][]string{}
+	err := client.BackfillTransactions(
+		context.Background(),
+		TransactionRequest{Cursor: "ignored", Order: OrderDesc},
+		[]LedgerShard{{From: 1, To: 1}, {From: 2, To: 3}},
+		2,
+		func(shard LedgerShard, tx hProtocol.Transaction) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			fetched[shard] = append(fetched[shard], tx.ID)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[LedgerShard][]string{
+		{From: 1, To: 1}: {"a"},
+		{From: 2, To: 3}: {"b", "c"},
+	}, fetched)
+}
+
+func TestBackfillOperationsErrors(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/payments?cursor=8589934592&limit=10&order=asc",
+	).ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/payments?cursor=8589938689&limit=10&order=asc"}},
+  "_embedded": {"records": [{"id": "8589938689", "paging_token": "8589938689", "type": "payment", "type_i": 1}]}
+}`)
+
+	request := OperationRequest{Limit: 10}
+	err := client.BackfillOperations(
+		context.Background(),
+		*request.SetPaymentsEndpoint(),
+		[]LedgerShard{{From: 2, To: 2}},
+		1,
+		func(shard LedgerShard, op operations.Operation) error {
+			assert.Equal(t, "8589938689", op.GetID())
+			return errors.New("handler failed")
+		},
+	)
+	assert.EqualError(t, err, "error backfilling ledgers 2-2: handler failed")
+
+	hmock.On(
+		"GET",
+		"https://localhost/operations?cursor=8589934592&limit=200&order=asc",
+	).ReturnString(404, notFoundResponse)
+	err = client.BackfillOperations(
+		context.Background(),
+		OperationRequest{},
+		[]LedgerShard{{From: 2, To: 2}},
+		1,
+		func(shard LedgerShard, op operations.Operation) error { return nil },
+	)
+	assert.EqualError(t, err, "error backfilling ledgers 2-2: error fetching operations: horizon error: \"Resource Missing\" - check horizon.Error.Problem for more information")
+
+	err = client.BackfillOperations(context.Background(), OperationRequest{}, []LedgerShard{{From: 3, To: 2}}, 1, nil)
+	assert.EqualError(t, err, "error backfilling ledgers 3-2: invalid ledger shard 3-2")
+	err = client.BackfillOperations(context.Background(), OperationRequest{}, nil, 0, nil)
+	assert.EqualError(t, err, "concurrency must be positive")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.BackfillOperations(ctx, OperationRequest{}, []LedgerShard{{From: 2, To: 2}}, 1, nil)
+	assert.Equal(t, context.Canceled, err)
+}
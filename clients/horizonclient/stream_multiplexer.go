@@ -0,0 +1,255 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// ErrStreamSubscriberTooSlow is the error returned by StreamSubscription.Err()
+// when a subscriber did not drain its buffer fast enough and was disconnected
+// from the shared stream. The subscriber can resume on its own using Cursor().
+var ErrStreamSubscriberTooSlow = errors.New("stream subscriber is too slow")
+
+// ErrStreamSubscriptionClosed is the error returned by StreamSubscription.Err()
+// when the subscription was closed by the consumer or its context was cancelled.
+var ErrStreamSubscriptionClosed = errors.New("stream subscription closed")
+
+// StreamEvent is a single event received from a shared Horizon stream.
+type StreamEvent struct {
+	// PagingToken is the paging token of the streamed resource, if it has one.
+	PagingToken string
+	// Data is the raw JSON body of the event.
+	Data []byte
+}
+
+// Decode unmarshals the event data into the provided value, e.g. a
+// hProtocol.Transaction or an operations.Operation.
+func (e StreamEvent) Decode(v interface{}) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return errors.Wrap(err, "error unmarshaling data")
+	}
+	return nil
+}
+
+// StreamMultiplexer opens at most one streaming connection per Horizon
+// endpoint (including its filters and query parameters) and fans the events out
+// to any number of in-process subscribers. This reduces the number of
+// connections held against rate-limited Horizon instances.
+//
+// Every subscriber has its own buffer and cursor. A subscriber whose buffer is
+// full when a new event arrives is disconnected with ErrStreamSubscriberTooSlow
+// so that it never blocks the other subscribers of the same stream.
+type StreamMultiplexer struct {
+	client *Client
+
+	mutex   sync.Mutex
+	streams map[string]*sharedStream
+}
+
+// NewStreamMultiplexer returns a StreamMultiplexer opening its streams with
+// the given client.
+func NewStreamMultiplexer(client *Client) *StreamMultiplexer {
+	return &StreamMultiplexer{
+		client:  client,
+		streams: map[string]*sharedStream{},
+	}
+}
+
+// Subscribe subscribes to the stream of the given request. If there is
+// already a stream open for the same endpoint, the subscription is attached to
+// it and only receives the events streamed from that point onwards. Otherwise
+// a new stream is opened, starting at the cursor of the request (or "now").
+//
+// bufferSize is the number of events which can be queued for the subscriber
+// before it is considered too slow. The subscription is closed when ctx is
+// cancelled or Close() is called. The underlying connection is closed once its
+// last subscription is closed.
+func (m *StreamMultiplexer) Subscribe(
+	ctx context.Context,
+	request HorizonRequest,
+	bufferSize int,
+) (*StreamSubscription, error) {
+	if bufferSize < 1 {
+		return nil, errors.New("buffer size must be positive")
+	}
+	endpoint, err := request.BuildURL()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build endpoint")
+	}
+	streamURL := fmt.Sprintf("%s%s", m.client.fixHorizonURL(), endpoint)
+
+	subscription := &StreamSubscription{
+		events: make(chan StreamEvent, bufferSize),
+		done:   make(chan struct{}),
+	}
+	m.attach(streamURL, subscription)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			subscription.Close()
+		case <-subscription.done:
+		}
+	}()
+
+	return subscription, nil
+}
+
+// attach adds the subscription to the stream open for the given URL, opening
+// a new stream if there is none.
+func (m *StreamMultiplexer) attach(streamURL string, subscription *StreamSubscription) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stream, ok := m.streams[streamURL]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream = &sharedStream{
+			multiplexer: m,
+			url:         streamURL,
+			cancel:      cancel,
+			subscribers: map[*StreamSubscription]struct{}{},
+		}
+		m.streams[streamURL] = stream
+		go stream.run(ctx)
+	}
+	stream.subscribers[subscription] = struct{}{}
+	subscription.stream = stream
+}
+
+// sharedStream is a single streaming connection shared by many subscribers.
+// Its subscribers are guarded by the mutex of the multiplexer.
+type sharedStream struct {
+	multiplexer *StreamMultiplexer
+	url         string
+	cancel      context.CancelFunc
+	subscribers map[*StreamSubscription]struct{}
+}
+
+func (s *sharedStream) run(ctx context.Context) {
+	err := s.multiplexer.client.stream(ctx, s.url, func(data []byte) error {
+		var resource struct {
+			PagingToken string `json:"paging_token"`
+		}
+		// Not all streamed resources are JSON objects with a paging token
+		// (e.g. order books), so a decoding error is not fatal here.
+		_ = json.Unmarshal(data, &resource)
+
+		s.publish(StreamEvent{PagingToken: resource.PagingToken, Data: data})
+		return nil
+	})
+	if err == nil {
+		err = ErrStreamSubscriptionClosed
+	}
+	s.closeAll(err)
+}
+
+func (s *sharedStream) publish(event StreamEvent) {
+	s.multiplexer.mutex.Lock()
+	defer s.multiplexer.mutex.Unlock()
+	for subscription := range s.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			delete(s.subscribers, subscription)
+			subscription.finish(ErrStreamSubscriberTooSlow)
+		}
+	}
+	s.stopIfUnused()
+}
+
+func (s *sharedStream) unsubscribe(subscription *StreamSubscription, err error) {
+	s.multiplexer.mutex.Lock()
+	defer s.multiplexer.mutex.Unlock()
+	if _, ok := s.subscribers[subscription]; !ok {
+		return
+	}
+	delete(s.subscribers, subscription)
+	subscription.finish(err)
+	s.stopIfUnused()
+}
+
+func (s *sharedStream) closeAll(err error) {
+	s.multiplexer.mutex.Lock()
+	defer s.multiplexer.mutex.Unlock()
+	for subscription := range s.subscribers {
+		delete(s.subscribers, subscription)
+		subscription.finish(err)
+	}
+	s.stopIfUnused()
+}
+
+// stopIfUnused closes the connection once there are no subscribers left, so
+// the next subscription for the same endpoint opens a new one. It must be
+// called with the multiplexer mutex held.
+func (s *sharedStream) stopIfUnused() {
+	if len(s.subscribers) > 0 {
+		return
+	}
+	s.cancel()
+	if s.multiplexer.streams[s.url] == s {
+		delete(s.multiplexer.streams, s.url)
+	}
+}
+
+// StreamSubscription is a single consumer of a stream shared through a
+// StreamMultiplexer.
+type StreamSubscription struct {
+	stream *sharedStream
+	events chan StreamEvent
+	done   chan struct{}
+
+	mutex  sync.Mutex
+	cursor string
+	err    error
+}
+
+// Events returns the channel on which the events are delivered. The channel is
+// closed when the subscription ends, after which Err() describes why.
+func (s *StreamSubscription) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Ack records the paging token of an event the consumer has processed. Use
+// Cursor() to resume from that position with a new request.
+func (s *StreamSubscription) Ack(event StreamEvent) {
+	if event.PagingToken == "" {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cursor = event.PagingToken
+}
+
+// Cursor returns the paging token of the last acknowledged event.
+func (s *StreamSubscription) Cursor() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cursor
+}
+
+// Err returns the reason the subscription ended, or nil if it is still active.
+func (s *StreamSubscription) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+// Close detaches the subscription from the shared stream.
+func (s *StreamSubscription) Close() {
+	s.stream.unsubscribe(s, ErrStreamSubscriptionClosed)
+}
+
+// finish ends the subscription. It must be called with the multiplexer mutex
+// held and at most once per subscription.
+func (s *StreamSubscription) finish(err error) {
+	s.mutex.Lock()
+	s.err = err
+	s.mutex.Unlock()
+	close(s.events)
+	close(s.done)
+}
@@ -0,0 +1,141 @@
+package horizonclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedStream mocks a transactions stream which sends a single event per
+// connection, each connection being released by sending to the returned
+// channel. Closing the channel makes further connections fail.
+func gatedStream(hmock *httptest.Client) chan struct{} {
+	next := make(chan struct{})
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=now",
+	).Return(func(*http.Request) (*http.Response, error) {
+		if _, ok := <-next; !ok {
+			return httpmock.NewStringResponse(500, ""), nil
+		}
+		// httpmock bodies rewind once read, use a plain reader so the
+		// connection ends after one event.
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader(txStreamResponse)),
+		}, nil
+	})
+	return next
+}
+
+func TestStreamMultiplexerSharesStream(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	next := gatedStream(hmock)
+	defer close(next)
+
+	multiplexer := NewStreamMultiplexer(client)
+	first, err := multiplexer.Subscribe(context.Background(), TransactionRequest{}, 10)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	second, err := multiplexer.Subscribe(ctx, TransactionRequest{}, 10)
+	require.NoError(t, err)
+	assert.Same(t, first.stream, second.stream)
+	next <- struct{}{}
+
+	for _, subscription := range []*StreamSubscription{first, second} {
+		event, ok := <-subscription.Events()
+		require.True(t, ok)
+		var transaction hProtocol.Transaction
+		require.NoError(t, event.Decode(&transaction))
+		assert.Equal(t, "1534f6507420c6871b557cc2fc800c29fb1ed1e012e694993ffe7a39c824056e", transaction.Hash)
+		assert.Equal(t, "2608707301036032", event.PagingToken)
+		subscription.Ack(event)
+		assert.Equal(t, "2608707301036032", subscription.Cursor())
+	}
+
+	cancel()
+	for range second.Events() {
+	}
+	assert.Equal(t, ErrStreamSubscriptionClosed, second.Err())
+	assert.NoError(t, first.Err())
+
+	first.Close()
+	for range first.Events() {
+	}
+	assert.Equal(t, ErrStreamSubscriptionClosed, first.Err())
+	assert.Eventually(t, func() bool {
+		multiplexer.mutex.Lock()
+		defer multiplexer.mutex.Unlock()
+		return len(multiplexer.streams) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStreamMultiplexerSlowSubscriber(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	next := gatedStream(hmock)
+	defer close(next)
+
+	multiplexer := NewStreamMultiplexer(client)
+	slow, err := multiplexer.Subscribe(context.Background(), TransactionRequest{}, 1)
+	require.NoError(t, err)
+	fast, err := multiplexer.Subscribe(context.Background(), TransactionRequest{}, 1)
+	require.NoError(t, err)
+	defer fast.Close()
+
+	// The slow subscriber never reads so it is dropped on the second event,
+	// while the fast one keeps receiving events.
+	for i := 0; i < 3; i++ {
+		next <- struct{}{}
+		_, ok := <-fast.Events()
+		require.True(t, ok)
+	}
+	assert.Equal(t, ErrStreamSubscriberTooSlow, slow.Err())
+	assert.NoError(t, fast.Err())
+
+	_, ok := <-slow.Events()
+	assert.True(t, ok)
+	_, ok = <-slow.Events()
+	assert.False(t, ok)
+}
+
+func TestStreamMultiplexerStreamError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On(
+		"GET",
+		"https://localhost/transactions?cursor=now",
+	).ReturnString(500, txStreamResponse)
+
+	multiplexer := NewStreamMultiplexer(client)
+	subscription, err := multiplexer.Subscribe(context.Background(), TransactionRequest{}, 1)
+	require.NoError(t, err)
+	for range subscription.Events() {
+	}
+	if assert.Error(t, subscription.Err()) {
+		assert.Contains(t, subscription.Err().Error(), "got bad HTTP status code 500")
+	}
+
+	_, err = multiplexer.Subscribe(context.Background(), TransactionRequest{}, 0)
+	assert.EqualError(t, err, "buffer size must be positive")
+}
@@ -0,0 +1,110 @@
+package horizonclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// StreamReconnect configures the reconnection of the streams of a Client
+// (see Client.StreamReconnect). Once connected, a stream whose connection
+// drops, or fails to reconnect, is reconnected from the cursor of the last
+// record received, after a backoff growing with the consecutive failures,
+// so that no record is lost or received twice. A stream ended by Horizon,
+// e.g. when the connection is idle, is reconnected at once. The error of a
+// record which cannot be decoded is returned instead.
+type StreamReconnect struct {
+	// Disabled makes the streams return the error of a dropped connection
+	// instead of reconnecting.
+	Disabled bool
+	// InitialBackoff is the delay before the first reconnection after a
+	// failure, 1 second if 0. It doubles with every consecutive failure.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between reconnections, 30 seconds if
+	// 0.
+	MaxBackoff time.Duration
+	// MaxReconnects is the number of consecutive failed reconnections,
+	// without any record received, after which the stream returns the error,
+	// unlimited if 0.
+	MaxReconnects int
+	// OnReconnect is called, if set, before every reconnection, e.g. to log
+	// disconnects.
+	OnReconnect func(StreamReconnectEvent)
+
+	// sleep replaces sleepContext in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// StreamReconnectEvent describes a reconnection of a stream reported to
+// StreamReconnect.OnReconnect.
+type StreamReconnectEvent struct {
+	// URL is the URL the stream reconnects to.
+	URL string
+	// Cursor is the cursor the stream resumes from, the paging token of the
+	// last record received.
+	Cursor string
+	// Failures is the number of consecutive failures, 0 if the stream was
+	// ended by Horizon.
+	Failures int
+	// Delay is the delay before the reconnection.
+	Delay time.Duration
+	// Err is the error of the connection, nil if the stream was ended by
+	// Horizon.
+	Err error
+}
+
+// defaultStreamReconnect is used by the clients without StreamReconnect.
+var defaultStreamReconnect = &StreamReconnect{}
+
+func (c *Client) streamReconnect() *StreamReconnect {
+	if c.StreamReconnect == nil {
+		return defaultStreamReconnect
+	}
+	return c.StreamReconnect
+}
+
+// Backoff returns the delay before the reconnection following the given
+// number of consecutive failures, randomized between half of it and all of it.
+func (r *StreamReconnect) Backoff(failures int) time.Duration {
+	initial := r.InitialBackoff
+	if initial <= 0 {
+		initial = time.Second
+	}
+	max := r.MaxBackoff
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	backoff := math.Min(float64(initial)*math.Pow(2, float64(failures-1)), float64(max))
+	return time.Duration(backoff * (1 - 0.5*rand.Float64()))
+}
+
+// reconnect returns the delay before reconnecting after a failure, and false
+// if the stream must return the error instead. Connection errors are only
+// retried when the request could succeed later (see DefaultRetryable).
+func (r *StreamReconnect) reconnect(failures int, resp *http.Response, err error, readErr bool) (time.Duration, bool) {
+	if r.Disabled || (r.MaxReconnects > 0 && failures > r.MaxReconnects) {
+		return 0, false
+	}
+	if !readErr && !DefaultRetryable(resp, err) {
+		return 0, false
+	}
+	return r.Backoff(failures), true
+}
+
+// wait calls OnReconnect and waits for the delay of the event, returning
+// ctx.Err() if ctx is done before.
+func (r *StreamReconnect) wait(ctx context.Context, event StreamReconnectEvent) error {
+	if r.OnReconnect != nil {
+		r.OnReconnect(event)
+	}
+	if event.Delay <= 0 {
+		return ctx.Err()
+	}
+	sleep := sleepContext
+	if r.sleep != nil {
+		sleep = r.sleep
+	}
+	return sleep(ctx, event.Delay)
+}
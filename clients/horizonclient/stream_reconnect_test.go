@@ -0,0 +1,179 @@
+package horizonclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+// ledgerEvents returns the server-sent events of ledgers, whose ids are
+// their sequences.
+func ledgerEvents(sequences ...int32) string {
+	var events strings.Builder
+	for _, sequence := range sequences {
+		fmt.Fprintf(&events, "id: %d\ndata: {\"sequence\":%d,\"paging_token\":\"%d\"}\n\n", sequence, sequence, sequence)
+	}
+	return events.String()
+}
+
+// streamResponder responds with the events, followed by a dropped connection
+// if drop is true.
+func streamResponder(events string, drop bool) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var body io.Reader = strings.NewReader(events)
+		if drop {
+			body = io.MultiReader(body, failingReader{})
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(body)}, nil
+	}
+}
+
+// newTestStreamReconnect returns the reconnect recording its events and its
+// sleeps instead of sleeping.
+func newTestStreamReconnect(reconnect *StreamReconnect) (*StreamReconnect, *[]StreamReconnectEvent, *[]time.Duration) {
+	var events []StreamReconnectEvent
+	var sleeps []time.Duration
+	reconnect.OnReconnect = func(event StreamReconnectEvent) {
+		events = append(events, event)
+	}
+	reconnect.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return ctx.Err()
+	}
+	return reconnect, &events, &sleeps
+}
+
+func TestStreamReconnectBackoff(t *testing.T) {
+	reconnect := &StreamReconnect{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for failures, max := range []time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 5: 5 * time.Second} {
+		if failures == 0 {
+			continue
+		}
+		backoff := reconnect.Backoff(failures)
+		assert.True(t, backoff >= max/2 && backoff <= max, "failures %d backoff %v", failures, backoff)
+	}
+}
+
+func TestStreamReconnectResumesFromCursor(t *testing.T) {
+	hmock := httptest.NewClient()
+	reconnect, events, sleeps := newTestStreamReconnect(&StreamReconnect{})
+	client := &Client{
+		HorizonURL:      "https://localhost/",
+		HTTP:            hmock,
+		StreamReconnect: reconnect,
+	}
+
+	hmock.On("GET", "https://localhost/ledgers?cursor=1").Return(streamResponder(ledgerEvents(2, 3), true))
+	// the connection fails once, then the stream ends
+	hmock.On("GET", "https://localhost/ledgers?cursor=3").Return(sequenceResponder(
+		errorResponder(errors.New("connection refused")),
+		streamResponder(ledgerEvents(4), false),
+	))
+	hmock.On("GET", "https://localhost/ledgers?cursor=4").Return(streamResponder(ledgerEvents(5), false))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var sequences []int32
+	err := client.StreamLedgers(ctx, LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {
+		sequences = append(sequences, ledger.Sequence)
+		if ledger.Sequence == 5 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{2, 3, 4, 5}, sequences)
+
+	require.Len(t, *events, 3)
+	assert.Equal(t, "https://localhost/ledgers?cursor=3", (*events)[0].URL)
+	assert.Equal(t, "3", (*events)[0].Cursor)
+	assert.Equal(t, 1, (*events)[0].Failures)
+	assert.Contains(t, (*events)[0].Err.Error(), "connection reset by peer")
+	assert.Equal(t, 2, (*events)[1].Failures)
+	assert.Contains(t, (*events)[1].Err.Error(), "connection refused")
+	// the stream ended by Horizon is reconnected at once
+	assert.Equal(t, StreamReconnectEvent{URL: "https://localhost/ledgers?cursor=4", Cursor: "4"}, (*events)[2])
+	assert.Len(t, *sleeps, 2)
+}
+
+func TestStreamReconnectFailures(t *testing.T) {
+	hmock := httptest.NewClient()
+	reconnect, events, _ := newTestStreamReconnect(&StreamReconnect{MaxReconnects: 2})
+	client := &Client{
+		HorizonURL:      "https://localhost/",
+		HTTP:            hmock,
+		StreamReconnect: reconnect,
+	}
+	hmock.On("GET", "https://localhost/ledgers?cursor=1").Return(streamResponder(ledgerEvents(2), true))
+	hmock.On("GET", "https://localhost/ledgers?cursor=2").Return(func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+	})
+
+	err := client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "got bad HTTP status code 503")
+	}
+	assert.Len(t, *events, 2)
+
+	// 4xx responses are not retried
+	*events = nil
+	hmock.On("GET", "https://localhost/ledgers?cursor=2").Return(func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+	})
+	err = client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "got bad HTTP status code 404")
+	}
+	assert.Len(t, *events, 1)
+
+	// the errors are returned when reconnecting is disabled
+	*events = nil
+	reconnect.Disabled = true
+	err = client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "connection reset by peer")
+	}
+	assert.Empty(t, *events)
+}
+
+func TestStreamReconnectDecodeError(t *testing.T) {
+	hmock := httptest.NewClient()
+	reconnect, events, _ := newTestStreamReconnect(&StreamReconnect{MaxReconnects: 1})
+	client := &Client{
+		HorizonURL:      "https://localhost/",
+		HTTP:            hmock,
+		StreamReconnect: reconnect,
+		ResponseFormat:  JSONLinesFormat{},
+	}
+
+	// the record which cannot be decoded is returned instead of reconnecting
+	hmock.On("GET", "https://localhost/ledgers?cursor=1").Return(streamResponder(
+		"{\"sequence\":2,\"paging_token\":\"2\"}\n{\"sequence\":\n", false,
+	))
+	var sequences []int32
+	err := client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {
+		sequences = append(sequences, ledger.Sequence)
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "error decoding record")
+	}
+	assert.Equal(t, []int32{2}, sequences)
+	assert.Empty(t, *events)
+}
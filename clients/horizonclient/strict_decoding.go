@@ -0,0 +1,214 @@
+package horizonclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+)
+
+// StrictViolationKind is the reason a field of a response is rejected by
+// StrictDecoding.
+type StrictViolationKind string
+
+const (
+	// StrictViolationUnknown is a field which does not decode into any field
+	// of the resource: it is silently dropped, or kept in its Extra field.
+	StrictViolationUnknown StrictViolationKind = "unknown"
+	// StrictViolationAmbiguous is a field which only matches the JSON name
+	// of a field of the resource case insensitively: encoding/json decodes it, but when the
+	// response also has the field itself, which one wins depends on their
+	// order in the response.
+	StrictViolationAmbiguous StrictViolationKind = "ambiguous"
+	// StrictViolationDeprecated is a field deprecated by Horizon.
+	StrictViolationDeprecated StrictViolationKind = "deprecated"
+)
+
+// StrictViolation is a field of a response rejected by StrictDecoding.
+type StrictViolation struct {
+	Kind StrictViolationKind
+	// Path is the path of the field in the JSON document, e.g.
+	// "_embedded.records[0].num_accounts".
+	Path string
+	// Detail is the field of the resource an ambiguous field matches, or the
+	// field replacing a deprecated one, if any.
+	Detail string
+}
+
+func (v StrictViolation) String() string {
+	switch {
+	case v.Kind == StrictViolationAmbiguous:
+		return fmt.Sprintf("ambiguous field %s (matches %s)", v.Path, v.Detail)
+	case v.Kind == StrictViolationDeprecated && v.Detail != "":
+		return fmt.Sprintf("deprecated field %s (use %s)", v.Path, v.Detail)
+	default:
+		return fmt.Sprintf("%s field %s", v.Kind, v.Path)
+	}
+}
+
+// StrictDecodingError is returned by the clients with StrictDecoding when a
+// response has fields they reject.
+type StrictDecodingError struct {
+	// Resource is the type the response was decoded into, e.g.
+	// "horizon.AssetStatsPage".
+	Resource   string
+	Violations []StrictViolation
+}
+
+func (e *StrictDecodingError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		violations[i] = violation.String()
+	}
+	return fmt.Sprintf("strict decoding of %s: %s", e.Resource, strings.Join(violations, ", "))
+}
+
+// deprecatedFields are the fields deprecated by Horizon, keyed by the type
+// declaring them and their JSON name, with the fields replacing them.
+var deprecatedFields = map[string]string{
+	"horizon.AssetStat.amount":       "balances",
+	"horizon.AssetStat.num_accounts": "accounts",
+}
+
+// StrictDecoding makes a Client reject the responses with fields the SDK
+// does not know, maps ambiguously or which Horizon has deprecated (see
+// Client.StrictDecoding), e.g. to catch in a staging environment the
+// changes of a Horizon upgrade breaking an integration, before they reach
+// production.
+//
+// The responses of the requests and the records of the streams of ledgers,
+// offers, order books, trades and transactions are checked against the
+// resources of the protocols/horizon package they are decoded into. The
+// operations and effects, decoded into interfaces depending on their type,
+// are not checked, and neither are the responses of a ResponseFormat other
+// than HALFormat, except for their stream records.
+type StrictDecoding struct {
+	// AllowUnknownFields accepts the fields unknown to the SDK, e.g. to only
+	// reject the deprecated fields.
+	AllowUnknownFields bool
+	// AllowDeprecatedFields accepts the fields deprecated by Horizon.
+	AllowDeprecatedFields bool
+	// DeprecatedFields are deprecated fields in addition to the ones known
+	// to the SDK, keyed by the type declaring them and their JSON name, e.g.
+	// "horizon.AssetStat.amount", with the fields replacing them, if any.
+	DeprecatedFields map[string]string
+}
+
+// Check returns a *StrictDecodingError if the JSON document data, decoded
+// into v, has fields s rejects.
+func (s *StrictDecoding) Check(data []byte, v interface{}) error {
+	if s == nil || v == nil {
+		return nil
+	}
+	// v can be a pointer to an interface holding the resource
+	value := reflect.ValueOf(v)
+	for (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && !value.IsNil() {
+		value = value.Elem()
+	}
+	t := value.Type()
+	var violations []StrictViolation
+	s.check(data, t, "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return &StrictDecodingError{Resource: t.String(), Violations: violations}
+}
+
+// unmarshal decodes data into v, after checking it if s is not nil.
+func (s *StrictDecoding) unmarshal(data []byte, v interface{}) error {
+	if err := s.Check(data, v); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (s *StrictDecoding) deprecated(t reflect.Type, name string) (string, bool) {
+	key := t.String() + "." + name
+	if replacement, ok := s.DeprecatedFields[key]; ok {
+		return replacement, true
+	}
+	replacement, ok := deprecatedFields[key]
+	return replacement, ok
+}
+
+func (s *StrictDecoding) check(data []byte, t reflect.Type, path string, violations *[]StrictViolation) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if data[0] != '{' || json.Unmarshal(data, &fields) != nil {
+			return
+		}
+		known := hProtocol.JSONFields(t)
+		if len(known) == 0 {
+			// e.g. time.Time
+			return
+		}
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fieldPath := joinPath(path, name)
+			field, ok := hProtocol.MatchJSONField(known, name)
+			if !ok {
+				if !s.AllowUnknownFields {
+					*violations = append(*violations, StrictViolation{Kind: StrictViolationUnknown, Path: fieldPath})
+				}
+				continue
+			}
+			if field.Name != name && field.Tagged {
+				*violations = append(*violations, StrictViolation{Kind: StrictViolationAmbiguous, Path: fieldPath, Detail: field.Name})
+			}
+
+			if replacement, ok := s.deprecated(field.Owner, name); ok && !s.AllowDeprecatedFields {
+				*violations = append(*violations, StrictViolation{Kind: StrictViolationDeprecated, Path: fieldPath, Detail: replacement})
+			}
+			s.check(fields[name], field.Type, fieldPath, violations)
+		}
+	case reflect.Slice, reflect.Array:
+		var elements []json.RawMessage
+		if t.Elem().Kind() == reflect.Uint8 || data[0] != '[' || json.Unmarshal(data, &elements) != nil {
+			return
+		}
+		for i, element := range elements {
+			s.check(element, t.Elem(), fmt.Sprintf("%s[%d]", path, i), violations)
+		}
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if data[0] != '{' || json.Unmarshal(data, &values) != nil {
+			return
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s.check(values[key], t.Elem(), joinPath(path, key), violations)
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,91 @@
+package horizonclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+)
+
+func TestStrictDecodingCheck(t *testing.T) {
+	strict := &StrictDecoding{}
+	assert.NoError(t, strict.Check([]byte(ledgerResponse), &hProtocol.Ledger{}))
+
+	err := strict.Check([]byte(assetsResponse), &hProtocol.AssetsPage{})
+	if assert.IsType(t, &StrictDecodingError{}, err) {
+		assert.Equal(t, "horizon.AssetsPage", err.(*StrictDecodingError).Resource)
+		assert.Equal(t, []StrictViolation{
+			{Kind: StrictViolationDeprecated, Path: "_embedded.records[0].amount", Detail: "balances"},
+			{Kind: StrictViolationDeprecated, Path: "_embedded.records[0].num_accounts", Detail: "accounts"},
+		}, err.(*StrictDecodingError).Violations)
+	}
+	assert.NoError(t, (&StrictDecoding{AllowDeprecatedFields: true}).Check([]byte(assetsResponse), &hProtocol.AssetsPage{}))
+
+	// the untagged fields, e.g. Records, are matched case insensitively as
+	// intended, but not the tagged ones
+	data := []byte(`{"sequence": 2, "Paging_Token": "2", "new_field": {"a": 1}, "_links": {"self": {"href": "", "unknown": true}}}`)
+	err = strict.Check(data, &hProtocol.Ledger{})
+	assert.EqualError(t, err, "strict decoding of horizon.Ledger: ambiguous field Paging_Token (matches paging_token), "+
+		"unknown field _links.self.unknown, unknown field new_field")
+
+	strict = &StrictDecoding{
+		AllowUnknownFields: true,
+		DeprecatedFields:   map[string]string{"horizon.Ledger.sequence": ""},
+	}
+	err = strict.Check(data, &hProtocol.Ledger{})
+	assert.EqualError(t, err, "strict decoding of horizon.Ledger: ambiguous field Paging_Token (matches paging_token), "+
+		"deprecated field sequence")
+
+	// a nil StrictDecoding accepts everything
+	strict = nil
+	assert.NoError(t, strict.Check(data, &hProtocol.Ledger{}))
+}
+
+func TestStrictDecodingClient(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/assets").ReturnString(200, assetsResponse)
+	_, err := client.Assets(AssetRequest{})
+	require.NoError(t, err)
+
+	client.StrictDecoding = &StrictDecoding{}
+	hmock.On("GET", "https://localhost/assets").ReturnString(200, assetsResponse)
+	_, err = client.Assets(AssetRequest{})
+	if assert.Error(t, err) {
+		assert.IsType(t, &StrictDecodingError{}, errors.Cause(err))
+	}
+
+	hmock.On("GET", "https://localhost/ledgers/2").ReturnString(200, ledgerResponse)
+	_, err = client.LedgerDetail(2)
+	assert.NoError(t, err)
+}
+
+func TestStrictDecodingStream(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL:      "https://localhost/",
+		HTTP:            hmock,
+		StrictDecoding:  &StrictDecoding{},
+		StreamReconnect: &StreamReconnect{Disabled: true},
+	}
+	hmock.On("GET", "https://localhost/ledgers?cursor=1").Return(streamResponder(
+		"id: 2\ndata: {\"sequence\":2,\"paging_token\":\"2\"}\n\n"+
+			"id: 3\ndata: {\"sequence\":3,\"paging_token\":\"3\",\"new_field\":true}\n\n",
+		false,
+	))
+
+	var sequences []int32
+	err := client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {
+		sequences = append(sequences, ledger.Sequence)
+	})
+	assert.EqualError(t, err, "handler error: error unmarshaling data for ledger request: strict decoding of horizon.Ledger: unknown field new_field")
+	assert.Equal(t, []int32{2}, sequences)
+}
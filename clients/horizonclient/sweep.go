@@ -0,0 +1,401 @@
+package horizonclient
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/stellar/go/amount"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	// maxSweepOperations and maxSweepSignatures are the maximum numbers of
+	// operations and signatures of a transaction.
+	maxSweepOperations = 100
+	maxSweepSignatures = 20
+	// defaultBaseReserve is the base reserve of the public network, in
+	// stroops.
+	defaultBaseReserve = 5000000
+)
+
+// SweepPlanner plans the consolidation of many accounts into a target
+// account, e.g. the deposit accounts of an exchange into its cold wallet:
+// the balances of the accounts are paid to the target and the accounts are
+// merged into it, after their offers, trustlines, data entries and signers
+// are removed.
+//
+// The operations of the accounts are packed, in the order of the accounts,
+// in as few transactions as the operation and signature limits allow. The
+// transactions are paid by FeeAccount, so that the accounts are swept
+// entirely, and must be signed by it and by the accounts, once each, e.g.
+// with their master keys.
+type SweepPlanner struct {
+	Client *Client
+	// Target is the account receiving the balances.
+	Target string
+	// FeeAccount is the source of the transactions, Target if empty.
+	FeeAccount string
+	// BaseFee is the fee per operation of the transactions,
+	// txnbuild.MinBaseFee if 0.
+	BaseFee int64
+	// MaxOperations is the maximum number of operations per transaction, 100
+	// if 0.
+	MaxOperations int
+	// BaseReserve is the base reserve of the network in stroops, the one of
+	// the public network if 0. It is used to sweep the native balance of the
+	// accounts which cannot be merged.
+	BaseReserve int64
+	// Timebounds are the time bounds of the transactions, a timeout of 5
+	// minutes if they are not set.
+	Timebounds txnbuild.Timebounds
+}
+
+// SweptAccount is the outcome of the consolidation of an account planned by
+// a SweepPlanner.
+type SweptAccount struct {
+	AccountID string
+	// Merged is true if the account is merged into the target. When it is
+	// not, the balances which can be, including the native balance above the
+	// reserve, are swept.
+	Merged bool
+	// Issues are the reasons why the account is not merged or some of its
+	// balances are not swept.
+	Issues []string
+}
+
+// SweepTransaction is a transaction of a SweepPlan, with the accounts which
+// must sign it, the fee account first.
+type SweepTransaction struct {
+	Transaction *txnbuild.Transaction
+	Signers     []string
+}
+
+// SweepPlan is the plan returned by SweepPlanner.Plan. The transactions must
+// be submitted in order.
+type SweepPlan struct {
+	Transactions []SweepTransaction
+	Accounts     []SweptAccount
+}
+
+// sweepGroup are the operations sweeping an account.
+type sweepGroup struct {
+	account    string
+	operations []txnbuild.Operation
+}
+
+// Plan loads the target, the fee account and the accounts and plans their
+// consolidation. The target and the fee account are not swept if they are
+// among the accounts.
+func (p *SweepPlanner) Plan(accounts []string) (SweepPlan, error) {
+	if p.Target == "" {
+		return SweepPlan{}, errors.New("sweep planner has no target")
+	}
+	target, err := p.Client.AccountDetail(AccountRequest{AccountID: p.Target})
+	if err != nil {
+		return SweepPlan{}, errors.Wrap(err, "error loading target account")
+	}
+	feeAccount := target
+	if p.FeeAccount != "" && p.FeeAccount != p.Target {
+		feeAccount, err = p.Client.AccountDetail(AccountRequest{AccountID: p.FeeAccount})
+		if err != nil {
+			return SweepPlan{}, errors.Wrap(err, "error loading fee account")
+		}
+	}
+
+	// headroom is what the trustlines of the target can still receive
+	headroom := map[string]int64{}
+	for _, balance := range target.Balances {
+		if balance.Type == "native" || balance.LiquidityPoolId != "" {
+			continue
+		}
+		if balance.IsAuthorized != nil && !*balance.IsAuthorized {
+			continue
+		}
+		limit, err := amount.ParseInt64(balance.Limit)
+		if err != nil {
+			return SweepPlan{}, errors.Wrapf(err, "invalid limit of trustline to %s", sweepAssetKey(balance))
+		}
+		held, buying, err := sweepAmounts(balance.Balance, balance.BuyingLiabilities)
+		if err != nil {
+			return SweepPlan{}, errors.Wrapf(err, "invalid balance of trustline to %s", sweepAssetKey(balance))
+		}
+		headroom[sweepAssetKey(balance)] = limit - held - buying
+	}
+
+	var plan SweepPlan
+	var groups []sweepGroup
+	seen := map[string]bool{p.Target: true, feeAccount.AccountID: true}
+	for _, accountID := range accounts {
+		if seen[accountID] {
+			continue
+		}
+		seen[accountID] = true
+		account, err := p.Client.AccountDetail(AccountRequest{AccountID: accountID})
+		if err != nil {
+			return SweepPlan{}, errors.Wrapf(err, "error loading account %s", accountID)
+		}
+		swept, operations, err := p.planAccount(account, headroom)
+		if err != nil {
+			return SweepPlan{}, errors.Wrapf(err, "error planning sweep of account %s", accountID)
+		}
+		plan.Accounts = append(plan.Accounts, swept)
+		if len(operations) > 0 {
+			groups = append(groups, sweepGroup{account: accountID, operations: operations})
+		}
+	}
+
+	plan.Transactions, err = p.pack(&feeAccount, groups)
+	if err != nil {
+		return SweepPlan{}, err
+	}
+	return plan, nil
+}
+
+// planAccount returns the operations sweeping an account, updating the
+// headroom of the trustlines of the target.
+func (p *SweepPlanner) planAccount(account hProtocol.Account, headroom map[string]int64) (SweptAccount, []txnbuild.Operation, error) {
+	id := account.AccountID
+	swept := SweptAccount{AccountID: id}
+	mergeable := true
+	block := func(format string, args ...interface{}) {
+		swept.Issues = append(swept.Issues, fmt.Sprintf(format, args...))
+		mergeable = false
+	}
+	if account.NumSponsoring > 0 {
+		block("the account sponsors %d reserves", account.NumSponsoring)
+	}
+	if account.Flags.AuthImmutable {
+		block("the account has the auth immutable flag")
+	}
+
+	// the offers are deleted first, releasing the liabilities of the
+	// balances
+	var operations []txnbuild.Operation
+	offers, err := p.loadOffers(account)
+	if err != nil {
+		return swept, nil, err
+	}
+	for _, offer := range offers {
+		operations = append(operations, &txnbuild.ManageSellOffer{
+			Selling:       sweepAsset(offer.Selling.Type, offer.Selling.Code, offer.Selling.Issuer),
+			Buying:        sweepAsset(offer.Buying.Type, offer.Buying.Code, offer.Buying.Issuer),
+			Amount:        "0",
+			Price:         xdr.Price{N: xdr.Int32(offer.PriceR.N), D: xdr.Int32(offer.PriceR.D)},
+			OfferID:       offer.ID,
+			SourceAccount: id,
+		})
+	}
+
+	var native int64
+	var removals []txnbuild.Operation
+	for _, balance := range account.Balances {
+		if balance.Type == "native" {
+			if native, err = amount.ParseInt64(balance.Balance); err != nil {
+				return swept, nil, errors.Wrap(err, "invalid native balance")
+			}
+			continue
+		}
+		if balance.LiquidityPoolId != "" {
+			block("the account holds shares of liquidity pool %s", balance.LiquidityPoolId)
+			continue
+		}
+
+		key := sweepAssetKey(balance)
+		asset := txnbuild.CreditAsset{Code: balance.Code, Issuer: balance.Issuer}
+		held, err := amount.ParseInt64(balance.Balance)
+		if err != nil {
+			return swept, nil, errors.Wrapf(err, "invalid balance of trustline to %s", key)
+		}
+		if held > 0 {
+			if balance.IsAuthorized != nil && !*balance.IsAuthorized {
+				block("the trustline to %s is not authorized", key)
+				continue
+			}
+			// an issuer can always receive its own asset
+			if balance.Issuer != p.Target {
+				room, ok := headroom[key]
+				if !ok {
+					block("the target cannot receive %s", key)
+					continue
+				}
+				if room < held {
+					block("the trustline of the target to %s cannot receive %s more", key, balance.Balance)
+					continue
+				}
+				headroom[key] = room - held
+			}
+			operations = append(operations, &txnbuild.Payment{
+				Destination:   p.Target,
+				Amount:        balance.Balance,
+				Asset:         asset,
+				SourceAccount: id,
+			})
+		}
+		removals = append(removals, &txnbuild.ChangeTrust{
+			Line:          asset.MustToChangeTrustAsset(),
+			Limit:         "0",
+			SourceAccount: id,
+		})
+	}
+
+	if !mergeable {
+		// the native balance above the reserve, once the offers are deleted,
+		// is swept
+		reserve := p.BaseReserve
+		if reserve == 0 {
+			reserve = defaultBaseReserve
+		}
+		entries := int64(2+account.SubentryCount-int32(len(offers))) + int64(account.NumSponsoring) - int64(account.NumSponsored)
+		if excess := native - entries*reserve; excess > 0 {
+			operations = append(operations, &txnbuild.Payment{
+				Destination:   p.Target,
+				Amount:        amount.StringFromInt64(excess),
+				Asset:         txnbuild.NativeAsset{},
+				SourceAccount: id,
+			})
+		}
+		return swept, operations, nil
+	}
+
+	operations = append(operations, removals...)
+	var names []string
+	for name := range account.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		operations = append(operations, &txnbuild.ManageData{Name: name, SourceAccount: id})
+	}
+	for _, signer := range account.Signers {
+		if signer.Key == id {
+			continue
+		}
+		operations = append(operations, &txnbuild.SetOptions{
+			Signer:        &txnbuild.Signer{Address: signer.Key, Weight: 0},
+			SourceAccount: id,
+		})
+	}
+	operations = append(operations, &txnbuild.AccountMerge{Destination: p.Target, SourceAccount: id})
+	swept.Merged = true
+	return swept, operations, nil
+}
+
+// loadOffers loads the offers of the account if it has liabilities.
+func (p *SweepPlanner) loadOffers(account hProtocol.Account) ([]hProtocol.Offer, error) {
+	hasLiabilities := false
+	for _, balance := range account.Balances {
+		buying, selling, err := sweepAmounts(balance.BuyingLiabilities, balance.SellingLiabilities)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid liabilities")
+		}
+		if buying > 0 || selling > 0 {
+			hasLiabilities = true
+		}
+	}
+	if !hasLiabilities {
+		return nil, nil
+	}
+
+	var offers []hProtocol.Offer
+	page, err := p.Client.Offers(OfferRequest{ForAccount: account.AccountID, Limit: 200})
+	for {
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading offers")
+		}
+		offers = append(offers, page.Embedded.Records...)
+		if len(page.Embedded.Records) < 200 {
+			return offers, nil
+		}
+		page, err = p.Client.NextOffersPage(page)
+	}
+}
+
+// pack packs the operations of the accounts in transactions paid by the fee
+// account, starting a new transaction when the current one is full or has
+// as many signers as it can.
+func (p *SweepPlanner) pack(feeAccount *hProtocol.Account, groups []sweepGroup) ([]SweepTransaction, error) {
+	maxOperations := p.MaxOperations
+	if maxOperations <= 0 || maxOperations > maxSweepOperations {
+		maxOperations = maxSweepOperations
+	}
+	baseFee := p.BaseFee
+	if baseFee == 0 {
+		baseFee = txnbuild.MinBaseFee
+	}
+	timebounds := p.Timebounds
+	if timebounds == (txnbuild.Timebounds{}) {
+		timebounds = txnbuild.NewTimeout(300)
+	}
+
+	var transactions []SweepTransaction
+	var operations []txnbuild.Operation
+	signers := []string{feeAccount.AccountID}
+	flush := func() error {
+		if len(operations) == 0 {
+			return nil
+		}
+		tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+			SourceAccount:        feeAccount,
+			IncrementSequenceNum: true,
+			Operations:           operations,
+			BaseFee:              baseFee,
+			Timebounds:           timebounds,
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not build sweep transaction")
+		}
+		transactions = append(transactions, SweepTransaction{Transaction: tx, Signers: signers})
+		operations = nil
+		signers = []string{feeAccount.AccountID}
+		return nil
+	}
+
+	for _, group := range groups {
+		for _, op := range group.operations {
+			newSigner := signers[len(signers)-1] != group.account
+			if len(operations) == maxOperations || (newSigner && len(signers) == maxSweepSignatures) {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				newSigner = true
+			}
+			if newSigner {
+				signers = append(signers, group.account)
+			}
+			operations = append(operations, op)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func sweepAsset(typ, code, issuer string) txnbuild.Asset {
+	if typ == "native" {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: code, Issuer: issuer}
+}
+
+func sweepAssetKey(balance hProtocol.Balance) string {
+	return balance.Code + ":" + balance.Issuer
+}
+
+// sweepAmounts parses two amounts, empty amounts being 0.
+func sweepAmounts(a, b string) (int64, int64, error) {
+	var parsed [2]int64
+	for i, s := range []string{a, b} {
+		if s == "" {
+			continue
+		}
+		var err error
+		if parsed[i], err = amount.ParseInt64(s); err != nil {
+			return 0, 0, err
+		}
+	}
+	return parsed[0], parsed[1], nil
+}
@@ -0,0 +1,143 @@
+package horizonclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/txnbuild"
+)
+
+func sweepAccount(id string, native string, balances ...hProtocol.Balance) hProtocol.Account {
+	return hProtocol.Account{
+		AccountID: id,
+		Sequence:  "100",
+		Balances:  append(balances, hProtocol.Balance{Balance: native, Asset: base.Asset{Type: "native"}}),
+		Signers:   []hProtocol.Signer{{Key: id, Weight: 1, Type: "ed25519_public_key"}},
+	}
+}
+
+func mockSweepAccount(hmock *httptest.Client, account hProtocol.Account) {
+	hmock.On("GET", "https://localhost/accounts/"+account.AccountID).
+		ReturnJSON(200, account)
+}
+
+func TestSweepPlanner(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	issuer := keypair.MustRandom().Address()
+	usd := base.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: issuer}
+	eur := base.Asset{Type: "credit_alphanum4", Code: "EUR", Issuer: issuer}
+
+	target := sweepAccount(keypair.MustRandom().Address(), "1000.0000000",
+		hProtocol.Balance{Balance: "990.0000000", Limit: "1000.0000000", Asset: usd},
+	)
+	mockSweepAccount(hmock, target)
+
+	merged := sweepAccount(keypair.MustRandom().Address(), "10.0000000",
+		hProtocol.Balance{Balance: "5.0000000", Limit: "100.0000000", SellingLiabilities: "1.0000000", Asset: usd},
+	)
+	merged.SubentryCount = 4
+	merged.Data = map[string]string{"memo": "aGVsbG8="}
+	cosigner := keypair.MustRandom().Address()
+	merged.Signers = append(merged.Signers, hProtocol.Signer{Key: cosigner, Weight: 1, Type: "ed25519_public_key"})
+	mockSweepAccount(hmock, merged)
+	hmock.On("GET", "https://localhost/accounts/"+merged.AccountID+"/offers?limit=200").
+		ReturnJSON(200, hProtocol.OffersPage{Embedded: struct {
+			Records []hProtocol.Offer `json:"records"`
+		}{Records: []hProtocol.Offer{{
+			ID:      42,
+			Seller:  merged.AccountID,
+			Selling: hProtocol.Asset(usd),
+			Buying:  hProtocol.Asset{Type: "native"},
+			Amount:  "1.0000000",
+			PriceR:  hProtocol.Price{N: 1, D: 2},
+		}}}})
+
+	// the target trusts USD up to 10 more, and does not trust EUR
+	sponsor := sweepAccount(keypair.MustRandom().Address(), "100.0000000",
+		hProtocol.Balance{Balance: "6.0000000", Limit: "100.0000000", Asset: usd},
+		hProtocol.Balance{Balance: "3.0000000", Limit: "100.0000000", Asset: eur},
+	)
+	sponsor.SubentryCount = 2
+	sponsor.NumSponsoring = 1
+	mockSweepAccount(hmock, sponsor)
+
+	planner := &SweepPlanner{Client: client, Target: target.AccountID, MaxOperations: 4}
+	plan, err := planner.Plan([]string{merged.AccountID, target.AccountID, sponsor.AccountID, merged.AccountID})
+	require.NoError(t, err)
+
+	assert.Equal(t, []SweptAccount{
+		{AccountID: merged.AccountID, Merged: true},
+		{AccountID: sponsor.AccountID, Issues: []string{
+			"the account sponsors 1 reserves",
+			"the trustline of the target to USD:" + issuer + " cannot receive 6.0000000 more",
+			"the target cannot receive EUR:" + issuer,
+		}},
+	}, plan.Accounts)
+
+	require.Len(t, plan.Transactions, 2)
+	assert.Equal(t, []string{target.AccountID, merged.AccountID}, plan.Transactions[0].Signers)
+	assert.Equal(t, []string{target.AccountID, merged.AccountID, sponsor.AccountID}, plan.Transactions[1].Signers)
+
+	operations := append(plan.Transactions[0].Transaction.Operations(), plan.Transactions[1].Transaction.Operations()...)
+	require.Len(t, operations, 7)
+	assert.Equal(t, &txnbuild.ManageSellOffer{
+		Selling:       txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+		Buying:        txnbuild.NativeAsset{},
+		Amount:        "0",
+		Price:         operations[0].(*txnbuild.ManageSellOffer).Price,
+		OfferID:       42,
+		SourceAccount: merged.AccountID,
+	}, operations[0])
+	assert.Equal(t, "5.0000000", operations[1].(*txnbuild.Payment).Amount)
+	assert.Equal(t, "0", operations[2].(*txnbuild.ChangeTrust).Limit)
+	assert.Equal(t, "memo", operations[3].(*txnbuild.ManageData).Name)
+	assert.Equal(t, cosigner, operations[4].(*txnbuild.SetOptions).Signer.Address)
+	assert.Equal(t, &txnbuild.AccountMerge{Destination: target.AccountID, SourceAccount: merged.AccountID}, operations[5])
+	// the native balance above the reserve of the account, its two trustlines
+	// and the reserve it sponsors
+	assert.Equal(t, &txnbuild.Payment{
+		Destination:   target.AccountID,
+		Amount:        "97.5000000",
+		Asset:         txnbuild.NativeAsset{},
+		SourceAccount: sponsor.AccountID,
+	}, operations[6])
+
+	assert.Equal(t, int64(101), plan.Transactions[0].Transaction.SequenceNumber())
+	assert.Equal(t, int64(102), plan.Transactions[1].Transaction.SequenceNumber())
+}
+
+func TestSweepPlannerSignatureLimit(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	target := sweepAccount(keypair.MustRandom().Address(), "1000.0000000")
+	feeAccount := sweepAccount(keypair.MustRandom().Address(), "1000.0000000")
+	mockSweepAccount(hmock, target)
+	mockSweepAccount(hmock, feeAccount)
+
+	var accounts []string
+	for i := 0; i < 25; i++ {
+		account := sweepAccount(keypair.MustRandom().Address(), fmt.Sprintf("%d.0000000", i+1))
+		mockSweepAccount(hmock, account)
+		accounts = append(accounts, account.AccountID)
+	}
+
+	planner := &SweepPlanner{Client: client, Target: target.AccountID, FeeAccount: feeAccount.AccountID}
+	plan, err := planner.Plan(accounts)
+	require.NoError(t, err)
+	require.Len(t, plan.Transactions, 2)
+	assert.Len(t, plan.Transactions[0].Transaction.Operations(), 19)
+	assert.Equal(t, append([]string{feeAccount.AccountID}, accounts[:19]...), plan.Transactions[0].Signers)
+	assert.Equal(t, append([]string{feeAccount.AccountID}, accounts[19:]...), plan.Transactions[1].Signers)
+	assert.Equal(t, feeAccount.AccountID, plan.Transactions[1].Transaction.SourceAccount().AccountID)
+
+	_, err = (&SweepPlanner{Client: client}).Plan(accounts)
+	assert.EqualError(t, err, "sweep planner has no target")
+}
@@ -2,7 +2,6 @@ package horizonclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -95,7 +94,7 @@ func (tr TradeRequest) StreamTrades(ctx context.Context, client *Client,
 
 	return client.stream(ctx, url, func(data []byte) error {
 		var trade hProtocol.Trade
-		err = json.Unmarshal(data, &trade)
+		err = client.StrictDecoding.unmarshal(data, &trade)
 		if err != nil {
 			return errors.Wrap(err, "error unmarshaling data")
 		}
@@ -2,7 +2,6 @@ package horizonclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -77,7 +76,7 @@ func (tr TransactionRequest) StreamTransactions(ctx context.Context, client *Cli
 
 	return client.stream(ctx, url, func(data []byte) error {
 		var transaction hProtocol.Transaction
-		err = json.Unmarshal(data, &transaction)
+		err = client.StrictDecoding.unmarshal(data, &transaction)
 		if err != nil {
 			return errors.Wrap(err, "error unmarshaling data")
 		}
@@ -0,0 +1,52 @@
+package derivation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/stellar/go/keypair"
+)
+
+// ErrInvalidMnemonic is returned by FromMnemonic when the phrase is not a
+// valid BIP-39 mnemonic: a word is not in the english wordlist, or its
+// checksum does not match.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// FromMnemonic derives the keypair of an index from a BIP-39 mnemonic phrase
+// and its optional passphrase, as described by SEP-5: the key is derived on
+// the path m/44'/148'/index' (see StellarAccountPathFormat), so that the same
+// keypairs are derived from the phrase as by hardware wallets and other
+// wallets. The primary account is the one of index 0.
+//
+// The words of the phrase can be separated by any whitespace. A passphrase
+// with non ASCII characters must be normalized in NFKD.
+func FromMnemonic(phrase, passphrase string, index uint32) (*keypair.Full, error) {
+	if index >= FirstHardenedIndex {
+		return nil, fmt.Errorf("index must be lower than %d", FirstHardenedIndex)
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(strings.Join(strings.Fields(phrase), " "), passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	defer zero(seed)
+
+	key, err := DeriveForPath(fmt.Sprintf(StellarAccountPathFormat, index), seed)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(key.Key)
+	defer zero(key.ChainCode)
+
+	rawSeed := key.RawSeed()
+	defer zero(rawSeed[:])
+	return keypair.FromRawSeed(rawSeed)
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,70 @@
+package derivation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The test vectors of SEP-5.
+func TestFromMnemonic(t *testing.T) {
+	for _, testCase := range []struct {
+		phrase     string
+		passphrase string
+		index      uint32
+		address    string
+		seed       string
+	}{
+		{
+			phrase:  "illness spike retreat truth genius clock brain pass fit cave bargain toe",
+			index:   0,
+			address: "GDRXE2BQUC3AZNPVFSCEZ76NJ3WWL25FYFK6RGZGIEKWE4SOOHSUJUJ6",
+			seed:    "SBGWSG6BTNCKCOB3DIFBGCVMUPQFYPA2G4O34RMTB343OYPXU5DJDVMN",
+		},
+		{
+			phrase:  "illness spike retreat truth genius clock brain pass fit cave bargain toe",
+			index:   9,
+			address: "GBTVYYDIYWGUQUTKX6ZMLGSZGMTESJYJKJWAATGZGITA25ZB6T5REF44",
+			seed:    "SCJGVMJ66WAUHQHNLMWDFGY2E72QKSI3XGSBYV6BANDFUFE7VY4XNXXR",
+		},
+		{
+			phrase: "bench hurt jump file august wise shallow faculty impulse spring exact slush " +
+				"thunder author capable act festival slice deposit sauce coconut afford frown better",
+			index:   1,
+			address: "GB3MTYFXPBZBUINVG72XR7AQ6P2I32CYSXWNRKJ2PV5H5C7EAM5YYISO",
+			seed:    "SBKSABCPDWXDFSZISAVJ5XKVIEWV4M5O3KBRRLSPY3COQI7ZP423FYB4",
+		},
+		{
+			phrase: "cable spray genius state float twenty onion head street palace net private " +
+				"method loan turn phrase state blanket interest dry amazing dress blast tube",
+			passphrase: "p4ssphr4se",
+			index:      0,
+			address:    "GDAHPZ2NSYIIHZXM56Y36SBVTV5QKFIZGYMMBHOU53ETUSWTP62B63EQ",
+			seed:       "SAFWTGXVS7ELMNCXELFWCFZOPMHUZ5LXNBGUVRCY3FHLFPXK4QPXYP2X",
+		},
+		{
+			// the words can be separated by any whitespace
+			phrase:  "  abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon  about ",
+			index:   0,
+			address: "GB3JDWCQJCWMJ3IILWIGDTQJJC5567PGVEVXSCVPEQOTDN64VJBDQBYX",
+			seed:    "SBUV3MRWKNS6AYKZ6E6MOUVF2OYMON3MIUASWL3JLY5E3ISDJFELYBRZ",
+		},
+	} {
+		kp, err := FromMnemonic(testCase.phrase, testCase.passphrase, testCase.index)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.address, kp.Address())
+		assert.Equal(t, testCase.seed, kp.Seed())
+	}
+}
+
+func TestFromMnemonicInvalid(t *testing.T) {
+	// the checksum does not match
+	_, err := FromMnemonic("illness spike retreat truth genius clock brain pass fit cave bargain illness", "", 0)
+	assert.Equal(t, ErrInvalidMnemonic, err)
+	_, err = FromMnemonic("illness spike retreat truth genius clock brain pass fit cave bargain stellar", "", 0)
+	assert.Equal(t, ErrInvalidMnemonic, err)
+
+	_, err = FromMnemonic("illness spike retreat truth genius clock brain pass fit cave bargain toe", "", FirstHardenedIndex)
+	assert.EqualError(t, err, "index must be lower than 2147483648")
+}
@@ -0,0 +1,149 @@
+package historyarchive
+
+import (
+	"io"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// CategoryHandlers are the callbacks StreamCheckpoint and StreamRange call
+// with the records of the category files of checkpoints, one record at a
+// time, so that the files are never held in memory. The categories without a
+// handler are not read. Returning an error from a handler stops the reading
+// and is returned.
+type CategoryHandlers struct {
+	LedgerHeader func(xdr.LedgerHeaderHistoryEntry) error
+	Transactions func(xdr.TransactionHistoryEntry) error
+	Results      func(xdr.TransactionHistoryResultEntry) error
+	SCP          func(xdr.ScpHistoryEntry) error
+}
+
+// StreamLedgerHeaders calls fn with every ledger header of a ledger file and
+// closes the stream.
+func StreamLedgerHeaders(stream *XdrStream, fn func(xdr.LedgerHeaderHistoryEntry) error) error {
+	return streamRecords(stream, func() error {
+		var entry xdr.LedgerHeaderHistoryEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		return fn(entry)
+	})
+}
+
+// StreamTransactions calls fn with the transaction set of every ledger of a
+// transactions file and closes the stream.
+func StreamTransactions(stream *XdrStream, fn func(xdr.TransactionHistoryEntry) error) error {
+	return streamRecords(stream, func() error {
+		var entry xdr.TransactionHistoryEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		return fn(entry)
+	})
+}
+
+// StreamResults calls fn with the transaction results of every ledger of a
+// results file and closes the stream.
+func StreamResults(stream *XdrStream, fn func(xdr.TransactionHistoryResultEntry) error) error {
+	return streamRecords(stream, func() error {
+		var entry xdr.TransactionHistoryResultEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		return fn(entry)
+	})
+}
+
+// StreamSCPHistory calls fn with every SCP history entry of a scp file and
+// closes the stream.
+func StreamSCPHistory(stream *XdrStream, fn func(xdr.ScpHistoryEntry) error) error {
+	return streamRecords(stream, func() error {
+		var entry xdr.ScpHistoryEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		return fn(entry)
+	})
+}
+
+// streamRecords calls next until it returns io.EOF or an error, and closes
+// the stream, which verifies its hash if one is expected.
+func streamRecords(stream *XdrStream, next func() error) error {
+	for {
+		err := next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			stream.closeReaders()
+			return err
+		}
+	}
+	return stream.Close()
+}
+
+// StreamCheckpoint reads the category files of the checkpoint having a
+// handler, in the order ledger, transactions, results and scp. The scp file
+// is optional: it is skipped if the archive does not have it.
+func (a *Archive) StreamCheckpoint(chk uint32, handlers CategoryHandlers) error {
+	if handlers.LedgerHeader != nil {
+		if err := a.streamCategory("ledger", chk, func(stream *XdrStream) error {
+			return StreamLedgerHeaders(stream, handlers.LedgerHeader)
+		}); err != nil {
+			return err
+		}
+	}
+	if handlers.Transactions != nil {
+		if err := a.streamCategory("transactions", chk, func(stream *XdrStream) error {
+			return StreamTransactions(stream, handlers.Transactions)
+		}); err != nil {
+			return err
+		}
+	}
+	if handlers.Results != nil {
+		if err := a.streamCategory("results", chk, func(stream *XdrStream) error {
+			return StreamResults(stream, handlers.Results)
+		}); err != nil {
+			return err
+		}
+	}
+	if handlers.SCP != nil {
+		if err := a.streamCategory("scp", chk, func(stream *XdrStream) error {
+			return StreamSCPHistory(stream, handlers.SCP)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamRange reads the category files of the checkpoints of rng, made by
+// CheckpointManager.MakeRange, in order, with StreamCheckpoint.
+func (a *Archive) StreamRange(rng Range, handlers CategoryHandlers) error {
+	frequency := uint64(a.checkpointManager.GetCheckpointFrequency())
+	for chk := uint64(rng.Low); chk <= uint64(rng.High); chk += frequency {
+		if err := a.StreamCheckpoint(uint32(chk), handlers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Archive) streamCategory(category string, chk uint32, stream func(*XdrStream) error) error {
+	exists, err := a.CategoryCheckpointExists(category, chk)
+	if err != nil {
+		return errors.Wrap(err, "could not check if category checkpoint exists")
+	}
+	if !exists {
+		if !categoryRequired(category) {
+			return nil
+		}
+		return errors.Errorf("%s file of checkpoint %d is missing", category, chk)
+	}
+
+	rdr, err := a.GetXdrStream(CategoryCheckpointPath(category, chk))
+	if err != nil {
+		return errors.Wrapf(err, "error opening %s stream of checkpoint %d", category, chk)
+	}
+	return errors.Wrapf(stream(rdr), "error streaming %s file of checkpoint %d", category, chk)
+}
@@ -0,0 +1,127 @@
+package historyarchive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+func categoryReaderFixture(t *testing.T) *Archive {
+	arch := GetTestMockArchive()
+	for _, chk := range []uint32{63, 127} {
+		var headers, transactions, results []xdrEntry
+		for _, seq := range []uint32{chk - 1, chk} {
+			headers = append(headers, &xdr.LedgerHeaderHistoryEntry{
+				Hash:   xdr.Hash{byte(seq)},
+				Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(seq)},
+			})
+			transactions = append(transactions, &xdr.TransactionHistoryEntry{
+				LedgerSeq: xdr.Uint32(seq),
+				TxSet:     xdr.TransactionSet{PreviousLedgerHash: xdr.Hash{byte(seq - 1)}},
+			})
+			results = append(results, &xdr.TransactionHistoryResultEntry{LedgerSeq: xdr.Uint32(seq)})
+		}
+		writeCategoryFile(t, arch.backend, CategoryCheckpointPath("ledger", chk), headers)
+		writeCategoryFile(t, arch.backend, CategoryCheckpointPath("transactions", chk), transactions)
+		writeCategoryFile(t, arch.backend, CategoryCheckpointPath("results", chk), results)
+	}
+	// only the first checkpoint has a scp file
+	writeCategoryFile(t, arch.backend, CategoryCheckpointPath("scp", 63), []xdrEntry{
+		&xdr.ScpHistoryEntry{V: 0, V0: &xdr.ScpHistoryEntryV0{
+			LedgerMessages: xdr.LedgerScpMessages{LedgerSeq: 63},
+		}},
+	})
+	return arch
+}
+
+func TestStreamCheckpoint(t *testing.T) {
+	arch := categoryReaderFixture(t)
+
+	var headers, transactions, results, scp []uint32
+	require.NoError(t, arch.StreamCheckpoint(63, CategoryHandlers{
+		LedgerHeader: func(entry xdr.LedgerHeaderHistoryEntry) error {
+			headers = append(headers, uint32(entry.Header.LedgerSeq))
+			return nil
+		},
+		Transactions: func(entry xdr.TransactionHistoryEntry) error {
+			transactions = append(transactions, uint32(entry.LedgerSeq))
+			return nil
+		},
+		Results: func(entry xdr.TransactionHistoryResultEntry) error {
+			results = append(results, uint32(entry.LedgerSeq))
+			return nil
+		},
+		SCP: func(entry xdr.ScpHistoryEntry) error {
+			scp = append(scp, uint32(entry.V0.LedgerMessages.LedgerSeq))
+			return nil
+		},
+	}))
+	assert.Equal(t, []uint32{62, 63}, headers)
+	assert.Equal(t, []uint32{62, 63}, transactions)
+	assert.Equal(t, []uint32{62, 63}, results)
+	assert.Equal(t, []uint32{63}, scp)
+
+	// the categories without handlers are not read
+	headers = nil
+	require.NoError(t, arch.StreamCheckpoint(127, CategoryHandlers{
+		LedgerHeader: func(entry xdr.LedgerHeaderHistoryEntry) error {
+			headers = append(headers, uint32(entry.Header.LedgerSeq))
+			return nil
+		},
+	}))
+	assert.Equal(t, []uint32{126, 127}, headers)
+
+	err := arch.StreamCheckpoint(191, CategoryHandlers{
+		Results: func(entry xdr.TransactionHistoryResultEntry) error { return nil },
+	})
+	assert.EqualError(t, err, "results file of checkpoint 191 is missing")
+}
+
+func TestStreamRange(t *testing.T) {
+	arch := categoryReaderFixture(t)
+
+	var results, scp []uint32
+	require.NoError(t, arch.StreamRange(arch.checkpointManager.MakeRange(0, 127), CategoryHandlers{
+		Results: func(entry xdr.TransactionHistoryResultEntry) error {
+			results = append(results, uint32(entry.LedgerSeq))
+			return nil
+		},
+		// the scp file of the second checkpoint is optional
+		SCP: func(entry xdr.ScpHistoryEntry) error {
+			scp = append(scp, uint32(entry.V0.LedgerMessages.LedgerSeq))
+			return nil
+		},
+	}))
+	assert.Equal(t, []uint32{62, 63, 126, 127}, results)
+	assert.Equal(t, []uint32{63}, scp)
+
+	// an error of a handler stops the reading
+	stop := errors.New("stop")
+	var transactions []uint32
+	err := arch.StreamRange(arch.checkpointManager.MakeRange(0, 127), CategoryHandlers{
+		Transactions: func(entry xdr.TransactionHistoryEntry) error {
+			transactions = append(transactions, uint32(entry.LedgerSeq))
+			if entry.LedgerSeq == 63 {
+				return stop
+			}
+			return nil
+		},
+	})
+	assert.EqualError(t, err, "error streaming transactions file of checkpoint 63: stop")
+	assert.Equal(t, stop, errors.Cause(err))
+	assert.Equal(t, []uint32{62, 63}, transactions)
+}
+
+func TestStreamResultsHash(t *testing.T) {
+	arch := categoryReaderFixture(t)
+
+	stream, err := arch.GetXdrStream(CategoryCheckpointPath("results", 63))
+	require.NoError(t, err)
+	stream.SetExpectedHash(Hash{1})
+	err = StreamResults(stream, func(entry xdr.TransactionHistoryResultEntry) error { return nil })
+	assert.EqualError(t, err, "Stream hash does not match expected hash!")
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"strconv"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// BucketDifference is a bucket of the bucket lists of two history archive
+// states which differs.
+type BucketDifference struct {
+	// Level is the level of the bucket in the bucket list.
+	Level int `json:"level"`
+	// Field is "curr", "snap", "next.state" or "next.output".
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// HASDifference is the result of CompareHAS and CompareArchives.
+type HASDifference struct {
+	LedgerA uint32 `json:"ledger_a"`
+	LedgerB uint32 `json:"ledger_b"`
+	// LedgerLag is LedgerA minus LedgerB: it is positive when B lags behind
+	// A and negative when A lags behind B.
+	LedgerLag int64 `json:"ledger_lag"`
+	// NetworkPassphraseMismatch is true when the states have different
+	// network passphrases, which are missing in the states published by
+	// Stellar-Core before v14.1.0 and are not compared then.
+	NetworkPassphraseMismatch bool `json:"network_passphrase_mismatch"`
+	// Buckets are the buckets which differ between the states of the same
+	// ledger: the archives drifted and at least one of them is corrupt.
+	Buckets []BucketDifference `json:"buckets"`
+}
+
+// InSync returns true if the states are identical.
+func (d HASDifference) InSync() bool {
+	return d.LedgerLag == 0 && !d.NetworkPassphraseMismatch && len(d.Buckets) == 0
+}
+
+// Drifted returns true if the bucket lists of the states differ for the same
+// ledger.
+func (d HASDifference) Drifted() bool {
+	return len(d.Buckets) > 0
+}
+
+// CompareHAS returns the differences between the states. The bucket lists
+// are only compared when the states are of the same ledger, as the bucket
+// list changes with every ledger.
+func CompareHAS(a, b HistoryArchiveState) HASDifference {
+	d := HASDifference{
+		LedgerA:   a.CurrentLedger,
+		LedgerB:   b.CurrentLedger,
+		LedgerLag: int64(a.CurrentLedger) - int64(b.CurrentLedger),
+		NetworkPassphraseMismatch: a.NetworkPassphrase != "" && b.NetworkPassphrase != "" &&
+			a.NetworkPassphrase != b.NetworkPassphrase,
+	}
+	if d.LedgerLag == 0 {
+		d.Buckets = compareBuckets(a, b)
+	}
+	return d
+}
+
+func compareBuckets(a, b HistoryArchiveState) []BucketDifference {
+	var buckets []BucketDifference
+	for level := range a.CurrentBuckets {
+		ba, bb := a.CurrentBuckets[level], b.CurrentBuckets[level]
+		for _, field := range []struct {
+			name string
+			a, b string
+		}{
+			{"curr", ba.Curr, bb.Curr},
+			{"snap", ba.Snap, bb.Snap},
+			{"next.state", strconv.FormatUint(uint64(ba.Next.State), 10), strconv.FormatUint(uint64(bb.Next.State), 10)},
+			{"next.output", ba.Next.Output, bb.Next.Output},
+		} {
+			if field.a != field.b {
+				buckets = append(buckets, BucketDifference{Level: level, Field: field.name, A: field.a, B: field.b})
+			}
+		}
+	}
+	return buckets
+}
+
+// CompareArchives compares the current states of the archives, e.g. a
+// primary archive and one of its mirrors. When one of them lags behind, its
+// current state is compared with the state published by the other one for
+// the same checkpoint, so that a drift of their bucket lists is detected even
+// though their current ledgers differ.
+func CompareArchives(a, b ArchiveInterface) (HASDifference, error) {
+	stateA, err := a.GetRootHAS()
+	if err != nil {
+		return HASDifference{}, errors.Wrap(err, "error fetching root HAS of archive a")
+	}
+	stateB, err := b.GetRootHAS()
+	if err != nil {
+		return HASDifference{}, errors.Wrap(err, "error fetching root HAS of archive b")
+	}
+
+	d := CompareHAS(stateA, stateB)
+	switch {
+	case d.LedgerLag > 0:
+		checkpoint, err := a.GetCheckpointHAS(stateB.CurrentLedger)
+		if err != nil {
+			return d, errors.Wrapf(err, "error fetching HAS of checkpoint %d of archive a", stateB.CurrentLedger)
+		}
+		d.Buckets = compareBuckets(checkpoint, stateB)
+	case d.LedgerLag < 0:
+		checkpoint, err := b.GetCheckpointHAS(stateA.CurrentLedger)
+		if err != nil {
+			return d, errors.Wrapf(err, "error fetching HAS of checkpoint %d of archive b", stateA.CurrentLedger)
+		}
+		d.Buckets = compareBuckets(stateA, checkpoint)
+	}
+	return d, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compareTestHAS(ledger uint32, curr string) HistoryArchiveState {
+	has := HistoryArchiveState{
+		CurrentLedger:     ledger,
+		NetworkPassphrase: "Test SDF Network ; September 2015",
+	}
+	has.CurrentBuckets[0].Curr = curr
+	has.CurrentBuckets[1].Next.State = 1
+	has.CurrentBuckets[1].Next.Output = "bb"
+	return has
+}
+
+func TestCompareHAS(t *testing.T) {
+	d := CompareHAS(compareTestHAS(127, "aa"), compareTestHAS(127, "aa"))
+	assert.True(t, d.InSync())
+	assert.False(t, d.Drifted())
+
+	b := compareTestHAS(127, "ab")
+	b.CurrentBuckets[1].Next.State = 0
+	b.CurrentBuckets[1].Next.Output = ""
+	d = CompareHAS(compareTestHAS(127, "aa"), b)
+	assert.False(t, d.InSync())
+	assert.True(t, d.Drifted())
+	assert.Equal(t, []BucketDifference{
+		{Level: 0, Field: "curr", A: "aa", B: "ab"},
+		{Level: 1, Field: "next.state", A: "1", B: "0"},
+		{Level: 1, Field: "next.output", A: "bb", B: ""},
+	}, d.Buckets)
+
+	// the buckets of different ledgers are not compared
+	d = CompareHAS(compareTestHAS(63, "aa"), compareTestHAS(127, "ab"))
+	assert.Equal(t, HASDifference{LedgerA: 63, LedgerB: 127, LedgerLag: -64}, d)
+	assert.False(t, d.InSync())
+
+	b = compareTestHAS(127, "aa")
+	b.NetworkPassphrase = "Public Global Stellar Network ; September 2015"
+	d = CompareHAS(compareTestHAS(127, "aa"), b)
+	assert.True(t, d.NetworkPassphraseMismatch)
+	b.NetworkPassphrase = ""
+	assert.True(t, CompareHAS(compareTestHAS(127, "aa"), b).InSync())
+}
+
+func TestCompareArchives(t *testing.T) {
+	primary, mirror := &MockArchive{}, &MockArchive{}
+	primary.On("GetRootHAS").Return(compareTestHAS(191, "cc"), nil)
+	mirror.On("GetRootHAS").Return(compareTestHAS(127, "ab"), nil)
+	primary.On("GetCheckpointHAS", uint32(127)).Return(compareTestHAS(127, "aa"), nil).Once()
+
+	// the mirror lags behind and its state of checkpoint 127 differs
+	d, err := CompareArchives(primary, mirror)
+	require.NoError(t, err)
+	assert.Equal(t, HASDifference{
+		LedgerA:   191,
+		LedgerB:   127,
+		LedgerLag: 64,
+		Buckets:   []BucketDifference{{Level: 0, Field: "curr", A: "aa", B: "ab"}},
+	}, d)
+
+	primary.On("GetCheckpointHAS", uint32(127)).Return(compareTestHAS(127, "ab"), nil).Once()
+	d, err = CompareArchives(primary, mirror)
+	require.NoError(t, err)
+	assert.False(t, d.Drifted())
+
+	primary.On("GetCheckpointHAS", uint32(127)).Return(HistoryArchiveState{}, errors.New("not found")).Once()
+	d, err = CompareArchives(mirror, primary)
+	assert.EqualError(t, err, "error fetching HAS of checkpoint 127 of archive b: not found")
+	assert.Equal(t, int64(-64), d.LedgerLag)
+
+	broken := &MockArchive{}
+	broken.On("GetRootHAS").Return(HistoryArchiveState{}, errors.New("unavailable"))
+	_, err = CompareArchives(primary, broken)
+	assert.EqualError(t, err, "error fetching root HAS of archive b: unavailable")
+	primary.AssertExpectations(t)
+}
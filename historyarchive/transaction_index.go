@@ -0,0 +1,171 @@
+package historyarchive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// A transaction index file starts with a header made of the magic bytes
+// "STXI", the version of the format and the number of transactions, followed
+// by one record per transaction, sorted by hash: the transaction hash and
+// the sequence of its ledger. All the integers are big-endian.
+const (
+	transactionIndexMagic      = "STXI"
+	transactionIndexVersion    = uint32(1)
+	transactionIndexHeaderSize = 16
+	transactionIndexRecordSize = 36
+)
+
+type transactionIndexRecord struct {
+	hash   Hash
+	ledger uint32
+}
+
+// BuildTransactionIndex scans the results files of the checkpoints of
+// opts.Range and writes to w an index of the hashes of their transactions,
+// successful or not, to the ledgers containing them, which TransactionIndex
+// reads. It returns the number of transactions indexed.
+//
+// The index is built in memory, 36 bytes per transaction, before it is
+// written. An error is returned if a results file is missing, the index would
+// be incomplete otherwise.
+func BuildTransactionIndex(arch *Archive, opts *CommandOptions, w io.Writer) (int, error) {
+	state, err := arch.GetRootHAS()
+	if err != nil {
+		return 0, err
+	}
+	rng := opts.Range.clamp(state.Range(), arch.checkpointManager)
+
+	log.Printf("Indexing transactions in range: %s", rng)
+	var records []transactionIndexRecord
+	for chk := range rng.GenerateCheckpoints(arch.checkpointManager) {
+		exists, err := arch.CategoryCheckpointExists("results", chk)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, errors.Errorf("results file of checkpoint %d is missing", chk)
+		}
+		records, err = appendTransactionIndexRecords(records, arch, chk)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error indexing results file of checkpoint %d", chk)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return bytes.Compare(records[i].hash[:], records[j].hash[:]) < 0
+	})
+
+	buffered := bufio.NewWriter(w)
+	header := make([]byte, transactionIndexHeaderSize)
+	copy(header, transactionIndexMagic)
+	binary.BigEndian.PutUint32(header[4:], transactionIndexVersion)
+	binary.BigEndian.PutUint64(header[8:], uint64(len(records)))
+	if _, err := buffered.Write(header); err != nil {
+		return 0, err
+	}
+	record := make([]byte, transactionIndexRecordSize)
+	for _, r := range records {
+		copy(record, r.hash[:])
+		binary.BigEndian.PutUint32(record[32:], r.ledger)
+		if _, err := buffered.Write(record); err != nil {
+			return 0, err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return 0, err
+	}
+	log.Printf("Indexed %d transactions", len(records))
+	return len(records), nil
+}
+
+func appendTransactionIndexRecords(records []transactionIndexRecord, arch *Archive, chk uint32) ([]transactionIndexRecord, error) {
+	rdr, err := arch.GetXdrStream(CategoryCheckpointPath("results", chk))
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+
+	for {
+		var entry xdr.TransactionHistoryResultEntry
+		if err := rdr.ReadOne(&entry); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+		for _, result := range entry.TxResultSet.Results {
+			records = append(records, transactionIndexRecord{hash: Hash(result.TransactionHash), ledger: uint32(entry.LedgerSeq)})
+		}
+	}
+}
+
+// TransactionIndex looks up the ledgers of transactions in an index written
+// by BuildTransactionIndex, without loading it in memory.
+type TransactionIndex struct {
+	r     io.ReaderAt
+	count int64
+}
+
+// OpenTransactionIndex reads the header of the index read from r, e.g. an
+// *os.File.
+func OpenTransactionIndex(r io.ReaderAt) (*TransactionIndex, error) {
+	header := make([]byte, transactionIndexHeaderSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, errors.Wrap(err, "error reading transaction index header")
+	}
+	if string(header[:4]) != transactionIndexMagic {
+		return nil, errors.New("not a transaction index")
+	}
+	if version := binary.BigEndian.Uint32(header[4:]); version != transactionIndexVersion {
+		return nil, errors.Errorf("unsupported transaction index version %d", version)
+	}
+	return &TransactionIndex{r: r, count: int64(binary.BigEndian.Uint64(header[8:]))}, nil
+}
+
+// Len returns the number of transactions of the index.
+func (i *TransactionIndex) Len() int {
+	return int(i.count)
+}
+
+// Lookup returns the sequence of the ledger containing the transaction, and
+// false if the transaction is not in the index.
+func (i *TransactionIndex) Lookup(hash Hash) (uint32, bool, error) {
+	var err error
+	n := sort.Search(int(i.count), func(n int) bool {
+		if err != nil {
+			return true
+		}
+		var h Hash
+		h, _, err = i.record(int64(n))
+		return bytes.Compare(h[:], hash[:]) >= 0
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	if n == int(i.count) {
+		return 0, false, nil
+	}
+	h, ledger, err := i.record(int64(n))
+	if err != nil || h != hash {
+		return 0, false, err
+	}
+	return ledger, true, nil
+}
+
+func (i *TransactionIndex) record(n int64) (Hash, uint32, error) {
+	var h Hash
+	record := make([]byte, transactionIndexRecordSize)
+	if _, err := i.r.ReadAt(record, transactionIndexHeaderSize+n*transactionIndexRecordSize); err != nil {
+		return h, 0, errors.Wrapf(err, "error reading transaction index record %d", n)
+	}
+	copy(h[:], record)
+	return h, binary.BigEndian.Uint32(record[32:]), nil
+}
@@ -0,0 +1,98 @@
+package historyarchive
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transactionIndexFixture(t *testing.T) (*Archive, map[Hash]uint32) {
+	arch := GetTestMockArchive()
+	var has HistoryArchiveState
+	has.CurrentLedger = 127
+	require.NoError(t, arch.PutRootHAS(has, &CommandOptions{Force: true}))
+
+	hashes := map[Hash]uint32{}
+	for _, chk := range []uint32{63, 127} {
+		var entries []xdrEntry
+		for _, seq := range []uint32{chk - 10, chk} {
+			var resultSet xdr.TransactionResultSet
+			for i := byte(0); i < 3; i++ {
+				h := Hash{byte(seq), i}
+				hashes[h] = seq
+				resultSet.Results = append(resultSet.Results, xdr.TransactionResultPair{
+					TransactionHash: xdr.Hash(h),
+					Result: xdr.TransactionResult{
+						Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &[]xdr.OperationResult{}},
+					},
+				})
+			}
+			entries = append(entries, &xdr.TransactionHistoryResultEntry{LedgerSeq: xdr.Uint32(seq), TxResultSet: resultSet})
+		}
+		writeCategoryFile(t, arch.backend, CategoryCheckpointPath("results", chk), entries)
+	}
+	return arch, hashes
+}
+
+func TestTransactionIndex(t *testing.T) {
+	arch, hashes := transactionIndexFixture(t)
+
+	var buffer bytes.Buffer
+	n, err := BuildTransactionIndex(arch, &CommandOptions{Range: Range{Low: 0, High: 0xffffffff}}, &buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 12, n)
+	assert.Equal(t, transactionIndexHeaderSize+12*transactionIndexRecordSize, buffer.Len())
+
+	index, err := OpenTransactionIndex(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 12, index.Len())
+	for h, seq := range hashes {
+		ledger, ok, err := index.Lookup(h)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, seq, ledger)
+	}
+	for _, h := range []Hash{{}, {53, 3}, {0xff, 0xff}} {
+		_, ok, err := index.Lookup(h)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	// a truncated index fails when a missing record is read
+	truncated, err := OpenTransactionIndex(bytes.NewReader(buffer.Bytes()[:transactionIndexHeaderSize+transactionIndexRecordSize]))
+	require.NoError(t, err)
+	_, _, err = truncated.Lookup(Hash{0xff})
+	assert.Error(t, err)
+
+	_, err = OpenTransactionIndex(bytes.NewReader([]byte("not an index file")))
+	assert.EqualError(t, err, "not a transaction index")
+}
+
+func TestTransactionIndexRange(t *testing.T) {
+	arch, _ := transactionIndexFixture(t)
+
+	var buffer bytes.Buffer
+	n, err := BuildTransactionIndex(arch, &CommandOptions{Range: Range{Low: 127, High: 127}}, &buffer)
+	require.NoError(t, err)
+	// ledgers 117 and 127 only
+	assert.Equal(t, 6, n)
+	index, err := OpenTransactionIndex(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+	ledger, ok, err := index.Lookup(Hash{117, 2})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(117), ledger)
+	_, ok, err = index.Lookup(Hash{53, 2})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	missing := GetTestMockArchive()
+	var has HistoryArchiveState
+	has.CurrentLedger = 63
+	require.NoError(t, missing.PutRootHAS(has, &CommandOptions{Force: true}))
+	_, err = BuildTransactionIndex(missing, &CommandOptions{Range: Range{Low: 0, High: 63}}, &buffer)
+	assert.EqualError(t, err, "results file of checkpoint 63 is missing")
+}
@@ -0,0 +1,319 @@
+// Copyright 2016 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"fmt"
+	"io"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stellar/go/xdr"
+)
+
+// CorruptObject describes an object of an archive which failed verification.
+type CorruptObject struct {
+	// Path is the path of the object in the archive.
+	Path string `json:"path"`
+	// Error is the reason the object failed verification.
+	Error string `json:"error"`
+	// Source is the index of the alternate archive holding a valid copy of
+	// the object, or -1 if none of them does.
+	Source int `json:"source"`
+	// Repaired is true when the object was overwritten with the valid copy,
+	// which is never the case in dry-run mode.
+	Repaired bool `json:"repaired"`
+}
+
+// RepairReport is the result of VerifyAndRepair.
+type RepairReport struct {
+	// Checked is the number of objects which were verified.
+	Checked int `json:"checked"`
+	// Corrupt contains the objects which failed verification.
+	Corrupt []CorruptObject `json:"corrupt"`
+}
+
+// Unrepairable returns the number of corrupt objects for which no alternate
+// archive had a valid copy.
+func (r RepairReport) Unrepairable() int {
+	n := 0
+	for _, object := range r.Corrupt {
+		if object.Source < 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// VerifyAndRepair verifies the objects of dst in opts.Range and, when one
+// fails hash verification, refetches it from the first alternate archive
+// whose copy passes the same verification, overwriting the copy of dst.
+//
+// The following objects are verified:
+//   - buckets, whose hash must match their name
+//   - ledger files, whose headers must match their hashes and be chained
+//   - transactions and results files, whose hashes must match the headers of
+//     the ledger file of the same checkpoint
+//
+// Missing objects are skipped, Repair takes care of them. It assumes that all
+// the archives have the same checkpoint ledger frequency. An error is returned
+// when some corrupt objects could not be repaired, the report lists them.
+func VerifyAndRepair(dst *Archive, alternates []*Archive, opts *CommandOptions) (RepairReport, error) {
+	var report RepairReport
+	state, e := dst.GetRootHAS()
+	if e != nil {
+		return report, e
+	}
+	opts.Range = opts.Range.clamp(state.Range(), dst.checkpointManager)
+
+	log.Printf("Verifying and repairing objects in range: %s", opts.Range)
+	buckets := make(map[Hash]bool)
+	for chk := range opts.Range.GenerateCheckpoints(dst.checkpointManager) {
+		if err := verifyAndRepairCheckpoint(dst, alternates, chk, opts, &report); err != nil {
+			return report, err
+		}
+
+		exists, err := dst.CategoryCheckpointExists("history", chk)
+		if err != nil {
+			return report, err
+		}
+		if !exists {
+			continue
+		}
+		has, err := dst.GetCheckpointHAS(chk)
+		if err != nil {
+			return report, err
+		}
+		hashes, err := has.Buckets()
+		if err != nil {
+			return report, err
+		}
+		for _, h := range hashes {
+			buckets[h] = true
+		}
+	}
+
+	for h := range buckets {
+		h := h
+		exists, err := dst.BucketExists(h)
+		if err != nil {
+			return report, err
+		}
+		if !exists {
+			continue
+		}
+		err = verifyAndRepairObject(dst, alternates, BucketPath(h), func(arch *Archive) error {
+			return arch.VerifyBucketHash(h)
+		}, opts, &report)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"checked":      report.Checked,
+		"corrupt":      len(report.Corrupt),
+		"unrepairable": report.Unrepairable(),
+	}).Info("Finished verifying and repairing")
+	if n := report.Unrepairable(); n != 0 {
+		return report, fmt.Errorf("%d corrupt objects could not be repaired", n)
+	}
+	return report, nil
+}
+
+// verifyAndRepairCheckpoint verifies and repairs the ledger, transactions and
+// results files of a checkpoint.
+func verifyAndRepairCheckpoint(dst *Archive, alternates []*Archive, chk uint32, opts *CommandOptions, report *RepairReport) error {
+	// headers are the ones of the valid ledger file, either the one of dst or
+	// the one of the alternate it was repaired from
+	var headers map[uint32]xdr.LedgerHeader
+	process := func(cat string, verify func(arch *Archive) error) error {
+		exists, err := dst.CategoryCheckpointExists(cat, chk)
+		if err != nil || !exists {
+			return err
+		}
+		return verifyAndRepairObject(dst, alternates, CategoryCheckpointPath(cat, chk), verify, opts, report)
+	}
+
+	err := process("ledger", func(arch *Archive) error {
+		h, err := verifyLedgerFile(arch, chk)
+		if err == nil {
+			headers = h
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if headers == nil {
+		log.WithField("checkpoint", chk).Warn("Skipping transactions and results files without valid ledger file")
+		return nil
+	}
+
+	err = process("transactions", func(arch *Archive) error {
+		return verifyTransactionsFile(arch, chk, headers)
+	})
+	if err != nil {
+		return err
+	}
+	return process("results", func(arch *Archive) error {
+		return verifyResultsFile(arch, chk, headers)
+	})
+}
+
+// verifyAndRepairObject verifies the object of dst at pth and, if it is
+// corrupt, copies it from the first alternate for which verify succeeds. An
+// error is only returned when writing to dst fails.
+func verifyAndRepairObject(
+	dst *Archive,
+	alternates []*Archive,
+	pth string,
+	verify func(arch *Archive) error,
+	opts *CommandOptions,
+	report *RepairReport,
+) error {
+	report.Checked++
+	err := verify(dst)
+	if err == nil {
+		return nil
+	}
+
+	object := CorruptObject{Path: pth, Error: err.Error(), Source: -1}
+	logger := log.WithFields(log.Fields{"path": pth, "error": err})
+	logger.Warn("Object failed verification")
+
+	for i, alternate := range alternates {
+		if exists, err := alternate.backend.Exists(pth); err != nil || !exists {
+			continue
+		}
+		if err := verify(alternate); err != nil {
+			logger.WithFields(log.Fields{
+				"alternate":       i,
+				"alternate_error": err,
+			}).Warn("Alternate copy failed verification")
+			continue
+		}
+
+		object.Source = i
+		if !opts.DryRun {
+			forced := *opts
+			forced.Force = true
+			if err := copyPath(alternate, dst, pth, &forced); err != nil {
+				return err
+			}
+			object.Repaired = true
+		}
+		break
+	}
+
+	logger.WithFields(log.Fields{
+		"source":   object.Source,
+		"repaired": object.Repaired,
+	}).Info("Processed corrupt object")
+	report.Corrupt = append(report.Corrupt, object)
+	return nil
+}
+
+// verifyLedgerFile checks the hashes of the headers of a ledger file, and that
+// every header refers to the previous one, returning the headers by ledger
+// sequence.
+func verifyLedgerFile(arch *Archive, chk uint32) (map[uint32]xdr.LedgerHeader, error) {
+	rdr, err := arch.GetXdrStream(CategoryCheckpointPath("ledger", chk))
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+
+	headers := make(map[uint32]xdr.LedgerHeader)
+	var previous *xdr.LedgerHeaderHistoryEntry
+	for {
+		var entry xdr.LedgerHeaderHistoryEntry
+		if err = rdr.ReadOne(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		h, err := HashXdr(&entry.Header)
+		if err != nil {
+			return nil, err
+		}
+		if h != Hash(entry.Hash) {
+			return nil, fmt.Errorf("Ledger %d expected hash %s, got %s",
+				entry.Header.LedgerSeq, Hash(entry.Hash), h)
+		}
+		if previous != nil && entry.Header.PreviousLedgerHash != previous.Hash {
+			return nil, fmt.Errorf("Ledger %d expected previous hash %s, got %s",
+				entry.Header.LedgerSeq, Hash(previous.Hash), Hash(entry.Header.PreviousLedgerHash))
+		}
+		headers[uint32(entry.Header.LedgerSeq)] = entry.Header
+		previous = &entry
+	}
+	return headers, nil
+}
+
+// verifyTransactionsFile checks the transaction sets of a transactions file
+// against the given headers.
+func verifyTransactionsFile(arch *Archive, chk uint32, headers map[uint32]xdr.LedgerHeader) error {
+	rdr, err := arch.GetXdrStream(CategoryCheckpointPath("transactions", chk))
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+
+	for {
+		var entry xdr.TransactionHistoryEntry
+		if err = rdr.ReadOne(&entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		header, ok := headers[uint32(entry.LedgerSeq)]
+		if !ok {
+			return fmt.Errorf("Ledger %d is not in the ledger file", entry.LedgerSeq)
+		}
+		h, err := HashTxSet(&entry.TxSet)
+		if err != nil {
+			return err
+		}
+		if h != Hash(header.ScpValue.TxSetHash) {
+			return fmt.Errorf("Ledger %d expected tx set hash %s, got %s",
+				entry.LedgerSeq, Hash(header.ScpValue.TxSetHash), h)
+		}
+	}
+}
+
+// verifyResultsFile checks the result sets of a results file against the given
+// headers.
+func verifyResultsFile(arch *Archive, chk uint32, headers map[uint32]xdr.LedgerHeader) error {
+	rdr, err := arch.GetXdrStream(CategoryCheckpointPath("results", chk))
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+
+	for {
+		var entry xdr.TransactionHistoryResultEntry
+		if err = rdr.ReadOne(&entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		header, ok := headers[uint32(entry.LedgerSeq)]
+		if !ok {
+			return fmt.Errorf("Ledger %d is not in the ledger file", entry.LedgerSeq)
+		}
+		h, err := HashXdr(&entry.TxResultSet)
+		if err != nil {
+			return err
+		}
+		if h != Hash(header.TxSetResultHash) {
+			return fmt.Errorf("Ledger %d expected tx result set hash %s, got %s",
+				entry.LedgerSeq, Hash(header.TxSetResultHash), h)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type verifyRepairFixture struct {
+	bucket     Hash
+	bucketData []byte
+	ledgers    []xdrEntry
+	results    []xdrEntry
+}
+
+func newVerifyRepairFixture(t *testing.T) verifyRepairFixture {
+	var fixture verifyRepairFixture
+
+	var gz bytes.Buffer
+	writer := gzip.NewWriter(&gz)
+	raw := []byte("bucket entries")
+	_, err := writer.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	fixture.bucket = sha256.Sum256(raw)
+	fixture.bucketData = gz.Bytes()
+
+	resultSet := xdr.TransactionResultSet{}
+	resultSetHash, err := HashXdr(&resultSet)
+	require.NoError(t, err)
+
+	var previous xdr.Hash
+	for seq := uint32(62); seq <= 63; seq++ {
+		header := xdr.LedgerHeader{
+			LedgerSeq:          xdr.Uint32(seq),
+			PreviousLedgerHash: previous,
+			TxSetResultHash:    xdr.Hash(resultSetHash),
+		}
+		h, err := HashXdr(&header)
+		require.NoError(t, err)
+		previous = xdr.Hash(h)
+		fixture.ledgers = append(fixture.ledgers, &xdr.LedgerHeaderHistoryEntry{Hash: previous, Header: header})
+		fixture.results = append(fixture.results, &xdr.TransactionHistoryResultEntry{
+			LedgerSeq:   xdr.Uint32(seq),
+			TxResultSet: resultSet,
+		})
+	}
+	return fixture
+}
+
+func (f verifyRepairFixture) archive(t *testing.T) *Archive {
+	arch := GetTestMockArchive()
+	var has HistoryArchiveState
+	has.CurrentLedger = 63
+	has.CurrentBuckets[0].Curr = f.bucket.String()
+	opts := &CommandOptions{Force: true}
+	require.NoError(t, arch.PutCheckpointHAS(63, has, opts))
+	require.NoError(t, arch.PutRootHAS(has, opts))
+	require.NoError(t, arch.backend.PutFile(BucketPath(f.bucket), ioutil.NopCloser(bytes.NewReader(f.bucketData))))
+	writeCategoryFile(t, arch.backend, CategoryCheckpointPath("ledger", 63), f.ledgers)
+	writeCategoryFile(t, arch.backend, CategoryCheckpointPath("results", 63), f.results)
+	return arch
+}
+
+func corruptBucket(t *testing.T, arch *Archive, h Hash) {
+	var gz bytes.Buffer
+	writer := gzip.NewWriter(&gz)
+	_, err := writer.Write([]byte("corrupt"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, arch.backend.PutFile(BucketPath(h), ioutil.NopCloser(&gz)))
+}
+
+func TestVerifyAndRepair(t *testing.T) {
+	fixture := newVerifyRepairFixture(t)
+	dst := fixture.archive(t)
+	alternates := []*Archive{fixture.archive(t), fixture.archive(t)}
+
+	// the ledger file of dst is corrupt and alternates[0] has a valid copy
+	corruptLedger := *fixture.ledgers[1].(*xdr.LedgerHeaderHistoryEntry)
+	corruptLedger.Header.LedgerVersion = 1
+	writeCategoryFile(t, dst.backend, CategoryCheckpointPath("ledger", 63), []xdrEntry{fixture.ledgers[0], &corruptLedger})
+	// the bucket is corrupt in dst and alternates[0], alternates[1] has a
+	// valid copy
+	corruptBucket(t, dst, fixture.bucket)
+	corruptBucket(t, alternates[0], fixture.bucket)
+
+	report, err := VerifyAndRepair(dst, alternates, &CommandOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	require.Len(t, report.Corrupt, 2)
+	assert.Equal(t, CategoryCheckpointPath("ledger", 63), report.Corrupt[0].Path)
+	assert.Equal(t, 0, report.Corrupt[0].Source)
+	assert.False(t, report.Corrupt[0].Repaired)
+	assert.Equal(t, BucketPath(fixture.bucket), report.Corrupt[1].Path)
+	assert.Equal(t, 1, report.Corrupt[1].Source)
+	assert.Equal(t, "Bucket hash mismatch: expected "+fixture.bucket.String()+", got "+Hash(sha256.Sum256([]byte("corrupt"))).String(), report.Corrupt[1].Error)
+	assert.False(t, report.Corrupt[1].Repaired)
+	assert.Error(t, dst.VerifyBucketHash(fixture.bucket))
+
+	report, err = VerifyAndRepair(dst, alternates, &CommandOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Corrupt, 2)
+	assert.True(t, report.Corrupt[0].Repaired)
+	assert.True(t, report.Corrupt[1].Repaired)
+	assert.NoError(t, dst.VerifyBucketHash(fixture.bucket))
+
+	report, err = VerifyAndRepair(dst, alternates, &CommandOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Empty(t, report.Corrupt)
+}
+
+func TestVerifyAndRepairUnrepairable(t *testing.T) {
+	fixture := newVerifyRepairFixture(t)
+	dst := fixture.archive(t)
+	alternate := fixture.archive(t)
+
+	// the results do not match the headers anywhere
+	results := []xdrEntry{fixture.results[0], &xdr.TransactionHistoryResultEntry{
+		LedgerSeq: 63,
+		TxResultSet: xdr.TransactionResultSet{
+			Results: []xdr.TransactionResultPair{{
+				Result: xdr.TransactionResult{
+					FeeCharged: 100,
+					Result: xdr.TransactionResultResult{
+						Code:    xdr.TransactionResultCodeTxSuccess,
+						Results: &[]xdr.OperationResult{},
+					},
+				},
+			}},
+		},
+	}}
+	writeCategoryFile(t, dst.backend, CategoryCheckpointPath("results", 63), results)
+	writeCategoryFile(t, alternate.backend, CategoryCheckpointPath("results", 63), results)
+
+	report, err := VerifyAndRepair(dst, []*Archive{alternate}, &CommandOptions{})
+	assert.EqualError(t, err, "1 corrupt objects could not be repaired")
+	require.Len(t, report.Corrupt, 1)
+	assert.Equal(t, CategoryCheckpointPath("results", 63), report.Corrupt[0].Path)
+	assert.Equal(t, -1, report.Corrupt[0].Source)
+	assert.False(t, report.Corrupt[0].Repaired)
+	assert.Equal(t, 1, report.Unrepairable())
+}
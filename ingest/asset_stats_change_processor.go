@@ -0,0 +1,278 @@
+package ingest
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// HolderDistributionBuckets is the number of buckets of
+// AssetStats.HolderDistribution. It is enough to fit the order of magnitude
+// of the largest possible balance (math.MaxInt64 stroops).
+const HolderDistributionBuckets = 13
+
+// AssetStatsChangeProcessor is a state processor computing, for every asset,
+// the statistics exposed by Horizon's /assets endpoint: the number of
+// trustlines, claimable balances and liquidity pools holding the asset and
+// the amounts they hold. It also keeps track of the supply of the native asset
+// (held by accounts instead of trustlines) and of the distribution of the
+// holders' balances.
+//
+// The statistics are updated incrementally so the processor can be fed the
+// state of a checkpoint (see CheckpointChangeReader) followed by the changes
+// of the subsequent ledgers (see LedgerChangeReader). When it is only fed
+// ledger changes the results are the deltas of those ledgers.
+type AssetStatsChangeProcessor struct {
+	results AssetStatsChangeProcessorResults
+}
+
+// AssetStatsChangeProcessorResults contains the stats of all assets, keyed by
+// the canonical string representation of the asset (see
+// xdr.Asset.StringCanonical).
+type AssetStatsChangeProcessorResults map[string]*AssetStats
+
+// AssetStats contains the statistics of a single asset.
+type AssetStats struct {
+	Asset    xdr.Asset
+	Accounts AssetStatsAccounts
+	Balances AssetStatsBalances
+	// HolderDistribution counts the holders (trustlines or, for the native
+	// asset, accounts) by the order of magnitude of their balance. Bucket 0
+	// counts the balances lower than one unit, and bucket i > 0 the balances
+	// in [10^(i-1), 10^i) units.
+	HolderDistribution [HolderDistributionBuckets]int64
+}
+
+// AssetStatsAccounts contains the number of entries holding an asset. For the
+// native asset, accounts are counted as Authorized.
+type AssetStatsAccounts struct {
+	Authorized                      int64
+	AuthorizedToMaintainLiabilities int64
+	Unauthorized                    int64
+	ClaimableBalances               int64
+	LiquidityPools                  int64
+}
+
+// AssetStatsBalances contains the amounts of an asset (in stroops) held by
+// the entries counted in AssetStatsAccounts.
+type AssetStatsBalances struct {
+	Authorized                      *big.Int
+	AuthorizedToMaintainLiabilities *big.Int
+	Unauthorized                    *big.Int
+	ClaimableBalances               *big.Int
+	LiquidityPools                  *big.Int
+}
+
+// CirculatingSupply returns the total amount of the asset held by accounts,
+// trustlines (regardless of their authorization), claimable balances and
+// liquidity pools. Issuers do not hold their own assets so they are never
+// part of the supply.
+func (s *AssetStats) CirculatingSupply() *big.Int {
+	supply := new(big.Int)
+	supply.Add(supply, s.Balances.Authorized)
+	supply.Add(supply, s.Balances.AuthorizedToMaintainLiabilities)
+	supply.Add(supply, s.Balances.Unauthorized)
+	supply.Add(supply, s.Balances.ClaimableBalances)
+	supply.Add(supply, s.Balances.LiquidityPools)
+	return supply
+}
+
+// NumHolders returns the number of accounts or trustlines holding the asset.
+func (s *AssetStats) NumHolders() int64 {
+	return s.Accounts.Authorized +
+		s.Accounts.AuthorizedToMaintainLiabilities +
+		s.Accounts.Unauthorized
+}
+
+func (s *AssetStats) isZero() bool {
+	if s.Accounts != (AssetStatsAccounts{}) ||
+		s.HolderDistribution != [HolderDistributionBuckets]int64{} {
+		return false
+	}
+	return s.Balances.Authorized.Sign() == 0 &&
+		s.Balances.AuthorizedToMaintainLiabilities.Sign() == 0 &&
+		s.Balances.Unauthorized.Sign() == 0 &&
+		s.Balances.ClaimableBalances.Sign() == 0 &&
+		s.Balances.LiquidityPools.Sign() == 0
+}
+
+// NewAssetStatsChangeProcessor creates a new AssetStatsChangeProcessor.
+func NewAssetStatsChangeProcessor() *AssetStatsChangeProcessor {
+	return &AssetStatsChangeProcessor{
+		results: AssetStatsChangeProcessorResults{},
+	}
+}
+
+// ProcessChange updates the stats with the given change. Changes of entries
+// other than accounts, trustlines, claimable balances and liquidity pools are
+// ignored.
+func (p *AssetStatsChangeProcessor) ProcessChange(ctx context.Context, change Change) error {
+	if change.Pre == nil && change.Post == nil {
+		return NewStateError(errors.New("both pre and post entries cannot be nil"))
+	}
+
+	switch change.Type {
+	case xdr.LedgerEntryTypeAccount:
+		p.processAccount(change)
+	case xdr.LedgerEntryTypeTrustline:
+		p.processTrustLine(change)
+	case xdr.LedgerEntryTypeClaimableBalance:
+		p.processClaimableBalance(change)
+	case xdr.LedgerEntryTypeLiquidityPool:
+		return p.processLiquidityPool(change)
+	}
+	return nil
+}
+
+// GetResults returns the stats of all assets with a non-zero stat.
+func (p *AssetStatsChangeProcessor) GetResults() AssetStatsChangeProcessorResults {
+	return p.results
+}
+
+func (p *AssetStatsChangeProcessor) processAccount(change Change) {
+	native := xdr.MustNewNativeAsset()
+	if change.Pre != nil {
+		balance := int64(change.Pre.Data.MustAccount().Balance)
+		p.adjust(native, func(stats *AssetStats) {
+			stats.Accounts.Authorized--
+			stats.Balances.Authorized.Sub(stats.Balances.Authorized, big.NewInt(balance))
+			stats.HolderDistribution[holderDistributionBucket(balance)]--
+		})
+	}
+	if change.Post != nil {
+		balance := int64(change.Post.Data.MustAccount().Balance)
+		p.adjust(native, func(stats *AssetStats) {
+			stats.Accounts.Authorized++
+			stats.Balances.Authorized.Add(stats.Balances.Authorized, big.NewInt(balance))
+			stats.HolderDistribution[holderDistributionBucket(balance)]++
+		})
+	}
+}
+
+func (p *AssetStatsChangeProcessor) processTrustLine(change Change) {
+	var entry *xdr.TrustLineEntry
+	if change.Pre != nil {
+		entry = change.Pre.Data.TrustLine
+	} else {
+		entry = change.Post.Data.TrustLine
+	}
+	// Pool shares are not assets which can be held outside of pools, like in
+	// Horizon they are not part of the stats.
+	if entry.Asset.Type == xdr.AssetTypeAssetTypePoolShare {
+		return
+	}
+	asset := entry.Asset.ToAsset()
+
+	if change.Pre != nil {
+		p.adjustTrustLine(asset, change.Pre.Data.MustTrustLine(), -1)
+	}
+	if change.Post != nil {
+		p.adjustTrustLine(asset, change.Post.Data.MustTrustLine(), 1)
+	}
+}
+
+// adjustTrustLine adds (sign = 1) or removes (sign = -1) the given trustline
+// from the stats of the asset.
+func (p *AssetStatsChangeProcessor) adjustTrustLine(asset xdr.Asset, trustLine xdr.TrustLineEntry, sign int64) {
+	balance := int64(trustLine.Balance)
+	flags := xdr.TrustLineFlags(trustLine.Flags)
+	p.adjust(asset, func(stats *AssetStats) {
+		amount := big.NewInt(sign * balance)
+		switch {
+		case flags.IsAuthorized():
+			stats.Accounts.Authorized += sign
+			stats.Balances.Authorized.Add(stats.Balances.Authorized, amount)
+		case flags.IsAuthorizedToMaintainLiabilitiesFlag():
+			stats.Accounts.AuthorizedToMaintainLiabilities += sign
+			stats.Balances.AuthorizedToMaintainLiabilities.Add(stats.Balances.AuthorizedToMaintainLiabilities, amount)
+		default:
+			stats.Accounts.Unauthorized += sign
+			stats.Balances.Unauthorized.Add(stats.Balances.Unauthorized, amount)
+		}
+		stats.HolderDistribution[holderDistributionBucket(balance)] += sign
+	})
+}
+
+func (p *AssetStatsChangeProcessor) processClaimableBalance(change Change) {
+	if change.Pre != nil {
+		entry := change.Pre.Data.MustClaimableBalance()
+		p.adjust(entry.Asset, func(stats *AssetStats) {
+			stats.Accounts.ClaimableBalances--
+			stats.Balances.ClaimableBalances.Sub(stats.Balances.ClaimableBalances, big.NewInt(int64(entry.Amount)))
+		})
+	}
+	if change.Post != nil {
+		entry := change.Post.Data.MustClaimableBalance()
+		p.adjust(entry.Asset, func(stats *AssetStats) {
+			stats.Accounts.ClaimableBalances++
+			stats.Balances.ClaimableBalances.Add(stats.Balances.ClaimableBalances, big.NewInt(int64(entry.Amount)))
+		})
+	}
+}
+
+func (p *AssetStatsChangeProcessor) processLiquidityPool(change Change) error {
+	lpType, err := change.GetLiquidityPoolType()
+	if err != nil {
+		return NewStateError(err)
+	}
+	if lpType != xdr.LiquidityPoolTypeLiquidityPoolConstantProduct {
+		return errors.Errorf("Unknown liquidity pool type=%d", lpType)
+	}
+
+	adjustPool := func(entry *xdr.LiquidityPoolEntry, sign int64) {
+		cp := entry.Body.MustConstantProduct()
+		p.adjust(cp.Params.AssetA, func(stats *AssetStats) {
+			stats.Accounts.LiquidityPools += sign
+			stats.Balances.LiquidityPools.Add(stats.Balances.LiquidityPools, big.NewInt(sign*int64(cp.ReserveA)))
+		})
+		p.adjust(cp.Params.AssetB, func(stats *AssetStats) {
+			stats.Accounts.LiquidityPools += sign
+			stats.Balances.LiquidityPools.Add(stats.Balances.LiquidityPools, big.NewInt(sign*int64(cp.ReserveB)))
+		})
+	}
+	if change.Pre != nil {
+		adjustPool(change.Pre.Data.LiquidityPool, -1)
+	}
+	if change.Post != nil {
+		adjustPool(change.Post.Data.LiquidityPool, 1)
+	}
+	return nil
+}
+
+// adjust applies the given update to the stats of the asset, removing them
+// from the results when they are back to zero.
+func (p *AssetStatsChangeProcessor) adjust(asset xdr.Asset, update func(stats *AssetStats)) {
+	key := asset.StringCanonical()
+	stats, ok := p.results[key]
+	if !ok {
+		stats = &AssetStats{
+			Asset: asset,
+			Balances: AssetStatsBalances{
+				Authorized:                      new(big.Int),
+				AuthorizedToMaintainLiabilities: new(big.Int),
+				Unauthorized:                    new(big.Int),
+				ClaimableBalances:               new(big.Int),
+				LiquidityPools:                  new(big.Int),
+			},
+		}
+		p.results[key] = stats
+	}
+
+	update(stats)
+
+	if stats.isZero() {
+		delete(p.results, key)
+	}
+}
+
+// holderDistributionBucket returns the index of the
+// AssetStats.HolderDistribution bucket of the given balance (in stroops).
+func holderDistributionBucket(balance int64) int {
+	bucket := 0
+	for units := balance / 10000000; units > 0; units /= 10 {
+		bucket++
+	}
+	return bucket
+}
@@ -0,0 +1,170 @@
+package ingest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetStatsChangeProcessor(t *testing.T) {
+	ctx := context.Background()
+	processor := NewAssetStatsChangeProcessor()
+
+	issuer := xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML")
+	holder := xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB")
+	usd := xdr.MustNewCreditAsset("USD", issuer.Address())
+	native := xdr.MustNewNativeAsset()
+
+	account := func(balance xdr.Int64) *xdr.LedgerEntry {
+		return &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type:    xdr.LedgerEntryTypeAccount,
+				Account: &xdr.AccountEntry{AccountId: holder, Balance: balance},
+			},
+		}
+	}
+	trustLine := func(balance xdr.Int64, flags xdr.TrustLineFlags) *xdr.LedgerEntry {
+		return &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeTrustline,
+				TrustLine: &xdr.TrustLineEntry{
+					AccountId: holder,
+					Asset:     usd.ToTrustLineAsset(),
+					Balance:   balance,
+					Flags:     xdr.Uint32(flags),
+				},
+			},
+		}
+	}
+
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeAccount,
+		Post: account(50000000),
+	}))
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeTrustline,
+		Post: trustLine(0, xdr.TrustLineFlagsAuthorizedFlag),
+	}))
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeTrustline,
+		Pre:  trustLine(0, xdr.TrustLineFlagsAuthorizedFlag),
+		Post: trustLine(1230000000, xdr.TrustLineFlagsAuthorizedFlag),
+	}))
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeClaimableBalance,
+		Post: &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeClaimableBalance,
+				ClaimableBalance: &xdr.ClaimableBalanceEntry{
+					Asset:  usd,
+					Amount: 10,
+				},
+			},
+		},
+	}))
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeLiquidityPool,
+		Post: &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeLiquidityPool,
+				LiquidityPool: &xdr.LiquidityPoolEntry{
+					Body: xdr.LiquidityPoolEntryBody{
+						Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+						ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+							Params: xdr.LiquidityPoolConstantProductParameters{
+								AssetA: native,
+								AssetB: usd,
+								Fee:    xdr.LiquidityPoolFeeV18,
+							},
+							ReserveA: 100,
+							ReserveB: 200,
+						},
+					},
+				},
+			},
+		},
+	}))
+	// Offers are ignored
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeOffer,
+		Post: &xdr.LedgerEntry{},
+	}))
+
+	results := processor.GetResults()
+	require.Len(t, results, 2)
+
+	usdStats := results[usd.StringCanonical()]
+	require.NotNil(t, usdStats)
+	assert.True(t, usdStats.Asset.Equals(usd))
+	assert.Equal(t, AssetStatsAccounts{
+		Authorized:        1,
+		ClaimableBalances: 1,
+		LiquidityPools:    1,
+	}, usdStats.Accounts)
+	assert.Equal(t, big.NewInt(1230000000), usdStats.Balances.Authorized)
+	assert.Equal(t, big.NewInt(10), usdStats.Balances.ClaimableBalances)
+	assert.Equal(t, big.NewInt(200), usdStats.Balances.LiquidityPools)
+	assert.Equal(t, big.NewInt(1230000210), usdStats.CirculatingSupply())
+	assert.Equal(t, int64(1), usdStats.NumHolders())
+	assert.Equal(t, [HolderDistributionBuckets]int64{0, 0, 0, 1}, usdStats.HolderDistribution)
+
+	nativeStats := results[native.StringCanonical()]
+	require.NotNil(t, nativeStats)
+	assert.Equal(t, big.NewInt(50000100), nativeStats.CirculatingSupply())
+	assert.Equal(t, [HolderDistributionBuckets]int64{0, 1}, nativeStats.HolderDistribution)
+
+	// Deauthorizing moves the trustline to Unauthorized
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeTrustline,
+		Pre:  trustLine(1230000000, xdr.TrustLineFlagsAuthorizedFlag),
+		Post: trustLine(1230000000, 0),
+	}))
+	assert.Equal(t, int64(0), usdStats.Accounts.Authorized)
+	assert.Equal(t, int64(1), usdStats.Accounts.Unauthorized)
+	assert.Equal(t, big.NewInt(1230000000), usdStats.Balances.Unauthorized)
+
+	// Merging the account removes the native stats
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeLiquidityPool,
+		Pre: &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeLiquidityPool,
+				LiquidityPool: &xdr.LiquidityPoolEntry{
+					Body: xdr.LiquidityPoolEntryBody{
+						Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+						ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+							Params: xdr.LiquidityPoolConstantProductParameters{
+								AssetA: native,
+								AssetB: usd,
+								Fee:    xdr.LiquidityPoolFeeV18,
+							},
+							ReserveA: 100,
+							ReserveB: 200,
+						},
+					},
+				},
+			},
+		},
+	}))
+	require.NoError(t, processor.ProcessChange(ctx, Change{
+		Type: xdr.LedgerEntryTypeAccount,
+		Pre:  account(50000000),
+	}))
+	results = processor.GetResults()
+	assert.Len(t, results, 1)
+	assert.NotContains(t, results, native.StringCanonical())
+
+	assert.Error(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeAccount}))
+}
+
+func TestHolderDistributionBucket(t *testing.T) {
+	assert.Equal(t, 0, holderDistributionBucket(0))
+	assert.Equal(t, 0, holderDistributionBucket(9999999))
+	assert.Equal(t, 1, holderDistributionBucket(10000000))
+	assert.Equal(t, 2, holderDistributionBucket(100000000))
+	assert.Equal(t, HolderDistributionBuckets-1, holderDistributionBucket(9223372036854775807))
+}
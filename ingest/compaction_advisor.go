@@ -0,0 +1,244 @@
+package ingest
+
+import (
+	"context"
+	"encoding/gob"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	// DefaultCompactionMinDeadVersions is the MinDeadVersions of a
+	// CompactionAdvisor whose MinDeadVersions is not set.
+	DefaultCompactionMinDeadVersions = 10000
+	// DefaultCompactionDeadRatio is the DeadRatio of a CompactionAdvisor
+	// whose DeadRatio is not set.
+	DefaultCompactionDeadRatio = 0.5
+)
+
+// EntryChurn counts the changes of the entries of a type since the state
+// store keeping them was last compacted.
+type EntryChurn struct {
+	Created int64
+	Updated int64
+	Removed int64
+	// Live is the number of entries in the store: the entries created minus
+	// the entries removed since the advisor started, which is accurate if it
+	// started with the changes of a history checkpoint.
+	Live int64
+}
+
+// DeadVersions returns the number of versions of entries written to the
+// store and made obsolete since its last compaction: every update leaves the
+// previous version of an entry behind and every removal its last one, until
+// the store collects them.
+func (c EntryChurn) DeadVersions() int64 {
+	return c.Updated + c.Removed
+}
+
+// DeadRatio returns the share of the versions in the store which are dead,
+// between 0 and 1.
+func (c EntryChurn) DeadRatio() float64 {
+	live := c.Live
+	if live < 0 {
+		live = 0
+	}
+	if live+c.DeadVersions() == 0 {
+		return 0
+	}
+	return float64(c.DeadVersions()) / float64(live+c.DeadVersions())
+}
+
+// CompactionRecommendation is emitted by a CompactionAdvisor when the entries
+// of a type churned enough for the store to be worth compacting.
+type CompactionRecommendation struct {
+	Type   xdr.LedgerEntryType
+	Ledger uint32
+	Churn  EntryChurn
+}
+
+// CompactionAdvisor tracks the churn of every type of ledger entry and
+// recommends compacting (vacuuming, garbage collecting...) the state store of
+// a type once the versions of its entries made obsolete by updates and
+// removals reach MinDeadVersions and DeadRatio of the versions in the store.
+// Long-running indexers whose store is never compacted grow without bound
+// even though the number of live entries does not.
+//
+// The advisor is a ChangeProcessor, and Advise must be called once the
+// changes of a ledger are processed. A type is recommended once until
+// Compacted is called for it, which resets its churn.
+//
+// It is safe to use a CompactionAdvisor from several goroutines.
+type CompactionAdvisor struct {
+	// MinDeadVersions is the minimum number of dead versions of a type to
+	// recommend compacting it, DefaultCompactionMinDeadVersions if 0.
+	MinDeadVersions int64
+	// DeadRatio is the minimum share of dead versions of a type to recommend
+	// compacting it, DefaultCompactionDeadRatio if 0.
+	DeadRatio float64
+	// OnRecommendation, if set, is called by Advise with every
+	// recommendation, e.g. to trigger the compaction of the store.
+	OnRecommendation func(CompactionRecommendation)
+
+	mutex       sync.Mutex
+	churn       map[xdr.LedgerEntryType]EntryChurn
+	recommended map[xdr.LedgerEntryType]bool
+}
+
+// ProcessChange counts the change in the churn of its entry type.
+func (a *CompactionAdvisor) ProcessChange(ctx context.Context, change Change) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.init()
+
+	churn := a.churn[change.Type]
+	switch change.LedgerEntryChangeType() {
+	case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+		churn.Created++
+		churn.Live++
+	case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+		churn.Updated++
+	case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+		churn.Removed++
+		churn.Live--
+	}
+	a.churn[change.Type] = churn
+	return nil
+}
+
+// Advise returns the recommendations, sorted by entry type, for the types
+// which churned enough by the end of the ledger and were not recommended
+// already, and calls OnRecommendation with each of them.
+func (a *CompactionAdvisor) Advise(ledger uint32) []CompactionRecommendation {
+	a.mutex.Lock()
+	a.init()
+	minDeadVersions, deadRatio := a.MinDeadVersions, a.DeadRatio
+	if minDeadVersions == 0 {
+		minDeadVersions = DefaultCompactionMinDeadVersions
+	}
+	if deadRatio == 0 {
+		deadRatio = DefaultCompactionDeadRatio
+	}
+
+	var recommendations []CompactionRecommendation
+	for entryType, churn := range a.churn {
+		if a.recommended[entryType] ||
+			churn.DeadVersions() < minDeadVersions ||
+			churn.DeadRatio() < deadRatio {
+			continue
+		}
+		a.recommended[entryType] = true
+		recommendations = append(recommendations, CompactionRecommendation{
+			Type:   entryType,
+			Ledger: ledger,
+			Churn:  churn,
+		})
+	}
+	a.mutex.Unlock()
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].Type < recommendations[j].Type
+	})
+	if a.OnRecommendation != nil {
+		for _, recommendation := range recommendations {
+			a.OnRecommendation(recommendation)
+		}
+	}
+	return recommendations
+}
+
+// Compacted resets the churn of the entry type, whose store was compacted,
+// keeping its live entries.
+func (a *CompactionAdvisor) Compacted(entryType xdr.LedgerEntryType) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.init()
+	a.churn[entryType] = EntryChurn{Live: a.churn[entryType].Live}
+	delete(a.recommended, entryType)
+}
+
+// Churn returns the churn of every entry type with changes.
+func (a *CompactionAdvisor) Churn() map[xdr.LedgerEntryType]EntryChurn {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	churn := make(map[xdr.LedgerEntryType]EntryChurn, len(a.churn))
+	for entryType, c := range a.churn {
+		churn[entryType] = c
+	}
+	return churn
+}
+
+// Map returns the churn as a map of metrics, like
+// StatsChangeProcessorResults.Map, e.g. to be logged.
+func (a *CompactionAdvisor) Map() map[string]interface{} {
+	metrics := map[string]interface{}{}
+	for entryType, churn := range a.Churn() {
+		prefix := "churn_" + entryTypeMetricName(entryType)
+		metrics[prefix+"_created"] = churn.Created
+		metrics[prefix+"_updated"] = churn.Updated
+		metrics[prefix+"_removed"] = churn.Removed
+		metrics[prefix+"_live"] = churn.Live
+		metrics[prefix+"_dead_ratio"] = churn.DeadRatio()
+	}
+	return metrics
+}
+
+func entryTypeMetricName(entryType xdr.LedgerEntryType) string {
+	switch entryType {
+	case xdr.LedgerEntryTypeAccount:
+		return "accounts"
+	case xdr.LedgerEntryTypeTrustline:
+		return "trust_lines"
+	case xdr.LedgerEntryTypeOffer:
+		return "offers"
+	case xdr.LedgerEntryTypeData:
+		return "data"
+	case xdr.LedgerEntryTypeClaimableBalance:
+		return "claimable_balances"
+	case xdr.LedgerEntryTypeLiquidityPool:
+		return "liquidity_pools"
+	default:
+		return entryType.String()
+	}
+}
+
+type compactionAdvisorState struct {
+	Churn       map[xdr.LedgerEntryType]EntryChurn
+	Recommended map[xdr.LedgerEntryType]bool
+}
+
+// SnapshotState implements StatefulProcessor, so that the churn since the
+// last compaction survives restarts.
+func (a *CompactionAdvisor) SnapshotState(w io.Writer) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.init()
+	state := compactionAdvisorState{Churn: a.churn, Recommended: a.recommended}
+	return errors.Wrap(gob.NewEncoder(w).Encode(state), "error encoding compaction advisor state")
+}
+
+// RestoreState implements StatefulProcessor.
+func (a *CompactionAdvisor) RestoreState(r io.Reader) error {
+	var state compactionAdvisorState
+	if err := gob.NewDecoder(r).Decode(&state); err != nil {
+		return errors.Wrap(err, "error decoding compaction advisor state")
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.churn, a.recommended = state.Churn, state.Recommended
+	a.init()
+	return nil
+}
+
+func (a *CompactionAdvisor) init() {
+	if a.churn == nil {
+		a.churn = map[xdr.LedgerEntryType]EntryChurn{}
+	}
+	if a.recommended == nil {
+		a.recommended = map[xdr.LedgerEntryType]bool{}
+	}
+}
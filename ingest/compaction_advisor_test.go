@@ -0,0 +1,90 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactionAdvisor(t *testing.T) {
+	ctx := context.Background()
+	offer := &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type:  xdr.LedgerEntryTypeOffer,
+		Offer: &xdr.OfferEntry{OfferId: 1},
+	}}
+	account := &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.AccountEntry{Balance: 1},
+	}}
+	created := func(entry *xdr.LedgerEntry) Change {
+		return Change{Type: entry.Data.Type, Post: entry}
+	}
+	updated := func(entry *xdr.LedgerEntry) Change {
+		return Change{Type: entry.Data.Type, Pre: entry, Post: entry}
+	}
+	removed := func(entry *xdr.LedgerEntry) Change {
+		return Change{Type: entry.Data.Type, Pre: entry}
+	}
+
+	var recommended []CompactionRecommendation
+	advisor := &CompactionAdvisor{
+		MinDeadVersions: 3,
+		OnRecommendation: func(recommendation CompactionRecommendation) {
+			recommended = append(recommended, recommendation)
+		},
+	}
+
+	// offers are created and removed, accounts mostly live
+	for _, change := range []Change{
+		created(offer), created(offer), removed(offer), removed(offer),
+		created(account), created(account), created(account), updated(account),
+	} {
+		require.NoError(t, advisor.ProcessChange(ctx, change))
+	}
+	assert.Empty(t, advisor.Advise(10))
+
+	require.NoError(t, advisor.ProcessChange(ctx, created(offer)))
+	require.NoError(t, advisor.ProcessChange(ctx, updated(offer)))
+	require.NoError(t, advisor.ProcessChange(ctx, updated(account)))
+	// the accounts have 2 dead versions for 3 live entries, below the ratio
+	expected := []CompactionRecommendation{{
+		Type:   xdr.LedgerEntryTypeOffer,
+		Ledger: 11,
+		Churn:  EntryChurn{Created: 3, Updated: 1, Removed: 2, Live: 1},
+	}}
+	assert.Equal(t, expected, advisor.Advise(11))
+	assert.Equal(t, expected, recommended)
+	assert.InDelta(t, 0.75, expected[0].Churn.DeadRatio(), 0.001)
+
+	// a type is not recommended again until it is compacted
+	require.NoError(t, advisor.ProcessChange(ctx, updated(offer)))
+	assert.Empty(t, advisor.Advise(12))
+
+	var snapshot bytes.Buffer
+	require.NoError(t, advisor.SnapshotState(&snapshot))
+	restored := &CompactionAdvisor{MinDeadVersions: 3}
+	require.NoError(t, restored.RestoreState(&snapshot))
+	assert.Equal(t, advisor.Churn(), restored.Churn())
+	assert.Empty(t, restored.Advise(12))
+
+	advisor.Compacted(xdr.LedgerEntryTypeOffer)
+	assert.Equal(t, map[xdr.LedgerEntryType]EntryChurn{
+		xdr.LedgerEntryTypeOffer:   {Live: 1},
+		xdr.LedgerEntryTypeAccount: {Created: 3, Updated: 2, Live: 3},
+	}, advisor.Churn())
+	assert.Equal(t, int64(1), advisor.Map()["churn_offers_live"])
+	assert.Equal(t, int64(2), advisor.Map()["churn_accounts_updated"])
+	assert.Empty(t, advisor.Advise(13))
+}
+
+func TestEntryChurnDeadRatio(t *testing.T) {
+	assert.Equal(t, 0.0, EntryChurn{}.DeadRatio())
+	assert.Equal(t, 1.0, EntryChurn{Created: 1, Removed: 1}.DeadRatio())
+	// an advisor started after the entries were created
+	assert.Equal(t, 1.0, EntryChurn{Removed: 5, Live: -5}.DeadRatio())
+	assert.Equal(t, 0.5, EntryChurn{Updated: 2, Live: 2}.DeadRatio())
+}
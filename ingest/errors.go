@@ -1,12 +1,54 @@
 package ingest
 
 import (
+	stderrors "errors"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
 	"github.com/stellar/go/support/errors"
 )
 
 // ErrNotFound is returned when the requested ledger is not found
 var ErrNotFound = errors.New("ledger not found")
 
+// The kinds of the errors returned by the ledger backends and the readers
+// and processors of this package, so that the code orchestrating ingestion
+// can decide whether to retry or to alert without matching error messages.
+// They are the kinds defined by the ledgerbackend package.
+var (
+	// ErrRetryable is the kind of the errors caused by transient conditions
+	// after which the same call can be retried.
+	ErrRetryable = ledgerbackend.ErrRetryable
+	// ErrCorruptMeta is the kind of the errors caused by ledger metadata which
+	// cannot be decoded or is inconsistent.
+	ErrCorruptMeta = ledgerbackend.ErrCorruptMeta
+	// ErrBackendGone is the kind of the errors returned by a ledger backend
+	// which can no longer be used.
+	ErrBackendGone = ledgerbackend.ErrBackendGone
+)
+
+// WithKind annotates err with kind, see ledgerbackend.WithKind.
+func WithKind(kind, err error) error {
+	return ledgerbackend.WithKind(kind, err)
+}
+
+// ErrorKind returns the kind of err, or nil if it has none, see
+// ledgerbackend.ErrorKind.
+func ErrorKind(err error) error {
+	return ledgerbackend.ErrorKind(err)
+}
+
+// IsRetryable returns true if err is of the ErrRetryable kind.
+func IsRetryable(err error) bool {
+	return ledgerbackend.IsRetryable(err)
+}
+
+// IsPermanent returns true if err is of the ErrCorruptMeta or ErrBackendGone
+// kind, or is a StateError, which retrying the call cannot fix.
+func IsPermanent(err error) bool {
+	var stateErr StateError
+	return ledgerbackend.IsPermanent(err) || stderrors.As(err, &stateErr)
+}
+
 // StateError is a fatal error indicating that the Change stream
 // produced a result which violates fundamental invariants (e.g. an account
 // transferred more XLM than the account held in its balance).
@@ -0,0 +1,27 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+func TestErrorKinds(t *testing.T) {
+	tx := LedgerTransaction{UnsafeMeta: xdr.TransactionMeta{V: 3}}
+	_, err := tx.GetChanges()
+	assert.EqualError(t, err, "Unsupported TransactionMeta version")
+	assert.Equal(t, ErrCorruptMeta, ErrorKind(err))
+	assert.True(t, IsPermanent(err))
+	assert.False(t, IsRetryable(err))
+
+	err = errors.Wrap(NewStateError(errors.New("negative balance")), "could not process change")
+	assert.Nil(t, ErrorKind(err))
+	assert.True(t, IsPermanent(err))
+
+	err = errors.Wrap(WithKind(ErrRetryable, errors.New("timeout")), "could not get ledger")
+	assert.True(t, IsRetryable(err))
+	assert.False(t, IsPermanent(err))
+}
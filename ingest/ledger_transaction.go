@@ -77,7 +77,7 @@ func (t *LedgerTransaction) GetChanges() ([]Change, error) {
 		txChangesAfter := GetChangesFromLedgerEntryChanges(v2Meta.TxChangesAfter)
 		changes = append(changes, txChangesAfter...)
 	default:
-		return changes, errors.New("Unsupported TransactionMeta version")
+		return changes, WithKind(ErrCorruptMeta, errors.New("Unsupported TransactionMeta version"))
 	}
 
 	return changes, nil
@@ -109,7 +109,7 @@ func (t *LedgerTransaction) GetOperationChanges(operationIndex uint32) ([]Change
 		v2Meta := t.UnsafeMeta.MustV2()
 		changes = operationChanges(v2Meta.Operations, operationIndex)
 	default:
-		return changes, errors.New("Unsupported TransactionMeta version")
+		return changes, WithKind(ErrCorruptMeta, errors.New("Unsupported TransactionMeta version"))
 	}
 
 	return changes, nil
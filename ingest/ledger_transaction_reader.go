@@ -82,7 +82,7 @@ func (reader *LedgerTransactionReader) storeTransactions(lcm xdr.LedgerCloseMeta
 		envelope, ok := byHash[result.TransactionHash]
 		if !ok {
 			hexHash := hex.EncodeToString(result.TransactionHash[:])
-			return errors.Errorf("unknown tx hash in LedgerCloseMeta: %v", hexHash)
+			return WithKind(ErrCorruptMeta, errors.Errorf("unknown tx hash in LedgerCloseMeta: %v", hexHash))
 		}
 
 		// We check the version only if FeeProcessing are non empty because some backends
@@ -99,7 +99,7 @@ func (b *bufferedLedgerMetaReader) readLedgerMetaFromPipe() (*xdr.LedgerCloseMet
 	var xlcm xdr.LedgerCloseMeta
 	_, err = xlcm.DecodeFrom(b.decoder)
 	if err != nil {
-		return nil, errors.Wrap(err, "unmarshalling framed LedgerCloseMeta")
+		return nil, WithKind(ErrCorruptMeta, errors.Wrap(err, "unmarshalling framed LedgerCloseMeta"))
 	}
 	return &xlcm, nil
 }
@@ -471,7 +471,7 @@ func (c *CaptiveStellarCore) GetLedger(ctx context.Context, sequence uint32) (xd
 	}
 
 	if c.closed {
-		return xdr.LedgerCloseMeta{}, errors.New("stellar-core is no longer usable")
+		return xdr.LedgerCloseMeta{}, WithKind(ErrBackendGone, errors.New("stellar-core is no longer usable"))
 	}
 
 	if c.prepared == nil {
@@ -525,31 +525,31 @@ func (c *CaptiveStellarCore) handleMetaPipeResult(sequence uint32, result metaRe
 	// If we got something unexpected; close and reset
 	if c.nextLedger != 0 && seq != c.nextLedger {
 		c.stellarCoreRunner.close()
-		return false, xdr.LedgerCloseMeta{}, errors.Errorf(
+		return false, xdr.LedgerCloseMeta{}, WithKind(ErrCorruptMeta, errors.Errorf(
 			"unexpected ledger sequence (expected=%d actual=%d)",
 			c.nextLedger,
 			seq,
-		)
+		))
 	} else if c.nextLedger == 0 && seq > c.prepared.from {
 		// First stream ledger is greater than prepared.from
 		c.stellarCoreRunner.close()
-		return false, xdr.LedgerCloseMeta{}, errors.Errorf(
+		return false, xdr.LedgerCloseMeta{}, WithKind(ErrCorruptMeta, errors.Errorf(
 			"unexpected ledger sequence (expected=<=%d actual=%d)",
 			c.prepared.from,
 			seq,
-		)
+		))
 	}
 
 	newPreviousLedgerHash := result.LedgerCloseMeta.PreviousLedgerHash().HexString()
 	if c.previousLedgerHash != nil && *c.previousLedgerHash != newPreviousLedgerHash {
 		// We got something unexpected; close and reset
 		c.stellarCoreRunner.close()
-		return false, xdr.LedgerCloseMeta{}, errors.Errorf(
+		return false, xdr.LedgerCloseMeta{}, WithKind(ErrCorruptMeta, errors.Errorf(
 			"unexpected previous ledger hash for ledger %d (expected=%s actual=%s)",
 			seq,
 			*c.previousLedgerHash,
 			newPreviousLedgerHash,
-		)
+		))
 	}
 
 	c.nextLedger = result.LedgerSequence() + 1
@@ -588,15 +588,15 @@ func (c *CaptiveStellarCore) checkMetaPipeResult(result metaResult, ok bool) err
 		} else if exited, err := c.stellarCoreRunner.getProcessExitError(); exited {
 			// Case 2 - The stellar core process exited unexpectedly
 			if err == nil {
-				return errors.Errorf("stellar core exited unexpectedly")
+				return WithKind(ErrBackendGone, errors.Errorf("stellar core exited unexpectedly"))
 			} else {
-				return errors.Wrap(err, "stellar core exited unexpectedly")
+				return WithKind(ErrBackendGone, errors.Wrap(err, "stellar core exited unexpectedly"))
 			}
 		} else if !ok {
 			// This case should never happen because the ledger buffer channel can only be closed
 			// if and only if the process exits or the context is cancelled.
 			// However, we add this check for the sake of completeness
-			return errors.Errorf("meta pipe closed unexpectedly")
+			return WithKind(ErrBackendGone, errors.Errorf("meta pipe closed unexpectedly"))
 		}
 	}
 	return nil
@@ -614,7 +614,7 @@ func (c *CaptiveStellarCore) GetLatestLedgerSequence(ctx context.Context) (uint3
 	defer c.stellarCoreLock.RUnlock()
 
 	if c.closed {
-		return 0, errors.New("stellar-core is no longer usable")
+		return 0, WithKind(ErrBackendGone, errors.New("stellar-core is no longer usable"))
 	}
 	if c.prepared == nil {
 		return 0, errors.New("stellar-core must be prepared, call PrepareRange first")
@@ -1017,6 +1017,7 @@ func TestCaptiveAfterClose(t *testing.T) {
 
 	_, err = captiveBackend.GetLatestLedgerSequence(ctx)
 	assert.EqualError(t, err, "stellar-core is no longer usable")
+	assert.True(t, IsPermanent(err))
 
 	mockArchive.AssertExpectations(t)
 	mockRunner.AssertExpectations(t)
@@ -1164,6 +1165,7 @@ func TestCaptiveGetLedgerTerminatedUnexpectedly(t *testing.T) {
 
 			_, err = captiveBackend.GetLedger(ctx, 65)
 			assert.EqualError(t, err, testCase.expectedError)
+			assert.Equal(t, ErrBackendGone, ErrorKind(err))
 
 			mockArchive.AssertExpectations(t)
 			mockRunner.AssertExpectations(t)
@@ -157,7 +157,7 @@ func (dbb *DatabaseBackend) getLedgerAfterExist(ctx context.Context, sequence ui
 			// Ledger was not found
 			return false, fetchedSequence, nil
 		default:
-			return false, fetchedSequence, errors.Wrapf(err, "Error getting ledger after %d", sequence)
+			return false, fetchedSequence, WithKind(ErrRetryable, errors.Wrapf(err, "Error getting ledger after %d", sequence))
 		}
 	}
 
@@ -182,7 +182,7 @@ func (dbb *DatabaseBackend) getLedgerQuery(ctx context.Context, sequence uint32)
 			// Ledger was not found
 			return false, xdr.LedgerCloseMeta{}, nil
 		default:
-			return false, xdr.LedgerCloseMeta{}, errors.Wrap(err, "Error getting ledger header")
+			return false, xdr.LedgerCloseMeta{}, WithKind(ErrRetryable, errors.Wrap(err, "Error getting ledger header"))
 		}
 	}
 
@@ -198,14 +198,14 @@ func (dbb *DatabaseBackend) getLedgerQuery(ctx context.Context, sequence uint32)
 	err = dbb.session.SelectRaw(ctx, &txhRows, txHistoryQuery+orderBy, sequence)
 	// Return errors...
 	if err != nil {
-		return false, lcm, errors.Wrap(err, "Error getting txHistory")
+		return false, lcm, WithKind(ErrRetryable, errors.Wrap(err, "Error getting txHistory"))
 	}
 
 	// ...otherwise store the data
 	for i, tx := range txhRows {
 		// Sanity check index. Note that first TXIndex in a ledger is 1
 		if i != int(tx.TXIndex)-1 {
-			return false, xdr.LedgerCloseMeta{}, errors.New("transactions read from DB history table are misordered")
+			return false, xdr.LedgerCloseMeta{}, WithKind(ErrCorruptMeta, errors.New("transactions read from DB history table are misordered"))
 		}
 
 		lcm.V0.TxSet.Txs = append(lcm.V0.TxSet.Txs, tx.TXBody)
@@ -224,14 +224,14 @@ func (dbb *DatabaseBackend) getLedgerQuery(ctx context.Context, sequence uint32)
 	err = dbb.session.SelectRaw(ctx, &txfhRows, txFeeHistoryQuery+orderBy, sequence)
 	// Return errors...
 	if err != nil {
-		return false, lcm, errors.Wrap(err, "Error getting txFeeHistory")
+		return false, lcm, WithKind(ErrRetryable, errors.Wrap(err, "Error getting txFeeHistory"))
 	}
 
 	// ...otherwise store the data
 	for i, tx := range txfhRows {
 		// Sanity check index. Note that first TXIndex in a ledger is 1
 		if i != int(tx.TXIndex)-1 {
-			return false, xdr.LedgerCloseMeta{}, errors.New("transactions read from DB fee history table are misordered")
+			return false, xdr.LedgerCloseMeta{}, WithKind(ErrCorruptMeta, errors.New("transactions read from DB fee history table are misordered"))
 		}
 		lcm.V0.TxProcessing[i].FeeProcessing = tx.TXChanges
 	}
@@ -241,7 +241,7 @@ func (dbb *DatabaseBackend) getLedgerQuery(ctx context.Context, sequence uint32)
 	err = dbb.session.SelectRaw(ctx, &upgradeHistoryRows, upgradeHistoryQuery, sequence)
 	// Return errors...
 	if err != nil {
-		return false, lcm, errors.Wrap(err, "Error getting upgradeHistoryRows")
+		return false, lcm, WithKind(ErrRetryable, errors.Wrap(err, "Error getting upgradeHistoryRows"))
 	}
 
 	// ...otherwise store the data
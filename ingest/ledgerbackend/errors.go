@@ -0,0 +1,84 @@
+package ledgerbackend
+
+import (
+	stderrors "errors"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// The kinds of the errors returned by the ledger backends, and by the ingest
+// package, so that the code orchestrating ingestion can decide whether to
+// retry or to alert without matching error messages. Errors are annotated
+// with a kind by WithKind, which is reported by ErrorKind and errors.Is even
+// after they are wrapped. Errors without a kind are unclassified.
+var (
+	// ErrRetryable is the kind of the errors caused by transient conditions,
+	// e.g. a network or database failure, after which the same call can be
+	// retried.
+	ErrRetryable = errors.New("retryable error")
+	// ErrCorruptMeta is the kind of the errors caused by ledger metadata which
+	// cannot be decoded or is inconsistent, e.g. ledgers which do not follow
+	// each other. Retrying with the same source will fail again.
+	ErrCorruptMeta = errors.New("corrupt ledger meta")
+	// ErrBackendGone is the kind of the errors returned by a backend which can
+	// no longer be used, e.g. because it was closed or its stellar-core
+	// process exited. The range must be prepared again, or a new backend
+	// created.
+	ErrBackendGone = errors.New("ledger backend is gone")
+)
+
+// kindError is an error annotated with its kind.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e kindError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the annotated error, see errors.Cause.
+func (e kindError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the annotated error.
+func (e kindError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is the kind of the error.
+func (e kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// WithKind annotates err with kind, one of ErrRetryable, ErrCorruptMeta and
+// ErrBackendGone, without changing its message. It returns nil if err is nil.
+func WithKind(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return kindError{kind: kind, err: err}
+}
+
+// ErrorKind returns the kind of err, the outermost one if it was annotated
+// several times, or nil if it has none.
+func ErrorKind(err error) error {
+	var kerr kindError
+	if stderrors.As(err, &kerr) {
+		return kerr.kind
+	}
+	return nil
+}
+
+// IsRetryable returns true if err is of the ErrRetryable kind.
+func IsRetryable(err error) bool {
+	return ErrorKind(err) == ErrRetryable
+}
+
+// IsPermanent returns true if err is of the ErrCorruptMeta or ErrBackendGone
+// kind, which retrying the call cannot fix.
+func IsPermanent(err error) bool {
+	kind := ErrorKind(err)
+	return kind == ErrCorruptMeta || kind == ErrBackendGone
+}
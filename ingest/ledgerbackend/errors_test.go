@@ -0,0 +1,36 @@
+package ledgerbackend
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go/support/errors"
+)
+
+func TestErrorKind(t *testing.T) {
+	assert.NoError(t, WithKind(ErrRetryable, nil))
+
+	cause := errors.New("connection refused")
+	err := errors.Wrap(WithKind(ErrRetryable, cause), "could not get ledger")
+	assert.EqualError(t, err, "could not get ledger: connection refused")
+	assert.Equal(t, ErrRetryable, ErrorKind(err))
+	assert.True(t, stderrors.Is(err, ErrRetryable))
+	assert.False(t, stderrors.Is(err, ErrCorruptMeta))
+	assert.Equal(t, cause, errors.Cause(err))
+	assert.True(t, IsRetryable(err))
+	assert.False(t, IsPermanent(err))
+
+	// the outermost kind wins
+	err = WithKind(ErrCorruptMeta, err)
+	assert.Equal(t, ErrCorruptMeta, ErrorKind(err))
+	assert.False(t, IsRetryable(err))
+	assert.True(t, IsPermanent(err))
+	assert.True(t, IsPermanent(WithKind(ErrBackendGone, cause)))
+
+	assert.Nil(t, ErrorKind(cause))
+	assert.Nil(t, ErrorKind(nil))
+	assert.False(t, IsRetryable(cause))
+	assert.False(t, IsPermanent(cause))
+}
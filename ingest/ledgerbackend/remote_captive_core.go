@@ -137,7 +137,7 @@ func (c RemoteCaptiveStellarCore) GetLatestLedgerSequence(ctx context.Context) (
 
 	response, err := c.client.Do(request)
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to execute request")
+		return 0, WithKind(ErrRetryable, errors.Wrap(err, "failed to execute request"))
 	}
 
 	var parsed LatestLedgerSequenceResponse
@@ -208,7 +208,7 @@ func (c RemoteCaptiveStellarCore) IsPrepared(ctx context.Context, ledgerRange Ra
 	var response *http.Response
 	response, err = c.client.Do(request)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to execute request")
+		return false, WithKind(ErrRetryable, errors.Wrap(err, "failed to execute request"))
 	}
 
 	var parsed PrepareRangeResponse
@@ -242,7 +242,7 @@ func (c RemoteCaptiveStellarCore) GetLedger(ctx context.Context, sequence uint32
 
 		response, err := c.client.Do(request)
 		if err != nil {
-			return xdr.LedgerCloseMeta{}, errors.Wrap(err, "failed to execute request")
+			return xdr.LedgerCloseMeta{}, WithKind(ErrRetryable, errors.Wrap(err, "failed to execute request"))
 		}
 
 		if response.StatusCode == http.StatusRequestTimeout {
@@ -0,0 +1,731 @@
+package ledgerbackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Ensure SyntheticBackend implements LedgerBackend
+var _ LedgerBackend = (*SyntheticBackend)(nil)
+
+// SyntheticOperationTypes are the operation types a SyntheticBackend can
+// generate.
+var SyntheticOperationTypes = []xdr.OperationType{
+	xdr.OperationTypeCreateAccount,
+	xdr.OperationTypePayment,
+	xdr.OperationTypeManageSellOffer,
+	xdr.OperationTypeChangeTrust,
+	xdr.OperationTypeManageData,
+	xdr.OperationTypeBumpSequence,
+}
+
+// SyntheticConfig configures the ledgers generated by a SyntheticBackend.
+type SyntheticConfig struct {
+	// NetworkPassphrase is the passphrase of the network the transactions
+	// are hashed for, to be passed to the readers of the ledgers.
+	NetworkPassphrase string
+	// TPS is the average number of transactions per second. The number of
+	// transactions of every ledger is sampled from a Poisson distribution
+	// of mean TPS times CloseTime. 10 if 0.
+	TPS float64
+	// CloseTime is the time between two ledgers, 5 seconds if 0.
+	CloseTime time.Duration
+	// MaxOperationsPerTransaction is the maximum number of operations of the
+	// transactions, whose number of operations is uniformly distributed
+	// between 1 and it. 1 if 0.
+	MaxOperationsPerTransaction int
+	// OperationMix are the relative weights of the operation types of
+	// SyntheticOperationTypes generated, e.g. {Payment: 9, ManageData: 1}
+	// for 90% of payments. Payments, account creations, offers, trustlines
+	// and data entries in the proportions of pubnet if nil.
+	OperationMix map[xdr.OperationType]int
+	// Accounts is the number of accounts existing before the first ledger,
+	// the sources and destinations of the operations, 100 if 0. The
+	// accounts created by CreateAccount operations join them.
+	Accounts int
+	// Seed seeds the random generator: the ledgers generated from the same
+	// config are the same.
+	Seed int64
+	// ProtocolVersion is the protocol version of the ledgers, 18 if 0.
+	ProtocolVersion uint32
+	// Realtime makes the ledgers available at the pace they close, every
+	// CloseTime from the preparation of the range, instead of as fast as
+	// they are requested, for soak tests.
+	Realtime bool
+}
+
+// SyntheticBackend is a LedgerBackend generating randomized ledgers, so that
+// the processors of ledgers can be benchmarked and soak-tested without
+// stellar-core or pubnet data.
+//
+// The transactions are successful and their meta are consistent with the
+// state of the ledger: the changes of an entry start with its state after
+// the previous change, the sequence numbers of the accounts are bumped by
+// their transactions and the fees charged are taken from their balance. The
+// accounts pay the fees and the operations of their transactions from their
+// balance above their minimum balance: the operations an account cannot
+// afford are replaced by bump sequence operations, and fewer transactions
+// are generated if no account can pay their fee. The signatures are random
+// and cannot be verified.
+//
+// The ledgers are generated in order: GetLedger returns the latest ledger
+// generated again, generates the ledgers following it, and returns an error
+// for the ledgers before it. PrepareRange starts again from the first ledger
+// of the range.
+type SyntheticBackend struct {
+	config     SyntheticConfig
+	operations []xdr.OperationType
+	weights    []int
+
+	mutex       sync.Mutex
+	closed      bool
+	prepared    *Range
+	preparedAt  time.Time
+	random      *rand.Rand
+	accounts    []*syntheticAccount
+	issuer      xdr.AccountId
+	asset       xdr.Asset
+	header      xdr.LedgerHeaderHistoryEntry
+	last        *xdr.LedgerCloseMeta
+	nextLedger  uint32
+	dataEntries int
+}
+
+// syntheticStartingBalance is the balance of the accounts created by the
+// create account operations.
+const syntheticStartingBalance = xdr.Int64(10 * 10000000)
+
+// syntheticAccount is the state of an account and of its subentries. The
+// ledger entries are never modified, the updates replace them.
+type syntheticAccount struct {
+	account   xdr.LedgerEntry
+	trustline *xdr.LedgerEntry
+	data      map[string]xdr.LedgerEntry
+}
+
+// NewSyntheticBackend returns a SyntheticBackend generating ledgers as
+// configured.
+func NewSyntheticBackend(config SyntheticConfig) (*SyntheticBackend, error) {
+	if config.NetworkPassphrase == "" {
+		return nil, errors.New("network passphrase is required")
+	}
+	if config.TPS == 0 {
+		config.TPS = 10
+	}
+	if config.CloseTime == 0 {
+		config.CloseTime = 5 * time.Second
+	}
+	if config.MaxOperationsPerTransaction == 0 {
+		config.MaxOperationsPerTransaction = 1
+	}
+	if config.Accounts == 0 {
+		config.Accounts = 100
+	}
+	if config.ProtocolVersion == 0 {
+		config.ProtocolVersion = 18
+	}
+	if config.OperationMix == nil {
+		config.OperationMix = map[xdr.OperationType]int{
+			xdr.OperationTypePayment:         60,
+			xdr.OperationTypeManageSellOffer: 25,
+			xdr.OperationTypeChangeTrust:     5,
+			xdr.OperationTypeManageData:      5,
+			xdr.OperationTypeCreateAccount:   5,
+		}
+	}
+	switch {
+	case config.TPS < 0:
+		return nil, errors.New("TPS cannot be negative")
+	case config.CloseTime < 0:
+		return nil, errors.New("close time cannot be negative")
+	case config.MaxOperationsPerTransaction < 0 || config.MaxOperationsPerTransaction > 100:
+		return nil, errors.New("transactions must have between 1 and 100 operations")
+	case config.Accounts < 2:
+		return nil, errors.New("at least 2 accounts are required")
+	}
+
+	backend := &SyntheticBackend{config: config}
+	for _, operationType := range SyntheticOperationTypes {
+		weight := config.OperationMix[operationType]
+		if weight < 0 {
+			return nil, errors.Errorf("weight of %s cannot be negative", operationType)
+		}
+		if weight > 0 {
+			backend.operations = append(backend.operations, operationType)
+			backend.weights = append(backend.weights, weight)
+		}
+	}
+	for operationType := range config.OperationMix {
+		if !isSyntheticOperationType(operationType) {
+			return nil, errors.Errorf("%s operations cannot be generated", operationType)
+		}
+	}
+	if len(backend.operations) == 0 {
+		return nil, errors.New("operation mix has no operation")
+	}
+	return backend, nil
+}
+
+func isSyntheticOperationType(operationType xdr.OperationType) bool {
+	for _, t := range SyntheticOperationTypes {
+		if t == operationType {
+			return true
+		}
+	}
+	return false
+}
+
+// PrepareRange starts generating the ledgers of ledgerRange, from its first
+// ledger.
+func (b *SyntheticBackend) PrepareRange(ctx context.Context, ledgerRange Range) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return WithKind(ErrBackendGone, errors.New("backend is closed"))
+	}
+	if ledgerRange.from < 2 {
+		return errors.New("the first ledger of the range must be at least 2")
+	}
+
+	b.prepared = &ledgerRange
+	b.preparedAt = time.Now()
+	b.random = rand.New(rand.NewSource(b.config.Seed))
+	b.accounts = nil
+	b.last = nil
+	b.nextLedger = ledgerRange.from
+	b.dataEntries = 0
+
+	b.issuer = b.randomAccountID()
+	b.asset = xdr.MustNewCreditAsset("SYN", b.issuer.Address())
+	for i := 0; i < b.config.Accounts; i++ {
+		b.accounts = append(b.accounts, &syntheticAccount{
+			account: accountLedgerEntry(ledgerRange.from-1, xdr.AccountEntry{
+				AccountId:  b.randomAccountID(),
+				Balance:    10000 * 10000000,
+				SeqNum:     xdr.SequenceNumber(int64(ledgerRange.from-1) << 32),
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			}),
+			data: map[string]xdr.LedgerEntry{},
+		})
+	}
+
+	b.header = xdr.LedgerHeaderHistoryEntry{
+		Header: xdr.LedgerHeader{
+			LedgerVersion: xdr.Uint32(b.config.ProtocolVersion),
+			LedgerSeq:     xdr.Uint32(ledgerRange.from - 1),
+			ScpValue:      xdr.StellarValue{CloseTime: xdr.TimePoint(b.closeTime(ledgerRange.from - 1))},
+			TotalCoins:    1000000000000000000,
+			BaseFee:       100,
+			BaseReserve:   5000000,
+			MaxTxSetSize:  1000,
+		},
+	}
+	b.random.Read(b.header.Hash[:])
+	return nil
+}
+
+// IsPrepared returns true if ledgerRange is within the prepared range.
+func (b *SyntheticBackend) IsPrepared(ctx context.Context, ledgerRange Range) (bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.prepared != nil && b.prepared.Contains(ledgerRange), nil
+}
+
+// GetLatestLedgerSequence returns the latest ledger available: the last
+// ledger of a bounded range, or the latest closed ledger of an unbounded
+// range in real time, or the latest ledger generated of an unbounded range.
+func (b *SyntheticBackend) GetLatestLedgerSequence(ctx context.Context) (uint32, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return 0, WithKind(ErrBackendGone, errors.New("backend is closed"))
+	}
+	if b.prepared == nil {
+		return 0, errors.New("session is not prepared, call PrepareRange first")
+	}
+
+	if b.config.Realtime {
+		latest := b.prepared.from + uint32(time.Since(b.preparedAt)/b.config.CloseTime)
+		if b.prepared.bounded && latest > b.prepared.to {
+			latest = b.prepared.to
+		}
+		return latest, nil
+	}
+	if b.prepared.bounded {
+		return b.prepared.to, nil
+	}
+	if b.nextLedger > b.prepared.from {
+		return b.nextLedger - 1, nil
+	}
+	return b.prepared.from, nil
+}
+
+// GetLedger returns the ledger with the given sequence, generating it and
+// the ledgers before it which were not generated yet. In real time, it
+// blocks until the ledger closes.
+func (b *SyntheticBackend) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	if err := b.waitForClose(ctx, sequence); err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return xdr.LedgerCloseMeta{}, WithKind(ErrBackendGone, errors.New("backend is closed"))
+	}
+	if b.prepared == nil {
+		return xdr.LedgerCloseMeta{}, errors.New("session is not prepared, call PrepareRange first")
+	}
+	if sequence < b.prepared.from || (b.prepared.bounded && sequence > b.prepared.to) {
+		return xdr.LedgerCloseMeta{}, errors.Errorf(
+			"requested ledger %d is not in the prepared range %s", sequence, b.prepared,
+		)
+	}
+	if b.last != nil && sequence == b.nextLedger-1 {
+		return *b.last, nil
+	}
+	if sequence < b.nextLedger {
+		return xdr.LedgerCloseMeta{}, errors.Errorf(
+			"requested ledger %d was generated already, call PrepareRange to start again", sequence,
+		)
+	}
+
+	for b.nextLedger <= sequence {
+		meta, err := b.generateLedger()
+		if err != nil {
+			return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "could not generate ledger %d", b.nextLedger)
+		}
+		b.last = &meta
+		b.nextLedger++
+	}
+	return *b.last, nil
+}
+
+// waitForClose blocks, in real time, until the ledger with the given sequence
+// of the prepared range closes. The mutex is not held while waiting, so that
+// the other methods of the backend, e.g. Close, are not blocked.
+func (b *SyntheticBackend) waitForClose(ctx context.Context, sequence uint32) error {
+	if !b.config.Realtime {
+		return nil
+	}
+	b.mutex.Lock()
+	prepared, preparedAt := b.prepared, b.preparedAt
+	b.mutex.Unlock()
+	// the errors of the requests out of the range are returned by GetLedger
+	if prepared == nil || sequence < prepared.from {
+		return nil
+	}
+
+	closesAt := preparedAt.Add(time.Duration(sequence-prepared.from) * b.config.CloseTime)
+	if wait := time.Until(closesAt); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// Close closes the backend.
+func (b *SyntheticBackend) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.closed = true
+	return nil
+}
+
+// closeTime returns the close time of a ledger of the prepared range.
+func (b *SyntheticBackend) closeTime(sequence uint32) int64 {
+	return b.preparedAt.Unix() + int64(time.Duration(int64(sequence)-int64(b.prepared.from))*b.config.CloseTime/time.Second)
+}
+
+func (b *SyntheticBackend) randomAccountID() xdr.AccountId {
+	var key xdr.Uint256
+	b.random.Read(key[:])
+	return xdr.AccountId{Type: xdr.PublicKeyTypePublicKeyTypeEd25519, Ed25519: &key}
+}
+
+// generateLedger generates the ledger b.nextLedger.
+func (b *SyntheticBackend) generateLedger() (xdr.LedgerCloseMeta, error) {
+	sequence := b.nextLedger
+	header := b.header.Header
+	header.LedgerSeq = xdr.Uint32(sequence)
+	header.PreviousLedgerHash = b.header.Hash
+	header.ScpValue = xdr.StellarValue{CloseTime: xdr.TimePoint(b.closeTime(sequence))}
+
+	count := poisson(b.random, b.config.TPS*b.config.CloseTime.Seconds())
+	if count > int(header.MaxTxSetSize) {
+		count = int(header.MaxTxSetSize)
+	}
+	meta := xdr.LedgerCloseMetaV0{
+		TxSet: xdr.TransactionSet{
+			PreviousLedgerHash: header.PreviousLedgerHash,
+			Txs:                make([]xdr.TransactionEnvelope, 0, count),
+		},
+		TxProcessing: make([]xdr.TransactionResultMeta, 0, count),
+	}
+	for i := 0; i < count; i++ {
+		envelope, resultMeta, ok, err := b.generateTransaction(sequence, &header)
+		if err != nil {
+			return xdr.LedgerCloseMeta{}, err
+		}
+		if !ok {
+			break
+		}
+		meta.TxSet.Txs = append(meta.TxSet.Txs, envelope)
+		meta.TxProcessing = append(meta.TxProcessing, resultMeta)
+	}
+
+	txSet, err := meta.TxSet.MarshalBinary()
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, errors.Wrap(err, "could not encode transaction set")
+	}
+	header.ScpValue.TxSetHash = sha256.Sum256(txSet)
+	encodedHeader, err := header.MarshalBinary()
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, errors.Wrap(err, "could not encode ledger header")
+	}
+	b.header = xdr.LedgerHeaderHistoryEntry{Hash: sha256.Sum256(encodedHeader), Header: header}
+	meta.LedgerHeader = b.header
+	return xdr.LedgerCloseMeta{V: 0, V0: &meta}, nil
+}
+
+// generateTransaction generates a successful transaction of a random source
+// account, charging its fee to the fee pool of header. It returns false if no
+// account can pay the fee.
+func (b *SyntheticBackend) generateTransaction(sequence uint32, header *xdr.LedgerHeader) (xdr.TransactionEnvelope, xdr.TransactionResultMeta, bool, error) {
+	operationCount := 1 + b.random.Intn(b.config.MaxOperationsPerTransaction)
+	fee := int64(header.BaseFee) * int64(operationCount)
+	source := b.pickSource(xdr.Int64(fee), header)
+	if source == nil {
+		return xdr.TransactionEnvelope{}, xdr.TransactionResultMeta{}, false, nil
+	}
+
+	// the fees are charged before the transactions are applied
+	before := source.account
+	charged := *before.Data.Account
+	charged.Balance -= xdr.Int64(fee)
+	source.account = accountLedgerEntry(sequence, charged)
+	feeChanges := updateChanges(before, source.account)
+	header.FeePool += xdr.Int64(fee)
+
+	before = source.account
+	bumped := *before.Data.Account
+	bumped.SeqNum++
+	source.account = accountLedgerEntry(sequence, bumped)
+	txChanges := updateChanges(before, source.account)
+
+	tx := xdr.Transaction{
+		SourceAccount: before.Data.Account.AccountId.ToMuxedAccount(),
+		Fee:           xdr.Uint32(fee),
+		SeqNum:        bumped.SeqNum,
+		TimeBounds:    &xdr.TimeBounds{MaxTime: header.ScpValue.CloseTime + 300},
+		Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+	}
+	operationMetas := make([]xdr.OperationMeta, 0, operationCount)
+	results := make([]xdr.OperationResult, 0, operationCount)
+	for i := 0; i < operationCount; i++ {
+		op, result, changes := b.generateOperation(sequence, source, header)
+		tx.Operations = append(tx.Operations, op)
+		results = append(results, xdr.OperationResult{Code: xdr.OperationResultCodeOpInner, Tr: &result})
+		operationMetas = append(operationMetas, xdr.OperationMeta{Changes: changes})
+	}
+
+	signature := xdr.DecoratedSignature{Signature: make([]byte, 64)}
+	copy(signature.Hint[:], before.Data.Account.AccountId.Ed25519[28:])
+	b.random.Read(signature.Signature)
+	envelope := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: tx, Signatures: []xdr.DecoratedSignature{signature}},
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, b.config.NetworkPassphrase)
+	if err != nil {
+		return xdr.TransactionEnvelope{}, xdr.TransactionResultMeta{}, false, errors.Wrap(err, "could not hash transaction")
+	}
+
+	return envelope, xdr.TransactionResultMeta{
+		Result: xdr.TransactionResultPair{
+			TransactionHash: hash,
+			Result: xdr.TransactionResult{
+				FeeCharged: xdr.Int64(fee),
+				Result: xdr.TransactionResultResult{
+					Code:    xdr.TransactionResultCodeTxSuccess,
+					Results: &results,
+				},
+			},
+		},
+		FeeProcessing: feeChanges,
+		TxApplyProcessing: xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				TxChangesBefore: txChanges,
+				Operations:      operationMetas,
+			},
+		},
+	}, true, nil
+}
+
+// pickSource returns a random account whose balance above its minimum
+// balance covers fee, nil if there is none.
+func (b *SyntheticBackend) pickSource(fee xdr.Int64, header *xdr.LedgerHeader) *syntheticAccount {
+	start := b.random.Intn(len(b.accounts))
+	for i := range b.accounts {
+		account := b.accounts[(start+i)%len(b.accounts)]
+		if spendableBalance(*account.account.Data.Account, header) >= fee {
+			return account
+		}
+	}
+	return nil
+}
+
+// spendableBalance returns the balance of the account above its minimum
+// balance.
+func spendableBalance(account xdr.AccountEntry, header *xdr.LedgerHeader) xdr.Int64 {
+	return account.Balance - xdr.Int64(2+int64(account.NumSubEntries))*xdr.Int64(header.BaseReserve)
+}
+
+// affordable returns true if the source account can pay for an operation of
+// the given type from its balance above its minimum balance, which goes up
+// by the base reserve for every new subentry.
+func affordable(operationType xdr.OperationType, source *syntheticAccount, header *xdr.LedgerHeader) bool {
+	available := spendableBalance(*source.account.Data.Account, header)
+	switch operationType {
+	case xdr.OperationTypeCreateAccount:
+		return available >= syntheticStartingBalance
+	case xdr.OperationTypePayment:
+		return available > 0
+	case xdr.OperationTypeChangeTrust:
+		return source.trustline != nil || available >= xdr.Int64(header.BaseReserve)
+	case xdr.OperationTypeManageSellOffer, xdr.OperationTypeManageData:
+		return available >= xdr.Int64(header.BaseReserve)
+	}
+	return true
+}
+
+// pickOperationType returns a random operation type of the mix.
+func (b *SyntheticBackend) pickOperationType() xdr.OperationType {
+	total := 0
+	for _, weight := range b.weights {
+		total += weight
+	}
+	n := b.random.Intn(total)
+	for i, weight := range b.weights {
+		if n < weight {
+			return b.operations[i]
+		}
+		n -= weight
+	}
+	return b.operations[len(b.operations)-1]
+}
+
+// generateOperation generates an operation of the source account, its result
+// and its changes.
+func (b *SyntheticBackend) generateOperation(sequence uint32, source *syntheticAccount, header *xdr.LedgerHeader) (xdr.Operation, xdr.OperationResultTr, xdr.LedgerEntryChanges) {
+	sourceAccount := *source.account.Data.Account
+	operationType := b.pickOperationType()
+	if !affordable(operationType, source, header) {
+		operationType = xdr.OperationTypeBumpSequence
+	}
+	result := xdr.OperationResultTr{Type: operationType}
+	var body xdr.OperationBody
+	var changes xdr.LedgerEntryChanges
+
+	switch operationType {
+	case xdr.OperationTypeCreateAccount:
+		amount := syntheticStartingBalance
+		created := &syntheticAccount{
+			account: accountLedgerEntry(sequence, xdr.AccountEntry{
+				AccountId:  b.randomAccountID(),
+				Balance:    amount,
+				SeqNum:     xdr.SequenceNumber(int64(sequence) << 32),
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			}),
+			data: map[string]xdr.LedgerEntry{},
+		}
+		b.accounts = append(b.accounts, created)
+		changes = append(changes, createdChange(created.account))
+		sourceAccount.Balance -= amount
+		changes = append(changes, b.updateAccount(sequence, source, sourceAccount)...)
+		body = xdr.OperationBody{Type: operationType, CreateAccountOp: &xdr.CreateAccountOp{
+			Destination:     created.account.Data.Account.AccountId,
+			StartingBalance: amount,
+		}}
+		result.CreateAccountResult = &xdr.CreateAccountResult{Code: xdr.CreateAccountResultCodeCreateAccountSuccess}
+
+	case xdr.OperationTypePayment:
+		destination := b.accounts[b.random.Intn(len(b.accounts))]
+		for destination == source {
+			destination = b.accounts[b.random.Intn(len(b.accounts))]
+		}
+		maxAmount := spendableBalance(sourceAccount, header)
+		if maxAmount > 100*10000000 {
+			maxAmount = 100 * 10000000
+		}
+		amount := 1 + xdr.Int64(b.random.Int63n(int64(maxAmount)))
+		sourceAccount.Balance -= amount
+		changes = append(changes, b.updateAccount(sequence, source, sourceAccount)...)
+		destinationAccount := *destination.account.Data.Account
+		destinationAccount.Balance += amount
+		changes = append(changes, b.updateAccount(sequence, destination, destinationAccount)...)
+		body = xdr.OperationBody{Type: operationType, PaymentOp: &xdr.PaymentOp{
+			Destination: destinationAccount.AccountId.ToMuxedAccount(),
+			Asset:       xdr.MustNewNativeAsset(),
+			Amount:      amount,
+		}}
+		result.PaymentResult = &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentSuccess}
+
+	case xdr.OperationTypeManageSellOffer:
+		header.IdPool++
+		offer := xdr.OfferEntry{
+			SellerId: sourceAccount.AccountId,
+			OfferId:  xdr.Int64(header.IdPool),
+			Selling:  xdr.MustNewNativeAsset(),
+			Buying:   b.asset,
+			Amount:   xdr.Int64(1 + b.random.Int63n(100*10000000)),
+			Price:    xdr.Price{N: xdr.Int32(1 + b.random.Int31n(1000)), D: 100},
+		}
+		offerEntry := xdr.LedgerEntry{
+			LastModifiedLedgerSeq: xdr.Uint32(sequence),
+			Data:                  xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeOffer, Offer: &offer},
+		}
+		changes = append(changes, createdChange(offerEntry))
+		sourceAccount.NumSubEntries++
+		changes = append(changes, b.updateAccount(sequence, source, sourceAccount)...)
+		body = xdr.OperationBody{Type: operationType, ManageSellOfferOp: &xdr.ManageSellOfferOp{
+			Selling: offer.Selling,
+			Buying:  offer.Buying,
+			Amount:  offer.Amount,
+			Price:   offer.Price,
+		}}
+		result.ManageSellOfferResult = &xdr.ManageSellOfferResult{
+			Code: xdr.ManageSellOfferResultCodeManageSellOfferSuccess,
+			Success: &xdr.ManageOfferSuccessResult{
+				Offer: xdr.ManageOfferSuccessResultOffer{Effect: xdr.ManageOfferEffectManageOfferCreated, Offer: &offer},
+			},
+		}
+
+	case xdr.OperationTypeChangeTrust:
+		limit := xdr.Int64(1 + b.random.Int63n(math.MaxInt64))
+		trustline := xdr.TrustLineEntry{
+			AccountId: sourceAccount.AccountId,
+			Asset:     b.asset.ToTrustLineAsset(),
+			Limit:     limit,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		}
+		if source.trustline != nil {
+			trustline.Balance = source.trustline.Data.TrustLine.Balance
+		}
+		trustlineEntry := xdr.LedgerEntry{
+			LastModifiedLedgerSeq: xdr.Uint32(sequence),
+			Data:                  xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeTrustline, TrustLine: &trustline},
+		}
+		if source.trustline == nil {
+			changes = append(changes, createdChange(trustlineEntry))
+			sourceAccount.NumSubEntries++
+			changes = append(changes, b.updateAccount(sequence, source, sourceAccount)...)
+		} else {
+			changes = append(changes, updateChanges(*source.trustline, trustlineEntry)...)
+		}
+		source.trustline = &trustlineEntry
+		body = xdr.OperationBody{Type: operationType, ChangeTrustOp: &xdr.ChangeTrustOp{
+			Line:  b.asset.ToChangeTrustAsset(),
+			Limit: limit,
+		}}
+		result.ChangeTrustResult = &xdr.ChangeTrustResult{Code: xdr.ChangeTrustResultCodeChangeTrustSuccess}
+
+	case xdr.OperationTypeManageData:
+		name := fmt.Sprintf("synthetic-%d", b.random.Intn(10))
+		value := make(xdr.DataValue, 1+b.random.Intn(64))
+		b.random.Read(value)
+		dataEntry := xdr.LedgerEntry{
+			LastModifiedLedgerSeq: xdr.Uint32(sequence),
+			Data: xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeData, Data: &xdr.DataEntry{
+				AccountId: sourceAccount.AccountId,
+				DataName:  xdr.String64(name),
+				DataValue: value,
+			}},
+		}
+		if previous, ok := source.data[name]; ok {
+			changes = append(changes, updateChanges(previous, dataEntry)...)
+		} else {
+			changes = append(changes, createdChange(dataEntry))
+			sourceAccount.NumSubEntries++
+			changes = append(changes, b.updateAccount(sequence, source, sourceAccount)...)
+		}
+		source.data[name] = dataEntry
+		body = xdr.OperationBody{Type: operationType, ManageDataOp: &xdr.ManageDataOp{
+			DataName:  xdr.String64(name),
+			DataValue: &value,
+		}}
+		result.ManageDataResult = &xdr.ManageDataResult{Code: xdr.ManageDataResultCodeManageDataSuccess}
+
+	case xdr.OperationTypeBumpSequence:
+		sourceAccount.SeqNum += xdr.SequenceNumber(1 + b.random.Int63n(100))
+		changes = append(changes, b.updateAccount(sequence, source, sourceAccount)...)
+		body = xdr.OperationBody{Type: operationType, BumpSequenceOp: &xdr.BumpSequenceOp{
+			BumpTo: sourceAccount.SeqNum,
+		}}
+		result.BumpSeqResult = &xdr.BumpSequenceResult{Code: xdr.BumpSequenceResultCodeBumpSequenceSuccess}
+	}
+
+	return xdr.Operation{Body: body}, result, changes
+}
+
+// updateAccount replaces the account entry of the account with entry,
+// returning the changes.
+func (b *SyntheticBackend) updateAccount(sequence uint32, account *syntheticAccount, entry xdr.AccountEntry) xdr.LedgerEntryChanges {
+	before := account.account
+	account.account = accountLedgerEntry(sequence, entry)
+	return updateChanges(before, account.account)
+}
+
+func accountLedgerEntry(sequence uint32, account xdr.AccountEntry) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		LastModifiedLedgerSeq: xdr.Uint32(sequence),
+		Data:                  xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeAccount, Account: &account},
+	}
+}
+
+func createdChange(entry xdr.LedgerEntry) xdr.LedgerEntryChange {
+	return xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: &entry}
+}
+
+func updateChanges(before, after xdr.LedgerEntry) xdr.LedgerEntryChanges {
+	return xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &before},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &after},
+	}
+}
+
+// poisson samples the Poisson distribution of the given mean, approximated
+// by a normal distribution for large means.
+func poisson(random *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	if mean > 30 {
+		n := int(math.Round(random.NormFloat64()*math.Sqrt(mean) + mean))
+		if n < 0 {
+			return 0
+		}
+		return n
+	}
+	limit := math.Exp(-mean)
+	n := 0
+	for p := random.Float64(); p > limit; p *= random.Float64() {
+		n++
+	}
+	return n
+}
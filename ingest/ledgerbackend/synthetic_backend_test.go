@@ -0,0 +1,254 @@
+package ledgerbackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+func TestNewSyntheticBackendValidation(t *testing.T) {
+	_, err := NewSyntheticBackend(SyntheticConfig{})
+	assert.EqualError(t, err, "network passphrase is required")
+
+	_, err = NewSyntheticBackend(SyntheticConfig{NetworkPassphrase: network.TestNetworkPassphrase, Accounts: 1})
+	assert.EqualError(t, err, "at least 2 accounts are required")
+
+	_, err = NewSyntheticBackend(SyntheticConfig{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		OperationMix:      map[xdr.OperationType]int{xdr.OperationTypeInflation: 1},
+	})
+	assert.EqualError(t, err, "OperationTypeInflation operations cannot be generated")
+
+	_, err = NewSyntheticBackend(SyntheticConfig{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		OperationMix:      map[xdr.OperationType]int{xdr.OperationTypePayment: 0},
+	})
+	assert.EqualError(t, err, "operation mix has no operation")
+}
+
+func TestSyntheticBackendLedgers(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewSyntheticBackend(SyntheticConfig{
+		NetworkPassphrase:           network.TestNetworkPassphrase,
+		TPS:                         20,
+		MaxOperationsPerTransaction: 5,
+		OperationMix: map[xdr.OperationType]int{
+			xdr.OperationTypeCreateAccount:   1,
+			xdr.OperationTypePayment:         1,
+			xdr.OperationTypeManageSellOffer: 1,
+			xdr.OperationTypeChangeTrust:     1,
+			xdr.OperationTypeManageData:      1,
+			xdr.OperationTypeBumpSequence:    1,
+		},
+		Accounts: 10,
+		Seed:     1,
+	})
+	require.NoError(t, err)
+
+	_, err = backend.GetLedger(ctx, 2)
+	assert.EqualError(t, err, "session is not prepared, call PrepareRange first")
+
+	require.NoError(t, backend.PrepareRange(ctx, BoundedRange(2, 21)))
+	prepared, err := backend.IsPrepared(ctx, BoundedRange(5, 10))
+	require.NoError(t, err)
+	assert.True(t, prepared)
+	latest, err := backend.GetLatestLedgerSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(21), latest)
+
+	_, err = backend.GetLedger(ctx, 22)
+	assert.EqualError(t, err, "requested ledger 22 is not in the prepared range [2,21]")
+
+	// every change of an entry starts with its state after the previous one
+	entries := map[string]xdr.LedgerEntry{}
+	checkChanges := func(changes xdr.LedgerEntryChanges) {
+		for _, change := range changes {
+			entry := change.State
+			if entry == nil {
+				entry = change.Updated
+			}
+			if entry == nil {
+				entry = change.Created
+			}
+			key, err := entry.LedgerKey().MarshalBinaryBase64()
+			require.NoError(t, err)
+			if change.State != nil {
+				if previous, ok := entries[key]; ok {
+					assert.Equal(t, previous, *change.State)
+				}
+				continue
+			}
+			entries[key] = *entry
+		}
+	}
+
+	var previousHash xdr.Hash
+	transactions := 0
+	var ledgers []xdr.LedgerCloseMeta
+	for sequence := uint32(2); sequence <= 21; sequence++ {
+		ledger, err := backend.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		ledgers = append(ledgers, ledger)
+		assert.Equal(t, sequence, ledger.LedgerSequence())
+
+		header := ledger.V0.LedgerHeader
+		encoded, err := header.Header.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, xdr.Hash(sha256.Sum256(encoded)), header.Hash)
+		if sequence > 2 {
+			assert.Equal(t, previousHash, header.Header.PreviousLedgerHash)
+		}
+		previousHash = header.Hash
+
+		encoded, err = ledger.V0.TxSet.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, xdr.Hash(sha256.Sum256(encoded)), header.Header.ScpValue.TxSetHash)
+
+		require.Len(t, ledger.V0.TxProcessing, len(ledger.V0.TxSet.Txs))
+		for i, envelope := range ledger.V0.TxSet.Txs {
+			transactions++
+			result := ledger.V0.TxProcessing[i]
+			hash, err := network.HashTransactionInEnvelope(envelope, network.TestNetworkPassphrase)
+			require.NoError(t, err)
+			assert.Equal(t, xdr.Hash(hash), result.Result.TransactionHash)
+			assert.True(t, result.Result.Successful())
+			results, ok := result.Result.OperationResults()
+			require.True(t, ok)
+			assert.Len(t, results, len(envelope.Operations()))
+
+			checkChanges(result.FeeProcessing)
+			checkChanges(result.TxApplyProcessing.V2.TxChangesBefore)
+			require.Len(t, result.TxApplyProcessing.V2.Operations, len(envelope.Operations()))
+			for _, op := range result.TxApplyProcessing.V2.Operations {
+				checkChanges(op.Changes)
+			}
+		}
+	}
+	// 100 transactions per ledger on average
+	assert.InDelta(t, 20*100, transactions, 300)
+
+	// the latest ledger can be requested again, but not the ones before it
+	ledger, err := backend.GetLedger(ctx, 21)
+	require.NoError(t, err)
+	assert.Equal(t, ledgers[len(ledgers)-1], ledger)
+	_, err = backend.GetLedger(ctx, 20)
+	assert.EqualError(t, err, "requested ledger 20 was generated already, call PrepareRange to start again")
+
+	// the ledgers are generated again from the seed
+	require.NoError(t, backend.PrepareRange(ctx, BoundedRange(2, 21)))
+	ledger, err = backend.GetLedger(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ledgers[0].V0.TxSet, ledger.V0.TxSet)
+
+	require.NoError(t, backend.Close())
+	_, err = backend.GetLedger(ctx, 3)
+	assert.True(t, IsPermanent(err))
+}
+
+func TestSyntheticBackendRealtime(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewSyntheticBackend(SyntheticConfig{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		CloseTime:         50 * time.Millisecond,
+		Realtime:          true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.PrepareRange(ctx, UnboundedRange(10)))
+
+	latest, err := backend.GetLatestLedgerSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), latest)
+
+	start := time.Now()
+	_, err = backend.GetLedger(ctx, 12)
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = backend.GetLedger(cancelled, 100)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestSyntheticBackendBalances(t *testing.T) {
+	ctx := context.Background()
+	// the created accounts are funded with 10 XLM, less than the payments
+	backend, err := NewSyntheticBackend(SyntheticConfig{
+		NetworkPassphrase:           network.TestNetworkPassphrase,
+		TPS:                         50,
+		MaxOperationsPerTransaction: 3,
+		OperationMix: map[xdr.OperationType]int{
+			xdr.OperationTypeCreateAccount:   2,
+			xdr.OperationTypePayment:         6,
+			xdr.OperationTypeManageSellOffer: 1,
+			xdr.OperationTypeManageData:      1,
+		},
+		Accounts: 3,
+		Seed:     1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.PrepareRange(ctx, BoundedRange(2, 101)))
+
+	for sequence := uint32(2); sequence <= 101; sequence++ {
+		ledger, err := backend.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		baseReserve := xdr.Int64(ledger.V0.LedgerHeader.Header.BaseReserve)
+		checkBalances := func(changes xdr.LedgerEntryChanges) {
+			for _, change := range changes {
+				entry := change.Updated
+				if entry == nil {
+					entry = change.Created
+				}
+				if entry == nil || entry.Data.Type != xdr.LedgerEntryTypeAccount {
+					continue
+				}
+				account := entry.Data.Account
+				minBalance := (2 + xdr.Int64(account.NumSubEntries)) * baseReserve
+				require.True(t, account.Balance >= minBalance,
+					"balance of %s in ledger %d is below its minimum balance", account.AccountId.Address(), sequence)
+			}
+		}
+		for _, result := range ledger.V0.TxProcessing {
+			checkBalances(result.FeeProcessing)
+			for _, op := range result.TxApplyProcessing.V2.Operations {
+				checkBalances(op.Changes)
+			}
+		}
+	}
+}
+
+func TestSyntheticBackendRealtimeUnlocked(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backend, err := NewSyntheticBackend(SyntheticConfig{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		CloseTime:         time.Hour,
+		Realtime:          true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.PrepareRange(ctx, UnboundedRange(10)))
+
+	done := make(chan error)
+	go func() {
+		_, err := backend.GetLedger(ctx, 11)
+		done <- err
+	}()
+	// let GetLedger start waiting
+	time.Sleep(20 * time.Millisecond)
+
+	// the backend is not locked while GetLedger waits for the ledger
+	latest, err := backend.GetLatestLedgerSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), latest)
+	require.NoError(t, backend.Close())
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
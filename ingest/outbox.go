@@ -0,0 +1,258 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// DefaultOutboxTable is the table of the messages of an Outbox with no Table.
+const DefaultOutboxTable = "ingest_outbox"
+
+// outboxInsertBatchSize is the maximum number of messages inserted by a
+// single query, postgres allowing at most 65535 parameters per query and a
+// message having 5 of them.
+const outboxInsertBatchSize = 65535 / 5
+
+// Outbox implements the transactional outbox pattern on top of ingestion.
+//
+// Ingestion is at-least-once: after a crash a ledger is processed again and
+// its changes are emitted twice. Commit writes the changes of a ledger to the
+// outbox table in the same database transaction as the business rows of the
+// consumer, and records the ledger as committed, so that a ledger processed
+// again is skipped and neither the rows nor the messages are duplicated.
+// Publish then delivers the messages from the outbox to a message broker or
+// any other downstream system and marks them as published in the transaction
+// they were read in. The messages are delivered in order only if a single
+// publisher runs at a time, see Publish.
+//
+// A message published whose transaction fails to commit is published again,
+// so downstream systems must deduplicate the messages by OutboxMessage.ID to
+// get end-to-end exactly-once semantics.
+type Outbox struct {
+	// Session is the session of the database of the outbox tables and of the
+	// business rows. It is cloned by Commit and Publish which run in their own
+	// transactions.
+	Session db.SessionInterface
+	// Table is the name of the table of the messages, DefaultOutboxTable if
+	// empty. The committed ledgers are recorded in the Table + "_ledgers"
+	// table.
+	Table string
+}
+
+// OutboxMessage is a change of a ledger written to an Outbox.
+type OutboxMessage struct {
+	// ID is the unique and increasing identifier of the message.
+	ID int64
+	// Ledger is the sequence of the ledger of the change.
+	Ledger uint32
+	// Index is the index of the change among the changes of the ledger.
+	Index  uint32
+	Change Change
+}
+
+type outboxRow struct {
+	ID     int64  `db:"id"`
+	Ledger uint32 `db:"ledger_sequence"`
+	Index  uint32 `db:"change_index"`
+	Type   int32  `db:"change_type"`
+	Pre    []byte `db:"pre"`
+	Post   []byte `db:"post"`
+}
+
+func (o *Outbox) table() string {
+	if o.Table == "" {
+		return DefaultOutboxTable
+	}
+	return o.Table
+}
+
+func (o *Outbox) ledgersTable() string {
+	return o.table() + "_ledgers"
+}
+
+// CreateTables creates the outbox tables if they do not exist yet.
+func (o *Outbox) CreateTables(ctx context.Context) error {
+	_, err := o.Session.ExecRaw(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id bigserial PRIMARY KEY,
+		ledger_sequence integer NOT NULL,
+		change_index integer NOT NULL,
+		change_type integer NOT NULL,
+		pre bytea,
+		post bytea,
+		published boolean NOT NULL DEFAULT false
+	)`, o.table()))
+	if err != nil {
+		return errors.Wrap(err, "could not create outbox table")
+	}
+	_, err = o.Session.ExecRaw(ctx, fmt.Sprintf(
+		`CREATE INDEX IF NOT EXISTS %s_unpublished ON %s (id) WHERE NOT published`,
+		o.table(), o.table(),
+	))
+	if err != nil {
+		return errors.Wrap(err, "could not create outbox index")
+	}
+	_, err = o.Session.ExecRaw(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (ledger_sequence integer PRIMARY KEY)`,
+		o.ledgersTable(),
+	))
+	return errors.Wrap(err, "could not create outbox ledgers table")
+}
+
+// Commit writes the changes of the ledger to the outbox and calls store, in
+// a single database transaction, with a session in this transaction to write
+// the business rows derived from the changes. The transaction is rolled back
+// if store returns an error.
+//
+// Commit returns false, without calling store, if the ledger was already
+// committed.
+func (o *Outbox) Commit(
+	ctx context.Context,
+	ledger uint32,
+	changes []Change,
+	store func(ctx context.Context, session db.SessionInterface) error,
+) (bool, error) {
+	session := o.Session.Clone()
+	if err := session.Begin(); err != nil {
+		return false, errors.Wrap(err, "could not begin transaction")
+	}
+	defer session.Rollback()
+
+	result, err := session.Exec(ctx, sq.Insert(o.ledgersTable()).
+		Columns("ledger_sequence").
+		Values(ledger).
+		Suffix("ON CONFLICT DO NOTHING"))
+	if err != nil {
+		return false, errors.Wrapf(err, "could not record ledger %d", ledger)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "could not count the rows affected")
+	}
+	if inserted == 0 {
+		return false, nil
+	}
+
+	for start := 0; start < len(changes); start += outboxInsertBatchSize {
+		end := start + outboxInsertBatchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		insert := sq.Insert(o.table()).
+			Columns("ledger_sequence", "change_index", "change_type", "pre", "post")
+		for i := start; i < end; i++ {
+			change := changes[i]
+			pre, err := marshalOutboxEntry(change.Pre)
+			if err != nil {
+				return false, errors.Wrapf(err, "could not encode change %d of ledger %d", i, ledger)
+			}
+			post, err := marshalOutboxEntry(change.Post)
+			if err != nil {
+				return false, errors.Wrapf(err, "could not encode change %d of ledger %d", i, ledger)
+			}
+			insert = insert.Values(ledger, i, int32(change.Type), pre, post)
+		}
+		if _, err = session.Exec(ctx, insert); err != nil {
+			return false, errors.Wrapf(err, "could not write the changes of ledger %d", ledger)
+		}
+	}
+
+	if err = store(ctx, session); err != nil {
+		return false, err
+	}
+	if err = session.Commit(); err != nil {
+		return false, errors.Wrap(err, "could not commit transaction")
+	}
+	return true, nil
+}
+
+// Publish reads up to limit unpublished messages, in order, and calls publish
+// with them. The messages are marked as published if publish returns nil.
+// It returns the number of messages published, 0 if there are none left.
+//
+// The messages read are locked until they are marked as published, so
+// several publishers can run concurrently without publishing the same
+// messages. However the messages are then delivered out of order: a
+// publisher skips the messages locked by another one, and a batch of earlier
+// messages whose publication fails is published again after later ones.
+// Downstream systems which require the messages in order must be fed by a
+// single publisher.
+func (o *Outbox) Publish(
+	ctx context.Context,
+	limit uint64,
+	publish func(ctx context.Context, messages []OutboxMessage) error,
+) (int, error) {
+	session := o.Session.Clone()
+	if err := session.Begin(); err != nil {
+		return 0, errors.Wrap(err, "could not begin transaction")
+	}
+	defer session.Rollback()
+
+	var rows []outboxRow
+	err := session.Select(ctx, &rows, sq.Select("id", "ledger_sequence", "change_index", "change_type", "pre", "post").
+		From(o.table()).
+		Where("NOT published").
+		OrderBy("id").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED"))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read outbox")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	messages := make([]OutboxMessage, len(rows))
+	ids := make([]int64, len(rows))
+	for i, row := range rows {
+		messages[i] = OutboxMessage{
+			ID:     row.ID,
+			Ledger: row.Ledger,
+			Index:  row.Index,
+			Change: Change{Type: xdr.LedgerEntryType(row.Type)},
+		}
+		if messages[i].Change.Pre, err = unmarshalOutboxEntry(row.Pre); err != nil {
+			return 0, errors.Wrapf(err, "could not decode message %d", row.ID)
+		}
+		if messages[i].Change.Post, err = unmarshalOutboxEntry(row.Post); err != nil {
+			return 0, errors.Wrapf(err, "could not decode message %d", row.ID)
+		}
+		ids[i] = row.ID
+	}
+
+	if err = publish(ctx, messages); err != nil {
+		return 0, err
+	}
+	_, err = session.Exec(ctx, sq.Update(o.table()).
+		Set("published", true).
+		Where(sq.Eq{"id": ids}))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not mark messages as published")
+	}
+	if err = session.Commit(); err != nil {
+		return 0, errors.Wrap(err, "could not commit transaction")
+	}
+	return len(messages), nil
+}
+
+func marshalOutboxEntry(entry *xdr.LedgerEntry) ([]byte, error) {
+	if entry == nil {
+		return nil, nil
+	}
+	return entry.MarshalBinary()
+}
+
+func unmarshalOutboxEntry(data []byte) (*xdr.LedgerEntry, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var entry xdr.LedgerEntry
+	if err := entry.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
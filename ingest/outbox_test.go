@@ -0,0 +1,258 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/db/dbtest"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type rowsAffected int64
+
+func (r rowsAffected) LastInsertId() (int64, error) { return 0, nil }
+func (r rowsAffected) RowsAffected() (int64, error) { return int64(r), nil }
+
+func matchQuery(t *testing.T, expectedSQL string, expectedArgs ...interface{}) interface{} {
+	return mock.MatchedBy(func(query sq.Sqlizer) bool {
+		sql, args, err := query.ToSql()
+		require.NoError(t, err)
+		if sql != expectedSQL {
+			return false
+		}
+		return expectedArgs == nil || assert.ObjectsAreEqual(expectedArgs, args)
+	})
+}
+
+func outboxTestChanges() []Change {
+	post := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+				Balance:   100,
+			},
+		},
+	}
+	pre := post
+	pre.LastModifiedLedgerSeq = 9
+	return []Change{
+		{Type: xdr.LedgerEntryTypeAccount, Post: &post},
+		{Type: xdr.LedgerEntryTypeAccount, Pre: &pre, Post: &post},
+	}
+}
+
+func TestOutboxCommit(t *testing.T) {
+	ctx := context.Background()
+	changes := outboxTestChanges()
+	pre, err := changes[1].Pre.MarshalBinary()
+	require.NoError(t, err)
+	post, err := changes[1].Post.MarshalBinary()
+	require.NoError(t, err)
+
+	session := &db.MockSession{}
+	defer session.AssertExpectations(t)
+	outbox := Outbox{Session: session}
+
+	session.On("Clone").Return(session)
+	session.On("Begin").Return(nil)
+	session.On("Rollback").Return(nil)
+	session.On("Exec", ctx, matchQuery(t,
+		"INSERT INTO ingest_outbox_ledgers (ledger_sequence) VALUES (?) ON CONFLICT DO NOTHING", uint32(10),
+	)).Return(rowsAffected(1), nil).Once()
+	session.On("Exec", ctx, matchQuery(t,
+		"INSERT INTO ingest_outbox (ledger_sequence,change_index,change_type,pre,post) VALUES (?,?,?,?,?),(?,?,?,?,?)",
+		uint32(10), 0, int32(xdr.LedgerEntryTypeAccount), []byte(nil), post,
+		uint32(10), 1, int32(xdr.LedgerEntryTypeAccount), pre, post,
+	)).Return(rowsAffected(2), nil).Once()
+	session.On("Commit").Return(nil).Once()
+
+	stored := false
+	committed, err := outbox.Commit(ctx, 10, changes, func(ctx context.Context, tx db.SessionInterface) error {
+		assert.Equal(t, session, tx)
+		stored = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, committed)
+	assert.True(t, stored)
+
+	// a ledger committed already is skipped
+	session.On("Exec", ctx, matchQuery(t,
+		"INSERT INTO ingest_outbox_ledgers (ledger_sequence) VALUES (?) ON CONFLICT DO NOTHING", uint32(10),
+	)).Return(rowsAffected(0), nil).Once()
+	committed, err = outbox.Commit(ctx, 10, changes, func(ctx context.Context, tx db.SessionInterface) error {
+		t.Fatal("store called for a committed ledger")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, committed)
+}
+
+func TestOutboxCommitBatches(t *testing.T) {
+	ctx := context.Background()
+	session := &db.MockSession{}
+	defer session.AssertExpectations(t)
+	outbox := Outbox{Session: session}
+
+	changes := make([]Change, outboxInsertBatchSize+1)
+	for i := range changes {
+		changes[i] = outboxTestChanges()[0]
+	}
+	insertedArgs := func(count int) interface{} {
+		return mock.MatchedBy(func(query sq.Sqlizer) bool {
+			sql, args, err := query.ToSql()
+			require.NoError(t, err)
+			return strings.HasPrefix(sql, "INSERT INTO ingest_outbox (") && len(args) == 5*count
+		})
+	}
+
+	session.On("Clone").Return(session)
+	session.On("Begin").Return(nil)
+	session.On("Rollback").Return(nil)
+	session.On("Exec", ctx, matchQuery(t,
+		"INSERT INTO ingest_outbox_ledgers (ledger_sequence) VALUES (?) ON CONFLICT DO NOTHING", uint32(10),
+	)).Return(rowsAffected(1), nil).Once()
+	session.On("Exec", ctx, insertedArgs(outboxInsertBatchSize)).Return(rowsAffected(outboxInsertBatchSize), nil).Once()
+	session.On("Exec", ctx, insertedArgs(1)).Return(rowsAffected(1), nil).Once()
+	session.On("Commit").Return(nil).Once()
+
+	committed, err := outbox.Commit(ctx, 10, changes, func(ctx context.Context, tx db.SessionInterface) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, committed)
+}
+
+func TestOutboxCommitStoreError(t *testing.T) {
+	ctx := context.Background()
+	session := &db.MockSession{}
+	defer session.AssertExpectations(t)
+	outbox := Outbox{Session: session, Table: "events"}
+
+	// the transaction is rolled back and not committed
+	session.On("Clone").Return(session)
+	session.On("Begin").Return(nil)
+	session.On("Rollback").Return(nil).Once()
+	session.On("Exec", ctx, matchQuery(t,
+		"INSERT INTO events_ledgers (ledger_sequence) VALUES (?) ON CONFLICT DO NOTHING", uint32(11),
+	)).Return(rowsAffected(1), nil).Once()
+
+	committed, err := outbox.Commit(ctx, 11, nil, func(ctx context.Context, tx db.SessionInterface) error {
+		return errors.New("duplicate key")
+	})
+	assert.EqualError(t, err, "duplicate key")
+	assert.False(t, committed)
+}
+
+func TestOutboxPublish(t *testing.T) {
+	ctx := context.Background()
+	changes := outboxTestChanges()
+	pre, err := changes[1].Pre.MarshalBinary()
+	require.NoError(t, err)
+	post, err := changes[1].Post.MarshalBinary()
+	require.NoError(t, err)
+
+	session := &db.MockSession{}
+	defer session.AssertExpectations(t)
+	outbox := Outbox{Session: session}
+
+	selectQuery := matchQuery(t,
+		"SELECT id, ledger_sequence, change_index, change_type, pre, post FROM ingest_outbox "+
+			"WHERE NOT published ORDER BY id LIMIT 10 FOR UPDATE SKIP LOCKED",
+	)
+	session.On("Clone").Return(session)
+	session.On("Begin").Return(nil)
+	session.On("Rollback").Return(nil)
+	session.On("Select", ctx, mock.Anything, selectQuery).Run(func(args mock.Arguments) {
+		rows := args.Get(1).(*[]outboxRow)
+		*rows = []outboxRow{
+			{ID: 3, Ledger: 10, Index: 0, Type: int32(xdr.LedgerEntryTypeAccount), Post: post},
+			{ID: 4, Ledger: 10, Index: 1, Type: int32(xdr.LedgerEntryTypeAccount), Pre: pre, Post: post},
+		}
+	}).Return(nil).Once()
+	session.On("Exec", ctx, matchQuery(t,
+		"UPDATE ingest_outbox SET published = ? WHERE id IN (?,?)", true, int64(3), int64(4),
+	)).Return(rowsAffected(2), nil).Once()
+	session.On("Commit").Return(nil).Once()
+
+	var published []OutboxMessage
+	count, err := outbox.Publish(ctx, 10, func(ctx context.Context, messages []OutboxMessage) error {
+		published = messages
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []OutboxMessage{
+		{ID: 3, Ledger: 10, Index: 0, Change: changes[0]},
+		{ID: 4, Ledger: 10, Index: 1, Change: changes[1]},
+	}, published)
+
+	// messages are not marked as published if publish fails
+	session.On("Select", ctx, mock.Anything, selectQuery).Run(func(args mock.Arguments) {
+		rows := args.Get(1).(*[]outboxRow)
+		*rows = []outboxRow{{ID: 5, Ledger: 11, Type: int32(xdr.LedgerEntryTypeAccount), Post: post}}
+	}).Return(nil).Once()
+	_, err = outbox.Publish(ctx, 10, func(ctx context.Context, messages []OutboxMessage) error {
+		return errors.New("broker unavailable")
+	})
+	assert.EqualError(t, err, "broker unavailable")
+
+	session.On("Select", ctx, mock.Anything, selectQuery).Return(nil).Once()
+	count, err = outbox.Publish(ctx, 10, func(ctx context.Context, messages []OutboxMessage) error {
+		t.Fatal("publish called without messages")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestOutboxCommitManyChanges(t *testing.T) {
+	ctx := context.Background()
+	testDB := dbtest.Postgres(t)
+	defer testDB.Close()
+	session := &db.Session{DB: testDB.Open()}
+	defer session.DB.Close()
+
+	outbox := Outbox{Session: session}
+	require.NoError(t, outbox.CreateTables(ctx))
+
+	// more changes than can be inserted by a single query
+	changes := make([]Change, 0, 2*outboxInsertBatchSize+1)
+	for len(changes) < cap(changes) {
+		changes = append(changes, outboxTestChanges()...)
+	}
+	changes = changes[:cap(changes)]
+
+	committed, err := outbox.Commit(ctx, 10, changes, func(ctx context.Context, tx db.SessionInterface) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, committed)
+
+	var published []OutboxMessage
+	for {
+		count, err := outbox.Publish(ctx, 5000, func(ctx context.Context, messages []OutboxMessage) error {
+			published = append(published, messages...)
+			return nil
+		})
+		require.NoError(t, err)
+		if count == 0 {
+			break
+		}
+	}
+	require.Len(t, published, len(changes))
+	for i, message := range published {
+		assert.Equal(t, uint32(10), message.Ledger)
+		assert.Equal(t, uint32(i), message.Index)
+		assert.Equal(t, changes[i], message.Change)
+	}
+}
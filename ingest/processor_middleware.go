@@ -0,0 +1,197 @@
+package ingest
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// ChangeProcessor is a processor of ledger entry changes, like
+// StatsChangeProcessor or AssetStatsChangeProcessor.
+type ChangeProcessor interface {
+	ProcessChange(ctx context.Context, change Change) error
+}
+
+// ChangeProcessorFunc is an adapter allowing the use of a function as a
+// ChangeProcessor.
+type ChangeProcessorFunc func(ctx context.Context, change Change) error
+
+// ProcessChange calls f(ctx, change).
+func (f ChangeProcessorFunc) ProcessChange(ctx context.Context, change Change) error {
+	return f(ctx, change)
+}
+
+// LedgerTransactionProcessor is a processor of the transactions read by a
+// LedgerTransactionReader.
+type LedgerTransactionProcessor interface {
+	ProcessTransaction(ctx context.Context, transaction LedgerTransaction) error
+}
+
+// LedgerTransactionProcessorFunc is an adapter allowing the use of a function
+// as a LedgerTransactionProcessor.
+type LedgerTransactionProcessorFunc func(ctx context.Context, transaction LedgerTransaction) error
+
+// ProcessTransaction calls f(ctx, transaction).
+func (f LedgerTransactionProcessorFunc) ProcessTransaction(ctx context.Context, transaction LedgerTransaction) error {
+	return f(ctx, transaction)
+}
+
+// ChangeMiddleware wraps a ChangeProcessor to add a cross-cutting concern
+// (logging, timing, panic recovery, filtering...) to it, like an http
+// middleware wraps an http.Handler.
+type ChangeMiddleware func(next ChangeProcessor) ChangeProcessor
+
+// LedgerTransactionMiddleware wraps a LedgerTransactionProcessor, see
+// ChangeMiddleware.
+type LedgerTransactionMiddleware func(next LedgerTransactionProcessor) LedgerTransactionProcessor
+
+// ChainChangeMiddleware wraps the processor with the given middleware. The
+// first middleware is the outermost one: it sees every change first and the
+// result of the processor last. A panic of the processor or of any middleware
+// is recovered and returned as an error to the enclosing middleware (see
+// RecoverChangePanics).
+func ChainChangeMiddleware(processor ChangeProcessor, middleware ...ChangeMiddleware) ChangeProcessor {
+	recoverPanics := RecoverChangePanics()
+	processor = recoverPanics(processor)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		processor = recoverPanics(middleware[i](processor))
+	}
+	return processor
+}
+
+// ChainLedgerTransactionMiddleware wraps the processor with the given
+// middleware, the first one being the outermost one. Like in
+// ChainChangeMiddleware, panics are recovered and returned as errors.
+func ChainLedgerTransactionMiddleware(
+	processor LedgerTransactionProcessor,
+	middleware ...LedgerTransactionMiddleware,
+) LedgerTransactionProcessor {
+	recoverPanics := RecoverLedgerTransactionPanics()
+	processor = recoverPanics(processor)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		processor = recoverPanics(middleware[i](processor))
+	}
+	return processor
+}
+
+// RecoverChangePanics returns a middleware converting a panic of the
+// processor into an error, so that a single malformed change does not crash
+// the whole ingestion.
+func RecoverChangePanics() ChangeMiddleware {
+	return func(next ChangeProcessor) ChangeProcessor {
+		return ChangeProcessorFunc(func(ctx context.Context, change Change) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = errors.Errorf("panic processing %s change: %v", change.Type, r)
+				}
+			}()
+			return next.ProcessChange(ctx, change)
+		})
+	}
+}
+
+// RecoverLedgerTransactionPanics returns a middleware converting a panic of
+// the processor into an error identifying the transaction.
+func RecoverLedgerTransactionPanics() LedgerTransactionMiddleware {
+	return func(next LedgerTransactionProcessor) LedgerTransactionProcessor {
+		return LedgerTransactionProcessorFunc(func(ctx context.Context, transaction LedgerTransaction) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = errors.Errorf(
+						"panic processing transaction %s (index %d): %v",
+						transaction.Result.TransactionHash.HexString(), transaction.Index, r,
+					)
+				}
+			}()
+			return next.ProcessTransaction(ctx, transaction)
+		})
+	}
+}
+
+// TimeChanges returns a middleware calling observe with the time it took to
+// process every change, e.g. to feed a metrics histogram.
+func TimeChanges(observe func(change Change, duration time.Duration)) ChangeMiddleware {
+	return func(next ChangeProcessor) ChangeProcessor {
+		return ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			start := time.Now()
+			err := next.ProcessChange(ctx, change)
+			observe(change, time.Since(start))
+			return err
+		})
+	}
+}
+
+// TimeLedgerTransactions returns a middleware calling observe with the time
+// it took to process every transaction.
+func TimeLedgerTransactions(observe func(transaction LedgerTransaction, duration time.Duration)) LedgerTransactionMiddleware {
+	return func(next LedgerTransactionProcessor) LedgerTransactionProcessor {
+		return LedgerTransactionProcessorFunc(func(ctx context.Context, transaction LedgerTransaction) error {
+			start := time.Now()
+			err := next.ProcessTransaction(ctx, transaction)
+			observe(transaction, time.Since(start))
+			return err
+		})
+	}
+}
+
+// FilterChanges returns a middleware only passing the changes for which keep
+// returns true to the processor. It can also be used to sample changes.
+func FilterChanges(keep func(change Change) bool) ChangeMiddleware {
+	return func(next ChangeProcessor) ChangeProcessor {
+		return ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			if !keep(change) {
+				return nil
+			}
+			return next.ProcessChange(ctx, change)
+		})
+	}
+}
+
+// FilterLedgerTransactions returns a middleware only passing the transactions
+// for which keep returns true to the processor.
+func FilterLedgerTransactions(keep func(transaction LedgerTransaction) bool) LedgerTransactionMiddleware {
+	return func(next LedgerTransactionProcessor) LedgerTransactionProcessor {
+		return LedgerTransactionProcessorFunc(func(ctx context.Context, transaction LedgerTransaction) error {
+			if !keep(transaction) {
+				return nil
+			}
+			return next.ProcessTransaction(ctx, transaction)
+		})
+	}
+}
+
+// LogChangeErrors returns a middleware logging the errors returned by the
+// processor along with the type of the change. The errors are still returned.
+func LogChangeErrors(logger *log.Entry) ChangeMiddleware {
+	return func(next ChangeProcessor) ChangeProcessor {
+		return ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			err := next.ProcessChange(ctx, change)
+			if err != nil {
+				logger.WithError(err).
+					WithField("type", change.Type.String()).
+					Error("Error processing change")
+			}
+			return err
+		})
+	}
+}
+
+// LogLedgerTransactionErrors returns a middleware logging the errors returned
+// by the processor along with the hash of the transaction. The errors are
+// still returned.
+func LogLedgerTransactionErrors(logger *log.Entry) LedgerTransactionMiddleware {
+	return func(next LedgerTransactionProcessor) LedgerTransactionProcessor {
+		return LedgerTransactionProcessorFunc(func(ctx context.Context, transaction LedgerTransaction) error {
+			err := next.ProcessTransaction(ctx, transaction)
+			if err != nil {
+				logger.WithError(err).WithFields(log.F{
+					"hash":  transaction.Result.TransactionHash.HexString(),
+					"index": transaction.Index,
+				}).Error("Error processing transaction")
+			}
+			return err
+		})
+	}
+}
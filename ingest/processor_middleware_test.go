@@ -0,0 +1,164 @@
+package ingest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainChangeMiddleware(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	trace := func(name string) ChangeMiddleware {
+		return func(next ChangeProcessor) ChangeProcessor {
+			return ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+				calls = append(calls, name+" before")
+				err := next.ProcessChange(ctx, change)
+				calls = append(calls, name+" after")
+				return err
+			})
+		}
+	}
+	processor := ChainChangeMiddleware(
+		ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			calls = append(calls, "processor")
+			return nil
+		}),
+		trace("outer"),
+		trace("inner"),
+	)
+
+	assert.NoError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeAccount}))
+	assert.Equal(t, []string{"outer before", "inner before", "processor", "inner after", "outer after"}, calls)
+
+	// StatsChangeProcessor is a ChangeProcessor
+	stats := &StatsChangeProcessor{}
+	processor = ChainChangeMiddleware(stats, FilterChanges(func(change Change) bool {
+		return change.Type == xdr.LedgerEntryTypeAccount
+	}))
+	assert.NoError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeAccount, Post: &xdr.LedgerEntry{}}))
+	assert.NoError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeOffer, Post: &xdr.LedgerEntry{}}))
+	assert.Equal(t, int64(1), stats.GetResults().AccountsCreated)
+	assert.Equal(t, int64(0), stats.GetResults().OffersCreated)
+}
+
+func TestChainMiddlewareRecoversPanics(t *testing.T) {
+	ctx := context.Background()
+
+	var processorErr error
+	processor := ChainChangeMiddleware(
+		ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			panic("unexpected change")
+		}),
+		func(next ChangeProcessor) ChangeProcessor {
+			return ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+				processorErr = next.ProcessChange(ctx, change)
+				return nil
+			})
+		},
+		FilterChanges(func(change Change) bool {
+			if change.Type == xdr.LedgerEntryTypeData {
+				panic("unexpected data")
+			}
+			return true
+		}),
+	)
+
+	// The panic of the processor is returned to the middleware
+	assert.NoError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeAccount}))
+	assert.EqualError(t, processorErr, "panic processing LedgerEntryTypeAccount change: unexpected change")
+
+	// and so is the panic of the filter
+	assert.NoError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeData}))
+	assert.EqualError(t, processorErr, "panic processing LedgerEntryTypeData change: unexpected data")
+
+	transactionProcessor := ChainLedgerTransactionMiddleware(
+		LedgerTransactionProcessorFunc(func(ctx context.Context, transaction LedgerTransaction) error {
+			return nil
+		}),
+		TimeLedgerTransactions(func(transaction LedgerTransaction, duration time.Duration) {
+			panic("boom")
+		}),
+	)
+	assert.EqualError(t, transactionProcessor.ProcessTransaction(ctx, LedgerTransaction{Index: 1}),
+		"panic processing transaction 0000000000000000000000000000000000000000000000000000000000000000 (index 1): boom")
+}
+
+func TestChangeMiddleware(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	done := logger.StartTest(logrus.ErrorLevel)
+
+	var observed []xdr.LedgerEntryType
+	processor := ChainChangeMiddleware(
+		ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			switch change.Type {
+			case xdr.LedgerEntryTypeOffer:
+				panic("unexpected offer")
+			case xdr.LedgerEntryTypeData:
+				return errors.New("invalid data")
+			}
+			return nil
+		}),
+		LogChangeErrors(logger),
+		TimeChanges(func(change Change, duration time.Duration) {
+			assert.True(t, duration >= 0)
+			observed = append(observed, change.Type)
+		}),
+		RecoverChangePanics(),
+	)
+
+	assert.NoError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeAccount}))
+	assert.EqualError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeOffer}), "panic processing LedgerEntryTypeOffer change: unexpected offer")
+	assert.EqualError(t, processor.ProcessChange(ctx, Change{Type: xdr.LedgerEntryTypeData}), "invalid data")
+	assert.Equal(t, []xdr.LedgerEntryType{xdr.LedgerEntryTypeAccount, xdr.LedgerEntryTypeOffer, xdr.LedgerEntryTypeData}, observed)
+
+	logs := done()
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, "Error processing change", logs[0].Message)
+		assert.Equal(t, "LedgerEntryTypeOffer", logs[0].Data["type"])
+		assert.Equal(t, "LedgerEntryTypeData", logs[1].Data["type"])
+	}
+}
+
+func TestLedgerTransactionMiddleware(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	done := logger.StartTest(logrus.ErrorLevel)
+
+	transaction := LedgerTransaction{
+		Index:  2,
+		Result: xdr.TransactionResultPair{TransactionHash: xdr.Hash{0xab}},
+	}
+	var observed []uint32
+	processor := ChainLedgerTransactionMiddleware(
+		LedgerTransactionProcessorFunc(func(ctx context.Context, transaction LedgerTransaction) error {
+			panic("boom")
+		}),
+		LogLedgerTransactionErrors(logger),
+		TimeLedgerTransactions(func(transaction LedgerTransaction, duration time.Duration) {
+			observed = append(observed, transaction.Index)
+		}),
+		FilterLedgerTransactions(func(transaction LedgerTransaction) bool {
+			return transaction.Index%2 == 0
+		}),
+		RecoverLedgerTransactionPanics(),
+	)
+
+	assert.NoError(t, processor.ProcessTransaction(ctx, LedgerTransaction{Index: 1}))
+	assert.EqualError(t, processor.ProcessTransaction(ctx, transaction),
+		"panic processing transaction ab00000000000000000000000000000000000000000000000000000000000000 (index 2): boom")
+	assert.Equal(t, []uint32{1, 2}, observed)
+
+	logs := done()
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, "Error processing transaction", logs[0].Message)
+		assert.Equal(t, uint32(2), logs[0].Data["index"])
+	}
+}
@@ -0,0 +1,187 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// StatefulProcessor is a processor whose state (supply counters,
+// aggregations...) can be snapshotted and restored, so that it does not have
+// to be rebuilt from a checkpoint when ingestion restarts, see
+// StateCheckpointer.
+type StatefulProcessor interface {
+	// SnapshotState writes the state of the processor to w.
+	SnapshotState(w io.Writer) error
+	// RestoreState replaces the state of the processor with the one read from
+	// r, written by SnapshotState.
+	RestoreState(r io.Reader) error
+}
+
+// processorSnapshot is the content of a snapshot written by a
+// StateCheckpointer.
+type processorSnapshot struct {
+	Ledger uint32
+	States map[string][]byte
+}
+
+// StateCheckpointer checkpoints the states of stateful processors together
+// with the last ledger they processed. Restarting from a checkpoint, the
+// processors are in the state they were in after that ledger, and ingestion
+// resumes from the next ledger.
+//
+// The states and the ledger are always written and read together so that a
+// crash never leaves processors with a state from a ledger other than the
+// ledger ingestion resumes from.
+type StateCheckpointer struct {
+	// Path is the file written by Checkpoint and read by Restore.
+	Path string
+
+	names      []string
+	processors map[string]StatefulProcessor
+}
+
+// NewStateCheckpointer creates a StateCheckpointer writing its checkpoints to
+// the file at path.
+func NewStateCheckpointer(path string) *StateCheckpointer {
+	return &StateCheckpointer{
+		Path:       path,
+		processors: map[string]StatefulProcessor{},
+	}
+}
+
+// Register adds a processor to the checkpoints under the given name, which
+// identifies its state in the snapshots and must not change across restarts.
+func (c *StateCheckpointer) Register(name string, processor StatefulProcessor) error {
+	if c.processors == nil {
+		c.processors = map[string]StatefulProcessor{}
+	}
+	if _, ok := c.processors[name]; ok {
+		return errors.Errorf("processor %s is already registered", name)
+	}
+	c.names = append(c.names, name)
+	c.processors[name] = processor
+	return nil
+}
+
+// WriteSnapshot writes the states of the processors, after they processed
+// the given ledger, to w. It can be used to store the snapshots somewhere
+// other than in a file, e.g. in the database transaction of an Outbox.
+func (c *StateCheckpointer) WriteSnapshot(w io.Writer, ledger uint32) error {
+	snapshot := processorSnapshot{
+		Ledger: ledger,
+		States: make(map[string][]byte, len(c.names)),
+	}
+	for _, name := range c.names {
+		var buf bytes.Buffer
+		if err := c.processors[name].SnapshotState(&buf); err != nil {
+			return errors.Wrapf(err, "could not snapshot the state of processor %s", name)
+		}
+		snapshot.States[name] = buf.Bytes()
+	}
+	return errors.Wrap(gob.NewEncoder(w).Encode(snapshot), "could not encode snapshot")
+}
+
+// ReadSnapshot restores the states of the processors from a snapshot written
+// by WriteSnapshot and returns the ledger they processed last. The states of
+// processors not registered anymore are ignored.
+//
+// It fails, before restoring any state, if a registered processor has no
+// state in the snapshot. If it fails while restoring the states, some of the
+// processors may have been restored already, so all of them must be rebuilt.
+func (c *StateCheckpointer) ReadSnapshot(r io.Reader) (uint32, error) {
+	var snapshot processorSnapshot
+	if err := gob.NewDecoder(r).Decode(&snapshot); err != nil {
+		return 0, errors.Wrap(err, "could not decode snapshot")
+	}
+	for _, name := range c.names {
+		if _, ok := snapshot.States[name]; !ok {
+			return 0, errors.Errorf("snapshot has no state for processor %s", name)
+		}
+	}
+	for _, name := range c.names {
+		if err := c.processors[name].RestoreState(bytes.NewReader(snapshot.States[name])); err != nil {
+			return 0, errors.Wrapf(err, "could not restore the state of processor %s", name)
+		}
+	}
+	return snapshot.Ledger, nil
+}
+
+// Checkpoint writes a snapshot of the states of the processors, after they
+// processed the given ledger, to Path. The previous checkpoint is replaced
+// atomically, so it is kept if Checkpoint fails or the process crashes.
+func (c *StateCheckpointer) Checkpoint(ledger uint32) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(c.Path), filepath.Base(c.Path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create checkpoint file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if err = c.WriteSnapshot(tmp, ledger); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not sync checkpoint file")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not close checkpoint file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), c.Path), "could not replace checkpoint file")
+}
+
+// Restore restores the states of the processors from the checkpoint at Path
+// and returns the ledger they processed last. It returns false if there is no
+// checkpoint yet, in which case the processors must be built from scratch.
+func (c *StateCheckpointer) Restore() (uint32, bool, error) {
+	file, err := os.Open(c.Path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Wrap(err, "could not open checkpoint file")
+	}
+	defer file.Close()
+
+	ledger, err := c.ReadSnapshot(file)
+	if err != nil {
+		return 0, false, err
+	}
+	return ledger, true, nil
+}
+
+// SnapshotState writes the stats of all assets to w.
+func (p *AssetStatsChangeProcessor) SnapshotState(w io.Writer) error {
+	return errors.Wrap(gob.NewEncoder(w).Encode(p.results), "could not encode asset stats")
+}
+
+// RestoreState replaces the stats of all assets with the ones read from r.
+func (p *AssetStatsChangeProcessor) RestoreState(r io.Reader) error {
+	results := AssetStatsChangeProcessorResults{}
+	if err := gob.NewDecoder(r).Decode(&results); err != nil {
+		return errors.Wrap(err, "could not decode asset stats")
+	}
+	p.results = results
+	return nil
+}
+
+// SnapshotState writes the counters of the processor to w.
+func (p *StatsChangeProcessor) SnapshotState(w io.Writer) error {
+	return errors.Wrap(gob.NewEncoder(w).Encode(p.results), "could not encode stats")
+}
+
+// RestoreState replaces the counters of the processor with the ones read
+// from r.
+func (p *StatsChangeProcessor) RestoreState(r io.Reader) error {
+	var results StatsChangeProcessorResults
+	if err := gob.NewDecoder(r).Decode(&results); err != nil {
+		return errors.Wrap(err, "could not decode stats")
+	}
+	p.results = results
+	return nil
+}
@@ -0,0 +1,119 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	issuer := xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML")
+	holder := xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB")
+	changes := []Change{
+		{
+			Type: xdr.LedgerEntryTypeAccount,
+			Post: &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+				Type:    xdr.LedgerEntryTypeAccount,
+				Account: &xdr.AccountEntry{AccountId: holder, Balance: 50000000},
+			}},
+		},
+		{
+			Type: xdr.LedgerEntryTypeTrustline,
+			Post: &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeTrustline,
+				TrustLine: &xdr.TrustLineEntry{
+					AccountId: holder,
+					Asset:     xdr.MustNewCreditAsset("USD", issuer.Address()).ToTrustLineAsset(),
+					Balance:   1230000000,
+					Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+				},
+			}},
+		},
+	}
+
+	assetStats := NewAssetStatsChangeProcessor()
+	stats := &StatsChangeProcessor{}
+	for _, change := range changes {
+		require.NoError(t, assetStats.ProcessChange(ctx, change))
+		require.NoError(t, stats.ProcessChange(ctx, change))
+	}
+
+	path := filepath.Join(t.TempDir(), "state")
+	checkpointer := NewStateCheckpointer(path)
+	require.NoError(t, checkpointer.Register("asset_stats", assetStats))
+	require.NoError(t, checkpointer.Register("stats", stats))
+	assert.EqualError(t, checkpointer.Register("stats", stats), "processor stats is already registered")
+
+	_, ok, err := checkpointer.Restore()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, checkpointer.Checkpoint(41))
+	require.NoError(t, checkpointer.Checkpoint(42))
+
+	restoredAssetStats := NewAssetStatsChangeProcessor()
+	restoredStats := &StatsChangeProcessor{}
+	restorer := NewStateCheckpointer(path)
+	require.NoError(t, restorer.Register("stats", restoredStats))
+	require.NoError(t, restorer.Register("asset_stats", restoredAssetStats))
+	ledger, ok, err := restorer.Restore()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(42), ledger)
+	assert.Equal(t, stats.GetResults(), restoredStats.GetResults())
+	assert.Equal(t, assetStats.GetResults(), restoredAssetStats.GetResults())
+	for key, assetStat := range restoredAssetStats.GetResults() {
+		assert.Equal(t, assetStats.GetResults()[key].CirculatingSupply(), assetStat.CirculatingSupply())
+	}
+
+	// the restored processors carry on from the snapshot
+	require.NoError(t, restoredStats.ProcessChange(ctx, changes[0]))
+	assert.Equal(t, int64(2), restoredStats.GetResults().AccountsCreated)
+
+	missing := NewStateCheckpointer(path)
+	require.NoError(t, missing.Register("offers", &StatsChangeProcessor{}))
+	_, _, err = missing.Restore()
+	assert.EqualError(t, err, "snapshot has no state for processor offers")
+}
+
+type failingProcessor struct{}
+
+func (failingProcessor) SnapshotState(w io.Writer) error {
+	return errors.New("boom")
+}
+
+func (failingProcessor) RestoreState(r io.Reader) error {
+	return errors.New("boom")
+}
+
+func TestStateCheckpointerKeepsPreviousCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	stats := &StatsChangeProcessor{}
+	checkpointer := NewStateCheckpointer(path)
+	require.NoError(t, checkpointer.Register("stats", stats))
+	require.NoError(t, checkpointer.Checkpoint(10))
+
+	require.NoError(t, checkpointer.Register("failing", failingProcessor{}))
+	assert.EqualError(t, checkpointer.Checkpoint(11), "could not snapshot the state of processor failing: boom")
+
+	restorer := NewStateCheckpointer(path)
+	require.NoError(t, restorer.Register("stats", &StatsChangeProcessor{}))
+	ledger, ok, err := restorer.Restore()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(10), ledger)
+
+	var buf bytes.Buffer
+	require.NoError(t, restorer.WriteSnapshot(&buf, 12))
+	ledger, err = restorer.ReadSnapshot(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(12), ledger)
+}
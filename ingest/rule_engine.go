@@ -0,0 +1,198 @@
+package ingest
+
+import (
+	"context"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ChangePredicate returns true if a change matches a rule of a RuleEngine.
+type ChangePredicate func(change Change) bool
+
+// Rule is a named predicate of a RuleEngine.
+type Rule struct {
+	Name  string
+	Match ChangePredicate
+}
+
+// RuleMatch is a change matching a rule, with the context it was found in.
+type RuleMatch struct {
+	Rule   string
+	Change Change
+	// Transaction is the transaction which made the change, nil if the change
+	// was given to ProcessChange.
+	Transaction *LedgerTransaction
+	// OperationIndex is the index of the operation of Transaction which made
+	// the change.
+	OperationIndex uint32
+}
+
+// RuleHandler is called by a RuleEngine with every change matching one of
+// its rules, e.g. to send an alert. An error stops the processing.
+type RuleHandler func(ctx context.Context, match RuleMatch) error
+
+// RuleEngine calls a handler with the changes matching its rules, e.g. large
+// transfers or authorization revocations, to build alerting systems without
+// writing processors. It implements ChangeProcessor, to be fed the changes of
+// a ChangeReader, and LedgerTransactionProcessor, to be fed the transactions
+// of a LedgerTransactionReader with their operations as context, and can
+// be chained before another processor with Middleware.
+//
+// A change matching several rules is given to the handler once per rule, in
+// the order the rules were added. RuleEngine is not safe for concurrent use
+// while rules are added.
+type RuleEngine struct {
+	rules   []Rule
+	handler RuleHandler
+}
+
+// NewRuleEngine returns a RuleEngine calling handler with the changes
+// matching rules.
+func NewRuleEngine(handler RuleHandler, rules ...Rule) *RuleEngine {
+	return &RuleEngine{rules: rules, handler: handler}
+}
+
+// AddRule adds a rule to the engine.
+func (e *RuleEngine) AddRule(name string, match ChangePredicate) {
+	e.rules = append(e.rules, Rule{Name: name, Match: match})
+}
+
+// ProcessChange calls the handler with change for every rule it matches.
+func (e *RuleEngine) ProcessChange(ctx context.Context, change Change) error {
+	return e.match(ctx, RuleMatch{Change: change})
+}
+
+// ProcessTransaction calls the handler with the changes of the operations of
+// the transaction matching the rules. The fee changes and the changes made
+// by the transaction itself (sequence number bumps, removals of pre-authorized
+// transaction signers) are not matched.
+func (e *RuleEngine) ProcessTransaction(ctx context.Context, transaction LedgerTransaction) error {
+	for i := range transaction.Envelope.Operations() {
+		changes, err := transaction.GetOperationChanges(uint32(i))
+		if err != nil {
+			return errors.Wrapf(err, "could not read changes of operation %d", i)
+		}
+		for _, change := range changes {
+			match := RuleMatch{Change: change, Transaction: &transaction, OperationIndex: uint32(i)}
+			if err := e.match(ctx, match); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *RuleEngine) match(ctx context.Context, match RuleMatch) error {
+	for _, rule := range e.rules {
+		if !rule.Match(match.Change) {
+			continue
+		}
+		match.Rule = rule.Name
+		if err := e.handler(ctx, match); err != nil {
+			return errors.Wrapf(err, "error handling match of rule %s", rule.Name)
+		}
+	}
+	return nil
+}
+
+// Middleware returns a middleware matching every change against the rules
+// of the engine before passing it to the next processor.
+func (e *RuleEngine) Middleware() ChangeMiddleware {
+	return func(next ChangeProcessor) ChangeProcessor {
+		return ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			if err := e.ProcessChange(ctx, change); err != nil {
+				return err
+			}
+			return next.ProcessChange(ctx, change)
+		})
+	}
+}
+
+// balanceOf returns the balance of asset held by the account or trustline
+// entry, and false if the entry does not hold the asset.
+func balanceOf(entry *xdr.LedgerEntry, asset xdr.Asset) (xdr.Int64, bool) {
+	if entry == nil {
+		return 0, false
+	}
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		if asset.Type == xdr.AssetTypeAssetTypeNative {
+			return entry.Data.MustAccount().Balance, true
+		}
+	case xdr.LedgerEntryTypeTrustline:
+		trustLine := entry.Data.MustTrustLine()
+		if asset.Type != xdr.AssetTypeAssetTypeNative && trustLine.Asset.Equals(asset.ToTrustLineAsset()) {
+			return trustLine.Balance, true
+		}
+	}
+	return 0, false
+}
+
+// LargeTransfer returns a predicate matching the changes of accounts (for
+// the native asset) or trustlines whose balance of asset increases or
+// decreases by at least threshold stroops. The balance of a created or
+// removed entry changes from or to zero.
+func LargeTransfer(asset xdr.Asset, threshold xdr.Int64) ChangePredicate {
+	return func(change Change) bool {
+		pre, preOK := balanceOf(change.Pre, asset)
+		post, postOK := balanceOf(change.Post, asset)
+		if !preOK && !postOK {
+			return false
+		}
+		delta := post - pre
+		if delta < 0 {
+			delta = -delta
+		}
+		return delta >= threshold
+	}
+}
+
+// TrustLineFlagsChanged returns a predicate matching the updates of
+// trustlines setting or clearing any of flags, e.g.
+// xdr.TrustLineFlagsAuthorizedFlag to detect authorization revocations.
+func TrustLineFlagsChanged(flags xdr.TrustLineFlags) ChangePredicate {
+	return func(change Change) bool {
+		if change.Type != xdr.LedgerEntryTypeTrustline || change.Pre == nil || change.Post == nil {
+			return false
+		}
+		pre := change.Pre.Data.MustTrustLine().Flags
+		post := change.Post.Data.MustTrustLine().Flags
+		return (pre^post)&xdr.Uint32(flags) != 0
+	}
+}
+
+// AccountFlagsChanged returns a predicate matching the updates of accounts
+// setting or clearing any of flags.
+func AccountFlagsChanged(flags xdr.AccountFlags) ChangePredicate {
+	return func(change Change) bool {
+		if change.Type != xdr.LedgerEntryTypeAccount || change.Pre == nil || change.Post == nil {
+			return false
+		}
+		pre := change.Pre.Data.MustAccount().Flags
+		post := change.Post.Data.MustAccount().Flags
+		return (pre^post)&xdr.Uint32(flags) != 0
+	}
+}
+
+// SignerAdded returns a predicate matching the changes of accounts adding a
+// signer, including the creation of accounts with signers.
+func SignerAdded() ChangePredicate {
+	return func(change Change) bool {
+		if change.Type != xdr.LedgerEntryTypeAccount || change.Post == nil {
+			return false
+		}
+		previous := map[string]bool{}
+		if change.Pre != nil {
+			for _, signer := range change.Pre.Data.MustAccount().Signers {
+				previous[signer.Key.Address()] = true
+			}
+		}
+		for _, signer := range change.Post.Data.MustAccount().Signers {
+			if !previous[signer.Key.Address()] {
+				return true
+			}
+		}
+		return false
+	}
+}
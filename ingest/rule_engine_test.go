@@ -0,0 +1,179 @@
+package ingest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ruleAccount = "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	ruleIssuer  = "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"
+	ruleSigner  = "GCO26ZSBD63TKYX45H2C7D2WOFWOUSG5BMTNC3BG4QMXM3PAYI6WHKVZ"
+)
+
+func ruleAccountEntry(balance xdr.Int64, flags xdr.AccountFlags, signers ...string) *xdr.LedgerEntry {
+	account := xdr.AccountEntry{
+		AccountId: xdr.MustAddress(ruleAccount),
+		Balance:   balance,
+		Flags:     xdr.Uint32(flags),
+	}
+	for _, signer := range signers {
+		account.Signers = append(account.Signers, xdr.Signer{Key: xdr.MustSigner(signer), Weight: 1})
+	}
+	return &xdr.LedgerEntry{Data: xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeAccount, Account: &account}}
+}
+
+func ruleTrustLineEntry(asset xdr.Asset, balance xdr.Int64, flags xdr.TrustLineFlags) *xdr.LedgerEntry {
+	return &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeTrustline,
+		TrustLine: &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress(ruleAccount),
+			Asset:     asset.ToTrustLineAsset(),
+			Balance:   balance,
+			Flags:     xdr.Uint32(flags),
+		},
+	}}
+}
+
+func TestRulePredicates(t *testing.T) {
+	usd := xdr.MustNewCreditAsset("USD", ruleIssuer)
+	eur := xdr.MustNewCreditAsset("EUR", ruleIssuer)
+	native := xdr.MustNewNativeAsset()
+	account := func(pre, post *xdr.LedgerEntry) Change {
+		return Change{Type: xdr.LedgerEntryTypeAccount, Pre: pre, Post: post}
+	}
+	trustLine := func(pre, post *xdr.LedgerEntry) Change {
+		return Change{Type: xdr.LedgerEntryTypeTrustline, Pre: pre, Post: post}
+	}
+
+	largeNative := LargeTransfer(native, 1000)
+	assert.True(t, largeNative(account(ruleAccountEntry(5000, 0), ruleAccountEntry(4000, 0))))
+	assert.False(t, largeNative(account(ruleAccountEntry(5000, 0), ruleAccountEntry(4001, 0))))
+	assert.True(t, largeNative(account(nil, ruleAccountEntry(1000, 0))))
+	assert.False(t, largeNative(trustLine(nil, ruleTrustLineEntry(usd, 5000, 0))))
+	largeUSD := LargeTransfer(usd, 1000)
+	assert.True(t, largeUSD(trustLine(ruleTrustLineEntry(usd, 0, 0), ruleTrustLineEntry(usd, 2000, 0))))
+	assert.True(t, largeUSD(trustLine(ruleTrustLineEntry(usd, 2000, 0), nil)))
+	assert.False(t, largeUSD(trustLine(ruleTrustLineEntry(eur, 0, 0), ruleTrustLineEntry(eur, 2000, 0))))
+	assert.False(t, largeUSD(account(ruleAccountEntry(5000, 0), ruleAccountEntry(0, 0))))
+
+	revoked := TrustLineFlagsChanged(xdr.TrustLineFlagsAuthorizedFlag)
+	assert.True(t, revoked(trustLine(
+		ruleTrustLineEntry(usd, 0, xdr.TrustLineFlagsAuthorizedFlag),
+		ruleTrustLineEntry(usd, 0, xdr.TrustLineFlagsAuthorizedToMaintainLiabilitiesFlag),
+	)))
+	assert.False(t, revoked(trustLine(
+		ruleTrustLineEntry(usd, 0, xdr.TrustLineFlagsAuthorizedFlag),
+		ruleTrustLineEntry(usd, 10, xdr.TrustLineFlagsAuthorizedFlag|xdr.TrustLineFlagsTrustlineClawbackEnabledFlag),
+	)))
+	assert.False(t, revoked(trustLine(nil, ruleTrustLineEntry(usd, 0, xdr.TrustLineFlagsAuthorizedFlag))))
+
+	clawback := AccountFlagsChanged(xdr.AccountFlagsAuthClawbackEnabledFlag)
+	assert.True(t, clawback(account(ruleAccountEntry(0, 0), ruleAccountEntry(0, xdr.AccountFlagsAuthClawbackEnabledFlag))))
+	assert.False(t, clawback(account(ruleAccountEntry(0, 0), ruleAccountEntry(0, xdr.AccountFlagsAuthRequiredFlag))))
+
+	signerAdded := SignerAdded()
+	assert.True(t, signerAdded(account(ruleAccountEntry(0, 0), ruleAccountEntry(0, 0, ruleSigner))))
+	assert.True(t, signerAdded(account(nil, ruleAccountEntry(0, 0, ruleSigner))))
+	assert.False(t, signerAdded(account(ruleAccountEntry(0, 0, ruleSigner), ruleAccountEntry(10, 0, ruleSigner))))
+	assert.False(t, signerAdded(account(ruleAccountEntry(0, 0, ruleSigner), nil)))
+}
+
+func TestRuleEngine(t *testing.T) {
+	ctx := context.Background()
+	var matches []RuleMatch
+	engine := NewRuleEngine(
+		func(ctx context.Context, match RuleMatch) error {
+			matches = append(matches, match)
+			return nil
+		},
+		Rule{Name: "large", Match: LargeTransfer(xdr.MustNewNativeAsset(), 1000)},
+	)
+	engine.AddRule("signer", SignerAdded())
+
+	payment := Change{Type: xdr.LedgerEntryTypeAccount, Pre: ruleAccountEntry(5000, 0), Post: ruleAccountEntry(0, 0, ruleSigner)}
+	ignored := Change{Type: xdr.LedgerEntryTypeAccount, Pre: ruleAccountEntry(0, 0), Post: ruleAccountEntry(1, 0)}
+
+	require.NoError(t, engine.ProcessChange(ctx, payment))
+	assert.Equal(t, []RuleMatch{{Rule: "large", Change: payment}, {Rule: "signer", Change: payment}}, matches)
+
+	matches = nil
+	var processed []Change
+	processor := ChainChangeMiddleware(
+		ChangeProcessorFunc(func(ctx context.Context, change Change) error {
+			processed = append(processed, change)
+			return nil
+		}),
+		engine.Middleware(),
+	)
+	require.NoError(t, processor.ProcessChange(ctx, ignored))
+	assert.Empty(t, matches)
+	assert.Equal(t, []Change{ignored}, processed)
+
+	engine.AddRule("failing", func(change Change) bool { return true })
+	engine.handler = func(ctx context.Context, match RuleMatch) error {
+		if match.Rule == "failing" {
+			return errors.New("alert failed")
+		}
+		return nil
+	}
+	assert.EqualError(t, processor.ProcessChange(ctx, ignored), "error handling match of rule failing: alert failed")
+	assert.Len(t, processed, 1)
+}
+
+func TestRuleEngineProcessTransaction(t *testing.T) {
+	ctx := context.Background()
+	var matches []RuleMatch
+	engine := NewRuleEngine(func(ctx context.Context, match RuleMatch) error {
+		matches = append(matches, match)
+		return nil
+	})
+	engine.AddRule("signer", SignerAdded())
+
+	updated := func(pre, post *xdr.LedgerEntry) xdr.LedgerEntryChanges {
+		return xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: pre},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: post},
+		}
+	}
+	transaction := LedgerTransaction{
+		Index: 1,
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					Operations: []xdr.Operation{
+						{Body: xdr.OperationBody{Type: xdr.OperationTypeBumpSequence, BumpSequenceOp: &xdr.BumpSequenceOp{}}},
+						{Body: xdr.OperationBody{Type: xdr.OperationTypeSetOptions, SetOptionsOp: &xdr.SetOptionsOp{}}},
+					},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				// the signer added by the transaction itself is not matched
+				TxChangesBefore: updated(ruleAccountEntry(0, 0), ruleAccountEntry(0, 0, ruleIssuer)),
+				Operations: []xdr.OperationMeta{
+					{Changes: updated(ruleAccountEntry(0, 0), ruleAccountEntry(1, 0))},
+					{Changes: updated(ruleAccountEntry(1, 0), ruleAccountEntry(1, 0, ruleSigner))},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, engine.ProcessTransaction(ctx, transaction))
+	require.Len(t, matches, 1)
+	assert.Equal(t, "signer", matches[0].Rule)
+	assert.Equal(t, uint32(1), matches[0].OperationIndex)
+	assert.Equal(t, &transaction, matches[0].Transaction)
+	assert.Equal(t, ruleAccountEntry(1, 0, ruleSigner), matches[0].Change.Post)
+
+	transaction.UnsafeMeta = xdr.TransactionMeta{V: 0}
+	assert.EqualError(t, engine.ProcessTransaction(ctx, transaction), "could not read changes of operation 0: TransactionMeta.V=0 not supported")
+}
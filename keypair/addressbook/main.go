@@ -0,0 +1,263 @@
+// Package addressbook maps human labels to Stellar accounts, on one or more
+// networks, in a JSON format shared by the command line tools built on the
+// SDK:
+//
+//	{
+//	  "version": 1,
+//	  "contacts": [
+//	    {"label": "alice", "network": "testnet", "address": "GA..."},
+//	    {"label": "exchange", "network": "pubnet", "address": "MA..."}
+//	  ]
+//	}
+//
+// The network of a contact is "pubnet", "testnet" or the passphrase of any
+// other network, and its address is a G (account) or an M (muxed account)
+// address. A label is unique on its network only, so that the same label can
+// refer to the testnet and the pubnet accounts of a contact.
+package addressbook
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// Pubnet is the network name of the public network.
+	Pubnet = "pubnet"
+	// Testnet is the network name of the test network.
+	Testnet = "testnet"
+
+	// Version is the version of the serialization format.
+	Version = 1
+)
+
+// Contact is an account of an address book.
+type Contact struct {
+	Label   string `json:"label"`
+	Network string `json:"network"`
+	Address string `json:"address"`
+	// Note is a free form description of the contact.
+	Note string `json:"note,omitempty"`
+}
+
+// Passphrase returns the network passphrase of the contact.
+func (c Contact) Passphrase() string {
+	return Passphrase(c.Network)
+}
+
+// Validate returns an error if the label, the network or the address of the
+// contact is invalid.
+func (c Contact) Validate() error {
+	if c.Label == "" {
+		return errors.New("label is empty")
+	}
+	if strings.TrimSpace(c.Label) != c.Label {
+		return errors.Errorf("label %q has leading or trailing spaces", c.Label)
+	}
+	for _, r := range c.Label {
+		if !unicode.IsPrint(r) {
+			return errors.Errorf("label %q has non printable characters", c.Label)
+		}
+	}
+	if c.Network == "" {
+		return errors.Errorf("network of %s is empty", c.Label)
+	}
+	if err := validateAddress(c.Address); err != nil {
+		return errors.Wrapf(err, "invalid address of %s", c.Label)
+	}
+	return nil
+}
+
+func validateAddress(address string) error {
+	if strings.HasPrefix(address, "M") {
+		_, err := strkey.DecodeMuxedAccount(address)
+		return err
+	}
+	_, err := keypair.ParseAddress(address)
+	return err
+}
+
+// NetworkName returns the name of the network of the passphrase, Pubnet or
+// Testnet for the well-known networks and the passphrase itself otherwise.
+func NetworkName(passphrase string) string {
+	switch passphrase {
+	case network.PublicNetworkPassphrase:
+		return Pubnet
+	case network.TestNetworkPassphrase:
+		return Testnet
+	default:
+		return passphrase
+	}
+}
+
+// Passphrase returns the passphrase of the named network, the reverse of
+// NetworkName.
+func Passphrase(networkName string) string {
+	switch networkName {
+	case Pubnet:
+		return network.PublicNetworkPassphrase
+	case Testnet:
+		return network.TestNetworkPassphrase
+	default:
+		return networkName
+	}
+}
+
+type contactKey struct {
+	network string
+	label   string
+}
+
+// Book is an address book. The zero value is an empty address book ready to
+// use. It is not safe for concurrent use.
+type Book struct {
+	contacts map[contactKey]Contact
+}
+
+// Add adds the contact to the book. The network can be given as a name or as
+// a passphrase, it is stored as a name. An error is returned if the contact
+// is invalid or if its label is already used on its network.
+func (b *Book) Add(contact Contact) error {
+	contact.Network = NetworkName(contact.Network)
+	if err := contact.Validate(); err != nil {
+		return err
+	}
+	key := contactKey{network: contact.Network, label: contact.Label}
+	if _, ok := b.contacts[key]; ok {
+		return errors.Errorf("label %s is already used on %s", contact.Label, contact.Network)
+	}
+	if b.contacts == nil {
+		b.contacts = map[contactKey]Contact{}
+	}
+	b.contacts[key] = contact
+	return nil
+}
+
+// Remove removes the contact with the label on the network, returning false
+// if there is none.
+func (b *Book) Remove(networkName, label string) bool {
+	key := contactKey{network: NetworkName(networkName), label: label}
+	if _, ok := b.contacts[key]; !ok {
+		return false
+	}
+	delete(b.contacts, key)
+	return true
+}
+
+// Lookup returns the contact with the label on the network.
+func (b *Book) Lookup(networkName, label string) (Contact, bool) {
+	contact, ok := b.contacts[contactKey{network: NetworkName(networkName), label: label}]
+	return contact, ok
+}
+
+// Address returns the address of the contact with the label on the network,
+// or an error if there is none.
+func (b *Book) Address(networkName, label string) (string, error) {
+	contact, ok := b.Lookup(networkName, label)
+	if !ok {
+		return "", errors.Errorf("no contact %s on %s", label, NetworkName(networkName))
+	}
+	return contact.Address, nil
+}
+
+// Labels returns the sorted labels of the address on the network, e.g. to
+// display the name of the destination of a payment.
+func (b *Book) Labels(networkName, address string) []string {
+	networkName = NetworkName(networkName)
+	var labels []string
+	for key, contact := range b.contacts {
+		if key.network == networkName && contact.Address == address {
+			labels = append(labels, contact.Label)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// Contacts returns the contacts of the network, sorted by label, or the
+// contacts of all the networks, sorted by network then label, if networkName
+// is empty.
+func (b *Book) Contacts(networkName string) []Contact {
+	networkName = NetworkName(networkName)
+	contacts := []Contact{}
+	for key, contact := range b.contacts {
+		if networkName == "" || key.network == networkName {
+			contacts = append(contacts, contact)
+		}
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].Network != contacts[j].Network {
+			return contacts[i].Network < contacts[j].Network
+		}
+		return contacts[i].Label < contacts[j].Label
+	})
+	return contacts
+}
+
+// Networks returns the sorted names of the networks of the contacts.
+func (b *Book) Networks() []string {
+	set := map[string]bool{}
+	for key := range b.contacts {
+		set[key.network] = true
+	}
+	networks := make([]string, 0, len(set))
+	for networkName := range set {
+		networks = append(networks, networkName)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+type bookJSON struct {
+	Version  int       `json:"version"`
+	Contacts []Contact `json:"contacts"`
+}
+
+// MarshalJSON implements json.Marshaler, with the contacts sorted by network
+// then label so that the encoding of a book is stable.
+func (b *Book) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bookJSON{Version: Version, Contacts: b.Contacts("")})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, validating every contact.
+func (b *Book) UnmarshalJSON(data []byte) error {
+	var decoded bookJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version != Version {
+		return errors.Errorf("unsupported address book version %d", decoded.Version)
+	}
+	book := Book{}
+	for i, contact := range decoded.Contacts {
+		if err := book.Add(contact); err != nil {
+			return errors.Wrapf(err, "invalid contact %d", i)
+		}
+	}
+	*b = book
+	return nil
+}
+
+// Load reads an address book from r.
+func Load(r io.Reader) (*Book, error) {
+	book := &Book{}
+	if err := json.NewDecoder(r).Decode(book); err != nil {
+		return nil, errors.Wrap(err, "could not read address book")
+	}
+	return book, nil
+}
+
+// Save writes the address book to w, indented to be edited by hand.
+func (b *Book) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errors.Wrap(encoder.Encode(b), "could not write address book")
+}
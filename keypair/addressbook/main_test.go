@@ -0,0 +1,107 @@
+package addressbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	alice    = "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	bob      = "GBZXN7PIRZGNMHGA7MUUUF4GWPY5AYPV6LY4UV2GL6VJGIQRXFDNMADI"
+	exchange = "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ"
+)
+
+func TestBook(t *testing.T) {
+	var book Book
+	require.NoError(t, book.Add(Contact{Label: "alice", Network: Testnet, Address: alice}))
+	require.NoError(t, book.Add(Contact{Label: "alice", Network: network.PublicNetworkPassphrase, Address: bob}))
+	require.NoError(t, book.Add(Contact{Label: "exchange", Network: Pubnet, Address: exchange, Note: "deposits"}))
+	require.NoError(t, book.Add(Contact{Label: "bob", Network: Pubnet, Address: bob}))
+	require.NoError(t, book.Add(Contact{Label: "local", Network: "Standalone Network ; February 2017", Address: alice}))
+
+	contact, ok := book.Lookup(network.TestNetworkPassphrase, "alice")
+	require.True(t, ok)
+	assert.Equal(t, Contact{Label: "alice", Network: Testnet, Address: alice}, contact)
+	assert.Equal(t, network.TestNetworkPassphrase, contact.Passphrase())
+
+	address, err := book.Address(Pubnet, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, bob, address)
+	_, err = book.Address(Testnet, "bob")
+	assert.EqualError(t, err, "no contact bob on testnet")
+
+	assert.Equal(t, []string{"alice", "bob"}, book.Labels(Pubnet, bob))
+	assert.Empty(t, book.Labels(Testnet, bob))
+	assert.Equal(t, []string{"Standalone Network ; February 2017", Pubnet, Testnet}, book.Networks())
+	assert.Equal(t, []Contact{
+		{Label: "alice", Network: Pubnet, Address: bob},
+		{Label: "bob", Network: Pubnet, Address: bob},
+		{Label: "exchange", Network: Pubnet, Address: exchange, Note: "deposits"},
+	}, book.Contacts(Pubnet))
+	assert.Len(t, book.Contacts(""), 5)
+
+	assert.True(t, book.Remove(Pubnet, "bob"))
+	assert.False(t, book.Remove(Pubnet, "bob"))
+	assert.Equal(t, []string{"alice"}, book.Labels(Pubnet, bob))
+}
+
+func TestBookValidation(t *testing.T) {
+	var book Book
+	require.NoError(t, book.Add(Contact{Label: "alice", Network: Testnet, Address: alice}))
+
+	for _, testCase := range []struct {
+		contact Contact
+		err     string
+	}{
+		{Contact{Label: "alice", Network: Testnet, Address: bob}, "label alice is already used on testnet"},
+		{Contact{Network: Testnet, Address: bob}, "label is empty"},
+		{Contact{Label: " bob", Network: Testnet, Address: bob}, `label " bob" has leading or trailing spaces`},
+		{Contact{Label: "bob\x00", Network: Testnet, Address: bob}, `label "bob\x00" has non printable characters`},
+		{Contact{Label: "bob", Address: bob}, "network of bob is empty"},
+		{Contact{Label: "bob", Network: Testnet, Address: "GBOB"}, "invalid address of bob: strkey is 4 bytes long; minimum valid length is 5"},
+		{Contact{Label: "bob", Network: Testnet, Address: "MBOB"}, "invalid address of bob: invalid muxed account"},
+	} {
+		assert.EqualError(t, book.Add(testCase.contact), testCase.err)
+	}
+	assert.Len(t, book.Contacts(""), 1)
+}
+
+func TestBookSerialization(t *testing.T) {
+	var book Book
+	require.NoError(t, book.Add(Contact{Label: "exchange", Network: Pubnet, Address: exchange, Note: "deposits"}))
+	require.NoError(t, book.Add(Contact{Label: "alice", Network: Testnet, Address: alice}))
+	require.NoError(t, book.Add(Contact{Label: "alice", Network: Pubnet, Address: bob}))
+
+	var buffer bytes.Buffer
+	require.NoError(t, book.Save(&buffer))
+	assert.JSONEq(t, `{
+  "version": 1,
+  "contacts": [
+    {"label": "alice", "network": "pubnet", "address": "`+bob+`"},
+    {"label": "exchange", "network": "pubnet", "address": "`+exchange+`", "note": "deposits"},
+    {"label": "alice", "network": "testnet", "address": "`+alice+`"}
+  ]
+}`, buffer.String())
+
+	loaded, err := Load(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, &book, loaded)
+
+	_, err = Load(bytes.NewBufferString(`{"version": 2, "contacts": []}`))
+	assert.EqualError(t, err, "could not read address book: unsupported address book version 2")
+	_, err = Load(bytes.NewBufferString(`{"version": 1, "contacts": [
+		{"label": "alice", "network": "testnet", "address": "` + alice + `"},
+		{"label": "alice", "network": "testnet", "address": "` + bob + `"}
+	]}`))
+	assert.EqualError(t, err, "could not read address book: invalid contact 1: label alice is already used on testnet")
+
+	var empty Book
+	data, err := json.Marshal(&empty)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 1, "contacts": []}`, string(data))
+}
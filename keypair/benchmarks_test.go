@@ -157,3 +157,20 @@ func BenchmarkFull_Sign(b *testing.B) {
 		_, _ = sk.Sign(input[:])
 	}
 }
+
+func BenchmarkFull_SignParallel(b *testing.B) {
+	// The same keypair signs from all the goroutines.
+	sk := keypair.MustRandom()
+
+	// Random input for creating a valid signature.
+	input := [32]byte{}
+	_, err := rand.Read(input[:])
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = sk.Sign(input[:])
+		}
+	})
+}
@@ -1,8 +1,9 @@
 package keypair
 
 import (
-	"bytes"
+	"context"
 	"crypto/ed25519"
+	"crypto/subtle"
 	"encoding/base64"
 
 	"github.com/stellar/go/strkey"
@@ -11,50 +12,37 @@ import (
 
 type Full struct {
 	address    string
-	seed       string
 	publicKey  ed25519.PublicKey
 	privateKey ed25519.PrivateKey
+	signHook   SignHook
+	signGuard  *SignGuard
+	usages     KeyUsage
 }
 
 func newFull(seed string) (*Full, error) {
-	rawSeed, err := strkey.Decode(strkey.VersionByteSeed, seed)
-	if err != nil {
-		return nil, err
-	}
-	reader := bytes.NewReader(rawSeed)
-	pub, priv, err := ed25519.GenerateKey(reader)
-	if err != nil {
-		return nil, err
-	}
-	address, err := strkey.Encode(strkey.VersionByteAccountID, pub)
+	rawSeed := []byte(seed)
+	defer zero(rawSeed)
+	return newFullFromBytes(rawSeed)
+}
+
+func newFullFromBytes(seed []byte) (*Full, error) {
+	rawSeed, err := strkey.DecodeSeed(seed)
+	defer zero(rawSeed[:])
 	if err != nil {
 		return nil, err
 	}
-	return &Full{
-		address:    address,
-		seed:       seed,
-		publicKey:  pub,
-		privateKey: priv,
-	}, nil
+	return newFullFromRawSeed(rawSeed)
 }
 
 func newFullFromRawSeed(rawSeed [32]byte) (*Full, error) {
-	seed, err := strkey.Encode(strkey.VersionByteSeed, rawSeed[:])
-	if err != nil {
-		return nil, err
-	}
-	reader := bytes.NewReader(rawSeed[:])
-	pub, priv, err := ed25519.GenerateKey(reader)
-	if err != nil {
-		return nil, err
-	}
+	priv := ed25519.NewKeyFromSeed(rawSeed[:])
+	pub := priv.Public().(ed25519.PublicKey)
 	address, err := strkey.Encode(strkey.VersionByteAccountID, pub)
 	if err != nil {
 		return nil, err
 	}
 	return &Full{
 		address:    address,
-		seed:       seed,
 		publicKey:  pub,
 		privateKey: priv,
 	}, nil
@@ -75,8 +63,22 @@ func (kp *Full) Hint() (r [4]byte) {
 	return
 }
 
+// Seed returns the strkey encoded seed ('S...') of this keypair. Every call
+// allocates a new string which cannot be wiped, prefer SeedBytes when the
+// seed does not need to be a string.
 func (kp *Full) Seed() string {
-	return kp.seed
+	seed := kp.SeedBytes()
+	defer zero(seed)
+	return string(seed)
+}
+
+// SeedBytes returns the strkey encoded seed ('S...') of this keypair. The
+// caller owns the returned slice and may wipe it once done with it.
+func (kp *Full) SeedBytes() []byte {
+	var rawSeed [32]byte
+	defer zero(rawSeed[:])
+	copy(rawSeed[:], kp.privateKey.Seed())
+	return strkey.EncodeSeed(rawSeed)
 }
 
 func (kp *Full) Verify(input []byte, sig []byte) error {
@@ -90,7 +92,26 @@ func (kp *Full) Verify(input []byte, sig []byte) error {
 }
 
 func (kp *Full) Sign(input []byte) ([]byte, error) {
-	return ed25519.Sign(kp.privateKey, input), nil
+	return kp.SignWithContext(context.Background(), input)
+}
+
+// SignWithContext is like Sign but passes ctx to the sign hooks (see
+// SignHook), e.g. to identify the request on whose behalf the input is signed,
+// and to the sign guard (see WithSignGuard and WithSignValue). The usage of
+// the signature declared by ctx, see WithKeyUsage, must be allowed by the
+// keypair.
+func (kp *Full) SignWithContext(ctx context.Context, input []byte) ([]byte, error) {
+	if err := kp.checkUsage(signUsage(ctx)); err != nil {
+		return nil, err
+	}
+	if kp.signGuard != nil {
+		if err := kp.signGuard.allow(ctx, kp.address); err != nil {
+			return nil, err
+		}
+	}
+	sig := ed25519.Sign(kp.privateKey, input)
+	kp.reportSign(ctx, input, sig)
+	return sig, nil
 }
 
 // SignBase64 signs the input data and returns a base64 encoded string, the
@@ -104,7 +125,13 @@ func (kp *Full) SignBase64(input []byte) (string, error) {
 }
 
 func (kp *Full) SignDecorated(input []byte) (xdr.DecoratedSignature, error) {
-	sig, err := kp.Sign(input)
+	return kp.SignDecoratedWithContext(context.Background(), input)
+}
+
+// SignDecoratedWithContext is like SignDecorated but passes ctx to the sign
+// hooks and guard, see SignWithContext.
+func (kp *Full) SignDecoratedWithContext(ctx context.Context, input []byte) (xdr.DecoratedSignature, error) {
+	sig, err := kp.SignWithContext(ctx, input)
 	if err != nil {
 		return xdr.DecoratedSignature{}, err
 	}
@@ -122,5 +149,12 @@ func (kp *Full) Equal(f *Full) bool {
 	if kp == nil || f == nil {
 		return false
 	}
-	return kp.seed == f.seed
+	return subtle.ConstantTimeCompare(kp.privateKey, f.privateKey) == 1
+}
+
+// zero wipes secret material from memory once it is no longer needed.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
 }
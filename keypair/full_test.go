@@ -39,7 +39,7 @@ func TestFull_Equal(t *testing.T) {
 	assert.True(t, kp1.Equal(kp1))
 
 	// A non-nil Full is equal to another Full containing the same address.
-	assert.True(t, kp1.Equal(MustParseFull(kp1.seed)))
+	assert.True(t, kp1.Equal(MustParseFull(kp1.Seed())))
 
 	// A non-nil Full is not equal a non-nil Full of different value.
 	assert.False(t, kp1.Equal(kp2))
@@ -107,3 +107,19 @@ var _ = Describe("keypair.Full", func() {
 	})
 
 })
+
+func TestParseFullBytes(t *testing.T) {
+	seed := []byte("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	kp, err := ParseFullBytes(seed)
+	assert.NoError(t, err)
+	assert.True(t, kp.Equal(MustParseFull(string(seed))))
+	assert.Equal(t, seed, kp.SeedBytes())
+	assert.Equal(t, string(seed), kp.Seed())
+	// the input is left untouched
+	assert.Equal(t, "SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP", string(seed))
+
+	_, err = ParseFullBytes([]byte("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	assert.Error(t, err)
+	_, err = ParseFullBytes([]byte("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUQ"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package keypair
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// KeyUsage is a set of purposes a keypair is used for. A keypair restricted
+// to some usages (see Full.WithUsages) refuses to be used for the others,
+// e.g. a keypair designated for SEP-10 authentication refuses to sign
+// transactions, so that a key leaking from one part of a system cannot be
+// used by another.
+type KeyUsage uint8
+
+const (
+	// UsageSignTransactions is the signature of transactions.
+	UsageSignTransactions KeyUsage = 1 << iota
+	// UsageAuth is the signature of authentication challenges, e.g. SEP-10
+	// challenge transactions.
+	UsageAuth
+	// UsageSignMessages is the signature of any other data. It is the usage
+	// of the signatures made without a usage set with WithKeyUsage.
+	UsageSignMessages
+	// UsageEncryption is the derivation of shared secrets, see
+	// Full.SharedSecret, Full.Seal and Full.Open.
+	UsageEncryption
+
+	allUsages = UsageSignTransactions | UsageAuth | UsageSignMessages | UsageEncryption
+)
+
+var keyUsageNames = []struct {
+	usage KeyUsage
+	name  string
+}{
+	{UsageSignTransactions, "sign_transactions"},
+	{UsageAuth, "auth"},
+	{UsageSignMessages, "sign_messages"},
+	{UsageEncryption, "encryption"},
+}
+
+// String returns the names of the usages, e.g. "auth|encryption".
+func (u KeyUsage) String() string {
+	var names []string
+	for _, usage := range keyUsageNames {
+		if u&usage.usage != 0 {
+			names = append(names, usage.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// KeyUsageError is returned when a keypair is used for a usage it is not
+// allowed.
+type KeyUsageError struct {
+	// Signer is the address of the keypair.
+	Signer string
+	// Usage is the refused usage.
+	Usage KeyUsage
+	// Allowed are the usages of the keypair.
+	Allowed KeyUsage
+}
+
+func (e *KeyUsageError) Error() string {
+	return fmt.Sprintf("keypair %s cannot be used for %s, only for %s", e.Signer, e.Usage, e.Allowed)
+}
+
+type keyUsageContextKey struct{}
+
+// WithKeyUsage returns a copy of ctx declaring the usage of the signature
+// made with it, checked against the usages of the keypair (see
+// Full.WithUsages). It is passed to Full.SignWithContext or
+// Full.SignDecoratedWithContext.
+func WithKeyUsage(ctx context.Context, usage KeyUsage) context.Context {
+	return context.WithValue(ctx, keyUsageContextKey{}, usage)
+}
+
+// KeyUsageFromContext returns the usage declared by WithKeyUsage, if any.
+func KeyUsageFromContext(ctx context.Context) (KeyUsage, bool) {
+	usage, ok := ctx.Value(keyUsageContextKey{}).(KeyUsage)
+	return usage, ok
+}
+
+// WithUsages returns a copy of the keypair which can only be used for
+// usages, returning a *KeyUsageError otherwise. Keypairs are allowed all the
+// usages by default. An error is returned if usages is empty or has unknown
+// usages.
+func (kp *Full) WithUsages(usages KeyUsage) (*Full, error) {
+	if usages == 0 {
+		return nil, fmt.Errorf("keypair %s must be allowed at least one usage", kp.address)
+	}
+	if usages&^allUsages != 0 {
+		return nil, fmt.Errorf("unknown key usages %#x", uint8(usages&^allUsages))
+	}
+	withUsages := *kp
+	withUsages.usages = usages
+	return &withUsages, nil
+}
+
+// Usages returns the usages the keypair is allowed, all of them if it is not
+// restricted by WithUsages.
+func (kp *Full) Usages() KeyUsage {
+	if kp.usages == 0 {
+		return allUsages
+	}
+	return kp.usages
+}
+
+// checkUsage returns a *KeyUsageError if the keypair is not allowed usage.
+func (kp *Full) checkUsage(usage KeyUsage) error {
+	if kp.usages == 0 || kp.usages&usage == usage {
+		return nil
+	}
+	return &KeyUsageError{Signer: kp.address, Usage: usage, Allowed: kp.usages}
+}
+
+// signUsage returns the usage of the signature made with ctx.
+func signUsage(ctx context.Context) KeyUsage {
+	if usage, ok := KeyUsageFromContext(ctx); ok && usage != 0 {
+		return usage
+	}
+	return UsageSignMessages
+}
@@ -0,0 +1,65 @@
+package keypair
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyUsage(t *testing.T) {
+	kp := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	peer := MustRandom()
+	input := []byte("hello")
+
+	// keypairs are allowed all the usages by default
+	assert.Equal(t, UsageSignTransactions|UsageAuth|UsageSignMessages|UsageEncryption, kp.Usages())
+	_, err := kp.SignWithContext(WithKeyUsage(context.Background(), UsageSignTransactions), input)
+	require.NoError(t, err)
+	_, err = kp.SharedSecret(peer)
+	require.NoError(t, err)
+
+	auth, err := kp.WithUsages(UsageAuth)
+	require.NoError(t, err)
+	assert.Equal(t, UsageAuth, auth.Usages())
+	assert.Equal(t, UsageSignTransactions|UsageAuth|UsageSignMessages|UsageEncryption, kp.Usages())
+
+	sig, err := auth.SignWithContext(WithKeyUsage(context.Background(), UsageAuth), input)
+	require.NoError(t, err)
+	assert.NoError(t, kp.Verify(input, sig))
+
+	_, err = auth.SignDecoratedWithContext(WithKeyUsage(context.Background(), UsageSignTransactions), input)
+	require.IsType(t, &KeyUsageError{}, err)
+	assert.Equal(t, &KeyUsageError{Signer: kp.Address(), Usage: UsageSignTransactions, Allowed: UsageAuth}, err)
+	assert.EqualError(t, err, "keypair "+kp.Address()+" cannot be used for sign_transactions, only for auth")
+
+	// signatures without a declared usage are messages
+	_, err = auth.Sign(input)
+	assert.Equal(t, &KeyUsageError{Signer: kp.Address(), Usage: UsageSignMessages, Allowed: UsageAuth}, err)
+	_, err = auth.SharedSecret(peer)
+	assert.Equal(t, &KeyUsageError{Signer: kp.Address(), Usage: UsageEncryption, Allowed: UsageAuth}, err)
+	_, err = auth.Seal(peer, input, nil)
+	assert.IsType(t, &KeyUsageError{}, err)
+
+	both, err := kp.WithUsages(UsageSignMessages | UsageEncryption)
+	require.NoError(t, err)
+	_, err = both.Sign(input)
+	assert.NoError(t, err)
+	sealed, err := both.Seal(peer, input, nil)
+	require.NoError(t, err)
+	opened, err := peer.Open(kp, sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, input, opened)
+
+	_, err = kp.WithUsages(0)
+	assert.EqualError(t, err, "keypair "+kp.Address()+" must be allowed at least one usage")
+	_, err = kp.WithUsages(UsageAuth | 1<<7)
+	assert.EqualError(t, err, "unknown key usages 0x80")
+}
+
+func TestKeyUsageString(t *testing.T) {
+	assert.Equal(t, "none", KeyUsage(0).String())
+	assert.Equal(t, "auth", UsageAuth.String())
+	assert.Equal(t, "sign_transactions|encryption", (UsageSignTransactions | UsageEncryption).String())
+}
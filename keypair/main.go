@@ -4,9 +4,9 @@ import (
 	"crypto/rand"
 	"errors"
 	"io"
+	"strings"
 
 	"github.com/stellar/go/network"
-	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/xdr"
 )
 
@@ -79,16 +79,12 @@ func Root(networkPassphrase string) *Full {
 // an address, or a seed.  If the provided input is a seed, the resulting KP
 // will have signing capabilities.
 func Parse(addressOrSeed string) (KP, error) {
-	addr, err := ParseAddress(addressOrSeed)
-	if err == nil {
-		return addr, nil
+	// Seeds always start with 'S' and are never decoded as addresses, so that
+	// they only go through the constant-time seed decoder.
+	if strings.HasPrefix(addressOrSeed, "S") {
+		return ParseFull(addressOrSeed)
 	}
-
-	if err != strkey.ErrInvalidVersionByte {
-		return nil, err
-	}
-
-	return ParseFull(addressOrSeed)
+	return ParseAddress(addressOrSeed)
 }
 
 // ParseAddress constructs a new FromAddress keypair from the provided string,
@@ -103,6 +99,14 @@ func ParseFull(seed string) (*Full, error) {
 	return newFull(seed)
 }
 
+// ParseFullBytes is like ParseFull, but takes the seed as a byte slice so
+// that callers can avoid holding it in a string, which cannot be wiped from
+// memory. The seed is decoded in constant time and seed is not modified or
+// retained.
+func ParseFullBytes(seed []byte) (*Full, error) {
+	return newFullFromBytes(seed)
+}
+
 // FromRawSeed creates a new keypair from the provided raw ED25519 seed
 func FromRawSeed(rawSeed [32]byte) (*Full, error) {
 	return newFullFromRawSeed(rawSeed)
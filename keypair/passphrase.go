@@ -0,0 +1,86 @@
+package keypair
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/stellar/go/network"
+)
+
+// ErrPublicNetworkPassphrase is returned by FromPassphrase when it is asked
+// for a keypair of the public network.
+var ErrPublicNetworkPassphrase = errors.New("keys derived from passphrases must not be used on the public network")
+
+const (
+	// MinPassphraseSaltLength is the minimum length of the salt of
+	// FromPassphrase.
+	MinPassphraseSaltLength = 16
+	// MinPassphraseLength is the minimum length of the passphrase of
+	// FromPassphrase.
+	MinPassphraseLength = 12
+)
+
+// PassphraseParams are the Argon2id parameters of FromPassphraseWithParams.
+type PassphraseParams struct {
+	// Time is the number of passes over the memory.
+	Time uint32
+	// Memory is the memory used, in KiB.
+	Memory uint32
+	// Threads is the degree of parallelism.
+	Threads uint8
+}
+
+// DefaultPassphraseParams are the parameters of FromPassphrase, those
+// recommended by RFC 9106 for memory constrained environments: 3 passes over
+// 64 MiB.
+var DefaultPassphraseParams = PassphraseParams{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+// FromPassphrase derives a keypair deterministically from a passphrase and a
+// salt with Argon2id, e.g. to recreate the accounts of a demo or of a test
+// network from memorable passphrases.
+//
+// WARNING: a keypair derived from a passphrase, a "brainwallet", is only as
+// strong as the passphrase, which anyone who learns it, or guesses it
+// offline, can use to take the funds of the account. Do not use it to hold
+// real funds: it refuses the public network, returning
+// ErrPublicNetworkPassphrase. Use Random to create the keys of real accounts.
+//
+// The network passphrase is part of the derivation, so the same passphrase
+// and salt derive different keys on different networks. The salt, e.g. the
+// name of the user or of the demo, must be at least MinPassphraseSaltLength
+// bytes long and the passphrase at least MinPassphraseLength characters.
+func FromPassphrase(networkPassphrase, passphrase string, salt []byte) (*Full, error) {
+	return FromPassphraseWithParams(networkPassphrase, passphrase, salt, DefaultPassphraseParams)
+}
+
+// FromPassphraseWithParams is FromPassphrase with custom Argon2id parameters.
+// The same parameters must be used to derive the same keypair again.
+func FromPassphraseWithParams(networkPassphrase, passphrase string, salt []byte, params PassphraseParams) (*Full, error) {
+	if networkPassphrase == network.PublicNetworkPassphrase {
+		return nil, ErrPublicNetworkPassphrase
+	}
+	if networkPassphrase == "" {
+		return nil, errors.New("network passphrase is empty")
+	}
+	if len([]rune(passphrase)) < MinPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters long", MinPassphraseLength)
+	}
+	if len(salt) < MinPassphraseSaltLength {
+		return nil, fmt.Errorf("salt must be at least %d bytes long", MinPassphraseSaltLength)
+	}
+	if params.Time == 0 || params.Memory == 0 || params.Threads == 0 {
+		return nil, errors.New("argon2id parameters must be positive")
+	}
+
+	networkID := network.ID(networkPassphrase)
+	h := sha256.New()
+	h.Write(networkID[:])
+	h.Write(salt)
+
+	var rawSeed [32]byte
+	copy(rawSeed[:], argon2.IDKey([]byte(passphrase), h.Sum(nil), params.Time, params.Memory, params.Threads, uint32(len(rawSeed))))
+	return FromRawSeed(rawSeed)
+}
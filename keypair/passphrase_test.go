@@ -0,0 +1,65 @@
+package keypair
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/network"
+)
+
+// testPassphraseParams keep the tests fast, they are much weaker than
+// DefaultPassphraseParams.
+var testPassphraseParams = PassphraseParams{Time: 1, Memory: 64, Threads: 1}
+
+func TestFromPassphrase(t *testing.T) {
+	salt := []byte("alice@example.com")
+	kp, err := FromPassphraseWithParams(network.TestNetworkPassphrase, "correct horse battery staple", salt, testPassphraseParams)
+	require.NoError(t, err)
+
+	again, err := FromPassphraseWithParams(network.TestNetworkPassphrase, "correct horse battery staple", salt, testPassphraseParams)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Seed(), again.Seed())
+
+	for _, other := range []struct {
+		network, passphrase string
+		salt                []byte
+		params              PassphraseParams
+	}{
+		{"Standalone Network ; February 2017", "correct horse battery staple", salt, testPassphraseParams},
+		{network.TestNetworkPassphrase, "correct horse battery stapler", salt, testPassphraseParams},
+		{network.TestNetworkPassphrase, "correct horse battery staple", []byte("bob@example.com!!"), testPassphraseParams},
+		{network.TestNetworkPassphrase, "correct horse battery staple", salt, PassphraseParams{Time: 2, Memory: 64, Threads: 1}},
+	} {
+		derived, err := FromPassphraseWithParams(other.network, other.passphrase, other.salt, other.params)
+		require.NoError(t, err)
+		assert.NotEqual(t, kp.Address(), derived.Address())
+	}
+}
+
+func TestFromPassphraseDefaultParams(t *testing.T) {
+	kp, err := FromPassphrase(network.TestNetworkPassphrase, "correct horse battery staple", []byte("alice@example.com"))
+	require.NoError(t, err)
+	withParams, err := FromPassphraseWithParams(network.TestNetworkPassphrase, "correct horse battery staple", []byte("alice@example.com"), DefaultPassphraseParams)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address(), withParams.Address())
+}
+
+func TestFromPassphraseErrors(t *testing.T) {
+	salt := []byte("alice@example.com")
+	_, err := FromPassphrase(network.PublicNetworkPassphrase, "correct horse battery staple", salt)
+	assert.Equal(t, ErrPublicNetworkPassphrase, err)
+
+	_, err = FromPassphraseWithParams("", "correct horse battery staple", salt, testPassphraseParams)
+	assert.EqualError(t, err, "network passphrase is empty")
+
+	_, err = FromPassphraseWithParams(network.TestNetworkPassphrase, "hunter2", salt, testPassphraseParams)
+	assert.EqualError(t, err, "passphrase must be at least 12 characters long")
+
+	_, err = FromPassphraseWithParams(network.TestNetworkPassphrase, "correct horse battery staple", []byte("alice"), testPassphraseParams)
+	assert.EqualError(t, err, "salt must be at least 16 bytes long")
+
+	_, err = FromPassphraseWithParams(network.TestNetworkPassphrase, "correct horse battery staple", salt, PassphraseParams{})
+	assert.EqualError(t, err, "argon2id parameters must be positive")
+}
@@ -0,0 +1,190 @@
+// Package remotesigner defines the RemoteSigner gRPC service, which separates
+// the signing of transactions from the services building and submitting them,
+// and provides Server, a reference implementation signing with keypairs.
+//
+// The service is defined in remotesigner.proto, from which the stubs are
+// generated with go generate.
+package remotesigner
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+// Server is a RemoteSignerServer signing with the keypairs it is created
+// with. The keypairs can be guarded by a keypair.SignGuard, in which case the
+// refused signatures fail with a PermissionDenied status. The value of a
+// transaction passed to the guard, with keypair.WithSignValue, is the sum of
+// the amounts sent by its payment operations (see TransactionValue), the
+// value of the requests being ignored. The keypairs whose guard limits the
+// value of the signatures refuse to sign hashes, whose value is unknown.
+type Server struct {
+	UnimplementedRemoteSignerServer
+
+	addresses []string
+	keys      map[string]*keypair.Full
+}
+
+// NewServer creates a Server signing with the given keypairs.
+func NewServer(keys ...*keypair.Full) *Server {
+	s := &Server{keys: map[string]*keypair.Full{}}
+	for _, kp := range keys {
+		if _, ok := s.keys[kp.Address()]; ok {
+			continue
+		}
+		s.addresses = append(s.addresses, kp.Address())
+		s.keys[kp.Address()] = kp
+	}
+	return s
+}
+
+// GetPublicKeys returns the addresses of the keypairs of the server, in the
+// order they were given to NewServer.
+func (s *Server) GetPublicKeys(ctx context.Context, req *GetPublicKeysRequest) (*GetPublicKeysResponse, error) {
+	return &GetPublicKeysResponse{PublicKeys: append([]string(nil), s.addresses...)}, nil
+}
+
+// SignHash signs the hash with the requested keypair.
+func (s *Server) SignHash(ctx context.Context, req *SignHashRequest) (*SignHashResponse, error) {
+	if len(req.Hash) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "hash must be 32 bytes long, got %d", len(req.Hash))
+	}
+	kp, err := s.key(req.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if guard := kp.SignGuard(); guard != nil && guard.LimitsValue() {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"the signatures of %s are limited by value, only transactions can be signed", req.PublicKey)
+	}
+	signature, err := kp.SignDecoratedWithContext(ctx, req.Hash)
+	if err != nil {
+		return nil, signError(err)
+	}
+	return &SignHashResponse{Signature: signature.Signature, Hint: signature.Hint[:]}, nil
+}
+
+// SignTransaction signs the transaction, or fee bump transaction, with the
+// requested keypairs, or all the keypairs of the server if none is requested.
+// No signature is added if any of the keypairs fails to sign.
+func (s *Server) SignTransaction(ctx context.Context, req *SignTransactionRequest) (*SignTransactionResponse, error) {
+	if req.NetworkPassphrase == "" {
+		return nil, status.Error(codes.InvalidArgument, "network passphrase is required")
+	}
+	tx, err := txnbuild.TransactionFromXDR(req.EnvelopeXdr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction envelope: %v", err)
+	}
+	hash, err := tx.Hash(req.NetworkPassphrase)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not hash transaction: %v", err)
+	}
+
+	signers := req.PublicKeys
+	if len(signers) == 0 {
+		signers = s.addresses
+	}
+	if value, ok := TransactionValue(tx); ok {
+		ctx = keypair.WithSignValue(ctx, value)
+	}
+	signatures := make([]xdr.DecoratedSignature, 0, len(signers))
+	for _, address := range signers {
+		kp, err := s.key(address)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := kp.SignDecoratedWithContext(ctx, hash[:])
+		if err != nil {
+			return nil, signError(err)
+		}
+		signatures = append(signatures, signature)
+	}
+
+	var envelope string
+	if inner, ok := tx.Transaction(); ok {
+		if inner, err = inner.AddSignatureDecorated(signatures...); err == nil {
+			envelope, err = inner.Base64()
+		}
+	} else {
+		feeBump, _ := tx.FeeBump()
+		if feeBump, err = feeBump.AddSignatureDecorated(signatures...); err == nil {
+			envelope, err = feeBump.Base64()
+		}
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not encode signed transaction: %v", err)
+	}
+	return &SignTransactionResponse{
+		EnvelopeXdr: envelope,
+		SignedBy:    append([]string(nil), signers...),
+	}, nil
+}
+
+func (s *Server) key(address string) (*keypair.Full, error) {
+	kp, ok := s.keys[address]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no key for %s", address)
+	}
+	return kp, nil
+}
+
+// TransactionValue returns the value of the transaction, or of the inner
+// transaction of a fee bump, passed to the keypair.SignGuard of the keypairs
+// of a Server: the sum of the amounts, in stroops of their assets, sent by its
+// payment, path payment, create account and create claimable balance
+// operations. It returns false if the value is unknown because the
+// transaction merges an account, whose balance is not in the envelope.
+func TransactionValue(tx *txnbuild.GenericTransaction) (int64, bool) {
+	inner, ok := tx.Transaction()
+	if !ok {
+		feeBump, _ := tx.FeeBump()
+		inner = feeBump.InnerTransaction()
+	}
+
+	var total int64
+	for _, op := range inner.Operations() {
+		var sent string
+		switch op := op.(type) {
+		case *txnbuild.Payment:
+			sent = op.Amount
+		case *txnbuild.PathPaymentStrictReceive:
+			sent = op.SendMax
+		case *txnbuild.PathPaymentStrictSend:
+			sent = op.SendAmount
+		case *txnbuild.CreateAccount:
+			sent = op.Amount
+		case *txnbuild.CreateClaimableBalance:
+			sent = op.Amount
+		case *txnbuild.AccountMerge:
+			return 0, false
+		default:
+			continue
+		}
+		value, err := amount.ParseInt64(sent)
+		if err != nil {
+			return 0, false
+		}
+		if total > math.MaxInt64-value {
+			return math.MaxInt64, true
+		}
+		total += value
+	}
+	return total, true
+}
+
+func signError(err error) error {
+	var refused *keypair.SignRefusedError
+	if errors.As(err, &refused) {
+		return status.Error(codes.PermissionDenied, refused.Error())
+	}
+	return status.Errorf(codes.Internal, "could not sign: %v", err)
+}
@@ -0,0 +1,213 @@
+package remotesigner
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+)
+
+func newTestClient(t *testing.T, server RemoteSignerServer) RemoteSignerClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	RegisterRemoteSignerServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewRemoteSignerClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	first, second := keypair.MustRandom(), keypair.MustRandom()
+	client := newTestClient(t, NewServer(first, second, first))
+
+	keys, err := client.GetPublicKeys(ctx, &GetPublicKeysRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Address(), second.Address()}, keys.PublicKeys)
+
+	hash := make([]byte, 32)
+	signature, err := client.SignHash(ctx, &SignHashRequest{PublicKey: second.Address(), Hash: hash})
+	require.NoError(t, err)
+	assert.NoError(t, second.Verify(hash, signature.Signature))
+	hint := second.Hint()
+	assert.Equal(t, hint[:], signature.Hint)
+
+	_, err = client.SignHash(ctx, &SignHashRequest{PublicKey: second.Address(), Hash: hash[:31]})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SignHash(ctx, &SignHashRequest{PublicKey: keypair.MustRandom().Address(), Hash: hash})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerSignTransaction(t *testing.T) {
+	ctx := context.Background()
+	source, cosigner := keypair.MustRandom(), keypair.MustRandom()
+	client := newTestClient(t, NewServer(source, cosigner))
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 0}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	envelope, err := tx.Base64()
+	require.NoError(t, err)
+
+	signed, err := client.SignTransaction(ctx, &SignTransactionRequest{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		EnvelopeXdr:       envelope,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{source.Address(), cosigner.Address()}, signed.SignedBy)
+	expected, err := tx.Sign(network.TestNetworkPassphrase, source, cosigner)
+	require.NoError(t, err)
+	expectedEnvelope, err := expected.Base64()
+	require.NoError(t, err)
+	assert.Equal(t, expectedEnvelope, signed.EnvelopeXdr)
+
+	feeAccount := keypair.MustRandom()
+	feeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      expected,
+		FeeAccount: feeAccount.Address(),
+		BaseFee:    txnbuild.MinBaseFee,
+	})
+	require.NoError(t, err)
+	feeBumpEnvelope, err := feeBump.Base64()
+	require.NoError(t, err)
+	_, err = client.SignTransaction(ctx, &SignTransactionRequest{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		EnvelopeXdr:       feeBumpEnvelope,
+		PublicKeys:        []string{feeAccount.Address()},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	feeClient := newTestClient(t, NewServer(feeAccount))
+	signed, err = feeClient.SignTransaction(ctx, &SignTransactionRequest{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		EnvelopeXdr:       feeBumpEnvelope,
+	})
+	require.NoError(t, err)
+	expectedFeeBump, err := feeBump.Sign(network.TestNetworkPassphrase, feeAccount)
+	require.NoError(t, err)
+	expectedEnvelope, err = expectedFeeBump.Base64()
+	require.NoError(t, err)
+	assert.Equal(t, expectedEnvelope, signed.EnvelopeXdr)
+
+	_, err = client.SignTransaction(ctx, &SignTransactionRequest{EnvelopeXdr: envelope})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SignTransaction(ctx, &SignTransactionRequest{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		EnvelopeXdr:       "AAAA",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func signGuardTestEnvelope(t *testing.T, source string, ops ...txnbuild.Operation) string {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           ops,
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	envelope, err := tx.Base64()
+	require.NoError(t, err)
+	return envelope
+}
+
+func TestServerSignGuard(t *testing.T) {
+	ctx := context.Background()
+	guard := keypair.NewSignGuard(keypair.SignPolicy{MaxValue: 100, RequireValue: true})
+	kp := keypair.MustRandom()
+	client := newTestClient(t, NewServer(kp.WithSignGuard(guard)))
+	destination := keypair.MustRandom().Address()
+
+	// the value of a hash is unknown
+	hash := make([]byte, 32)
+	_, err := client.SignHash(ctx, &SignHashRequest{PublicKey: kp.Address(), Hash: hash, Value: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the value of a transaction is derived from its payments, not from the
+	// value of the request
+	sign := func(ops ...txnbuild.Operation) error {
+		_, err := client.SignTransaction(ctx, &SignTransactionRequest{
+			NetworkPassphrase: network.TestNetworkPassphrase,
+			EnvelopeXdr:       signGuardTestEnvelope(t, kp.Address(), ops...),
+			Value:             1,
+		})
+		return err
+	}
+	assert.NoError(t, sign(
+		&txnbuild.Payment{Destination: destination, Amount: "0.0000060", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.CreateAccount{Destination: destination, Amount: "0.0000040"},
+	))
+	err = sign(
+		&txnbuild.Payment{Destination: destination, Amount: "0.0000060", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.PathPaymentStrictSend{
+			SendAsset: txnbuild.NativeAsset{}, SendAmount: "0.0000041",
+			Destination: destination, DestAsset: txnbuild.NativeAsset{}, DestMin: "0.0000001",
+		},
+	)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, codes.PermissionDenied, status.Code(sign(&txnbuild.AccountMerge{Destination: destination})))
+	assert.NoError(t, sign(&txnbuild.BumpSequence{BumpTo: 10}))
+
+	// hashes can be signed by the keypairs whose value is not limited
+	limited := keypair.MustRandom()
+	client = newTestClient(t, NewServer(limited.WithSignGuard(keypair.NewSignGuard(keypair.SignPolicy{
+		MaxSignatures: 10,
+		Interval:      time.Hour,
+	}))))
+	_, err = client.SignHash(ctx, &SignHashRequest{PublicKey: limited.Address(), Hash: hash})
+	assert.NoError(t, err)
+}
+
+func TestTransactionValue(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	destination := keypair.MustRandom().Address()
+	value := func(envelope string) (int64, bool) {
+		tx, err := txnbuild.TransactionFromXDR(envelope)
+		require.NoError(t, err)
+		return TransactionValue(tx)
+	}
+
+	total, ok := value(signGuardTestEnvelope(t, source,
+		&txnbuild.Payment{Destination: destination, Amount: "1", Asset: txnbuild.CreditAsset{Code: "USD", Issuer: source}},
+		&txnbuild.PathPaymentStrictReceive{
+			SendAsset: txnbuild.NativeAsset{}, SendMax: "2",
+			Destination: destination, DestAsset: txnbuild.NativeAsset{}, DestAmount: "1",
+		},
+		&txnbuild.CreateClaimableBalance{
+			Amount: "3", Asset: txnbuild.NativeAsset{},
+			Destinations: []txnbuild.Claimant{txnbuild.NewClaimant(destination, nil)},
+		},
+		&txnbuild.ManageData{Name: "name", Value: []byte("value")},
+	))
+	assert.True(t, ok)
+	assert.Equal(t, int64(60000000), total)
+
+	_, ok = value(signGuardTestEnvelope(t, source, &txnbuild.AccountMerge{Destination: destination}))
+	assert.False(t, ok)
+}
@@ -0,0 +1,167 @@
+package keypair
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SignRefusedError is returned by the signing methods of a Full keypair
+// guarded by a SignGuard when its policy refuses the signature.
+type SignRefusedError struct {
+	// Signer is the address of the keypair.
+	Signer string
+	// Value is the value of the signature, see WithSignValue, or 0 if it is
+	// unknown.
+	Value int64
+	// Reason describes the limit of the policy which would be exceeded.
+	Reason string
+}
+
+func (e *SignRefusedError) Error() string {
+	return fmt.Sprintf("signature by %s refused: %s", e.Signer, e.Reason)
+}
+
+// SignPolicy are the limits a SignGuard enforces on the signatures of each
+// keypair it guards, limiting the damage an attacker who took control of a
+// hot wallet, but not of its keys, can do. A limit is not enforced if it is
+// 0.
+type SignPolicy struct {
+	// MaxSignatures is the maximum number of signatures during Interval.
+	MaxSignatures int
+	// MaxValue is the maximum value of a signature, see WithSignValue.
+	MaxValue int64
+	// MaxIntervalValue is the maximum value of the signatures during
+	// Interval.
+	MaxIntervalValue int64
+	// Interval is the sliding window of MaxSignatures and MaxIntervalValue,
+	// which are not enforced if it is 0.
+	Interval time.Duration
+	// RequireValue refuses the signatures whose value is unknown. The value
+	// limits ignore them otherwise.
+	RequireValue bool
+	// Alert, if not nil, is called with the signatures refused, e.g. to page
+	// the operators of the wallet.
+	Alert func(ctx context.Context, refused *SignRefusedError)
+}
+
+type signValueContextKey struct{}
+
+// WithSignValue returns a copy of ctx carrying the value of the signature
+// made with it, e.g. the amount in stroops of the payments of the
+// transaction signed, for the value limits of SignPolicy. It is passed to
+// Full.SignWithContext or Full.SignDecoratedWithContext.
+func WithSignValue(ctx context.Context, value int64) context.Context {
+	return context.WithValue(ctx, signValueContextKey{}, value)
+}
+
+func signValue(ctx context.Context) (int64, bool) {
+	value, ok := ctx.Value(signValueContextKey{}).(int64)
+	return value, ok
+}
+
+type signRecord struct {
+	at    time.Time
+	value int64
+}
+
+// SignGuard enforces a SignPolicy on the signatures of the keypairs it
+// guards, see Full.WithSignGuard. Every keypair has its own limits, so a
+// guard can be shared by the keypairs of a wallet. It is safe for concurrent
+// use.
+type SignGuard struct {
+	policy SignPolicy
+	now    func() time.Time
+
+	mu      sync.Mutex
+	records map[string][]signRecord
+}
+
+// NewSignGuard returns a guard enforcing policy.
+func NewSignGuard(policy SignPolicy) *SignGuard {
+	return &SignGuard{
+		policy:  policy,
+		now:     time.Now,
+		records: map[string][]signRecord{},
+	}
+}
+
+// WithSignGuard returns a copy of the keypair whose signatures are refused,
+// with a *SignRefusedError, when they exceed the policy of guard.
+func (kp *Full) WithSignGuard(guard *SignGuard) *Full {
+	withGuard := *kp
+	withGuard.signGuard = guard
+	return &withGuard
+}
+
+// SignGuard returns the guard of the keypair, nil if it has none.
+func (kp *Full) SignGuard() *SignGuard {
+	return kp.signGuard
+}
+
+// LimitsValue returns true if the policy of the guard limits the value of the
+// signatures, which must then be known to be enforced.
+func (g *SignGuard) LimitsValue() bool {
+	p := g.policy
+	return p.RequireValue || p.MaxValue > 0 || (p.Interval > 0 && p.MaxIntervalValue > 0)
+}
+
+// allow records the signature of signer if the policy allows it, and returns
+// a *SignRefusedError otherwise.
+func (g *SignGuard) allow(ctx context.Context, signer string) error {
+	value, hasValue := signValue(ctx)
+	refused := g.check(signer, value, hasValue)
+	if refused == nil {
+		return nil
+	}
+	if g.policy.Alert != nil {
+		g.policy.Alert(ctx, refused)
+	}
+	return refused
+}
+
+func (g *SignGuard) check(signer string, value int64, hasValue bool) *SignRefusedError {
+	refuse := func(format string, args ...interface{}) *SignRefusedError {
+		return &SignRefusedError{Signer: signer, Value: value, Reason: fmt.Sprintf(format, args...)}
+	}
+	p := g.policy
+	if !hasValue {
+		if p.RequireValue {
+			return refuse("the value of the signature is unknown")
+		}
+	} else if p.MaxValue > 0 && value > p.MaxValue {
+		return refuse("value %d is above the maximum value %d", value, p.MaxValue)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+	records := g.records[signer]
+	if p.Interval > 0 {
+		// forget the signatures which left the window
+		start := 0
+		for start < len(records) && !records[start].at.After(now.Add(-p.Interval)) {
+			start++
+		}
+		records = append(records[:0], records[start:]...)
+
+		if p.MaxSignatures > 0 && len(records) >= p.MaxSignatures {
+			g.records[signer] = records
+			return refuse("%d signatures were made in the last %v, the maximum", len(records), p.Interval)
+		}
+		if hasValue && p.MaxIntervalValue > 0 {
+			total := value
+			for _, record := range records {
+				total += record.value
+			}
+			if total > p.MaxIntervalValue {
+				g.records[signer] = records
+				return refuse("value %d of the last %v would be above the maximum value %d", total, p.Interval, p.MaxIntervalValue)
+			}
+		}
+		records = append(records, signRecord{at: now, value: value})
+	}
+	g.records[signer] = records
+	return nil
+}
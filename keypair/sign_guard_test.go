@@ -0,0 +1,101 @@
+package keypair
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignGuardRateLimit(t *testing.T) {
+	kp := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	other := MustRandom()
+
+	var alerts []*SignRefusedError
+	guard := NewSignGuard(SignPolicy{
+		MaxSignatures: 2,
+		Interval:      time.Minute,
+		Alert: func(ctx context.Context, refused *SignRefusedError) {
+			alerts = append(alerts, refused)
+		},
+	})
+	now := time.Unix(1000, 0)
+	guard.now = func() time.Time { return now }
+	guarded := kp.WithSignGuard(guard)
+	otherGuarded := other.WithSignGuard(guard)
+
+	input := []byte("hello")
+	_, err := guarded.Sign(input)
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = guarded.SignDecorated(input)
+	require.NoError(t, err)
+
+	_, err = guarded.Sign(input)
+	require.IsType(t, &SignRefusedError{}, err)
+	assert.Equal(t, kp.Address(), err.(*SignRefusedError).Signer)
+	assert.Equal(t, []*SignRefusedError{err.(*SignRefusedError)}, alerts)
+
+	// the limits are per keypair, and the keypair is not guarded
+	_, err = otherGuarded.Sign(input)
+	assert.NoError(t, err)
+	_, err = kp.Sign(input)
+	assert.NoError(t, err)
+
+	// the first signature left the window
+	now = now.Add(31 * time.Second)
+	_, err = guarded.Sign(input)
+	assert.NoError(t, err)
+	_, err = guarded.Sign(input)
+	assert.Error(t, err)
+}
+
+func TestSignGuardValueLimits(t *testing.T) {
+	kp := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	guard := NewSignGuard(SignPolicy{
+		MaxValue:         100,
+		MaxIntervalValue: 150,
+		Interval:         time.Hour,
+	})
+	guarded := kp.WithSignGuard(guard)
+	input := []byte("hello")
+	ctx := context.Background()
+
+	_, err := guarded.SignWithContext(WithSignValue(ctx, 101), input)
+	require.Error(t, err)
+	assert.Equal(t, int64(101), err.(*SignRefusedError).Value)
+	assert.Equal(t, "signature by "+kp.Address()+" refused: value 101 is above the maximum value 100", err.Error())
+
+	sig, err := guarded.SignDecoratedWithContext(WithSignValue(ctx, 100), input)
+	require.NoError(t, err)
+	assert.NoError(t, kp.Verify(input, sig.Signature))
+	_, err = guarded.SignWithContext(WithSignValue(ctx, 50), input)
+	require.NoError(t, err)
+	_, err = guarded.SignWithContext(WithSignValue(ctx, 1), input)
+	assert.EqualError(t, err, "signature by "+kp.Address()+" refused: value 151 of the last 1h0m0s would be above the maximum value 150")
+
+	// the value limits ignore the signatures without value
+	_, err = guarded.Sign(input)
+	assert.NoError(t, err)
+
+	strict := kp.WithSignGuard(NewSignGuard(SignPolicy{MaxValue: 100, RequireValue: true}))
+	_, err = strict.Sign(input)
+	assert.EqualError(t, err, "signature by "+kp.Address()+" refused: the value of the signature is unknown")
+	_, err = strict.SignWithContext(WithSignValue(ctx, 10), input)
+	assert.NoError(t, err)
+}
+
+func TestSignGuardLimitsValue(t *testing.T) {
+	kp := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	assert.Nil(t, kp.SignGuard())
+	guard := NewSignGuard(SignPolicy{MaxValue: 100})
+	assert.Equal(t, guard, kp.WithSignGuard(guard).SignGuard())
+
+	assert.True(t, guard.LimitsValue())
+	assert.True(t, NewSignGuard(SignPolicy{RequireValue: true}).LimitsValue())
+	assert.True(t, NewSignGuard(SignPolicy{MaxIntervalValue: 100, Interval: time.Hour}).LimitsValue())
+	assert.False(t, NewSignGuard(SignPolicy{MaxIntervalValue: 100}).LimitsValue())
+	assert.False(t, NewSignGuard(SignPolicy{MaxSignatures: 10, Interval: time.Hour}).LimitsValue())
+}
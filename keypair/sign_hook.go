@@ -0,0 +1,63 @@
+package keypair
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync/atomic"
+)
+
+// SignEvent describes a signature made by a Full keypair, see SignHook.
+type SignEvent struct {
+	// Signer is the address of the keypair.
+	Signer string
+	// Input is the signed data, the hash of the transaction when signing
+	// transactions. It must not be modified.
+	Input []byte
+	// Hash is the SHA-256 hash of Input.
+	Hash [32]byte
+	// Signature is the signature of Input. It must not be modified.
+	Signature []byte
+}
+
+// SignHook is called after every signature made by a Full keypair, e.g. to
+// keep an audit trail of the signatures of a custody service. ctx is the one
+// passed to SignWithContext, or context.Background() for the other signing
+// methods.
+type SignHook func(ctx context.Context, event SignEvent)
+
+var globalSignHook atomic.Value
+
+// SetSignHook sets the hook called after the signatures of all the Full
+// keypairs, in addition to their own hook (see Full.WithSignHook). A nil hook
+// removes the global hook.
+func SetSignHook(hook SignHook) {
+	globalSignHook.Store(hook)
+}
+
+// WithSignHook returns a copy of the keypair calling hook after each of its
+// signatures, before the global hook set by SetSignHook.
+func (kp *Full) WithSignHook(hook SignHook) *Full {
+	withHook := *kp
+	withHook.signHook = hook
+	return &withHook
+}
+
+func (kp *Full) reportSign(ctx context.Context, input, signature []byte) {
+	global, _ := globalSignHook.Load().(SignHook)
+	if kp.signHook == nil && global == nil {
+		return
+	}
+
+	event := SignEvent{
+		Signer:    kp.address,
+		Input:     input,
+		Hash:      sha256.Sum256(input),
+		Signature: signature,
+	}
+	if kp.signHook != nil {
+		kp.signHook(ctx, event)
+	}
+	if global != nil {
+		global(ctx, event)
+	}
+}
@@ -0,0 +1,60 @@
+package keypair
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signHookContextKey struct{}
+
+func TestSignHook(t *testing.T) {
+	kp := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+
+	var calls []string
+	var operators []interface{}
+	var events []SignEvent
+	SetSignHook(func(ctx context.Context, event SignEvent) {
+		calls = append(calls, "global")
+		events = append(events, event)
+	})
+	defer SetSignHook(nil)
+	withHook := kp.WithSignHook(func(ctx context.Context, event SignEvent) {
+		calls = append(calls, "keypair")
+		operators = append(operators, ctx.Value(signHookContextKey{}))
+	})
+
+	ctx := context.WithValue(context.Background(), signHookContextKey{}, "operator")
+	input := []byte("hello")
+	sig, err := withHook.SignWithContext(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keypair", "global"}, calls)
+	require.Len(t, events, 1)
+	assert.Equal(t, SignEvent{
+		Signer:    kp.Address(),
+		Input:     input,
+		Hash:      sha256.Sum256(input),
+		Signature: sig,
+	}, events[0])
+	assert.NoError(t, kp.Verify(input, sig))
+
+	// the other signing methods call the hooks too, and the original keypair
+	// only calls the global hook
+	calls = nil
+	_, err = kp.SignDecorated(input)
+	require.NoError(t, err)
+	_, err = withHook.SignBase64(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"global", "keypair", "global"}, calls)
+	assert.Equal(t, []interface{}{"operator", nil}, operators)
+	assert.True(t, withHook.Equal(kp))
+
+	SetSignHook(nil)
+	calls = nil
+	_, err = kp.Sign(input)
+	require.NoError(t, err)
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,68 @@
+package signerpool
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+)
+
+// benchmarkJobs returns the jobs signing count random hashes with 10 keys.
+func benchmarkJobs(b *testing.B, count int) []Job {
+	kps := make([]*keypair.Full, 10)
+	for i := range kps {
+		kps[i] = keypair.MustRandom()
+	}
+	jobs := make([]Job, count)
+	for i := range jobs {
+		input := make([]byte, 32)
+		_, err := rand.Read(input)
+		require.NoError(b, err)
+		jobs[i] = Job{Signer: kps[i%len(kps)], Input: input}
+	}
+	return jobs
+}
+
+func BenchmarkSequentialSign(b *testing.B) {
+	jobs := benchmarkJobs(b, 1000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, job := range jobs {
+			_, _ = job.Signer.SignDecoratedWithContext(ctx, job.Input)
+		}
+	}
+}
+
+func BenchmarkPoolSign(b *testing.B) {
+	jobs := benchmarkJobs(b, 1000)
+	ctx := context.Background()
+
+	for _, workers := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			pool := &Pool{Workers: workers}
+			for i := 0; i < b.N; i++ {
+				pool.Sign(ctx, jobs)
+			}
+		})
+	}
+}
+
+func BenchmarkPoolSignSerialized(b *testing.B) {
+	jobs := benchmarkJobs(b, 1000)
+	ctx := context.Background()
+	pool := &Pool{
+		Workers:      8,
+		SerializeKey: func(signer Signer) bool { return true },
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Sign(ctx, jobs)
+	}
+}
@@ -0,0 +1,165 @@
+// Package signerpool signs in bulk, e.g. the transactions of an airdrop,
+// distributing the signatures across worker goroutines instead of making
+// them one at a time on a single goroutine.
+//
+// The signatures of a keypair.Full are safe to make concurrently, so the
+// jobs of the same key are spread across the workers, unless the pool
+// serializes them (see Pool.SerializeKey), e.g. for keys whose sign hooks
+// are not safe for concurrent use.
+//
+// The benchmarks of the package compare the pool to sequential signing on
+// the machine they run on:
+//
+//	go test -run none -bench . ./keypair/signerpool
+package signerpool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+// Signer signs the jobs of a Pool. It is implemented by *keypair.Full.
+type Signer interface {
+	Address() string
+	SignDecoratedWithContext(ctx context.Context, input []byte) (xdr.DecoratedSignature, error)
+}
+
+var _ Signer = (*keypair.Full)(nil)
+
+// Job is an input to sign by a signer.
+type Job struct {
+	Signer Signer
+	// Input is the data signed, the hash of the transaction when signing
+	// transactions. It must not be modified until the job is signed.
+	Input []byte
+}
+
+// Result is the signature of a Job, or the error signing it.
+type Result struct {
+	Signature xdr.DecoratedSignature
+	Err       error
+}
+
+// Pool signs jobs with worker goroutines. The zero Pool is ready to use and
+// a Pool can be used concurrently.
+type Pool struct {
+	// Workers is the number of worker goroutines signing the jobs,
+	// runtime.GOMAXPROCS(0) if 0.
+	Workers int
+	// SerializeKey returns true, if set, for the signers whose signatures
+	// must not be made concurrently, e.g. keypairs whose sign hook is not
+	// safe for concurrent use or signers backed by a device signing one
+	// input at a time. Their jobs are signed one at a time, by any worker.
+	SerializeKey func(signer Signer) bool
+}
+
+func (p *Pool) workers(jobs int) int {
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > jobs {
+		workers = jobs
+	}
+	return workers
+}
+
+// Sign signs the jobs and returns their results, in the order of the jobs.
+// When ctx is done before all the jobs are signed, the jobs not signed yet
+// fail with ctx.Err(). ctx is passed to the signers, e.g. to their sign
+// hooks and guards, see keypair.Full.SignWithContext.
+func (p *Pool) Sign(ctx context.Context, jobs []Job) []Result {
+	results := make([]Result, len(jobs))
+	if len(jobs) == 0 {
+		return results
+	}
+
+	// the locks of the serialized signers, created before the workers start
+	// so that they are only read concurrently
+	locks := map[string]*sync.Mutex{}
+	if p.SerializeKey != nil {
+		for _, job := range jobs {
+			if address := job.Signer.Address(); locks[address] == nil && p.SerializeKey(job.Signer) {
+				locks[address] = &sync.Mutex{}
+			}
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := p.workers(len(jobs)); i > 0; i-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = sign(ctx, jobs[index], locks)
+			}
+		}()
+	}
+
+	for index := range jobs {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func sign(ctx context.Context, job Job, locks map[string]*sync.Mutex) Result {
+	if err := ctx.Err(); err != nil {
+		return Result{Err: err}
+	}
+	if lock := locks[job.Signer.Address()]; lock != nil {
+		lock.Lock()
+		defer lock.Unlock()
+	}
+	signature, err := job.Signer.SignDecoratedWithContext(ctx, job.Input)
+	return Result{Signature: signature, Err: err}
+}
+
+// SignTransactions returns the transactions signed by all the signers, in
+// the order of the transactions, or the first error signing them. As for
+// txnbuild.Transaction.SignWithContext, the usage of the signatures is given
+// by txnbuild.KeyUsageContext.
+func (p *Pool) SignTransactions(ctx context.Context, networkPassphrase string, txs []*txnbuild.Transaction, signers ...Signer) ([]*txnbuild.Transaction, error) {
+	usageCtx := ctx
+	jobs := make([]Job, 0, len(txs)*len(signers))
+	for i, tx := range txs {
+		// the usage is the same for all the transactions, or an error
+		var err error
+		if usageCtx, err = txnbuild.KeyUsageContext(ctx, tx.ToXDR()); err != nil {
+			return nil, errors.Wrapf(err, "transaction %d", i)
+		}
+		hash, err := tx.Hash(networkPassphrase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to hash transaction %d", i)
+		}
+		for _, signer := range signers {
+			jobs = append(jobs, Job{Signer: signer, Input: hash[:]})
+		}
+	}
+
+	results := p.Sign(usageCtx, jobs)
+	signed := make([]*txnbuild.Transaction, len(txs))
+	for i, tx := range txs {
+		signatures := make([]xdr.DecoratedSignature, len(signers))
+		for j := range signers {
+			result := results[i*len(signers)+j]
+			if result.Err != nil {
+				return nil, errors.Wrapf(result.Err, "failed to sign transaction %d by %s", i, signers[j].Address())
+			}
+			signatures[j] = result.Signature
+		}
+		var err error
+		if signed[i], err = tx.AddSignatureDecorated(signatures...); err != nil {
+			return nil, errors.Wrapf(err, "failed to add signatures to transaction %d", i)
+		}
+	}
+	return signed, nil
+}
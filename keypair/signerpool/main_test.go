@@ -0,0 +1,130 @@
+package signerpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+func TestPoolSign(t *testing.T) {
+	kps := []*keypair.Full{keypair.MustRandom(), keypair.MustRandom()}
+	var jobs []Job
+	for i := 0; i < 50; i++ {
+		jobs = append(jobs, Job{Signer: kps[i%2], Input: []byte{byte(i)}})
+	}
+
+	pool := &Pool{Workers: 4}
+	results := pool.Sign(context.Background(), jobs)
+	require.Len(t, results, len(jobs))
+	for i, result := range results {
+		require.NoError(t, result.Err)
+		expected, err := jobs[i].Signer.SignDecoratedWithContext(context.Background(), jobs[i].Input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, result.Signature)
+	}
+
+	assert.Empty(t, pool.Sign(context.Background(), nil))
+}
+
+// countingSigner records how many of its signatures are made concurrently.
+type countingSigner struct {
+	*keypair.Full
+	current, max int32
+	wait         *sync.WaitGroup
+}
+
+func (s *countingSigner) SignDecoratedWithContext(ctx context.Context, input []byte) (xdr.DecoratedSignature, error) {
+	current := atomic.AddInt32(&s.current, 1)
+	defer atomic.AddInt32(&s.current, -1)
+	for {
+		max := atomic.LoadInt32(&s.max)
+		if current <= max || atomic.CompareAndSwapInt32(&s.max, max, current) {
+			break
+		}
+	}
+	if s.wait != nil {
+		// the first signatures wait for each other, so that they are
+		// concurrent unless they are serialized
+		s.wait.Done()
+		s.wait.Wait()
+	}
+	return s.Full.SignDecoratedWithContext(ctx, input)
+}
+
+func TestPoolSerializeKey(t *testing.T) {
+	serialized := &countingSigner{Full: keypair.MustRandom()}
+	var wait sync.WaitGroup
+	wait.Add(2)
+	concurrent := &countingSigner{Full: keypair.MustRandom(), wait: &wait}
+
+	var jobs []Job
+	for i := 0; i < 20; i++ {
+		jobs = append(jobs, Job{Signer: serialized, Input: []byte{byte(i)}})
+	}
+	jobs = append(jobs, Job{Signer: concurrent, Input: []byte{1}}, Job{Signer: concurrent, Input: []byte{2}})
+
+	pool := &Pool{
+		Workers: 4,
+		SerializeKey: func(signer Signer) bool {
+			return signer.Address() == serialized.Address()
+		},
+	}
+	for _, result := range pool.Sign(context.Background(), jobs) {
+		require.NoError(t, result.Err)
+	}
+	assert.Equal(t, int32(1), serialized.max)
+	assert.Equal(t, int32(2), concurrent.max)
+}
+
+func TestPoolSignCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := (&Pool{}).Sign(ctx, []Job{{Signer: keypair.MustRandom(), Input: []byte{1}}})
+	assert.Equal(t, []Result{{Err: context.Canceled}}, results)
+}
+
+func TestPoolSignTransactions(t *testing.T) {
+	source := keypair.MustRandom()
+	cosigner := keypair.MustRandom()
+	var txs []*txnbuild.Transaction
+	for i := int64(0); i < 10; i++ {
+		tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+			SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: i},
+			IncrementSequenceNum: true,
+			Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 0}},
+			BaseFee:              txnbuild.MinBaseFee,
+			Timebounds:           txnbuild.NewInfiniteTimeout(),
+		})
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+
+	signed, err := (&Pool{Workers: 3}).SignTransactions(context.Background(), network.TestNetworkPassphrase, txs, source, cosigner)
+	require.NoError(t, err)
+	require.Len(t, signed, len(txs))
+	for i, tx := range signed {
+		expected, err := txs[i].Sign(network.TestNetworkPassphrase, source, cosigner)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Signatures(), tx.Signatures())
+		// the transactions are not modified
+		assert.Empty(t, txs[i].Signatures())
+	}
+
+	auth, err := source.WithUsages(keypair.UsageAuth)
+	require.NoError(t, err)
+	_, err = (&Pool{}).SignTransactions(context.Background(), network.TestNetworkPassphrase, txs, auth)
+	assert.Error(t, err)
+	// transactions which are not challenges cannot be signed for
+	// authentication
+	_, err = (&Pool{}).SignTransactions(keypair.WithKeyUsage(context.Background(), keypair.UsageAuth), network.TestNetworkPassphrase, txs, auth)
+	assert.EqualError(t, err, "transaction 0: only challenge transactions can be signed for authentication: transaction sequence number must be 0")
+}
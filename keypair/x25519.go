@@ -0,0 +1,195 @@
+package keypair
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/stellar/go/strkey"
+)
+
+// ErrDecryptionFailed is returned by Full.Open when the message cannot be
+// decrypted: it is malformed, was not sealed for the keypair by the peer or
+// was modified.
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// sealInfo is the HKDF info of the keys of Full.Seal, binding them to the
+// scheme.
+const sealInfo = "stellar keypair x25519 xchacha20poly1305 v1"
+
+// fieldPrime is 2^255 - 19, the prime of the field of curve25519 and
+// edwards25519.
+var fieldPrime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// x25519PrivateKey returns the X25519 private key of the keypair: the clamped
+// first half of the SHA-512 hash of its seed, the scalar of its ed25519 key.
+func (kp *Full) x25519PrivateKey() []byte {
+	h := sha512.Sum512(kp.privateKey.Seed())
+	zero(h[32:])
+	scalar := h[:32]
+	scalar[0] &= 248
+	scalar[31] &= 127
+	scalar[31] |= 64
+	return scalar
+}
+
+// x25519PublicKey converts an ed25519 public key to the X25519 public key,
+// the u-coordinate (1 + y) / (1 - y) of the Montgomery form of the point.
+func x25519PublicKey(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != 32 {
+		return nil, ErrInvalidKey
+	}
+	// the key is the little-endian y-coordinate with the sign of x in the
+	// top bit
+	le := make([]byte, 32)
+	copy(le, publicKey)
+	le[31] &= 127
+	y := new(big.Int).SetBytes(reverse(le))
+	if y.Cmp(fieldPrime) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	numerator := new(big.Int).Add(big.NewInt(1), y)
+	denominator := new(big.Int).Sub(big.NewInt(1), y)
+	denominator.Mod(denominator, fieldPrime)
+	if denominator.Sign() == 0 {
+		// y = 1 is the identity, which has no Montgomery form
+		return nil, ErrInvalidKey
+	}
+	denominator.ModInverse(denominator, fieldPrime)
+	u := numerator.Mul(numerator, denominator)
+	u.Mod(u, fieldPrime)
+
+	out := make([]byte, 32)
+	u.FillBytes(out)
+	return reverse(out), nil
+}
+
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
+
+// SharedSecret returns the X25519 shared secret of the keypair and the peer,
+// the same as peer.SharedSecret(kp) when the secret key of the peer is
+// known: their ed25519 keys are converted to X25519 keys, so that two
+// Stellar accounts can agree on a secret knowing only each other's address.
+//
+// The secret is the raw output of X25519, it must be passed through a key
+// derivation function such as HKDF before it is used as a key, see Seal for
+// an authenticated encryption scheme built on it. ErrInvalidKey is returned
+// if the key of the peer is invalid or of low order, and a *KeyUsageError if
+// the keypair is not allowed UsageEncryption.
+func (kp *Full) SharedSecret(peer KP) ([]byte, error) {
+	if err := kp.checkUsage(UsageEncryption); err != nil {
+		return nil, err
+	}
+	peerKey, err := strkey.Decode(strkey.VersionByteAccountID, peer.Address())
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	peerPublic, err := x25519PublicKey(peerKey)
+	if err != nil {
+		return nil, err
+	}
+	private := kp.x25519PrivateKey()
+	defer zero(private)
+	secret, err := curve25519.X25519(private, peerPublic)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return secret, nil
+}
+
+// sealKey derives the key of the messages sealed by sender for recipient
+// from their shared secret.
+func (kp *Full) sealKey(sender, recipient KP) ([]byte, error) {
+	peer := recipient
+	if sender.Address() != kp.Address() {
+		peer = sender
+	}
+	secret, err := kp.SharedSecret(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(secret)
+
+	senderKey, err := strkey.Decode(strkey.VersionByteAccountID, sender.Address())
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	recipientKey, err := strkey.Decode(strkey.VersionByteAccountID, recipient.Address())
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	salt := append(senderKey, recipientKey...)
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(sealInfo)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Seal encrypts and authenticates plaintext for the peer, e.g. an encrypted
+// memo or attachment sent to another account, which it decrypts with Open.
+// additionalData, which can be nil, is authenticated but not encrypted, e.g.
+// the hash of the transaction the message is attached to.
+//
+// The key is derived with HKDF-SHA256 from the SharedSecret of the keypair
+// and the peer and their public keys, in this order, so that a message
+// sealed by A for B cannot be passed off as a message sealed by B for A.
+// The message is encrypted with XChaCha20-Poly1305 and a random nonce: the
+// sealed message is the 24 bytes nonce followed by the ciphertext, 40 bytes
+// longer than plaintext.
+func (kp *Full) Seal(peer KP, plaintext, additionalData []byte) ([]byte, error) {
+	key, err := kp.sealKey(kp, peer)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(key)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, sealed); err != nil {
+		return nil, err
+	}
+	return aead.Seal(sealed, sealed, plaintext, additionalData), nil
+}
+
+// Open decrypts a message sealed for the keypair by the peer with Seal, with
+// the same additionalData. ErrDecryptionFailed is returned if the message
+// cannot be authenticated.
+func (kp *Full) Open(peer KP, sealed, additionalData []byte) ([]byte, error) {
+	key, err := kp.sealKey(peer, kp)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(key)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrDecryptionFailed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
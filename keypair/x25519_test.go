@@ -0,0 +1,81 @@
+package keypair
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestX25519PublicKey(t *testing.T) {
+	// the conversion of the ed25519 public key is the X25519 public key of
+	// the converted private key
+	for i := 0; i < 10; i++ {
+		kp := MustRandom()
+		expected, err := curve25519.X25519(kp.x25519PrivateKey(), curve25519.Basepoint)
+		require.NoError(t, err)
+		converted, err := x25519PublicKey(kp.publicKey)
+		require.NoError(t, err)
+		assert.Equal(t, expected, converted)
+	}
+
+	identity := make([]byte, 32)
+	identity[0] = 1
+	_, err := x25519PublicKey(identity)
+	assert.Equal(t, ErrInvalidKey, err)
+	_, err = x25519PublicKey([]byte{1, 2, 3})
+	assert.Equal(t, ErrInvalidKey, err)
+}
+
+func TestFull_SharedSecret(t *testing.T) {
+	alice := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	bob := MustParseFull("SBPBTSQAIEA5HLWLVWA4TJ7RBKHCEERE2W2DZLB6AUUCEUIYWLJF2EUS")
+
+	secret, err := alice.SharedSecret(bob.FromAddress())
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+	other, err := bob.SharedSecret(alice)
+	require.NoError(t, err)
+	assert.Equal(t, secret, other)
+
+	eve, err := MustRandom().SharedSecret(bob)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, eve)
+}
+
+func TestFull_SealOpen(t *testing.T) {
+	alice := MustParseFull("SBFGFF27Y64ZUGFAIG5AMJGQODZZKV2YQKAVUUN4HNE24XZXD2OEUVUP")
+	bob := MustParseFull("SBPBTSQAIEA5HLWLVWA4TJ7RBKHCEERE2W2DZLB6AUUCEUIYWLJF2EUS")
+	plaintext := []byte("invoice 42")
+	ad := []byte("transaction hash")
+
+	sealed, err := alice.Seal(bob.FromAddress(), plaintext, ad)
+	require.NoError(t, err)
+	assert.Len(t, sealed, len(plaintext)+40)
+	again, err := alice.Seal(bob, plaintext, ad)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "the nonces are random")
+
+	opened, err := bob.Open(alice.FromAddress(), sealed, ad)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+
+	_, err = bob.Open(alice, sealed, []byte("other transaction"))
+	assert.Equal(t, ErrDecryptionFailed, err)
+	_, err = MustRandom().Open(alice, sealed, ad)
+	assert.Equal(t, ErrDecryptionFailed, err)
+	// the message was sealed by alice for bob, not by bob for alice
+	_, err = alice.Open(bob, sealed, ad)
+	assert.Equal(t, ErrDecryptionFailed, err)
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = bob.Open(alice, tampered, ad)
+	assert.Equal(t, ErrDecryptionFailed, err)
+	_, err = bob.Open(alice, sealed[:30], ad)
+	assert.Equal(t, ErrDecryptionFailed, err)
+
+	_, err = alice.Seal(MustParse("SBPBTSQAIEA5HLWLVWA4TJ7RBKHCEERE2W2DZLB6AUUCEUIYWLJF2EUS"), nil, nil)
+	assert.NoError(t, err)
+}
@@ -3,12 +3,7 @@
 package network
 
 import (
-	"bytes"
-
-	"strings"
-
 	"github.com/stellar/go/hash"
-	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
 
@@ -30,20 +25,9 @@ func ID(passphrase string) [32]byte {
 // contained in the provided envelope using the network identified by the supplied passphrase.
 // The resulting hash is the value that can be signed by stellar secret key to
 // authorize the transaction identified by the hash to stellar validators.
+// It is the same as envelope.Hash(passphrase).
 func HashTransactionInEnvelope(envelope xdr.TransactionEnvelope, passphrase string) ([32]byte, error) {
-	var hash [32]byte
-	var err error
-	switch envelope.Type {
-	case xdr.EnvelopeTypeEnvelopeTypeTx:
-		hash, err = HashTransaction(envelope.V1.Tx, passphrase)
-	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
-		hash, err = HashTransactionV0(envelope.V0.Tx, passphrase)
-	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
-		hash, err = HashFeeBumpTransaction(envelope.FeeBump.Tx, passphrase)
-	default:
-		err = errors.New("invalid transaction type")
-	}
-	return hash, err
+	return envelope.Hash(passphrase)
 }
 
 // HashTransaction derives the network specific hash for the provided
@@ -51,11 +35,10 @@ func HashTransactionInEnvelope(envelope xdr.TransactionEnvelope, passphrase stri
 // resulting hash is the value that can be signed by stellar secret key to
 // authorize the transaction identified by the hash to stellar validators.
 func HashTransaction(tx xdr.Transaction, passphrase string) ([32]byte, error) {
-	taggedTx := xdr.TransactionSignaturePayloadTaggedTransaction{
+	return HashTransactionInEnvelope(xdr.TransactionEnvelope{
 		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
-		Tx:   &tx,
-	}
-	return hashTx(taggedTx, passphrase)
+		V1:   &xdr.TransactionV1Envelope{Tx: tx},
+	}, passphrase)
 }
 
 // HashFeeBumpTransaction derives the network specific hash for the provided
@@ -63,11 +46,10 @@ func HashTransaction(tx xdr.Transaction, passphrase string) ([32]byte, error) {
 // resulting hash is the value that can be signed by stellar secret key to
 // authorize the transaction identified by the hash to stellar validators.
 func HashFeeBumpTransaction(tx xdr.FeeBumpTransaction, passphrase string) ([32]byte, error) {
-	taggedTx := xdr.TransactionSignaturePayloadTaggedTransaction{
+	return HashTransactionInEnvelope(xdr.TransactionEnvelope{
 		Type:    xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
-		FeeBump: &tx,
-	}
-	return hashTx(taggedTx, passphrase)
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{Tx: tx},
+	}, passphrase)
 }
 
 // HashTransactionV0 derives the network specific hash for the provided
@@ -75,39 +57,8 @@ func HashFeeBumpTransaction(tx xdr.FeeBumpTransaction, passphrase string) ([32]b
 // resulting hash is the value that can be signed by stellar secret key to
 // authorize the transaction identified by the hash to stellar validators.
 func HashTransactionV0(tx xdr.TransactionV0, passphrase string) ([32]byte, error) {
-	sa, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, tx.SourceAccountEd25519)
-	if err != nil {
-		return [32]byte{}, err
-	}
-	v1Tx := xdr.Transaction{
-		SourceAccount: sa,
-		Fee:           tx.Fee,
-		Memo:          tx.Memo,
-		Operations:    tx.Operations,
-		SeqNum:        tx.SeqNum,
-		TimeBounds:    tx.TimeBounds,
-	}
-	return HashTransaction(v1Tx, passphrase)
-}
-
-func hashTx(
-	tx xdr.TransactionSignaturePayloadTaggedTransaction,
-	passphrase string,
-) ([32]byte, error) {
-	if strings.TrimSpace(passphrase) == "" {
-		return [32]byte{}, errors.New("empty network passphrase")
-	}
-
-	var txBytes bytes.Buffer
-	payload := xdr.TransactionSignaturePayload{
-		NetworkId:         ID(passphrase),
-		TaggedTransaction: tx,
-	}
-
-	_, err := xdr.Marshal(&txBytes, payload)
-	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "marshal tx failed")
-	}
-
-	return hash.Hash(txBytes.Bytes()), nil
+	return HashTransactionInEnvelope(xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxV0,
+		V0:   &xdr.TransactionV0Envelope{Tx: tx},
+	}, passphrase)
 }
@@ -1,5 +1,7 @@
 package base
 
+import "encoding/json"
+
 type Price struct {
 	N int32 `json:"n"`
 	D int32 `json:"d"`
@@ -32,3 +34,27 @@ type LiquidityPoolOrAsset struct {
 type Rehydratable interface {
 	Rehydrate() error
 }
+
+// Extra are the fields of a response unknown to the SDK, see
+// horizon.UnknownFields. They are kept behind a pointer so that the resources
+// holding them stay comparable.
+type Extra struct {
+	fields *map[string]json.RawMessage
+}
+
+// NewExtra returns the Extra of the given fields.
+func NewExtra(fields map[string]json.RawMessage) Extra {
+	if fields == nil {
+		return Extra{}
+	}
+	return Extra{fields: &fields}
+}
+
+// Fields returns the fields unknown to the SDK keyed by their JSON name, nil
+// if there are none.
+func (e Extra) Fields() map[string]json.RawMessage {
+	if e.fields == nil {
+		return nil
+	}
+	return *e.fields
+}
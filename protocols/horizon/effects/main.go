@@ -2,8 +2,10 @@ package effects
 
 import (
 	"encoding/json"
+	"reflect"
 	"time"
 
+	"github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/protocols/horizon/base"
 	"github.com/stellar/go/support/render/hal"
 	"github.com/stellar/go/xdr"
@@ -277,6 +279,10 @@ type Base struct {
 	Type            string    `json:"type"`
 	TypeI           int32     `json:"type_i"`
 	LedgerCloseTime time.Time `json:"created_at"`
+
+	// Extra are the fields of the response unknown to the SDK, set by
+	// UnmarshalEffect, see horizon.UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implements `hal.Pageable` and Effect
@@ -959,10 +965,26 @@ func UnmarshalEffect(effectType string, dataString []byte) (effects Effect, err
 		}
 		effects = effect
 	}
-	return
+	if err != nil {
+		return
+	}
+	return withExtra(effects, dataString)
 }
 
 // interface implementations
 var _ base.Rehydratable = &SignerCreated{}
 var _ base.Rehydratable = &SignerRemoved{}
 var _ base.Rehydratable = &SignerUpdated{}
+
+// withExtra returns a copy of the effect with the fields of data unknown to
+// it in the Extra field of its base.
+func withExtra(v Effect, data []byte) (Effect, error) {
+	extra, err := horizon.UnknownFields(data, v)
+	if err != nil || extra == nil {
+		return v, err
+	}
+	value := reflect.New(reflect.TypeOf(v)).Elem()
+	value.Set(reflect.ValueOf(v))
+	value.FieldByName("Extra").Set(reflect.ValueOf(base.NewExtra(extra)))
+	return value.Interface().(Effect), nil
+}
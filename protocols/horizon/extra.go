@@ -0,0 +1,227 @@
+package horizon
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/stellar/go/protocols/horizon/base"
+)
+
+// jsonFieldsCache caches the JSONFields of the types.
+var jsonFieldsCache sync.Map
+
+// UnknownFields returns the fields of the JSON object data which do not
+// decode into a field of v, a struct or a pointer to a struct, nil if there
+// are none. The resources of this package keep them in their Extra field, so
+// that the fields added by newer versions of Horizon can be read before they
+// are supported by the SDK.
+//
+// Fields are matched like encoding/json does, case insensitively and
+// through embedded structs.
+func UnknownFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := JSONFields(reflect.TypeOf(v))
+	var unknown map[string]json.RawMessage
+	for name, value := range fields {
+		if _, ok := MatchJSONField(known, name); ok {
+			continue
+		}
+		if unknown == nil {
+			unknown = map[string]json.RawMessage{}
+		}
+		unknown[name] = value
+	}
+	return unknown, nil
+}
+
+// JSONField is a field of a struct decoded by encoding/json, see JSONFields.
+type JSONField struct {
+	// Name is its JSON name.
+	Name string
+	Type reflect.Type
+	// Owner is the struct declaring it, which differs from the decoded
+	// struct for the fields promoted from embedded structs.
+	Owner reflect.Type
+	// Tagged is false for the fields named after their Go name, which are
+	// meant to be matched case insensitively.
+	Tagged bool
+	// depth is the depth of the embedded struct declaring it: the shallowest
+	// field of a name is decoded
+	depth int
+}
+
+// JSONFields returns the fields of t, a struct or a pointer to a struct,
+// decoded by encoding/json, keyed by their JSON name, including the fields
+// promoted from untagged embedded structs. The returned map is shared and
+// must not be modified.
+func JSONFields(t reflect.Type) map[string]JSONField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]JSONField)
+	}
+	fields := map[string]JSONField{}
+	addJSONFields(t, 0, fields)
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+func addJSONFields(t reflect.Type, depth int, fields map[string]JSONField) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && name == "" {
+			// the fields of untagged embedded structs are promoted
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addJSONFields(embedded, depth+1, fields)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		if existing, ok := fields[name]; !ok || depth < existing.depth {
+			fields[name] = JSONField{Name: name, Type: field.Type, Owner: t, Tagged: tagged, depth: depth}
+		}
+	}
+}
+
+// MatchJSONField returns the field of fields, from JSONFields, a JSON field
+// name decodes into: the field of that name, or else a field whose name
+// matches it case insensitively, as encoding/json does.
+func MatchJSONField(fields map[string]JSONField, name string) (JSONField, bool) {
+	if field, ok := fields[name]; ok {
+		return field, true
+	}
+	for known, field := range fields {
+		if strings.EqualFold(known, name) {
+			return field, true
+		}
+	}
+	return JSONField{}, false
+}
+
+// decodeWithExtra decodes data into v, a pointer to a type without an
+// UnmarshalJSON method, and returns the fields unknown to it.
+func decodeWithExtra(data []byte, v interface{}) (base.Extra, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return base.Extra{}, err
+	}
+	extra, err := UnknownFields(data, v)
+	return base.NewExtra(extra), err
+}
+
+// UnmarshalJSON decodes the Account, keeping its unknown fields in Extra.
+func (a *Account) UnmarshalJSON(data []byte) (err error) {
+	type alias Account
+	a.Extra, err = decodeWithExtra(data, (*alias)(a))
+	return
+}
+
+// UnmarshalJSON decodes the AccountData, keeping its unknown fields in Extra.
+func (a *AccountData) UnmarshalJSON(data []byte) (err error) {
+	type alias AccountData
+	a.Extra, err = decodeWithExtra(data, (*alias)(a))
+	return
+}
+
+// UnmarshalJSON decodes the AssetStat, keeping its unknown fields in Extra.
+func (a *AssetStat) UnmarshalJSON(data []byte) (err error) {
+	type alias AssetStat
+	a.Extra, err = decodeWithExtra(data, (*alias)(a))
+	return
+}
+
+// UnmarshalJSON decodes the ClaimableBalance, keeping its unknown fields in Extra.
+func (c *ClaimableBalance) UnmarshalJSON(data []byte) (err error) {
+	type alias ClaimableBalance
+	c.Extra, err = decodeWithExtra(data, (*alias)(c))
+	return
+}
+
+// UnmarshalJSON decodes the FeeStats, keeping its unknown fields in Extra.
+func (f *FeeStats) UnmarshalJSON(data []byte) (err error) {
+	type alias FeeStats
+	f.Extra, err = decodeWithExtra(data, (*alias)(f))
+	return
+}
+
+// UnmarshalJSON decodes the Ledger, keeping its unknown fields in Extra.
+func (l *Ledger) UnmarshalJSON(data []byte) (err error) {
+	type alias Ledger
+	l.Extra, err = decodeWithExtra(data, (*alias)(l))
+	return
+}
+
+// UnmarshalJSON decodes the LiquidityPool, keeping its unknown fields in Extra.
+func (l *LiquidityPool) UnmarshalJSON(data []byte) (err error) {
+	type alias LiquidityPool
+	l.Extra, err = decodeWithExtra(data, (*alias)(l))
+	return
+}
+
+// UnmarshalJSON decodes the Offer, keeping its unknown fields in Extra.
+func (o *Offer) UnmarshalJSON(data []byte) (err error) {
+	type alias Offer
+	o.Extra, err = decodeWithExtra(data, (*alias)(o))
+	return
+}
+
+// UnmarshalJSON decodes the OrderBookSummary, keeping its unknown fields in Extra.
+func (o *OrderBookSummary) UnmarshalJSON(data []byte) (err error) {
+	type alias OrderBookSummary
+	o.Extra, err = decodeWithExtra(data, (*alias)(o))
+	return
+}
+
+// UnmarshalJSON decodes the Path, keeping its unknown fields in Extra.
+func (p *Path) UnmarshalJSON(data []byte) (err error) {
+	type alias Path
+	p.Extra, err = decodeWithExtra(data, (*alias)(p))
+	return
+}
+
+// UnmarshalJSON decodes the Root, keeping its unknown fields in Extra.
+func (r *Root) UnmarshalJSON(data []byte) (err error) {
+	type alias Root
+	r.Extra, err = decodeWithExtra(data, (*alias)(r))
+	return
+}
+
+// UnmarshalJSON decodes the Trade, keeping its unknown fields in Extra.
+func (t *Trade) UnmarshalJSON(data []byte) (err error) {
+	type alias Trade
+	t.Extra, err = decodeWithExtra(data, (*alias)(t))
+	return
+}
+
+// UnmarshalJSON decodes the TradeAggregation, keeping its unknown fields in Extra.
+func (t *TradeAggregation) UnmarshalJSON(data []byte) (err error) {
+	type alias TradeAggregation
+	t.Extra, err = decodeWithExtra(data, (*alias)(t))
+	return
+}
@@ -57,6 +57,10 @@ type Account struct {
 	NumSponsored         uint32            `json:"num_sponsored"`
 	Sponsor              string            `json:"sponsor,omitempty"`
 	PT                   string            `json:"paging_token"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -183,6 +187,10 @@ type AssetStat struct {
 	LiquidityPoolsAmount    string            `json:"liquidity_pools_amount"`
 	Balances                AssetStatBalances `json:"balances"`
 	Flags                   AccountFlags      `json:"flags"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -246,6 +254,10 @@ type Ledger struct {
 	MaxTxSetSize               int32     `json:"max_tx_set_size"`
 	ProtocolVersion            int32     `json:"protocol_version"`
 	HeaderXDR                  string    `json:"header_xdr"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 func (l Ledger) PagingToken() string {
@@ -270,6 +282,10 @@ type Offer struct {
 	LastModifiedLedger int32      `json:"last_modified_ledger"`
 	LastModifiedTime   *time.Time `json:"last_modified_time"`
 	Sponsor            string     `json:"sponsor,omitempty"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 func (o Offer) PagingToken() string {
@@ -282,6 +298,10 @@ type OrderBookSummary struct {
 	Asks    []PriceLevel `json:"asks"`
 	Selling Asset        `json:"base"`
 	Buying  Asset        `json:"counter"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // Path represents a single payment path.
@@ -295,6 +315,10 @@ type Path struct {
 	DestinationAssetIssuer string  `json:"destination_asset_issuer,omitempty"`
 	DestinationAmount      string  `json:"destination_amount"`
 	Path                   []Asset `json:"path"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // stub implementation to satisfy pageable interface
@@ -351,6 +375,10 @@ type Root struct {
 	NetworkPassphrase            string    `json:"network_passphrase"`
 	CurrentProtocolVersion       int32     `json:"current_protocol_version"`
 	CoreSupportedProtocolVersion int32     `json:"core_supported_protocol_version"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // Signer represents one of an account's signers.
@@ -430,6 +458,10 @@ type Trade struct {
 	CounterAssetIssuer     string     `json:"counter_asset_issuer,omitempty"`
 	BaseIsSeller           bool       `json:"base_is_seller"`
 	Price                  TradePrice `json:"price,omitempty"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -477,6 +509,10 @@ type TradeAggregation struct {
 	OpenR         TradePrice `json:"open_r"`
 	Close         string     `json:"close"`
 	CloseR        TradePrice `json:"close_r"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implementation for hal.Pageable. Not actually used
@@ -527,6 +563,10 @@ type Transaction struct {
 	ValidBefore        string              `json:"valid_before,omitempty"`
 	FeeBumpTransaction *FeeBumpTransaction `json:"fee_bump_transaction,omitempty"`
 	InnerTransaction   *InnerTransaction   `json:"inner_transaction,omitempty"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // FeeBumpTransaction contains information about a fee bump transaction
@@ -567,7 +607,8 @@ func (t Transaction) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements a custom unmarshaler for Transaction
-// which can handle a max_fee field which can be a string or int
+// which can handle a max_fee field which can be a string or int, and keeps
+// the unknown fields in Extra
 func (t *Transaction) UnmarshalJSON(data []byte) error {
 	type Alias Transaction // we define Alias to avoid infinite recursion when calling UnmarshalJSON()
 	v := &struct {
@@ -594,7 +635,9 @@ func (t *Transaction) UnmarshalJSON(data []byte) error {
 			return err
 		}
 	}
-	return nil
+	extra, err := UnknownFields(data, t)
+	t.Extra = base.NewExtra(extra)
+	return err
 }
 
 // PagingToken implementation for hal.Pageable
@@ -641,6 +684,10 @@ func MustKeyTypeFromAddress(address string) string {
 type AccountData struct {
 	Value   string `json:"value"`
 	Sponsor string `json:"sponsor,omitempty"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // AccountsPage returns a list of account records
@@ -717,6 +764,10 @@ type FeeStats struct {
 
 	FeeCharged FeeDistribution `json:"fee_charged"`
 	MaxFee     FeeDistribution `json:"max_fee"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // TransactionsPage contains records of transaction information returned by Horizon
@@ -757,6 +808,10 @@ type ClaimableBalance struct {
 	Claimants          []Claimant            `json:"claimants"`
 	Flags              ClaimableBalanceFlags `json:"flags"`
 	PT                 string                `json:"paging_token"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 type ClaimableBalances struct {
@@ -797,6 +852,10 @@ type LiquidityPool struct {
 	Reserves           []LiquidityPoolReserve `json:"reserves"`
 	LastModifiedLedger uint32                 `json:"last_modified_ledger"`
 	LastModifiedTime   *time.Time             `json:"last_modified_time"`
+
+	// Extra are the fields of the response unknown to the SDK, see
+	// UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -2,6 +2,7 @@ package horizon
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -238,3 +239,70 @@ func TestTradeAggregation_PagingToken(t *testing.T) {
 	ta := TradeAggregation{Timestamp: 64}
 	assert.Equal(t, "64", ta.PagingToken())
 }
+
+func TestUnknownFields(t *testing.T) {
+	data := `{
+		"_links": {"self": {"href": "https://horizon.stellar.org/ledgers/1"}},
+		"id": "63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99",
+		"SEQUENCE": 1,
+		"soroban_fee_write_1kb": 1000,
+		"new_resource": {"a": [1, 2]}
+	}`
+
+	var ledger Ledger
+	assert.NoError(t, json.Unmarshal([]byte(data), &ledger))
+	assert.Equal(t, int32(1), ledger.Sequence)
+	assert.Equal(t, "https://horizon.stellar.org/ledgers/1", ledger.Links.Self.Href)
+	assert.Equal(t, map[string]json.RawMessage{
+		"soroban_fee_write_1kb": json.RawMessage(`1000`),
+		"new_resource":          json.RawMessage(`{"a": [1, 2]}`),
+	}, ledger.Extra.Fields())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"sequence": 2}`), &ledger))
+	assert.Nil(t, ledger.Extra.Fields())
+
+	// the unknown fields do not make the resources incomparable
+	var a, b Ledger
+	assert.NoError(t, json.Unmarshal([]byte(`{"sequence": 2}`), &a))
+	assert.NoError(t, json.Unmarshal([]byte(`{"sequence": 2}`), &b))
+	assert.True(t, a == b)
+}
+
+func TestJSONFields(t *testing.T) {
+	fields := JSONFields(reflect.TypeOf(&AssetStat{}))
+	assert.Equal(t, reflect.TypeOf(AssetStat{}), fields["num_accounts"].Owner)
+	assert.True(t, fields["num_accounts"].Tagged)
+	// promoted from the embedded base.Asset
+	assert.Equal(t, "asset_code", fields["asset_code"].Name)
+	assert.NotEqual(t, reflect.TypeOf(AssetStat{}), fields["asset_code"].Owner)
+	assert.NotContains(t, fields, "Extra")
+
+	field, ok := MatchJSONField(fields, "NUM_ACCOUNTS")
+	assert.True(t, ok)
+	assert.Equal(t, "num_accounts", field.Name)
+	_, ok = MatchJSONField(fields, "unknown")
+	assert.False(t, ok)
+}
+
+func TestTransactionUnknownFields(t *testing.T) {
+	var tx Transaction
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"hash": "a1b2",
+		"memo_type": "none",
+		"max_fee": "200",
+		"preconditions": {"min_account_sequence": "5"}
+	}`), &tx))
+	assert.Equal(t, "a1b2", tx.Hash)
+	assert.Equal(t, int64(200), tx.MaxFee)
+	assert.Equal(t, map[string]json.RawMessage{
+		"preconditions": json.RawMessage(`{"min_account_sequence": "5"}`),
+	}, tx.Extra.Fields())
+
+	// the unknown fields of the records of pages are kept too
+	var page TransactionsPage
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"_embedded": {"records": [{"hash": "a1b2", "new_field": true}]}
+	}`), &page))
+	assert.Len(t, page.Embedded.Records, 1)
+	assert.Equal(t, json.RawMessage(`true`), page.Embedded.Records[0].Extra.Fields()["new_field"])
+}
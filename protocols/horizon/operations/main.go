@@ -3,6 +3,7 @@ package operations
 import (
 	"encoding/json"
 	"errors"
+	"reflect"
 	"time"
 
 	"github.com/stellar/go/protocols/horizon"
@@ -69,6 +70,10 @@ type Base struct {
 	TransactionHash string               `json:"transaction_hash"`
 	Transaction     *horizon.Transaction `json:"transaction,omitempty"`
 	Sponsor         string               `json:"sponsor,omitempty"`
+
+	// Extra are the fields of the response unknown to the SDK, set by
+	// UnmarshalOperation, see horizon.UnknownFields.
+	Extra base.Extra `json:"-"`
 }
 
 // PagingToken implements hal.Pageable
@@ -375,6 +380,11 @@ func (base Base) IsTransactionSuccessful() bool {
 	return base.TransactionSuccessful
 }
 
+// GetBase returns the fields shared by all the operation types.
+func (base Base) GetBase() Base {
+	return base
+}
+
 // OperationsPage is the json resource representing a page of operations.
 // OperationsPage.Record can contain various operation types.
 type OperationsPage struct {
@@ -569,5 +579,21 @@ func UnmarshalOperation(operationTypeID int32, dataString []byte) (ops Operation
 		err = errors.New("Invalid operation format, unable to unmarshal json response")
 	}
 
-	return
+	if err != nil {
+		return
+	}
+	return withExtra(ops, dataString)
+}
+
+// withExtra returns a copy of the operation with the fields of data unknown to
+// it in the Extra field of its base.
+func withExtra(v Operation, data []byte) (Operation, error) {
+	extra, err := horizon.UnknownFields(data, v)
+	if err != nil || extra == nil {
+		return v, err
+	}
+	value := reflect.New(reflect.TypeOf(v)).Elem()
+	value.Set(reflect.ValueOf(v))
+	value.FieldByName("Extra").Set(reflect.ValueOf(base.NewExtra(extra)))
+	return value.Interface().(Operation), nil
 }
@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -30,3 +31,23 @@ func TestUnmarshalOperationAllCovered(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, mistmatchErr, err)
 }
+
+func TestUnmarshalOperationUnknownFields(t *testing.T) {
+	op, err := UnmarshalOperation(int32(xdr.OperationTypeManageSellOffer), []byte(`{
+		"id": "1",
+		"type_i": 3,
+		"offer_id": "10",
+		"amount": "1.0000000",
+		"price": "2.0000000",
+		"new_field": "value"
+	}`))
+	assert.NoError(t, err)
+	offer := op.(ManageSellOffer)
+	assert.Equal(t, "1", offer.ID)
+	assert.Equal(t, int64(10), offer.OfferID)
+	assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`"value"`)}, offer.Extra.Fields())
+
+	op, err = UnmarshalOperation(int32(xdr.OperationTypeBumpSequence), []byte(`{"id": "2", "bump_to": "5"}`))
+	assert.NoError(t, err)
+	assert.Nil(t, op.(BumpSequence).Extra.Fields())
+}
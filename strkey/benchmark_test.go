@@ -21,3 +21,11 @@ func BenchmarkEncode_accountID(b *testing.B) {
 		_, _ = strkey.Encode(strkey.VersionByteAccountID, accountID)
 	}
 }
+
+func BenchmarkAppendEncode_accountID(b *testing.B) {
+	accountID := make([]byte, 32)
+	buf := make([]byte, 0, 56)
+	for i := 0; i < b.N; i++ {
+		buf, _ = strkey.AppendEncode(buf[:0], strkey.VersionByteAccountID, accountID)
+	}
+}
@@ -69,6 +69,17 @@ func TestEncode(t *testing.T) {
 			},
 			Expected: "XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
 		},
+		{
+			Name:        "Contract",
+			VersionByte: VersionByteContract,
+			Payload: []byte{
+				0x69, 0xa8, 0xc4, 0xcb, 0xb9, 0xf6, 0x4e, 0x8a,
+				0x07, 0x98, 0xf6, 0xe1, 0xac, 0x65, 0xd0, 0x6c,
+				0x31, 0x62, 0x92, 0x90, 0x56, 0xbc, 0xf4, 0xcd,
+				0xb7, 0xd3, 0x73, 0x8d, 0x18, 0x55, 0xf3, 0x63,
+			},
+			Expected: "CBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHO4X",
+		},
 	}
 
 	for _, kase := range cases {
@@ -93,3 +104,25 @@ func TestEncode(t *testing.T) {
 	})
 	assert.EqualError(t, err, "data exceeds maximum payload size for strkey")
 }
+
+func TestAppendEncode(t *testing.T) {
+	payload := make([]byte, 32)
+	payload[0] = 1
+	expected, err := Encode(VersionByteAccountID, payload)
+	assert.NoError(t, err)
+
+	dst, err := AppendEncode([]byte("account="), VersionByteAccountID, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "account="+expected, string(dst))
+
+	buf := make([]byte, 0, 56)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = AppendEncode(buf[:0], VersionByteAccountID, payload)
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.Equal(t, expected, string(buf))
+
+	dst, err = AppendEncode([]byte("x"), VersionByte(2), payload)
+	assert.Equal(t, ErrInvalidVersionByte, err)
+	assert.Equal(t, "x", string(dst))
+}
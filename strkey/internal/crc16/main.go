@@ -110,3 +110,19 @@ func Validate(data []byte, expected uint16) error {
 
 	return nil
 }
+
+// ConstantTimeChecksum returns the same checksum as Checksum, computed bit by
+// bit without table lookups or branches depending on the data, so that it can
+// be used on secret data.
+func ConstantTimeChecksum(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			// mask is 0xffff when the top bit is set and 0 otherwise
+			mask := -(crc >> 15)
+			crc = (crc << 1) ^ (0x1021 & mask)
+		}
+	}
+	return crc
+}
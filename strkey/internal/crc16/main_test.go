@@ -18,3 +18,14 @@ func TestValidate(t *testing.T) {
 	err = Validate([]byte{0x12, 0x34, 0x56, 0x78, 0x90}, 0x48e7)
 	assert.ErrorIs(t, err, ErrInvalidChecksum)
 }
+
+func TestConstantTimeChecksum(t *testing.T) {
+	result := ConstantTimeChecksum([]byte{0x12, 0x34, 0x56, 0x78, 0x90})
+	assert.Equal(t, uint16(0x48e6), result)
+
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = byte(i * 7)
+		assert.Equal(t, Checksum(data[:i]), ConstantTimeChecksum(data[:i]))
+	}
+}
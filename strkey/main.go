@@ -34,6 +34,10 @@ const (
 	//VersionByteHashX is the version byte used for encoded stellar hashX
 	//signer keys.
 	VersionByteHashX = 23 << 3 // Base32-encodes to 'X...'
+
+	//VersionByteContract is the version byte used for encoded contract
+	//addresses.
+	VersionByteContract = 2 << 3 // Base32-encodes to 'C...'
 )
 
 // maxPayloadSize is the maximum length of the payload for all versions.
@@ -126,15 +130,27 @@ func MustDecode(expected VersionByte, src string) []byte {
 // Encode encodes the provided data to a StrKey, using the provided version
 // byte.
 func Encode(version VersionByte, src []byte) (string, error) {
-	if err := checkValidVersionByte(version); err != nil {
+	encArr := [maxEncodedSize]byte{}
+	enc, err := AppendEncode(encArr[:0], version, src)
+	if err != nil {
 		return "", err
 	}
+	return string(enc), nil
+}
+
+// AppendEncode is like Encode but appends the encoded strkey to dst and
+// returns the extended buffer, so that strkeys can be rendered without
+// allocations into a reused buffer, e.g. by loggers and indexers.
+func AppendEncode(dst []byte, version VersionByte, src []byte) ([]byte, error) {
+	if err := checkValidVersionByte(version); err != nil {
+		return dst, err
+	}
 
 	payloadSize := len(src)
 
 	// check src does not exceed maximum payload size
 	if payloadSize > maxPayloadSize {
-		return "", fmt.Errorf("data exceeds maximum payload size for strkey")
+		return dst, fmt.Errorf("data exceeds maximum payload size for strkey")
 	}
 
 	// pack
@@ -150,12 +166,22 @@ func Encode(version VersionByte, src []byte) (string, error) {
 	binary.LittleEndian.PutUint16(raw[1+payloadSize:], crc)
 
 	// base32 encode
-	encArr := [maxEncodedSize]byte{}
 	encSize := encoding.EncodedLen(rawSize)
-	enc := encArr[:encSize]
+	dst, enc := grow(dst, encSize)
 	encoding.Encode(enc, raw)
+	return dst, nil
+}
 
-	return string(enc), nil
+// grow extends dst by n bytes, reallocating it if its capacity is too
+// small, and returns the extended buffer and its last n bytes.
+func grow(dst []byte, n int) ([]byte, []byte) {
+	if cap(dst)-len(dst) < n {
+		grown := make([]byte, len(dst), 2*cap(dst)+n)
+		copy(grown, dst)
+		dst = grown
+	}
+	dst = dst[:len(dst)+n]
+	return dst, dst[len(dst)-n:]
 }
 
 // MustEncode is like Encode, but panics on error
@@ -182,7 +208,7 @@ func Version(src string) (VersionByte, error) {
 // is not one of the defined valid version byte constants.
 func checkValidVersionByte(version VersionByte) error {
 	switch version {
-	case VersionByteAccountID, VersionByteMuxedAccount, VersionByteSeed, VersionByteHashTx, VersionByteHashX:
+	case VersionByteAccountID, VersionByteMuxedAccount, VersionByteSeed, VersionByteHashTx, VersionByteHashX, VersionByteContract:
 		return nil
 	default:
 		return ErrInvalidVersionByte
@@ -237,7 +263,7 @@ func decodeString(src string) ([]byte, error) {
 			return nil, errors.New("non-canonical strkey; unused bits should be set to 0")
 		}
 	}
-	n, err := base32.StdEncoding.WithPadding(base32.NoPadding).Decode(srcBytes, srcBytes)
+	n, err := encoding.Decode(srcBytes, srcBytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "base32 decode failed")
 	}
@@ -272,7 +298,7 @@ func IsValidEd25519SecretSeed(i interface{}) bool {
 		return false
 	}
 
-	_, err := Decode(VersionByteSeed, enc)
+	_, err := DecodeSeed([]byte(enc))
 
 	return err == nil
 }
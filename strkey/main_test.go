@@ -36,6 +36,11 @@ func TestVersion(t *testing.T) {
 			Address:             "XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
 			ExpectedVersionByte: VersionByteHashX,
 		},
+		{
+			Name:                "Contract",
+			Address:             "CBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHO4X",
+			ExpectedVersionByte: VersionByteContract,
+		},
 		{
 			Name:                "Other (0x60)",
 			Address:             "MBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
@@ -0,0 +1,85 @@
+package strkey
+
+import (
+	"encoding/binary"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// Key is a strkey decoded by Parse. Its version byte tells its kind.
+type Key struct {
+	// Version is the version byte of the strkey: VersionByteAccountID,
+	// VersionByteMuxedAccount, VersionByteSeed, VersionByteHashTx,
+	// VersionByteHashX or VersionByteContract.
+	Version VersionByte
+	// Raw is the 32 bytes ed25519 public key, seed, hash or contract id of
+	// the strkey.
+	// For a muxed account it is the public key of the underlying account.
+	Raw []byte
+	// MuxedID is the id of a muxed account, 0 for the other kinds.
+	MuxedID uint64
+}
+
+// Parse decodes a strkey of any kind, checking its checksum and the length
+// of its payload, so that callers do not have to call Version, Decode and
+// then split the payload of muxed accounts themselves.
+func Parse(address string) (Key, error) {
+	version, payload, err := DecodeAny(address)
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch version {
+	case VersionByteMuxedAccount:
+		if len(payload) != 40 {
+			return Key{}, errors.Errorf("invalid binary length: %d", len(payload))
+		}
+		return Key{
+			Version: version,
+			Raw:     payload[:32],
+			MuxedID: binary.BigEndian.Uint64(payload[32:]),
+		}, nil
+	default:
+		if len(payload) != 32 {
+			return Key{}, errors.Errorf("invalid binary length: %d", len(payload))
+		}
+		return Key{Version: version, Raw: payload}, nil
+	}
+}
+
+// IsPublicKey returns true if the key is an account (G...) or a muxed
+// account (M...), whose Raw is an ed25519 public key.
+func (k Key) IsPublicKey() bool {
+	return k.Version == VersionByteAccountID || k.Version == VersionByteMuxedAccount
+}
+
+// IsSignerKey returns true if the key can be the signer of an account: an
+// ed25519 public key (G...), a pre-authorized transaction (T...) or a hash
+// (X...).
+func (k Key) IsSignerKey() bool {
+	switch k.Version {
+	case VersionByteAccountID, VersionByteHashTx, VersionByteHashX:
+		return true
+	}
+	return false
+}
+
+// AccountID returns the account (G...) address of an account or of the
+// account underlying a muxed account.
+func (k Key) AccountID() (string, error) {
+	if !k.IsPublicKey() {
+		return "", errors.New("strkey is not an account or muxed account")
+	}
+	return Encode(VersionByteAccountID, k.Raw)
+}
+
+// Address encodes the key back to its strkey.
+func (k Key) Address() (string, error) {
+	if k.Version != VersionByteMuxedAccount {
+		return Encode(k.Version, k.Raw)
+	}
+	payload := make([]byte, len(k.Raw)+8)
+	copy(payload, k.Raw)
+	binary.BigEndian.PutUint64(payload[len(k.Raw):], k.MuxedID)
+	return Encode(k.Version, payload)
+}
@@ -0,0 +1,61 @@
+package strkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	accountID := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	cases := []struct {
+		Name      string
+		Address   string
+		Version   VersionByte
+		MuxedID   uint64
+		PublicKey bool
+		Signer    bool
+	}{
+		{"AccountID", accountID, VersionByteAccountID, 0, true, true},
+		{"MuxedAccount", "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK", VersionByteMuxedAccount, 9223372036854775808, true, false},
+		{"Seed", "SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKR", VersionByteSeed, 0, false, false},
+		{"HashTx", "TBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHXL7", VersionByteHashTx, 0, false, true},
+		{"HashX", "XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG", VersionByteHashX, 0, false, true},
+		{"Contract", "CA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUWDA", VersionByteContract, 0, false, false},
+	}
+
+	for _, kase := range cases {
+		t.Run(kase.Name, func(t *testing.T) {
+			key, err := Parse(kase.Address)
+			require.NoError(t, err)
+			assert.Equal(t, kase.Version, key.Version)
+			assert.Len(t, key.Raw, 32)
+			assert.Equal(t, kase.MuxedID, key.MuxedID)
+			assert.Equal(t, kase.PublicKey, key.IsPublicKey())
+			assert.Equal(t, kase.Signer, key.IsSignerKey())
+
+			address, err := key.Address()
+			require.NoError(t, err)
+			assert.Equal(t, kase.Address, address)
+
+			if kase.PublicKey {
+				underlying, err := key.AccountID()
+				require.NoError(t, err)
+				assert.Equal(t, accountID, underlying)
+			} else {
+				_, err = key.AccountID()
+				assert.EqualError(t, err, "strkey is not an account or muxed account")
+			}
+		})
+	}
+
+	_, err := Parse("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGA")
+	assert.Error(t, err)
+	_, err = Parse("GA7Q")
+	assert.Error(t, err)
+	short, err := Encode(VersionByteAccountID, []byte{1, 2, 3})
+	require.NoError(t, err)
+	_, err = Parse(short)
+	assert.EqualError(t, err, "invalid binary length: 3")
+}
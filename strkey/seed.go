@@ -0,0 +1,117 @@
+package strkey
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+
+	"github.com/stellar/go/strkey/internal/crc16"
+	"github.com/stellar/go/support/errors"
+)
+
+// rawSeedSize is the length of a seed strkey in its raw form: version byte,
+// ed25519 seed and checksum.
+const rawSeedSize = 1 + 32 + 2
+
+// EncodedSeedSize is the length of a base32 encoded seed strkey ('S...').
+const EncodedSeedSize = rawSeedSize * 8 / 5
+
+// DecodeSeed decodes an ed25519 seed strkey ('S...') into the raw seed. Unlike
+// Decode, the running time of DecodeSeed only depends on the length of src and
+// not on its contents, and it does not allocate: the only copy of the secret
+// is the returned array. The errors it returns only tell which check failed,
+// never where.
+func DecodeSeed(src []byte) (seed [32]byte, err error) {
+	if len(src) != EncodedSeedSize {
+		return seed, errors.Errorf("seed strkey is %d bytes long; expected %d", len(src), EncodedSeedSize)
+	}
+
+	var raw [rawSeedSize]byte
+	defer func() {
+		for i := range raw {
+			raw[i] = 0
+		}
+	}()
+
+	// decode 8 characters (40 bits) at a time into 5 bytes
+	valid := -1
+	for group := 0; group < EncodedSeedSize/8; group++ {
+		var bits uint64
+		for _, c := range src[group*8 : group*8+8] {
+			value, ok := decodeBase32Char(c)
+			valid &= ok
+			bits = bits<<5 | uint64(value)
+		}
+		for i := 4; i >= 0; i-- {
+			raw[group*5+i] = byte(bits)
+			bits >>= 8
+		}
+	}
+
+	versionOk := subtle.ConstantTimeByteEq(raw[0], byte(VersionByteSeed))
+	checksum := crc16.ConstantTimeChecksum(raw[:rawSeedSize-2])
+	checksumOk := subtle.ConstantTimeEq(
+		int32(checksum),
+		int32(binary.LittleEndian.Uint16(raw[rawSeedSize-2:])),
+	)
+
+	switch {
+	case valid == 0:
+		return seed, errors.New("base32 decode failed: illegal character")
+	case versionOk == 0:
+		return seed, ErrInvalidVersionByte
+	case checksumOk == 0:
+		return seed, crc16.ErrInvalidChecksum
+	}
+
+	copy(seed[:], raw[1:rawSeedSize-2])
+	return seed, nil
+}
+
+// EncodeSeed encodes the provided ed25519 seed to a strkey ('S...'). Like
+// DecodeSeed, it runs in constant time.
+func EncodeSeed(seed [32]byte) []byte {
+	var raw [rawSeedSize]byte
+	defer func() {
+		for i := range raw {
+			raw[i] = 0
+		}
+	}()
+	raw[0] = byte(VersionByteSeed)
+	copy(raw[1:], seed[:])
+	binary.LittleEndian.PutUint16(raw[rawSeedSize-2:], crc16.ConstantTimeChecksum(raw[:rawSeedSize-2]))
+
+	// encode 5 bytes (40 bits) at a time into 8 characters
+	dst := make([]byte, EncodedSeedSize)
+	for group := 0; group < rawSeedSize/5; group++ {
+		var bits uint64
+		for _, b := range raw[group*5 : group*5+5] {
+			bits = bits<<8 | uint64(b)
+		}
+		for i := 7; i >= 0; i-- {
+			dst[group*8+i] = encodeBase32Char(byte(bits & 0x1f))
+			bits >>= 5
+		}
+	}
+	return dst
+}
+
+// decodeBase32Char returns the 5-bit value of a character of the base32
+// alphabet, and ok = -1 if the character belongs to the alphabet or 0
+// otherwise, without branching on the character.
+func decodeBase32Char(c byte) (value byte, ok int) {
+	x := int(c)
+	// the masks are -1 when the character is in the range and 0 otherwise
+	isLetter := ((int('A') - 1 - x) & (x - int('Z') - 1)) >> 8
+	isDigit := ((int('2') - 1 - x) & (x - int('7') - 1)) >> 8
+	value = byte((isLetter & (x - 'A')) | (isDigit & (x - '2' + 26)))
+	return value, isLetter | isDigit
+}
+
+// encodeBase32Char returns the character of the base32 alphabet for a 5-bit
+// value, without branching on the value.
+func encodeBase32Char(value byte) byte {
+	x := int(value)
+	// isLetter is -1 when the value maps to 'A'...'Z' and 0 otherwise
+	isLetter := (x - 26) >> 8
+	return byte(x + 'A' + (^isLetter & ('2' - 'A' - 26)))
+}
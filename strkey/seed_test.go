@@ -0,0 +1,67 @@
+package strkey
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stellar/go/strkey/internal/crc16"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSeed(t *testing.T) {
+	seed := "SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKR"
+	expected := MustDecode(VersionByteSeed, seed)
+
+	raw, err := DecodeSeed([]byte(seed))
+	require.NoError(t, err)
+	assert.Equal(t, expected, raw[:])
+
+	// corrupted checksum
+	_, err = DecodeSeed([]byte("SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKS"))
+	assert.ErrorIs(t, err, crc16.ErrInvalidChecksum)
+
+	// an address
+	_, err = DecodeSeed([]byte("GA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQHES5"))
+	assert.ErrorIs(t, err, ErrInvalidVersionByte)
+
+	// lowercase and padding characters are not part of the alphabet
+	_, err = DecodeSeed([]byte("sBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKR"))
+	assert.EqualError(t, err, "base32 decode failed: illegal character")
+	_, err = DecodeSeed([]byte("SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOK="))
+	assert.EqualError(t, err, "base32 decode failed: illegal character")
+
+	_, err = DecodeSeed([]byte("SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHO"))
+	assert.EqualError(t, err, "seed strkey is 54 bytes long; expected 56")
+	_, err = DecodeSeed(nil)
+	assert.Error(t, err)
+}
+
+func TestEncodeSeed(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var seed [32]byte
+		_, err := rand.Read(seed[:])
+		require.NoError(t, err)
+
+		encoded := EncodeSeed(seed)
+		assert.Equal(t, MustEncode(VersionByteSeed, seed[:]), string(encoded))
+
+		decoded, err := DecodeSeed(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, seed, decoded)
+	}
+}
+
+func TestDecodeBase32Char(t *testing.T) {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	for c := 0; c < 256; c++ {
+		value, ok := decodeBase32Char(byte(c))
+		if decodingTable[c] == 0xff {
+			assert.Equal(t, 0, ok, "character %d", c)
+			continue
+		}
+		assert.Equal(t, -1, ok, "character %d", c)
+		assert.Equal(t, decodingTable[c], value, "character %d", c)
+		assert.Equal(t, alphabet[value], encodeBase32Char(value))
+	}
+}
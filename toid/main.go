@@ -1,8 +1,14 @@
+// Package toid implements the total order IDs (SEP-35) Horizon uses as the
+// IDs and paging tokens of ledgers, transactions and operations: New builds
+// one from its components, Parse and ParseString take it apart and
+// LedgerRangeInclusive returns the IDs bounding a range of ledgers.
 package toid
 
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 )
 
 //
@@ -102,6 +108,10 @@ func LedgerRangeInclusive(from, to int32) (int64, int64, error) {
 		return 0, 0, errors.New("Invalid range: from or to negative")
 	}
 
+	if to == math.MaxInt32 {
+		return 0, 0, errors.New("Invalid range: to overflows")
+	}
+
 	var toidFrom, toidTo int64
 	if from == 1 {
 		toidFrom = 0
@@ -135,19 +145,59 @@ func New(ledger int32, tx int32, op int32) *ID {
 	}
 }
 
-// ToInt64 converts this struct back into an int64
-func (id ID) ToInt64() (result int64) {
+// NextLedger returns the id of the start of the next ledger, or an error if
+// the ledger sequence would overflow.
+func (id ID) NextLedger() (ID, error) {
+	if id.LedgerSequence == math.MaxInt32 {
+		return ID{}, errors.New("ledger sequence overflow")
+	}
+	return ID{LedgerSequence: id.LedgerSequence + 1}, nil
+}
+
+// NextTransaction returns the id of the next transaction of the same ledger,
+// or an error if the transaction order would overflow. Unlike
+// IncOperationOrder it never rolls over to the next ledger.
+func (id ID) NextTransaction() (ID, error) {
+	if id.TransactionOrder >= TransactionMask {
+		return ID{}, errors.New("transaction order overflow")
+	}
+	return ID{LedgerSequence: id.LedgerSequence, TransactionOrder: id.TransactionOrder + 1}, nil
+}
+
+// NextOperation returns the id of the next operation of the same
+// transaction, or an error if the operation order would overflow. Unlike
+// IncOperationOrder it never rolls over to the next ledger.
+func (id ID) NextOperation() (ID, error) {
+	if id.OperationOrder >= OperationMask {
+		return ID{}, errors.New("operation order overflow")
+	}
+	next := id
+	next.OperationOrder++
+	return next, nil
+}
 
+// Validate returns an error if a component of the id is out of range, in
+// which case ToInt64 panics.
+func (id ID) Validate() error {
 	if id.LedgerSequence < 0 {
-		panic("invalid ledger sequence")
+		return errors.New("invalid ledger sequence")
 	}
 
-	if id.TransactionOrder > TransactionMask {
-		panic("transaction order overflow")
+	if id.TransactionOrder < 0 || id.TransactionOrder > TransactionMask {
+		return errors.New("transaction order overflow")
 	}
 
-	if id.OperationOrder > OperationMask {
-		panic("operation order overflow")
+	if id.OperationOrder < 0 || id.OperationOrder > OperationMask {
+		return errors.New("operation order overflow")
+	}
+	return nil
+}
+
+// ToInt64 converts this struct back into an int64. It panics if the id is
+// not valid, see Validate.
+func (id ID) ToInt64() (result int64) {
+	if err := id.Validate(); err != nil {
+		panic(err.Error())
 	}
 
 	result = result | ((int64(id.LedgerSequence) & LedgerMask) << LedgerShift)
@@ -169,3 +219,16 @@ func Parse(id int64) (result ID) {
 
 	return
 }
+
+// ParseString parses the decimal representation of an id, e.g. a Horizon
+// paging token.
+func ParseString(id string) (ID, error) {
+	parsed, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return ID{}, fmt.Errorf("invalid total order id %q: %v", id, err)
+	}
+	if parsed < 0 {
+		return ID{}, fmt.Errorf("invalid total order id %q: negative", id)
+	}
+	return Parse(parsed), nil
+}
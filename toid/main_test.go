@@ -179,3 +179,56 @@ func TestLedgerRangeInclusive(t *testing.T) {
 	_, _, err = LedgerRangeInclusive(-3, -5)
 	assert.Error(t, err)
 }
+
+func TestLedgerRangeInclusiveOverflow(t *testing.T) {
+	_, _, err := LedgerRangeInclusive(1, math.MaxInt32)
+	assert.EqualError(t, err, "Invalid range: to overflows")
+
+	_, to, err := LedgerRangeInclusive(1, math.MaxInt32-1)
+	assert.NoError(t, err)
+	assert.Equal(t, ID{math.MaxInt32, 0, 0}, Parse(to))
+}
+
+func TestParseString(t *testing.T) {
+	id, err := ParseString("12884910080")
+	assert.NoError(t, err)
+	assert.Equal(t, ID{3, 2, 0}, id)
+	assert.Equal(t, "12884910080", id.String())
+
+	_, err = ParseString("-1")
+	assert.EqualError(t, err, `invalid total order id "-1": negative`)
+
+	_, err = ParseString("now")
+	assert.EqualError(t, err, `invalid total order id "now": strconv.ParseInt: parsing "now": invalid syntax`)
+}
+
+func TestID_Validate(t *testing.T) {
+	assert.NoError(t, ID{math.MaxInt32, TransactionMask, OperationMask}.Validate())
+	assert.EqualError(t, ID{-1, 0, 0}.Validate(), "invalid ledger sequence")
+	assert.EqualError(t, ID{0, TransactionMask + 1, 0}.Validate(), "transaction order overflow")
+	assert.EqualError(t, ID{0, -1, 0}.Validate(), "transaction order overflow")
+	assert.EqualError(t, ID{0, 0, OperationMask + 1}.Validate(), "operation order overflow")
+	assert.EqualError(t, ID{0, 0, -1}.Validate(), "operation order overflow")
+}
+
+func TestID_Next(t *testing.T) {
+	id := ID{2, 3, 4}
+
+	next, err := id.NextLedger()
+	assert.NoError(t, err)
+	assert.Equal(t, ID{3, 0, 0}, next)
+	_, err = ID{math.MaxInt32, 0, 0}.NextLedger()
+	assert.EqualError(t, err, "ledger sequence overflow")
+
+	next, err = id.NextTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, ID{2, 4, 0}, next)
+	_, err = ID{2, TransactionMask, 0}.NextTransaction()
+	assert.EqualError(t, err, "transaction order overflow")
+
+	next, err = id.NextOperation()
+	assert.NoError(t, err)
+	assert.Equal(t, ID{2, 3, 5}, next)
+	_, err = ID{2, 3, OperationMask}.NextOperation()
+	assert.EqualError(t, err, "operation order overflow")
+}
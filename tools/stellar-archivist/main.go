@@ -163,6 +163,75 @@ func repair(src string, dst string, opts *Options) {
 	}
 }
 
+func verifyRepair(dst string, alternates []string, opts *Options) {
+	dstArch := historyarchive.MustConnect(dst, opts.ConnectOpts)
+	alternateArchs := make([]*historyarchive.Archive, len(alternates))
+	for i, alternate := range alternates {
+		alternateArchs[i] = historyarchive.MustConnect(alternate, opts.ConnectOpts)
+	}
+	opts.SetRange(dstArch, nil)
+	log.Printf("verifying and repairing %v from %v\n", dst, alternates)
+	report, e := historyarchive.VerifyAndRepair(dstArch, alternateArchs, &opts.CommandOpts)
+	for _, object := range report.Corrupt {
+		source := "none"
+		if object.Source >= 0 {
+			source = alternates[object.Source]
+		}
+		log.WithFields(log.Fields{
+			"path":     object.Path,
+			"error":    object.Error,
+			"source":   source,
+			"repaired": object.Repaired,
+		}).Warn("Corrupt object")
+	}
+	if e != nil {
+		log.Fatal(e)
+	}
+}
+
+func indexTransactions(a string, output string, opts *Options) {
+	arch := historyarchive.MustConnect(a, opts.ConnectOpts)
+	opts.SetRange(arch, nil)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err = historyarchive.BuildTransactionIndex(arch, &opts.CommandOpts, file); err != nil {
+		file.Close()
+		log.Fatal(err)
+	}
+	if err = file.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func lookupTransactions(indexPath string, hashes []string) {
+	file, err := os.Open(indexPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	index, err := historyarchive.OpenTransactionIndex(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, hash := range hashes {
+		h, err := historyarchive.DecodeHash(hash)
+		if err != nil {
+			log.Fatal(errors.Wrapf(err, "invalid transaction hash %s", hash))
+		}
+		ledger, ok, err := index.Lookup(h)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if ok {
+			fmt.Printf("%s %d\n", hash, ledger)
+		} else {
+			fmt.Printf("%s not found\n", hash)
+		}
+	}
+}
+
 func main() {
 
 	var opts Options
@@ -325,6 +394,39 @@ func main() {
 		},
 	})
 
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "verify-repair",
+		Run: func(cmd *cobra.Command, args []string) {
+			opts.SetupLogging()
+			opts.MaybeProfile()
+			if len(args) < 2 {
+				log.Fatal("require a destination and at least 1 alternate archive")
+			}
+			verifyRepair(args[0], args[1:], &opts)
+		},
+	})
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "index-transactions",
+		Run: func(cmd *cobra.Command, args []string) {
+			opts.SetupLogging()
+			opts.MaybeProfile()
+			src, dst := srcDst(args)
+			indexTransactions(src, dst, &opts)
+		},
+	})
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "lookup-transaction",
+		Run: func(cmd *cobra.Command, args []string) {
+			opts.SetupLogging()
+			if len(args) < 2 {
+				log.Fatal("require an index file and at least 1 transaction hash")
+			}
+			lookupTransactions(args[0], args[1:])
+		},
+	})
+
 	rootCmd.AddCommand(&cobra.Command{
 		Use: "dumpxdr",
 		Run: func(cmd *cobra.Command, args []string) {
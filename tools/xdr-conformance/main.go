@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stellar/go/xdr/xdrtest"
+)
+
+var (
+	addr string
+	typ  string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "xdr-conformance",
+	Short: "xdr-conformance canonicalizes XDR with the Go SDK to check the encoders of other languages",
+	// errors are about the input, not the usage
+	SilenceUsage: true,
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "serve the canonicalization over HTTP (POST / {\"type\": ..., \"xdr\": ...} and GET /types)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Fprintf(os.Stderr, "listening on %s\n", addr)
+		return http.ListenAndServe(addr, xdrtest.Handler{})
+	},
+}
+
+var canonicalizeCmd = &cobra.Command{
+	Use:   "canonicalize [base64-encoded XDR object...]",
+	Short: "print the canonical encoding and the JSON rendering of base64 XDR objects, read from stdin if there are no arguments",
+	RunE:  canonicalize,
+}
+
+var typesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "print the supported XDR types",
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, typeName := range xdr.TypeNames() {
+			fmt.Println(typeName)
+		}
+	},
+}
+
+func main() {
+	serveCmd.Flags().StringVar(&addr, "addr", "localhost:8000", "address to listen on")
+	canonicalizeCmd.Flags().StringVarP(&typ, "type", "t", "TransactionEnvelope", "xdr type, see the types command")
+	rootCmd.AddCommand(serveCmd, canonicalizeCmd, typesCmd)
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// canonicalize prints one JSON line per object, so that the output of many
+// objects read from stdin can be compared line by line.
+func canonicalize(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(nil, 16*1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				args = append(args, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return errors.Wrap(err, "error reading stdin")
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for i, arg := range args {
+		data, err := base64.StdEncoding.DecodeString(arg)
+		if err != nil {
+			return errors.Wrapf(err, "invalid base64 in object %d", i)
+		}
+		canonical, err := xdrtest.Canonicalize(typ, data)
+		if err != nil {
+			return errors.Wrapf(err, "error canonicalizing object %d", i)
+		}
+		if err := encoder.Encode(canonical); err != nil {
+			return err
+		}
+	}
+	return nil
+}
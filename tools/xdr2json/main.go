@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+var (
+	typ       string
+	indent    bool
+	listTypes bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "xdr2json [base64-encoded XDR object...]",
+	Short: "xdr2json prints base64 XDR objects as JSON, read from stdin if there are no arguments",
+	RunE:  run,
+	// errors are about the input, not the usage
+	SilenceUsage: true,
+}
+
+func main() {
+	rootCmd.Flags().StringVarP(&typ, "type", "t", "TransactionEnvelope", "xdr type, see --list-types")
+	rootCmd.Flags().BoolVarP(&indent, "indent", "i", false, "indent the JSON output")
+	rootCmd.Flags().BoolVar(&listTypes, "list-types", false, "print the supported XDR types")
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// run prints one JSON document per object, on a single line unless indent is
+// set, so that the output of many objects read from stdin can be processed
+// line by line.
+func run(cmd *cobra.Command, args []string) error {
+	if listTypes {
+		for _, typeName := range xdr.TypeNames() {
+			fmt.Println(typeName)
+		}
+		return nil
+	}
+
+	if len(args) == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(nil, 16*1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				args = append(args, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return errors.Wrap(err, "error reading stdin")
+		}
+	}
+
+	for i, arg := range args {
+		data, err := base64.StdEncoding.DecodeString(arg)
+		if err != nil {
+			return errors.Wrapf(err, "invalid base64 in object %d", i)
+		}
+		rendered, err := xdr.ToJSON(typ, data)
+		if err != nil {
+			return errors.Wrapf(err, "error converting object %d", i)
+		}
+		if indent {
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, rendered, "", "  "); err != nil {
+				return err
+			}
+			rendered = buf.Bytes()
+		}
+		fmt.Println(string(rendered))
+	}
+	return nil
+}
@@ -48,9 +48,9 @@ func (am *AccountMerge) FromXDR(xdrOp xdr.Operation) error {
 // invalid. Otherwise, it returns nil.
 func (am *AccountMerge) Validate() error {
 	var err error
-	_, err = xdr.AddressToMuxedAccount(am.Destination)
+	err = validateStellarMuxedAddress(am.Destination)
 	if err != nil {
-		return NewValidationError("Destination", err.Error())
+		return fieldValidationError("Destination", err)
 	}
 	return nil
 }
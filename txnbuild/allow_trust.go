@@ -100,12 +100,12 @@ func (at *AllowTrust) FromXDR(xdrOp xdr.Operation) error {
 func (at *AllowTrust) Validate() error {
 	err := validateStellarPublicKey(at.Trustor)
 	if err != nil {
-		return NewValidationError("Trustor", err.Error())
+		return fieldValidationError("Trustor", err)
 	}
 
 	err = validateAssetCode(at.Type)
 	if err != nil {
-		return NewValidationError("Type", err.Error())
+		return fieldValidationError("Type", err)
 	}
 	return nil
 }
@@ -0,0 +1,53 @@
+package txnbuild
+
+import "github.com/stellar/go/support/errors"
+
+// Annotations are opaque labels, such as correlation ids, attached to a
+// transaction or to its operations when it is built. They are not part of the
+// transaction XDR, so they do not survive serialization, but clients
+// submitting the transaction report them (see horizonclient.SubmitHook) to
+// trace payments end to end.
+type Annotations map[string]string
+
+// clone returns a copy of the annotations, or nil when there are none.
+func (a Annotations) clone() Annotations {
+	if len(a) == 0 {
+		return nil
+	}
+	c := make(Annotations, len(a))
+	for key, value := range a {
+		c[key] = value
+	}
+	return c
+}
+
+// merge returns a copy of the annotations overridden by the given ones.
+func (a Annotations) merge(overrides Annotations) Annotations {
+	merged := a.clone()
+	if merged == nil && len(overrides) > 0 {
+		merged = make(Annotations, len(overrides))
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// cloneOperationAnnotations copies the annotations of the operations of a
+// transaction with the given number of operations, ensuring they refer to
+// existing operations.
+func cloneOperationAnnotations(annotations map[int]Annotations, numOperations int) (map[int]Annotations, error) {
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+	c := make(map[int]Annotations, len(annotations))
+	for index, a := range annotations {
+		if index < 0 || index >= numOperations {
+			return nil, errors.Errorf("annotations refer to operation %d but the transaction has %d operations", index, numOperations)
+		}
+		if a = a.clone(); a != nil {
+			c[index] = a
+		}
+	}
+	return c, nil
+}
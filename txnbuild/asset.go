@@ -118,7 +118,8 @@ func (ca CreditAsset) GetType() (AssetType, error) {
 	case len(ca.Code) >= 5 && len(ca.Code) <= 12:
 		return AssetTypeCreditAlphanum12, nil
 	default:
-		return AssetTypeCreditAlphanum4, errors.New("asset code length must be between 1 and 12 characters")
+		return AssetTypeCreditAlphanum4, newCodedError(CodeAssetCodeInvalid, map[string]string{"code": ca.Code},
+			"asset code length must be between 1 and 12 characters")
 	}
 }
 
@@ -48,7 +48,7 @@ func (bs *BeginSponsoringFutureReserves) FromXDR(xdrOp xdr.Operation) error {
 func (bs *BeginSponsoringFutureReserves) Validate() error {
 	err := validateStellarPublicKey(bs.SponsoredID)
 	if err != nil {
-		return NewValidationError("SponsoredID", err.Error())
+		return fieldValidationError("SponsoredID", err)
 	}
 
 	return nil
@@ -42,7 +42,7 @@ func (bs *BumpSequence) FromXDR(xdrOp xdr.Operation) error {
 func (bs *BumpSequence) Validate() error {
 	err := validateAmount(bs.BumpTo)
 	if err != nil {
-		return NewValidationError("BumpTo", err.Error())
+		return fieldValidationError("BumpTo", err)
 	}
 	return nil
 }
@@ -29,6 +29,22 @@ func RemoveTrustlineOp(issuedAsset ChangeTrustAsset) ChangeTrust {
 	}
 }
 
+// LiquidityPoolShareTrustlineOp returns a ChangeTrust operation creating, or
+// updating, the trustline to the shares of the constant product liquidity
+// pool of the two assets, given in any order, with the given limit, e.g.
+// MaxTrustlineLimit. The account must already trust the credit assets of the
+// pool.
+func LiquidityPoolShareTrustlineOp(a, b Asset, limit string) (ChangeTrust, error) {
+	line, err := NewLiquidityPoolShareChangeTrustAsset(a, b)
+	if err != nil {
+		return ChangeTrust{}, err
+	}
+	return ChangeTrust{
+		Line:  line,
+		Limit: limit,
+	}, nil
+}
+
 // BuildXDR for ChangeTrust returns a fully configured XDR Operation.
 func (ct *ChangeTrust) BuildXDR() (xdr.Operation, error) {
 	if ct.Line.IsNative() {
@@ -86,13 +102,13 @@ func (ct *ChangeTrust) Validate() error {
 	if ct.Limit != "" {
 		err := validateAmount(ct.Limit)
 		if err != nil {
-			return NewValidationError("Limit", err.Error())
+			return fieldValidationError("Limit", err)
 		}
 	}
 
 	err := validateChangeTrustAsset(ct.Line)
 	if err != nil {
-		return NewValidationError("Line", err.Error())
+		return fieldValidationError("Line", err)
 	}
 	return nil
 }
@@ -20,6 +20,17 @@ type LiquidityPoolShareChangeTrustAsset struct {
 	LiquidityPoolParameters LiquidityPoolParameters
 }
 
+// NewLiquidityPoolShareChangeTrustAsset returns the share asset of the constant product liquidity pool
+// of the two given assets, using the default fee (LiquidityPoolFeeV18). The assets can be given in any
+// order, they are sorted as required by the protocol.
+func NewLiquidityPoolShareChangeTrustAsset(a, b Asset) (LiquidityPoolShareChangeTrustAsset, error) {
+	params, err := NewLiquidityPoolParameters(a, b)
+	if err != nil {
+		return LiquidityPoolShareChangeTrustAsset{}, err
+	}
+	return LiquidityPoolShareChangeTrustAsset{LiquidityPoolParameters: params}, nil
+}
+
 // GetType for LiquidityPoolShareChangeTrustAsset returns the enum type of the asset, based on its code length.
 func (lpsa LiquidityPoolShareChangeTrustAsset) GetType() (AssetType, error) {
 	return AssetTypePoolShare, nil
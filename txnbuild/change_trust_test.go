@@ -95,3 +95,95 @@ func TestChangeTrustRoundtrip(t *testing.T) {
 	}
 	testOperationsMarshallingRoundtrip(t, []Operation{&changeTrust}, true)
 }
+
+func TestChangeTrustLiquidityPoolShareRoundtrip(t *testing.T) {
+	issuer := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	line, err := NewLiquidityPoolShareChangeTrustAsset(CreditAsset{"ABCD", issuer}, NativeAsset{})
+	assert.NoError(t, err)
+	// Assets are sorted
+	assert.Equal(t, NativeAsset{}, line.LiquidityPoolParameters.AssetA)
+	assert.Equal(t, CreditAsset{"ABCD", issuer}, line.LiquidityPoolParameters.AssetB)
+	assert.Equal(t, int32(LiquidityPoolFeeV18), line.LiquidityPoolParameters.Fee)
+
+	changeTrust := ChangeTrust{
+		SourceAccount: issuer,
+		Line:          line,
+		Limit:         "1.0000000",
+	}
+	testOperationsMarshallingRoundtrip(t, []Operation{&changeTrust}, false)
+}
+
+func TestNewLiquidityPoolShareChangeTrustAssetSameAssets(t *testing.T) {
+	_, err := NewLiquidityPoolShareChangeTrustAsset(NativeAsset{}, NativeAsset{})
+	assert.EqualError(t, err, "liquidity pool assets must be different")
+}
+
+func TestChangeTrustValidateLiquidityPoolShare(t *testing.T) {
+	kp0 := newKeypair0()
+	txSourceAccount := NewSimpleAccount(kp0.Address(), int64(9605939170639898))
+	assetA := NativeAsset{}
+	assetB := CreditAsset{"ABCD", kp0.Address()}
+
+	for _, testCase := range []struct {
+		name     string
+		params   LiquidityPoolParameters
+		expected string
+	}{
+		{
+			"unsorted assets",
+			LiquidityPoolParameters{AssetA: assetB, AssetB: assetA, Fee: LiquidityPoolFeeV18},
+			"Field: Line, Error: liquidity pool assets must be in lexicographic order (AssetA < AssetB)",
+		},
+		{
+			"invalid fee",
+			LiquidityPoolParameters{AssetA: assetA, AssetB: assetB, Fee: 1},
+			"Field: Line, Error: liquidity pool fee must be 30",
+		},
+		{
+			"missing asset",
+			LiquidityPoolParameters{AssetA: assetA, Fee: LiquidityPoolFeeV18},
+			"Field: Line, Error: liquidity pool asset B: asset is undefined",
+		},
+		{
+			"invalid asset",
+			LiquidityPoolParameters{AssetA: assetA, AssetB: CreditAsset{"ABCD", "invalid"}, Fee: LiquidityPoolFeeV18},
+			"Field: Line, Error: liquidity pool asset B: asset issuer: invalid is not a valid stellar public key",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			changeTrust := ChangeTrust{
+				Line: LiquidityPoolShareChangeTrustAsset{LiquidityPoolParameters: testCase.params},
+			}
+			_, err := NewTransaction(
+				TransactionParams{
+					SourceAccount:        &txSourceAccount,
+					IncrementSequenceNum: true,
+					Operations:           []Operation{&changeTrust},
+					BaseFee:              MinBaseFee,
+					Timebounds:           NewInfiniteTimeout(),
+				},
+			)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLiquidityPoolShareTrustlineOp(t *testing.T) {
+	issuer := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	op, err := LiquidityPoolShareTrustlineOp(CreditAsset{"ABCD", issuer}, NativeAsset{}, MaxTrustlineLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeTrust{
+		Line: LiquidityPoolShareChangeTrustAsset{LiquidityPoolParameters: LiquidityPoolParameters{
+			AssetA: NativeAsset{},
+			AssetB: CreditAsset{"ABCD", issuer},
+			Fee:    LiquidityPoolFeeV18,
+		}},
+		Limit: MaxTrustlineLimit,
+	}, op)
+	testOperationsMarshallingRoundtrip(t, []Operation{&op}, false)
+
+	_, err = LiquidityPoolShareTrustlineOp(NativeAsset{}, NativeAsset{}, MaxTrustlineLimit)
+	assert.EqualError(t, err, "liquidity pool assets must be different")
+}
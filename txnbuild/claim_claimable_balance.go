@@ -59,7 +59,7 @@ func (cb *ClaimClaimableBalance) Validate() error {
 	var xdrBalanceID xdr.ClaimableBalanceId
 	err := xdr.SafeUnmarshalHex(cb.BalanceID, &xdrBalanceID)
 	if err != nil {
-		return NewValidationError("BalanceID", err.Error())
+		return fieldValidationError("BalanceID", err)
 	}
 
 	return nil
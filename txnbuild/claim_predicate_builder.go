@@ -0,0 +1,83 @@
+package txnbuild
+
+import (
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ClaimPredicateBuilder builds the predicate of a Claimant fluently, e.g.
+//
+//	predicate, err := txnbuild.BeforeRelativeTime(24 * time.Hour).
+//	    Or(txnbuild.BeforeAbsoluteTime(deadline).Not()).
+//	    Build()
+//
+// The builders are immutable: combining them returns new builders. Build
+// validates the predicate as stellar-core does (see xdr.ClaimPredicate
+// Validate), so that invalid predicates are rejected before they are
+// submitted.
+type ClaimPredicateBuilder struct {
+	predicate xdr.ClaimPredicate
+}
+
+// Unconditional returns the builder of a predicate always fulfilled.
+func Unconditional() ClaimPredicateBuilder {
+	return ClaimPredicateBuilder{predicate: UnconditionalPredicate}
+}
+
+// BeforeAbsoluteTime returns the builder of a predicate fulfilled while the
+// close time of the ledger is before t. It is truncated to the second.
+func BeforeAbsoluteTime(t time.Time) ClaimPredicateBuilder {
+	return ClaimPredicateBuilder{predicate: BeforeAbsoluteTimePredicate(t.Unix())}
+}
+
+// BeforeRelativeTime returns the builder of a predicate fulfilled during d
+// after the close time of the ledger creating the claimable balance. It is
+// truncated to the second.
+func BeforeRelativeTime(d time.Duration) ClaimPredicateBuilder {
+	return ClaimPredicateBuilder{predicate: BeforeRelativeTimePredicate(int64(d / time.Second))}
+}
+
+// And returns the builder of a predicate fulfilled when both b and other are.
+func (b ClaimPredicateBuilder) And(other ClaimPredicateBuilder) ClaimPredicateBuilder {
+	return ClaimPredicateBuilder{predicate: AndPredicate(b.predicate, other.predicate)}
+}
+
+// Or returns the builder of a predicate fulfilled when b or other is.
+func (b ClaimPredicateBuilder) Or(other ClaimPredicateBuilder) ClaimPredicateBuilder {
+	return ClaimPredicateBuilder{predicate: OrPredicate(b.predicate, other.predicate)}
+}
+
+// Not returns the builder of a predicate fulfilled when b is not.
+func (b ClaimPredicateBuilder) Not() ClaimPredicateBuilder {
+	return ClaimPredicateBuilder{predicate: NotPredicate(b.predicate)}
+}
+
+// Build returns the predicate, or an error if stellar-core would reject it,
+// e.g. because it is nested deeper than xdr.MaxClaimPredicateDepth.
+func (b ClaimPredicateBuilder) Build() (xdr.ClaimPredicate, error) {
+	if err := b.predicate.Validate(); err != nil {
+		return xdr.ClaimPredicate{}, errors.Wrap(err, "invalid predicate")
+	}
+	return b.predicate, nil
+}
+
+// Claimant returns the Claimant of destination with the predicate.
+func (b ClaimPredicateBuilder) Claimant(destination string) (Claimant, error) {
+	predicate, err := b.Build()
+	if err != nil {
+		return Claimant{}, err
+	}
+	return NewClaimant(destination, &predicate), nil
+}
+
+// Evaluate returns true if the predicate is fulfilled by a ledger closed at
+// the given time. The relative times are relative to createdAt, the close
+// time of the ledger creating the claimable balance.
+func (b ClaimPredicateBuilder) Evaluate(createdAt, at time.Time) (bool, error) {
+	if _, err := b.Build(); err != nil {
+		return false, err
+	}
+	return b.predicate.Absolute(createdAt).Evaluate(at)
+}
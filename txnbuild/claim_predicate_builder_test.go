@@ -0,0 +1,65 @@
+package txnbuild
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/xdr"
+)
+
+func TestClaimPredicateBuilder(t *testing.T) {
+	deadline := time.Unix(1600000000, 0)
+	predicate, err := BeforeRelativeTime(24 * time.Hour).
+		Or(BeforeAbsoluteTime(deadline).Not()).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, OrPredicate(
+		BeforeRelativeTimePredicate(86400),
+		NotPredicate(BeforeAbsoluteTimePredicate(1600000000)),
+	), predicate)
+
+	predicate, err = Unconditional().Build()
+	require.NoError(t, err)
+	assert.Equal(t, UnconditionalPredicate, predicate)
+
+	_, err = Unconditional().Not().Not().Not().Not().Build()
+	assert.EqualError(t, err, "invalid predicate: predicate is nested deeper than 4 levels")
+	_, err = BeforeRelativeTime(-time.Hour).Build()
+	assert.EqualError(t, err, "invalid predicate: before relative time predicate must have a non-negative time")
+}
+
+func TestClaimPredicateBuilderClaimant(t *testing.T) {
+	destination := "GCR22L3WS7TP72S4Z27YTO6JIQYDJK2KLS2TQNHK6Y7XYPA3AGT3X4FH"
+	claimant, err := BeforeRelativeTime(time.Hour).Claimant(destination)
+	require.NoError(t, err)
+	assert.Equal(t, NewClaimant(destination, &[]xdr.ClaimPredicate{BeforeRelativeTimePredicate(3600)}[0]), claimant)
+
+	_, err = Unconditional().Not().Not().Not().Not().Claimant(destination)
+	assert.Error(t, err)
+}
+
+func TestClaimPredicateBuilderEvaluate(t *testing.T) {
+	createdAt := time.Unix(1000, 0)
+	// claimable during an hour, after a minute
+	builder := BeforeRelativeTime(time.Hour).And(BeforeRelativeTime(time.Minute).Not())
+
+	for _, c := range []struct {
+		at       time.Time
+		expected bool
+	}{
+		{createdAt, false},
+		{createdAt.Add(time.Minute), true},
+		{createdAt.Add(time.Hour - time.Second), true},
+		{createdAt.Add(time.Hour), false},
+	} {
+		ok, err := builder.Evaluate(createdAt, c.at)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, ok, "at %v", c.at)
+	}
+
+	_, err := Unconditional().Not().Not().Not().Not().Evaluate(createdAt, createdAt)
+	assert.Error(t, err)
+}
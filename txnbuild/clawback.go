@@ -81,20 +81,20 @@ func (cb *Clawback) FromXDR(xdrOp xdr.Operation) error {
 // of the fields are invalid. Otherwise, it returns nil.
 func (cb *Clawback) Validate() error {
 	var err error
-	_, err = xdr.AddressToMuxedAccount(cb.From)
+	err = validateStellarMuxedAddress(cb.From)
 
 	if err != nil {
-		return NewValidationError("From", err.Error())
+		return fieldValidationError("From", err)
 	}
 
 	err = validateAmount(cb.Amount)
 	if err != nil {
-		return NewValidationError("Amount", err.Error())
+		return fieldValidationError("Amount", err)
 	}
 
 	err = validateAssetCode(cb.Asset)
 	if err != nil {
-		return NewValidationError("Asset", err.Error())
+		return fieldValidationError("Asset", err)
 	}
 
 	return nil
@@ -0,0 +1,102 @@
+package txnbuild
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/support/errors"
+)
+
+// TrustlineFlagsLoader loads the flags of the trustline of an account for a
+// credit asset, returning false if the account has no such trustline. It is
+// implemented by horizonclient.Client.
+type TrustlineFlagsLoader interface {
+	LoadTrustlineFlags(accountID string, asset CreditAsset) ([]TrustLineFlag, bool, error)
+}
+
+// ClawbackNotEnabledError is returned by ValidateClawback when the trustline
+// of the holder does not have the TrustLineClawbackEnabled flag, because it
+// was created before the issuer enabled clawbacks, in which case a Clawback
+// operation would fail with op_not_clawback_enabled.
+type ClawbackNotEnabledError struct {
+	Holder string
+	Asset  CreditAsset
+}
+
+func (e *ClawbackNotEnabledError) Error() string {
+	return fmt.Sprintf(
+		"trustline of %s for %s:%s is not clawback enabled, it was created before clawbacks were enabled",
+		e.Holder, e.Asset.Code, e.Asset.Issuer,
+	)
+}
+
+// ClawbackIssuanceOps returns the operations issuing amount of a
+// clawback-enabled asset to distributor: the issuer sets the AuthRevocable
+// and AuthClawbackEnabled flags (clawbacks require revocable assets), the
+// distributor creates its trustline and the issuer pays it. The transaction
+// must be signed by the issuer and the distributor.
+//
+// Only the trustlines created after the flags are set are clawback enabled,
+// so the SetOptions operation comes first and the trustlines which already
+// exist for the asset, if any, cannot be clawed back.
+func ClawbackIssuanceOps(asset CreditAsset, distributor, amount string) []Operation {
+	return []Operation{
+		&SetOptions{
+			SetFlags:      []AccountFlag{AuthRevocable, AuthClawbackEnabled},
+			SourceAccount: asset.Issuer,
+		},
+		&ChangeTrust{Line: asset.MustToChangeTrustAsset(), SourceAccount: distributor},
+		&Payment{Destination: distributor, Amount: amount, Asset: asset, SourceAccount: asset.Issuer},
+	}
+}
+
+// RevocationOps returns the operations revoking amount of asset from holder:
+// the issuer deauthorizes the trustline of the holder, so that it can no
+// longer send, receive nor trade the asset, then claws back the amount. The
+// transaction must be signed by the issuer. The trustline can be authorized
+// again later with a SetTrustLineFlags operation.
+//
+// No Clawback operation is returned if clawbackAmount is "" or zero (e.g. "0"
+// or "0.0000000"), the trustline is only frozen.
+func RevocationOps(asset CreditAsset, holder, clawbackAmount string) []Operation {
+	ops := []Operation{
+		&SetTrustLineFlags{
+			Trustor:       holder,
+			Asset:         asset,
+			ClearFlags:    []TrustLineFlag{TrustLineAuthorized, TrustLineAuthorizedToMaintainLiabilities},
+			SourceAccount: asset.Issuer,
+		},
+	}
+	if clawbackAmount == "" {
+		return ops
+	}
+	// an invalid amount is kept so that building the Clawback fails
+	if parsed, err := amount.ParseInt64(clawbackAmount); err == nil && parsed == 0 {
+		return ops
+	}
+	ops = append(ops, &Clawback{From: holder, Amount: clawbackAmount, Asset: asset, SourceAccount: asset.Issuer})
+	return ops
+}
+
+// ValidateClawback checks, with loader, that the trustline of holder for
+// asset exists and is clawback enabled, returning a *ClawbackNotEnabledError
+// if it was created before the issuer enabled clawbacks.
+func ValidateClawback(loader TrustlineFlagsLoader, asset CreditAsset, holder string) error {
+	if loader == nil {
+		return errors.New("trustline flags loader is missing")
+	}
+	holder = accountFromMuxed(holder)
+	flags, ok, err := loader.LoadTrustlineFlags(holder, asset)
+	if err != nil {
+		return errors.Wrapf(err, "could not load trustline of %s", holder)
+	}
+	if !ok {
+		return errors.Errorf("%s has no trustline for %s:%s", holder, asset.Code, asset.Issuer)
+	}
+	for _, flag := range flags {
+		if flag == TrustLineClawbackEnabled {
+			return nil
+		}
+	}
+	return &ClawbackNotEnabledError{Holder: holder, Asset: asset}
+}
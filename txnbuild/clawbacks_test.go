@@ -0,0 +1,88 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mapTrustlineFlagsLoader map[string][]TrustLineFlag
+
+func (l mapTrustlineFlagsLoader) LoadTrustlineFlags(accountID string, asset CreditAsset) ([]TrustLineFlag, bool, error) {
+	if accountID == "" {
+		return nil, false, errors.New("account not found")
+	}
+	flags, ok := l[accountID]
+	return flags, ok, nil
+}
+
+func TestClawbackIssuanceOps(t *testing.T) {
+	issuer := newKeypair0().Address()
+	distributor := newKeypair1().Address()
+	usd := CreditAsset{Code: "USD", Issuer: issuer}
+
+	ops := ClawbackIssuanceOps(usd, distributor, "1000")
+	assert.Equal(t, []Operation{
+		&SetOptions{SetFlags: []AccountFlag{AuthRevocable, AuthClawbackEnabled}, SourceAccount: issuer},
+		&ChangeTrust{Line: usd.MustToChangeTrustAsset(), SourceAccount: distributor},
+		&Payment{Destination: distributor, Amount: "1000", Asset: usd, SourceAccount: issuer},
+	}, ops)
+	for _, op := range ops {
+		_, err := op.BuildXDR()
+		assert.NoError(t, err)
+	}
+}
+
+func TestRevocationOps(t *testing.T) {
+	issuer := newKeypair0().Address()
+	holder := newKeypair1().Address()
+	usd := CreditAsset{Code: "USD", Issuer: issuer}
+	freeze := &SetTrustLineFlags{
+		Trustor:       holder,
+		Asset:         usd,
+		ClearFlags:    []TrustLineFlag{TrustLineAuthorized, TrustLineAuthorizedToMaintainLiabilities},
+		SourceAccount: issuer,
+	}
+
+	ops := RevocationOps(usd, holder, "10")
+	assert.Equal(t, []Operation{
+		freeze,
+		&Clawback{From: holder, Amount: "10", Asset: usd, SourceAccount: issuer},
+	}, ops)
+	for _, op := range ops {
+		_, err := op.BuildXDR()
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []Operation{freeze}, RevocationOps(usd, holder, ""))
+	assert.Equal(t, []Operation{freeze}, RevocationOps(usd, holder, "0"))
+	assert.Equal(t, []Operation{freeze}, RevocationOps(usd, holder, "0.0000000"))
+	assert.Equal(t, []Operation{freeze}, RevocationOps(usd, holder, "0.00"))
+
+	// an invalid amount is reported when building the clawback
+	ops = RevocationOps(usd, holder, "ten")
+	if assert.Len(t, ops, 2) {
+		_, err := ops[1].BuildXDR()
+		assert.Error(t, err)
+	}
+}
+
+func TestValidateClawback(t *testing.T) {
+	issuer := newKeypair0().Address()
+	enabled := newKeypair1().Address()
+	legacy := newKeypair2().Address()
+	usd := CreditAsset{Code: "USD", Issuer: issuer}
+	loader := mapTrustlineFlagsLoader{
+		enabled: {TrustLineAuthorized, TrustLineClawbackEnabled},
+		legacy:  {TrustLineAuthorized},
+	}
+
+	assert.NoError(t, ValidateClawback(loader, usd, enabled))
+	err := ValidateClawback(loader, usd, legacy)
+	assert.Equal(t, &ClawbackNotEnabledError{Holder: legacy, Asset: usd}, err)
+	assert.EqualError(t, err, "trustline of "+legacy+" for USD:"+issuer+" is not clawback enabled, it was created before clawbacks were enabled")
+	assert.EqualError(t, ValidateClawback(loader, usd, issuer), issuer+" has no trustline for USD:"+issuer)
+	assert.EqualError(t, ValidateClawback(loader, usd, ""), "could not load trustline of : account not found")
+	assert.EqualError(t, ValidateClawback(nil, usd, enabled), "trustline flags loader is missing")
+}
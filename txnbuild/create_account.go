@@ -58,12 +58,12 @@ func (ca *CreateAccount) FromXDR(xdrOp xdr.Operation) error {
 func (ca *CreateAccount) Validate() error {
 	err := validateStellarPublicKey(ca.Destination)
 	if err != nil {
-		return NewValidationError("Destination", err.Error())
+		return fieldValidationError("Destination", err)
 	}
 
 	err = validateAmount(ca.Amount)
 	if err != nil {
-		return NewValidationError("Amount", err.Error())
+		return fieldValidationError("Amount", err)
 	}
 
 	return nil
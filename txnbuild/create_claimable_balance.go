@@ -170,18 +170,18 @@ func (cb *CreateClaimableBalance) Validate() error {
 	for _, d := range cb.Destinations {
 		err := validateStellarPublicKey(d.Destination)
 		if err != nil {
-			return NewValidationError("Destinations", err.Error())
+			return fieldValidationError("Destinations", err)
 		}
 	}
 
 	err := validateAmount(cb.Amount)
 	if err != nil {
-		return NewValidationError("Amount", err.Error())
+		return fieldValidationError("Amount", err)
 	}
 
 	err = validateStellarAsset(cb.Asset)
 	if err != nil {
-		return NewValidationError("Asset", err.Error())
+		return fieldValidationError("Asset", err)
 	}
 
 	return nil
@@ -0,0 +1,149 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Draft is a snapshot of the TransactionParams of a transaction which is not
+// built yet, e.g. a transaction waiting for approval, which can be encoded to
+// JSON with encoding/json, persisted, and decoded later to build the
+// transaction. The sequence number of the source account is not part of the
+// draft: it is loaded when the transaction is built, once approved.
+//
+// The operations and the memo are kept as base64 XDR, so that restoring a
+// draft yields the same operations. The Hooks of the params are not kept and
+// must be set again when the draft is restored.
+type Draft struct {
+	SourceAccount        string              `json:"source_account"`
+	IncrementSequenceNum bool                `json:"increment_sequence_num"`
+	Operations           []string            `json:"operations"`
+	BaseFee              int64               `json:"base_fee"`
+	Memo                 string              `json:"memo,omitempty"`
+	Timebounds           *DraftTimebounds    `json:"timebounds,omitempty"`
+	Annotations          Annotations         `json:"annotations,omitempty"`
+	OperationAnnotations map[int]Annotations `json:"operation_annotations,omitempty"`
+}
+
+// DraftTimebounds are the time bounds of a Draft, present if the time bounds
+// of the params were constructed with NewTimebounds(), NewTimeout() or
+// NewInfiniteTimeout(). Timeout is the timeout given to NewTimeout(), which
+// is applied again when the draft is restored, so that a draft waiting for
+// approval longer than its timeout is not restored expired.
+type DraftTimebounds struct {
+	MinTime int64 `json:"min_time"`
+	MaxTime int64 `json:"max_time"`
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// NewDraft returns a snapshot of params. It returns an error if the params
+// have no source account or if an operation or the memo cannot be encoded to
+// XDR.
+func NewDraft(params TransactionParams) (*Draft, error) {
+	if params.SourceAccount == nil {
+		return nil, errors.New("transaction has no source account")
+	}
+
+	draft := &Draft{
+		SourceAccount:        params.SourceAccount.GetAccountID(),
+		IncrementSequenceNum: params.IncrementSequenceNum,
+		Operations:           make([]string, 0, len(params.Operations)),
+		BaseFee:              params.BaseFee,
+		Annotations:          params.Annotations.clone(),
+	}
+	for i, op := range params.Operations {
+		xdrOp, err := op.BuildXDR()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode operation %d", i)
+		}
+		encoded, err := xdr.MarshalBase64(xdrOp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode operation %d", i)
+		}
+		draft.Operations = append(draft.Operations, encoded)
+	}
+	if params.Memo != nil {
+		xdrMemo, err := params.Memo.ToXDR()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode memo")
+		}
+		if draft.Memo, err = xdr.MarshalBase64(xdrMemo); err != nil {
+			return nil, errors.Wrap(err, "could not encode memo")
+		}
+	}
+	if params.Timebounds.wasBuilt {
+		draft.Timebounds = &DraftTimebounds{
+			MinTime: params.Timebounds.MinTime,
+			MaxTime: params.Timebounds.MaxTime,
+			Timeout: params.Timebounds.timeout,
+		}
+	}
+	for index, annotations := range params.OperationAnnotations {
+		if draft.OperationAnnotations == nil {
+			draft.OperationAnnotations = map[int]Annotations{}
+		}
+		draft.OperationAnnotations[index] = annotations.clone()
+	}
+	return draft, nil
+}
+
+// Params restores the params of the draft, with sourceAccount, the source
+// account of the draft loaded when the transaction is built, e.g. a
+// horizon.Account, for the sequence number. It returns an error if
+// sourceAccount is not the source account of the draft.
+func (d *Draft) Params(sourceAccount Account) (TransactionParams, error) {
+	if sourceAccount == nil {
+		return TransactionParams{}, errors.New("transaction has no source account")
+	}
+	if sourceAccount.GetAccountID() != d.SourceAccount {
+		return TransactionParams{}, errors.Errorf(
+			"source account %s is not the source account of the draft %s",
+			sourceAccount.GetAccountID(), d.SourceAccount,
+		)
+	}
+
+	params := TransactionParams{
+		SourceAccount:        sourceAccount,
+		IncrementSequenceNum: d.IncrementSequenceNum,
+		Operations:           make([]Operation, 0, len(d.Operations)),
+		BaseFee:              d.BaseFee,
+		Annotations:          d.Annotations.clone(),
+	}
+	for i, encoded := range d.Operations {
+		var xdrOp xdr.Operation
+		if err := xdr.SafeUnmarshalBase64(encoded, &xdrOp); err != nil {
+			return TransactionParams{}, errors.Wrapf(err, "could not decode operation %d", i)
+		}
+		op, err := operationFromXDR(xdrOp)
+		if err != nil {
+			return TransactionParams{}, errors.Wrapf(err, "could not decode operation %d", i)
+		}
+		params.Operations = append(params.Operations, op)
+	}
+	if d.Memo != "" {
+		var xdrMemo xdr.Memo
+		if err := xdr.SafeUnmarshalBase64(d.Memo, &xdrMemo); err != nil {
+			return TransactionParams{}, errors.Wrap(err, "could not decode memo")
+		}
+		memo, err := memoFromXDR(xdrMemo)
+		if err != nil {
+			return TransactionParams{}, errors.Wrap(err, "could not decode memo")
+		}
+		params.Memo = memo
+	}
+	if d.Timebounds != nil && d.Timebounds.Timeout > 0 {
+		params.Timebounds = NewTimeout(d.Timebounds.Timeout)
+	} else if d.Timebounds != nil {
+		params.Timebounds = NewTimebounds(d.Timebounds.MinTime, d.Timebounds.MaxTime)
+	}
+	for index, annotations := range d.OperationAnnotations {
+		if index < 0 || index >= len(params.Operations) {
+			return TransactionParams{}, errors.Errorf("annotations of operation %d which does not exist", index)
+		}
+		if params.OperationAnnotations == nil {
+			params.OperationAnnotations = map[int]Annotations{}
+		}
+		params.OperationAnnotations[index] = annotations.clone()
+	}
+	return params, nil
+}
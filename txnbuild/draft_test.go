@@ -0,0 +1,136 @@
+package txnbuild
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+)
+
+func TestDraftRoundTrip(t *testing.T) {
+	source := keypair.MustRandom()
+	destination := keypair.MustRandom()
+	params := TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations: []Operation{
+			&Payment{Destination: destination.Address(), Amount: "10.0000000", Asset: NativeAsset{}},
+			&ManageData{Name: "approval", Value: []byte("pending"), SourceAccount: destination.Address()},
+		},
+		BaseFee:              200,
+		Memo:                 MemoText("invoice 42"),
+		Timebounds:           NewTimebounds(0, 1700000000),
+		Annotations:          Annotations{"request": "r1"},
+		OperationAnnotations: map[int]Annotations{1: {"step": "approval"}},
+	}
+
+	draft, err := NewDraft(params)
+	require.NoError(t, err)
+	data, err := json.Marshal(draft)
+	require.NoError(t, err)
+
+	var restored Draft
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, *draft, restored)
+
+	// the sequence number is loaded when the draft is built
+	account := &SimpleAccount{AccountID: source.Address(), Sequence: 41}
+	restoredParams, err := restored.Params(account)
+	require.NoError(t, err)
+	assert.Equal(t, params.Operations, restoredParams.Operations)
+	assert.Equal(t, params.Memo, restoredParams.Memo)
+	assert.Equal(t, params.Timebounds, restoredParams.Timebounds)
+	assert.Equal(t, params.Annotations, restoredParams.Annotations)
+	assert.Equal(t, params.OperationAnnotations, restoredParams.OperationAnnotations)
+
+	tx, err := NewTransaction(restoredParams)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), tx.SequenceNumber())
+	expected, err := NewTransaction(TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: source.Address(), Sequence: 41},
+		IncrementSequenceNum: true,
+		Operations:           params.Operations,
+		BaseFee:              params.BaseFee,
+		Memo:                 params.Memo,
+		Timebounds:           params.Timebounds,
+	})
+	require.NoError(t, err)
+	expectedHash, err := expected.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	_, err = restored.Params(&SimpleAccount{AccountID: destination.Address()})
+	assert.EqualError(t, err, "source account "+destination.Address()+" is not the source account of the draft "+source.Address())
+}
+
+func TestDraftTimeout(t *testing.T) {
+	source := keypair.MustRandom()
+	params := TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: source.Address()},
+		Operations:    []Operation{&BumpSequence{BumpTo: 10}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewTimeout(300),
+	}
+	// the draft was saved an hour ago, its absolute time bounds expired since
+	params.Timebounds.MaxTime -= 3600
+
+	draft, err := NewDraft(params)
+	require.NoError(t, err)
+	data, err := json.Marshal(draft)
+	require.NoError(t, err)
+	var restored Draft
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, int64(300), restored.Timebounds.Timeout)
+
+	before := time.Now().UTC().Unix()
+	restoredParams, err := restored.Params(&SimpleAccount{AccountID: source.Address()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), restoredParams.Timebounds.MinTime)
+	assert.GreaterOrEqual(t, restoredParams.Timebounds.MaxTime, before+300)
+	assert.LessOrEqual(t, restoredParams.Timebounds.MaxTime, time.Now().UTC().Unix()+300)
+	assert.NoError(t, restoredParams.Timebounds.Validate())
+}
+
+func TestDraftOptionalFields(t *testing.T) {
+	source := keypair.MustRandom()
+
+	// drafts without memo nor time bounds restore without them
+	draft, err := NewDraft(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: source.Address()},
+		Operations:    []Operation{&BumpSequence{BumpTo: 10}},
+	})
+	require.NoError(t, err)
+	data, err := json.Marshal(draft)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"source_account": "`+source.Address()+`",
+		"increment_sequence_num": false,
+		"operations": ["AAAAAAAAAAsAAAAAAAAACg=="],
+		"base_fee": 0
+	}`, string(data))
+
+	params, err := draft.Params(&SimpleAccount{AccountID: source.Address()})
+	require.NoError(t, err)
+	assert.Nil(t, params.Memo)
+	assert.Equal(t, Timebounds{}, params.Timebounds)
+	assert.Equal(t, []Operation{&BumpSequence{BumpTo: 10}}, params.Operations)
+
+	_, err = NewDraft(TransactionParams{})
+	assert.EqualError(t, err, "transaction has no source account")
+	_, err = NewDraft(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: source.Address()},
+		Memo:          MemoText("a memo which is longer than 28 bytes"),
+	})
+	assert.Contains(t, err.Error(), "could not encode memo")
+
+	draft.Operations = append(draft.Operations, "AAAA")
+	_, err = draft.Params(&SimpleAccount{AccountID: source.Address()})
+	assert.Contains(t, err.Error(), "could not decode operation 1")
+}
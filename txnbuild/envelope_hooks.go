@@ -0,0 +1,47 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// EnvelopeHooks let networks which extend the transaction envelope, such as
+// private networks carrying custom extension fields, set and read those
+// fields without forking txnbuild. The hooks should only touch the parts of
+// the envelope which txnbuild does not model: the operations, memo, time
+// bounds and fees of a Transaction are not updated from the envelope.
+type EnvelopeHooks struct {
+	// PreEncode is called with the envelope of a transaction built by
+	// NewTransaction or NewFeeBumpTransaction, before it is stored in the
+	// transaction and signed. It may modify the envelope; an error aborts the
+	// build.
+	PreEncode func(envelope *xdr.TransactionEnvelope) error
+	// PostDecode is called by TransactionFromXDRWithHooks with the decoded
+	// envelope and the transaction parsed from it. An error aborts the
+	// decoding.
+	PostDecode func(envelope xdr.TransactionEnvelope, tx *GenericTransaction) error
+}
+
+func (h *EnvelopeHooks) preEncode(envelope *xdr.TransactionEnvelope) error {
+	if h == nil || h.PreEncode == nil {
+		return nil
+	}
+	return errors.Wrap(h.PreEncode(envelope), "pre-encode hook failed")
+}
+
+// TransactionFromXDRWithHooks parses the supplied transaction envelope in
+// base64 XDR like TransactionFromXDR and calls the PostDecode hook with it.
+func TransactionFromXDRWithHooks(txeB64 string, hooks EnvelopeHooks) (*GenericTransaction, error) {
+	tx, err := TransactionFromXDR(txeB64)
+	if err != nil || hooks.PostDecode == nil {
+		return tx, err
+	}
+	envelope, err := tx.ToXDR()
+	if err != nil {
+		return nil, err
+	}
+	if err := hooks.PostDecode(envelope, tx); err != nil {
+		return nil, errors.Wrap(err, "post-decode hook failed")
+	}
+	return tx, nil
+}
@@ -0,0 +1,97 @@
+package txnbuild
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/xdr"
+)
+
+func TestEnvelopeHooksPreEncode(t *testing.T) {
+	kp := keypair.MustRandom()
+	var called int
+	hooks := &EnvelopeHooks{
+		PreEncode: func(envelope *xdr.TransactionEnvelope) error {
+			called++
+			// stands in for a network specific extension of the envelope
+			envelope.V1.Tx.SeqNum++
+			return nil
+		},
+	}
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+		Hooks:         hooks,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, called)
+	assert.Equal(t, int64(2), tx.ToXDR().SeqNum())
+
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: kp.Address(),
+		BaseFee:    MinBaseFee,
+		Hooks: &EnvelopeHooks{
+			PreEncode: func(envelope *xdr.TransactionEnvelope) error {
+				envelope.FeeBump.Tx.Fee++
+				return nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*MinBaseFee+1), feeBump.ToXDR().FeeBumpFee())
+
+	hooks.PreEncode = func(*xdr.TransactionEnvelope) error {
+		return errors.New("missing extension")
+	}
+	_, err = NewTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+		Hooks:         hooks,
+	})
+	assert.EqualError(t, err, "pre-encode hook failed: missing extension")
+}
+
+func TestTransactionFromXDRWithHooks(t *testing.T) {
+	kp := keypair.MustRandom()
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+
+	var decoded xdr.TransactionEnvelope
+	parsed, err := TransactionFromXDRWithHooks(b64, EnvelopeHooks{
+		PostDecode: func(envelope xdr.TransactionEnvelope, gtx *GenericTransaction) error {
+			decoded = envelope
+			simple, ok := gtx.Transaction()
+			assert.True(t, ok)
+			assert.Equal(t, kp.Address(), simple.SourceAccount().AccountID)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, decoded.Equals(tx.ToXDR()))
+	simple, ok := parsed.Transaction()
+	require.True(t, ok)
+	assert.Equal(t, int64(1), simple.SequenceNumber())
+
+	_, err = TransactionFromXDRWithHooks(b64, EnvelopeHooks{
+		PostDecode: func(xdr.TransactionEnvelope, *GenericTransaction) error {
+			return errors.New("unknown extension")
+		},
+	})
+	assert.EqualError(t, err, "post-decode hook failed: unknown extension")
+}
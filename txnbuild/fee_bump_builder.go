@@ -0,0 +1,133 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// NewFeeBumpTransactionFromXDR wraps the transaction whose envelope is given
+// in base64 XDR, e.g. a transaction signed by the user of a wallet, in a fee
+// bump transaction paid by feeAccount, bidding baseFee per operation. It
+// returns an error if the envelope is a fee bump transaction already.
+func NewFeeBumpTransactionFromXDR(innerB64, feeAccount string, baseFee int64) (*FeeBumpTransaction, error) {
+	inner, err := innerTransactionFromXDR(innerB64)
+	if err != nil {
+		return nil, err
+	}
+	return NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: feeAccount,
+		BaseFee:    baseFee,
+	})
+}
+
+func innerTransactionFromXDR(innerB64 string) (*Transaction, error) {
+	parsed, err := TransactionFromXDR(innerB64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse inner transaction")
+	}
+	inner, ok := parsed.Transaction()
+	if !ok {
+		return nil, errors.New("inner transaction is a fee bump transaction")
+	}
+	return inner, nil
+}
+
+// FeeBumpBuilder wraps the transactions signed by the users of a service
+// sponsoring their fees, given as base64 envelopes, in fee bump transactions
+// paid by FeeAccount. The inner transactions are validated before the fee
+// bump is built, so that a user cannot make the service pay more than it
+// intends to, and the fee bumps are ready to be signed by FeeAccount.
+type FeeBumpBuilder struct {
+	// FeeAccount is the address of the account paying the fee bumps.
+	FeeAccount string
+	// MaxBaseFee is the maximum fee per operation the service pays. The inner
+	// transactions bidding more, and the fee bumps which would need more
+	// because of the fee of the network, are refused. They are not capped if
+	// it is 0.
+	MaxBaseFee int64
+	// MaxOperations is the maximum number of operations of the inner
+	// transactions, not limited if it is 0.
+	MaxOperations int
+}
+
+// Build validates the signed transaction whose envelope is given in base64
+// XDR, and wraps it in a fee bump transaction bidding the minimum fee per
+// operation needed for it to be included: networkBaseFee, e.g. a percentile
+// of the fees charged reported by horizonclient.Client.FeeStats, or the fee
+// per operation of the inner transaction if it is higher, and at least
+// MinBaseFee.
+//
+// The inner transaction is refused if it is not signed, if its source
+// account is FeeAccount, since the transactions of the users must not
+// operate on the account of the service, or if it exceeds the limits of the
+// builder.
+func (b FeeBumpBuilder) Build(innerB64 string, networkBaseFee int64) (*FeeBumpTransaction, error) {
+	inner, err := innerTransactionFromXDR(innerB64)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.validateInner(inner); err != nil {
+		return nil, err
+	}
+
+	baseFee := MinFeeBumpBaseFee(inner, networkBaseFee)
+	if b.MaxBaseFee > 0 && baseFee > b.MaxBaseFee {
+		return nil, errors.Errorf(
+			"fee bump base fee %d is above the maximum base fee %d", baseFee, b.MaxBaseFee,
+		)
+	}
+
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: b.FeeAccount,
+		BaseFee:    baseFee,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create fee bump transaction")
+	}
+	return feeBump, nil
+}
+
+func (b FeeBumpBuilder) validateInner(inner *Transaction) error {
+	if len(inner.Signatures()) == 0 {
+		return errors.New("inner transaction is not signed")
+	}
+	if b.MaxOperations > 0 && len(inner.Operations()) > b.MaxOperations {
+		return errors.Errorf(
+			"inner transaction has %d operations, more than the maximum of %d",
+			len(inner.Operations()), b.MaxOperations,
+		)
+	}
+	if b.MaxBaseFee > 0 && inner.BaseFee() > b.MaxBaseFee {
+		return errors.Errorf(
+			"inner transaction base fee %d is above the maximum base fee %d", inner.BaseFee(), b.MaxBaseFee,
+		)
+	}
+
+	feeAccount, err := xdr.AddressToMuxedAccount(b.FeeAccount)
+	if err != nil {
+		return errors.Wrap(err, "fee account is not a valid address")
+	}
+	source := inner.envelope.SourceAccount().ToAccountId()
+	if source.Equals(feeAccount.ToAccountId()) {
+		return errors.New("inner transaction source account is the fee account")
+	}
+	return nil
+}
+
+// MinFeeBumpBaseFee returns the minimum fee per operation a fee bump of the
+// inner transaction must bid to be included when the fee per operation of
+// the network is networkBaseFee: the highest of networkBaseFee, the fee per
+// operation of the inner transaction, which a fee bump cannot bid below, and
+// MinBaseFee.
+func MinFeeBumpBaseFee(inner *Transaction, networkBaseFee int64) int64 {
+	baseFee := networkBaseFee
+	if baseFee < inner.BaseFee() {
+		baseFee = inner.BaseFee()
+	}
+	if baseFee < MinBaseFee {
+		baseFee = MinBaseFee
+	}
+	return baseFee
+}
@@ -0,0 +1,154 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+func signedInnerXDR(t *testing.T, kp *keypair.Full, baseFee int64, ops int) string {
+	b64, err := signedInner(t, kp, baseFee, ops).Base64()
+	require.NoError(t, err)
+	return b64
+}
+
+func signedInner(t *testing.T, kp *keypair.Full, baseFee int64, ops int) *Transaction {
+	account := NewSimpleAccount(kp.Address(), 1)
+	operations := make([]Operation, ops)
+	for i := range operations {
+		operations[i] = &BumpSequence{BumpTo: 10}
+	}
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        &account,
+		IncrementSequenceNum: true,
+		Operations:           operations,
+		BaseFee:              baseFee,
+		Timebounds:           NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestNewFeeBumpTransactionFromXDR(t *testing.T) {
+	user := keypair.MustRandom()
+	feeAccount := keypair.MustRandom()
+	innerB64 := signedInnerXDR(t, user, MinBaseFee, 2)
+
+	feeBump, err := NewFeeBumpTransactionFromXDR(innerB64, feeAccount.Address(), 200)
+	require.NoError(t, err)
+	assert.Equal(t, feeAccount.Address(), feeBump.FeeAccount())
+	assert.Equal(t, int64(200), feeBump.BaseFee())
+	assert.Equal(t, int64(600), feeBump.MaxFee())
+	innerB64Again, err := feeBump.InnerTransaction().Base64()
+	require.NoError(t, err)
+	assert.Equal(t, innerB64, innerB64Again)
+
+	feeBumpB64, err := feeBump.Base64()
+	require.NoError(t, err)
+	_, err = NewFeeBumpTransactionFromXDR(feeBumpB64, feeAccount.Address(), 200)
+	assert.EqualError(t, err, "inner transaction is a fee bump transaction")
+
+	_, err = NewFeeBumpTransactionFromXDR("AAAA", feeAccount.Address(), 200)
+	assert.Contains(t, err.Error(), "could not parse inner transaction")
+}
+
+func TestFeeBumpLegacyInner(t *testing.T) {
+	user := keypair.MustRandom()
+	feeAccount := keypair.MustRandom()
+	inner := signedInner(t, user, MinBaseFee, 2)
+	innerHash, err := inner.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	convertToV0(inner)
+	innerB64, err := inner.Base64()
+	require.NoError(t, err)
+
+	fromXDR, err := NewFeeBumpTransactionFromXDR(innerB64, feeAccount.Address(), 200)
+	require.NoError(t, err)
+	built, err := FeeBumpBuilder{FeeAccount: feeAccount.Address()}.Build(innerB64, 200)
+	require.NoError(t, err)
+
+	for _, feeBump := range []*FeeBumpTransaction{fromXDR, built} {
+		// the v0 inner transaction is wrapped as the equivalent v1
+		// transaction, whose hash and signatures are the same
+		assert.Equal(t, xdr.EnvelopeTypeEnvelopeTypeTx, feeBump.InnerTransaction().envelope.Type)
+		hash, err := feeBump.InnerTransaction().HashHex(network.TestNetworkPassphrase)
+		require.NoError(t, err)
+		assert.Equal(t, innerHash, hash)
+		assert.Equal(t, inner.Signatures(), feeBump.InnerTransaction().Signatures())
+
+		feeBump, err = feeBump.Sign(network.TestNetworkPassphrase, feeAccount)
+		require.NoError(t, err)
+		feeBumpB64, err := feeBump.Base64()
+		require.NoError(t, err)
+		parsed, err := TransactionFromXDR(feeBumpB64)
+		require.NoError(t, err)
+		decoded, ok := parsed.FeeBump()
+		require.True(t, ok)
+		assert.Equal(t, int64(600), decoded.MaxFee())
+		assert.Equal(t, user.Address(), decoded.InnerTransaction().SourceAccount().AccountID)
+	}
+}
+
+func TestFeeBumpBuilder(t *testing.T) {
+	user := keypair.MustRandom()
+	feeAccount := keypair.MustRandom()
+	builder := FeeBumpBuilder{FeeAccount: feeAccount.Address(), MaxBaseFee: 1000, MaxOperations: 2}
+
+	// the fee bump bids the fee of the network
+	feeBump, err := builder.Build(signedInnerXDR(t, user, MinBaseFee, 2), 300)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), feeBump.BaseFee())
+	assert.Equal(t, int64(900), feeBump.MaxFee())
+	assert.Empty(t, feeBump.Signatures())
+
+	// the fee bump cannot bid less than the inner transaction or MinBaseFee
+	feeBump, err = builder.Build(signedInnerXDR(t, user, 500, 1), 300)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), feeBump.BaseFee())
+	feeBump, err = builder.Build(signedInnerXDR(t, user, MinBaseFee, 1), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(MinBaseFee), feeBump.BaseFee())
+
+	_, err = builder.Build(signedInnerXDR(t, user, MinBaseFee, 1), 2000)
+	assert.EqualError(t, err, "fee bump base fee 2000 is above the maximum base fee 1000")
+	_, err = builder.Build(signedInnerXDR(t, user, 2000, 1), 300)
+	assert.EqualError(t, err, "inner transaction base fee 2000 is above the maximum base fee 1000")
+	_, err = builder.Build(signedInnerXDR(t, user, MinBaseFee, 3), 300)
+	assert.EqualError(t, err, "inner transaction has 3 operations, more than the maximum of 2")
+	_, err = builder.Build(signedInnerXDR(t, feeAccount, MinBaseFee, 1), 300)
+	assert.EqualError(t, err, "inner transaction source account is the fee account")
+
+	builder.MaxBaseFee = 0
+	feeBump, err = builder.Build(signedInnerXDR(t, user, MinBaseFee, 1), 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), feeBump.BaseFee())
+}
+
+func TestFeeBumpBuilderUnsignedInner(t *testing.T) {
+	user := keypair.MustRandom()
+	account := NewSimpleAccount(user.Address(), 1)
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount: &account,
+		Operations:    []Operation{&BumpSequence{BumpTo: 10}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	innerB64, err := tx.Base64()
+	require.NoError(t, err)
+
+	builder := FeeBumpBuilder{FeeAccount: keypair.MustRandom().Address()}
+	_, err = builder.Build(innerB64, 300)
+	assert.EqualError(t, err, "inner transaction is not signed")
+
+	builder.FeeAccount = "GBOB"
+	_, err = builder.Build(signedInnerXDR(t, user, MinBaseFee, 1), 300)
+	assert.Contains(t, err.Error(), "fee account is not a valid address")
+}
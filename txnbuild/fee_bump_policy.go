@@ -0,0 +1,114 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+)
+
+// FeeBumpReason is the reason why a FeeBumpPolicy wraps a transaction in a
+// fee bump transaction.
+type FeeBumpReason string
+
+const (
+	// FeeBumpNotNeeded means that the source account of the transaction
+	// pays its fee.
+	FeeBumpNotNeeded FeeBumpReason = ""
+	// FeeBumpHighFee means that the fee per operation of the network is
+	// above FeeBumpPolicy.FeeThreshold.
+	FeeBumpHighFee FeeBumpReason = "high_fee"
+	// FeeBumpUnderpriced means that the transaction bids less than the fee
+	// per operation of the network and would not be included.
+	FeeBumpUnderpriced FeeBumpReason = "underpriced"
+	// FeeBumpLowBalance means that the source account of the transaction
+	// cannot pay its fee without going below its reserve.
+	FeeBumpLowBalance FeeBumpReason = "low_balance"
+)
+
+// FeeBumpConditions are the conditions a FeeBumpPolicy decides on, known by
+// the wallet when its user submits a transaction.
+type FeeBumpConditions struct {
+	// NetworkBaseFee is the fee per operation a transaction must bid to be
+	// included, e.g. a percentile of the fees charged reported by
+	// horizonclient.Client.FeeStats. MinBaseFee if it is 0.
+	NetworkBaseFee int64
+	// AvailableBalance is the native balance of the source account of the
+	// transaction available for its fee, in stroops: its balance minus its
+	// minimum balance and its selling liabilities.
+	AvailableBalance int64
+}
+
+// FeeBumpPolicy decides when a wallet subsidizes the fee of the transactions
+// of its users, wrapping them in fee bump transactions paid by FeeAccount.
+// The fee of a fee bump transaction is paid entirely by its fee account, the
+// inner transaction does not pay any part of it, so a wallet splitting fees
+// with its users must charge them its part in the inner transaction, e.g.
+// with a payment to FeeAccount.
+type FeeBumpPolicy struct {
+	// FeeAccount is the address of the account paying the fee bumps.
+	FeeAccount string
+	// FeeThreshold is the fee per operation of the network above which the
+	// wallet pays the fees of its users. Transactions are not bumped because
+	// of the fee of the network if it is 0.
+	FeeThreshold int64
+	// LowBalanceThreshold is the available balance of the source account, in
+	// stroops, below which the wallet pays its fees. It is the fee the
+	// transaction would pay if it is 0, so that accounts which cannot pay
+	// their fee are subsidized.
+	LowBalanceThreshold int64
+	// MaxBaseFee is the maximum fee per operation the wallet pays. The fee
+	// bumps which would need more fail, they are not capped if it is 0.
+	MaxBaseFee int64
+}
+
+// Decide returns why the transaction should be wrapped in a fee bump
+// transaction, FeeBumpNotNeeded if it should not. The reasons are checked
+// in the order of the FeeBumpReason constants.
+func (p FeeBumpPolicy) Decide(tx *Transaction, conditions FeeBumpConditions) FeeBumpReason {
+	networkBaseFee := conditions.NetworkBaseFee
+	if networkBaseFee < MinBaseFee {
+		networkBaseFee = MinBaseFee
+	}
+	if p.FeeThreshold > 0 && networkBaseFee > p.FeeThreshold {
+		return FeeBumpHighFee
+	}
+	if tx.BaseFee() < networkBaseFee {
+		return FeeBumpUnderpriced
+	}
+
+	threshold := p.LowBalanceThreshold
+	if threshold == 0 {
+		threshold = networkBaseFee * int64(len(tx.Operations()))
+	}
+	if conditions.AvailableBalance < threshold {
+		return FeeBumpLowBalance
+	}
+	return FeeBumpNotNeeded
+}
+
+// Apply wraps the transaction in a fee bump transaction paid by FeeAccount
+// if the policy decides so, see Decide. The fee bump bids the minimum fee
+// per operation for the network, see MinFeeBumpBaseFee, and must be signed
+// by FeeAccount. It returns a nil fee bump transaction
+// when the transaction should be submitted as is.
+func (p FeeBumpPolicy) Apply(tx *Transaction, conditions FeeBumpConditions) (*FeeBumpTransaction, FeeBumpReason, error) {
+	reason := p.Decide(tx, conditions)
+	if reason == FeeBumpNotNeeded {
+		return nil, reason, nil
+	}
+
+	baseFee := MinFeeBumpBaseFee(tx, conditions.NetworkBaseFee)
+	if p.MaxBaseFee > 0 && baseFee > p.MaxBaseFee {
+		return nil, reason, errors.Errorf(
+			"fee bump base fee %d is above the maximum base fee %d of the policy", baseFee, p.MaxBaseFee,
+		)
+	}
+
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: p.FeeAccount,
+		BaseFee:    baseFee,
+	})
+	if err != nil {
+		return nil, reason, errors.Wrap(err, "could not create fee bump transaction")
+	}
+	return feeBump, reason, nil
+}
@@ -0,0 +1,109 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+func feeBumpPolicyTransaction(t *testing.T, baseFee int64) *Transaction {
+	account := NewSimpleAccount(keypair.MustRandom().Address(), 1)
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        &account,
+		IncrementSequenceNum: true,
+		Operations: []Operation{
+			&BumpSequence{BumpTo: 10},
+			&BumpSequence{BumpTo: 20},
+		},
+		BaseFee:    baseFee,
+		Timebounds: NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestFeeBumpPolicyDecide(t *testing.T) {
+	policy := FeeBumpPolicy{FeeAccount: keypair.MustRandom().Address(), FeeThreshold: 500}
+	tx := feeBumpPolicyTransaction(t, 200)
+
+	for _, test := range []struct {
+		name       string
+		conditions FeeBumpConditions
+		reason     FeeBumpReason
+	}{
+		{"not needed", FeeBumpConditions{NetworkBaseFee: 150, AvailableBalance: 1000}, FeeBumpNotNeeded},
+		{"default network fee", FeeBumpConditions{AvailableBalance: 200}, FeeBumpNotNeeded},
+		{"high fee", FeeBumpConditions{NetworkBaseFee: 501, AvailableBalance: 1000000}, FeeBumpHighFee},
+		{"underpriced", FeeBumpConditions{NetworkBaseFee: 300, AvailableBalance: 1000000}, FeeBumpUnderpriced},
+		{"low balance", FeeBumpConditions{NetworkBaseFee: 150, AvailableBalance: 299}, FeeBumpLowBalance},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.reason, policy.Decide(tx, test.conditions))
+		})
+	}
+
+	policy.LowBalanceThreshold = 10000
+	assert.Equal(t, FeeBumpLowBalance, policy.Decide(tx, FeeBumpConditions{AvailableBalance: 9999}))
+	policy.FeeThreshold = 0
+	assert.Equal(t, FeeBumpNotNeeded, policy.Decide(tx, FeeBumpConditions{NetworkBaseFee: 200, AvailableBalance: 10000}))
+}
+
+func TestFeeBumpPolicyApply(t *testing.T) {
+	feeAccount := keypair.MustRandom().Address()
+	policy := FeeBumpPolicy{FeeAccount: feeAccount, FeeThreshold: 500, MaxBaseFee: 1000}
+	tx := feeBumpPolicyTransaction(t, 200)
+
+	feeBump, reason, err := policy.Apply(tx, FeeBumpConditions{NetworkBaseFee: 150, AvailableBalance: 1000})
+	require.NoError(t, err)
+	assert.Nil(t, feeBump)
+	assert.Equal(t, FeeBumpNotNeeded, reason)
+
+	feeBump, reason, err = policy.Apply(tx, FeeBumpConditions{NetworkBaseFee: 600})
+	require.NoError(t, err)
+	assert.Equal(t, FeeBumpHighFee, reason)
+	assert.Equal(t, feeAccount, feeBump.FeeAccount())
+	assert.Equal(t, int64(600), feeBump.BaseFee())
+	assert.Equal(t, tx, feeBump.InnerTransaction())
+
+	// the fee bump bids at least the fee of the inner transaction
+	feeBump, reason, err = policy.Apply(tx, FeeBumpConditions{NetworkBaseFee: 50})
+	require.NoError(t, err)
+	assert.Equal(t, FeeBumpLowBalance, reason)
+	assert.Equal(t, int64(200), feeBump.BaseFee())
+
+	_, reason, err = policy.Apply(tx, FeeBumpConditions{NetworkBaseFee: 1001})
+	assert.Equal(t, FeeBumpHighFee, reason)
+	assert.EqualError(t, err, "fee bump base fee 1001 is above the maximum base fee 1000 of the policy")
+
+	policy.FeeAccount = "GBOB"
+	_, _, err = policy.Apply(tx, FeeBumpConditions{NetworkBaseFee: 600})
+	assert.Error(t, err)
+}
+
+func TestFeeBumpPolicyApplyV0(t *testing.T) {
+	policy := FeeBumpPolicy{FeeAccount: keypair.MustRandom().Address(), FeeThreshold: 500}
+	tx := feeBumpPolicyTransaction(t, 200)
+	convertToV0(tx)
+
+	feeBump, reason, err := policy.Apply(tx, FeeBumpConditions{NetworkBaseFee: 300})
+	require.NoError(t, err)
+	assert.Equal(t, FeeBumpUnderpriced, reason)
+	assert.Equal(t, int64(300), feeBump.BaseFee())
+	assert.Equal(t, xdr.EnvelopeTypeEnvelopeTypeTx, feeBump.InnerTransaction().envelope.Type)
+
+	// the fee bump of the v0 transaction can be encoded and hashed
+	_, err = feeBump.Base64()
+	require.NoError(t, err)
+	_, err = feeBump.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	innerHash, err := feeBump.InnerTransaction().HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	originalHash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, originalHash, innerHash)
+}
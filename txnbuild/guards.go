@@ -0,0 +1,230 @@
+package txnbuild
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// Guard is a condition on the state of the ledger a guarded transaction is
+// only valid in, so that automations acting concurrently on the same
+// accounts can apply changes computed from the state they observed, as a
+// compare-and-set: the transaction fails, without any effect, if the state
+// changed since it was observed. See NewGuardedTransaction.
+//
+// The protocol does not support preconditions on the state of the ledger
+// (nor extra signers), so the guards are operations failing when the state
+// changed, prepended to the operations of the transaction.
+type Guard interface {
+	// GuardOperations returns the operations failing when the state changed.
+	GuardOperations() ([]Operation, error)
+	// GuardFailed returns true if the result codes of the transaction, as
+	// returned by Horizon, mean that the state changed: txCode is the result
+	// code of the transaction and opCodes are the result codes of the
+	// operations of the guard, empty if the transaction failed before its
+	// operations were applied.
+	GuardFailed(txCode string, opCodes []string) bool
+}
+
+// SequenceGuard pins the sequence number of the source account of the
+// transaction: it is only valid if no transaction of the account was
+// applied since its sequence number was Sequence.
+type SequenceGuard struct {
+	AccountID string
+	Sequence  int64
+}
+
+// GuardOperations returns no operations: the sequence number of the
+// transaction is set by NewGuardedTransaction.
+func (g SequenceGuard) GuardOperations() ([]Operation, error) {
+	return nil, nil
+}
+
+// GuardFailed returns true if the sequence number of the transaction was
+// not the next one of the account.
+func (g SequenceGuard) GuardFailed(txCode string, opCodes []string) bool {
+	return txCode == "tx_bad_seq"
+}
+
+// ClaimableBalanceGuard consumes a claimable balance used as a lock token:
+// the transaction is only valid if the balance still exists and can be
+// claimed by Claimant, and the first guarded transaction applied claims it,
+// so that the others fail. The token is typically a balance of the minimum
+// native amount created by the automation holding the lock, e.g. with a
+// ClaimPredicateBuilder expiring it.
+type ClaimableBalanceGuard struct {
+	// BalanceID is the id of the claimable balance, see
+	// Transaction.ClaimableBalanceID.
+	BalanceID string
+	// Claimant is the account claiming the balance, the source account of
+	// the transaction if it is empty.
+	Claimant string
+}
+
+// GuardOperations returns the operation claiming the balance.
+func (g ClaimableBalanceGuard) GuardOperations() ([]Operation, error) {
+	if g.BalanceID == "" {
+		return nil, errors.New("claimable balance guard has no balance id")
+	}
+	return []Operation{&ClaimClaimableBalance{BalanceID: g.BalanceID, SourceAccount: g.Claimant}}, nil
+}
+
+// GuardFailed returns true if the balance was claimed, or can no longer be
+// claimed by Claimant.
+func (g ClaimableBalanceGuard) GuardFailed(txCode string, opCodes []string) bool {
+	if len(opCodes) != 1 {
+		return false
+	}
+	return opCodes[0] == "op_does_not_exist" || opCodes[0] == "op_cannot_claim"
+}
+
+// DataVersionGuard checks and increments the version of a state stored in
+// the data entries of an account: the version is the suffix of the name of
+// a data entry, e.g. "config.7". The transaction is only valid if the
+// version of the state is still Version, and replaces the entry by the one
+// of the next version, so that the other transactions guarded by the same
+// version fail.
+type DataVersionGuard struct {
+	// Account is the account holding the data entry, the source account of
+	// the transaction if it is empty.
+	Account string
+	// Name is the name of the state, the prefix of the name of the entry.
+	Name    string
+	Version uint64
+}
+
+// DataName returns the name of the data entry of the version.
+func (g DataVersionGuard) DataName(version uint64) string {
+	return g.Name + "." + strconv.FormatUint(version, 10)
+}
+
+// Initialize returns the operation creating the data entry of Version, to
+// apply once before the state is guarded.
+func (g DataVersionGuard) Initialize() *ManageData {
+	return &ManageData{
+		Name:          g.DataName(g.Version),
+		Value:         []byte(strconv.FormatUint(g.Version, 10)),
+		SourceAccount: g.Account,
+	}
+}
+
+// GuardOperations returns the operations deleting the data entry of Version,
+// failing if it does not exist, and creating the one of the next version.
+func (g DataVersionGuard) GuardOperations() ([]Operation, error) {
+	if g.Name == "" {
+		return nil, errors.New("data version guard has no name")
+	}
+	next := g.Version + 1
+	if next == 0 {
+		return nil, errors.New("data version guard version overflows")
+	}
+	if name := g.DataName(next); len(name) > 64 {
+		return nil, fmt.Errorf("data entry name %q is longer than 64 bytes", name)
+	}
+	return []Operation{
+		&ManageData{Name: g.DataName(g.Version), SourceAccount: g.Account},
+		&ManageData{
+			Name:          g.DataName(next),
+			Value:         []byte(strconv.FormatUint(next, 10)),
+			SourceAccount: g.Account,
+		},
+	}, nil
+}
+
+// GuardFailed returns true if the data entry of Version does not exist.
+func (g DataVersionGuard) GuardFailed(txCode string, opCodes []string) bool {
+	return len(opCodes) > 0 && opCodes[0] == "op_data_name_not_found"
+}
+
+// GuardedTransaction is a transaction only valid in the state of the ledger
+// its guards check, see NewGuardedTransaction.
+type GuardedTransaction struct {
+	*Transaction
+	// Guards are the guards of the transaction, in order.
+	Guards []Guard
+	// operations are the index of the first operation of every guard, and
+	// the index of the first operation of the transaction parameters.
+	operations []int
+}
+
+// NewGuardedTransaction returns the transaction of the parameters prepended
+// with the operations of the guards, in order, so that it fails if any guard
+// does. A SequenceGuard replaces the source account of the parameters,
+// which must be its account, and increments its sequence number.
+//
+// The source accounts of the guard operations must sign the transaction.
+func NewGuardedTransaction(params TransactionParams, guards ...Guard) (*GuardedTransaction, error) {
+	if len(guards) == 0 {
+		return nil, errors.New("transaction has no guards")
+	}
+
+	var operations []Operation
+	indexes := make([]int, 0, len(guards)+1)
+	pinned := false
+	for i, guard := range guards {
+		if sequence, ok := guard.(SequenceGuard); ok {
+			if pinned {
+				return nil, errors.New("transaction has several sequence guards")
+			}
+			if params.SourceAccount != nil && params.SourceAccount.GetAccountID() != sequence.AccountID {
+				return nil, errors.New("sequence guard account is not the source account of the transaction")
+			}
+			params.SourceAccount = &SimpleAccount{AccountID: sequence.AccountID, Sequence: sequence.Sequence}
+			params.IncrementSequenceNum = true
+			pinned = true
+		}
+		guardOperations, err := guard.GuardOperations()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid guard %d", i)
+		}
+		indexes = append(indexes, len(operations))
+		operations = append(operations, guardOperations...)
+	}
+	indexes = append(indexes, len(operations))
+
+	// the annotations of the operations follow them
+	if len(params.OperationAnnotations) > 0 {
+		annotations := make(map[int]Annotations, len(params.OperationAnnotations))
+		for index, annotation := range params.OperationAnnotations {
+			annotations[index+len(operations)] = annotation
+		}
+		params.OperationAnnotations = annotations
+	}
+	params.Operations = append(operations, params.Operations...)
+
+	tx, err := NewTransaction(params)
+	if err != nil {
+		return nil, err
+	}
+	return &GuardedTransaction{
+		Transaction: tx,
+		Guards:      append([]Guard(nil), guards...),
+		operations:  indexes,
+	}, nil
+}
+
+// GuardOperations returns the number of operations prepended by the guards:
+// the operations of the transaction parameters follow them.
+func (t *GuardedTransaction) GuardOperations() int {
+	return t.operations[len(t.operations)-1]
+}
+
+// FailedGuards returns the guards which failed, given the result codes of
+// the transaction returned by Horizon (see horizon.TransactionResultCodes),
+// or none if the transaction failed for another reason: when some do, the
+// state changed and the transaction must be computed again from the new
+// state.
+func (t *GuardedTransaction) FailedGuards(txCode string, opCodes []string) []Guard {
+	var failed []Guard
+	for i, guard := range t.Guards {
+		var guardCodes []string
+		if start, end := t.operations[i], t.operations[i+1]; end <= len(opCodes) {
+			guardCodes = opCodes[start:end]
+		}
+		if guard.GuardFailed(txCode, guardCodes) {
+			failed = append(failed, guard)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,105 @@
+package txnbuild
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+)
+
+const guardBalanceID = "00000000da0d57da7d4850e7fc10d2a9d0ebc731f7afb40574c03395b17d49149b91f5be"
+
+func TestNewGuardedTransaction(t *testing.T) {
+	kp := keypair.MustRandom()
+	other := keypair.MustRandom()
+	loaded := &SimpleAccount{AccountID: kp.Address(), Sequence: 200}
+
+	tx, err := NewGuardedTransaction(TransactionParams{
+		SourceAccount:        loaded,
+		IncrementSequenceNum: true,
+		Operations:           []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:              MinBaseFee,
+		Timebounds:           NewInfiniteTimeout(),
+		OperationAnnotations: map[int]Annotations{0: {"step": "bump"}},
+	},
+		SequenceGuard{AccountID: kp.Address(), Sequence: 100},
+		ClaimableBalanceGuard{BalanceID: guardBalanceID},
+		DataVersionGuard{Account: other.Address(), Name: "config", Version: 7},
+	)
+	require.NoError(t, err)
+
+	// the sequence number is pinned, whatever the loaded account
+	assert.Equal(t, int64(101), tx.SequenceNumber())
+	assert.Equal(t, int64(200), loaded.Sequence)
+	assert.Equal(t, 3, tx.GuardOperations())
+	assert.Equal(t, []Operation{
+		&ClaimClaimableBalance{BalanceID: guardBalanceID},
+		&ManageData{Name: "config.7", SourceAccount: other.Address()},
+		&ManageData{Name: "config.8", Value: []byte("8"), SourceAccount: other.Address()},
+		&BumpSequence{BumpTo: 0},
+	}, tx.Operations())
+	assert.Equal(t, map[int]Annotations{3: {"step": "bump"}}, tx.AllOperationAnnotations())
+
+	_, err = NewGuardedTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: other.Address()},
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	}, SequenceGuard{AccountID: kp.Address(), Sequence: 100})
+	assert.EqualError(t, err, "sequence guard account is not the source account of the transaction")
+
+	_, err = NewGuardedTransaction(TransactionParams{
+		SourceAccount: loaded,
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	}, ClaimableBalanceGuard{})
+	assert.EqualError(t, err, "invalid guard 0: claimable balance guard has no balance id")
+}
+
+func TestDataVersionGuard(t *testing.T) {
+	guard := DataVersionGuard{Name: "config", Version: 7}
+	assert.Equal(t, &ManageData{Name: "config.7", Value: []byte("7")}, guard.Initialize())
+
+	// the name of the entry of the next version must fit in 64 bytes
+	guard.Name = strings.Repeat("a", 62)
+	_, err := guard.GuardOperations()
+	assert.NoError(t, err)
+	guard.Version = 9
+	_, err = guard.GuardOperations()
+	assert.EqualError(t, err, `data entry name "`+guard.Name+`.10" is longer than 64 bytes`)
+
+	guard = DataVersionGuard{Name: "config", Version: ^uint64(0)}
+	_, err = guard.GuardOperations()
+	assert.EqualError(t, err, "data version guard version overflows")
+}
+
+func TestGuardedTransactionFailedGuards(t *testing.T) {
+	kp := keypair.MustRandom()
+	sequence := SequenceGuard{AccountID: kp.Address(), Sequence: 100}
+	balance := ClaimableBalanceGuard{BalanceID: guardBalanceID}
+	data := DataVersionGuard{Name: "config", Version: 7}
+	tx, err := NewGuardedTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: kp.Address()},
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	}, sequence, balance, data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Guard{sequence}, tx.FailedGuards("tx_bad_seq", nil))
+	assert.Equal(t, []Guard{balance, data}, tx.FailedGuards("tx_failed", []string{
+		"op_does_not_exist", "op_data_name_not_found", "op_success", "op_success",
+	}))
+	assert.Equal(t, []Guard{data}, tx.FailedGuards("tx_failed", []string{
+		"op_success", "op_data_name_not_found", "op_success", "op_success",
+	}))
+	// the failures of the other operations are not the guards'
+	assert.Empty(t, tx.FailedGuards("tx_failed", []string{
+		"op_success", "op_success", "op_success", "op_underfunded",
+	}))
+	assert.Empty(t, tx.FailedGuards("tx_insufficient_fee", nil))
+}
@@ -13,11 +13,21 @@ import (
 // It is a wrapper around the IsValidEd25519PublicKey method of the strkey package.
 func validateStellarPublicKey(publicKey string) error {
 	if publicKey == "" {
-		return errors.New("public key is undefined")
+		return newCodedError(CodePublicKeyUndefined, nil, "public key is undefined")
 	}
 
 	if !strkey.IsValidEd25519PublicKey(publicKey) {
-		return errors.Errorf("%s is not a valid stellar public key", publicKey)
+		return newCodedError(CodePublicKeyInvalid, map[string]string{"value": publicKey},
+			"%s is not a valid stellar public key", publicKey)
+	}
+	return nil
+}
+
+// validateStellarMuxedAddress returns an error if an account address, either a G... or a M... address, is
+// invalid. Otherwise, it returns nil.
+func validateStellarMuxedAddress(address string) error {
+	if _, err := xdr.AddressToMuxedAccount(address); err != nil {
+		return withCode(CodePublicKeyInvalid, map[string]string{"value": address}, "", err)
 	}
 	return nil
 }
@@ -25,12 +35,13 @@ func validateStellarPublicKey(publicKey string) error {
 // validateStellarSignerKey returns an error if a signerkey is invalid. Otherwise, it returns nil.
 func validateStellarSignerKey(signerKey string) error {
 	if signerKey == "" {
-		return errors.New("signer key is undefined")
+		return newCodedError(CodeSignerKeyUndefined, nil, "signer key is undefined")
 	}
 
 	var xdrKey xdr.SignerKey
 	if err := xdrKey.SetAddress(signerKey); err != nil {
-		return errors.Errorf("%s is not a valid stellar signer key", signerKey)
+		return newCodedError(CodeSignerKeyInvalid, map[string]string{"value": signerKey},
+			"%s is not a valid stellar signer key", signerKey)
 	}
 	return nil
 }
@@ -39,7 +50,7 @@ func validateStellarSignerKey(signerKey string) error {
 // nil, has an invalid asset code or issuer.
 func validateStellarAsset(asset BasicAsset) error {
 	if asset == nil {
-		return errors.New("asset is undefined")
+		return newCodedError(CodeAssetUndefined, nil, "asset is undefined")
 	}
 
 	if asset.IsNative() {
@@ -53,7 +64,7 @@ func validateStellarAsset(asset BasicAsset) error {
 
 	err = validateStellarPublicKey(asset.GetIssuer())
 	if err != nil {
-		return errors.Errorf("asset issuer: %s", err.Error())
+		return withCode(CodeAssetIssuerInvalid, map[string]string{"value": asset.GetIssuer()}, "asset issuer: ", err)
 	}
 
 	return nil
@@ -71,28 +82,30 @@ func validateAmount(n interface{}) error {
 	case string:
 		v, err := amount.ParseInt64(value)
 		if err != nil {
-			return err
+			return withCode(CodeAmountInvalid, map[string]string{"value": value}, "", err)
 		}
 		stellarAmount = v
 	default:
-		return errors.Errorf("could not parse expected numeric value %v", n)
+		return newCodedError(CodeAmountInvalid, map[string]string{"value": fmt.Sprint(n)},
+			"could not parse expected numeric value %v", n)
 	}
 
 	if stellarAmount < 0 {
-		return errors.New("amount can not be negative")
+		return newCodedError(CodeAmountNegative, nil, "amount can not be negative")
 	}
 	return nil
 }
 
 func validatePrice(p xdr.Price) error {
+	params := map[string]string{"n": fmt.Sprint(p.N), "d": fmt.Sprint(p.D)}
 	if p.N == 0 {
-		return errors.Errorf("price cannot be 0: %d/%d", p.N, p.D)
+		return newCodedError(CodePriceZero, params, "price cannot be 0: %d/%d", p.N, p.D)
 	}
 	if p.D == 0 {
-		return errors.Errorf("price denominator cannot be 0: %d/%d", p.N, p.D)
+		return newCodedError(CodePriceDenominatorZero, params, "price denominator cannot be 0: %d/%d", p.N, p.D)
 	}
 	if p.N < 0 || p.D < 0 {
-		return errors.Errorf("price cannot be negative: %d/%d", p.N, p.D)
+		return newCodedError(CodePriceNegative, params, "price cannot be negative: %d/%d", p.N, p.D)
 	}
 	return nil
 }
@@ -107,11 +120,11 @@ func validateAssetCode(asset BasicAsset) error {
 	// - asset code is valid
 	// - asset issuer is not required. This is actually ignored by the operation
 	if asset == nil {
-		return errors.New("asset is undefined")
+		return newCodedError(CodeAssetUndefined, nil, "asset is undefined")
 	}
 
 	if asset.IsNative() {
-		return errors.New("native (XLM) asset type is not allowed")
+		return newCodedError(CodeAssetNativeNotAllowed, nil, "native (XLM) asset type is not allowed")
 	}
 
 	_, err := asset.GetType()
@@ -139,18 +152,58 @@ func validateChangeTrustAsset(asset ChangeTrustAsset) error {
 	if err != nil {
 		return err
 	} else if assetType == AssetTypePoolShare {
-		// No issuer for these to validate.
-		return nil
+		// No issuer for these to validate, only the pool parameters.
+		params, ok := asset.GetLiquidityPoolParameters()
+		if !ok {
+			return newCodedError(CodePoolParametersUndefined, nil, "liquidity pool parameters are undefined")
+		}
+		return validateLiquidityPoolParameters(params)
 	}
 
 	err = validateStellarPublicKey(asset.GetIssuer())
 	if err != nil {
-		return errors.Errorf("asset issuer: %s", err.Error())
+		return withCode(CodeAssetIssuerInvalid, map[string]string{"value": asset.GetIssuer()}, "asset issuer: ", err)
 	}
 
 	return nil
 }
 
+// validateLiquidityPoolParameters checks if the provided parameters describe a valid constant product
+// liquidity pool: both assets must be valid and distinct, sorted in lexicographic order, and the fee
+// must be LiquidityPoolFeeV18. It returns an error if the parameters are invalid.
+func validateLiquidityPoolParameters(params LiquidityPoolParameters) error {
+	err := validateStellarAsset(params.AssetA)
+	if err != nil {
+		return withCode(CodePoolAssetInvalid, map[string]string{"asset": "A"}, "liquidity pool asset A: ", err)
+	}
+	err = validateStellarAsset(params.AssetB)
+	if err != nil {
+		return withCode(CodePoolAssetInvalid, map[string]string{"asset": "B"}, "liquidity pool asset B: ", err)
+	}
+
+	xdrAssetA, err := params.AssetA.ToXDR()
+	if err != nil {
+		return withCode(CodePoolAssetInvalid, map[string]string{"asset": "A"}, "liquidity pool asset A: ", err)
+	}
+	xdrAssetB, err := params.AssetB.ToXDR()
+	if err != nil {
+		return withCode(CodePoolAssetInvalid, map[string]string{"asset": "B"}, "liquidity pool asset B: ", err)
+	}
+	if xdrAssetA.Equals(xdrAssetB) {
+		return newCodedError(CodePoolAssetsEqual, nil, "liquidity pool assets must be different")
+	}
+	if !xdrAssetA.LessThan(xdrAssetB) {
+		return newCodedError(CodePoolAssetsUnordered, nil,
+			"liquidity pool assets must be in lexicographic order (AssetA < AssetB)")
+	}
+
+	if params.Fee != LiquidityPoolFeeV18 {
+		return newCodedError(CodePoolFeeInvalid, map[string]string{"fee": fmt.Sprint(LiquidityPoolFeeV18)},
+			"liquidity pool fee must be %d", LiquidityPoolFeeV18)
+	}
+	return nil
+}
+
 // validatePassiveOffer checks if the fields of a CreatePassiveOffer struct are valid.
 // It checks that the buying and selling assets are valid stellar assets, and that amount and price are valid.
 // It returns an error if any field is invalid.
@@ -159,22 +212,22 @@ func validatePassiveOffer(buying, selling Asset, offerAmount string, price xdr.P
 	// https://github.com/stellar/go/pull/1707#discussion_r321508440
 	err := validateStellarAsset(buying)
 	if err != nil {
-		return NewValidationError("Buying", err.Error())
+		return fieldValidationError("Buying", err)
 	}
 
 	err = validateStellarAsset(selling)
 	if err != nil {
-		return NewValidationError("Selling", err.Error())
+		return fieldValidationError("Selling", err)
 	}
 
 	err = validateAmount(offerAmount)
 	if err != nil {
-		return NewValidationError("Amount", err.Error())
+		return fieldValidationError("Amount", err)
 	}
 
 	err = validatePrice(price)
 	if err != nil {
-		return NewValidationError("Price", err.Error())
+		return fieldValidationError("Price", err)
 	}
 
 	return nil
@@ -191,15 +244,17 @@ func validateOffer(buying, selling Asset, offerAmount string, price xdr.Price, o
 
 	err = validateAmount(offerID)
 	if err != nil {
-		return NewValidationError("OfferID", err.Error())
+		return fieldValidationError("OfferID", err)
 	}
 	return nil
 }
 
 // ValidationError is a custom error struct that holds validation errors of txnbuild's operation structs.
 type ValidationError struct {
-	Field   string // Field is the struct field on which the validation error occured.
-	Message string // Message is the validation error message.
+	Field   string            // Field is the struct field on which the validation error occured.
+	Message string            // Message is the validation error message.
+	Code    ErrorCode         // Code is the machine readable reason of the error.
+	Params  map[string]string // Params are the parameters of the error, see the ErrorCode constants.
 }
 
 // Error for ValidationError struct implements the error interface.
@@ -207,11 +262,24 @@ func (opError *ValidationError) Error() string {
 	return fmt.Sprintf("Field: %s, Error: %s", opError.Field, opError.Message)
 }
 
-// NewValidationError creates a ValidationError struct with the provided field and message values.
+// NewValidationError creates a ValidationError struct with the provided field and message values,
+// and the CodeInvalid code.
 func NewValidationError(field, message string) *ValidationError {
+	return NewCodedValidationError(field, CodeInvalid, nil, message)
+}
+
+// NewCodedValidationError creates a ValidationError struct with the provided field, code, parameters and
+// message values. The "field" parameter is set to field.
+func NewCodedValidationError(field string, code ErrorCode, params map[string]string, message string) *ValidationError {
+	merged := map[string]string{"field": field}
+	for name, value := range params {
+		merged[name] = value
+	}
 	return &ValidationError{
 		Field:   field,
 		Message: message,
+		Code:    code,
+		Params:  merged,
 	}
 }
 
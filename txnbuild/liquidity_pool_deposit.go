@@ -28,10 +28,6 @@ func NewLiquidityPoolDeposit(
 	minPrice,
 	maxPrice xdr.Price,
 ) (LiquidityPoolDeposit, error) {
-	if b.Asset.LessThan(a.Asset) {
-		return LiquidityPoolDeposit{}, errors.New("AssetA must be <= AssetB")
-	}
-
 	poolId, err := NewLiquidityPoolId(a.Asset, b.Asset)
 	if err != nil {
 		return LiquidityPoolDeposit{}, err
@@ -113,22 +109,22 @@ func (lpd *LiquidityPoolDeposit) FromXDR(xdrOp xdr.Operation) error {
 func (lpd *LiquidityPoolDeposit) Validate() error {
 	err := validateAmount(lpd.MaxAmountA)
 	if err != nil {
-		return NewValidationError("MaxAmountA", err.Error())
+		return fieldValidationError("MaxAmountA", err)
 	}
 
 	err = validateAmount(lpd.MaxAmountB)
 	if err != nil {
-		return NewValidationError("MaxAmountB", err.Error())
+		return fieldValidationError("MaxAmountB", err)
 	}
 
 	err = validatePrice(lpd.MinPrice)
 	if err != nil {
-		return NewValidationError("MinPrice", err.Error())
+		return fieldValidationError("MinPrice", err)
 	}
 
 	err = validatePrice(lpd.MaxPrice)
 	if err != nil {
-		return NewValidationError("MaxPrice", err.Error())
+		return fieldValidationError("MaxPrice", err)
 	}
 
 	return nil
@@ -2,8 +2,6 @@
 package txnbuild
 
 import (
-	"fmt"
-
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
@@ -11,11 +9,10 @@ import (
 // LiquidityPoolId represents the Stellar liquidity pool id.
 type LiquidityPoolId [32]byte
 
+// NewLiquidityPoolId returns the id of the constant product liquidity pool of
+// the two assets with the fee LiquidityPoolFeeV18. The assets must be distinct
+// and sorted, see xdr.ValidatePoolAssets.
 func NewLiquidityPoolId(a, b Asset) (LiquidityPoolId, error) {
-	if b.LessThan(a) {
-		return LiquidityPoolId{}, fmt.Errorf("AssetA must be <= AssetB")
-	}
-
 	xdrAssetA, err := a.ToXDR()
 	if err != nil {
 		return LiquidityPoolId{}, errors.Wrap(err, "failed to build XDR AssetA ID")
@@ -26,6 +23,10 @@ func NewLiquidityPoolId(a, b Asset) (LiquidityPoolId, error) {
 		return LiquidityPoolId{}, errors.Wrap(err, "failed to build XDR AssetB ID")
 	}
 
+	if err = xdr.ValidatePoolAssets(xdrAssetA, xdrAssetB); err != nil {
+		return LiquidityPoolId{}, err
+	}
+
 	id, err := xdr.NewPoolId(xdrAssetA, xdrAssetB, xdr.LiquidityPoolFeeV18)
 	if err != nil {
 		return LiquidityPoolId{}, errors.Wrap(err, "failed to build XDR liquidity pool id")
@@ -33,6 +34,31 @@ func NewLiquidityPoolId(a, b Asset) (LiquidityPoolId, error) {
 	return LiquidityPoolId(id), nil
 }
 
+// NewLiquidityPoolIdWithFee returns the id of the constant product liquidity
+// pool of the two assets and fee, the SHA-256 hash of the XDR encoding of its
+// parameters. The assets must be distinct and sorted (see
+// LiquidityPoolParameters.PoolId); the protocol only supports the fee
+// LiquidityPoolFeeV18.
+func NewLiquidityPoolIdWithFee(a, b Asset, fee int32) (LiquidityPoolId, error) {
+	return LiquidityPoolParameters{AssetA: a, AssetB: b, Fee: fee}.PoolId()
+}
+
+// ParseLiquidityPoolId returns the liquidity pool id of its hex encoded form,
+// as returned by Horizon.
+func ParseLiquidityPoolId(s string) (LiquidityPoolId, error) {
+	id, err := xdr.NewPoolIdFromHex(s)
+	if err != nil {
+		return LiquidityPoolId{}, err
+	}
+	return LiquidityPoolId(id), nil
+}
+
+// String returns the hex encoded form of the liquidity pool id, as used by
+// Horizon.
+func (lpi LiquidityPoolId) String() string {
+	return xdr.PoolId(lpi).HexString()
+}
+
 func (lpi LiquidityPoolId) ToXDR() (xdr.PoolId, error) {
 	return xdr.PoolId(lpi), nil
 }
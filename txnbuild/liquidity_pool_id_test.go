@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewLiquidityPoolId(t *testing.T) {
@@ -23,5 +24,46 @@ func TestNewLiquidityPoolId(t *testing.T) {
 	// Wrong asset id order should fail. If users mess this up, and we were to
 	// silently fix it they could set the wrong MaxAmounts when depositing.
 	_, err = NewLiquidityPoolId(b, a)
-	assert.EqualError(t, err, "AssetA must be <= AssetB")
+	assert.EqualError(t, err, "AssetA must be < AssetB")
+	_, err = NewLiquidityPoolId(a, a)
+	assert.EqualError(t, err, "AssetA and AssetB must be different")
+}
+
+func TestLiquidityPoolParametersPoolId(t *testing.T) {
+	a := NativeAsset{}
+	b := CreditAsset{Code: "ABC", Issuer: "GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3"}
+
+	// the assets are sorted
+	params, err := NewLiquidityPoolParameters(b, a)
+	require.NoError(t, err)
+	assert.Equal(t, LiquidityPoolParameters{AssetA: a, AssetB: b, Fee: LiquidityPoolFeeV18}, params)
+
+	expected, err := NewLiquidityPoolId(a, b)
+	require.NoError(t, err)
+	id, err := params.PoolId()
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+	id, err = NewLiquidityPoolIdWithFee(a, b, LiquidityPoolFeeV18)
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+
+	// the fee is part of the parameters hashed
+	id, err = NewLiquidityPoolIdWithFee(a, b, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, id)
+
+	_, err = NewLiquidityPoolIdWithFee(b, a, LiquidityPoolFeeV18)
+	assert.EqualError(t, err, "failed to build XDR liquidity pool id: AssetA must be < AssetB")
+	_, err = NewLiquidityPoolIdWithFee(a, a, LiquidityPoolFeeV18)
+	assert.EqualError(t, err, "failed to build XDR liquidity pool id: AssetA and AssetB must be different")
+}
+
+func TestParseLiquidityPoolId(t *testing.T) {
+	hex := "cc22414997d7e3d9a9ac3b1d65ca9cc3e5f35ce33e0bd6a885648b11aaa3b72d"
+	id, err := ParseLiquidityPoolId(hex)
+	require.NoError(t, err)
+	assert.Equal(t, hex, id.String())
+
+	_, err = ParseLiquidityPoolId("zz")
+	assert.Error(t, err)
 }
@@ -17,6 +17,36 @@ type LiquidityPoolParameters struct {
 	Fee    int32
 }
 
+// NewLiquidityPoolParameters returns the parameters of the constant product
+// liquidity pool of the two assets with the fee LiquidityPoolFeeV18. The
+// assets can be given in any order, they are sorted as required by the
+// protocol.
+func NewLiquidityPoolParameters(a, b Asset) (LiquidityPoolParameters, error) {
+	if b.LessThan(a) {
+		a, b = b, a
+	}
+	params := LiquidityPoolParameters{AssetA: a, AssetB: b, Fee: LiquidityPoolFeeV18}
+	if err := validateLiquidityPoolParameters(params); err != nil {
+		return LiquidityPoolParameters{}, err
+	}
+	return params, nil
+}
+
+// PoolId returns the id of the liquidity pool of the parameters. The assets
+// must be distinct and in lexicographic order (AssetA < AssetB), see
+// xdr.ValidatePoolAssets.
+func (lpi LiquidityPoolParameters) PoolId() (LiquidityPoolId, error) {
+	params, err := lpi.ToXDR()
+	if err != nil {
+		return LiquidityPoolId{}, err
+	}
+	id, err := params.PoolId()
+	if err != nil {
+		return LiquidityPoolId{}, errors.Wrap(err, "failed to build XDR liquidity pool id")
+	}
+	return LiquidityPoolId(id), nil
+}
+
 func (lpi LiquidityPoolParameters) ToXDR() (xdr.LiquidityPoolParameters, error) {
 	xdrAssetA, err := lpi.AssetA.ToXDR()
 	if err != nil {
@@ -26,10 +26,6 @@ func NewLiquidityPoolWithdraw(
 	a, b AssetAmount,
 	amount string,
 ) (LiquidityPoolWithdraw, error) {
-	if b.Asset.LessThan(a.Asset) {
-		return LiquidityPoolWithdraw{}, errors.New("AssetA must be <= AssetB")
-	}
-
 	poolId, err := NewLiquidityPoolId(a.Asset, b.Asset)
 	if err != nil {
 		return LiquidityPoolWithdraw{}, err
@@ -109,17 +105,17 @@ func (lpd *LiquidityPoolWithdraw) FromXDR(xdrOp xdr.Operation) error {
 func (lpd *LiquidityPoolWithdraw) Validate() error {
 	err := validateAmount(lpd.Amount)
 	if err != nil {
-		return NewValidationError("Amount", err.Error())
+		return fieldValidationError("Amount", err)
 	}
 
 	err = validateAmount(lpd.MinAmountA)
 	if err != nil {
-		return NewValidationError("MinAmountA", err.Error())
+		return fieldValidationError("MinAmountA", err)
 	}
 
 	err = validateAmount(lpd.MinAmountB)
 	if err != nil {
-		return NewValidationError("MinAmountB", err.Error())
+		return fieldValidationError("MinAmountB", err)
 	}
 
 	return nil
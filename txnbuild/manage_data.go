@@ -56,11 +56,11 @@ func (md *ManageData) FromXDR(xdrOp xdr.Operation) error {
 // of the fields are invalid. Otherwise, it returns nil.
 func (md *ManageData) Validate() error {
 	if len(md.Name) > 64 {
-		return NewValidationError("Name", "maximum length is 64 characters")
+		return NewCodedValidationError("Name", CodeTooLong, map[string]string{"max": "64"}, "maximum length is 64 characters")
 	}
 
 	if len(md.Value) > 64 {
-		return NewValidationError("Value", "maximum length is 64 bytes")
+		return NewCodedValidationError("Value", CodeTooLong, map[string]string{"max": "64"}, "maximum length is 64 bytes")
 	}
 	return nil
 }
@@ -0,0 +1,107 @@
+package txnbuild
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// CombineSignatures returns a new Transaction instance with the signatures of
+// the transaction and of others, partially signed copies of the same
+// transaction collected from its signers, e.g. decoded with
+// TransactionFromXDR. The signatures present in several copies are kept once.
+//
+// It returns an error if one of the others is not the same transaction on the
+// given network.
+func (t *Transaction) CombineSignatures(network string, others ...*Transaction) (*Transaction, error) {
+	hash, err := t.Hash(network)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash transaction")
+	}
+
+	combined := uniqueSignatures(nil, t.Signatures())
+	for i, other := range others {
+		otherHash, err := other.Hash(network)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not hash transaction %d", i)
+		}
+		if otherHash != hash {
+			return nil, errors.Errorf("transaction %d is not the same transaction", i)
+		}
+		combined = uniqueSignatures(combined, other.Signatures())
+	}
+	return t.clone(combined), nil
+}
+
+// DuplicateSignatures returns the signatures of the transaction which repeat
+// an earlier one, with the same hint and signature, in the order they are
+// attached. They take room in the envelope, which is limited to 20
+// signatures, and make stellar-core reject the transaction with
+// txBAD_AUTH_EXTRA.
+func (t *Transaction) DuplicateSignatures() []xdr.DecoratedSignature {
+	var duplicates []xdr.DecoratedSignature
+	signatures := t.Signatures()
+	for i, signature := range signatures {
+		if containsSignature(signatures[:i], signature) {
+			duplicates = append(duplicates, signature)
+		}
+	}
+	return duplicates
+}
+
+// SignerStatus reports which of the signers of an account, e.g. the
+// SignerSummary() of its horizon.Account record, signed the transaction and
+// which did not, so that a multisig coordinator knows whom to ask for the
+// missing signatures. The signers are sorted by address, and those with a
+// weight of 0, which cannot sign, are ignored.
+//
+// The ed25519 public key (G...), pre-authorized transaction (T...) and hash
+// (X...) signers are supported, like in SignaturesSatisfy.
+func (t *Transaction) SignerStatus(network string, signers SignerSummary) (signed []string, missing []string, err error) {
+	hash, err := t.Hash(network)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not hash transaction")
+	}
+
+	addresses := make([]string, 0, len(signers))
+	for signer, weight := range signers {
+		if weight > 0 {
+			addresses = append(addresses, signer)
+		}
+	}
+	sort.Strings(addresses)
+
+	for _, signer := range addresses {
+		ok, err := signedBy(hash, t.Signatures(), signer)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			signed = append(signed, signer)
+		} else {
+			missing = append(missing, signer)
+		}
+	}
+	return signed, missing, nil
+}
+
+// uniqueSignatures appends the signatures which are not in signatures yet.
+func uniqueSignatures(signatures, others []xdr.DecoratedSignature) []xdr.DecoratedSignature {
+	for _, signature := range others {
+		if !containsSignature(signatures, signature) {
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures
+}
+
+func containsSignature(signatures []xdr.DecoratedSignature, signature xdr.DecoratedSignature) bool {
+	for _, s := range signatures {
+		if s.Hint == signature.Hint && bytes.Equal(s.Signature, signature.Signature) {
+			return true
+		}
+	}
+	return false
+}
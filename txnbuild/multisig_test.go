@@ -0,0 +1,93 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+)
+
+func multisigTransaction(t *testing.T, source *keypair.Full, sequence int64) *Transaction {
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: source.Address(), Sequence: sequence},
+		IncrementSequenceNum: true,
+		Operations:           []Operation{&BumpSequence{BumpTo: 10}},
+		BaseFee:              MinBaseFee,
+		Timebounds:           NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestCombineSignatures(t *testing.T) {
+	source := keypair.MustRandom()
+	signer1 := keypair.MustRandom()
+	signer2 := keypair.MustRandom()
+	tx := multisigTransaction(t, source, 1)
+
+	copy1, err := tx.Sign(network.TestNetworkPassphrase, source, signer1)
+	require.NoError(t, err)
+	copy2, err := tx.Sign(network.TestNetworkPassphrase, signer2, source)
+	require.NoError(t, err)
+
+	// the copies are usually exchanged as envelopes
+	copy2B64, err := copy2.Base64()
+	require.NoError(t, err)
+	parsed, err := TransactionFromXDR(copy2B64)
+	require.NoError(t, err)
+	decoded, ok := parsed.Transaction()
+	require.True(t, ok)
+
+	combined, err := copy1.CombineSignatures(network.TestNetworkPassphrase, decoded)
+	require.NoError(t, err)
+	require.Len(t, combined.Signatures(), 3)
+	assert.Equal(t, copy1.Signatures()[0], combined.Signatures()[0])
+	assert.Equal(t, copy1.Signatures()[1], combined.Signatures()[1])
+	assert.Equal(t, copy2.Signatures()[0], combined.Signatures()[2])
+	assert.Len(t, copy1.Signatures(), 2)
+	assert.Empty(t, combined.DuplicateSignatures())
+
+	_, err = copy1.CombineSignatures(network.TestNetworkPassphrase, copy2, multisigTransaction(t, source, 2))
+	assert.EqualError(t, err, "transaction 1 is not the same transaction")
+}
+
+func TestDuplicateSignatures(t *testing.T) {
+	source := keypair.MustRandom()
+	signer := keypair.MustRandom()
+	tx, err := multisigTransaction(t, source, 1).Sign(network.TestNetworkPassphrase, source, signer, source)
+	require.NoError(t, err)
+	tx, err = tx.AddSignatureDecorated(tx.Signatures()[1])
+	require.NoError(t, err)
+
+	assert.Equal(t, tx.Signatures()[2:], tx.DuplicateSignatures())
+}
+
+func TestSignerStatus(t *testing.T) {
+	source := keypair.MustRandom()
+	signer1 := keypair.MustRandom()
+	signer2 := keypair.MustRandom()
+	removed := keypair.MustRandom()
+	tx, err := multisigTransaction(t, source, 1).Sign(network.TestNetworkPassphrase, signer1, removed)
+	require.NoError(t, err)
+
+	signers := SignerSummary{
+		source.Address():  1,
+		signer1.Address(): 1,
+		signer2.Address(): 2,
+		removed.Address(): 0,
+	}
+	signed, missing, err := tx.SignerStatus(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{signer1.Address()}, signed)
+	expectedMissing := []string{source.Address(), signer2.Address()}
+	if expectedMissing[0] > expectedMissing[1] {
+		expectedMissing[0], expectedMissing[1] = expectedMissing[1], expectedMissing[0]
+	}
+	assert.Equal(t, expectedMissing, missing)
+
+	_, _, err = tx.SignerStatus(network.TestNetworkPassphrase, SignerSummary{"GBOB": 1})
+	assert.Contains(t, err.Error(), "invalid signer GBOB")
+}
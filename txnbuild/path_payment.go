@@ -132,29 +132,29 @@ func (pp *PathPaymentStrictReceive) FromXDR(xdrOp xdr.Operation) error {
 // Validate for PathPaymentStrictReceive validates the required struct fields. It returns an error if any
 // of the fields are invalid. Otherwise, it returns nil.
 func (pp *PathPaymentStrictReceive) Validate() error {
-	_, err := xdr.AddressToMuxedAccount(pp.Destination)
+	err := validateStellarMuxedAddress(pp.Destination)
 	if err != nil {
-		return NewValidationError("Destination", err.Error())
+		return fieldValidationError("Destination", err)
 	}
 
 	err = validateStellarAsset(pp.SendAsset)
 	if err != nil {
-		return NewValidationError("SendAsset", err.Error())
+		return fieldValidationError("SendAsset", err)
 	}
 
 	err = validateStellarAsset(pp.DestAsset)
 	if err != nil {
-		return NewValidationError("DestAsset", err.Error())
+		return fieldValidationError("DestAsset", err)
 	}
 
 	err = validateAmount(pp.SendMax)
 	if err != nil {
-		return NewValidationError("SendMax", err.Error())
+		return fieldValidationError("SendMax", err)
 	}
 
 	err = validateAmount(pp.DestAmount)
 	if err != nil {
-		return NewValidationError("DestAmount", err.Error())
+		return fieldValidationError("DestAmount", err)
 	}
 
 	return nil
@@ -125,29 +125,29 @@ func (pp *PathPaymentStrictSend) FromXDR(xdrOp xdr.Operation) error {
 // Validate for PathPaymentStrictSend validates the required struct fields. It returns an error if any
 // of the fields are invalid. Otherwise, it returns nil.
 func (pp *PathPaymentStrictSend) Validate() error {
-	_, err := xdr.AddressToMuxedAccount(pp.Destination)
+	err := validateStellarMuxedAddress(pp.Destination)
 	if err != nil {
-		return NewValidationError("Destination", err.Error())
+		return fieldValidationError("Destination", err)
 	}
 
 	err = validateStellarAsset(pp.SendAsset)
 	if err != nil {
-		return NewValidationError("SendAsset", err.Error())
+		return fieldValidationError("SendAsset", err)
 	}
 
 	err = validateStellarAsset(pp.DestAsset)
 	if err != nil {
-		return NewValidationError("DestAsset", err.Error())
+		return fieldValidationError("DestAsset", err)
 	}
 
 	err = validateAmount(pp.SendAmount)
 	if err != nil {
-		return NewValidationError("SendAmount", err.Error())
+		return fieldValidationError("SendAmount", err)
 	}
 
 	err = validateAmount(pp.DestMin)
 	if err != nil {
-		return NewValidationError("DestMin", err.Error())
+		return fieldValidationError("DestMin", err)
 	}
 
 	return nil
@@ -77,20 +77,20 @@ func (p *Payment) FromXDR(xdrOp xdr.Operation) error {
 // Validate for Payment validates the required struct fields. It returns an error if any
 // of the fields are invalid. Otherwise, it returns nil.
 func (p *Payment) Validate() error {
-	_, err := xdr.AddressToMuxedAccount(p.Destination)
+	err := validateStellarMuxedAddress(p.Destination)
 
 	if err != nil {
-		return NewValidationError("Destination", err.Error())
+		return fieldValidationError("Destination", err)
 	}
 
 	err = validateStellarAsset(p.Asset)
 	if err != nil {
-		return NewValidationError("Asset", err.Error())
+		return fieldValidationError("Asset", err)
 	}
 
 	err = validateAmount(p.Amount)
 	if err != nil {
-		return NewValidationError("Amount", err.Error())
+		return fieldValidationError("Amount", err)
 	}
 
 	return nil
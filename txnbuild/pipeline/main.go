@@ -0,0 +1,456 @@
+// Package pipeline loads sequences of transactions described in YAML, e.g. to
+// bootstrap the accounts and assets of a network from a file kept under
+// version control:
+//
+//	network: testnet
+//	base_fee: 100
+//	accounts:
+//	  root: GA...
+//	  issuer: GB...
+//	transactions:
+//	  - name: create issuer
+//	    source: root
+//	    timeout: 300
+//	    memo: {text: bootstrap}
+//	    operations:
+//	      - type: create_account
+//	        destination: issuer
+//	        amount: "100"
+//	  - name: issue USD
+//	    source: issuer
+//	    operations:
+//	      - type: change_trust
+//	        source: root
+//	        asset: USD:issuer
+//	      - type: payment
+//	        destination: root
+//	        asset: USD:issuer
+//	        amount: "1000"
+//
+// Accounts are referenced by their name in the accounts section or by their
+// address. Assets are "native" or "CODE:issuer". The signers of a
+// transaction are its source account and the source accounts of its
+// operations unless they are listed in its signers field.
+package pipeline
+
+import (
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/keypair/addressbook"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+)
+
+// Pipeline is a sequence of transactions loaded by Load.
+type Pipeline struct {
+	NetworkPassphrase string
+	BaseFee           int64
+	// Accounts are the addresses of the accounts, by name.
+	Accounts map[string]string
+	Steps    []Step
+}
+
+// Step is a transaction of a Pipeline, with its accounts resolved to
+// addresses.
+type Step struct {
+	Name          string
+	SourceAccount string
+	Operations    []txnbuild.Operation
+	Memo          txnbuild.Memo
+	// Timeout is the number of seconds the transaction is valid for once it
+	// is built, 0 if it does not expire.
+	Timeout int64
+	// Signers are the addresses of the accounts which must sign the
+	// transaction.
+	Signers []string
+}
+
+// AccountLoader loads the source account of a transaction, with its current
+// sequence number. A function calling horizonclient.Client.AccountDetail can
+// be used.
+type AccountLoader func(address string) (txnbuild.Account, error)
+
+type document struct {
+	Network      string            `yaml:"network"`
+	BaseFee      int64             `yaml:"base_fee"`
+	Accounts     map[string]string `yaml:"accounts"`
+	Transactions []transaction     `yaml:"transactions"`
+}
+
+type transaction struct {
+	Name       string      `yaml:"name"`
+	Source     string      `yaml:"source"`
+	Timeout    int64       `yaml:"timeout"`
+	Memo       memo        `yaml:"memo"`
+	Operations []operation `yaml:"operations"`
+	Signers    []string    `yaml:"signers"`
+}
+
+type memo struct {
+	Text *string `yaml:"text"`
+	ID   *uint64 `yaml:"id"`
+	Hash string  `yaml:"hash"`
+}
+
+type signer struct {
+	Account string `yaml:"account"`
+	Weight  uint8  `yaml:"weight"`
+}
+
+// operation contains the fields of all the operation types, the ones which
+// do not apply to the type are ignored.
+type operation struct {
+	Type            string   `yaml:"type"`
+	Source          string   `yaml:"source"`
+	Destination     string   `yaml:"destination"`
+	Amount          string   `yaml:"amount"`
+	Asset           string   `yaml:"asset"`
+	Limit           string   `yaml:"limit"`
+	Name            string   `yaml:"name"`
+	Value           *string  `yaml:"value"`
+	Trustor         string   `yaml:"trustor"`
+	Sponsored       string   `yaml:"sponsored"`
+	SetFlags        []string `yaml:"set_flags"`
+	ClearFlags      []string `yaml:"clear_flags"`
+	HomeDomain      *string  `yaml:"home_domain"`
+	MasterWeight    *uint8   `yaml:"master_weight"`
+	LowThreshold    *uint8   `yaml:"low_threshold"`
+	MediumThreshold *uint8   `yaml:"medium_threshold"`
+	HighThreshold   *uint8   `yaml:"high_threshold"`
+	Signer          *signer  `yaml:"signer"`
+}
+
+var accountFlags = map[string]txnbuild.AccountFlag{
+	"auth_required":         txnbuild.AuthRequired,
+	"auth_revocable":        txnbuild.AuthRevocable,
+	"auth_immutable":        txnbuild.AuthImmutable,
+	"auth_clawback_enabled": txnbuild.AuthClawbackEnabled,
+}
+
+var trustLineFlags = map[string]txnbuild.TrustLineFlag{
+	"authorized":                         txnbuild.TrustLineAuthorized,
+	"authorized_to_maintain_liabilities": txnbuild.TrustLineAuthorizedToMaintainLiabilities,
+	"clawback_enabled":                   txnbuild.TrustLineClawbackEnabled,
+}
+
+// Load reads a pipeline from r. Unknown fields and references to unknown
+// accounts are errors, the operations themselves are validated when the
+// transactions are built.
+func Load(r io.Reader) (*Pipeline, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read pipeline")
+	}
+	var doc document
+	if err = yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "could not parse pipeline")
+	}
+
+	if doc.Network == "" {
+		return nil, errors.New("network is missing")
+	}
+	p := &Pipeline{
+		NetworkPassphrase: addressbook.Passphrase(doc.Network),
+		BaseFee:           doc.BaseFee,
+		Accounts:          map[string]string{},
+	}
+	if p.BaseFee == 0 {
+		p.BaseFee = txnbuild.MinBaseFee
+	}
+	for name, address := range doc.Accounts {
+		if _, err := keypair.ParseAddress(address); err != nil {
+			return nil, errors.Wrapf(err, "invalid address of account %s", name)
+		}
+		p.Accounts[name] = address
+	}
+
+	for i, tx := range doc.Transactions {
+		step, err := p.step(tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid transaction %d", i)
+		}
+		p.Steps = append(p.Steps, step)
+	}
+	return p, nil
+}
+
+// address resolves a reference to an account, a name or an address.
+func (p *Pipeline) address(ref string) (string, error) {
+	if address, ok := p.Accounts[ref]; ok {
+		return address, nil
+	}
+	if _, err := keypair.ParseAddress(ref); err == nil {
+		return ref, nil
+	}
+	if strings.HasPrefix(ref, "M") {
+		if _, err := strkey.DecodeMuxedAccount(ref); err == nil {
+			return ref, nil
+		}
+	}
+	return "", errors.Errorf("unknown account %q", ref)
+}
+
+// optionalAddress resolves ref unless it is empty.
+func (p *Pipeline) optionalAddress(ref string) (string, error) {
+	if ref == "" {
+		return "", nil
+	}
+	return p.address(ref)
+}
+
+func (p *Pipeline) asset(ref string) (txnbuild.Asset, error) {
+	if ref == "native" {
+		return txnbuild.NativeAsset{}, nil
+	}
+	parts := strings.SplitN(ref, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, errors.Errorf("invalid asset %q", ref)
+	}
+	issuer, err := p.address(parts[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid issuer of asset %s", parts[0])
+	}
+	return txnbuild.CreditAsset{Code: parts[0], Issuer: issuer}, nil
+}
+
+func (p *Pipeline) step(tx transaction) (Step, error) {
+	if tx.Timeout < 0 {
+		return Step{}, errors.New("timeout is negative")
+	}
+	step := Step{Name: tx.Name, Timeout: tx.Timeout}
+	var err error
+	if step.SourceAccount, err = p.address(tx.Source); err != nil {
+		return step, errors.Wrap(err, "invalid source")
+	}
+	if step.Memo, err = tx.Memo.build(); err != nil {
+		return step, err
+	}
+	if len(tx.Operations) == 0 {
+		return step, errors.New("no operations")
+	}
+
+	signers := []string{step.SourceAccount}
+	for i, op := range tx.Operations {
+		built, source, err := p.operation(op)
+		if err != nil {
+			return step, errors.Wrapf(err, "invalid operation %d (%s)", i, op.Type)
+		}
+		step.Operations = append(step.Operations, built)
+		if source != "" {
+			signers = append(signers, source)
+		}
+	}
+	if len(tx.Signers) > 0 {
+		signers = nil
+		for _, ref := range tx.Signers {
+			address, err := p.address(ref)
+			if err != nil {
+				return step, errors.Wrap(err, "invalid signer")
+			}
+			signers = append(signers, address)
+		}
+	}
+	seen := map[string]bool{}
+	for _, signer := range signers {
+		if !seen[signer] {
+			seen[signer] = true
+			step.Signers = append(step.Signers, signer)
+		}
+	}
+	return step, nil
+}
+
+func (m memo) build() (txnbuild.Memo, error) {
+	switch {
+	case m.Text != nil && m.ID == nil && m.Hash == "":
+		return txnbuild.MemoText(*m.Text), nil
+	case m.Text == nil && m.ID != nil && m.Hash == "":
+		return txnbuild.MemoID(*m.ID), nil
+	case m.Text == nil && m.ID == nil && m.Hash != "":
+		var hash txnbuild.MemoHash
+		decoded, err := hex.DecodeString(m.Hash)
+		if err != nil || len(decoded) != len(hash) {
+			return nil, errors.Errorf("invalid memo hash %q", m.Hash)
+		}
+		copy(hash[:], decoded)
+		return hash, nil
+	case m.Text == nil && m.ID == nil && m.Hash == "":
+		return nil, nil
+	default:
+		return nil, errors.New("memo has several values")
+	}
+}
+
+// operation builds the operation and returns its source account, if any.
+func (p *Pipeline) operation(op operation) (txnbuild.Operation, string, error) {
+	source, err := p.optionalAddress(op.Source)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid source")
+	}
+	destination, err := p.optionalAddress(op.Destination)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid destination")
+	}
+	var asset txnbuild.Asset
+	if op.Asset != "" {
+		if asset, err = p.asset(op.Asset); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var built txnbuild.Operation
+	switch op.Type {
+	case "create_account":
+		built = &txnbuild.CreateAccount{Destination: destination, Amount: op.Amount, SourceAccount: source}
+	case "payment":
+		built = &txnbuild.Payment{Destination: destination, Amount: op.Amount, Asset: asset, SourceAccount: source}
+	case "change_trust":
+		if asset == nil {
+			return nil, "", errors.New("asset is missing")
+		}
+		line, err := asset.ToChangeTrustAsset()
+		if err != nil {
+			return nil, "", err
+		}
+		built = &txnbuild.ChangeTrust{Line: line, Limit: op.Limit, SourceAccount: source}
+	case "manage_data":
+		var value []byte
+		if op.Value != nil {
+			value = []byte(*op.Value)
+		}
+		built = &txnbuild.ManageData{Name: op.Name, Value: value, SourceAccount: source}
+	case "account_merge":
+		built = &txnbuild.AccountMerge{Destination: destination, SourceAccount: source}
+	case "begin_sponsoring_future_reserves":
+		sponsored, err := p.address(op.Sponsored)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "invalid sponsored account")
+		}
+		built = &txnbuild.BeginSponsoringFutureReserves{SponsoredID: sponsored, SourceAccount: source}
+	case "end_sponsoring_future_reserves":
+		built = &txnbuild.EndSponsoringFutureReserves{SourceAccount: source}
+	case "set_options":
+		built, err = p.setOptions(op, source)
+	case "set_trust_line_flags":
+		built, err = p.setTrustLineFlags(op, asset, source)
+	default:
+		return nil, "", errors.Errorf("unknown operation type %q", op.Type)
+	}
+	return built, source, err
+}
+
+func (p *Pipeline) setOptions(op operation, source string) (txnbuild.Operation, error) {
+	setOptions := &txnbuild.SetOptions{HomeDomain: op.HomeDomain, SourceAccount: source}
+	for _, name := range op.SetFlags {
+		flag, ok := accountFlags[name]
+		if !ok {
+			return nil, errors.Errorf("unknown account flag %q", name)
+		}
+		setOptions.SetFlags = append(setOptions.SetFlags, flag)
+	}
+	for _, name := range op.ClearFlags {
+		flag, ok := accountFlags[name]
+		if !ok {
+			return nil, errors.Errorf("unknown account flag %q", name)
+		}
+		setOptions.ClearFlags = append(setOptions.ClearFlags, flag)
+	}
+	setOptions.MasterWeight = threshold(op.MasterWeight)
+	setOptions.LowThreshold = threshold(op.LowThreshold)
+	setOptions.MediumThreshold = threshold(op.MediumThreshold)
+	setOptions.HighThreshold = threshold(op.HighThreshold)
+	if op.Signer != nil {
+		address, err := p.address(op.Signer.Account)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signer")
+		}
+		setOptions.Signer = &txnbuild.Signer{Address: address, Weight: txnbuild.Threshold(op.Signer.Weight)}
+	}
+	return setOptions, nil
+}
+
+func threshold(value *uint8) *txnbuild.Threshold {
+	if value == nil {
+		return nil
+	}
+	t := txnbuild.Threshold(*value)
+	return &t
+}
+
+func (p *Pipeline) setTrustLineFlags(op operation, asset txnbuild.Asset, source string) (txnbuild.Operation, error) {
+	trustor, err := p.address(op.Trustor)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid trustor")
+	}
+	setTrustLineFlags := &txnbuild.SetTrustLineFlags{Trustor: trustor, Asset: asset, SourceAccount: source}
+	for _, name := range op.SetFlags {
+		flag, ok := trustLineFlags[name]
+		if !ok {
+			return nil, errors.Errorf("unknown trustline flag %q", name)
+		}
+		setTrustLineFlags.SetFlags = append(setTrustLineFlags.SetFlags, flag)
+	}
+	for _, name := range op.ClearFlags {
+		flag, ok := trustLineFlags[name]
+		if !ok {
+			return nil, errors.Errorf("unknown trustline flag %q", name)
+		}
+		setTrustLineFlags.ClearFlags = append(setTrustLineFlags.ClearFlags, flag)
+	}
+	return setTrustLineFlags, nil
+}
+
+// Build builds the transactions of the steps, in order, loading their source
+// accounts with loader. An account is loaded once: the transactions of the
+// same source account get consecutive sequence numbers, so they must be
+// submitted in order.
+//
+// The accounts created by the pipeline can only be loaded once they exist,
+// so to use them as source accounts, build and submit the steps up to their
+// creation with BuildSteps first.
+func (p *Pipeline) Build(loader AccountLoader) ([]*txnbuild.Transaction, error) {
+	return p.BuildSteps(loader, 0, len(p.Steps))
+}
+
+// BuildSteps is like Build for the steps in [from, to).
+func (p *Pipeline) BuildSteps(loader AccountLoader, from, to int) ([]*txnbuild.Transaction, error) {
+	if from < 0 || to > len(p.Steps) || from > to {
+		return nil, errors.Errorf("invalid step range [%d, %d)", from, to)
+	}
+	accounts := map[string]txnbuild.Account{}
+	var transactions []*txnbuild.Transaction
+	for i, step := range p.Steps[from:to] {
+		account, ok := accounts[step.SourceAccount]
+		if !ok {
+			var err error
+			if account, err = loader(step.SourceAccount); err != nil {
+				return nil, errors.Wrapf(err, "could not load source account of transaction %d", from+i)
+			}
+			accounts[step.SourceAccount] = account
+		}
+		timebounds := txnbuild.NewInfiniteTimeout()
+		if step.Timeout > 0 {
+			timebounds = txnbuild.NewTimeout(step.Timeout)
+		}
+		tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+			SourceAccount:        account,
+			IncrementSequenceNum: true,
+			Operations:           step.Operations,
+			BaseFee:              p.BaseFee,
+			Memo:                 step.Memo,
+			Timebounds:           timebounds,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not build transaction %d", from+i)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
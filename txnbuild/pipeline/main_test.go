@@ -0,0 +1,202 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+)
+
+var (
+	root   = keypair.MustRandom().Address()
+	issuer = keypair.MustRandom().Address()
+)
+
+func definition() string {
+	return `
+network: testnet
+base_fee: 200
+accounts:
+  root: ` + root + `
+  issuer: ` + issuer + `
+transactions:
+  - name: create issuer
+    source: root
+    timeout: 300
+    memo: {text: bootstrap}
+    operations:
+      - type: create_account
+        destination: issuer
+        amount: "100"
+  - name: issue USD
+    source: issuer
+    operations:
+      - type: set_options
+        set_flags: [auth_revocable, auth_clawback_enabled]
+        home_domain: example.com
+        master_weight: 2
+        signer: {account: root, weight: 1}
+      - type: change_trust
+        source: root
+        asset: USD:issuer
+      - type: payment
+        destination: root
+        asset: USD:issuer
+        amount: "1000"
+  - name: freeze
+    source: issuer
+    memo: {id: 7}
+    signers: [issuer]
+    operations:
+      - type: set_trust_line_flags
+        trustor: root
+        asset: USD:issuer
+        clear_flags: [authorized]
+`
+}
+
+func TestLoad(t *testing.T) {
+	p, err := Load(strings.NewReader(definition()))
+	require.NoError(t, err)
+
+	assert.Equal(t, network.TestNetworkPassphrase, p.NetworkPassphrase)
+	assert.Equal(t, int64(200), p.BaseFee)
+	assert.Equal(t, map[string]string{"root": root, "issuer": issuer}, p.Accounts)
+	require.Len(t, p.Steps, 3)
+
+	create := p.Steps[0]
+	assert.Equal(t, "create issuer", create.Name)
+	assert.Equal(t, root, create.SourceAccount)
+	assert.Equal(t, txnbuild.MemoText("bootstrap"), create.Memo)
+	assert.Equal(t, int64(300), create.Timeout)
+	assert.Equal(t, []txnbuild.Operation{
+		&txnbuild.CreateAccount{Destination: issuer, Amount: "100"},
+	}, create.Operations)
+	assert.Equal(t, []string{root}, create.Signers)
+
+	issue := p.Steps[1]
+	assert.Equal(t, issuer, issue.SourceAccount)
+	assert.Nil(t, issue.Memo)
+	assert.Equal(t, int64(0), issue.Timeout)
+	require.Len(t, issue.Operations, 3)
+	homeDomain := "example.com"
+	masterWeight := txnbuild.Threshold(2)
+	assert.Equal(t, &txnbuild.SetOptions{
+		SetFlags:     []txnbuild.AccountFlag{txnbuild.AuthRevocable, txnbuild.AuthClawbackEnabled},
+		HomeDomain:   &homeDomain,
+		MasterWeight: &masterWeight,
+		Signer:       &txnbuild.Signer{Address: root, Weight: 1},
+	}, issue.Operations[0])
+	assert.Equal(t, &txnbuild.ChangeTrust{
+		Line:          txnbuild.CreditAsset{Code: "USD", Issuer: issuer}.MustToChangeTrustAsset(),
+		SourceAccount: root,
+	}, issue.Operations[1])
+	assert.Equal(t, &txnbuild.Payment{
+		Destination: root,
+		Amount:      "1000",
+		Asset:       txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+	}, issue.Operations[2])
+	assert.Equal(t, []string{issuer, root}, issue.Signers)
+
+	freeze := p.Steps[2]
+	assert.Equal(t, txnbuild.MemoID(7), freeze.Memo)
+	assert.Equal(t, []txnbuild.Operation{
+		&txnbuild.SetTrustLineFlags{
+			Trustor:    root,
+			Asset:      txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+			ClearFlags: []txnbuild.TrustLineFlag{txnbuild.TrustLineAuthorized},
+		},
+	}, freeze.Operations)
+	assert.Equal(t, []string{issuer}, freeze.Signers)
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		definition string
+		err        string
+	}{
+		{
+			"unknown field",
+			"network: testnet\nnetwrk: pubnet\n",
+			"could not parse pipeline",
+		},
+		{
+			"missing network",
+			"transactions: []\n",
+			"network is missing",
+		},
+		{
+			"invalid account",
+			"network: testnet\naccounts: {root: GABC}\n",
+			"invalid address of account root",
+		},
+		{
+			"unknown account",
+			"network: testnet\ntransactions:\n  - source: nobody\n    operations: [{type: end_sponsoring_future_reserves}]\n",
+			`invalid transaction 0: invalid source: unknown account "nobody"`,
+		},
+		{
+			"unknown operation",
+			"network: testnet\ntransactions:\n  - source: " + root + "\n    operations: [{type: inflation}]\n",
+			`invalid transaction 0: invalid operation 0 (inflation): unknown operation type "inflation"`,
+		},
+		{
+			"unknown flag",
+			"network: testnet\ntransactions:\n  - source: " + root + "\n    operations: [{type: set_options, set_flags: [frozen]}]\n",
+			`unknown account flag "frozen"`,
+		},
+		{
+			"several memos",
+			"network: testnet\ntransactions:\n  - source: " + root + "\n    memo: {id: 1, text: a}\n    operations: [{type: end_sponsoring_future_reserves}]\n",
+			"memo has several values",
+		},
+		{
+			"no operations",
+			"network: testnet\ntransactions:\n  - source: " + root + "\n",
+			"invalid transaction 0: no operations",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(test.definition))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	p, err := Load(strings.NewReader(definition()))
+	require.NoError(t, err)
+
+	loaded := map[string]int{}
+	loader := func(address string) (txnbuild.Account, error) {
+		loaded[address]++
+		account := txnbuild.NewSimpleAccount(address, 100)
+		return &account, nil
+	}
+	txs, err := p.Build(loader)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	assert.Equal(t, map[string]int{root: 1, issuer: 1}, loaded)
+
+	assert.Equal(t, int64(101), txs[0].SourceAccount().Sequence)
+	assert.Equal(t, int64(101), txs[1].SourceAccount().Sequence)
+	assert.Equal(t, int64(102), txs[2].SourceAccount().Sequence)
+	assert.Equal(t, int64(200), txs[1].BaseFee())
+	assert.NotEqual(t, int64(txnbuild.TimeoutInfinite), txs[0].Timebounds().MaxTime)
+	assert.Equal(t, int64(txnbuild.TimeoutInfinite), txs[1].Timebounds().MaxTime)
+
+	txs, err = p.BuildSteps(loader, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, int64(101), txs[0].SourceAccount().Sequence)
+
+	_, err = p.BuildSteps(loader, 2, 4)
+	assert.EqualError(t, err, "invalid step range [2, 4)")
+}
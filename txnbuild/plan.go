@@ -0,0 +1,212 @@
+package txnbuild
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+)
+
+// PlanPhase is a phase of the execution of a Plan.
+type PlanPhase string
+
+const (
+	// PlanPhaseBuild builds the transaction from Plan.Build.
+	PlanPhaseBuild PlanPhase = "build"
+	// PlanPhaseSimulate checks the transaction with Plan.Simulate.
+	PlanPhaseSimulate PlanPhase = "simulate"
+	// PlanPhaseSign signs the transaction with Plan.Signers.
+	PlanPhaseSign PlanPhase = "sign"
+	// PlanPhaseSubmit submits the transaction with Plan.Submit.
+	PlanPhaseSubmit PlanPhase = "submit"
+)
+
+// PlanEvent describes a phase of an attempt of a Plan.
+type PlanEvent struct {
+	Phase PlanPhase
+	// Attempt is the number of the attempt, starting at 1.
+	Attempt int
+	// Transaction is the transaction of the attempt, nil before it is built.
+	// It is signed after the sign phase.
+	Transaction *Transaction
+	// Duration and Err are the duration and the error of the phase, zero
+	// before it runs.
+	Duration time.Duration
+	Err      error
+}
+
+// PlanHook observes the phases of a Plan, e.g. to log or measure them. Before
+// is called before every phase, and can veto it by returning an error, which
+// fails the phase. After is called after every phase which was not vetoed,
+// with its outcome. Either function can be nil.
+type PlanHook struct {
+	Before func(ctx context.Context, event PlanEvent) error
+	After  func(ctx context.Context, event PlanEvent)
+}
+
+// PlanError is returned by Plan.Execute when a phase of its last attempt
+// failed.
+type PlanError struct {
+	Phase   PlanPhase
+	Attempt int
+	Err     error
+}
+
+func (e *PlanError) Error() string {
+	return fmt.Sprintf("%s phase of attempt %d failed: %v", e.Phase, e.Attempt, e.Err)
+}
+
+// Cause returns the error of the phase, see errors.Cause.
+func (e *PlanError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the error of the phase.
+func (e *PlanError) Unwrap() error {
+	return e.Err
+}
+
+// Plan runs the lifecycle of a transaction, building, simulating, signing
+// and submitting it, retrying failed attempts, so that applications do not
+// wire these steps, and their logging and metrics, ad hoc. A Plan can be
+// executed several times, e.g. once per payment of a payout service.
+//
+// Every attempt starts from the build phase, so that Build can reload the
+// source account after a tx_bad_seq failure or raise the fee after a
+// tx_insufficient_fee one.
+type Plan struct {
+	// Build returns the parameters of the transaction. It is required.
+	Build func(ctx context.Context, attempt int) (TransactionParams, error)
+	// Simulate checks the built transaction before it is signed, e.g. with
+	// EnsureTrustlines or a dry run against a test network. The phase is
+	// skipped if Simulate is nil.
+	Simulate func(ctx context.Context, tx *Transaction) error
+	// NetworkPassphrase and Signers sign the transaction. The phase is
+	// skipped if there are no signers.
+	NetworkPassphrase string
+	Signers           []*keypair.Full
+	// Submit submits the signed transaction, e.g. with
+	// horizonclient.Client.SubmitTransaction. The phase is skipped if Submit
+	// is nil, Execute returning the signed transaction.
+	Submit func(ctx context.Context, tx *Transaction) error
+
+	// MaxAttempts is the maximum number of attempts, 1 if it is 0.
+	MaxAttempts int
+	// Retry decides whether a failed attempt is retried, given the phase
+	// which failed and its error. Failed attempts are not retried if it is
+	// nil.
+	Retry func(phase PlanPhase, err error) bool
+	// RetryDelay is the time waited before retrying.
+	RetryDelay time.Duration
+
+	Hooks []PlanHook
+}
+
+// Execute runs the plan until an attempt succeeds or cannot be retried.
+// It returns the transaction of the successful attempt or a *PlanError
+// describing the failure of the last attempt. An attempt is not retried if
+// ctx is done.
+func (p *Plan) Execute(ctx context.Context) (*Transaction, error) {
+	if p.Build == nil {
+		return nil, errors.New("plan has no build function")
+	}
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		tx, phase, err := p.attempt(ctx, attempt)
+		if err == nil {
+			return tx, nil
+		}
+		planErr := &PlanError{Phase: phase, Attempt: attempt, Err: err}
+		if attempt >= maxAttempts || p.Retry == nil || ctx.Err() != nil || !p.Retry(phase, err) {
+			return nil, planErr
+		}
+		if p.RetryDelay > 0 {
+			timer := time.NewTimer(p.RetryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, planErr
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// attempt runs the phases of an attempt and returns the phase which failed,
+// if any.
+func (p *Plan) attempt(ctx context.Context, attempt int) (*Transaction, PlanPhase, error) {
+	var tx *Transaction
+	err := p.run(ctx, PlanEvent{Phase: PlanPhaseBuild, Attempt: attempt}, func() (*Transaction, error) {
+		params, err := p.Build(ctx, attempt)
+		if err != nil {
+			return nil, err
+		}
+		return NewTransaction(params)
+	}, &tx)
+	if err != nil {
+		return nil, PlanPhaseBuild, err
+	}
+
+	if p.Simulate != nil {
+		err = p.run(ctx, PlanEvent{Phase: PlanPhaseSimulate, Attempt: attempt, Transaction: tx}, func() (*Transaction, error) {
+			return tx, p.Simulate(ctx, tx)
+		}, &tx)
+		if err != nil {
+			return nil, PlanPhaseSimulate, err
+		}
+	}
+
+	if len(p.Signers) > 0 {
+		err = p.run(ctx, PlanEvent{Phase: PlanPhaseSign, Attempt: attempt, Transaction: tx}, func() (*Transaction, error) {
+			return tx.Sign(p.NetworkPassphrase, p.Signers...)
+		}, &tx)
+		if err != nil {
+			return nil, PlanPhaseSign, err
+		}
+	}
+
+	if p.Submit != nil {
+		err = p.run(ctx, PlanEvent{Phase: PlanPhaseSubmit, Attempt: attempt, Transaction: tx}, func() (*Transaction, error) {
+			return tx, p.Submit(ctx, tx)
+		}, &tx)
+		if err != nil {
+			return nil, PlanPhaseSubmit, err
+		}
+	}
+	return tx, "", nil
+}
+
+// run runs a phase between the hooks, storing the transaction it returns in
+// tx when it succeeds.
+func (p *Plan) run(ctx context.Context, event PlanEvent, phase func() (*Transaction, error), tx **Transaction) error {
+	for _, hook := range p.Hooks {
+		if hook.Before == nil {
+			continue
+		}
+		if err := hook.Before(ctx, event); err != nil {
+			return errors.Wrap(err, "vetoed by hook")
+		}
+	}
+
+	start := time.Now()
+	result, err := phase()
+	event.Duration = time.Since(start)
+	event.Err = err
+	if err == nil {
+		event.Transaction = result
+		*tx = result
+	}
+
+	for _, hook := range p.Hooks {
+		if hook.After != nil {
+			hook.After(ctx, event)
+		}
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+package txnbuild
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+)
+
+func planParams(kp *keypair.Full, sequence int64) TransactionParams {
+	account := NewSimpleAccount(kp.Address(), sequence)
+	return TransactionParams{
+		SourceAccount:        &account,
+		IncrementSequenceNum: true,
+		Operations:           []Operation{&BumpSequence{BumpTo: 10}},
+		BaseFee:              MinBaseFee,
+		Timebounds:           NewInfiniteTimeout(),
+	}
+}
+
+func TestPlanExecute(t *testing.T) {
+	kp := keypair.MustRandom()
+	var phases []string
+	var submitted *Transaction
+	plan := &Plan{
+		Build: func(ctx context.Context, attempt int) (TransactionParams, error) {
+			return planParams(kp, 1), nil
+		},
+		Simulate: func(ctx context.Context, tx *Transaction) error {
+			assert.Empty(t, tx.Signatures())
+			return nil
+		},
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		Signers:           []*keypair.Full{kp},
+		Submit: func(ctx context.Context, tx *Transaction) error {
+			submitted = tx
+			return nil
+		},
+		Hooks: []PlanHook{{
+			Before: func(ctx context.Context, event PlanEvent) error {
+				phases = append(phases, "before "+string(event.Phase))
+				return nil
+			},
+			After: func(ctx context.Context, event PlanEvent) {
+				assert.NoError(t, event.Err)
+				assert.NotNil(t, event.Transaction)
+				phases = append(phases, "after "+string(event.Phase))
+			},
+		}},
+	}
+
+	tx, err := plan.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, submitted, tx)
+	assert.Len(t, tx.Signatures(), 1)
+	assert.Equal(t, int64(2), tx.SequenceNumber())
+	assert.Equal(t, []string{
+		"before build", "after build",
+		"before simulate", "after simulate",
+		"before sign", "after sign",
+		"before submit", "after submit",
+	}, phases)
+}
+
+func TestPlanSkipsPhases(t *testing.T) {
+	kp := keypair.MustRandom()
+	var phases []PlanPhase
+	plan := &Plan{
+		Build: func(ctx context.Context, attempt int) (TransactionParams, error) {
+			return planParams(kp, 1), nil
+		},
+		Hooks: []PlanHook{{After: func(ctx context.Context, event PlanEvent) {
+			phases = append(phases, event.Phase)
+		}}},
+	}
+	tx, err := plan.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, tx.Signatures())
+	assert.Equal(t, []PlanPhase{PlanPhaseBuild}, phases)
+
+	_, err = (&Plan{}).Execute(context.Background())
+	assert.EqualError(t, err, "plan has no build function")
+}
+
+func TestPlanRetry(t *testing.T) {
+	kp := keypair.MustRandom()
+	badSeq := errors.New("tx_bad_seq")
+	var sequences []int64
+	var failures []PlanEvent
+	plan := &Plan{
+		Build: func(ctx context.Context, attempt int) (TransactionParams, error) {
+			// the account is reloaded at every attempt
+			return planParams(kp, int64(attempt)), nil
+		},
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		Signers:           []*keypair.Full{kp},
+		Submit: func(ctx context.Context, tx *Transaction) error {
+			sequences = append(sequences, tx.SequenceNumber())
+			if len(sequences) < 3 {
+				return badSeq
+			}
+			return nil
+		},
+		MaxAttempts: 3,
+		Retry: func(phase PlanPhase, err error) bool {
+			return phase == PlanPhaseSubmit && err == badSeq
+		},
+		Hooks: []PlanHook{{After: func(ctx context.Context, event PlanEvent) {
+			if event.Err != nil {
+				failures = append(failures, event)
+			}
+		}}},
+	}
+
+	tx, err := plan.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), tx.SequenceNumber())
+	assert.Equal(t, []int64{2, 3, 4}, sequences)
+	require.Len(t, failures, 2)
+	assert.Equal(t, 2, failures[1].Attempt)
+	assert.Equal(t, badSeq, failures[1].Err)
+
+	// the last attempt fails
+	sequences = nil
+	plan.MaxAttempts = 2
+	_, err = plan.Execute(context.Background())
+	require.Error(t, err)
+	planErr, ok := err.(*PlanError)
+	require.True(t, ok)
+	assert.Equal(t, PlanPhaseSubmit, planErr.Phase)
+	assert.Equal(t, 2, planErr.Attempt)
+	assert.Equal(t, badSeq, errors.Cause(err))
+	assert.EqualError(t, err, "submit phase of attempt 2 failed: tx_bad_seq")
+
+	// errors which are not retried
+	sequences = nil
+	plan.Retry = func(phase PlanPhase, err error) bool { return false }
+	_, err = plan.Execute(context.Background())
+	assert.Equal(t, 1, err.(*PlanError).Attempt)
+	assert.Len(t, sequences, 1)
+}
+
+func TestPlanHookVeto(t *testing.T) {
+	kp := keypair.MustRandom()
+	submitted := false
+	plan := &Plan{
+		Build: func(ctx context.Context, attempt int) (TransactionParams, error) {
+			return planParams(kp, 1), nil
+		},
+		Simulate: func(ctx context.Context, tx *Transaction) error {
+			return errors.New("op_underfunded")
+		},
+		Submit: func(ctx context.Context, tx *Transaction) error {
+			submitted = true
+			return nil
+		},
+	}
+	_, err := plan.Execute(context.Background())
+	assert.EqualError(t, err, "simulate phase of attempt 1 failed: op_underfunded")
+
+	plan.Simulate = nil
+	plan.Hooks = []PlanHook{{Before: func(ctx context.Context, event PlanEvent) error {
+		if event.Phase == PlanPhaseSubmit {
+			return errors.New("submissions are paused")
+		}
+		return nil
+	}}}
+	_, err = plan.Execute(context.Background())
+	assert.EqualError(t, err, "submit phase of attempt 1 failed: vetoed by hook: submissions are paused")
+	assert.False(t, submitted)
+
+	plan.Hooks = nil
+	_, err = plan.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, submitted)
+}
@@ -0,0 +1,141 @@
+package txnbuild
+
+import (
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// OfflineSlot is the sequence number and the timebounds of a transaction
+// signed long before it is submitted, see OfflineSchedule.
+type OfflineSlot struct {
+	SequenceNumber int64
+	Timebounds     Timebounds
+}
+
+// SourceAccount returns the source account to build the transaction of the
+// slot with, IncrementSequenceNum being true.
+func (s OfflineSlot) SourceAccount(accountID string) *SimpleAccount {
+	return &SimpleAccount{AccountID: accountID, Sequence: s.SequenceNumber - 1}
+}
+
+// OfflineSchedule derives the slots of count transactions of an account
+// signed in advance, e.g. the cold storage withdrawals of the coming months,
+// while the sequence number of the account is accountSequence. The slots
+// have consecutive sequence numbers and the i-th slot, from 0, is valid from
+// start + i * interval for validity.
+//
+// A transaction is only valid after the ones before it were applied, so when
+// a slot expires before its transaction is submitted the following ones can
+// never be applied, and must be signed again, instead of staying valid
+// forever.
+func OfflineSchedule(accountSequence int64, count int, start time.Time, interval, validity time.Duration) ([]OfflineSlot, error) {
+	if count <= 0 {
+		return nil, errors.New("the number of transactions must be positive")
+	}
+	if accountSequence < 0 {
+		return nil, errors.New("the sequence number of the account cannot be negative")
+	}
+	if interval < 0 {
+		return nil, errors.New("the interval cannot be negative")
+	}
+	if validity < time.Second {
+		return nil, errors.New("the validity must be at least one second")
+	}
+
+	slots := make([]OfflineSlot, count)
+	for i := range slots {
+		notBefore := start.Add(time.Duration(i) * interval)
+		slots[i] = OfflineSlot{
+			SequenceNumber: accountSequence + int64(i) + 1,
+			Timebounds:     NewTimebounds(notBefore.Unix(), notBefore.Add(validity).Unix()),
+		}
+	}
+	return slots, nil
+}
+
+// ReplayRisk is a precondition of a transaction signed offline which leaves
+// it open for too long, see ReplayPolicy.
+type ReplayRisk string
+
+const (
+	// ReplayRiskNoExpiry means that the transaction has no maximum time: it
+	// can be submitted by anyone holding its envelope until its sequence
+	// number is used.
+	ReplayRiskNoExpiry ReplayRisk = "no_expiry"
+	// ReplayRiskLongValidity means that the transaction remains valid for
+	// longer than ReplayPolicy.MaxValidity.
+	ReplayRiskLongValidity ReplayRisk = "long_validity"
+	// ReplayRiskSequenceGap means that the sequence number of the transaction
+	// is ahead of the one of its source account by more than
+	// ReplayPolicy.MaxSequenceGap: it becomes valid whenever the transactions
+	// filling the gap, or a bump sequence operation, are applied.
+	ReplayRiskSequenceGap ReplayRisk = "sequence_gap"
+)
+
+// ReplayConditions are the conditions a ReplayPolicy checks transactions in.
+type ReplayConditions struct {
+	// Now is the time of the check, time.Now() if it is zero.
+	Now time.Time
+	// AccountSequence is the sequence number of the source account of the
+	// transaction. The sequence number of the transaction is not checked if
+	// it is 0.
+	AccountSequence int64
+}
+
+// ReplayPolicy flags the transactions signed offline whose preconditions
+// make them replayable for longer than their signer intended, e.g. before
+// they are stored in cold storage or when they are received from it.
+type ReplayPolicy struct {
+	// MaxValidity is the longest time, from the check, a transaction can
+	// remain valid. Only the transactions which never expire are flagged if
+	// it is 0.
+	MaxValidity time.Duration
+	// MaxSequenceGap is the number of transactions of the source account
+	// which can be applied before the transaction.
+	MaxSequenceGap int64
+}
+
+// Check returns the risks of the transaction, none if it is safe. The risks
+// are in the order of the ReplayRisk constants.
+func (p ReplayPolicy) Check(tx *Transaction, conditions ReplayConditions) []ReplayRisk {
+	now := conditions.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var risks []ReplayRisk
+	timebounds := tx.Timebounds()
+	if timebounds.MaxTime == TimeoutInfinite {
+		risks = append(risks, ReplayRiskNoExpiry)
+	} else if p.MaxValidity > 0 {
+		from := now.Unix()
+		if timebounds.MinTime > from {
+			from = timebounds.MinTime
+		}
+		if time.Duration(timebounds.MaxTime-from)*time.Second > p.MaxValidity {
+			risks = append(risks, ReplayRiskLongValidity)
+		}
+	}
+
+	if conditions.AccountSequence > 0 && tx.SequenceNumber()-conditions.AccountSequence-1 > p.MaxSequenceGap {
+		risks = append(risks, ReplayRiskSequenceGap)
+	}
+	return risks
+}
+
+// CheckEnvelope is like Check for a base64 encoded transaction envelope. The
+// inner transaction of a fee bump transaction is checked, the fee bump having
+// no preconditions of its own.
+func (p ReplayPolicy) CheckEnvelope(envelope string, conditions ReplayConditions) ([]ReplayRisk, error) {
+	generic, err := TransactionFromXDR(envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse transaction envelope")
+	}
+	tx, ok := generic.Transaction()
+	if !ok {
+		feeBump, _ := generic.FeeBump()
+		tx = feeBump.InnerTransaction()
+	}
+	return p.Check(tx, conditions), nil
+}
@@ -0,0 +1,106 @@
+package txnbuild
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+)
+
+func TestOfflineSchedule(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	slots, err := OfflineSchedule(100, 3, start, 24*time.Hour, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []OfflineSlot{
+		{SequenceNumber: 101, Timebounds: NewTimebounds(1600000000, 1600003600)},
+		{SequenceNumber: 102, Timebounds: NewTimebounds(1600086400, 1600090000)},
+		{SequenceNumber: 103, Timebounds: NewTimebounds(1600172800, 1600176400)},
+	}, slots)
+
+	kp := keypair.MustRandom()
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        slots[1].SourceAccount(kp.Address()),
+		IncrementSequenceNum: true,
+		Operations:           []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:              MinBaseFee,
+		Timebounds:           slots[1].Timebounds,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(102), tx.SequenceNumber())
+
+	_, err = OfflineSchedule(100, 0, start, time.Hour, time.Hour)
+	assert.EqualError(t, err, "the number of transactions must be positive")
+	_, err = OfflineSchedule(100, 1, start, time.Hour, 0)
+	assert.EqualError(t, err, "the validity must be at least one second")
+}
+
+func TestReplayPolicyCheck(t *testing.T) {
+	kp := keypair.MustRandom()
+	newTx := func(sequence int64, timebounds Timebounds) *Transaction {
+		tx, err := NewTransaction(TransactionParams{
+			SourceAccount: &SimpleAccount{AccountID: kp.Address(), Sequence: sequence},
+			Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+			BaseFee:       MinBaseFee,
+			Timebounds:    timebounds,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	now := time.Unix(1600000000, 0)
+	policy := ReplayPolicy{MaxValidity: time.Hour}
+
+	for _, test := range []struct {
+		name       string
+		tx         *Transaction
+		conditions ReplayConditions
+		risks      []ReplayRisk
+	}{
+		{"safe", newTx(11, NewTimebounds(0, now.Unix()+3600)), ReplayConditions{Now: now, AccountSequence: 10}, nil},
+		{"unknown sequence", newTx(20, NewTimebounds(0, now.Unix()+60)), ReplayConditions{Now: now}, nil},
+		{"no expiry", newTx(11, NewInfiniteTimeout()), ReplayConditions{Now: now, AccountSequence: 10}, []ReplayRisk{ReplayRiskNoExpiry}},
+		{"long validity", newTx(11, NewTimebounds(0, now.Unix()+3601)), ReplayConditions{Now: now}, []ReplayRisk{ReplayRiskLongValidity}},
+		{"future window", newTx(11, NewTimebounds(now.Unix()+86400, now.Unix()+90000)), ReplayConditions{Now: now}, nil},
+		{"sequence gap", newTx(12, NewInfiniteTimeout()), ReplayConditions{Now: now, AccountSequence: 10}, []ReplayRisk{ReplayRiskNoExpiry, ReplayRiskSequenceGap}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.risks, policy.Check(test.tx, test.conditions))
+		})
+	}
+
+	policy.MaxSequenceGap = 1
+	assert.Empty(t, policy.Check(newTx(12, NewTimebounds(0, now.Unix()+60)), ReplayConditions{Now: now, AccountSequence: 10}))
+}
+
+func TestReplayPolicyCheckEnvelope(t *testing.T) {
+	kp := keypair.MustRandom()
+	inner, err := NewTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		Operations:    []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	inner, err = inner.Sign(network.TestNetworkPassphrase, kp)
+	require.NoError(t, err)
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: keypair.MustRandom().Address(),
+		BaseFee:    MinBaseFee,
+	})
+	require.NoError(t, err)
+
+	for _, tx := range []interface{ Base64() (string, error) }{inner, feeBump} {
+		envelope, err := tx.Base64()
+		require.NoError(t, err)
+		risks, err := ReplayPolicy{}.CheckEnvelope(envelope, ReplayConditions{})
+		require.NoError(t, err)
+		assert.Equal(t, []ReplayRisk{ReplayRiskNoExpiry}, risks)
+	}
+
+	_, err = ReplayPolicy{}.CheckEnvelope("AAAA", ReplayConditions{})
+	assert.Error(t, err)
+}
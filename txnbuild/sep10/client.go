@@ -0,0 +1,180 @@
+package sep10
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/go/clients/stellartoml"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+)
+
+// responseMaxSize is the maximum size of the responses of the web
+// authentication endpoints.
+const responseMaxSize = 100 * 1024
+
+// HTTP represents the http client that a Client uses to make http requests.
+type HTTP interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client authenticates accounts to the web authentication endpoint of a home
+// domain, returning the JWT the services of the home domain, e.g. SEP-6,
+// SEP-12 or SEP-24 servers, accept.
+type Client struct {
+	// HTTP is the http client used to make requests, http.DefaultClient if
+	// it is nil.
+	HTTP HTTP
+	// WebAuthEndpoint is the WEB_AUTH_ENDPOINT of the stellar.toml of the
+	// home domain.
+	WebAuthEndpoint string
+	// SigningKey is the SIGNING_KEY of the stellar.toml of the home domain,
+	// the address the challenges must be signed by.
+	SigningKey string
+	// NetworkPassphrase is the passphrase of the network the challenges are
+	// signed for.
+	NetworkPassphrase string
+	// HomeDomain is the home domain the challenges must be issued for.
+	HomeDomain string
+}
+
+// NewClientFromStellarToml returns a Client for the web authentication
+// endpoint of homeDomain, configured from its stellar.toml.
+func NewClientFromStellarToml(tomlClient stellartoml.ClientInterface, homeDomain string) (*Client, error) {
+	toml, err := tomlClient.GetStellarToml(homeDomain)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get the stellar.toml of %s", homeDomain)
+	}
+	if toml.WebAuthEndpoint == "" {
+		return nil, errors.Errorf("stellar.toml of %s has no WEB_AUTH_ENDPOINT", homeDomain)
+	}
+	if toml.SigningKey == "" {
+		return nil, errors.Errorf("stellar.toml of %s has no SIGNING_KEY", homeDomain)
+	}
+	return &Client{
+		WebAuthEndpoint:   toml.WebAuthEndpoint,
+		SigningKey:        toml.SigningKey,
+		NetworkPassphrase: toml.NetworkPassphrase,
+		HomeDomain:        homeDomain,
+	}, nil
+}
+
+// Authenticate fetches a challenge for account, a G... or M... address,
+// signs it with signers, and exchanges it for a JWT.
+func (c *Client) Authenticate(ctx context.Context, account string, signers ...*keypair.Full) (string, error) {
+	challenge, err := c.Challenge(ctx, account)
+	if err != nil {
+		return "", err
+	}
+	challenge, err = challenge.SignWithContext(
+		keypair.WithKeyUsage(ctx, keypair.UsageAuth), c.NetworkPassphrase, signers...,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "could not sign challenge")
+	}
+	return c.Token(ctx, challenge)
+}
+
+// Challenge fetches a challenge for account, a G... or M... address, and
+// verifies it with ReadChallengeTx before it is signed by the signers of the
+// account, e.g. by a custodian holding them.
+func (c *Client) Challenge(ctx context.Context, account string) (*txnbuild.Transaction, error) {
+	endpoint, err := url.Parse(c.WebAuthEndpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid web auth endpoint")
+	}
+	query := endpoint.Query()
+	query.Set("account", account)
+	query.Set("home_domain", c.HomeDomain)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	var resp struct {
+		Transaction       string `json:"transaction"`
+		NetworkPassphrase string `json:"network_passphrase"`
+	}
+	if err = c.do(req, &resp); err != nil {
+		return nil, errors.Wrap(err, "could not get challenge")
+	}
+	if resp.NetworkPassphrase != "" && resp.NetworkPassphrase != c.NetworkPassphrase {
+		return nil, errors.Errorf("challenge is for network %q but expect %q", resp.NetworkPassphrase, c.NetworkPassphrase)
+	}
+
+	tx, clientAccountID, _, err := ReadChallengeTx(
+		resp.Transaction, c.SigningKey, c.NetworkPassphrase, endpoint.Hostname(), []string{c.HomeDomain},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid challenge")
+	}
+	if clientAccountID != account {
+		return nil, errors.Errorf("challenge is for account %s but expect %s", clientAccountID, account)
+	}
+	return tx, nil
+}
+
+// Token exchanges the signed challenge for a JWT.
+func (c *Client) Token(ctx context.Context, challenge *txnbuild.Transaction) (string, error) {
+	challengeB64, err := challenge.Base64()
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode challenge")
+	}
+	body, err := json.Marshal(map[string]string{"transaction": challengeB64})
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.WebAuthEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err = c.do(req, &resp); err != nil {
+		return "", errors.Wrap(err, "could not get token")
+	}
+	if resp.Token == "" {
+		return "", errors.New("response has no token")
+	}
+	return resp.Token, nil
+}
+
+// do sends the request and decodes the JSON response into object, returning
+// the error of the response if its status is not 200.
+func (c *Client) do(req *http.Request, object interface{}) error {
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http request errored")
+	}
+	defer resp.Body.Close()
+
+	body := io.LimitReader(resp.Body, responseMaxSize)
+	if resp.StatusCode != http.StatusOK {
+		var problem struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(body).Decode(&problem) == nil && problem.Error != "" {
+			return errors.Errorf("http request failed with status %d: %s", resp.StatusCode, problem.Error)
+		}
+		return errors.Errorf("http request failed with status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(body).Decode(object); err != nil {
+		return errors.Wrap(err, "could not decode response")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package sep10
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/clients/stellartoml"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+)
+
+// newWebAuthServer returns a web authentication endpoint issuing tokens to
+// the accounts signing the challenges with their master key.
+func newWebAuthServer(t *testing.T, signingKey *keypair.Full) *httptest.Server {
+	const webAuthDomain = "127.0.0.1"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			tx, err := BuildChallengeTx(signingKey.Seed(), query.Get("account"), webAuthDomain, query.Get("home_domain"), network.TestNetworkPassphrase, time.Minute)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			challenge, err := tx.Base64()
+			require.NoError(t, err)
+			json.NewEncoder(w).Encode(map[string]string{
+				"transaction":        challenge,
+				"network_passphrase": network.TestNetworkPassphrase,
+			})
+		case http.MethodPost:
+			var req struct {
+				Transaction string `json:"transaction"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_, clientAccountID, _, err := ReadChallengeTx(req.Transaction, signingKey.Address(), network.TestNetworkPassphrase, webAuthDomain, []string{"example.com"})
+			require.NoError(t, err)
+			_, err = VerifyChallengeTxSigners(req.Transaction, signingKey.Address(), network.TestNetworkPassphrase, webAuthDomain, []string{"example.com"}, clientAccountID)
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": "token-" + clientAccountID})
+		}
+	}))
+}
+
+func TestClientAuthenticate(t *testing.T) {
+	signingKey := keypair.MustRandom()
+	server := newWebAuthServer(t, signingKey)
+	defer server.Close()
+
+	tomlClient := &stellartoml.MockClient{}
+	tomlClient.On("GetStellarToml", "example.com").Return(&stellartoml.Response{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		WebAuthEndpoint:   server.URL + "/auth",
+		SigningKey:        signingKey.Address(),
+	}, nil)
+	client, err := NewClientFromStellarToml(tomlClient, "example.com")
+	require.NoError(t, err)
+
+	account := keypair.MustRandom()
+	token, err := client.Authenticate(context.Background(), account.Address(), account)
+	require.NoError(t, err)
+	assert.Equal(t, "token-"+account.Address(), token)
+
+	_, err = client.Authenticate(context.Background(), account.Address(), keypair.MustRandom())
+	assert.EqualError(t, err, "could not get token: http request failed with status 401: transaction not signed by "+account.Address())
+
+	_, err = client.Challenge(context.Background(), "GBOB")
+	assert.Contains(t, err.Error(), "could not get challenge: http request failed with status 400: GBOB is not a valid account id")
+
+	// challenges which are not signed by the signing key are refused
+	client.SigningKey = keypair.MustRandom().Address()
+	_, err = client.Challenge(context.Background(), account.Address())
+	assert.EqualError(t, err, "invalid challenge: transaction source account is not equal to server's account")
+}
+
+func TestNewClientFromStellarTomlIncomplete(t *testing.T) {
+	tomlClient := &stellartoml.MockClient{}
+	tomlClient.On("GetStellarToml", "example.com").Return(&stellartoml.Response{
+		WebAuthEndpoint: "https://example.com/auth",
+	}, nil)
+	_, err := NewClientFromStellarToml(tomlClient, "example.com")
+	assert.EqualError(t, err, "stellar.toml of example.com has no SIGNING_KEY")
+}
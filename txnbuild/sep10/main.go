@@ -0,0 +1,51 @@
+// Package sep10 implements SEP-10 web authentication
+// (https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0010.md)
+// for the servers issuing and verifying challenge transactions, and a Client
+// for the wallets authenticating to them.
+//
+// The challenge functions are those of txnbuild, except that the client
+// account may be a muxed account (M...), as allowed since version 3.3.0 of
+// SEP-10, e.g. a custodial wallet authenticating one of its users (see
+// txnbuild.ChallengeTxOptions). The signers of a muxed client account are the
+// signers of the account it multiplexes.
+package sep10
+
+import (
+	"time"
+
+	"github.com/stellar/go/txnbuild"
+)
+
+// options are the options of the txnbuild challenge functions wrapped by the
+// package.
+var options = txnbuild.ChallengeTxOptions{AllowMuxedClientAccount: true}
+
+// BuildChallengeTx creates a challenge transaction, signed by the server,
+// for the client account, a G... or M... address, valid for timebound, which
+// must be at least 1s (300s is recommended). See txnbuild.BuildChallengeTx.
+func BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDomain, network string, timebound time.Duration) (*txnbuild.Transaction, error) {
+	return options.BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDomain, network, timebound)
+}
+
+// ReadChallengeTx reads a challenge transaction and returns the decoded
+// transaction, the client account, a G... or M... address, and the home
+// domain of homeDomains it was issued for. See txnbuild.ReadChallengeTx.
+func ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string) (tx *txnbuild.Transaction, clientAccountID string, matchedHomeDomain string, err error) {
+	return options.ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain, homeDomains)
+}
+
+// VerifyChallengeTxSigners verifies that a challenge transaction, valid
+// according to ReadChallengeTx, is signed by the server and by at least one of
+// the signers of the client account, G... addresses, and that all its
+// signatures are from them. See txnbuild.VerifyChallengeTxSigners.
+func VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string, signers ...string) ([]string, error) {
+	return options.VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain, homeDomains, signers...)
+}
+
+// VerifyChallengeTxThreshold is like VerifyChallengeTxSigners, and also
+// verifies that the weights of the signers who signed the challenge, from
+// signerSummary, e.g. the SignerSummary() of the horizon.Account of the
+// client account, meet threshold. See txnbuild.VerifyChallengeTxThreshold.
+func VerifyChallengeTxThreshold(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string, threshold txnbuild.Threshold, signerSummary txnbuild.SignerSummary) ([]string, error) {
+	return options.VerifyChallengeTxThreshold(challengeTx, serverAccountID, network, webAuthDomain, homeDomains, threshold, signerSummary)
+}
@@ -0,0 +1,87 @@
+package sep10
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+func TestChallengeMuxedClientAccount(t *testing.T) {
+	server := keypair.MustRandom()
+	client := keypair.MustRandom()
+	muxedAccount, err := xdr.MuxedAccountFromAccountId(client.Address(), 42)
+	require.NoError(t, err)
+	clientAccountID, err := muxedAccount.GetAddress()
+	require.NoError(t, err)
+
+	tx, err := BuildChallengeTx(server.Seed(), clientAccountID, "auth.example.com", "example.com", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+	challenge, err := tx.Base64()
+	require.NoError(t, err)
+
+	// txnbuild does not accept muxed client accounts
+	_, _, _, err = txnbuild.ReadChallengeTx(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"})
+	assert.Error(t, err)
+
+	readTx, readClientAccountID, homeDomain, err := ReadChallengeTx(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.org", "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, clientAccountID, readClientAccountID)
+	assert.Equal(t, "example.com", homeDomain)
+	assert.Len(t, readTx.Signatures(), 1)
+
+	_, err = VerifyChallengeTxSigners(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"}, client.Address())
+	assert.EqualError(t, err, "transaction not signed by "+client.Address())
+
+	tx, err = tx.Sign(network.TestNetworkPassphrase, client)
+	require.NoError(t, err)
+	challenge, err = tx.Base64()
+	require.NoError(t, err)
+	signersFound, err := VerifyChallengeTxSigners(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"}, server.Address(), client.Address(), client.Address())
+	require.NoError(t, err)
+	assert.Equal(t, []string{client.Address()}, signersFound)
+
+	signersFound, err = VerifyChallengeTxThreshold(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"}, 1, txnbuild.SignerSummary{client.Address(): 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{client.Address()}, signersFound)
+	_, err = VerifyChallengeTxThreshold(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"}, 2, txnbuild.SignerSummary{client.Address(): 1})
+	assert.EqualError(t, err, "signers with weight 1 do not meet threshold 2")
+}
+
+func TestReadChallengeTxInvalid(t *testing.T) {
+	server := keypair.MustRandom()
+	client := keypair.MustRandom()
+	tx, err := BuildChallengeTx(server.Seed(), client.Address(), "auth.example.com", "example.com", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+	challenge, err := tx.Base64()
+	require.NoError(t, err)
+
+	_, _, _, err = ReadChallengeTx(challenge, client.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"})
+	assert.EqualError(t, err, "transaction source account is not equal to server's account")
+	_, _, _, err = ReadChallengeTx(challenge, server.Address(), network.PublicNetworkPassphrase, "auth.example.com", []string{"example.com"})
+	assert.EqualError(t, err, "transaction not signed by "+server.Address())
+	_, _, _, err = ReadChallengeTx(challenge, server.Address(), network.TestNetworkPassphrase, "other.example.com", []string{"example.com"})
+	assert.EqualError(t, err, `web auth domain operation value is "auth.example.com" but expect "other.example.com"`)
+	_, _, _, err = ReadChallengeTx(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.org"})
+	assert.Contains(t, err.Error(), "operation key does not match any homeDomains passed")
+
+	// the challenges of txnbuild are read the same way
+	txnbuildTx, err := txnbuild.BuildChallengeTx(server.Seed(), client.Address(), "auth.example.com", "example.com", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+	challenge, err = txnbuildTx.Base64()
+	require.NoError(t, err)
+	_, clientAccountID, _, err := ReadChallengeTx(challenge, server.Address(), network.TestNetworkPassphrase, "auth.example.com", []string{"example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, client.Address(), clientAccountID)
+
+	_, err = BuildChallengeTx(server.Seed(), "GBOB", "auth.example.com", "example.com", network.TestNetworkPassphrase, time.Minute)
+	assert.Contains(t, err.Error(), "GBOB is not a valid account id")
+	_, err = BuildChallengeTx(server.Seed(), client.Address(), "auth.example.com", "example.com", network.TestNetworkPassphrase, time.Millisecond)
+	assert.EqualError(t, err, "provided timebound must be at least 1s (300s is recommended)")
+}
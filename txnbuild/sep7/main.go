@@ -0,0 +1,374 @@
+// Package sep7 parses and builds the web+stellar: URIs of SEP-7
+// (https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0007.md),
+// which ask a wallet to sign a transaction (the tx operation) or to pay a
+// destination (the pay operation), e.g.
+//
+//	web+stellar:pay?destination=GC...&amount=120.1234567&memo=skdjfasf&msg=pay%20me
+//
+// URIs are signed by their origin domain with the URI_REQUEST_SIGNING_KEY of
+// its stellar.toml, see URI.Sign and URI.Verify.
+package sep7
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/clients/stellartoml"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+)
+
+// Scheme is the scheme of SEP-7 URIs.
+const Scheme = "web+stellar"
+
+// Operations of SEP-7 URIs.
+const (
+	// OperationTx asks to sign a transaction.
+	OperationTx = "tx"
+	// OperationPay asks to pay a destination.
+	OperationPay = "pay"
+)
+
+// MessageMaxLength is the maximum number of characters of the msg parameter.
+const MessageMaxLength = 300
+
+// signaturePrefix is prepended to the URIs when they are signed: 35 zero
+// bytes and the byte 4, followed by "stellar.sep.7 - URI Scheme".
+var signaturePrefix = append(append(make([]byte, 35), 4), "stellar.sep.7 - URI Scheme"...)
+
+// URI is a SEP-7 URI. The parameters which are empty are omitted from the
+// encoded URI.
+type URI struct {
+	// Operation is OperationTx or OperationPay.
+	Operation string
+
+	// XDR is the base64 transaction envelope of a tx URI.
+	XDR string
+	// Replace is the Txrep specification of the fields of the transaction the
+	// wallet should ask the user to replace, in a tx URI.
+	Replace string
+	// Pubkey is the account which should sign the transaction, in a tx URI.
+	Pubkey string
+	// Chain is a URI the URI was derived from, in a tx URI.
+	Chain string
+
+	// Destination is the account or federation address to pay, in a pay URI.
+	Destination string
+	// Amount is the amount to pay, in a pay URI.
+	Amount string
+	// AssetCode and AssetIssuer are the asset to pay, native if AssetCode is
+	// empty, in a pay URI.
+	AssetCode   string
+	AssetIssuer string
+	// Memo is the memo of the payment, base64 encoded for hash memos, of
+	// type MemoType, MEMO_TEXT, MEMO_ID, MEMO_HASH or MEMO_RETURN, in a pay
+	// URI.
+	Memo     string
+	MemoType string
+
+	// Callback is the URL the signed transaction should be posted to,
+	// instead of being submitted to the network.
+	Callback string
+	// Message is a message shown to the user, of up to MessageMaxLength
+	// characters.
+	Message string
+	// NetworkPassphrase is the passphrase of the network of the
+	// transaction, the public network if it is empty.
+	NetworkPassphrase string
+	// OriginDomain is the domain which signed the URI.
+	OriginDomain string
+	// Signature is the base64 signature of the URI by the
+	// URI_REQUEST_SIGNING_KEY of OriginDomain.
+	Signature string
+
+	// unsigned is the URI decoded by Parse without its signature, whose
+	// parameters may not be in the order of unsignedString, and parsed is the
+	// unsignedString of its parameters as decoded: unsigned is only used while
+	// the parameters are unchanged.
+	unsigned string
+	parsed   string
+}
+
+// NewTxURI returns a tx URI asking to sign tx.
+func NewTxURI(tx *txnbuild.Transaction) (*URI, error) {
+	xdr, err := tx.Base64()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode transaction")
+	}
+	return &URI{Operation: OperationTx, XDR: xdr}, nil
+}
+
+// Parse parses and validates a SEP-7 URI.
+func Parse(uri string) (*URI, error) {
+	prefix := Scheme + ":"
+	if !strings.HasPrefix(uri, prefix) {
+		return nil, errors.Errorf("uri does not start with %s", prefix)
+	}
+	operation, rawQuery := uri[len(prefix):], ""
+	if i := strings.Index(operation, "?"); i >= 0 {
+		operation, rawQuery = operation[:i], operation[i+1:]
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid uri parameters")
+	}
+
+	u := &URI{
+		Operation:         operation,
+		XDR:               query.Get("xdr"),
+		Replace:           query.Get("replace"),
+		Pubkey:            query.Get("pubkey"),
+		Chain:             query.Get("chain"),
+		Destination:       query.Get("destination"),
+		Amount:            query.Get("amount"),
+		AssetCode:         query.Get("asset_code"),
+		AssetIssuer:       query.Get("asset_issuer"),
+		Memo:              query.Get("memo"),
+		MemoType:          query.Get("memo_type"),
+		Message:           query.Get("msg"),
+		NetworkPassphrase: query.Get("network_passphrase"),
+		OriginDomain:      query.Get("origin_domain"),
+		Signature:         query.Get("signature"),
+	}
+	if callback := query.Get("callback"); callback != "" {
+		if !strings.HasPrefix(callback, "url:") {
+			return nil, errors.New("callback must start with url:")
+		}
+		u.Callback = strings.TrimPrefix(callback, "url:")
+	}
+	if u.Signature != "" {
+		var params []string
+		for _, param := range strings.Split(rawQuery, "&") {
+			if !strings.HasPrefix(param, "signature=") {
+				params = append(params, param)
+			}
+		}
+		u.unsigned = prefix + operation + "?" + strings.Join(params, "&")
+		u.parsed = u.unsignedString()
+	}
+
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Validate checks that the URI has the parameters required by its
+// operation.
+func (u *URI) Validate() error {
+	switch u.Operation {
+	case OperationTx:
+		if u.XDR == "" {
+			return errors.New("tx uri has no xdr")
+		}
+	case OperationPay:
+		if u.Destination == "" {
+			return errors.New("pay uri has no destination")
+		}
+		if u.AssetIssuer != "" && u.AssetCode == "" {
+			return errors.New("pay uri has an asset issuer but no asset code")
+		}
+		if u.AssetCode != "" && u.AssetIssuer == "" {
+			return errors.New("pay uri has an asset code but no asset issuer")
+		}
+		switch u.MemoType {
+		case "", "MEMO_TEXT", "MEMO_ID", "MEMO_HASH", "MEMO_RETURN":
+		default:
+			return errors.Errorf("invalid memo type %s", u.MemoType)
+		}
+	default:
+		return errors.Errorf("unknown operation %q", u.Operation)
+	}
+	if len([]rune(u.Message)) > MessageMaxLength {
+		return errors.Errorf("msg is longer than %d characters", MessageMaxLength)
+	}
+	if u.Signature != "" && u.OriginDomain == "" {
+		return errors.New("uri is signed but has no origin domain")
+	}
+	return nil
+}
+
+// String returns the encoded URI, with its signature, if any, last. The
+// parameters of a signed URI decoded by Parse are in their original order, so
+// that its signature remains valid, unless they were modified since.
+func (u *URI) String() string {
+	uri := u.unsignedURI()
+	if u.Signature != "" {
+		uri += "&signature=" + escape(u.Signature)
+	}
+	return uri
+}
+
+// unsignedURI returns the URI without its signature, the URI signed by
+// Signature: the URI decoded by Parse as is while its parameters are
+// unchanged, so that the signature is checked against the current parameters.
+func (u *URI) unsignedURI() string {
+	unsigned := u.unsignedString()
+	if u.unsigned != "" && unsigned == u.parsed {
+		return u.unsigned
+	}
+	return unsigned
+}
+
+func (u *URI) unsignedString() string {
+	var params []string
+	add := func(name, value string) {
+		if value != "" {
+			params = append(params, name+"="+escape(value))
+		}
+	}
+	add("xdr", u.XDR)
+	add("replace", u.Replace)
+	add("destination", u.Destination)
+	add("amount", u.Amount)
+	add("asset_code", u.AssetCode)
+	add("asset_issuer", u.AssetIssuer)
+	add("memo", u.Memo)
+	add("memo_type", u.MemoType)
+	if u.Callback != "" {
+		add("callback", "url:"+u.Callback)
+	}
+	add("pubkey", u.Pubkey)
+	add("msg", u.Message)
+	add("network_passphrase", u.NetworkPassphrase)
+	add("origin_domain", u.OriginDomain)
+	add("chain", u.Chain)
+
+	uri := Scheme + ":" + u.Operation
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	return uri
+}
+
+// escape percent-encodes a parameter value, spaces as %20.
+func escape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// signaturePayload returns the data the signature of the URI signs.
+func (u *URI) signaturePayload() []byte {
+	return append(append([]byte{}, signaturePrefix...), u.unsignedURI()...)
+}
+
+// Sign signs the URI with the URI_REQUEST_SIGNING_KEY of its origin domain,
+// setting its Signature. A signed URI must be signed again once modified.
+func (u *URI) Sign(signingKey *keypair.Full) error {
+	if u.OriginDomain == "" {
+		return errors.New("uri has no origin domain")
+	}
+	u.Signature, u.unsigned, u.parsed = "", "", ""
+	signature, err := signingKey.Sign(u.signaturePayload())
+	if err != nil {
+		return errors.Wrap(err, "could not sign uri")
+	}
+	u.Signature = base64.StdEncoding.EncodeToString(signature)
+	return nil
+}
+
+// VerifySignature checks that the URI is signed by signingKey, the
+// URI_REQUEST_SIGNING_KEY of its origin domain.
+func (u *URI) VerifySignature(signingKey string) error {
+	if u.Signature == "" {
+		return errors.New("uri is not signed")
+	}
+	kp, err := keypair.ParseAddress(signingKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid signing key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(u.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if err := kp.Verify(u.signaturePayload(), signature); err != nil {
+		return errors.Errorf("uri is not signed by %s", signingKey)
+	}
+	return nil
+}
+
+// Verify checks that the URI is signed by the URI_REQUEST_SIGNING_KEY of the
+// stellar.toml of its origin domain. Wallets must verify the signed URIs
+// before showing their origin domain to the user.
+func (u *URI) Verify(tomlClient stellartoml.ClientInterface) error {
+	if u.OriginDomain == "" {
+		return errors.New("uri has no origin domain")
+	}
+	toml, err := tomlClient.GetStellarToml(u.OriginDomain)
+	if err != nil {
+		return errors.Wrapf(err, "could not get the stellar.toml of %s", u.OriginDomain)
+	}
+	if toml.UriRequestSigningKey == "" {
+		return errors.Errorf("stellar.toml of %s has no URI_REQUEST_SIGNING_KEY", u.OriginDomain)
+	}
+	return u.VerifySignature(toml.UriRequestSigningKey)
+}
+
+// Transaction decodes the transaction of a tx URI.
+func (u *URI) Transaction() (*txnbuild.Transaction, error) {
+	if u.Operation != OperationTx {
+		return nil, errors.Errorf("%s uri has no transaction", u.Operation)
+	}
+	parsed, err := txnbuild.TransactionFromXDR(u.XDR)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse transaction")
+	}
+	tx, ok := parsed.Transaction()
+	if !ok {
+		return nil, errors.New("fee bump transactions are not supported")
+	}
+	return tx, nil
+}
+
+// Payment returns the payment and the memo, nil if there is none, of a pay
+// URI, the destination being an account. The amount of the payment is empty
+// if the URI lets the user choose it.
+func (u *URI) Payment() (*txnbuild.Payment, txnbuild.Memo, error) {
+	if u.Operation != OperationPay {
+		return nil, nil, errors.Errorf("%s uri has no payment", u.Operation)
+	}
+	payment := &txnbuild.Payment{
+		Destination: u.Destination,
+		Amount:      u.Amount,
+		Asset:       txnbuild.NativeAsset{},
+	}
+	if u.AssetCode != "" {
+		payment.Asset = txnbuild.CreditAsset{Code: u.AssetCode, Issuer: u.AssetIssuer}
+	}
+
+	memo, err := u.memo()
+	if err != nil {
+		return nil, nil, err
+	}
+	return payment, memo, nil
+}
+
+func (u *URI) memo() (txnbuild.Memo, error) {
+	if u.Memo == "" {
+		return nil, nil
+	}
+	switch u.MemoType {
+	case "", "MEMO_TEXT":
+		return txnbuild.MemoText(u.Memo), nil
+	case "MEMO_ID":
+		id, err := strconv.ParseUint(u.Memo, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid memo id %s", u.Memo)
+		}
+		return txnbuild.MemoID(id), nil
+	case "MEMO_HASH", "MEMO_RETURN":
+		decoded, err := base64.StdEncoding.DecodeString(u.Memo)
+		if err != nil || len(decoded) != 32 {
+			return nil, errors.Errorf("invalid %s %s", strings.ToLower(u.MemoType), u.Memo)
+		}
+		var hash [32]byte
+		copy(hash[:], decoded)
+		if u.MemoType == "MEMO_HASH" {
+			return txnbuild.MemoHash(hash), nil
+		}
+		return txnbuild.MemoReturn(hash), nil
+	}
+	return nil, errors.Errorf("invalid memo type %s", u.MemoType)
+}
@@ -0,0 +1,172 @@
+package sep7
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/clients/stellartoml"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+)
+
+const (
+	specURI  = "web+stellar:pay?destination=GCALNQQBXAPZ2WIRSDDBMSTAKCUH5SG6U76YBFLQLIXJTF7FE5AX7AOO&amount=120.1234567&memo=skdjfasf&msg=pay%20me%20with%20lumens&origin_domain=someDomain.com"
+	specSeed = "SBPOVRVKTTV7W3IOX2FJPSMPCJ5L2WU2YKTP3HCLYPXNI5MDIGREVNYC"
+)
+
+func TestSign(t *testing.T) {
+	u, err := Parse(specURI)
+	require.NoError(t, err)
+	assert.Equal(t, "pay me with lumens", u.Message)
+	assert.Equal(t, specURI, u.String())
+
+	signingKey := keypair.MustParseFull(specSeed)
+	require.NoError(t, u.Sign(signingKey))
+	signature, err := base64.StdEncoding.DecodeString(u.Signature)
+	require.NoError(t, err)
+	payload := append(append(make([]byte, 35), 4), "stellar.sep.7 - URI Scheme"+specURI...)
+	assert.NoError(t, signingKey.Verify(payload, signature))
+	assert.Equal(t, specURI+"&signature="+url.QueryEscape(u.Signature), u.String())
+
+	u.OriginDomain = ""
+	assert.EqualError(t, u.Sign(signingKey), "uri has no origin domain")
+}
+
+func TestParseSigned(t *testing.T) {
+	signingKey := keypair.MustParseFull(specSeed)
+	uri := &URI{
+		Operation:    OperationPay,
+		Destination:  "GCALNQQBXAPZ2WIRSDDBMSTAKCUH5SG6U76YBFLQLIXJTF7FE5AX7AOO",
+		Message:      "pay me",
+		OriginDomain: "someDomain.com",
+	}
+	require.NoError(t, uri.Sign(signingKey))
+	parsed, err := Parse(uri.String())
+	require.NoError(t, err)
+	assert.Equal(t, uri.Signature, parsed.Signature)
+	require.NoError(t, parsed.VerifySignature(signingKey.Address()))
+
+	other := keypair.MustRandom()
+	assert.EqualError(t, parsed.VerifySignature(other.Address()), "uri is not signed by "+other.Address())
+
+	tomlClient := &stellartoml.MockClient{}
+	tomlClient.On("GetStellarToml", "someDomain.com").Return(&stellartoml.Response{
+		UriRequestSigningKey: signingKey.Address(),
+	}, nil)
+	assert.NoError(t, parsed.Verify(tomlClient))
+
+	// the URIs signed with their parameters in another order than String
+	// keep them
+	unsigned := "web+stellar:pay?msg=pay%20me&destination=GCALNQQBXAPZ2WIRSDDBMSTAKCUH5SG6U76YBFLQLIXJTF7FE5AX7AOO&origin_domain=someDomain.com"
+	signature, err := signingKey.Sign(append(append([]byte{}, signaturePrefix...), unsigned...))
+	require.NoError(t, err)
+	signed := unsigned + "&signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+	parsed, err = Parse(signed)
+	require.NoError(t, err)
+	assert.NoError(t, parsed.VerifySignature(signingKey.Address()))
+	assert.Equal(t, signed, parsed.String())
+
+	// the URIs modified once parsed are verified and encoded with their
+	// current parameters
+	parsed, err = Parse(signed)
+	require.NoError(t, err)
+	parsed.Amount = "100"
+	assert.EqualError(t, parsed.VerifySignature(signingKey.Address()), "uri is not signed by "+signingKey.Address())
+	assert.Contains(t, parsed.String(), "amount=100")
+	parsed.Amount = ""
+	assert.NoError(t, parsed.VerifySignature(signingKey.Address()))
+	assert.Equal(t, signed, parsed.String())
+
+	// tampered URIs are refused
+	parsed, err = Parse(strings.Replace(signed, "pay%20me", "pay%20me%20more", 1))
+	require.NoError(t, err)
+	assert.EqualError(t, parsed.VerifySignature(signingKey.Address()), "uri is not signed by "+signingKey.Address())
+}
+
+func TestTxURI(t *testing.T) {
+	source := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 10}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+
+	uri, err := NewTxURI(tx)
+	require.NoError(t, err)
+	uri.Callback = "https://example.com/sign"
+	uri.NetworkPassphrase = network.TestNetworkPassphrase
+	uri.Pubkey = source.Address()
+
+	parsed, err := Parse(uri.String())
+	require.NoError(t, err)
+	assert.Equal(t, uri, parsed)
+	assert.Contains(t, uri.String(), "callback=url%3Ahttps%3A%2F%2Fexample.com%2Fsign")
+	assert.Contains(t, uri.String(), "network_passphrase=Test%20SDF%20Network%20%3B%20September%202015")
+
+	decoded, err := parsed.Transaction()
+	require.NoError(t, err)
+	expectedHash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	hash, err := decoded.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	_, _, err = parsed.Payment()
+	assert.EqualError(t, err, "tx uri has no payment")
+}
+
+func TestPayURI(t *testing.T) {
+	issuer := keypair.MustRandom().Address()
+	u, err := Parse("web+stellar:pay?destination=GCALNQQBXAPZ2WIRSDDBMSTAKCUH5SG6U76YBFLQLIXJTF7FE5AX7AOO&amount=10&asset_code=USD&asset_issuer=" + issuer + "&memo=42&memo_type=MEMO_ID")
+	require.NoError(t, err)
+	payment, memo, err := u.Payment()
+	require.NoError(t, err)
+	assert.Equal(t, &txnbuild.Payment{
+		Destination: "GCALNQQBXAPZ2WIRSDDBMSTAKCUH5SG6U76YBFLQLIXJTF7FE5AX7AOO",
+		Amount:      "10",
+		Asset:       txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+	}, payment)
+	assert.Equal(t, txnbuild.MemoID(42), memo)
+
+	u, err = Parse("web+stellar:pay?destination=GCALNQQBXAPZ2WIRSDDBMSTAKCUH5SG6U76YBFLQLIXJTF7FE5AX7AOO&memo=AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA%3D&memo_type=MEMO_HASH")
+	require.NoError(t, err)
+	payment, memo, err = u.Payment()
+	require.NoError(t, err)
+	assert.Equal(t, txnbuild.NativeAsset{}, payment.Asset)
+	assert.Empty(t, payment.Amount)
+	assert.Equal(t, txnbuild.MemoHash{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}, memo)
+
+	u.Memo = "not a number"
+	u.MemoType = "MEMO_ID"
+	_, _, err = u.Payment()
+	assert.EqualError(t, err, "invalid memo id not a number")
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, testCase := range []struct {
+		uri string
+		err string
+	}{
+		{"stellar:pay?destination=GA", "uri does not start with web+stellar:"},
+		{"web+stellar:sign?xdr=AAAA", `unknown operation "sign"`},
+		{"web+stellar:tx", "tx uri has no xdr"},
+		{"web+stellar:pay?amount=1", "pay uri has no destination"},
+		{"web+stellar:pay?destination=GA&asset_code=USD", "pay uri has an asset code but no asset issuer"},
+		{"web+stellar:pay?destination=GA&memo_type=MEMO_FOO", "invalid memo type MEMO_FOO"},
+		{"web+stellar:tx?xdr=AAAA&callback=https://example.com", "callback must start with url:"},
+		{"web+stellar:tx?xdr=AAAA&signature=AAAA", "uri is signed but has no origin domain"},
+		{"web+stellar:tx?xdr=AAAA&msg=" + strings.Repeat("a", 301), "msg is longer than 300 characters"},
+	} {
+		_, err := Parse(testCase.uri)
+		assert.EqualError(t, err, testCase.err, testCase.uri)
+	}
+}
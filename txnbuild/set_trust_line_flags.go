@@ -109,12 +109,12 @@ func fromXDRTrustlineFlag(flags xdr.Uint32) []TrustLineFlag {
 func (stf *SetTrustLineFlags) Validate() error {
 	err := validateStellarPublicKey(stf.Trustor)
 	if err != nil {
-		return NewValidationError("Trustor", err.Error())
+		return fieldValidationError("Trustor", err)
 	}
 
 	err = validateAssetCode(stf.Asset)
 	if err != nil {
-		return NewValidationError("Asset", err.Error())
+		return fieldValidationError("Asset", err)
 	}
 	return nil
 }
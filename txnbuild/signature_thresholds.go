@@ -0,0 +1,228 @@
+package txnbuild
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ThresholdLevel is the threshold of the source account an operation must be
+// authorized with.
+type ThresholdLevel int
+
+const (
+	// ThresholdLevelLow is the threshold of AllowTrust, SetTrustLineFlags,
+	// BumpSequence, ClaimClaimableBalance and Inflation operations, and of
+	// the source account of the transaction for the fee and sequence number.
+	ThresholdLevelLow ThresholdLevel = iota
+	// ThresholdLevelMedium is the threshold of all the other operations.
+	ThresholdLevelMedium
+	// ThresholdLevelHigh is the threshold of AccountMerge operations and of
+	// SetOptions operations changing the signers or the thresholds.
+	ThresholdLevelHigh
+)
+
+// String returns the name of the level.
+func (l ThresholdLevel) String() string {
+	switch l {
+	case ThresholdLevelLow:
+		return "low"
+	case ThresholdLevelMedium:
+		return "medium"
+	case ThresholdLevelHigh:
+		return "high"
+	}
+	return fmt.Sprintf("ThresholdLevel(%d)", int(l))
+}
+
+// AccountThresholds are the thresholds of an account.
+type AccountThresholds struct {
+	Low    Threshold
+	Medium Threshold
+	High   Threshold
+}
+
+func (t AccountThresholds) level(level ThresholdLevel) Threshold {
+	switch level {
+	case ThresholdLevelLow:
+		return t.Low
+	case ThresholdLevelMedium:
+		return t.Medium
+	default:
+		return t.High
+	}
+}
+
+// SignatureRequirement is the weight an account must authorize a transaction
+// with, at the highest threshold level required by the transaction and its
+// operations, and the weight of its signers who signed the transaction.
+type SignatureRequirement struct {
+	AccountID string
+	Level     ThresholdLevel
+	Threshold Threshold
+	Weight    int32
+}
+
+// Satisfied returns true if the signatures meet the threshold. At least one
+// signer must have signed, even if the threshold is 0.
+func (r SignatureRequirement) Satisfied() bool {
+	return r.Weight > 0 && r.Weight >= int32(r.Threshold)
+}
+
+// operationThresholdLevel returns the threshold level of the source account
+// of the operation.
+func operationThresholdLevel(op Operation) ThresholdLevel {
+	switch o := op.(type) {
+	case *AllowTrust, *SetTrustLineFlags, *BumpSequence, *ClaimClaimableBalance, *Inflation:
+		return ThresholdLevelLow
+	case *AccountMerge:
+		return ThresholdLevelHigh
+	case *SetOptions:
+		if o.MasterWeight != nil || o.LowThreshold != nil || o.MediumThreshold != nil ||
+			o.HighThreshold != nil || o.Signer != nil {
+			return ThresholdLevelHigh
+		}
+	}
+	return ThresholdLevelMedium
+}
+
+// SignaturesSatisfy evaluates whether the signatures attached to the
+// transaction authorize it, so that whoever collects the signatures of a
+// multisig transaction knows when to stop and submit it.
+//
+// accountSigners are the signers of the source accounts of the transaction and
+// of its operations, including their master key, by account address, and
+// thresholds their thresholds. The ed25519 public key (G...), pre-authorized
+// transaction (T...) and hash (X...) signers are supported, the weights being
+// capped to 255 like in stellar-core.
+//
+// It returns the requirement of every source account, at the highest level
+// required by the operations it is the source of, in the order of the
+// operations, and whether all of them are satisfied.
+func (t *Transaction) SignaturesSatisfy(
+	network string,
+	accountSigners map[string]SignerSummary,
+	thresholds map[string]AccountThresholds,
+) (bool, []SignatureRequirement, error) {
+	hash, err := t.Hash(network)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "could not hash transaction")
+	}
+
+	var accounts []string
+	levels := map[string]ThresholdLevel{}
+	require := func(account string, level ThresholdLevel) {
+		current, ok := levels[account]
+		if !ok {
+			accounts = append(accounts, account)
+		}
+		if !ok || level > current {
+			levels[account] = level
+		}
+	}
+	txSource := accountFromMuxed(t.SourceAccount().AccountID)
+	require(txSource, ThresholdLevelLow)
+	for _, op := range t.Operations() {
+		source := txSource
+		if opSource := op.GetSourceAccount(); opSource != "" {
+			source = accountFromMuxed(opSource)
+		}
+		require(source, operationThresholdLevel(op))
+	}
+
+	satisfied := true
+	requirements := make([]SignatureRequirement, 0, len(accounts))
+	for _, account := range accounts {
+		accountThresholds, ok := thresholds[account]
+		if !ok {
+			return false, nil, errors.Errorf("no thresholds for account %s", account)
+		}
+		weight, err := signaturesWeight(hash, t.Signatures(), accountSigners[account])
+		if err != nil {
+			return false, nil, errors.Wrapf(err, "could not check the signers of account %s", account)
+		}
+		requirement := SignatureRequirement{
+			AccountID: account,
+			Level:     levels[account],
+			Threshold: accountThresholds.level(levels[account]),
+			Weight:    weight,
+		}
+		satisfied = satisfied && requirement.Satisfied()
+		requirements = append(requirements, requirement)
+	}
+	return satisfied, requirements, nil
+}
+
+// signaturesWeight returns the total weight of the signers who signed the
+// transaction with the given hash.
+func signaturesWeight(hash [32]byte, signatures []xdr.DecoratedSignature, signers SignerSummary) (int32, error) {
+	weight := int32(0)
+	for signer, signerWeight := range signers {
+		if signerWeight <= 0 {
+			continue
+		}
+		signed, err := signedBy(hash, signatures, signer)
+		if err != nil {
+			return 0, err
+		}
+		if !signed {
+			continue
+		}
+		if signerWeight > 255 {
+			signerWeight = 255
+		}
+		weight += signerWeight
+	}
+	return weight, nil
+}
+
+// signedBy returns true if one of the signatures of the transaction with the
+// given hash is from the signer, or if the signer is the hash of the
+// transaction, a pre-authorized transaction needing no signature.
+func signedBy(hash [32]byte, signatures []xdr.DecoratedSignature, signer string) (bool, error) {
+	version, err := strkey.Version(signer)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid signer %s", signer)
+	}
+
+	switch version {
+	case strkey.VersionByteAccountID:
+		kp, err := keypair.ParseAddress(signer)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid signer %s", signer)
+		}
+		hint := kp.Hint()
+		for _, signature := range signatures {
+			if signature.Hint == xdr.SignatureHint(hint) && kp.Verify(hash[:], signature.Signature) == nil {
+				return true, nil
+			}
+		}
+	case strkey.VersionByteHashTx:
+		preAuth, err := strkey.Decode(strkey.VersionByteHashTx, signer)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid signer %s", signer)
+		}
+		return bytes.Equal(preAuth, hash[:]), nil
+	case strkey.VersionByteHashX:
+		hashX, err := strkey.Decode(strkey.VersionByteHashX, signer)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid signer %s", signer)
+		}
+		var hint xdr.SignatureHint
+		copy(hint[:], hashX[len(hashX)-4:])
+		for _, signature := range signatures {
+			preimageHash := sha256.Sum256(signature.Signature)
+			if signature.Hint == hint && bytes.Equal(preimageHash[:], hashX) {
+				return true, nil
+			}
+		}
+	default:
+		return false, errors.Errorf("unsupported signer %s", signer)
+	}
+	return false, nil
+}
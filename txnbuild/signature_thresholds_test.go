@@ -0,0 +1,120 @@
+package txnbuild
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/strkey"
+)
+
+func TestSignaturesSatisfy(t *testing.T) {
+	source := keypair.MustRandom()
+	cosigner := keypair.MustRandom()
+	other := keypair.MustRandom()
+	issuer := keypair.MustRandom()
+
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations: []Operation{
+			&Payment{Destination: other.Address(), Amount: "1", Asset: NativeAsset{}},
+			&SetOptions{Signer: &Signer{Address: other.Address(), Weight: 1}},
+			&SetTrustLineFlags{
+				Trustor:       source.Address(),
+				Asset:         CreditAsset{Code: "USD", Issuer: issuer.Address()},
+				SetFlags:      []TrustLineFlag{TrustLineAuthorized},
+				SourceAccount: issuer.Address(),
+			},
+		},
+		BaseFee:    MinBaseFee,
+		Timebounds: NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+
+	accountSigners := map[string]SignerSummary{
+		source.Address(): {source.Address(): 1, cosigner.Address(): 5},
+		issuer.Address(): {issuer.Address(): 1},
+	}
+	thresholds := map[string]AccountThresholds{
+		source.Address(): {Low: 1, Medium: 2, High: 5},
+		issuer.Address(): {},
+	}
+
+	satisfied, requirements, err := tx.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	require.NoError(t, err)
+	assert.False(t, satisfied)
+	assert.Equal(t, []SignatureRequirement{
+		{AccountID: source.Address(), Level: ThresholdLevelHigh, Threshold: 5, Weight: 0},
+		{AccountID: issuer.Address(), Level: ThresholdLevelLow, Threshold: 0, Weight: 0},
+	}, requirements)
+
+	// the master key alone does not meet the high threshold
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source, issuer)
+	require.NoError(t, err)
+	satisfied, requirements, err = tx.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	require.NoError(t, err)
+	assert.False(t, satisfied)
+	assert.Equal(t, int32(1), requirements[0].Weight)
+	assert.True(t, requirements[1].Satisfied())
+
+	// signatures for another network are ignored
+	signed, err := tx.Sign(network.PublicNetworkPassphrase, cosigner)
+	require.NoError(t, err)
+	satisfied, _, err = signed.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	require.NoError(t, err)
+	assert.False(t, satisfied)
+
+	signed, err = tx.Sign(network.TestNetworkPassphrase, cosigner)
+	require.NoError(t, err)
+	satisfied, requirements, err = signed.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	require.NoError(t, err)
+	assert.True(t, satisfied)
+	assert.Equal(t, int32(6), requirements[0].Weight)
+
+	delete(thresholds, issuer.Address())
+	_, _, err = signed.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	assert.EqualError(t, err, "no thresholds for account "+issuer.Address())
+}
+
+func TestSignaturesSatisfyHashSigners(t *testing.T) {
+	source := keypair.MustRandom()
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []Operation{&BumpSequence{BumpTo: 0}},
+		BaseFee:              MinBaseFee,
+		Timebounds:           NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	thresholds := map[string]AccountThresholds{source.Address(): {Low: 1, Medium: 1, High: 1}}
+
+	hash, err := tx.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	preAuth, err := strkey.Encode(strkey.VersionByteHashTx, hash[:])
+	require.NoError(t, err)
+	satisfied, _, err := tx.SignaturesSatisfy(network.TestNetworkPassphrase, map[string]SignerSummary{
+		source.Address(): {source.Address(): 0, preAuth: 1},
+	}, thresholds)
+	require.NoError(t, err)
+	assert.True(t, satisfied)
+
+	preimage := []byte("preimage")
+	preimageHash := sha256.Sum256(preimage)
+	hashX, err := strkey.Encode(strkey.VersionByteHashX, preimageHash[:])
+	require.NoError(t, err)
+	accountSigners := map[string]SignerSummary{source.Address(): {hashX: 1}}
+	satisfied, _, err = tx.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	require.NoError(t, err)
+	assert.False(t, satisfied)
+
+	tx, err = tx.SignHashX(preimage)
+	require.NoError(t, err)
+	satisfied, _, err = tx.SignaturesSatisfy(network.TestNetworkPassphrase, accountSigners, thresholds)
+	require.NoError(t, err)
+	assert.True(t, satisfied)
+}
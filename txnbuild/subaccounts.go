@@ -0,0 +1,120 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+)
+
+// SubAccountParentDataKey is the name of the data entry of a sub-account
+// whose value is the address of its parent account.
+const SubAccountParentDataKey = "subaccount.parent"
+
+// SubAccountLoader loads the sub-accounts of an account: the accounts it
+// sponsors whose SubAccountParentDataKey data entry is its address. It is
+// implemented by horizonclient.Client.
+type SubAccountLoader interface {
+	LoadSubAccounts(parent string) ([]string, error)
+}
+
+// CreateSubAccountOps returns the operations creating the account child, with
+// the starting balance, as a sub-account of parent: parent sponsors the
+// reserves of child, which is tagged with a SubAccountParentDataKey data
+// entry pointing to parent. The transaction must be signed by parent and
+// child.
+func CreateSubAccountOps(parent, child, startingBalance string) []Operation {
+	if startingBalance == "" {
+		startingBalance = "0"
+	}
+	return []Operation{
+		&BeginSponsoringFutureReserves{SponsoredID: child, SourceAccount: parent},
+		&CreateAccount{Destination: child, Amount: startingBalance, SourceAccount: parent},
+		&ManageData{Name: SubAccountParentDataKey, Value: []byte(parent), SourceAccount: child},
+		&EndSponsoringFutureReserves{SourceAccount: child},
+	}
+}
+
+// SubAccountTree is an account and its sub-accounts, recursively.
+type SubAccountTree struct {
+	AccountID   string
+	SubAccounts []*SubAccountTree
+}
+
+// LoadSubAccountTree loads the sub-accounts of root, and theirs, with
+// loader. An error is returned if an account is found twice, which would
+// make the tree a graph and its teardown fail.
+func LoadSubAccountTree(loader SubAccountLoader, root string) (*SubAccountTree, error) {
+	seen := map[string]bool{root: true}
+	var load func(accountID string) (*SubAccountTree, error)
+	load = func(accountID string) (*SubAccountTree, error) {
+		children, err := loader.LoadSubAccounts(accountID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not load sub-accounts of %s", accountID)
+		}
+		tree := &SubAccountTree{AccountID: accountID}
+		for _, child := range children {
+			if seen[child] {
+				return nil, errors.Errorf("account %s is found twice in the sub-accounts of %s", child, root)
+			}
+			seen[child] = true
+			subTree, err := load(child)
+			if err != nil {
+				return nil, err
+			}
+			tree.SubAccounts = append(tree.SubAccounts, subTree)
+		}
+		return tree, nil
+	}
+	return load(root)
+}
+
+// Walk calls fn with every account of the tree and its parent, the parent of
+// the root being "", parents before their sub-accounts.
+func (t *SubAccountTree) Walk(fn func(accountID, parent string)) {
+	var walk func(tree *SubAccountTree, parent string)
+	walk = func(tree *SubAccountTree, parent string) {
+		fn(tree.AccountID, parent)
+		for _, subTree := range tree.SubAccounts {
+			walk(subTree, tree.AccountID)
+		}
+	}
+	walk(t, "")
+}
+
+// Accounts returns the addresses of the sub-accounts of the tree, root
+// excluded, parents first.
+func (t *SubAccountTree) Accounts() []string {
+	var accounts []string
+	t.Walk(func(accountID, parent string) {
+		if parent != "" {
+			accounts = append(accounts, accountID)
+		}
+	})
+	return accounts
+}
+
+// TeardownOps returns the operations merging the sub-accounts of the tree
+// into their parents, sub-accounts before their parents so that every
+// account merged no longer sponsors any account. The root is not merged.
+//
+// The SubAccountParentDataKey data entries are removed before the merges,
+// but the sub-accounts must not have other subentries (trustlines, offers,
+// other data entries or signers) left, or the merges fail. The transactions
+// must be signed by all the sub-accounts; the operations can be split into
+// several transactions (at most 100 operations each) kept in order.
+func (t *SubAccountTree) TeardownOps() []Operation {
+	var ops []Operation
+	var teardown func(tree *SubAccountTree, parent string)
+	teardown = func(tree *SubAccountTree, parent string) {
+		for _, subTree := range tree.SubAccounts {
+			teardown(subTree, tree.AccountID)
+		}
+		if parent == "" {
+			return
+		}
+		ops = append(ops,
+			&ManageData{Name: SubAccountParentDataKey, SourceAccount: tree.AccountID},
+			&AccountMerge{Destination: parent, SourceAccount: tree.AccountID},
+		)
+	}
+	teardown(t, "")
+	return ops
+}
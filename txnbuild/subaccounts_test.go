@@ -0,0 +1,97 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapSubAccountLoader map[string][]string
+
+func (l mapSubAccountLoader) LoadSubAccounts(parent string) ([]string, error) {
+	children, ok := l[parent]
+	if !ok {
+		return nil, errors.New("account not found")
+	}
+	return children, nil
+}
+
+func TestCreateSubAccountOps(t *testing.T) {
+	parent := newKeypair0().Address()
+	child := newKeypair1().Address()
+
+	ops := CreateSubAccountOps(parent, child, "")
+	assert.Equal(t, []Operation{
+		&BeginSponsoringFutureReserves{SponsoredID: child, SourceAccount: parent},
+		&CreateAccount{Destination: child, Amount: "0", SourceAccount: parent},
+		&ManageData{Name: SubAccountParentDataKey, Value: []byte(parent), SourceAccount: child},
+		&EndSponsoringFutureReserves{SourceAccount: child},
+	}, ops)
+
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: parent, Sequence: 1},
+		Operations:    ops,
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	assert.Len(t, tx.Operations(), 4)
+}
+
+func TestSubAccountTree(t *testing.T) {
+	root := newKeypair0().Address()
+	a := newKeypair1().Address()
+	b := newKeypair2().Address()
+	c := keypair.MustRandom().Address()
+	loader := mapSubAccountLoader{
+		root: {a, b},
+		a:    {c},
+		b:    {},
+		c:    {},
+	}
+
+	tree, err := LoadSubAccountTree(loader, root)
+	require.NoError(t, err)
+	assert.Equal(t, &SubAccountTree{
+		AccountID: root,
+		SubAccounts: []*SubAccountTree{
+			{AccountID: a, SubAccounts: []*SubAccountTree{{AccountID: c}}},
+			{AccountID: b},
+		},
+	}, tree)
+	assert.Equal(t, []string{a, c, b}, tree.Accounts())
+
+	var parents []string
+	tree.Walk(func(accountID, parent string) {
+		parents = append(parents, accountID+"<"+parent)
+	})
+	assert.Equal(t, []string{root + "<", a + "<" + root, c + "<" + a, b + "<" + root}, parents)
+
+	assert.Equal(t, []Operation{
+		&ManageData{Name: SubAccountParentDataKey, SourceAccount: c},
+		&AccountMerge{Destination: a, SourceAccount: c},
+		&ManageData{Name: SubAccountParentDataKey, SourceAccount: a},
+		&AccountMerge{Destination: root, SourceAccount: a},
+		&ManageData{Name: SubAccountParentDataKey, SourceAccount: b},
+		&AccountMerge{Destination: root, SourceAccount: b},
+	}, tree.TeardownOps())
+
+	leaf, err := LoadSubAccountTree(loader, b)
+	require.NoError(t, err)
+	assert.Empty(t, leaf.Accounts())
+	assert.Empty(t, leaf.TeardownOps())
+}
+
+func TestLoadSubAccountTreeErrors(t *testing.T) {
+	root := newKeypair0().Address()
+	a := newKeypair1().Address()
+
+	_, err := LoadSubAccountTree(mapSubAccountLoader{root: {a}, a: {root}}, root)
+	assert.EqualError(t, err, "account "+root+" is found twice in the sub-accounts of "+root)
+
+	_, err = LoadSubAccountTree(mapSubAccountLoader{root: {a}}, root)
+	assert.EqualError(t, err, "could not load sub-accounts of "+a+": account not found")
+}
@@ -22,6 +22,8 @@ type Timebounds struct {
 	MinTime  int64
 	MaxTime  int64
 	wasBuilt bool
+	// timeout is the timeout given to NewTimeout, 0 otherwise
+	timeout int64
 }
 
 // Validate for Timebounds sanity-checks the configured Timebound limits, and confirms the object was built
@@ -51,7 +53,7 @@ func (tb *Timebounds) Validate() error {
 // NewTimebounds is a factory method that constructs a Timebounds object from a min and max time.
 // A Transaction cannot be built unless a Timebounds object is provided through a factory method.
 func NewTimebounds(minTime, maxTime int64) Timebounds {
-	return Timebounds{minTime, maxTime, true}
+	return Timebounds{MinTime: minTime, MaxTime: maxTime, wasBuilt: true}
 }
 
 // NewTimeout is a factory method that sets the MaxTime to be the duration in seconds in the
@@ -59,7 +61,7 @@ func NewTimebounds(minTime, maxTime int64) Timebounds {
 // A Transaction cannot be built unless a Timebounds object is provided through a factory method.
 // This method uses the provided system time - make sure it is accurate.
 func NewTimeout(timeout int64) Timebounds {
-	return Timebounds{0, time.Now().UTC().Unix() + timeout, true}
+	return Timebounds{MinTime: 0, MaxTime: time.Now().UTC().Unix() + timeout, wasBuilt: true, timeout: timeout}
 }
 
 // NewInfiniteTimeout is a factory method that sets the MaxTime to a value representing an indefinite
@@ -67,5 +69,5 @@ func NewTimeout(timeout int64) Timebounds {
 // deterministic testing. A Transaction cannot be built unless a Timebounds object is provided through
 // a factory method.
 func NewInfiniteTimeout() Timebounds {
-	return Timebounds{0, TimeoutInfinite, true}
+	return Timebounds{MinTime: 0, MaxTime: TimeoutInfinite, wasBuilt: true}
 }
@@ -11,6 +11,7 @@ package txnbuild
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -47,12 +48,66 @@ func hashHex(e xdr.TransactionEnvelope, networkStr string) (string, error) {
 	return hex.EncodeToString(h[:]), nil
 }
 
+// KeyUsageContext returns a copy of ctx declaring the usage of the
+// signatures of the transaction envelope, see keypair.WithKeyUsage. The usage
+// is keypair.UsageAuth if ctx declares it and the envelope is a challenge
+// transaction (see BuildChallengeTx), which can never be applied by the
+// network, and keypair.UsageSignTransactions otherwise. An error is returned
+// if ctx declares keypair.UsageAuth for any other transaction.
+func KeyUsageContext(ctx context.Context, e xdr.TransactionEnvelope) (context.Context, error) {
+	if usage, ok := keypair.KeyUsageFromContext(ctx); ok && usage == keypair.UsageAuth {
+		if err := checkChallengeEnvelope(e); err != nil {
+			return nil, errors.Wrap(err, "only challenge transactions can be signed for authentication")
+		}
+		return ctx, nil
+	}
+	return keypair.WithKeyUsage(ctx, keypair.UsageSignTransactions), nil
+}
+
+// checkChallengeEnvelope returns an error if the envelope does not have the
+// structure of a challenge transaction, as checked by ReadChallengeTx,
+// regardless of its server account and domains.
+func checkChallengeEnvelope(e xdr.TransactionEnvelope) error {
+	if e.IsFeeBump() {
+		return errors.New("challenge cannot be a fee bump transaction")
+	}
+	if e.SeqNum() != 0 {
+		return errors.New("transaction sequence number must be 0")
+	}
+	if tb := e.TimeBounds(); tb == nil || int64(tb.MaxTime) == TimeoutInfinite {
+		return errors.New("transaction requires non-infinite timebounds")
+	}
+	operations := e.Operations()
+	if len(operations) < 1 {
+		return errors.New("transaction requires at least one manage_data operation")
+	}
+	for i, op := range operations {
+		if op.Body.Type != xdr.OperationTypeManageData {
+			return errors.New("operation type should be manage_data")
+		}
+		if op.SourceAccount == nil {
+			return errors.New("operation should have a source account")
+		}
+		if i == 0 && !strings.HasSuffix(string(op.Body.ManageDataOp.DataName), " auth") {
+			return errors.Errorf("operation key %q is not the key of a challenge", op.Body.ManageDataOp.DataName)
+		}
+	}
+	return nil
+}
+
+// concatSignatures signs the transaction with ctx, declaring the usage of
+// the signatures returned by KeyUsageContext.
 func concatSignatures(
+	ctx context.Context,
 	e xdr.TransactionEnvelope,
 	networkStr string,
 	signatures []xdr.DecoratedSignature,
 	kps ...*keypair.Full,
 ) ([]xdr.DecoratedSignature, error) {
+	ctx, err := KeyUsageContext(ctx, e)
+	if err != nil {
+		return nil, err
+	}
 	// Hash the transaction
 	h, err := network.HashTransactionInEnvelope(e, networkStr)
 	if err != nil {
@@ -67,7 +122,7 @@ func concatSignatures(
 	copy(extended, signatures)
 	// Sign the hash
 	for _, kp := range kps {
-		sig, err := kp.SignDecorated(h[:])
+		sig, err := kp.SignDecoratedWithContext(ctx, h[:])
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to sign transaction")
 		}
@@ -213,6 +268,9 @@ type Transaction struct {
 	operations    []Operation
 	memo          Memo
 	timebounds    Timebounds
+
+	annotations          Annotations
+	operationAnnotations map[int]Annotations
 }
 
 // BaseFee returns the per operation fee for this transaction.
@@ -257,6 +315,26 @@ func (t *Transaction) Signatures() []xdr.DecoratedSignature {
 	return t.envelope.Signatures()
 }
 
+// Annotations returns the annotations attached to this transaction, which are
+// not part of its XDR. The returned map should not be modified.
+func (t *Transaction) Annotations() Annotations {
+	return t.annotations
+}
+
+// OperationAnnotations returns the annotations attached to the operation at
+// the given index in Operations(), which are not part of the transaction XDR.
+// The returned map should not be modified.
+func (t *Transaction) OperationAnnotations(index int) Annotations {
+	return t.operationAnnotations[index]
+}
+
+// AllOperationAnnotations returns the annotations of all the operations which
+// have some, keyed by their index in Operations(). The returned map should not
+// be modified.
+func (t *Transaction) AllOperationAnnotations() map[int]Annotations {
+	return t.operationAnnotations
+}
+
 // Hash returns the network specific hash of this transaction
 // encoded as a byte array.
 func (t *Transaction) Hash(networkStr string) ([32]byte, error) {
@@ -293,7 +371,17 @@ func (t *Transaction) clone(signatures []xdr.DecoratedSignature) *Transaction {
 // Sign returns a new Transaction instance which extends the current instance
 // with additional signatures derived from the given list of keypair instances.
 func (t *Transaction) Sign(network string, kps ...*keypair.Full) (*Transaction, error) {
-	extendedSignatures, err := concatSignatures(t.envelope, network, t.Signatures(), kps...)
+	return t.SignWithContext(context.Background(), network, kps...)
+}
+
+// SignWithContext is like Sign but passes ctx to the keypairs (see
+// keypair.Full.SignWithContext). The signatures have the
+// keypair.UsageSignTransactions usage, or keypair.UsageAuth if the
+// transaction is a SEP-10 challenge and ctx declares it, e.g.
+// keypair.WithKeyUsage(ctx, keypair.UsageAuth) to sign a challenge with a
+// keypair restricted to authentication. See KeyUsageContext.
+func (t *Transaction) SignWithContext(ctx context.Context, network string, kps ...*keypair.Full) (*Transaction, error) {
+	extendedSignatures, err := concatSignatures(ctx, t.envelope, network, t.Signatures(), kps...)
 	if err != nil {
 		return nil, err
 	}
@@ -448,6 +536,8 @@ type FeeBumpTransaction struct {
 	maxFee     int64
 	feeAccount string
 	inner      *Transaction
+
+	annotations Annotations
 }
 
 // BaseFee returns the per operation fee for this transaction.
@@ -495,7 +585,14 @@ func (t *FeeBumpTransaction) clone(signatures []xdr.DecoratedSignature) *FeeBump
 // Sign returns a new FeeBumpTransaction instance which extends the current instance
 // with additional signatures derived from the given list of keypair instances.
 func (t *FeeBumpTransaction) Sign(network string, kps ...*keypair.Full) (*FeeBumpTransaction, error) {
-	extendedSignatures, err := concatSignatures(t.envelope, network, t.Signatures(), kps...)
+	return t.SignWithContext(context.Background(), network, kps...)
+}
+
+// SignWithContext is like Sign but passes ctx to the keypairs (see
+// keypair.Full.SignWithContext). The signatures have the
+// keypair.UsageSignTransactions usage.
+func (t *FeeBumpTransaction) SignWithContext(ctx context.Context, network string, kps ...*keypair.Full) (*FeeBumpTransaction, error) {
+	extendedSignatures, err := concatSignatures(ctx, t.envelope, network, t.Signatures(), kps...)
 	if err != nil {
 		return nil, err
 	}
@@ -605,6 +702,13 @@ func (t *FeeBumpTransaction) InnerTransaction() *Transaction {
 	return innerCopy
 }
 
+// Annotations returns the annotations attached to this fee bump transaction
+// and to its inner transaction, the former taking precedence. They are not
+// part of the transaction XDR.
+func (t *FeeBumpTransaction) Annotations() Annotations {
+	return t.inner.annotations.merge(t.annotations)
+}
+
 // GenericTransaction represents a parsed transaction envelope returned by TransactionFromXDR.
 // A GenericTransaction can be either a Transaction or a FeeBumpTransaction.
 type GenericTransaction struct {
@@ -803,6 +907,13 @@ type TransactionParams struct {
 	BaseFee              int64
 	Memo                 Memo
 	Timebounds           Timebounds
+	// Annotations are attached to the transaction but are not part of its XDR.
+	Annotations Annotations
+	// OperationAnnotations are attached to the operations, keyed by their
+	// index in Operations, but are not part of the transaction XDR.
+	OperationAnnotations map[int]Annotations
+	// Hooks customize the envelope of the transaction, see EnvelopeHooks.
+	Hooks *EnvelopeHooks
 }
 
 // NewTransaction returns a new Transaction instance
@@ -823,15 +934,20 @@ func NewTransaction(params TransactionParams) (*Transaction, error) {
 		return nil, errors.Wrap(err, "could not obtain account sequence")
 	}
 
+	// the timeout of the time bounds is only kept by drafts, the time bounds
+	// of a transaction are the same once it is decoded
+	timebounds := params.Timebounds
+	timebounds.timeout = 0
 	tx := &Transaction{
 		baseFee: params.BaseFee,
 		sourceAccount: SimpleAccount{
 			AccountID: params.SourceAccount.GetAccountID(),
 			Sequence:  sequence,
 		},
-		operations: params.Operations,
-		memo:       params.Memo,
-		timebounds: params.Timebounds,
+		operations:  params.Operations,
+		memo:        params.Memo,
+		timebounds:  timebounds,
+		annotations: params.Annotations.clone(),
 	}
 	var sourceAccount xdr.MuxedAccount
 	if err = sourceAccount.SetAddress(tx.sourceAccount.AccountID); err != nil {
@@ -845,6 +961,11 @@ func NewTransaction(params TransactionParams) (*Transaction, error) {
 		return nil, errors.New("transaction has no operations")
 	}
 
+	tx.operationAnnotations, err = cloneOperationAnnotations(params.OperationAnnotations, len(tx.operations))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid operation annotations")
+	}
+
 	// check if maxFee fits in a uint32
 	// 64 bit fees are only available in fee bump transactions
 	// if maxFee is negative then there must have been an int overflow
@@ -896,6 +1017,9 @@ func NewTransaction(params TransactionParams) (*Transaction, error) {
 		envelope.V1.Tx.Operations = append(envelope.V1.Tx.Operations, xdrOperation)
 	}
 
+	if err = params.Hooks.preEncode(&envelope); err != nil {
+		return nil, err
+	}
 	tx.envelope = envelope
 	return tx, nil
 }
@@ -906,24 +1030,41 @@ type FeeBumpTransactionParams struct {
 	Inner      *Transaction
 	FeeAccount string
 	BaseFee    int64
-}
-
+	// Annotations are attached to the fee bump transaction but are not part of
+	// its XDR.
+	Annotations Annotations
+	// Hooks customize the envelope of the fee bump transaction, see
+	// EnvelopeHooks.
+	Hooks *EnvelopeHooks
+}
+
+// convertToV1 upgrades a v0 transaction envelope to v1 without rebuilding it
+// from its operations, so that transactions decoded from historical ledgers,
+// which may have no time bounds or carry operations failing the current
+// validation rules, keep the same hash and signatures.
 func convertToV1(tx *Transaction) (*Transaction, error) {
-	sourceAccount := tx.SourceAccount()
-	signatures := tx.Signatures()
-	tx, err := NewTransaction(TransactionParams{
-		SourceAccount:        &sourceAccount,
-		IncrementSequenceNum: false,
-		Operations:           tx.Operations(),
-		BaseFee:              tx.BaseFee(),
-		Memo:                 tx.Memo(),
-		Timebounds:           tx.Timebounds(),
-	})
-	if err != nil {
-		return tx, err
+	if tx.envelope.V0 == nil {
+		return tx, errors.New("transaction envelope is not v0")
 	}
-	tx.envelope.V1.Signatures = signatures
-	return tx, nil
+	v0 := tx.envelope.V0
+
+	upgraded := *tx
+	upgraded.envelope = xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: tx.envelope.SourceAccount(),
+				Fee:           v0.Tx.Fee,
+				SeqNum:        v0.Tx.SeqNum,
+				TimeBounds:    v0.Tx.TimeBounds,
+				Memo:          v0.Tx.Memo,
+				Operations:    v0.Tx.Operations,
+				Ext:           xdr.TransactionExt{V: v0.Tx.Ext.V},
+			},
+			Signatures: v0.Signatures,
+		},
+	}
+	return &upgraded, nil
 }
 
 // NewFeeBumpTransaction returns a new FeeBumpTransaction instance
@@ -955,9 +1096,10 @@ func NewFeeBumpTransaction(params FeeBumpTransactionParams) (*FeeBumpTransaction
 		// number of operations in the inner transaction. Correspondingly, the minimum fee for
 		// the fee-bump transaction is one base fee more than the minimum fee for the inner
 		// transaction.
-		maxFee:     params.BaseFee * int64(len(inner.operations)+1),
-		feeAccount: params.FeeAccount,
-		inner:      new(Transaction),
+		maxFee:      params.BaseFee * int64(len(inner.operations)+1),
+		feeAccount:  params.FeeAccount,
+		inner:       new(Transaction),
+		annotations: params.Annotations.clone(),
 	}
 	*tx.inner = *inner
 
@@ -989,19 +1131,39 @@ func NewFeeBumpTransaction(params FeeBumpTransactionParams) (*FeeBumpTransaction
 				Fee:       xdr.Int64(tx.maxFee),
 				InnerTx: xdr.FeeBumpTransactionInnerTx{
 					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
-					V1:   innerEnv.V1,
+					V1:   tx.inner.envelope.V1,
 				},
 			},
 		},
 	}
+	if err := params.Hooks.preEncode(&tx.envelope); err != nil {
+		return nil, err
+	}
 
 	return tx, nil
 }
 
+// ChallengeTxOptions are the options of the SEP 10 challenge functions. The
+// zero value is the behavior of the functions of the package, e.g.
+// BuildChallengeTx, which are equivalent to the methods of
+// ChallengeTxOptions{}.
+type ChallengeTxOptions struct {
+	// AllowMuxedClientAccount accepts client accounts which are muxed
+	// accounts (M...), as allowed since version 3.3.0 of SEP 10, e.g. for a
+	// custodial wallet authenticating one of its users. The signers of a
+	// muxed client account are the signers of the account it multiplexes.
+	AllowMuxedClientAccount bool
+}
+
 // BuildChallengeTx is a factory method that creates a valid SEP 10 challenge, for use in web authentication.
 // "timebound" is the time duration the transaction should be valid for, and must be greater than 1s (300s is recommended).
 // More details on SEP 10: https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0010.md
 func BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDomain, network string, timebound time.Duration) (*Transaction, error) {
+	return ChallengeTxOptions{}.BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDomain, network, timebound)
+}
+
+// BuildChallengeTx is like the BuildChallengeTx function, with the options o.
+func (o ChallengeTxOptions) BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDomain, network string, timebound time.Duration) (*Transaction, error) {
 	if timebound < time.Second {
 		return nil, errors.New("provided timebound must be at least 1s (300s is recommended)")
 	}
@@ -1022,7 +1184,12 @@ func BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDo
 		return nil, errors.New("64 byte long random nonce required")
 	}
 
-	if _, err = xdr.AddressToAccountId(clientAccountID); err != nil {
+	if o.AllowMuxedClientAccount {
+		_, err = xdr.AddressToMuxedAccount(clientAccountID)
+	} else {
+		_, err = xdr.AddressToAccountId(clientAccountID)
+	}
+	if err != nil {
 		return nil, errors.Wrapf(err, "%s is not a valid account id", clientAccountID)
 	}
 
@@ -1061,7 +1228,8 @@ func BuildChallengeTx(serverSignerSecret, clientAccountID, webAuthDomain, homeDo
 	if err != nil {
 		return nil, err
 	}
-	tx, err = tx.Sign(network, serverKP.(*keypair.Full))
+	ctx := keypair.WithKeyUsage(context.Background(), keypair.UsageAuth)
+	tx, err = tx.SignWithContext(ctx, network, serverKP.(*keypair.Full))
 	if err != nil {
 		return nil, err
 	}
@@ -1106,6 +1274,11 @@ func generateRandomNonce(n int) ([]byte, error) {
 // - VerifyChallengeTxThreshold
 // - VerifyChallengeTxSigners
 func ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string) (tx *Transaction, clientAccountID string, matchedHomeDomain string, err error) {
+	return ChallengeTxOptions{}.ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain, homeDomains)
+}
+
+// ReadChallengeTx is like the ReadChallengeTx function, with the options o.
+func (o ChallengeTxOptions) ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string) (tx *Transaction, clientAccountID string, matchedHomeDomain string, err error) {
 	parsed, err := TransactionFromXDR(challengeTx)
 	if err != nil {
 		return tx, clientAccountID, matchedHomeDomain, errors.Wrap(err, "could not parse challenge")
@@ -1169,7 +1342,7 @@ func ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain string
 
 	clientAccountID = op.SourceAccount
 	rawOperations := tx.envelope.Operations()
-	if len(rawOperations) > 0 && rawOperations[0].SourceAccount.Type == xdr.CryptoKeyTypeKeyTypeMuxedEd25519 {
+	if !o.AllowMuxedClientAccount && len(rawOperations) > 0 && rawOperations[0].SourceAccount.Type == xdr.CryptoKeyTypeKeyTypeMuxedEd25519 {
 		err = errors.New("invalid operation source account: only valid Ed25519 accounts are allowed in challenge transactions")
 		return tx, clientAccountID, matchedHomeDomain, err
 	}
@@ -1243,12 +1416,18 @@ func ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain string
 //    server account or one of the signers provided in the arguments.
 //  - The signatures are all valid but do not meet the threshold.
 func VerifyChallengeTxThreshold(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string, threshold Threshold, signerSummary SignerSummary) (signersFound []string, err error) {
+	return ChallengeTxOptions{}.VerifyChallengeTxThreshold(challengeTx, serverAccountID, network, webAuthDomain, homeDomains, threshold, signerSummary)
+}
+
+// VerifyChallengeTxThreshold is like the VerifyChallengeTxThreshold function,
+// with the options o.
+func (o ChallengeTxOptions) VerifyChallengeTxThreshold(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string, threshold Threshold, signerSummary SignerSummary) (signersFound []string, err error) {
 	signers := make([]string, 0, len(signerSummary))
 	for s := range signerSummary {
 		signers = append(signers, s)
 	}
 
-	signersFound, err = VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain, homeDomains, signers...)
+	signersFound, err = o.VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain, homeDomains, signers...)
 	if err != nil {
 		return nil, err
 	}
@@ -1288,8 +1467,14 @@ func VerifyChallengeTxThreshold(challengeTx, serverAccountID, network, webAuthDo
 //  - One or more signatures in the transaction are not identifiable as the
 //    server account or one of the signers provided in the arguments.
 func VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string, signers ...string) ([]string, error) {
+	return ChallengeTxOptions{}.VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain, homeDomains, signers...)
+}
+
+// VerifyChallengeTxSigners is like the VerifyChallengeTxSigners function,
+// with the options o.
+func (o ChallengeTxOptions) VerifyChallengeTxSigners(challengeTx, serverAccountID, network, webAuthDomain string, homeDomains []string, signers ...string) ([]string, error) {
 	// Read the transaction which validates its structure.
-	tx, _, _, err := ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain, homeDomains)
+	tx, _, _, err := o.ReadChallengeTx(challengeTx, serverAccountID, network, webAuthDomain, homeDomains)
 	if err != nil {
 		return nil, err
 	}
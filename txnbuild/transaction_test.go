@@ -1,6 +1,7 @@
 package txnbuild
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/price"
 	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,7 +44,9 @@ func TestTimebounds(t *testing.T) {
 		},
 	)
 	assert.NoError(t, err)
-	assert.Equal(t, tb, tx.timebounds)
+	// the timeout of the time bounds is not kept by the transaction, as when
+	// it is decoded
+	assert.Equal(t, NewTimebounds(tb.MinTime, tb.MaxTime), tx.timebounds)
 	assert.Equal(t, xdr.TimePoint(tb.MinTime), tx.envelope.V1.Tx.TimeBounds.MinTime)
 	assert.Equal(t, xdr.TimePoint(tb.MaxTime), tx.envelope.V1.Tx.TimeBounds.MaxTime)
 }
@@ -1434,6 +1438,97 @@ func TestBuild(t *testing.T) {
 	assert.Equal(t, expectedSigned, txeB64, "tx envelope should match")
 }
 
+func TestFromXDRLegacyOperations(t *testing.T) {
+	kp0 := newKeypair0()
+	source := xdr.MustAddress(kp0.Address())
+	usd := xdr.AssetCode{Type: xdr.AssetTypeAssetTypeCreditAlphanum4, AssetCode4: &xdr.AssetCode4{'U', 'S', 'D'}}
+
+	// a v0 transaction without time bounds as found in historical ledgers,
+	// with operations which are deprecated or invalid today: an offer
+	// deleted with a zero price must still be decoded as it may be part of a
+	// failed transaction
+	envelope := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxV0,
+		V0: &xdr.TransactionV0Envelope{
+			Tx: xdr.TransactionV0{
+				SourceAccountEd25519: *source.Ed25519,
+				Fee:                  300,
+				SeqNum:               1,
+				Operations: []xdr.Operation{
+					{Body: xdr.OperationBody{Type: xdr.OperationTypeInflation}},
+					{Body: xdr.OperationBody{
+						Type: xdr.OperationTypeAllowTrust,
+						AllowTrustOp: &xdr.AllowTrustOp{
+							Trustor:   xdr.MustAddress(newKeypair1().Address()),
+							Asset:     usd,
+							Authorize: 1,
+						},
+					}},
+					{Body: xdr.OperationBody{
+						Type: xdr.OperationTypeManageSellOffer,
+						ManageSellOfferOp: &xdr.ManageSellOfferOp{
+							Selling: xdr.MustNewNativeAsset(),
+							Buying:  xdr.MustNewCreditAsset("USD", kp0.Address()),
+							OfferId: 12,
+						},
+					}},
+				},
+			},
+		},
+	}
+	txeB64, err := xdr.MarshalBase64(envelope)
+	require.NoError(t, err)
+
+	parsed, err := TransactionFromXDR(txeB64)
+	require.NoError(t, err)
+	tx, ok := parsed.Transaction()
+	require.True(t, ok)
+	assert.Equal(t, []Operation{
+		&Inflation{},
+		&AllowTrust{Trustor: newKeypair1().Address(), Type: CreditAsset{Code: "USD"}, Authorize: true},
+		&ManageSellOffer{
+			Selling: NativeAsset{},
+			Buying:  CreditAsset{Code: "USD", Issuer: kp0.Address()},
+			Amount:  "0.0000000",
+			OfferID: 12,
+		},
+	}, tx.Operations())
+	assert.Equal(t, Timebounds{}, tx.Timebounds())
+
+	encoded, err := tx.Base64()
+	require.NoError(t, err)
+	assert.Equal(t, txeB64, encoded)
+
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp0)
+	require.NoError(t, err)
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: newKeypair1().Address(),
+		BaseFee:    MinBaseFee,
+	})
+	require.NoError(t, err)
+	inner := feeBump.InnerTransaction()
+	assert.Nil(t, inner.envelope.TimeBounds())
+	assert.Equal(t, tx.Signatures(), inner.Signatures())
+	innerHash, err := inner.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	originalHash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, originalHash, innerHash)
+
+	// the fee bump envelope embeds the upgraded inner transaction
+	feeBumpB64, err := feeBump.Base64()
+	require.NoError(t, err)
+	var feeBumpEnvelope xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(feeBumpB64, &feeBumpEnvelope))
+	assert.Equal(t, *inner.envelope.V1, feeBumpEnvelope.FeeBump.Tx.InnerTx.MustV1())
+	feeBumpHash, err := feeBump.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	expectedHash, err := network.HashTransactionInEnvelope(feeBumpEnvelope, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, feeBumpHash)
+}
+
 func TestFromXDRBuildSignEncode(t *testing.T) {
 	expectedUnsigned := "AAAAAgAAAADg3G3hclysZlFitS+s5zWyiiJD5B0STWy5LXCj6i5yxQAAAGQAIiCNAAAAGwAAAAEAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAVuZXd0eAAAAAAAAAEAAAAAAAAAAAAAAACE4N7avBtJL576CIWTzGCbGPvSlVfMQAOjcYbSsSF2VAAAAAAF9eEAAAAAAAAAAAHqLnLFAAAAQAz221zc6QuNPFsmBkLMzd1QPXuNbDabMmdh3EutkV71A7DdAPiFzD0TGgm/loJ9TjOiJGpvaJdDCWDXitAT8Qo="
 
@@ -2448,6 +2543,36 @@ func TestReadChallengeTx_forbidsMuxedAccounts(t *testing.T) {
 	assert.Contains(t, err.Error(), errorMessage)
 }
 
+func TestChallengeTxOptions_allowsMuxedClientAccount(t *testing.T) {
+	serverKP := newKeypair0()
+	clientKP := newKeypair1()
+	muxedAccount, err := xdr.MuxedAccountFromAccountId(clientKP.Address(), 0xcafebabe)
+	require.NoError(t, err)
+	clientAccountID := muxedAccount.Address()
+
+	_, err = BuildChallengeTx(serverKP.Seed(), clientAccountID, "testwebauth.stellar.org", "testanchor.stellar.org", network.TestNetworkPassphrase, time.Hour)
+	assert.EqualError(t, err, clientAccountID+" is not a valid account id: invalid version byte")
+
+	options := ChallengeTxOptions{AllowMuxedClientAccount: true}
+	tx, err := options.BuildChallengeTx(serverKP.Seed(), clientAccountID, "testwebauth.stellar.org", "testanchor.stellar.org", network.TestNetworkPassphrase, time.Hour)
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, clientKP)
+	require.NoError(t, err)
+	challenge, err := tx.Base64()
+	require.NoError(t, err)
+
+	_, _, _, err = ReadChallengeTx(challenge, serverKP.Address(), network.TestNetworkPassphrase, "testwebauth.stellar.org", []string{"testanchor.stellar.org"})
+	assert.EqualError(t, err, "invalid operation source account: only valid Ed25519 accounts are allowed in challenge transactions")
+
+	_, readClientAccountID, _, err := options.ReadChallengeTx(challenge, serverKP.Address(), network.TestNetworkPassphrase, "testwebauth.stellar.org", []string{"testanchor.stellar.org"})
+	require.NoError(t, err)
+	assert.Equal(t, clientAccountID, readClientAccountID)
+
+	signersFound, err := options.VerifyChallengeTxThreshold(challenge, serverKP.Address(), network.TestNetworkPassphrase, "testwebauth.stellar.org", []string{"testanchor.stellar.org"}, 1, SignerSummary{clientKP.Address(): 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{clientKP.Address()}, signersFound)
+}
+
 func TestReadChallengeTx_doesVerifyHomeDomainFailure(t *testing.T) {
 	serverKP := newKeypair0()
 	clientKP := newKeypair1()
@@ -4841,3 +4966,144 @@ func TestGenericTransaction_HashHex(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, hashHex)
 }
+
+func TestTransactionAnnotations(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := NewSimpleAccount(kp0.Address(), 1)
+	annotations := Annotations{"correlation_id": "abc"}
+
+	tx, err := NewTransaction(
+		TransactionParams{
+			SourceAccount: &sourceAccount,
+			Operations:    []Operation{&BumpSequence{BumpTo: 1}, &BumpSequence{BumpTo: 2}},
+			BaseFee:       MinBaseFee,
+			Timebounds:    NewInfiniteTimeout(),
+			Annotations:   annotations,
+			OperationAnnotations: map[int]Annotations{
+				1: {"label": "second"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Annotations{"correlation_id": "abc"}, tx.Annotations())
+	assert.Nil(t, tx.OperationAnnotations(0))
+	assert.Equal(t, Annotations{"label": "second"}, tx.OperationAnnotations(1))
+
+	// the annotations are copied and are not part of the XDR
+	annotations["correlation_id"] = "changed"
+	assert.Equal(t, "abc", tx.Annotations()["correlation_id"])
+	withoutAnnotations, err := NewTransaction(
+		TransactionParams{
+			SourceAccount: &sourceAccount,
+			Operations:    []Operation{&BumpSequence{BumpTo: 1}, &BumpSequence{BumpTo: 2}},
+			BaseFee:       MinBaseFee,
+			Timebounds:    NewInfiniteTimeout(),
+		},
+	)
+	require.NoError(t, err)
+	expected, err := withoutAnnotations.Base64()
+	require.NoError(t, err)
+	actual, err := tx.Base64()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// signing keeps the annotations
+	signed, err := tx.Sign(network.TestNetworkPassphrase, kp0)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Annotations(), signed.Annotations())
+	assert.Equal(t, tx.AllOperationAnnotations(), signed.AllOperationAnnotations())
+
+	feeBump, err := NewFeeBumpTransaction(
+		FeeBumpTransactionParams{
+			Inner:       signed,
+			FeeAccount:  newKeypair1().Address(),
+			BaseFee:     MinBaseFee,
+			Annotations: Annotations{"correlation_id": "fee-bump", "fee": "sponsored"},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Annotations{"correlation_id": "fee-bump", "fee": "sponsored"}, feeBump.Annotations())
+	assert.Equal(t, signed.Annotations(), feeBump.InnerTransaction().Annotations())
+
+	_, err = NewTransaction(
+		TransactionParams{
+			SourceAccount:        &sourceAccount,
+			Operations:           []Operation{&BumpSequence{BumpTo: 1}},
+			BaseFee:              MinBaseFee,
+			Timebounds:           NewInfiniteTimeout(),
+			OperationAnnotations: map[int]Annotations{1: {"label": "missing"}},
+		},
+	)
+	assert.EqualError(t, err, "invalid operation annotations: annotations refer to operation 1 but the transaction has 1 operations")
+}
+
+func TestSignWithKeyUsage(t *testing.T) {
+	serverKP := newKeypair0()
+	clientKP, err := newKeypair1().WithUsages(keypair.UsageAuth)
+	require.NoError(t, err)
+
+	tx, err := BuildChallengeTx(serverKP.Seed(), clientKP.Address(), "testwebauth.stellar.org", "testanchor.stellar.org", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+
+	// a keypair restricted to authentication cannot sign transactions
+	_, err = tx.Sign(network.TestNetworkPassphrase, clientKP)
+	require.Error(t, err)
+	assert.IsType(t, &keypair.KeyUsageError{}, errors.Cause(err))
+
+	ctx := keypair.WithKeyUsage(context.Background(), keypair.UsageAuth)
+	signed, err := tx.SignWithContext(ctx, network.TestNetworkPassphrase, clientKP)
+	require.NoError(t, err)
+	assert.Len(t, signed.Signatures(), 2)
+
+	signedTx, err := signed.Base64()
+	require.NoError(t, err)
+	_, err = VerifyChallengeTxSigners(signedTx, serverKP.Address(), network.TestNetworkPassphrase, "testwebauth.stellar.org", []string{"testanchor.stellar.org"}, clientKP.Address())
+	assert.NoError(t, err)
+
+	// only challenges can be signed for authentication
+	payment, err := NewTransaction(TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: clientKP.Address(), Sequence: -1},
+		IncrementSequenceNum: true,
+		Operations:           []Operation{&Payment{Destination: serverKP.Address(), Amount: "10", Asset: NativeAsset{}}},
+		BaseFee:              MinBaseFee,
+		Timebounds:           NewTimeout(300),
+	})
+	require.NoError(t, err)
+	_, err = payment.SignWithContext(ctx, network.TestNetworkPassphrase, clientKP)
+	assert.EqualError(t, err, "only challenge transactions can be signed for authentication: operation type should be manage_data")
+
+	// nor can fee bumps, whose signatures are checked too
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      signed,
+		FeeAccount: clientKP.Address(),
+		BaseFee:    MinBaseFee,
+	})
+	require.NoError(t, err)
+	_, err = feeBump.SignWithContext(ctx, network.TestNetworkPassphrase, clientKP)
+	assert.EqualError(t, err, "only challenge transactions can be signed for authentication: challenge cannot be a fee bump transaction")
+	_, err = feeBump.Sign(network.TestNetworkPassphrase, clientKP)
+	assert.IsType(t, &keypair.KeyUsageError{}, errors.Cause(err))
+}
+
+func TestCheckChallengeEnvelope(t *testing.T) {
+	kp := newKeypair0()
+	build := func(sequence int64, timebounds Timebounds, ops ...Operation) xdr.TransactionEnvelope {
+		tx, err := NewTransaction(TransactionParams{
+			SourceAccount:        &SimpleAccount{AccountID: kp.Address(), Sequence: sequence},
+			IncrementSequenceNum: true,
+			Operations:           ops,
+			BaseFee:              MinBaseFee,
+			Timebounds:           timebounds,
+		})
+		require.NoError(t, err)
+		return tx.ToXDR()
+	}
+	auth := &ManageData{SourceAccount: kp.Address(), Name: "testanchor.stellar.org auth", Value: []byte("nonce")}
+
+	assert.NoError(t, checkChallengeEnvelope(build(-1, NewTimeout(300), auth)))
+	assert.EqualError(t, checkChallengeEnvelope(build(0, NewTimeout(300), auth)), "transaction sequence number must be 0")
+	assert.EqualError(t, checkChallengeEnvelope(build(-1, NewInfiniteTimeout(), auth)), "transaction requires non-infinite timebounds")
+	assert.EqualError(t, checkChallengeEnvelope(build(-1, NewTimeout(300), &ManageData{Name: "testanchor.stellar.org auth"})), "operation should have a source account")
+	assert.EqualError(t, checkChallengeEnvelope(build(-1, NewTimeout(300), &ManageData{SourceAccount: kp.Address(), Name: "name"})), `operation key "name" is not the key of a challenge`)
+	assert.EqualError(t, checkChallengeEnvelope(build(-1, NewTimeout(300), auth, &BumpSequence{BumpTo: 1})), "operation type should be manage_data")
+}
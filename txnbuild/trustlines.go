@@ -0,0 +1,156 @@
+package txnbuild
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// TrustlineLoader loads the credit assets an account has a trustline for. It
+// is implemented by horizonclient.Client.
+type TrustlineLoader interface {
+	LoadTrustlines(accountID string) ([]CreditAsset, error)
+}
+
+// TrustlineCheck configures EnsureTrustlines.
+type TrustlineCheck struct {
+	// Loader loads the trustlines of the destinations of the payments.
+	Loader TrustlineLoader
+	// Controlled are the accounts whose signatures the transaction will
+	// carry. When one of them is the destination of a payment of an asset it
+	// does not trust, a ChangeTrust operation is inserted for it instead of
+	// returning an error.
+	Controlled []string
+}
+
+// MissingTrustlineError is returned by EnsureTrustlines when the destination
+// of a payment has no trustline for the asset it would receive, in which case
+// the operation would fail with op_no_trust.
+type MissingTrustlineError struct {
+	// Operation is the index of the payment in the operations.
+	Operation   int
+	Destination string
+	Asset       Asset
+}
+
+func (e *MissingTrustlineError) Error() string {
+	return fmt.Sprintf(
+		"destination %s of operation %d has no trustline for %s:%s and would fail with op_no_trust",
+		e.Destination, e.Operation, e.Asset.GetCode(), e.Asset.GetIssuer(),
+	)
+}
+
+// EnsureTrustlines checks that the destinations of the Payment,
+// PathPaymentStrictReceive and PathPaymentStrictSend operations have a
+// trustline for the asset they receive, loading them with check.Loader.
+//
+// A ChangeTrust operation with the default limit, whose source account is the
+// destination, is inserted before the first payment to a destination listed in
+// check.Controlled which does not trust the asset. For other destinations a
+// *MissingTrustlineError is returned. The trustlines created by the
+// operations themselves (CreateAccount and ChangeTrust) are taken into
+// account, so are the asset issuers which never need a trustline.
+func EnsureTrustlines(operations []Operation, check TrustlineCheck) ([]Operation, error) {
+	if check.Loader == nil {
+		return nil, errors.New("trustline loader is missing")
+	}
+
+	controlled := map[string]bool{}
+	for _, account := range check.Controlled {
+		controlled[accountFromMuxed(account)] = true
+	}
+	loaded := map[string]map[CreditAsset]bool{}
+	// created contains the accounts created by the operations, and changed
+	// the trustlines created or removed by them
+	created := map[string]bool{}
+	changed := map[string]map[CreditAsset]bool{}
+	trusts := func(account string, asset CreditAsset) (bool, error) {
+		if trusted, ok := changed[account][asset]; ok {
+			return trusted, nil
+		}
+		if created[account] {
+			return false, nil
+		}
+		assets, ok := loaded[account]
+		if !ok {
+			trustlines, err := check.Loader.LoadTrustlines(account)
+			if err != nil {
+				return false, errors.Wrapf(err, "could not load trustlines of %s", account)
+			}
+			assets = map[CreditAsset]bool{}
+			for _, trustline := range trustlines {
+				assets[trustline] = true
+			}
+			loaded[account] = assets
+		}
+		return assets[asset], nil
+	}
+	change := func(account string, asset CreditAsset, trusted bool) {
+		if changed[account] == nil {
+			changed[account] = map[CreditAsset]bool{}
+		}
+		changed[account][asset] = trusted
+	}
+
+	result := make([]Operation, 0, len(operations))
+	for i, op := range operations {
+		var destination string
+		var asset Asset
+		switch op := op.(type) {
+		case *Payment:
+			destination, asset = op.Destination, op.Asset
+		case *PathPaymentStrictReceive:
+			destination, asset = op.Destination, op.DestAsset
+		case *PathPaymentStrictSend:
+			destination, asset = op.Destination, op.DestAsset
+		case *CreateAccount:
+			created[op.Destination] = true
+		case *ChangeTrust:
+			// the source account of the transaction is unknown here, it is up
+			// to the caller to check its own trustlines
+			if op.Line != nil && op.Line.GetIssuer() != "" && op.SourceAccount != "" {
+				key := CreditAsset{Code: op.Line.GetCode(), Issuer: op.Line.GetIssuer()}
+				change(accountFromMuxed(op.SourceAccount), key, op.Limit != "0")
+			}
+		}
+		if asset == nil || asset.IsNative() {
+			result = append(result, op)
+			continue
+		}
+
+		destination = accountFromMuxed(destination)
+		key := CreditAsset{Code: asset.GetCode(), Issuer: asset.GetIssuer()}
+		if destination == key.Issuer {
+			result = append(result, op)
+			continue
+		}
+		trusted, err := trusts(destination, key)
+		if err != nil {
+			return nil, err
+		}
+		if !trusted {
+			if !controlled[destination] {
+				return nil, &MissingTrustlineError{Operation: i, Destination: destination, Asset: asset}
+			}
+			line, err := asset.ToChangeTrustAsset()
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid asset of operation %d", i)
+			}
+			result = append(result, &ChangeTrust{Line: line, SourceAccount: destination})
+			change(destination, key, true)
+		}
+		result = append(result, op)
+	}
+	return result, nil
+}
+
+// accountFromMuxed returns the G address of a muxed account address, or the
+// address unchanged if it is not a valid M or G address.
+func accountFromMuxed(address string) string {
+	muxed, err := xdr.AddressToMuxedAccount(address)
+	if err != nil {
+		return address
+	}
+	return muxed.ToAccountId().Address()
+}
@@ -0,0 +1,129 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapTrustlineLoader struct {
+	trustlines map[string][]CreditAsset
+	loads      map[string]int
+}
+
+func (l *mapTrustlineLoader) LoadTrustlines(accountID string) ([]CreditAsset, error) {
+	l.loads[accountID]++
+	trustlines, ok := l.trustlines[accountID]
+	if !ok {
+		return nil, errors.New("account not found")
+	}
+	return trustlines, nil
+}
+
+func TestEnsureTrustlines(t *testing.T) {
+	issuer := newKeypair0().Address()
+	trusting := newKeypair1().Address()
+	controlled := newKeypair2().Address()
+	usd := CreditAsset{Code: "USD", Issuer: issuer}
+	eur := CreditAsset{Code: "EUR", Issuer: issuer}
+	loader := &mapTrustlineLoader{
+		trustlines: map[string][]CreditAsset{
+			trusting:   {usd},
+			controlled: {},
+		},
+		loads: map[string]int{},
+	}
+	check := TrustlineCheck{Loader: loader, Controlled: []string{controlled}}
+
+	operations := []Operation{
+		&Payment{Destination: trusting, Amount: "1", Asset: usd},
+		&Payment{Destination: trusting, Amount: "1", Asset: NativeAsset{}},
+		&Payment{Destination: trusting, Amount: "1", Asset: usd},
+		&PathPaymentStrictSend{Destination: controlled, DestAsset: eur, DestMin: "1", SendAsset: NativeAsset{}, SendAmount: "1"},
+		&Payment{Destination: controlled, Amount: "1", Asset: eur},
+		&Payment{Destination: issuer, Amount: "1", Asset: eur},
+	}
+	result, err := EnsureTrustlines(operations, check)
+	require.NoError(t, err)
+	assert.Equal(t, []Operation{
+		operations[0],
+		operations[1],
+		operations[2],
+		&ChangeTrust{Line: eur.MustToChangeTrustAsset(), SourceAccount: controlled},
+		operations[3],
+		operations[4],
+		operations[5],
+	}, result)
+	assert.Equal(t, map[string]int{trusting: 1, controlled: 1}, loader.loads)
+
+	// destinations which are not controlled cause an error
+	_, err = EnsureTrustlines([]Operation{
+		&Payment{Destination: trusting, Amount: "1", Asset: usd},
+		&PathPaymentStrictReceive{Destination: trusting, DestAsset: eur, DestAmount: "1", SendAsset: NativeAsset{}, SendMax: "1"},
+	}, check)
+	assert.EqualError(t, err, "destination "+trusting+" of operation 1 has no trustline for EUR:"+issuer+" and would fail with op_no_trust")
+	missing, ok := err.(*MissingTrustlineError)
+	require.True(t, ok)
+	assert.Equal(t, 1, missing.Operation)
+	assert.Equal(t, trusting, missing.Destination)
+	assert.Equal(t, eur, missing.Asset)
+
+	_, err = EnsureTrustlines([]Operation{&Payment{Destination: issuer, Amount: "1", Asset: usd}}, TrustlineCheck{})
+	assert.EqualError(t, err, "trustline loader is missing")
+}
+
+func TestEnsureTrustlinesOperationsChangingTrustlines(t *testing.T) {
+	issuer := newKeypair0().Address()
+	trusting := newKeypair1().Address()
+	created := newKeypair2().Address()
+	usd := CreditAsset{Code: "USD", Issuer: issuer}
+	loader := &mapTrustlineLoader{
+		trustlines: map[string][]CreditAsset{trusting: {usd}},
+		loads:      map[string]int{},
+	}
+
+	// the account created in the transaction is not loaded and the
+	// ChangeTrust operation for it is inserted after its creation
+	operations := []Operation{
+		&CreateAccount{Destination: created, Amount: "10"},
+		&Payment{Destination: created, Amount: "1", Asset: usd},
+	}
+	result, err := EnsureTrustlines(operations, TrustlineCheck{Loader: loader, Controlled: []string{created}})
+	require.NoError(t, err)
+	assert.Equal(t, []Operation{
+		operations[0],
+		&ChangeTrust{Line: usd.MustToChangeTrustAsset(), SourceAccount: created},
+		operations[1],
+	}, result)
+
+	// the trustlines created and removed by the operations are taken into
+	// account
+	operations = []Operation{
+		&CreateAccount{Destination: created, Amount: "10"},
+		&ChangeTrust{Line: usd.MustToChangeTrustAsset(), SourceAccount: created},
+		&Payment{Destination: created, Amount: "1", Asset: usd},
+	}
+	result, err = EnsureTrustlines(operations, TrustlineCheck{Loader: loader})
+	require.NoError(t, err)
+	assert.Equal(t, operations, result)
+
+	muxed, err := xdr.MuxedAccountFromAccountId(trusting, 7)
+	require.NoError(t, err)
+	operations = []Operation{
+		&ChangeTrust{Line: usd.MustToChangeTrustAsset(), Limit: "0", SourceAccount: trusting},
+		&Payment{Destination: muxed.Address(), Amount: "1", Asset: usd},
+	}
+	_, err = EnsureTrustlines(operations, TrustlineCheck{Loader: loader})
+	assert.EqualError(t, err, "destination "+trusting+" of operation 1 has no trustline for USD:"+issuer+" and would fail with op_no_trust")
+	assert.Empty(t, loader.loads)
+
+	_, err = EnsureTrustlines([]Operation{
+		&Payment{Destination: keypair.MustRandom().Address(), Amount: "1", Asset: usd},
+	}, TrustlineCheck{Loader: loader})
+	assert.Contains(t, err.Error(), "could not load trustlines of ")
+	assert.Contains(t, err.Error(), ": account not found")
+}
@@ -0,0 +1,144 @@
+// Package txnbuildtest provides assertions comparing the transactions and
+// operations built by an application with txnbuild against the ones expected
+// by its tests.
+//
+// The operations are compared as they are encoded in XDR, so that the
+// expected operations can be written naturally: "10" and "10.0000000" are
+// the same amount, and an operation built by the application compares equal
+// to the one decoded from its envelope. The signatures and the sequence
+// number of the transactions, which tests rarely control, are ignored.
+//
+// A failed assertion reports the index and the type of the operation and a
+// diff of the expected and actual operations.
+package txnbuildtest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go/txnbuild"
+)
+
+type tHelper interface {
+	Helper()
+}
+
+// NormalizeOperation returns the operation as it is decoded from its XDR, the
+// form the operations are compared in.
+func NormalizeOperation(op txnbuild.Operation) (txnbuild.Operation, error) {
+	if op == nil {
+		return nil, nil
+	}
+	xdrOp, err := op.BuildXDR()
+	if err != nil {
+		return nil, err
+	}
+	typ := reflect.TypeOf(op)
+	if typ.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("operation %T is not a pointer", op)
+	}
+	normalized := reflect.New(typ.Elem()).Interface().(txnbuild.Operation)
+	if err = normalized.FromXDR(xdrOp); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// AssertOpEquals asserts that the operations are equal once normalized, see
+// NormalizeOperation.
+func AssertOpEquals(t assert.TestingT, expected, actual txnbuild.Operation, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return assertOpEquals(t, "", expected, actual, msgAndArgs...)
+}
+
+// AssertOpsEqual asserts that the lists of operations have the same length
+// and that their operations are equal once normalized.
+func AssertOpsEqual(t assert.TestingT, expected, actual []txnbuild.Operation, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Len(t, actual, len(expected), msgAndArgs...) {
+		return false
+	}
+	equal := true
+	for i := range expected {
+		prefix := fmt.Sprintf("operation %d: ", i)
+		equal = assertOpEquals(t, prefix, expected[i], actual[i], msgAndArgs...) && equal
+	}
+	return equal
+}
+
+// AssertTransactionOps asserts that the operations of the transaction are the
+// expected ones, see AssertOpsEqual.
+func AssertTransactionOps(t assert.TestingT, expected []txnbuild.Operation, tx *txnbuild.Transaction, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return AssertOpsEqual(t, expected, tx.Operations(), msgAndArgs...)
+}
+
+// AssertTransactionEquals asserts that the transactions have the same source
+// account, base fee, memo, time bounds and operations, ignoring their
+// sequence numbers and signatures.
+func AssertTransactionEquals(t assert.TestingT, expected, actual *txnbuild.Transaction, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	equal := assertField(t, "source account", expected.SourceAccount().AccountID, actual.SourceAccount().AccountID, msgAndArgs...)
+	equal = assertField(t, "base fee", expected.BaseFee(), actual.BaseFee(), msgAndArgs...) && equal
+	equal = assertField(t, "memo", expected.Memo(), actual.Memo(), msgAndArgs...) && equal
+	equal = assertField(t, "time bounds", expected.Timebounds(), actual.Timebounds(), msgAndArgs...) && equal
+	return AssertOpsEqual(t, expected.Operations(), actual.Operations(), msgAndArgs...) && equal
+}
+
+func assertOpEquals(t assert.TestingT, prefix string, expected, actual txnbuild.Operation, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		return assert.Fail(t, fmt.Sprintf("%sexpected a %T operation, got a %T operation", prefix, expected, actual), msgAndArgs...)
+	}
+	normalizedExpected, err := NormalizeOperation(expected)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("%sinvalid expected %T operation: %v", prefix, expected, err), msgAndArgs...)
+	}
+	normalizedActual, err := NormalizeOperation(actual)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("%sinvalid %T operation: %v", prefix, actual, err), msgAndArgs...)
+	}
+	if assert.ObjectsAreEqual(normalizedExpected, normalizedActual) {
+		return true
+	}
+	// assert.Equal reports the diff of the operations
+	return assert.Equal(t, normalizedExpected, normalizedActual, message(fmt.Sprintf("%s%T operations differ", prefix, expected), msgAndArgs))
+}
+
+func assertField(t assert.TestingT, name string, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ObjectsAreEqual(expected, actual) {
+		return true
+	}
+	return assert.Equal(t, expected, actual, message("transactions have different "+name, msgAndArgs))
+}
+
+// message prepends the description of the failure to the message of the
+// caller, formatted like testify does.
+func message(description string, msgAndArgs []interface{}) string {
+	switch {
+	case len(msgAndArgs) == 0:
+		return description
+	case len(msgAndArgs) == 1:
+		return fmt.Sprintf("%s: %+v", description, msgAndArgs[0])
+	}
+	format, ok := msgAndArgs[0].(string)
+	if !ok {
+		return fmt.Sprintf("%s: %+v", description, msgAndArgs)
+	}
+	return description + ": " + fmt.Sprintf(format, msgAndArgs[1:]...)
+}
@@ -0,0 +1,106 @@
+package txnbuildtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+)
+
+// recorder is an assert.TestingT recording the failures.
+type recorder struct {
+	failures []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertOpEquals(t *testing.T) {
+	destination := keypair.MustRandom().Address()
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: keypair.MustRandom().Address()}
+
+	r := &recorder{}
+	assert.True(t, AssertOpEquals(r,
+		&txnbuild.Payment{Destination: destination, Amount: "10", Asset: usd},
+		&txnbuild.Payment{Destination: destination, Amount: "10.0000000", Asset: usd},
+	))
+	assert.Empty(t, r.failures)
+
+	assert.False(t, AssertOpEquals(r,
+		&txnbuild.Payment{Destination: destination, Amount: "10", Asset: usd},
+		&txnbuild.Payment{Destination: destination, Amount: "11", Asset: usd},
+		"payment to %s", "alice",
+	))
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], "*txnbuild.Payment operations differ: payment to alice")
+	assert.Contains(t, r.failures[0], `- Amount: (string) (len=10) "10.0000000"`)
+	assert.Contains(t, r.failures[0], `+ Amount: (string) (len=10) "11.0000000"`)
+
+	r = &recorder{}
+	assert.False(t, AssertOpEquals(r,
+		&txnbuild.Payment{Destination: destination, Amount: "10", Asset: usd},
+		&txnbuild.BumpSequence{BumpTo: 1},
+	))
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], "expected a *txnbuild.Payment operation, got a *txnbuild.BumpSequence operation")
+
+	r = &recorder{}
+	assert.False(t, AssertOpEquals(r,
+		&txnbuild.Payment{Destination: "invalid", Amount: "10", Asset: usd},
+		&txnbuild.Payment{Destination: destination, Amount: "10", Asset: usd},
+	))
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], "invalid expected *txnbuild.Payment operation")
+}
+
+func TestAssertTransactionEquals(t *testing.T) {
+	source := keypair.MustRandom()
+	destination := keypair.MustRandom().Address()
+	build := func(sequence int64, amount string) *txnbuild.Transaction {
+		tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+			SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: sequence},
+			IncrementSequenceNum: true,
+			Operations: []txnbuild.Operation{
+				&txnbuild.BumpSequence{BumpTo: 0},
+				&txnbuild.Payment{Destination: destination, Amount: amount, Asset: txnbuild.NativeAsset{}},
+			},
+			BaseFee:    txnbuild.MinBaseFee,
+			Memo:       txnbuild.MemoText("memo"),
+			Timebounds: txnbuild.NewTimebounds(0, 100),
+		})
+		require.NoError(t, err)
+		return tx
+	}
+
+	// the decoded transaction has a different sequence number and signatures
+	signed, err := build(5, "1").Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	envelope, err := signed.Base64()
+	require.NoError(t, err)
+	generic, err := txnbuild.TransactionFromXDR(envelope)
+	require.NoError(t, err)
+	decoded, _ := generic.Transaction()
+
+	r := &recorder{}
+	assert.True(t, AssertTransactionEquals(r, build(1, "1"), decoded))
+	assert.True(t, AssertTransactionOps(r, []txnbuild.Operation{
+		&txnbuild.BumpSequence{},
+		&txnbuild.Payment{Destination: destination, Amount: "1.0", Asset: txnbuild.NativeAsset{}},
+	}, decoded))
+	assert.Empty(t, r.failures)
+
+	assert.False(t, AssertTransactionEquals(r, build(1, "2"), decoded))
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], "operation 1: *txnbuild.Payment operations differ")
+
+	r = &recorder{}
+	assert.False(t, AssertTransactionOps(r, []txnbuild.Operation{&txnbuild.BumpSequence{}}, decoded))
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], "should have 1 item(s), but has 2")
+}
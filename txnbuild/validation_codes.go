@@ -0,0 +1,143 @@
+package txnbuild
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorCode is the machine readable reason of a ValidationError, e.g. to map
+// it to a localized message with a MessageCatalog. The codes are stable: new
+// codes may be added but existing ones are never renamed.
+type ErrorCode string
+
+// The codes of the validation errors. The parameters of the errors, set in
+// ValidationError.Params, are listed next to their code. All errors have a
+// "field" parameter, the name of the invalid field.
+const (
+	// CodeInvalid is the code of the validation errors without a more
+	// specific code.
+	CodeInvalid ErrorCode = "invalid"
+	// CodePublicKeyUndefined is the code of a missing account address.
+	CodePublicKeyUndefined ErrorCode = "public_key_undefined"
+	// CodePublicKeyInvalid is the code of an invalid account address: "value".
+	CodePublicKeyInvalid ErrorCode = "public_key_invalid"
+	// CodeSignerKeyUndefined is the code of a missing signer key.
+	CodeSignerKeyUndefined ErrorCode = "signer_key_undefined"
+	// CodeSignerKeyInvalid is the code of an invalid signer key: "value".
+	CodeSignerKeyInvalid ErrorCode = "signer_key_invalid"
+	// CodeAssetUndefined is the code of a missing asset.
+	CodeAssetUndefined ErrorCode = "asset_undefined"
+	// CodeAssetNativeNotAllowed is the code of the native asset used where
+	// only credit assets are allowed.
+	CodeAssetNativeNotAllowed ErrorCode = "asset_native_not_allowed"
+	// CodeAssetCodeInvalid is the code of an asset code which is empty or too
+	// long: "code".
+	CodeAssetCodeInvalid ErrorCode = "asset_code_invalid"
+	// CodeAssetIssuerInvalid is the code of a missing or invalid asset issuer:
+	// "value".
+	CodeAssetIssuerInvalid ErrorCode = "asset_issuer_invalid"
+	// CodeAmountInvalid is the code of an amount which cannot be parsed:
+	// "value".
+	CodeAmountInvalid ErrorCode = "amount_invalid"
+	// CodeAmountNegative is the code of a negative amount.
+	CodeAmountNegative ErrorCode = "amount_negative"
+	// CodePriceZero is the code of a price of 0: "n" and "d".
+	CodePriceZero ErrorCode = "price_zero"
+	// CodePriceDenominatorZero is the code of a price whose denominator is 0:
+	// "n" and "d".
+	CodePriceDenominatorZero ErrorCode = "price_denominator_zero"
+	// CodePriceNegative is the code of a negative price: "n" and "d".
+	CodePriceNegative ErrorCode = "price_negative"
+	// CodePoolParametersUndefined is the code of a pool share asset without
+	// liquidity pool parameters.
+	CodePoolParametersUndefined ErrorCode = "pool_parameters_undefined"
+	// CodePoolAssetInvalid is the code of an invalid asset of a liquidity pool:
+	// "asset", "A" or "B".
+	CodePoolAssetInvalid ErrorCode = "pool_asset_invalid"
+	// CodePoolAssetsEqual is the code of a liquidity pool of twice the same
+	// asset.
+	CodePoolAssetsEqual ErrorCode = "pool_assets_equal"
+	// CodePoolAssetsUnordered is the code of a liquidity pool whose assets are
+	// not in lexicographic order.
+	CodePoolAssetsUnordered ErrorCode = "pool_assets_unordered"
+	// CodePoolFeeInvalid is the code of an unsupported liquidity pool fee:
+	// "fee", the supported fee.
+	CodePoolFeeInvalid ErrorCode = "pool_fee_invalid"
+	// CodeTooLong is the code of a value longer than allowed: "max".
+	CodeTooLong ErrorCode = "too_long"
+)
+
+// codedError is an error returned by the validation helpers, whose code and
+// parameters are copied to the ValidationError wrapping it.
+type codedError struct {
+	code    ErrorCode
+	params  map[string]string
+	message string
+}
+
+func (e *codedError) Error() string {
+	return e.message
+}
+
+// newCodedError returns an error with the given code and parameters, and the
+// formatted message.
+func newCodedError(code ErrorCode, params map[string]string, format string, args ...interface{}) error {
+	return &codedError{code: code, params: params, message: fmt.Sprintf(format, args...)}
+}
+
+// withCode returns err with the given code, and the parameters of err if it
+// has some, the prefix prepended to its message. The params are added to the
+// parameters of err.
+func withCode(code ErrorCode, params map[string]string, prefix string, err error) error {
+	merged := map[string]string{}
+	var cerr *codedError
+	if stderrors.As(err, &cerr) {
+		for name, value := range cerr.params {
+			merged[name] = value
+		}
+	}
+	for name, value := range params {
+		merged[name] = value
+	}
+	return &codedError{code: code, params: merged, message: prefix + err.Error()}
+}
+
+// fieldValidationError returns a ValidationError of field with the message
+// of err, and its code and parameters if it has some, CodeInvalid otherwise.
+func fieldValidationError(field string, err error) *ValidationError {
+	var cerr *codedError
+	if stderrors.As(err, &cerr) {
+		return NewCodedValidationError(field, cerr.code, cerr.params, err.Error())
+	}
+	return NewValidationError(field, err.Error())
+}
+
+// MessageCatalog maps error codes to message templates, in which
+// "{name}" is replaced by the parameter name of the error, e.g.
+//
+//	txnbuild.MessageCatalog{
+//		txnbuild.CodeAmountNegative: "{field} : le montant ne peut pas être négatif",
+//	}
+//
+// It lets applications show validation errors in the language of their
+// users.
+type MessageCatalog map[ErrorCode]string
+
+// Message returns the message of err from the catalog if err is, or wraps, a
+// ValidationError whose code is in the catalog, and err.Error() otherwise.
+func (c MessageCatalog) Message(err error) string {
+	var verr *ValidationError
+	if !stderrors.As(err, &verr) {
+		return err.Error()
+	}
+	template, ok := c[verr.Code]
+	if !ok {
+		return err.Error()
+	}
+	replacements := make([]string, 0, 2*len(verr.Params))
+	for name, value := range verr.Params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
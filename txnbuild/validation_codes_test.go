@@ -0,0 +1,74 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrorCodes(t *testing.T) {
+	kp0 := newKeypair0()
+
+	createAccount := CreateAccount{Destination: "GBAD", Amount: "10"}
+	err := createAccount.Validate()
+	require.IsType(t, &ValidationError{}, err)
+	verr := err.(*ValidationError)
+	assert.Equal(t, "Field: Destination, Error: GBAD is not a valid stellar public key", verr.Error())
+	assert.Equal(t, CodePublicKeyInvalid, verr.Code)
+	assert.Equal(t, map[string]string{"field": "Destination", "value": "GBAD"}, verr.Params)
+
+	payment := Payment{Destination: "GBAD", Amount: "10", Asset: NativeAsset{}}
+	verr = payment.Validate().(*ValidationError)
+	assert.Equal(t, "invalid address length", verr.Message)
+	assert.Equal(t, CodePublicKeyInvalid, verr.Code)
+
+	payment = Payment{Destination: kp0.Address(), Amount: "-1", Asset: NativeAsset{}}
+	verr = payment.Validate().(*ValidationError)
+	assert.Equal(t, CodeAmountNegative, verr.Code)
+	assert.Equal(t, map[string]string{"field": "Amount"}, verr.Params)
+
+	payment = Payment{Destination: kp0.Address(), Amount: "10", Asset: CreditAsset{Code: "ABCD", Issuer: "GBAD"}}
+	verr = payment.Validate().(*ValidationError)
+	assert.Equal(t, "asset issuer: GBAD is not a valid stellar public key", verr.Message)
+	assert.Equal(t, CodeAssetIssuerInvalid, verr.Code)
+	assert.Equal(t, map[string]string{"field": "Asset", "value": "GBAD"}, verr.Params)
+
+	offer := ManageSellOffer{
+		Selling: NativeAsset{},
+		Buying:  CreditAsset{Code: "ABCD", Issuer: kp0.Address()},
+		Amount:  "10",
+		Price:   xdr.Price{N: 1, D: 0},
+	}
+	verr = offer.Validate().(*ValidationError)
+	assert.Equal(t, CodePriceDenominatorZero, verr.Code)
+	assert.Equal(t, map[string]string{"field": "Price", "n": "1", "d": "0"}, verr.Params)
+
+	data := ManageData{Name: string(make([]byte, 65))}
+	verr = data.Validate().(*ValidationError)
+	assert.Equal(t, CodeTooLong, verr.Code)
+	assert.Equal(t, map[string]string{"field": "Name", "max": "64"}, verr.Params)
+
+	verr = NewValidationError("Memo", "memo is too long")
+	assert.Equal(t, CodeInvalid, verr.Code)
+	assert.Equal(t, map[string]string{"field": "Memo"}, verr.Params)
+}
+
+func TestMessageCatalog(t *testing.T) {
+	catalog := MessageCatalog{
+		CodePublicKeyInvalid: "{field} : {value} n'est pas une clé publique valide",
+	}
+
+	payment := Payment{Destination: "GBAD", Amount: "10", Asset: NativeAsset{}}
+	err := errors.Wrap(payment.Validate(), "invalid operation")
+	assert.Equal(t, "Destination : GBAD n'est pas une clé publique valide", catalog.Message(err))
+
+	payment = Payment{Destination: newKeypair0().Address(), Amount: "-1", Asset: NativeAsset{}}
+	err = payment.Validate()
+	assert.Equal(t, err.Error(), catalog.Message(err))
+
+	err = errors.New("not a validation error")
+	assert.Equal(t, "not a validation error", catalog.Message(err))
+}
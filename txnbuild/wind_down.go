@@ -0,0 +1,225 @@
+package txnbuild
+
+import (
+	"sort"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// maxWindDownOperations is the maximum number of operations of a
+// transaction.
+const maxWindDownOperations = 100
+
+// WindDownLoader loads the state of an account to wind down. It is
+// implemented by horizonclient.Client.
+type WindDownLoader interface {
+	LoadWindDownState(accountID string) (WindDownState, error)
+}
+
+// WindDownTrustline is a trustline of an account to wind down.
+type WindDownTrustline struct {
+	Asset CreditAsset
+	// Balance is the amount of the asset held, e.g. "10.5".
+	Balance string
+	// Authorized is false if the issuer has not authorized the trustline, in
+	// which case its balance cannot be moved.
+	Authorized bool
+}
+
+// WindDownOffer is an offer of an account to wind down.
+type WindDownOffer struct {
+	ID      int64
+	Selling Asset
+	Buying  Asset
+	Price   xdr.Price
+}
+
+// WindDownState is the state of an account to wind down, as returned by a
+// WindDownLoader.
+type WindDownState struct {
+	AccountID string
+	Sequence  int64
+	// Trustlines are the trustlines to credit assets, liquidity pool shares
+	// excluded.
+	Trustlines []WindDownTrustline
+	// LiquidityPools are the ids of the liquidity pools the account holds
+	// shares of.
+	LiquidityPools []string
+	Offers         []WindDownOffer
+	// DataNames are the names of the data entries.
+	DataNames []string
+	// Signers are the signers of the account, its master key excluded.
+	Signers []string
+	// NumSponsoring is the number of reserves sponsored by the account.
+	NumSponsoring uint32
+}
+
+// WindDownPlanner plans the transactions deleting an account and merging it
+// into Destination. The account must not sponsor any reserve nor hold
+// liquidity pool shares, which must first be withdrawn.
+//
+// The operations, all sourced from the account, are in order: the offers
+// are cancelled, releasing the liabilities of the balances, the balances are
+// disposed of, the trustlines closed, the data entries removed, and the
+// signers removed just before the merge, in the last transaction, so that
+// the transactions before can still be signed by them.
+type WindDownPlanner struct {
+	Loader WindDownLoader
+	// Destination is the account receiving the native balance.
+	Destination string
+	// DisposeBalance returns the operation zeroing a credit balance, e.g. a
+	// PathPaymentStrictSend selling it, whose source account is the account
+	// or empty. When it is nil the balance is sent back to the issuer,
+	// burning it.
+	DisposeBalance func(trustline WindDownTrustline) (Operation, error)
+	// BaseFee is the fee per operation of the transactions, MinBaseFee if 0.
+	BaseFee int64
+	// MaxOperations is the maximum number of operations per transaction, 100
+	// if 0.
+	MaxOperations int
+	// Timebounds are the time bounds of the transactions, a timeout of 5
+	// minutes if they are not set.
+	Timebounds Timebounds
+}
+
+// Plan loads the account and returns the transactions winding it down, with
+// consecutive sequence numbers. They must be signed by the account and
+// submitted in order.
+func (p *WindDownPlanner) Plan(accountID string) ([]*Transaction, error) {
+	if p.Loader == nil {
+		return nil, errors.New("wind down loader is missing")
+	}
+	if p.Destination == "" {
+		return nil, errors.New("wind down planner has no destination")
+	}
+	if accountFromMuxed(p.Destination) == accountID {
+		return nil, errors.New("an account cannot be merged into itself")
+	}
+	state, err := p.Loader.LoadWindDownState(accountID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load account %s", accountID)
+	}
+	if state.NumSponsoring > 0 {
+		return nil, errors.Errorf("account %s sponsors %d reserves", accountID, state.NumSponsoring)
+	}
+	if len(state.LiquidityPools) > 0 {
+		return nil, errors.Errorf("account %s holds shares of liquidity pool %s", accountID, state.LiquidityPools[0])
+	}
+
+	var operations []Operation
+	for _, offer := range state.Offers {
+		operations = append(operations, &ManageSellOffer{
+			Selling:       offer.Selling,
+			Buying:        offer.Buying,
+			Amount:        "0",
+			Price:         offer.Price,
+			OfferID:       offer.ID,
+			SourceAccount: accountID,
+		})
+	}
+	for _, trustline := range state.Trustlines {
+		balance, err := amount.ParseInt64(trustline.Balance)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid balance of trustline to %s:%s", trustline.Asset.Code, trustline.Asset.Issuer)
+		}
+		if balance == 0 {
+			continue
+		}
+		if !trustline.Authorized {
+			return nil, errors.Errorf("trustline to %s:%s is not authorized", trustline.Asset.Code, trustline.Asset.Issuer)
+		}
+		op, err := p.disposeBalance(trustline)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not dispose of balance of %s:%s", trustline.Asset.Code, trustline.Asset.Issuer)
+		}
+		operations = append(operations, op)
+	}
+	for _, trustline := range state.Trustlines {
+		operations = append(operations, &ChangeTrust{
+			Line:          trustline.Asset.MustToChangeTrustAsset(),
+			Limit:         "0",
+			SourceAccount: accountID,
+		})
+	}
+	names := append([]string(nil), state.DataNames...)
+	sort.Strings(names)
+	for _, name := range names {
+		operations = append(operations, &ManageData{Name: name, SourceAccount: accountID})
+	}
+
+	// the signers are removed in the transaction merging the account
+	var final []Operation
+	for _, signer := range state.Signers {
+		final = append(final, &SetOptions{
+			Signer:        &Signer{Address: signer, Weight: 0},
+			SourceAccount: accountID,
+		})
+	}
+	final = append(final, &AccountMerge{Destination: p.Destination, SourceAccount: accountID})
+
+	return p.pack(&SimpleAccount{AccountID: accountID, Sequence: state.Sequence}, operations, final)
+}
+
+func (p *WindDownPlanner) disposeBalance(trustline WindDownTrustline) (Operation, error) {
+	if p.DisposeBalance == nil {
+		return &Payment{
+			Destination: trustline.Asset.Issuer,
+			Amount:      trustline.Balance,
+			Asset:       trustline.Asset,
+		}, nil
+	}
+	return p.DisposeBalance(trustline)
+}
+
+// pack packs the operations in transactions of at most MaxOperations
+// operations, the final operations being kept in the last one.
+func (p *WindDownPlanner) pack(account *SimpleAccount, operations, final []Operation) ([]*Transaction, error) {
+	maxOperations := p.MaxOperations
+	if maxOperations <= 0 || maxOperations > maxWindDownOperations {
+		maxOperations = maxWindDownOperations
+	}
+	if len(final) > maxOperations {
+		return nil, errors.Errorf("the %d signers cannot be removed in the transaction merging the account", len(final)-1)
+	}
+	baseFee := p.BaseFee
+	if baseFee == 0 {
+		baseFee = MinBaseFee
+	}
+	timebounds := p.Timebounds
+	if timebounds == (Timebounds{}) {
+		timebounds = NewTimeout(300)
+	}
+
+	var batches [][]Operation
+	for len(operations) > 0 {
+		n := maxOperations
+		if len(operations) < n {
+			n = len(operations)
+		}
+		batches = append(batches, operations[:n])
+		operations = operations[n:]
+	}
+	if last := len(batches) - 1; last >= 0 && len(batches[last])+len(final) <= maxOperations {
+		batches[last] = append(batches[last], final...)
+	} else {
+		batches = append(batches, final)
+	}
+
+	transactions := make([]*Transaction, 0, len(batches))
+	for _, batch := range batches {
+		tx, err := NewTransaction(TransactionParams{
+			SourceAccount:        account,
+			IncrementSequenceNum: true,
+			Operations:           batch,
+			BaseFee:              baseFee,
+			Timebounds:           timebounds,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build wind down transaction")
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,149 @@
+package txnbuild
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapWindDownLoader map[string]WindDownState
+
+func (l mapWindDownLoader) LoadWindDownState(accountID string) (WindDownState, error) {
+	state, ok := l[accountID]
+	if !ok {
+		return WindDownState{}, errors.New("account not found")
+	}
+	return state, nil
+}
+
+func TestWindDownPlanner(t *testing.T) {
+	account := newKeypair0().Address()
+	destination := newKeypair1().Address()
+	issuer := newKeypair2().Address()
+	usd := CreditAsset{Code: "USD", Issuer: issuer}
+	eur := CreditAsset{Code: "EUR", Issuer: issuer}
+	loader := mapWindDownLoader{account: {
+		AccountID: account,
+		Sequence:  10,
+		Trustlines: []WindDownTrustline{
+			{Asset: usd, Balance: "5.0000000", Authorized: true},
+			{Asset: eur, Balance: "0.0000000"},
+		},
+		Offers:    []WindDownOffer{{ID: 3, Selling: NativeAsset{}, Buying: usd, Price: xdr.Price{N: 1, D: 2}}},
+		DataNames: []string{"b", "a"},
+		Signers:   []string{issuer},
+	}}
+
+	planner := &WindDownPlanner{Loader: loader, Destination: destination, Timebounds: NewInfiniteTimeout()}
+	txs, err := planner.Plan(account)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, int64(11), txs[0].SequenceNumber())
+	ops := txs[0].Operations()
+	require.Len(t, ops, 8)
+	assert.Equal(t, []Operation{
+		&ManageSellOffer{Selling: NativeAsset{}, Buying: usd, Amount: "0", Price: xdr.Price{N: 1, D: 2}, OfferID: 3, SourceAccount: account},
+		&Payment{Destination: issuer, Amount: "5.0000000", Asset: usd},
+		&ChangeTrust{Line: usd.MustToChangeTrustAsset(), Limit: "0", SourceAccount: account},
+		&ChangeTrust{Line: eur.MustToChangeTrustAsset(), Limit: "0", SourceAccount: account},
+		&ManageData{Name: "a", SourceAccount: account},
+		&ManageData{Name: "b", SourceAccount: account},
+	}, ops[:6])
+	assert.Equal(t, &Signer{Address: issuer, Weight: 0}, ops[6].(*SetOptions).Signer)
+	assert.Equal(t, &AccountMerge{Destination: destination, SourceAccount: account}, ops[7])
+
+	// the balances are disposed of with the hook
+	planner.DisposeBalance = func(trustline WindDownTrustline) (Operation, error) {
+		return &PathPaymentStrictSend{
+			SendAsset:   trustline.Asset,
+			SendAmount:  trustline.Balance,
+			Destination: destination,
+			DestAsset:   NativeAsset{},
+			DestMin:     "1",
+		}, nil
+	}
+	txs, err = planner.Plan(account)
+	require.NoError(t, err)
+	assert.IsType(t, &PathPaymentStrictSend{}, txs[0].Operations()[1])
+
+	planner.DisposeBalance = func(trustline WindDownTrustline) (Operation, error) {
+		return nil, errors.New("no path")
+	}
+	_, err = planner.Plan(account)
+	assert.EqualError(t, err, "could not dispose of balance of USD:"+issuer+": no path")
+}
+
+func TestWindDownPlannerSplitsTransactions(t *testing.T) {
+	account := newKeypair0().Address()
+	destination := newKeypair1().Address()
+	state := WindDownState{AccountID: account, Sequence: 10, Signers: []string{newKeypair2().Address()}}
+	for i := 0; i < 5; i++ {
+		state.DataNames = append(state.DataNames, fmt.Sprintf("entry%d", i))
+	}
+
+	planner := &WindDownPlanner{
+		Loader:        mapWindDownLoader{account: state},
+		Destination:   destination,
+		MaxOperations: 3,
+		Timebounds:    NewInfiniteTimeout(),
+	}
+	txs, err := planner.Plan(account)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	for i, tx := range txs {
+		assert.Equal(t, int64(11+i), tx.SequenceNumber())
+	}
+	assert.Len(t, txs[0].Operations(), 3)
+	assert.Len(t, txs[1].Operations(), 2)
+	// the signer is removed with the merge
+	ops := txs[2].Operations()
+	require.Len(t, ops, 2)
+	assert.Equal(t, &Signer{Address: newKeypair2().Address(), Weight: 0}, ops[0].(*SetOptions).Signer)
+	assert.Equal(t, &AccountMerge{Destination: destination, SourceAccount: account}, ops[1])
+}
+
+func TestWindDownPlannerErrors(t *testing.T) {
+	account := newKeypair0().Address()
+	destination := newKeypair1().Address()
+	issuer := newKeypair2().Address()
+
+	for _, testCase := range []struct {
+		name  string
+		state WindDownState
+		err   string
+	}{
+		{
+			"sponsoring",
+			WindDownState{NumSponsoring: 2},
+			"account " + account + " sponsors 2 reserves",
+		},
+		{
+			"liquidity pool shares",
+			WindDownState{LiquidityPools: []string{"abcdef"}},
+			"account " + account + " holds shares of liquidity pool abcdef",
+		},
+		{
+			"unauthorized trustline",
+			WindDownState{Trustlines: []WindDownTrustline{{Asset: CreditAsset{Code: "USD", Issuer: issuer}, Balance: "1"}}},
+			"trustline to USD:" + issuer + " is not authorized",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			planner := &WindDownPlanner{Loader: mapWindDownLoader{account: testCase.state}, Destination: destination}
+			_, err := planner.Plan(account)
+			assert.EqualError(t, err, testCase.err)
+		})
+	}
+
+	planner := &WindDownPlanner{Loader: mapWindDownLoader{}, Destination: destination}
+	_, err := planner.Plan(account)
+	assert.EqualError(t, err, "could not load account "+account+": account not found")
+
+	planner.Destination = account
+	_, err = planner.Plan(account)
+	assert.EqualError(t, err, "an account cannot be merged into itself")
+}
@@ -1,5 +1,7 @@
 package xdr
 
+import "math"
+
 func (account *AccountEntry) SignerSummary() map[string]int32 {
 	ret := map[string]int32{}
 
@@ -85,3 +87,40 @@ func (account *AccountEntry) SponsorPerSigner() map[string]AccountId {
 
 	return signerToSponsor
 }
+
+// ComputeMinBalance returns the minimum balance of an account, in stroops, as
+// computed by stellar-core: two base reserves for the account itself, one per
+// subentry and one per entry it sponsors, minus one per entry of the account
+// sponsored by another account. The result of an invalid entry, sponsored for
+// more entries than it counts, is 0.
+func ComputeMinBalance(account AccountEntry, baseReserve Uint32) Int64 {
+	entries := 2 + int64(account.NumSubEntries) +
+		int64(account.NumSponsoring()) - int64(account.NumSponsored())
+	if entries < 0 {
+		return 0
+	}
+	return Int64(entries * int64(baseReserve))
+}
+
+// AvailableBalance returns the balance the account can spend, in stroops, as
+// computed by stellar-core: its balance above the minimum balance (see
+// ComputeMinBalance) which is not locked by the selling liabilities of its
+// offers, or 0 if there is none.
+func (account *AccountEntry) AvailableBalance(baseReserve Uint32) Int64 {
+	available := account.Balance - ComputeMinBalance(*account, baseReserve) - account.Liabilities().Selling
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// MaxReceivable returns the amount the account can receive, in stroops, as
+// computed by stellar-core: the amount bringing its balance, including the
+// buying liabilities of its offers, to the maximum balance.
+func (account *AccountEntry) MaxReceivable() Int64 {
+	receivable := Int64(math.MaxInt64) - account.Balance - account.Liabilities().Buying
+	if receivable < 0 {
+		return 0
+	}
+	return receivable
+}
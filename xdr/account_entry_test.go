@@ -1,6 +1,7 @@
 package xdr_test
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -113,3 +114,41 @@ func TestAccountEntrySponsorships(t *testing.T) {
 	assert.Equal(t, desc, signerIDs[0])
 	assert.Equal(t, expectedSponsorsForSigners, account.SponsorPerSigner())
 }
+
+func TestComputeMinBalance(t *testing.T) {
+	account := AccountEntry{Balance: 100000000}
+	assert.Equal(t, Int64(10000000), ComputeMinBalance(account, 5000000))
+	assert.Equal(t, Int64(90000000), account.AvailableBalance(5000000))
+
+	account = AccountEntry{
+		Balance:       100000000,
+		NumSubEntries: 3,
+		Ext: AccountEntryExt{
+			V: 1,
+			V1: &AccountEntryExtensionV1{
+				Liabilities: Liabilities{Buying: 300, Selling: 20000000},
+				Ext: AccountEntryExtensionV1Ext{
+					V: 2,
+					V2: &AccountEntryExtensionV2{
+						NumSponsored:  1,
+						NumSponsoring: 2,
+					},
+				},
+			},
+		},
+	}
+	// 2 + 3 subentries + 2 sponsoring - 1 sponsored
+	assert.Equal(t, Int64(30000000), ComputeMinBalance(account, 5000000))
+	assert.Equal(t, Int64(50000000), account.AvailableBalance(5000000))
+	assert.Equal(t, Int64(math.MaxInt64-100000300), account.MaxReceivable())
+
+	// the liabilities exceed the balance above the reserve
+	account.Balance = 40000000
+	assert.Equal(t, Int64(0), account.AvailableBalance(5000000))
+
+	// an account sponsored for more entries than it has
+	account.NumSubEntries = 0
+	account.Ext.V1.Ext.V2.NumSponsoring = 0
+	account.Ext.V1.Ext.V2.NumSponsored = 3
+	assert.Equal(t, Int64(0), ComputeMinBalance(account, 5000000))
+}
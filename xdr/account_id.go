@@ -23,18 +23,32 @@ func (aid *AccountId) GetAddress() (string, error) {
 	if aid == nil {
 		return "", nil
 	}
+	var buf [56]byte
+	address, err := aid.AppendAddress(buf[:0])
+	if err != nil {
+		return "", err
+	}
+	return string(address), nil
+}
+
+// AppendAddress appends the strkey encoded form of this AccountId to dst and
+// returns the extended buffer. Unlike Address, it does not allocate when dst
+// has room for the 56 bytes of the address, e.g. when rendering many
+// addresses into a reused buffer. A nil AccountId appends nothing.
+func (aid *AccountId) AppendAddress(dst []byte) ([]byte, error) {
+	if aid == nil {
+		return dst, nil
+	}
 
 	switch aid.Type {
 	case PublicKeyTypePublicKeyTypeEd25519:
 		ed, ok := aid.GetEd25519()
 		if !ok {
-			return "", fmt.Errorf("Could not get Ed25519")
+			return dst, fmt.Errorf("Could not get Ed25519")
 		}
-		raw := make([]byte, 32)
-		copy(raw, ed[:])
-		return strkey.Encode(strkey.VersionByteAccountID, raw)
+		return strkey.AppendEncode(dst, strkey.VersionByteAccountID, ed[:])
 	default:
-		return "", fmt.Errorf("Unknown account id type: %v", aid.Type)
+		return dst, fmt.Errorf("Unknown account id type: %v", aid.Type)
 	}
 }
 
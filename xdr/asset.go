@@ -44,19 +44,23 @@ func MustNewCreditAsset(code string, issuer string) Asset {
 	return a
 }
 
-// NewAssetCodeFromString returns a new allow trust asset, panicking if it can't.
+// NewAssetCodeFromString returns a new allow trust asset, or an error if the
+// code is not 1 to 12 alphanumeric characters long.
 func NewAssetCodeFromString(code string) (AssetCode, error) {
 	a := AssetCode{}
-	length := len(code)
-	switch {
+	switch length := len(code); {
 	case length >= 1 && length <= 4:
-		var newCode AssetCode4
-		copy(newCode[:], []byte(code)[:length])
+		newCode, err := NewAssetCode4(code)
+		if err != nil {
+			return a, err
+		}
 		a.Type = AssetTypeAssetTypeCreditAlphanum4
 		a.AssetCode4 = &newCode
 	case length >= 5 && length <= 12:
-		var newCode AssetCode12
-		copy(newCode[:], []byte(code)[:length])
+		newCode, err := NewAssetCode12(code)
+		if err != nil {
+			return a, err
+		}
 		a.Type = AssetTypeAssetTypeCreditAlphanum12
 		a.AssetCode12 = &newCode
 	default:
@@ -189,15 +193,19 @@ func (a *Asset) SetCredit(code string, issuer AccountId) error {
 
 	switch {
 	case length >= 1 && length <= 4:
-		newbody := AlphaNum4{Issuer: issuer}
-		copy(newbody.AssetCode[:], []byte(code)[:length])
+		assetCode, err := NewAssetCode4(code)
+		if err != nil {
+			return err
+		}
 		typ = AssetTypeAssetTypeCreditAlphanum4
-		body = newbody
+		body = AlphaNum4{AssetCode: assetCode, Issuer: issuer}
 	case length >= 5 && length <= 12:
-		newbody := AlphaNum12{Issuer: issuer}
-		copy(newbody.AssetCode[:], []byte(code)[:length])
+		assetCode, err := NewAssetCode12(code)
+		if err != nil {
+			return err
+		}
 		typ = AssetTypeAssetTypeCreditAlphanum12
-		body = newbody
+		body = AlphaNum12{AssetCode: assetCode, Issuer: issuer}
 	default:
 		return errors.New("Asset code length is invalid")
 	}
@@ -223,22 +231,7 @@ func (a *Asset) SetNative() error {
 // ToAssetCode for Asset converts the Asset to a corresponding XDR
 // "allow trust" asset, used by the XDR allow trust operation.
 func (a *Asset) ToAssetCode(code string) (AssetCode, error) {
-	length := len(code)
-
-	switch {
-	case length >= 1 && length <= 4:
-		var bytecode AssetCode4
-		byteArray := []byte(code)
-		copy(bytecode[:], byteArray[0:length])
-		return NewAssetCode(AssetTypeAssetTypeCreditAlphanum4, bytecode)
-	case length >= 5 && length <= 12:
-		var bytecode AssetCode12
-		byteArray := []byte(code)
-		copy(bytecode[:], byteArray[0:length])
-		return NewAssetCode(AssetTypeAssetTypeCreditAlphanum12, bytecode)
-	default:
-		return AssetCode{}, errors.New("Asset code length is invalid")
-	}
+	return NewAssetCodeFromString(code)
 }
 
 // String returns a display friendly form of the asset
@@ -28,3 +28,35 @@ func (a AssetCode) ToAsset(issuer AccountId) (asset Asset) {
 	}
 	return
 }
+
+// NewAssetCode4 returns the AssetCode4 of a code of 1 to 4 alphanumeric
+// characters, padded with zeros.
+func NewAssetCode4(code string) (AssetCode4, error) {
+	var result AssetCode4
+	if err := validateAssetCode(code, 1, len(result)); err != nil {
+		return result, err
+	}
+	copy(result[:], code)
+	return result, nil
+}
+
+// NewAssetCode12 returns the AssetCode12 of a code of 5 to 12 alphanumeric
+// characters, padded with zeros.
+func NewAssetCode12(code string) (AssetCode12, error) {
+	var result AssetCode12
+	if err := validateAssetCode(code, 5, len(result)); err != nil {
+		return result, err
+	}
+	copy(result[:], code)
+	return result, nil
+}
+
+func validateAssetCode(code string, min, max int) error {
+	if len(code) < min || len(code) > max {
+		return fmt.Errorf("asset code %q must be %d to %d characters long", code, min, max)
+	}
+	if !ValidAssetCode.MatchString(code) {
+		return fmt.Errorf("asset code %q must be alphanumeric", code)
+	}
+	return nil
+}
@@ -209,6 +209,41 @@ func TestAssetSetCredit(t *testing.T) {
 	assert.Equal(t, AssetCode12{'U', 'S', 'D', 'U', 'S', 'D', 0, 0, 0, 0, 0, 0}, a.AlphaNum12.AssetCode)
 }
 
+func TestAssetSetCreditInvalidCode(t *testing.T) {
+	issuer := MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+	a := &Asset{}
+	assert.EqualError(t, a.SetCredit("US$", issuer), `asset code "US$" must be alphanumeric`)
+	assert.EqualError(t, a.SetCredit("USD\x00USD", issuer), `asset code "USD\x00USD" must be alphanumeric`)
+	assert.EqualError(t, a.SetCredit("ABCDEFGHIJKLM", issuer), "Asset code length is invalid")
+	assert.Equal(t, Asset{}, *a)
+
+	_, err := NewAssetCodeFromString("É")
+	assert.EqualError(t, err, `asset code "É" must be alphanumeric`)
+}
+
+func TestNewAssetCode4And12(t *testing.T) {
+	code4, err := NewAssetCode4("USD")
+	require.NoError(t, err)
+	assert.Equal(t, AssetCode4{'U', 'S', 'D', 0}, code4)
+	_, err = NewAssetCode4("")
+	assert.EqualError(t, err, `asset code "" must be 1 to 4 characters long`)
+	_, err = NewAssetCode4("USDUSD")
+	assert.EqualError(t, err, `asset code "USDUSD" must be 1 to 4 characters long`)
+	_, err = NewAssetCode4("U-D")
+	assert.EqualError(t, err, `asset code "U-D" must be alphanumeric`)
+
+	code12, err := NewAssetCode12("USDUSD")
+	require.NoError(t, err)
+	assert.Equal(t, AssetCode12{'U', 'S', 'D', 'U', 'S', 'D', 0, 0, 0, 0, 0, 0}, code12)
+	_, err = NewAssetCode12("USD")
+	assert.EqualError(t, err, `asset code "USD" must be 5 to 12 characters long`)
+	_, err = NewAssetCode12("ABCDEFGHIJKLM")
+	assert.EqualError(t, err, `asset code "ABCDEFGHIJKLM" must be 5 to 12 characters long`)
+	_, err = NewAssetCode12("USD USD")
+	assert.EqualError(t, err, `asset code "USD USD" must be alphanumeric`)
+}
+
 func TestToAllowTrustOpAsset_AlphaNum4(t *testing.T) {
 	a := &Asset{}
 	at, err := a.ToAssetCode("ABCD")
@@ -0,0 +1,49 @@
+package xdr
+
+import (
+	"encoding/base64"
+	"sync/atomic"
+)
+
+// Base64Encoding is the base64 encoding of the XDR values marshaled and
+// unmarshaled by the package (MarshalBase64, SafeUnmarshalBase64,
+// EncodingBuffer.UnsafeMarshalBase64...). It is implemented by
+// base64.StdEncoding, the default, and can be replaced with SetBase64Encoding
+// by a faster implementation of the standard padded alphabet, e.g. a SIMD
+// one.
+type Base64Encoding interface {
+	EncodedLen(n int) int
+	Encode(dst, src []byte)
+	EncodeToString(src []byte) string
+	DecodedLen(n int) int
+	Decode(dst, src []byte) (int, error)
+	DecodeString(s string) ([]byte, error)
+}
+
+// base64EncodingValue wraps the Base64Encoding so that atomic.Value always
+// holds the same concrete type.
+type base64EncodingValue struct {
+	encoding Base64Encoding
+}
+
+var base64Encoding atomic.Value
+
+func init() {
+	SetBase64Encoding(nil)
+}
+
+// SetBase64Encoding replaces the base64 encoding used by the package, a nil
+// encoding restoring base64.StdEncoding. It is safe to call concurrently with
+// the encoding and decoding functions of the package, but it is meant to be
+// called once when the program starts.
+func SetBase64Encoding(encoding Base64Encoding) {
+	if encoding == nil {
+		encoding = base64.StdEncoding
+	}
+	base64Encoding.Store(base64EncodingValue{encoding: encoding})
+}
+
+// GetBase64Encoding returns the base64 encoding used by the package.
+func GetBase64Encoding() Base64Encoding {
+	return base64Encoding.Load().(base64EncodingValue).encoding
+}
@@ -0,0 +1,74 @@
+package xdr
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingBase64Encoding is a Base64Encoding counting its calls.
+type countingBase64Encoding struct {
+	*base64.Encoding
+	encodes, decodes int
+}
+
+func (e *countingBase64Encoding) Encode(dst, src []byte) {
+	e.encodes++
+	e.Encoding.Encode(dst, src)
+}
+
+func (e *countingBase64Encoding) EncodeToString(src []byte) string {
+	e.encodes++
+	return e.Encoding.EncodeToString(src)
+}
+
+func (e *countingBase64Encoding) DecodeString(s string) ([]byte, error) {
+	e.decodes++
+	return e.Encoding.DecodeString(s)
+}
+
+func TestSetBase64Encoding(t *testing.T) {
+	encoding := &countingBase64Encoding{Encoding: base64.StdEncoding}
+	SetBase64Encoding(encoding)
+	defer SetBase64Encoding(nil)
+	assert.Equal(t, encoding, GetBase64Encoding())
+
+	key := LedgerKey{Type: LedgerEntryTypeAccount, Account: &LedgerKeyAccount{AccountId: MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB")}}
+	encoded, err := MarshalBase64(key)
+	require.NoError(t, err)
+	keyBase64, err := key.MarshalBinaryBase64()
+	require.NoError(t, err)
+	assert.Equal(t, encoded, keyBase64)
+	buffered, err := NewEncodingBuffer().UnsafeMarshalBase64(key)
+	require.NoError(t, err)
+	assert.Equal(t, encoded, string(buffered))
+	assert.Equal(t, 3, encoding.encodes)
+
+	var decoded LedgerKey
+	require.NoError(t, SafeUnmarshalBase64(encoded, &decoded))
+	assert.Equal(t, key, decoded)
+	assert.Equal(t, 1, encoding.decodes)
+
+	SetBase64Encoding(nil)
+	assert.Equal(t, base64.StdEncoding, GetBase64Encoding())
+	require.NoError(t, SafeUnmarshalBase64(encoded, &decoded))
+	assert.Equal(t, 1, encoding.decodes)
+}
+
+func TestSafeUnmarshalBase64Errors(t *testing.T) {
+	var value int32
+	assert.Error(t, SafeUnmarshalBase64("AAAAAQ=", &value))
+	assert.EqualError(t, SafeUnmarshalBase64("AAAAAQAAAAI=", &value), "input not fully consumed. expected to read: 8, actual: 4")
+}
+
+func BenchmarkSafeUnmarshalBase64(b *testing.B) {
+	data := "AAAAAgAAAABi/B0L0JGythwN1lY0aypo19NHxvLCyO5tBEcCVvwF9wAAAAoAAAAAAAAAAQAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAK6jei3jmoI8TGlD/egc37PXtHKKzWV8wViZBaCu5L5MAAAAADuaygAAAAAAAAAAAVb8BfcAAABACmeyD4/+Oj7llOmTrcjKLHLTQJF0TV/VggCOUZ30ZPgMsQy6A2T//Zdzb7MULVo/Y7kDrqAZRS51rvIp7YMUAA=="
+	for i := 0; i < b.N; i++ {
+		var envelope TransactionEnvelope
+		if err := SafeUnmarshalBase64(data, &envelope); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
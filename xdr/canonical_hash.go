@@ -0,0 +1,88 @@
+package xdr
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// Hash returns the hash of the transaction of the envelope on the network
+// identified by networkPassphrase: the SHA-256 of its signature payload,
+// which is the value signed by the signers of the transaction and its id.
+// The transaction of a v0 envelope is hashed as the equivalent v1
+// transaction, as stellar-core does. The hash functions of the network
+// package are built on it.
+func (e TransactionEnvelope) Hash(networkPassphrase string) (Hash, error) {
+	if strings.TrimSpace(networkPassphrase) == "" {
+		return Hash{}, errors.New("empty network passphrase")
+	}
+
+	payload := TransactionSignaturePayload{
+		NetworkId: sha256.Sum256([]byte(networkPassphrase)),
+	}
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		if e.V0 == nil {
+			return Hash{}, errors.New("v0 transaction envelope is missing")
+		}
+		tx := e.V0.Tx
+		sourceAccount, err := NewMuxedAccount(CryptoKeyTypeKeyTypeEd25519, tx.SourceAccountEd25519)
+		if err != nil {
+			return Hash{}, err
+		}
+		payload.TaggedTransaction = TransactionSignaturePayloadTaggedTransaction{
+			Type: EnvelopeTypeEnvelopeTypeTx,
+			Tx: &Transaction{
+				SourceAccount: sourceAccount,
+				Fee:           tx.Fee,
+				SeqNum:        tx.SeqNum,
+				TimeBounds:    tx.TimeBounds,
+				Memo:          tx.Memo,
+				Operations:    tx.Operations,
+			},
+		}
+	case EnvelopeTypeEnvelopeTypeTx:
+		if e.V1 == nil {
+			return Hash{}, errors.New("v1 transaction envelope is missing")
+		}
+		tx := e.V1.Tx
+		payload.TaggedTransaction = TransactionSignaturePayloadTaggedTransaction{
+			Type: EnvelopeTypeEnvelopeTypeTx,
+			Tx:   &tx,
+		}
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if e.FeeBump == nil {
+			return Hash{}, errors.New("fee bump transaction envelope is missing")
+		}
+		tx := e.FeeBump.Tx
+		payload.TaggedTransaction = TransactionSignaturePayloadTaggedTransaction{
+			Type:    EnvelopeTypeEnvelopeTypeTxFeeBump,
+			FeeBump: &tx,
+		}
+	default:
+		return Hash{}, errors.Errorf("invalid transaction envelope type %d", e.Type)
+	}
+	return hashXDR(payload)
+}
+
+// Hash returns the SHA-256 of the XDR encoding of the ledger entry, which
+// does not depend on the network.
+func (entry LedgerEntry) Hash() (Hash, error) {
+	return hashXDR(entry)
+}
+
+// Hash returns the SHA-256 of the XDR encoding of the operation body, which
+// does not depend on the network.
+func (body OperationBody) Hash() (Hash, error) {
+	return hashXDR(body)
+}
+
+func hashXDR(v encoding.BinaryMarshaler) (Hash, error) {
+	b, err := v.MarshalBinary()
+	if err != nil {
+		return Hash{}, errors.Wrap(err, "marshal xdr failed")
+	}
+	return Hash(sha256.Sum256(b)), nil
+}
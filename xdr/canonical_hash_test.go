@@ -0,0 +1,116 @@
+package xdr_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionEnvelopeHash(t *testing.T) {
+	source, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, xdr.Uint256{1, 2, 3})
+	require.NoError(t, err)
+	ops := []xdr.Operation{{
+		Body: xdr.OperationBody{
+			Type:           xdr.OperationTypeBumpSequence,
+			BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 34},
+		},
+	}}
+	v0 := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxV0,
+		V0: &xdr.TransactionV0Envelope{
+			Tx: xdr.TransactionV0{
+				SourceAccountEd25519: xdr.Uint256{1, 2, 3},
+				Fee:                  100,
+				SeqNum:               33,
+				Memo:                 xdr.Memo{Type: xdr.MemoTypeMemoNone},
+				Operations:           ops,
+			},
+		},
+	}
+	v1 := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: source,
+				Fee:           100,
+				SeqNum:        33,
+				Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+				Operations:    ops,
+			},
+		},
+	}
+	feeBump := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: xdr.FeeBumpTransaction{
+				FeeSource: source,
+				Fee:       200,
+				InnerTx: xdr.FeeBumpTransactionInnerTx{
+					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+					V1:   v1.V1,
+				},
+			},
+		},
+	}
+
+	for envelope, expected := range map[*xdr.TransactionEnvelope]string{
+		&v0:      "1833311e5edf371ed806e3ee6c5838bb23cfafacbecf5612c06210ad84c1a560",
+		&v1:      "1833311e5edf371ed806e3ee6c5838bb23cfafacbecf5612c06210ad84c1a560",
+		&feeBump: "034fd9d0b2d90de504e802730c2e46f9fb326b30ce8450464bd332cca08e8aac",
+	} {
+		hash, err := envelope.Hash(network.TestNetworkPassphrase)
+		require.NoError(t, err)
+		assert.Equal(t, expected, hash.HexString(), envelope.Type.String())
+
+		other, err := envelope.Hash(network.PublicNetworkPassphrase)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	}
+
+	// a v0 transaction has the hash of the equivalent v1 transaction
+	v0Hash, err := v0.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	v1Hash, err := v1.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, v1Hash, v0Hash)
+
+	_, err = v1.Hash(" ")
+	assert.EqualError(t, err, "empty network passphrase")
+	_, err = xdr.TransactionEnvelope{}.Hash(network.TestNetworkPassphrase)
+	assert.EqualError(t, err, "v0 transaction envelope is missing")
+	_, err = xdr.TransactionEnvelope{Type: 100}.Hash(network.TestNetworkPassphrase)
+	assert.EqualError(t, err, "invalid transaction envelope type 100")
+}
+
+func TestLedgerEntryAndOperationBodyHash(t *testing.T) {
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeData,
+			Data: &xdr.DataEntry{
+				AccountId: xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+				DataName:  "name",
+				DataValue: xdr.DataValue("value"),
+			},
+		},
+	}
+	raw, err := entry.MarshalBinary()
+	require.NoError(t, err)
+	hash, err := entry.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Hash(sha256.Sum256(raw)), hash)
+
+	body := xdr.OperationBody{
+		Type:           xdr.OperationTypeBumpSequence,
+		BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 34},
+	}
+	raw, err = body.MarshalBinary()
+	require.NoError(t, err)
+	hash, err = body.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Hash(sha256.Sum256(raw)), hash)
+}
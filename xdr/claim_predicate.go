@@ -0,0 +1,170 @@
+package xdr
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MaxClaimPredicateDepth is the maximum nesting depth of a ClaimPredicate
+// accepted by stellar-core, the predicate itself being at depth 1.
+const MaxClaimPredicateDepth = 4
+
+// claimPredicateName returns the name of a predicate type in errors.
+func claimPredicateName(t ClaimPredicateType) string {
+	switch t {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		return "unconditional"
+	case ClaimPredicateTypeClaimPredicateAnd:
+		return "and"
+	case ClaimPredicateTypeClaimPredicateOr:
+		return "or"
+	case ClaimPredicateTypeClaimPredicateNot:
+		return "not"
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		return "before absolute time"
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		return "before relative time"
+	}
+	return t.String()
+}
+
+// Validate returns an error if stellar-core would reject the predicate: if it
+// is nested deeper than MaxClaimPredicateDepth, if an And or Or predicate
+// does not have exactly two predicates, if a Not predicate has none, or if a
+// time is negative.
+func (c ClaimPredicate) Validate() error {
+	return c.validate(1)
+}
+
+func (c ClaimPredicate) validate(depth int) error {
+	if depth > MaxClaimPredicateDepth {
+		return fmt.Errorf("predicate is nested deeper than %d levels", MaxClaimPredicateDepth)
+	}
+	switch c.Type {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		return nil
+	case ClaimPredicateTypeClaimPredicateAnd, ClaimPredicateTypeClaimPredicateOr:
+		predicates := c.AndPredicates
+		if c.Type == ClaimPredicateTypeClaimPredicateOr {
+			predicates = c.OrPredicates
+		}
+		if predicates == nil || len(*predicates) != 2 {
+			return fmt.Errorf("%s predicate must have exactly 2 predicates", claimPredicateName(c.Type))
+		}
+		for _, predicate := range *predicates {
+			if err := predicate.validate(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateNot:
+		if c.NotPredicate == nil || *c.NotPredicate == nil {
+			return fmt.Errorf("%s predicate must have a predicate", claimPredicateName(c.Type))
+		}
+		return (**c.NotPredicate).validate(depth + 1)
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		if c.AbsBefore == nil || *c.AbsBefore < 0 {
+			return fmt.Errorf("%s predicate must have a non-negative time", claimPredicateName(c.Type))
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		if c.RelBefore == nil || *c.RelBefore < 0 {
+			return fmt.Errorf("%s predicate must have a non-negative time", claimPredicateName(c.Type))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown predicate type %d", c.Type)
+	}
+}
+
+// Absolute returns the predicate the ledger stores for a claimable balance
+// created by a ledger closed at createdAt: its relative times are converted
+// to absolute times, capped to the maximum time, as stellar-core does.
+func (c ClaimPredicate) Absolute(createdAt time.Time) ClaimPredicate {
+	switch c.Type {
+	case ClaimPredicateTypeClaimPredicateAnd, ClaimPredicateTypeClaimPredicateOr:
+		predicates := c.AndPredicates
+		if c.Type == ClaimPredicateTypeClaimPredicateOr {
+			predicates = c.OrPredicates
+		}
+		if predicates == nil {
+			return c
+		}
+		converted := make([]ClaimPredicate, len(*predicates))
+		for i, predicate := range *predicates {
+			converted[i] = predicate.Absolute(createdAt)
+		}
+		result := ClaimPredicate{Type: c.Type}
+		if c.Type == ClaimPredicateTypeClaimPredicateAnd {
+			result.AndPredicates = &converted
+		} else {
+			result.OrPredicates = &converted
+		}
+		return result
+	case ClaimPredicateTypeClaimPredicateNot:
+		if c.NotPredicate == nil || *c.NotPredicate == nil {
+			return c
+		}
+		converted := (**c.NotPredicate).Absolute(createdAt)
+		inner := &converted
+		return ClaimPredicate{Type: c.Type, NotPredicate: &inner}
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		if c.RelBefore == nil {
+			return c
+		}
+		absBefore := Int64(math.MaxInt64)
+		if closeTime := createdAt.Unix(); int64(*c.RelBefore) <= math.MaxInt64-closeTime {
+			absBefore = Int64(closeTime) + *c.RelBefore
+		}
+		return ClaimPredicate{Type: ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, AbsBefore: &absBefore}
+	default:
+		return c
+	}
+}
+
+// Evaluate returns true if the predicate is fulfilled by a ledger closed at
+// the given time, as stellar-core evaluates it: a BeforeAbsoluteTime
+// predicate is fulfilled if the close time is strictly before its time. The
+// predicate must be valid (see Validate) and absolute (see Absolute): the
+// relative times depend on the creation of the claimable balance, and return
+// an error.
+func (c ClaimPredicate) Evaluate(at time.Time) (bool, error) {
+	if err := c.Validate(); err != nil {
+		return false, err
+	}
+	return c.evaluate(at.Unix())
+}
+
+func (c ClaimPredicate) evaluate(closeTime int64) (bool, error) {
+	switch c.Type {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		return true, nil
+	case ClaimPredicateTypeClaimPredicateAnd, ClaimPredicateTypeClaimPredicateOr:
+		predicates := c.AndPredicates
+		if c.Type == ClaimPredicateTypeClaimPredicateOr {
+			predicates = c.OrPredicates
+		}
+		// both predicates are evaluated, so that a relative time is an error
+		// wherever it is
+		left, err := (*predicates)[0].evaluate(closeTime)
+		if err != nil {
+			return false, err
+		}
+		right, err := (*predicates)[1].evaluate(closeTime)
+		if err != nil {
+			return false, err
+		}
+		if c.Type == ClaimPredicateTypeClaimPredicateAnd {
+			return left && right, nil
+		}
+		return left || right, nil
+	case ClaimPredicateTypeClaimPredicateNot:
+		ok, err := (**c.NotPredicate).evaluate(closeTime)
+		return !ok && err == nil, err
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		return closeTime < int64(*c.AbsBefore), nil
+	default:
+		return false, fmt.Errorf("%s predicate cannot be evaluated, convert it with Absolute", claimPredicateName(c.Type))
+	}
+}
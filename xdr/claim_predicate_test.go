@@ -0,0 +1,93 @@
+package xdr_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/xdr"
+)
+
+func absBefore(t int64) xdr.ClaimPredicate {
+	v := xdr.Int64(t)
+	return xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, AbsBefore: &v}
+}
+
+func relBefore(t int64) xdr.ClaimPredicate {
+	v := xdr.Int64(t)
+	return xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateBeforeRelativeTime, RelBefore: &v}
+}
+
+func and(left, right xdr.ClaimPredicate) xdr.ClaimPredicate {
+	predicates := []xdr.ClaimPredicate{left, right}
+	return xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateAnd, AndPredicates: &predicates}
+}
+
+func or(left, right xdr.ClaimPredicate) xdr.ClaimPredicate {
+	predicates := []xdr.ClaimPredicate{left, right}
+	return xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateOr, OrPredicates: &predicates}
+}
+
+func not(predicate xdr.ClaimPredicate) xdr.ClaimPredicate {
+	inner := &predicate
+	return xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateNot, NotPredicate: &inner}
+}
+
+var unconditional = xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateUnconditional}
+
+func TestClaimPredicateValidate(t *testing.T) {
+	assert.NoError(t, unconditional.Validate())
+	// 4 levels
+	assert.NoError(t, not(and(or(absBefore(1), relBefore(2)), not(unconditional))).Validate())
+
+	assert.EqualError(t, not(not(not(not(unconditional)))).Validate(), "predicate is nested deeper than 4 levels")
+	assert.EqualError(t, absBefore(-1).Validate(), "before absolute time predicate must have a non-negative time")
+	assert.EqualError(t, relBefore(-1).Validate(), "before relative time predicate must have a non-negative time")
+	assert.EqualError(t, xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateNot}.Validate(), "not predicate must have a predicate")
+
+	one := []xdr.ClaimPredicate{unconditional}
+	invalid := xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateOr, OrPredicates: &one}
+	assert.EqualError(t, invalid.Validate(), "or predicate must have exactly 2 predicates")
+	assert.EqualError(t, and(unconditional, invalid).Validate(), "or predicate must have exactly 2 predicates")
+}
+
+func TestClaimPredicateEvaluate(t *testing.T) {
+	at := time.Unix(1000, 0)
+	for _, c := range []struct {
+		predicate xdr.ClaimPredicate
+		expected  bool
+	}{
+		{unconditional, true},
+		{absBefore(1001), true},
+		// the close time must be strictly before
+		{absBefore(1000), false},
+		{not(absBefore(1000)), true},
+		{and(absBefore(2000), not(absBefore(500))), true},
+		{and(absBefore(2000), absBefore(500)), false},
+		{or(absBefore(500), absBefore(2000)), true},
+		{or(absBefore(500), absBefore(600)), false},
+	} {
+		ok, err := c.predicate.Evaluate(at)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, ok)
+	}
+
+	_, err := or(unconditional, relBefore(10)).Evaluate(at)
+	assert.EqualError(t, err, "before relative time predicate cannot be evaluated, convert it with Absolute")
+	_, err = absBefore(-1).Evaluate(at)
+	assert.Error(t, err)
+}
+
+func TestClaimPredicateAbsolute(t *testing.T) {
+	createdAt := time.Unix(1000, 0)
+	predicate := or(relBefore(60), not(and(relBefore(120), absBefore(5000))))
+	assert.Equal(t, or(absBefore(1060), not(and(absBefore(1120), absBefore(5000)))), predicate.Absolute(createdAt))
+	// the predicate is not modified
+	assert.Equal(t, or(relBefore(60), not(and(relBefore(120), absBefore(5000)))), predicate)
+
+	assert.Equal(t, absBefore(math.MaxInt64), relBefore(math.MaxInt64-10).Absolute(createdAt))
+	assert.Equal(t, unconditional, unconditional.Absolute(createdAt))
+}
@@ -69,8 +69,10 @@ func (t *Hash) Scan(src interface{}) error {
 		return err
 	}
 
-	var decodedHash Hash
-	copy(decodedHash[:], decodedBytes)
+	decodedHash, err := NewHashFromBytes(decodedBytes)
+	if err != nil {
+		return err
+	}
 
 	*t = decodedHash
 
@@ -0,0 +1,91 @@
+package xdr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stellar/go/gxdr"
+	"github.com/stellar/go/randxdr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandEquals(t *testing.T) {
+	gen := randxdr.NewGenerator()
+	randEnvelope := func() TransactionEnvelope {
+		shape := &gxdr.TransactionEnvelope{}
+		gen.Next(shape, []randxdr.Preset{})
+		var envelope TransactionEnvelope
+		require.NoError(t, gxdr.Convert(shape, &envelope))
+		return envelope
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, b := randEnvelope(), randEnvelope()
+		aBytes, err := a.MarshalBinary()
+		require.NoError(t, err)
+		bBytes, err := b.MarshalBinary()
+		require.NoError(t, err)
+
+		var copied TransactionEnvelope
+		require.NoError(t, copied.UnmarshalBinary(aBytes))
+		assert.True(t, a.Equals(copied))
+		assert.True(t, copied.Equals(a))
+		assert.Equal(t, bytes.Equal(aBytes, bBytes), a.Equals(b))
+	}
+
+	for i := 0; i < 1000; i++ {
+		shape := &gxdr.LedgerEntry{}
+		gen.Next(shape, []randxdr.Preset{})
+		var entry LedgerEntry
+		require.NoError(t, gxdr.Convert(shape, &entry))
+		raw, err := entry.MarshalBinary()
+		require.NoError(t, err)
+
+		var copied LedgerEntry
+		require.NoError(t, copied.UnmarshalBinary(raw))
+		assert.True(t, entry.Equals(copied))
+		copied.LastModifiedLedgerSeq++
+		assert.False(t, entry.Equals(copied))
+	}
+}
+
+func TestEqualsRepresentation(t *testing.T) {
+	// nil and empty slices are encoded the same
+	a := ScpQuorumSet{Threshold: 1}
+	b := ScpQuorumSet{Threshold: 1, Validators: []NodeId{}, InnerSets: []ScpQuorumSet{}}
+	assert.True(t, a.Equals(b))
+
+	// the arms not selected by the discriminant of a union are ignored
+	ext := TransactionResultExt{V: 0}
+	assert.True(t, ext.Equals(TransactionResultExt{}))
+	source := MustAddress("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+	asset := Asset{Type: AssetTypeAssetTypeNative}
+	stale := MuxedAccount{
+		Type:    CryptoKeyTypeKeyTypeEd25519,
+		Ed25519: source.Ed25519,
+		Med25519: &MuxedAccountMed25519{
+			Id:      1,
+			Ed25519: *source.Ed25519,
+		},
+	}
+	op := OperationBody{
+		Type:      OperationTypePayment,
+		PaymentOp: &PaymentOp{Destination: stale, Asset: asset, Amount: 10},
+	}
+	clean := OperationBody{
+		Type:      OperationTypePayment,
+		PaymentOp: &PaymentOp{Destination: source.ToMuxedAccount(), Asset: asset, Amount: 10},
+	}
+	assert.True(t, op.Equals(clean))
+
+	// pointers are followed
+	amount := Int64(10)
+	otherAmount := Int64(10)
+	assert.True(t, (&ClaimPredicate{Type: ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, AbsBefore: &amount}).
+		Equals(ClaimPredicate{Type: ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, AbsBefore: &otherAmount}))
+	otherAmount++
+	assert.False(t, (&ClaimPredicate{Type: ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, AbsBefore: &amount}).
+		Equals(ClaimPredicate{Type: ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, AbsBefore: &otherAmount}))
+}
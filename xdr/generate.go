@@ -0,0 +1,4 @@
+package xdr
+
+//go:generate go run ./internal/equalsgen -o xdr_equals_generated.go xdr_generated.go
+//go:generate go run ./internal/jsongen -o xdr_json_generated.go xdr_generated.go
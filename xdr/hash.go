@@ -1,7 +1,48 @@
 package xdr
 
-import "encoding/hex"
+import (
+	"encoding/hex"
+	"fmt"
+)
 
 func (h Hash) HexString() string {
 	return hex.EncodeToString(h[:])
 }
+
+// AppendHex appends the hex encoded form of the hash, as returned by
+// HexString, to dst and returns the extended buffer, without allocating when
+// dst has room for the 64 bytes.
+func (h Hash) AppendHex(dst []byte) []byte {
+	return appendHex(dst, h[:])
+}
+
+func appendHex(dst, src []byte) []byte {
+	n := hex.EncodedLen(len(src))
+	if cap(dst)-len(dst) < n {
+		grown := make([]byte, len(dst), 2*cap(dst)+n)
+		copy(grown, dst)
+		dst = grown
+	}
+	hex.Encode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+n]
+}
+
+// NewHashFromBytes returns the Hash of b, which must be 32 bytes long.
+func NewHashFromBytes(b []byte) (Hash, error) {
+	var h Hash
+	if len(b) != len(h) {
+		return h, fmt.Errorf("hash must be %d bytes long, got %d", len(h), len(b))
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+// NewUint256FromBytes returns the Uint256 of b, which must be 32 bytes long.
+func NewUint256FromBytes(b []byte) (Uint256, error) {
+	var u Uint256
+	if len(b) != len(u) {
+		return u, fmt.Errorf("uint256 must be %d bytes long, got %d", len(u), len(b))
+	}
+	copy(u[:], b)
+	return u, nil
+}
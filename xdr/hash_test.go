@@ -0,0 +1,91 @@
+package xdr_test
+
+import (
+	"testing"
+
+	. "github.com/stellar/go/xdr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHashFromBytes(t *testing.T) {
+	b := make([]byte, 32)
+	b[0], b[31] = 1, 2
+
+	h, err := NewHashFromBytes(b)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), h[0])
+	assert.Equal(t, uint8(2), h[31])
+
+	_, err = NewHashFromBytes(b[:31])
+	assert.EqualError(t, err, "hash must be 32 bytes long, got 31")
+	_, err = NewHashFromBytes(append(b, 0))
+	assert.EqualError(t, err, "hash must be 32 bytes long, got 33")
+
+	var scanned Hash
+	assert.EqualError(t, scanned.Scan([]byte("0102")), "hash must be 32 bytes long, got 2")
+}
+
+func TestNewUint256FromBytes(t *testing.T) {
+	b := make([]byte, 32)
+	b[0] = 3
+
+	u, err := NewUint256FromBytes(b)
+	require.NoError(t, err)
+	assert.Equal(t, Uint256{3}, u)
+
+	_, err = NewUint256FromBytes(nil)
+	assert.EqualError(t, err, "uint256 must be 32 bytes long, got 0")
+}
+
+func TestHashAppendHex(t *testing.T) {
+	var h Hash
+	h[0], h[31] = 0xab, 0x01
+	assert.Equal(t, "id="+h.HexString(), string(h.AppendHex([]byte("id="))))
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = h.AppendHex(buf[:0])
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestAccountIdAppendAddress(t *testing.T) {
+	address := "GCR22L3WS7TP72S4Z27YTO6JIQYDJK2KLS2TQNHK6Y7XYPA3AGT3X4FH"
+	aid := MustAddress(address)
+	dst, err := aid.AppendAddress([]byte("source="))
+	require.NoError(t, err)
+	assert.Equal(t, "source="+address, string(dst))
+
+	buf := make([]byte, 0, 56)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = aid.AppendAddress(buf[:0])
+	})
+	assert.Equal(t, float64(0), allocs)
+
+	var nilID *AccountId
+	dst, err = nilID.AppendAddress([]byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, "x", string(dst))
+
+	_, err = (&AccountId{Type: 3}).AppendAddress(nil)
+	assert.Error(t, err)
+}
+
+func BenchmarkAccountIdAddress(b *testing.B) {
+	aid := MustAddress("GCR22L3WS7TP72S4Z27YTO6JIQYDJK2KLS2TQNHK6Y7XYPA3AGT3X4FH")
+	b.Run("Address", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = aid.Address()
+		}
+	})
+	b.Run("AppendAddress", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 56)
+		for i := 0; i < b.N; i++ {
+			buf, _ = aid.AppendAddress(buf[:0])
+		}
+	})
+}
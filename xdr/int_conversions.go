@@ -0,0 +1,71 @@
+package xdr
+
+import (
+	"math"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// ErrOutOfRange is the cause of the errors returned by the checked
+// conversions between integer types when the value does not fit in the
+// target type.
+var ErrOutOfRange = errors.New("value out of range")
+
+// Int64FromUint64 converts v to an Int64, returning an error wrapping
+// ErrOutOfRange if v is larger than math.MaxInt64.
+func Int64FromUint64(v Uint64) (Int64, error) {
+	if v > math.MaxInt64 {
+		return 0, errors.Wrapf(ErrOutOfRange, "cannot convert %d to Int64", v)
+	}
+	return Int64(v), nil
+}
+
+// Uint64FromInt64 converts v to a Uint64, returning an error wrapping
+// ErrOutOfRange if v is negative.
+func Uint64FromInt64(v Int64) (Uint64, error) {
+	if v < 0 {
+		return 0, errors.Wrapf(ErrOutOfRange, "cannot convert %d to Uint64", v)
+	}
+	return Uint64(v), nil
+}
+
+// Uint32FromInt64 converts v to a Uint32, returning an error wrapping
+// ErrOutOfRange if v is negative or larger than math.MaxUint32.
+func Uint32FromInt64(v Int64) (Uint32, error) {
+	if v < 0 || v > math.MaxUint32 {
+		return 0, errors.Wrapf(ErrOutOfRange, "cannot convert %d to Uint32", v)
+	}
+	return Uint32(v), nil
+}
+
+// Uint32FromUint64 converts v to a Uint32, returning an error wrapping
+// ErrOutOfRange if v is larger than math.MaxUint32.
+func Uint32FromUint64(v Uint64) (Uint32, error) {
+	if v > math.MaxUint32 {
+		return 0, errors.Wrapf(ErrOutOfRange, "cannot convert %d to Uint32", v)
+	}
+	return Uint32(v), nil
+}
+
+// Int32FromInt64 converts v to an Int32, returning an error wrapping
+// ErrOutOfRange if v is outside of the range of int32.
+func Int32FromInt64(v Int64) (Int32, error) {
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, errors.Wrapf(ErrOutOfRange, "cannot convert %d to Int32", v)
+	}
+	return Int32(v), nil
+}
+
+// ClampToUint32 converts v to a Uint32, saturating instead of wrapping
+// around: negative values become 0 and values larger than math.MaxUint32
+// become math.MaxUint32.
+func ClampToUint32(v Int64) Uint32 {
+	switch {
+	case v < 0:
+		return 0
+	case v > math.MaxUint32:
+		return math.MaxUint32
+	default:
+		return Uint32(v)
+	}
+}
@@ -0,0 +1,61 @@
+package xdr
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInt64FromUint64(t *testing.T) {
+	v, err := Int64FromUint64(math.MaxInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, Int64(math.MaxInt64), v)
+
+	_, err = Int64FromUint64(math.MaxInt64 + 1)
+	assert.EqualError(t, err, "cannot convert 9223372036854775808 to Int64: value out of range")
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+}
+
+func TestUint64FromInt64(t *testing.T) {
+	v, err := Uint64FromInt64(0)
+	assert.NoError(t, err)
+	assert.Equal(t, Uint64(0), v)
+
+	_, err = Uint64FromInt64(-1)
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+}
+
+func TestUint32Conversions(t *testing.T) {
+	v, err := Uint32FromInt64(math.MaxUint32)
+	assert.NoError(t, err)
+	assert.Equal(t, Uint32(math.MaxUint32), v)
+	_, err = Uint32FromInt64(math.MaxUint32 + 1)
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+	_, err = Uint32FromInt64(-1)
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+
+	v, err = Uint32FromUint64(7)
+	assert.NoError(t, err)
+	assert.Equal(t, Uint32(7), v)
+	_, err = Uint32FromUint64(math.MaxUint32 + 1)
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+}
+
+func TestInt32FromInt64(t *testing.T) {
+	v, err := Int32FromInt64(math.MinInt32)
+	assert.NoError(t, err)
+	assert.Equal(t, Int32(math.MinInt32), v)
+	_, err = Int32FromInt64(math.MaxInt32 + 1)
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+	_, err = Int32FromInt64(math.MinInt32 - 1)
+	assert.Equal(t, ErrOutOfRange, errors.Cause(err))
+}
+
+func TestClampToUint32(t *testing.T) {
+	assert.Equal(t, Uint32(0), ClampToUint32(-5))
+	assert.Equal(t, Uint32(42), ClampToUint32(42))
+	assert.Equal(t, Uint32(math.MaxUint32), ClampToUint32(math.MaxUint32))
+	assert.Equal(t, Uint32(math.MaxUint32), ClampToUint32(math.MaxInt64))
+}
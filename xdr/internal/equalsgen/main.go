@@ -0,0 +1,302 @@
+// equalsgen generates the Equals methods of the types of xdr_generated.go,
+// the code generated by xdrgen from the .x files, in a separate file so that
+// regenerating either does not require changes to the other. It is run by go
+// generate in the xdr package.
+//
+// Equals compares two values deeply: pointers are followed, nil and empty
+// slices are equal, and only the arm selected by the discriminant of a union
+// is compared, so that two values are equal when they are encoded the same.
+// The types which already have an Equals method, outside of the generated
+// files, are skipped.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type generator struct {
+	// types are the types of the generated file by name, and names their
+	// names in the order of their declaration.
+	types map[string]ast.Expr
+	names []string
+	// switches are the discriminant fields of the unions by name.
+	switches map[string]string
+	// skip are the types with a hand-written Equals method.
+	skip map[string]bool
+
+	buf  bytes.Buffer
+	vars int
+}
+
+func main() {
+	output := flag.String("o", "xdr_equals_generated.go", "output file")
+	flag.Parse()
+	input := "xdr_generated.go"
+	if flag.NArg() > 0 {
+		input = flag.Arg(0)
+	}
+
+	g := &generator{
+		types:    map[string]ast.Expr{},
+		switches: map[string]string{},
+		skip:     map[string]bool{},
+	}
+	if err := g.load(input, *output); err != nil {
+		log.Fatal(err)
+	}
+	src, err := g.generate(filepath.Base(input))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load parses the types of the generated file and the Equals methods of the
+// other files of its package.
+func (g *generator) load(input, output string) error {
+	fset := token.NewFileSet()
+	generated, err := parser.ParseFile(fset, input, nil, 0)
+	if err != nil {
+		return err
+	}
+	for _, decl := range generated.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				g.types[spec.Name.Name] = spec.Type
+				g.names = append(g.names, spec.Name.Name)
+			}
+		case *ast.FuncDecl:
+			if decl.Name.Name != "SwitchFieldName" || decl.Recv == nil {
+				continue
+			}
+			ret, ok := decl.Body.List[0].(*ast.ReturnStmt).Results[0].(*ast.BasicLit)
+			if !ok {
+				// the definition of another union, e.g. AccountId
+				continue
+			}
+			field, err := strconv.Unquote(ret.Value)
+			if err != nil {
+				return err
+			}
+			g.switches[receiverType(decl)] = field
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(input), "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || sameFile(file, input) || sameFile(file, output) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Name.Name == "Equals" {
+				g.skip[receiverType(fn)] = true
+			}
+		}
+	}
+	return nil
+}
+
+func sameFile(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+func receiverType(fn *ast.FuncDecl) string {
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	return t.(*ast.Ident).Name
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate(input string) ([]byte, error) {
+	g.printf("// Code generated by equalsgen from %s. DO NOT EDIT.\n\n", input)
+	g.printf("package xdr\n\n")
+	g.printf("import \"bytes\"\n")
+	for _, name := range g.names {
+		if g.skip[name] {
+			continue
+		}
+		g.vars = 0
+		g.method(name, g.types[name])
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+// method writes the Equals method of a type. Structs have pointer receivers,
+// like the hand-written Equals methods, the other types value receivers.
+func (g *generator) method(name string, t ast.Expr) {
+	switch t := t.(type) {
+	case *ast.InterfaceType, *ast.StarExpr:
+		// pointer and interface types cannot have methods
+		return
+	case *ast.StructType:
+		g.printf("\n// Equals returns true if other is deeply equal to s.\n")
+		g.printf("func (s *%s) Equals(other %s) bool {\n", name, name)
+		if field, ok := g.switches[name]; ok {
+			g.union(t, field)
+		} else {
+			for _, f := range t.Fields.List {
+				g.compare(f.Type, "s."+f.Names[0].Name, "other."+f.Names[0].Name)
+			}
+		}
+		g.printf("return true\n}\n")
+		return
+	}
+
+	g.printf("\n// Equals returns true if other is deeply equal to s.\n")
+	if ident, ok := t.(*ast.Ident); ok && !g.comparable(t) {
+		// a definition of another type, compared by the method of the type
+		if _, isStruct := g.underlying(ident).(*ast.StructType); isStruct {
+			g.printf("func (s *%s) Equals(other %s) bool {\n", name, name)
+			g.printf("return (*%s)(s).Equals(%s(other))\n}\n", ident.Name, ident.Name)
+		} else {
+			g.printf("func (s %s) Equals(other %s) bool {\n", name, name)
+			g.printf("return %s(s).Equals(%s(other))\n}\n", ident.Name, ident.Name)
+		}
+		return
+	}
+	g.printf("func (s %s) Equals(other %s) bool {\n", name, name)
+	if g.comparable(t) {
+		g.printf("return s == other\n}\n")
+		return
+	}
+	if isBytes(t) {
+		g.printf("return bytes.Equal(s, other)\n}\n")
+		return
+	}
+	g.compare(t, "s", "other")
+	g.printf("return true\n}\n")
+}
+
+// union writes the comparison of the discriminants of a union and of the
+// arms they select.
+func (g *generator) union(t *ast.StructType, discriminant string) {
+	var arms []*ast.Field
+	for _, f := range t.Fields.List {
+		if f.Names[0].Name != discriminant {
+			arms = append(arms, f)
+		}
+	}
+	g.printf("if s.%s != other.%s {\nreturn false\n}\n", discriminant, discriminant)
+	if len(arms) == 0 {
+		return
+	}
+	g.printf("arm, _ := s.ArmForSwitch(int32(s.%s))\n", discriminant)
+	g.printf("switch arm {\n")
+	for _, f := range arms {
+		name := f.Names[0].Name
+		g.printf("case %q:\n", name)
+		g.compare(f.Type, "s."+name, "other."+name)
+	}
+	g.printf("}\n")
+}
+
+// compare writes the statements returning false if l and r, of type t, are
+// not equal. l must be addressable.
+func (g *generator) compare(t ast.Expr, l, r string) {
+	if g.comparable(t) {
+		g.printf("if %s != %s {\nreturn false\n}\n", l, r)
+		return
+	}
+	switch t := t.(type) {
+	case *ast.Ident:
+		if star, ok := g.types[t.Name].(*ast.StarExpr); ok {
+			g.compare(star, l, r)
+			return
+		}
+		g.printf("if !%s.Equals(%s) {\nreturn false\n}\n", l, r)
+	case *ast.StarExpr:
+		g.printf("if (%s == nil) != (%s == nil) {\nreturn false\n}\n", l, r)
+		g.printf("if %s != nil {\n", l)
+		g.compare(t.X, "(*"+l+")", "(*"+r+")")
+		g.printf("}\n")
+	case *ast.ArrayType:
+		if isBytes(t) {
+			g.printf("if !bytes.Equal(%s, %s) {\nreturn false\n}\n", l, r)
+			return
+		}
+		if t.Len == nil {
+			g.printf("if len(%s) != len(%s) {\nreturn false\n}\n", l, r)
+		}
+		i := fmt.Sprintf("i%d", g.vars)
+		g.vars++
+		g.printf("for %s := range %s {\n", i, l)
+		g.compare(t.Elt, l+"["+i+"]", r+"["+i+"]")
+		g.printf("}\n")
+	default:
+		panic(fmt.Sprintf("unsupported type %T", t))
+	}
+}
+
+func isBytes(t ast.Expr) bool {
+	array, ok := t.(*ast.ArrayType)
+	if !ok || array.Len != nil {
+		return false
+	}
+	elt, ok := array.Elt.(*ast.Ident)
+	return ok && elt.Name == "byte"
+}
+
+// underlying returns the type expression a named type is defined with,
+// following the definitions of other named types.
+func (g *generator) underlying(t *ast.Ident) ast.Expr {
+	for {
+		next, ok := g.types[t.Name]
+		if !ok {
+			return t
+		}
+		ident, ok := next.(*ast.Ident)
+		if !ok {
+			return next
+		}
+		t = ident
+	}
+}
+
+// comparable returns true if the values of type t can be compared with ==:
+// basic types and the arrays of comparable types, but not the pointers, which
+// are followed.
+func (g *generator) comparable(t ast.Expr) bool {
+	switch t := t.(type) {
+	case *ast.Ident:
+		u := g.underlying(t)
+		if ident, ok := u.(*ast.Ident); ok {
+			// a predeclared type
+			return ident.Name != "error"
+		}
+		return g.comparable(u)
+	case *ast.ArrayType:
+		return t.Len != nil && g.comparable(t.Elt)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,217 @@
+// jsongen generates the MarshalJSON and UnmarshalJSON methods of the types of
+// xdr_generated.go, the code generated by xdrgen from the .x files, in a
+// separate file so that regenerating either does not require changes to the
+// other. It is run by go generate in the xdr package.
+//
+// The methods of the structs and unions delegate to marshalXDRJSON and
+// unmarshalXDRJSON, which implement the JSON schema of the package, and the
+// methods of the enums encode their values by name. The types which already
+// have a MarshalJSON method, outside of the generated files, are skipped.
+//
+// It also generates the registry of the types by name used by ToJSON.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type generator struct {
+	// types are the types of the generated file by name, and names their
+	// names in the order of their declaration.
+	types map[string]ast.Expr
+	names []string
+	// maps are the names of the variables of the generated file which map
+	// enum values to their names.
+	maps map[string]bool
+	// skip are the types with a hand-written MarshalJSON method.
+	skip map[string]bool
+
+	buf bytes.Buffer
+}
+
+func main() {
+	output := flag.String("o", "xdr_json_generated.go", "output file")
+	flag.Parse()
+	input := "xdr_generated.go"
+	if flag.NArg() > 0 {
+		input = flag.Arg(0)
+	}
+
+	g := &generator{
+		types: map[string]ast.Expr{},
+		maps:  map[string]bool{},
+		skip:  map[string]bool{},
+	}
+	if err := g.load(input, *output); err != nil {
+		log.Fatal(err)
+	}
+	src, err := g.generate(filepath.Base(input))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load parses the types and enum maps of the generated file and the
+// MarshalJSON methods of the other files of its package.
+func (g *generator) load(input, output string) error {
+	fset := token.NewFileSet()
+	generated, err := parser.ParseFile(fset, input, nil, 0)
+	if err != nil {
+		return err
+	}
+	for _, decl := range generated.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				g.types[spec.Name.Name] = spec.Type
+				g.names = append(g.names, spec.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					g.maps[name.Name] = true
+				}
+			}
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(input), "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || sameFile(file, input) || sameFile(file, output) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Name.Name == "MarshalJSON" {
+				g.skip[receiverType(fn)] = true
+			}
+		}
+	}
+	return nil
+}
+
+func sameFile(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+func receiverType(fn *ast.FuncDecl) string {
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	return t.(*ast.Ident).Name
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate(input string) ([]byte, error) {
+	g.printf("// Code generated by jsongen from %s. DO NOT EDIT.\n\n", input)
+	g.printf("package xdr\n")
+	for _, name := range g.names {
+		if g.skip[name] {
+			continue
+		}
+		g.methods(name, g.types[name])
+	}
+	g.registry()
+	return format.Source(g.buf.Bytes())
+}
+
+// methods writes the MarshalJSON and UnmarshalJSON methods of a type, if it
+// is a struct, a union, an enum or a definition of one of them.
+func (g *generator) methods(name string, t ast.Expr) {
+	switch t := t.(type) {
+	case *ast.StructType:
+		g.printf("\n// MarshalJSON implements json.Marshaler.\n")
+		g.printf("func (s %s) MarshalJSON() ([]byte, error) {\n", name)
+		g.printf("return marshalXDRJSON(s)\n}\n")
+		g.printf("\n// UnmarshalJSON implements json.Unmarshaler.\n")
+		g.printf("func (s *%s) UnmarshalJSON(data []byte) error {\n", name)
+		g.printf("return unmarshalXDRJSON(data, s)\n}\n")
+	case *ast.Ident:
+		if enumMap := lowerFirst(name) + "Map"; t.Name == "int32" && g.maps[enumMap] {
+			g.printf("\n// MarshalJSON implements json.Marshaler.\n")
+			g.printf("func (e %s) MarshalJSON() ([]byte, error) {\n", name)
+			g.printf("return marshalEnumJSON(int32(e), %s, %q)\n}\n", enumMap, name)
+			g.printf("\n// UnmarshalJSON implements json.Unmarshaler.\n")
+			g.printf("func (e *%s) UnmarshalJSON(data []byte) error {\n", name)
+			g.printf("return unmarshalEnumJSON(data, (*int32)(e), %s, %q)\n}\n", enumMap, name)
+			return
+		}
+		if _, ok := g.types[t.Name]; !ok || !g.hasMethods(t.Name) {
+			return
+		}
+		// a definition of another type, encoded by the methods of the type
+		g.printf("\n// MarshalJSON implements json.Marshaler.\n")
+		g.printf("func (s %s) MarshalJSON() ([]byte, error) {\n", name)
+		g.printf("return %s(s).MarshalJSON()\n}\n", t.Name)
+		g.printf("\n// UnmarshalJSON implements json.Unmarshaler.\n")
+		g.printf("func (s *%s) UnmarshalJSON(data []byte) error {\n", name)
+		g.printf("return (*%s)(s).UnmarshalJSON(data)\n}\n", t.Name)
+	}
+}
+
+// registry writes the map of the constructors of the types by name, except
+// the pointer and interface types, which cannot be decoded.
+func (g *generator) registry() {
+	g.printf("\n// xdrTypes are the constructors of the XDR types by name.\n")
+	g.printf("var xdrTypes = map[string]func() interface{}{\n")
+	for _, name := range g.names {
+		switch g.types[name].(type) {
+		case *ast.InterfaceType, *ast.StarExpr:
+			continue
+		}
+		g.printf("%q: func() interface{} { return new(%s) },\n", name, name)
+	}
+	g.printf("}\n")
+}
+
+// hasMethods returns true if the named type has JSON methods, either
+// generated or hand-written.
+func (g *generator) hasMethods(name string) bool {
+	if g.skip[name] {
+		return true
+	}
+	switch t := g.types[name].(type) {
+	case *ast.StructType:
+		return true
+	case *ast.Ident:
+		if t.Name == "int32" {
+			return g.maps[lowerFirst(name)+"Map"]
+		}
+		if _, ok := g.types[t.Name]; ok {
+			return g.hasMethods(t.Name)
+		}
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
@@ -190,3 +190,58 @@ func (c *ClaimPredicate) UnmarshalJSON(b []byte) error {
 	*c = parsed
 	return nil
 }
+
+// MarshalJSON encodes the public key as a G... strkey.
+func (pk PublicKey) MarshalJSON() ([]byte, error) {
+	aid := AccountId(pk)
+	address, err := aid.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(address)
+}
+
+// UnmarshalJSON decodes a public key from a G... strkey.
+func (pk *PublicKey) UnmarshalJSON(b []byte) error {
+	var address string
+	if err := json.Unmarshal(b, &address); err != nil {
+		return err
+	}
+	return (*AccountId)(pk).SetAddress(address)
+}
+
+// MarshalJSON encodes the muxed account as a G... or M... strkey.
+func (m MuxedAccount) MarshalJSON() ([]byte, error) {
+	address, err := m.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(address)
+}
+
+// UnmarshalJSON decodes a muxed account from a G... or M... strkey.
+func (m *MuxedAccount) UnmarshalJSON(b []byte) error {
+	var address string
+	if err := json.Unmarshal(b, &address); err != nil {
+		return err
+	}
+	return m.SetAddress(address)
+}
+
+// MarshalJSON encodes the signer key as a strkey.
+func (skey SignerKey) MarshalJSON() ([]byte, error) {
+	address, err := skey.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(address)
+}
+
+// UnmarshalJSON decodes a signer key from a strkey.
+func (skey *SignerKey) UnmarshalJSON(b []byte) error {
+	var address string
+	if err := json.Unmarshal(b, &address); err != nil {
+		return err
+	}
+	return skey.SetAddress(address)
+}
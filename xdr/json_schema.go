@@ -0,0 +1,351 @@
+package xdr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// The JSON encoding of the XDR types, implemented by the methods of
+// xdr_json_generated.go, is designed to be read by humans and to decode back
+// to the same value:
+//
+//  - structs are objects whose keys are the snake cased names of their fields
+//  - unions are objects with the snake cased name of their discriminant, e.g.
+//    "type", and the arm it selects, e.g. {"type": "MemoTypeMemoText",
+//    "text": "hello"}; void arms are omitted
+//  - enums are the names of their values, e.g. "OperationTypePayment"
+//  - 64 bit integers, like the amounts in stroops, are decimal strings
+//  - opaque data and hashes are hex strings
+//  - strings are JSON strings, unless they are not valid UTF-8, in which case
+//    they are objects with their hex encoded bytes, e.g. {"hex": "ff00"}
+//  - account ids, muxed accounts and signer keys are strkeys
+//  - optional values are null when absent
+
+type xdrUnion interface {
+	SwitchFieldName() string
+	ArmForSwitch(int32) (string, bool)
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	jsonNull            = []byte("null")
+)
+
+// marshalXDRJSON encodes a struct or a union.
+func marshalXDRJSON(v interface{}) ([]byte, error) {
+	return marshalComposite(reflect.ValueOf(v))
+}
+
+// unmarshalXDRJSON decodes a struct or a union into the value v points to.
+func unmarshalXDRJSON(data []byte, v interface{}) error {
+	return unmarshalComposite(data, reflect.ValueOf(v).Elem())
+}
+
+func marshalEnumJSON(value int32, names map[int32]string, typeName string) ([]byte, error) {
+	name, ok := names[value]
+	if !ok {
+		return nil, errors.Errorf("invalid %s value: %d", typeName, value)
+	}
+	return json.Marshal(name)
+}
+
+func unmarshalEnumJSON(data []byte, value *int32, names map[int32]string, typeName string) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return errors.Wrapf(err, "invalid %s", typeName)
+	}
+	for v, n := range names {
+		if n == name {
+			*value = v
+			return nil
+		}
+	}
+	return errors.Errorf("invalid %s value: %q", typeName, name)
+}
+
+// jsonFieldName returns the snake cased name of a field, e.g. "ext_v1" for
+// "ExtV1" and "liquidity_pool_id" for "LiquidityPoolID".
+func jsonFieldName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func marshalComposite(v reflect.Value) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	write := func(name string, field reflect.Value) error {
+		data, err := marshalValue(field)
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(data)
+		return nil
+	}
+
+	if u, ok := v.Interface().(xdrUnion); ok {
+		discriminant := u.SwitchFieldName()
+		sw := v.FieldByName(discriminant)
+		if err := write(jsonFieldName(discriminant), sw); err != nil {
+			return nil, err
+		}
+		arm, ok := u.ArmForSwitch(switchValue(sw))
+		if !ok {
+			return nil, errors.Errorf("invalid %s %s: %v", v.Type().Name(), discriminant, sw.Interface())
+		}
+		if arm != "" {
+			if err := write(jsonFieldName(arm), v.FieldByName(arm)); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for i := 0; i < v.NumField(); i++ {
+			if err := write(jsonFieldName(v.Type().Field(i).Name), v.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func unmarshalComposite(data []byte, v reflect.Value) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		return errors.Errorf("%s cannot be null", v.Type().Name())
+	}
+	read := func(name string, field reflect.Value) error {
+		raw, ok := fields[name]
+		if !ok {
+			return errors.Errorf("%s is missing %s", v.Type().Name(), name)
+		}
+		delete(fields, name)
+		return errors.Wrap(unmarshalValue(raw, field), name)
+	}
+
+	// start from the zero value so that the arms of unions not selected by
+	// the discriminant are nil
+	v.Set(reflect.Zero(v.Type()))
+	if u, ok := v.Addr().Interface().(xdrUnion); ok {
+		discriminant := u.SwitchFieldName()
+		sw := v.FieldByName(discriminant)
+		if err := read(jsonFieldName(discriminant), sw); err != nil {
+			return err
+		}
+		arm, ok := u.ArmForSwitch(switchValue(sw))
+		if !ok {
+			return errors.Errorf("invalid %s %s: %v", v.Type().Name(), discriminant, sw.Interface())
+		}
+		if arm != "" {
+			if err := read(jsonFieldName(arm), v.FieldByName(arm)); err != nil {
+				return err
+			}
+		}
+	} else {
+		for i := 0; i < v.NumField(); i++ {
+			if err := read(jsonFieldName(v.Type().Field(i).Name), v.Field(i)); err != nil {
+				return err
+			}
+		}
+	}
+	for name := range fields {
+		return errors.Errorf("unknown %s field %s", v.Type().Name(), name)
+	}
+	return nil
+}
+
+// switchValue returns the discriminant of a union as the int32 passed to
+// ArmForSwitch.
+func switchValue(sw reflect.Value) int32 {
+	switch sw.Kind() {
+	case reflect.Bool:
+		if sw.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Uint32:
+		return int32(sw.Uint())
+	default:
+		return int32(sw.Int())
+	}
+}
+
+// invalidUTF8String is the encoding of the strings which are not valid UTF-8
+// and would not be decoded to the same bytes from a JSON string.
+type invalidUTF8String struct {
+	Hex string `json:"hex"`
+}
+
+func isOpaque(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+func marshalValue(v reflect.Value) ([]byte, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return jsonNull, nil
+		}
+		return marshalValue(v.Elem())
+	}
+	if v.Type().Implements(jsonMarshalerType) {
+		return v.Interface().(json.Marshaler).MarshalJSON()
+	}
+
+	switch {
+	case isOpaque(v.Type()):
+		if v.Kind() == reflect.Array {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return json.Marshal(hex.EncodeToString(b))
+		}
+		return json.Marshal(hex.EncodeToString(v.Bytes()))
+	case v.Kind() == reflect.String:
+		if s := v.String(); !utf8.ValidString(s) {
+			return json.Marshal(invalidUTF8String{Hex: hex.EncodeToString([]byte(s))})
+		}
+		return json.Marshal(v.String())
+	case v.Kind() == reflect.Int64:
+		return json.Marshal(strconv.FormatInt(v.Int(), 10))
+	case v.Kind() == reflect.Uint64:
+		return json.Marshal(strconv.FormatUint(v.Uint(), 10))
+	case v.Kind() == reflect.Struct:
+		return marshalComposite(v)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			data, err := marshalValue(v.Index(i))
+			if err != nil {
+				return nil, errors.Wrapf(err, "%d", i)
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(data)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	default:
+		return json.Marshal(v.Interface())
+	}
+}
+
+func unmarshalValue(data []byte, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		return unmarshalValue(data, v.Elem())
+	}
+	if reflect.PtrTo(v.Type()).Implements(jsonUnmarshalerType) {
+		return v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+
+	switch {
+	case isOpaque(v.Type()):
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		if v.Kind() == reflect.Array {
+			if len(b) != v.Len() {
+				return errors.Errorf("expected %d bytes, got %d", v.Len(), len(b))
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		v.SetBytes(b)
+		return nil
+	case v.Kind() == reflect.String:
+		var s string
+		if err := json.Unmarshal(data, &s); err == nil {
+			v.SetString(s)
+			return nil
+		}
+		var invalid invalidUTF8String
+		if err := json.Unmarshal(data, &invalid); err != nil {
+			return err
+		}
+		b, err := hex.DecodeString(invalid.Hex)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+		return nil
+	case v.Kind() == reflect.Int64 || v.Kind() == reflect.Uint64:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if v.Kind() == reflect.Int64 {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return err
+			}
+			v.SetInt(i)
+			return nil
+		}
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+		return nil
+	case v.Kind() == reflect.Struct:
+		return unmarshalComposite(data, v)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		if v.Kind() == reflect.Array {
+			if len(elems) != v.Len() {
+				return errors.Errorf("expected %d elements, got %d", v.Len(), len(elems))
+			}
+		} else {
+			v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+		}
+		for i, elem := range elems {
+			if err := unmarshalValue(elem, v.Index(i)); err != nil {
+				return errors.Wrapf(err, "%d", i)
+			}
+		}
+		return nil
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+}
@@ -0,0 +1,162 @@
+package xdr
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/gxdr"
+	"github.com/stellar/go/randxdr"
+	goxdr "github.com/xdrpp/goxdr/xdr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xdrJSONValue interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func TestRandJSONRoundTrip(t *testing.T) {
+	gen := randxdr.NewGenerator()
+	for _, testCase := range []struct {
+		shape goxdr.XdrType
+		value func() xdrJSONValue
+	}{
+		{&gxdr.TransactionEnvelope{}, func() xdrJSONValue { return &TransactionEnvelope{} }},
+		{&gxdr.TransactionResult{}, func() xdrJSONValue { return &TransactionResult{} }},
+		{&gxdr.LedgerEntry{}, func() xdrJSONValue { return &LedgerEntry{} }},
+		{&gxdr.LedgerEntryChange{}, func() xdrJSONValue { return &LedgerEntryChange{} }},
+		{&gxdr.LedgerKey{}, func() xdrJSONValue { return &LedgerKey{} }},
+		{&gxdr.TransactionMeta{}, func() xdrJSONValue { return &TransactionMeta{} }},
+	} {
+		for i := 0; i < 200; i++ {
+			// the existing encoding of ClaimPredicate cannot represent a
+			// nil "not" predicate
+			gen.Next(
+				testCase.shape,
+				[]randxdr.Preset{
+					{Selector: randxdr.IsPtr, Setter: randxdr.SetPtr(true)},
+				},
+			)
+			value := testCase.value()
+			require.NoError(t, gxdr.Convert(testCase.shape, value))
+			raw, err := MarshalBase64(value)
+			require.NoError(t, err)
+
+			serialized, err := json.Marshal(value)
+			require.NoError(t, err)
+			parsed := testCase.value()
+			require.NoError(t, json.Unmarshal(serialized, parsed), string(serialized))
+			parsedRaw, err := MarshalBase64(parsed)
+			require.NoError(t, err)
+			assert.Equal(t, raw, parsedRaw)
+		}
+	}
+}
+
+func TestOperationJSON(t *testing.T) {
+	source := MustMuxedAddress("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+	op := Operation{
+		SourceAccount: &source,
+		Body: OperationBody{
+			Type: OperationTypePayment,
+			PaymentOp: &PaymentOp{
+				Destination: MustMuxedAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+				Asset:       MustNewCreditAsset("USD", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"),
+				Amount:      1000000000,
+			},
+		},
+	}
+
+	serialized, err := json.Marshal(op)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"source_account": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+		"body": {
+			"type": "OperationTypePayment",
+			"payment_op": {
+				"destination": "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+				"asset": {
+					"type": "AssetTypeAssetTypeCreditAlphanum4",
+					"alpha_num4": {
+						"asset_code": "55534400",
+						"issuer": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+					}
+				},
+				"amount": "1000000000"
+			}
+		}
+	}`, string(serialized))
+
+	var parsed Operation
+	require.NoError(t, json.Unmarshal(serialized, &parsed))
+	assert.True(t, op.Equals(parsed))
+}
+
+func TestUnionJSONVoidArm(t *testing.T) {
+	serialized, err := json.Marshal(Memo{Type: MemoTypeMemoNone})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "MemoTypeMemoNone"}`, string(serialized))
+
+	serialized, err = json.Marshal(Operation{Body: OperationBody{Type: OperationTypeInflation}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"source_account": null, "body": {"type": "OperationTypeInflation"}}`, string(serialized))
+
+	serialized, err = json.Marshal(TransactionExt{V: 0})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"v": 0}`, string(serialized))
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"unknown discriminant", `{"type": "MemoTypeMemoBogus"}`, `type: invalid MemoType value: "MemoTypeMemoBogus"`},
+		{"missing arm", `{"type": "MemoTypeMemoId"}`, "Memo is missing id"},
+		{"unknown field", `{"type": "MemoTypeMemoNone", "text": "x"}`, "unknown Memo field text"},
+		{"amount as number", `{"type": "MemoTypeMemoId", "id": 1}`, "id: json: cannot unmarshal number into Go value of type string"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var memo Memo
+			assert.EqualError(t, json.Unmarshal([]byte(testCase.data), &memo), testCase.expected)
+		})
+	}
+}
+
+func TestStringJSON(t *testing.T) {
+	for _, text := range []string{"hello", "", "\xff\x00abc"} {
+		memo := MemoText(text)
+		serialized, err := json.Marshal(memo)
+		require.NoError(t, err)
+		var parsed Memo
+		require.NoError(t, json.Unmarshal(serialized, &parsed))
+		assert.Equal(t, text, parsed.MustText())
+	}
+
+	serialized, err := json.Marshal(MemoText("\xff"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "MemoTypeMemoText", "text": {"hex": "ff"}}`, string(serialized))
+}
+
+func TestJSONFieldName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Type":             "type",
+		"V0":               "v0",
+		"Ed25519":          "ed25519",
+		"CreateAccountOp":  "create_account_op",
+		"LiquidityPoolID":  "liquidity_pool_id",
+		"HashX":            "hash_x",
+		"AlphaNum12":       "alpha_num12",
+		"TxSetHash":        "tx_set_hash",
+		"NumSponsoring":    "num_sponsoring",
+		"ChangeTrustOp":    "change_trust_op",
+		"PreAuthTx":        "pre_auth_tx",
+		"ScpStatementType": "scp_statement_type",
+	} {
+		assert.Equal(t, expected, jsonFieldName(name))
+	}
+}
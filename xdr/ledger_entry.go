@@ -1,55 +1,82 @@
 package xdr
 
-import "fmt"
+import (
+	"crypto/sha256"
+	"fmt"
+)
 
-// LedgerKey implements the `Keyer` interface
+// LedgerKey implements the `Keyer` interface. It panics if the entry is
+// invalid, see GetLedgerKey.
 func (entry *LedgerEntry) LedgerKey() LedgerKey {
+	key, err := entry.GetLedgerKey()
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// GetLedgerKey returns the key of the ledger entry, or an error if the type
+// of the entry is unknown or its data does not match its type.
+func (entry *LedgerEntry) GetLedgerKey() (LedgerKey, error) {
 	var body interface{}
 
 	switch entry.Data.Type {
 	case LedgerEntryTypeAccount:
-		account := entry.Data.MustAccount()
+		account := entry.Data.Account
+		if account == nil {
+			return LedgerKey{}, fmt.Errorf("account entry is missing")
+		}
 		body = LedgerKeyAccount{
 			AccountId: account.AccountId,
 		}
 	case LedgerEntryTypeData:
-		data := entry.Data.MustData()
+		data := entry.Data.Data
+		if data == nil {
+			return LedgerKey{}, fmt.Errorf("data entry is missing")
+		}
 		body = LedgerKeyData{
 			AccountId: data.AccountId,
 			DataName:  data.DataName,
 		}
 	case LedgerEntryTypeOffer:
-		offer := entry.Data.MustOffer()
+		offer := entry.Data.Offer
+		if offer == nil {
+			return LedgerKey{}, fmt.Errorf("offer entry is missing")
+		}
 		body = LedgerKeyOffer{
 			SellerId: offer.SellerId,
 			OfferId:  offer.OfferId,
 		}
 	case LedgerEntryTypeTrustline:
-		tline := entry.Data.MustTrustLine()
+		tline := entry.Data.TrustLine
+		if tline == nil {
+			return LedgerKey{}, fmt.Errorf("trustline entry is missing")
+		}
 		body = LedgerKeyTrustLine{
 			AccountId: tline.AccountId,
 			Asset:     tline.Asset,
 		}
 	case LedgerEntryTypeClaimableBalance:
-		cBalance := entry.Data.MustClaimableBalance()
+		cBalance := entry.Data.ClaimableBalance
+		if cBalance == nil {
+			return LedgerKey{}, fmt.Errorf("claimable balance entry is missing")
+		}
 		body = LedgerKeyClaimableBalance{
 			BalanceId: cBalance.BalanceId,
 		}
 	case LedgerEntryTypeLiquidityPool:
-		lPool := entry.Data.MustLiquidityPool()
+		lPool := entry.Data.LiquidityPool
+		if lPool == nil {
+			return LedgerKey{}, fmt.Errorf("liquidity pool entry is missing")
+		}
 		body = LedgerKeyLiquidityPool{
 			LiquidityPoolId: lPool.LiquidityPoolId,
 		}
 	default:
-		panic(fmt.Errorf("Unknown entry type: %v", entry.Data.Type))
-	}
-
-	ret, err := NewLedgerKey(entry.Data.Type, body)
-	if err != nil {
-		panic(err)
+		return LedgerKey{}, fmt.Errorf("Unknown entry type: %d", entry.Data.Type)
 	}
 
-	return ret
+	return NewLedgerKey(entry.Data.Type, body)
 }
 
 // SponsoringID return SponsorshipDescriptor for a given ledger entry
@@ -61,6 +88,41 @@ func (entry *LedgerEntry) SponsoringID() SponsorshipDescriptor {
 	return sponsor
 }
 
+// Sponsor returns the address of the account sponsoring the reserves of the
+// ledger entry, or "" if it is not sponsored.
+func (entry *LedgerEntry) Sponsor() string {
+	sponsor := entry.SponsoringID()
+	if sponsor == nil {
+		return ""
+	}
+	return sponsor.Address()
+}
+
+// SetSponsoringID sets the account sponsoring the reserves of the ledger
+// entry, upgrading its extension to v1 if needed. A nil sponsor removes the
+// sponsorship.
+func (entry *LedgerEntry) SetSponsoringID(sponsor SponsorshipDescriptor) {
+	if entry.Ext.V != 1 || entry.Ext.V1 == nil {
+		entry.Ext = LedgerEntryExt{V: 1, V1: &LedgerEntryExtensionV1{}}
+	}
+	entry.Ext.V1.SponsoringId = sponsor
+}
+
+// SetSponsor sets the address of the account sponsoring the reserves of the
+// ledger entry. An empty address removes the sponsorship.
+func (entry *LedgerEntry) SetSponsor(address string) error {
+	if address == "" {
+		entry.SetSponsoringID(nil)
+		return nil
+	}
+	sponsor, err := AddressToAccountId(address)
+	if err != nil {
+		return err
+	}
+	entry.SetSponsoringID(&sponsor)
+	return nil
+}
+
 // Normalize overwrites LedgerEntry with all the extensions set to default values
 // (if extension is not present).
 // This is helpful to compare two ledger entries that are the same but for one of
@@ -150,3 +212,28 @@ func (entry *LedgerEntry) Normalize() *LedgerEntry {
 
 	return entry
 }
+
+// ContentHash returns the SHA-256 hash of the canonical XDR encoding of the
+// entry, a content-addressable id to deduplicate entries or detect their
+// changes in snapshots. The last modified ledger of the entry is not part of
+// its content, and the entry is normalized (see Normalize) before it is
+// encoded, so that the entries which differ only by absent extensions and
+// the order of their signers have the same hash. The entry is not modified.
+func (entry *LedgerEntry) ContentHash() (Hash, error) {
+	// Normalize modifies the entry in place, including through its
+	// pointers, so it runs on a deep copy
+	raw, err := entry.MarshalBinary()
+	if err != nil {
+		return Hash{}, err
+	}
+	var canonical LedgerEntry
+	if err = canonical.UnmarshalBinary(raw); err != nil {
+		return Hash{}, err
+	}
+	canonical.LastModifiedLedgerSeq = 0
+	canonical.Normalize()
+	if raw, err = canonical.MarshalBinary(); err != nil {
+		return Hash{}, err
+	}
+	return sha256.Sum256(raw), nil
+}
@@ -1,7 +1,6 @@
 package xdr
 
 import (
-	"encoding/base64"
 	"fmt"
 )
 
@@ -30,6 +29,32 @@ func (change *LedgerEntryChange) LedgerKey() LedgerKey {
 	}
 }
 
+// LedgerKeyFromChange returns the key of the ledger entry changed in change,
+// or an error if the change or its entry is invalid. Unlike
+// LedgerEntryChange.LedgerKey it never panics.
+func LedgerKeyFromChange(change LedgerEntryChange) (LedgerKey, error) {
+	var entry *LedgerEntry
+	switch change.Type {
+	case LedgerEntryChangeTypeLedgerEntryCreated:
+		entry = change.Created
+	case LedgerEntryChangeTypeLedgerEntryUpdated:
+		entry = change.Updated
+	case LedgerEntryChangeTypeLedgerEntryState:
+		entry = change.State
+	case LedgerEntryChangeTypeLedgerEntryRemoved:
+		if change.Removed == nil {
+			return LedgerKey{}, fmt.Errorf("removed ledger key is missing")
+		}
+		return *change.Removed, nil
+	default:
+		return LedgerKey{}, fmt.Errorf("Unknown change type: %d", change.Type)
+	}
+	if entry == nil {
+		return LedgerKey{}, fmt.Errorf("ledger entry of change type %d is missing", change.Type)
+	}
+	return entry.GetLedgerKey()
+}
+
 // MarshalBinaryBase64 marshals XDR into a binary form and then encodes it
 // using base64.
 func (change LedgerEntryChange) MarshalBinaryBase64() (string, error) {
@@ -38,7 +63,7 @@ func (change LedgerEntryChange) MarshalBinaryBase64() (string, error) {
 		return "", err
 	}
 
-	return base64.StdEncoding.EncodeToString(b), nil
+	return GetBase64Encoding().EncodeToString(b), nil
 }
 
 // GetLedgerEntry returns the ledger entry that was changed in `change`, along
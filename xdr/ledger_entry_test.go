@@ -26,6 +26,24 @@ func TestLedgerEntrySponsorship(t *testing.T) {
 	assert.Equal(t, desc, actualDesc)
 }
 
+func TestLedgerEntrySetSponsor(t *testing.T) {
+	entry := LedgerEntry{}
+	assert.Equal(t, "", entry.Sponsor())
+
+	sponsor := "GCO26ZSBD63TKYX45H2C7D2WOFWOUSG5BMTNC3BG4QMXM3PAYI6WHKVZ"
+	assert.NoError(t, entry.SetSponsor(sponsor))
+	assert.Equal(t, int32(1), entry.Ext.V)
+	assert.Equal(t, sponsor, entry.Sponsor())
+	assert.Equal(t, MustAddress(sponsor), *entry.SponsoringID())
+
+	assert.NoError(t, entry.SetSponsor(""))
+	assert.Equal(t, LedgerEntryExt{V: 1, V1: &LedgerEntryExtensionV1{}}, entry.Ext)
+	assert.Equal(t, "", entry.Sponsor())
+
+	assert.Error(t, entry.SetSponsor("GBOB"))
+	assert.Equal(t, "", entry.Sponsor())
+}
+
 func TestNormalizedClaimableBalance(t *testing.T) {
 	input := LedgerEntry{
 		LastModifiedLedgerSeq: 20,
@@ -89,3 +107,91 @@ func TestNormalizedClaimableBalance(t *testing.T) {
 	input.Normalize()
 	assert.Equal(t, expectedOutput, input)
 }
+
+func TestLedgerEntryContentHash(t *testing.T) {
+	signer := func(address string) Signer {
+		return Signer{Key: MustSigner(address), Weight: 1}
+	}
+	entry := LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: LedgerEntryData{
+			Type: LedgerEntryTypeAccount,
+			Account: &AccountEntry{
+				AccountId: MustAddress("GCO26ZSBD63TKYX45H2C7D2WOFWOUSG5BMTNC3BG4QMXM3PAYI6WHKVZ"),
+				Balance:   100,
+				Signers: []Signer{
+					signer("GCR22L3WS7TP72S4Z27YTO6JIQYDJK2KLS2TQNHK6Y7XYPA3AGT3X4FH"),
+					signer("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"),
+				},
+			},
+		},
+	}
+	hash, err := entry.ContentHash()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), entry.Ext.V, "the entry is not modified")
+	assert.Equal(t, Uint32(10), entry.LastModifiedLedgerSeq)
+
+	// the last modified ledger, absent extensions and the order of the
+	// signers are not part of the content
+	same := entry
+	same.LastModifiedLedgerSeq = 20
+	account := *entry.Data.Account
+	account.Signers = []Signer{account.Signers[1], account.Signers[0]}
+	same.Data.Account = &account
+	same.Normalize()
+	sameHash, err := same.ContentHash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	changed := account
+	changed.Balance = 101
+	different := LedgerEntry{Data: LedgerEntryData{Type: LedgerEntryTypeAccount, Account: &changed}}
+	differentHash, err := different.ContentHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, differentHash)
+}
+
+func TestLedgerEntryGetLedgerKey(t *testing.T) {
+	account := MustAddress("GCO26ZSBD63TKYX45H2C7D2WOFWOUSG5BMTNC3BG4QMXM3PAYI6WHKVZ")
+	entry := LedgerEntry{
+		Data: LedgerEntryData{
+			Type: LedgerEntryTypeData,
+			Data: &DataEntry{AccountId: account, DataName: "name", DataValue: DataValue("value")},
+		},
+	}
+	expected := LedgerKey{
+		Type: LedgerEntryTypeData,
+		Data: &LedgerKeyData{AccountId: account, DataName: "name"},
+	}
+
+	key, err := entry.GetLedgerKey()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, key)
+	assert.Equal(t, expected, entry.LedgerKey())
+
+	for _, change := range []LedgerEntryChange{
+		{Type: LedgerEntryChangeTypeLedgerEntryCreated, Created: &entry},
+		{Type: LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &entry},
+		{Type: LedgerEntryChangeTypeLedgerEntryState, State: &entry},
+		{Type: LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &expected},
+	} {
+		key, err = LedgerKeyFromChange(change)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, key)
+	}
+
+	_, err = (&LedgerEntry{Data: LedgerEntryData{Type: LedgerEntryTypeOffer}}).GetLedgerKey()
+	assert.EqualError(t, err, "offer entry is missing")
+	_, err = (&LedgerEntry{Data: LedgerEntryData{Type: 100}}).GetLedgerKey()
+	assert.EqualError(t, err, "Unknown entry type: 100")
+	assert.Panics(t, func() {
+		(&LedgerEntry{Data: LedgerEntryData{Type: 100}}).LedgerKey()
+	})
+
+	_, err = LedgerKeyFromChange(LedgerEntryChange{Type: LedgerEntryChangeTypeLedgerEntryRemoved})
+	assert.EqualError(t, err, "removed ledger key is missing")
+	_, err = LedgerKeyFromChange(LedgerEntryChange{Type: LedgerEntryChangeTypeLedgerEntryUpdated})
+	assert.EqualError(t, err, "ledger entry of change type 1 is missing")
+	_, err = LedgerKeyFromChange(LedgerEntryChange{Type: 100})
+	assert.EqualError(t, err, "Unknown change type: 100")
+}
@@ -1,8 +1,9 @@
 package xdr
 
 import (
-	"encoding/base64"
+	"bytes"
 	"fmt"
+	"sort"
 )
 
 // LedgerKey implements the `Keyer` interface
@@ -37,11 +38,175 @@ func (key *LedgerKey) Equals(other LedgerKey) bool {
 		l := key.MustLiquidityPool()
 		r := other.MustLiquidityPool()
 		return l.LiquidityPoolId == r.LiquidityPoolId
+	case LedgerEntryTypeClaimableBalance:
+		l := key.MustClaimableBalance()
+		r := other.MustClaimableBalance()
+		return l.BalanceId.Equals(r.BalanceId)
+	default:
+		panic(fmt.Errorf("Unknown ledger key type: %v", key.Type))
+	}
+}
+
+// Compare returns an integer comparing `key` to `other`. The result is 0 if
+// key == other, -1 if key < other and +1 if key > other. The order is the
+// lexicographic order of the binary XDR encoding of the keys, so keys of the
+// same type are contiguous and sorted keys can be stored and range-scanned in
+// any byte-ordered store.
+func (key *LedgerKey) Compare(other LedgerKey) int {
+	if c := compareUint32(uint32(key.Type), uint32(other.Type)); c != 0 {
+		return c
+	}
+
+	switch key.Type {
+	case LedgerEntryTypeAccount:
+		l := key.MustAccount()
+		r := other.MustAccount()
+		return compareAccountId(l.AccountId, r.AccountId)
+	case LedgerEntryTypeData:
+		l := key.MustData()
+		r := other.MustData()
+		if c := compareAccountId(l.AccountId, r.AccountId); c != 0 {
+			return c
+		}
+		// Variable length strings are encoded with their length first.
+		if c := compareUint32(uint32(len(l.DataName)), uint32(len(r.DataName))); c != 0 {
+			return c
+		}
+		return bytes.Compare([]byte(l.DataName), []byte(r.DataName))
+	case LedgerEntryTypeOffer:
+		l := key.MustOffer()
+		r := other.MustOffer()
+		if c := compareAccountId(l.SellerId, r.SellerId); c != 0 {
+			return c
+		}
+		return compareUint64(uint64(l.OfferId), uint64(r.OfferId))
+	case LedgerEntryTypeTrustline:
+		l := key.MustTrustLine()
+		r := other.MustTrustLine()
+		if c := compareAccountId(l.AccountId, r.AccountId); c != 0 {
+			return c
+		}
+		return compareTrustLineAsset(l.Asset, r.Asset)
+	case LedgerEntryTypeClaimableBalance:
+		l := key.MustClaimableBalance()
+		r := other.MustClaimableBalance()
+		return compareClaimableBalanceId(l.BalanceId, r.BalanceId)
+	case LedgerEntryTypeLiquidityPool:
+		l := key.MustLiquidityPool()
+		r := other.MustLiquidityPool()
+		return bytes.Compare(l.LiquidityPoolId[:], r.LiquidityPoolId[:])
 	default:
 		panic(fmt.Errorf("Unknown ledger key type: %v", key.Type))
 	}
 }
 
+// SortLedgerKeys sorts the keys in the order defined by LedgerKey.Compare.
+func SortLedgerKeys(keys []LedgerKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Compare(keys[j]) < 0
+	})
+}
+
+// SearchLedgerKeys returns the index of the first key of the sorted keys which
+// is greater than or equal to `key`, or len(keys) if there is none.
+func SearchLedgerKeys(keys []LedgerKey, key LedgerKey) int {
+	return sort.Search(len(keys), func(i int) bool {
+		return keys[i].Compare(key) >= 0
+	})
+}
+
+// LedgerKeysOfType returns the sub-slice of the sorted keys which have the
+// given type.
+func LedgerKeysOfType(keys []LedgerKey, entryType LedgerEntryType) []LedgerKey {
+	start := sort.Search(len(keys), func(i int) bool {
+		return uint32(keys[i].Type) >= uint32(entryType)
+	})
+	end := sort.Search(len(keys), func(i int) bool {
+		return uint32(keys[i].Type) > uint32(entryType)
+	})
+	return keys[start:end]
+}
+
+func compareUint32(l, r uint32) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUint64(l, r uint64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareAccountId(l, r AccountId) int {
+	if c := compareUint32(uint32(l.Type), uint32(r.Type)); c != 0 {
+		return c
+	}
+	switch l.Type {
+	case PublicKeyTypePublicKeyTypeEd25519:
+		lk := l.MustEd25519()
+		rk := r.MustEd25519()
+		return bytes.Compare(lk[:], rk[:])
+	default:
+		panic(fmt.Errorf("Unknown account id type: %v", l.Type))
+	}
+}
+
+func compareTrustLineAsset(l, r TrustLineAsset) int {
+	if c := compareUint32(uint32(l.Type), uint32(r.Type)); c != 0 {
+		return c
+	}
+	switch l.Type {
+	case AssetTypeAssetTypeNative:
+		return 0
+	case AssetTypeAssetTypeCreditAlphanum4:
+		la := l.MustAlphaNum4()
+		ra := r.MustAlphaNum4()
+		if c := bytes.Compare(la.AssetCode[:], ra.AssetCode[:]); c != 0 {
+			return c
+		}
+		return compareAccountId(la.Issuer, ra.Issuer)
+	case AssetTypeAssetTypeCreditAlphanum12:
+		la := l.MustAlphaNum12()
+		ra := r.MustAlphaNum12()
+		if c := bytes.Compare(la.AssetCode[:], ra.AssetCode[:]); c != 0 {
+			return c
+		}
+		return compareAccountId(la.Issuer, ra.Issuer)
+	case AssetTypeAssetTypePoolShare:
+		lp := l.MustLiquidityPoolId()
+		rp := r.MustLiquidityPoolId()
+		return bytes.Compare(lp[:], rp[:])
+	default:
+		panic(fmt.Errorf("Unknown asset type: %v", l.Type))
+	}
+}
+
+func compareClaimableBalanceId(l, r ClaimableBalanceId) int {
+	if c := compareUint32(uint32(l.Type), uint32(r.Type)); c != 0 {
+		return c
+	}
+	switch l.Type {
+	case ClaimableBalanceIdTypeClaimableBalanceIdTypeV0:
+		lh := l.MustV0()
+		rh := r.MustV0()
+		return bytes.Compare(lh[:], rh[:])
+	default:
+		panic(fmt.Errorf("Unknown claimable balance id type: %v", l.Type))
+	}
+}
+
 // SetAccount mutates `key` such that it represents the identity of `account`
 func (key *LedgerKey) SetAccount(account AccountId) error {
 	data := LedgerKeyAccount{account}
@@ -162,5 +327,5 @@ func (key LedgerKey) MarshalBinaryBase64() (string, error) {
 		return "", err
 	}
 
-	return base64.StdEncoding.EncodeToString(b), nil
+	return GetBase64Encoding().EncodeToString(b), nil
 }
@@ -1,9 +1,13 @@
 package xdr
 
 import (
+	"bytes"
 	"encoding/base64"
 	"testing"
 
+	"github.com/stellar/go/gxdr"
+	"github.com/stellar/go/randxdr"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,3 +42,60 @@ func TestTrimRightZeros(t *testing.T) {
 	require.Equal(t, []byte{0x0, 0x2}, trimRightZeros([]byte{0x0, 0x2, 0x0, 0x0}))
 	require.Equal(t, []byte{0x0, 0x2, 0x0, 0x1}, trimRightZeros([]byte{0x0, 0x2, 0x0, 0x1, 0x0}))
 }
+
+func TestLedgerKeyCompare(t *testing.T) {
+	gen := randxdr.NewGenerator()
+	keys := make([]LedgerKey, 1000)
+	for i := range keys {
+		shape := &gxdr.LedgerKey{}
+		gen.Next(shape, []randxdr.Preset{})
+		require.NoError(t, gxdr.Convert(shape, &keys[i]))
+	}
+
+	SortLedgerKeys(keys)
+	for i := 1; i < len(keys); i++ {
+		prev, err := keys[i-1].MarshalBinary()
+		require.NoError(t, err)
+		cur, err := keys[i].MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, bytes.Compare(prev, cur), keys[i-1].Compare(keys[i]))
+		assert.Equal(t, bytes.Compare(cur, prev), keys[i].Compare(keys[i-1]))
+		assert.Equal(t, 0, keys[i].Compare(keys[i]))
+	}
+
+	for i, key := range keys {
+		index := SearchLedgerKeys(keys, key)
+		assert.LessOrEqual(t, index, i)
+		assert.Equal(t, 0, keys[index].Compare(key))
+	}
+
+	total := 0
+	for _, entryType := range []LedgerEntryType{
+		LedgerEntryTypeAccount,
+		LedgerEntryTypeTrustline,
+		LedgerEntryTypeOffer,
+		LedgerEntryTypeData,
+		LedgerEntryTypeClaimableBalance,
+		LedgerEntryTypeLiquidityPool,
+	} {
+		ofType := LedgerKeysOfType(keys, entryType)
+		for _, key := range ofType {
+			assert.Equal(t, entryType, key.Type)
+		}
+		total += len(ofType)
+	}
+	assert.Equal(t, len(keys), total)
+}
+
+func TestLedgerKeyCompareData(t *testing.T) {
+	account := MustAddress("GBFLTCDLOE6YQ74B66RH3S2UW5I2MKZ5VLTM75F4YMIWUIXRIFVNRNIF")
+	var short, long, other LedgerKey
+	require.NoError(t, short.SetData(account, "b"))
+	require.NoError(t, long.SetData(account, "aa"))
+	require.NoError(t, other.SetData(account, "a"))
+
+	// Shorter names come first because of the length prefix
+	assert.Equal(t, -1, short.Compare(long))
+	assert.Equal(t, 1, long.Compare(short))
+	assert.Equal(t, 1, short.Compare(other))
+}
@@ -4,7 +4,6 @@ package xdr
 
 import (
 	"bytes"
-	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -45,13 +44,11 @@ func safeUnmarshalString(decoder func(reader io.Reader) io.Reader, data string,
 // decoding the xdr into the provided destination. Also ensures that the reader
 // is fully consumed.
 func SafeUnmarshalBase64(data string, dest interface{}) error {
-	return safeUnmarshalString(
-		func(r io.Reader) io.Reader {
-			return base64.NewDecoder(base64.StdEncoding, r)
-		},
-		data,
-		dest,
-	)
+	raw, err := GetBase64Encoding().DecodeString(data)
+	if err != nil {
+		return err
+	}
+	return SafeUnmarshal(raw, dest)
 }
 
 // SafeUnmarshalHex first decodes the provided reader from hex before
@@ -117,7 +114,7 @@ func marshalString(encoder func([]byte) string, v interface{}) (string, error) {
 }
 
 func MarshalBase64(v interface{}) (string, error) {
-	return marshalString(base64.StdEncoding.EncodeToString, v)
+	return marshalString(GetBase64Encoding().EncodeToString, v)
 }
 
 func MarshalHex(v interface{}) (string, error) {
@@ -171,9 +168,10 @@ func (e *EncodingBuffer) UnsafeMarshalBase64(encodable EncoderTo) ([]byte, error
 	if err != nil {
 		return nil, err
 	}
-	neededLen := base64.StdEncoding.EncodedLen(len(xdrEncoded))
+	encoding := GetBase64Encoding()
+	neededLen := encoding.EncodedLen(len(xdrEncoded))
 	e.scratchBuf = growSlice(e.scratchBuf, neededLen)
-	base64.StdEncoding.Encode(e.scratchBuf, xdrEncoded)
+	encoding.Encode(e.scratchBuf, xdrEncoded)
 	return e.scratchBuf, nil
 }
 
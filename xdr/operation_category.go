@@ -0,0 +1,90 @@
+package xdr
+
+// OperationCategory is a coarse classification of operation types which
+// allows callers (policy engines, analytics pipelines, ...) to group
+// operations without maintaining their own mapping of operation types.
+type OperationCategory int32
+
+const (
+	// OperationCategoryUnknown is returned for operation types which are not
+	// known to this version of the package.
+	OperationCategoryUnknown OperationCategory = iota
+	// OperationCategoryTransfer groups operations which move funds between
+	// accounts: account creation and merging, payments, path payments,
+	// claimable balances and inflation.
+	OperationCategoryTransfer
+	// OperationCategoryTrust groups operations which establish trustlines or
+	// let issuers control their assets: changing and authorizing trustlines,
+	// setting trustline flags and clawbacks.
+	OperationCategoryTrust
+	// OperationCategoryConfig groups operations which only change the
+	// configuration of the source account: options, data entries and the
+	// sequence number.
+	OperationCategoryConfig
+	// OperationCategoryDEX groups operations which interact with the
+	// decentralized exchange: offers and liquidity pools.
+	OperationCategoryDEX
+	// OperationCategorySponsorship groups operations which manage reserve
+	// sponsorships.
+	OperationCategorySponsorship
+	// OperationCategorySoroban groups smart contract operations. None of the
+	// operation types defined in this version of the XDR belong to it, it is
+	// reserved so the taxonomy stays stable once they are added.
+	OperationCategorySoroban
+)
+
+var operationCategoryNames = map[OperationCategory]string{
+	OperationCategoryUnknown:     "unknown",
+	OperationCategoryTransfer:    "transfer",
+	OperationCategoryTrust:       "trust",
+	OperationCategoryConfig:      "config",
+	OperationCategoryDEX:         "dex",
+	OperationCategorySponsorship: "sponsorship",
+	OperationCategorySoroban:     "soroban",
+}
+
+var operationCategories = map[OperationType]OperationCategory{
+	OperationTypeCreateAccount:                 OperationCategoryTransfer,
+	OperationTypePayment:                       OperationCategoryTransfer,
+	OperationTypePathPaymentStrictReceive:      OperationCategoryTransfer,
+	OperationTypeManageSellOffer:               OperationCategoryDEX,
+	OperationTypeCreatePassiveSellOffer:        OperationCategoryDEX,
+	OperationTypeSetOptions:                    OperationCategoryConfig,
+	OperationTypeChangeTrust:                   OperationCategoryTrust,
+	OperationTypeAllowTrust:                    OperationCategoryTrust,
+	OperationTypeAccountMerge:                  OperationCategoryTransfer,
+	OperationTypeInflation:                     OperationCategoryTransfer,
+	OperationTypeManageData:                    OperationCategoryConfig,
+	OperationTypeBumpSequence:                  OperationCategoryConfig,
+	OperationTypeManageBuyOffer:                OperationCategoryDEX,
+	OperationTypePathPaymentStrictSend:         OperationCategoryTransfer,
+	OperationTypeCreateClaimableBalance:        OperationCategoryTransfer,
+	OperationTypeClaimClaimableBalance:         OperationCategoryTransfer,
+	OperationTypeBeginSponsoringFutureReserves: OperationCategorySponsorship,
+	OperationTypeEndSponsoringFutureReserves:   OperationCategorySponsorship,
+	OperationTypeRevokeSponsorship:             OperationCategorySponsorship,
+	OperationTypeClawback:                      OperationCategoryTrust,
+	OperationTypeClawbackClaimableBalance:      OperationCategoryTrust,
+	OperationTypeSetTrustLineFlags:             OperationCategoryTrust,
+	OperationTypeLiquidityPoolDeposit:          OperationCategoryDEX,
+	OperationTypeLiquidityPoolWithdraw:         OperationCategoryDEX,
+}
+
+// String returns the lower case name of the category, e.g. "transfer".
+func (c OperationCategory) String() string {
+	if name, ok := operationCategoryNames[c]; ok {
+		return name
+	}
+	return operationCategoryNames[OperationCategoryUnknown]
+}
+
+// Category returns the category of the operation type or
+// OperationCategoryUnknown if the type is not known.
+func (e OperationType) Category() OperationCategory {
+	return operationCategories[e]
+}
+
+// Category returns the category of the operation, see OperationType.Category.
+func (o Operation) Category() OperationCategory {
+	return o.Body.Type.Category()
+}
@@ -0,0 +1,36 @@
+package xdr_test
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationCategory(t *testing.T) {
+	tt := assert.New(t)
+
+	for i := int32(0); xdr.OperationTypeCreateAccount.ValidEnum(i); i++ {
+		opType := xdr.OperationType(i)
+		tt.NotEqual(
+			xdr.OperationCategoryUnknown,
+			opType.Category(),
+			"operation type %s has no category",
+			opType.String(),
+		)
+	}
+
+	tt.Equal(xdr.OperationCategoryTransfer, xdr.OperationTypePayment.Category())
+	tt.Equal(xdr.OperationCategoryTrust, xdr.OperationTypeSetTrustLineFlags.Category())
+	tt.Equal(xdr.OperationCategoryConfig, xdr.OperationTypeBumpSequence.Category())
+	tt.Equal(xdr.OperationCategoryDEX, xdr.OperationTypeLiquidityPoolDeposit.Category())
+	tt.Equal(xdr.OperationCategorySponsorship, xdr.OperationTypeRevokeSponsorship.Category())
+	tt.Equal(xdr.OperationCategoryUnknown, xdr.OperationType(1000).Category())
+
+	op := xdr.Operation{Body: xdr.OperationBody{Type: xdr.OperationTypeManageBuyOffer}}
+	tt.Equal(xdr.OperationCategoryDEX, op.Category())
+
+	tt.Equal("dex", xdr.OperationCategoryDEX.String())
+	tt.Equal("soroban", xdr.OperationCategorySoroban.String())
+	tt.Equal("unknown", xdr.OperationCategory(1000).String())
+}
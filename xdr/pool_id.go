@@ -3,16 +3,16 @@ package xdr
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/hex"
 
 	"github.com/stellar/go/support/errors"
 )
 
 func NewPoolId(a, b Asset, fee Int32) (PoolId, error) {
-	if b.LessThan(a) {
-		return PoolId{}, errors.New("AssetA must be < AssetB")
+	if err := ValidatePoolAssets(a, b); err != nil {
+		return PoolId{}, err
 	}
 
-	// Assume the assets are already sorted.
 	params := LiquidityPoolParameters{
 		Type: LiquidityPoolTypeLiquidityPoolConstantProduct,
 		ConstantProduct: &LiquidityPoolConstantProductParameters{
@@ -28,3 +28,62 @@ func NewPoolId(a, b Asset, fee Int32) (PoolId, error) {
 	}
 	return sha256.Sum256(buf.Bytes()), nil
 }
+
+// SortPoolAssets returns the two assets of a liquidity pool in the order
+// required by the protocol, the lower one first.
+func SortPoolAssets(a, b Asset) (Asset, Asset) {
+	if b.LessThan(a) {
+		return b, a
+	}
+	return a, b
+}
+
+// ValidatePoolAssets returns an error unless a is strictly lower than b, as
+// the protocol requires for the assets of a liquidity pool: they must be
+// distinct and sorted (see SortPoolAssets).
+func ValidatePoolAssets(a, b Asset) error {
+	if a.Equals(b) {
+		return errors.New("AssetA and AssetB must be different")
+	}
+	if b.LessThan(a) {
+		return errors.New("AssetA must be < AssetB")
+	}
+	return nil
+}
+
+// PoolId returns the id of the liquidity pool of the parameters, the SHA-256
+// hash of their XDR encoding. The assets must be valid (see
+// ValidatePoolAssets).
+func (p LiquidityPoolParameters) PoolId() (PoolId, error) {
+	params, ok := p.GetConstantProduct()
+	if !ok {
+		return PoolId{}, errors.Errorf("unknown liquidity pool type %d", p.Type)
+	}
+	return NewPoolId(params.AssetA, params.AssetB, params.Fee)
+}
+
+// HexString returns the hex encoded form of the pool id, as used by Horizon.
+func (p PoolId) HexString() string {
+	return hex.EncodeToString(p[:])
+}
+
+// AppendHex appends the hex encoded form of the pool id, as returned by
+// HexString, to dst and returns the extended buffer, without allocating when
+// dst has room for the 64 bytes.
+func (p PoolId) AppendHex(dst []byte) []byte {
+	return appendHex(dst, p[:])
+}
+
+// NewPoolIdFromHex returns the pool id of its hex encoded form.
+func NewPoolIdFromHex(s string) (PoolId, error) {
+	var p PoolId
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return p, errors.Wrap(err, "invalid hex encoding of liquidity pool id")
+	}
+	if len(b) != len(p) {
+		return p, errors.Errorf("liquidity pool id must be %d bytes long, got %d", len(p), len(b))
+	}
+	copy(p[:], b)
+	return p, nil
+}
@@ -134,3 +134,55 @@ func TestNewPoolIdRejectsIncorrectOrder(t *testing.T) {
 	_, err := NewPoolId(MustNewCreditAsset("AbC", acc1), MustNewNativeAsset(), LiquidityPoolFeeV18)
 	assert.EqualError(t, err, "AssetA must be < AssetB")
 }
+
+func TestLiquidityPoolParametersPoolId(t *testing.T) {
+	acc1 := makeAccount(t, "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	credit := MustNewCreditAsset("AbC", acc1)
+
+	// the assets can be given in any order
+	a, b := SortPoolAssets(credit, MustNewNativeAsset())
+	assert.NoError(t, ValidatePoolAssets(a, b))
+	params := LiquidityPoolParameters{
+		Type: LiquidityPoolTypeLiquidityPoolConstantProduct,
+		ConstantProduct: &LiquidityPoolConstantProductParameters{
+			AssetA: a,
+			AssetB: b,
+			Fee:    LiquidityPoolFeeV18,
+		},
+	}
+	id, err := params.PoolId()
+	require.NoError(t, err)
+	expected, err := NewPoolId(MustNewNativeAsset(), credit, LiquidityPoolFeeV18)
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+
+	parsed, err := NewPoolIdFromHex(id.HexString())
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+	_, err = NewPoolIdFromHex("abcd")
+	assert.EqualError(t, err, "liquidity pool id must be 32 bytes long, got 2")
+
+	params.ConstantProduct.AssetA = b
+	_, err = params.PoolId()
+	assert.EqualError(t, err, "AssetA and AssetB must be different")
+	params.ConstantProduct.AssetB = a
+	_, err = params.PoolId()
+	assert.EqualError(t, err, "AssetA must be < AssetB")
+}
+
+func TestNewPoolIdRejectsEqualAssets(t *testing.T) {
+	_, err := NewPoolId(MustNewNativeAsset(), MustNewNativeAsset(), LiquidityPoolFeeV18)
+	assert.EqualError(t, err, "AssetA and AssetB must be different")
+}
+
+func TestPoolIdAppendHex(t *testing.T) {
+	var id PoolId
+	id[0], id[31] = 0xab, 0x01
+	assert.Equal(t, "pool="+id.HexString(), string(id.AppendHex([]byte("pool="))))
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = id.AppendHex(buf[:0])
+	})
+	assert.Equal(t, float64(0), allocs)
+}
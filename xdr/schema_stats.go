@@ -0,0 +1,182 @@
+package xdr
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+)
+
+// SchemaStats collects statistics about decoded XDR values: how many values
+// of every type were decoded, how many bytes they took in their XDR encoding,
+// and which arms of the unions were selected. Collected over a range of
+// ledgers, it reports which protocol features appear in the range, e.g.
+// before designing the storage schema of the entries or a migration.
+//
+// Values are recorded by Record, or by a Stream whose Stats is set. A
+// SchemaStats is safe for concurrent use.
+type SchemaStats struct {
+	lock  sync.Mutex
+	types map[string]*TypeStats
+}
+
+// TypeStats are the statistics of an XDR type collected by SchemaStats.
+type TypeStats struct {
+	// Name is the name of the type, e.g. "LedgerEntry".
+	Name string
+	// Count is the number of values of the type.
+	Count int64
+	// Bytes is the total size of the values in their XDR encoding.
+	Bytes int64
+	// Arms are the number of values of a union by discriminant, e.g.
+	// "OperationTypePayment" for an OperationBody. It is nil if the type is
+	// not a union.
+	Arms map[string]int64
+}
+
+// NewSchemaStats returns an empty SchemaStats.
+func NewSchemaStats() *SchemaStats {
+	return &SchemaStats{types: map[string]*TypeStats{}}
+}
+
+// Record adds v, a value of an XDR type or a pointer to one, and all the
+// values it is made of to the statistics. Nil values are ignored.
+func (s *SchemaStats) Record(v interface{}) {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.record(value)
+}
+
+// Types returns the statistics of the types recorded so far, sorted by name.
+func (s *SchemaStats) Types() []TypeStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	types := make([]TypeStats, 0, len(s.types))
+	for _, stats := range s.types {
+		copied := *stats
+		if stats.Arms != nil {
+			copied.Arms = make(map[string]int64, len(stats.Arms))
+			for arm, count := range stats.Arms {
+				copied.Arms[arm] = count
+			}
+		}
+		types = append(types, copied)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+	return types
+}
+
+// WriteReport writes the statistics as a table with one row per type,
+// followed by one row per arm for the unions.
+func (s *SchemaStats) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tCOUNT\tBYTES")
+	for _, stats := range s.Types() {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", stats.Name, stats.Count, stats.Bytes)
+		arms := make([]string, 0, len(stats.Arms))
+		for arm := range stats.Arms {
+			arms = append(arms, arm)
+		}
+		sort.Strings(arms)
+		for _, arm := range arms {
+			fmt.Fprintf(tw, "  %s\t%d\t\n", arm, stats.Arms[arm])
+		}
+	}
+	return tw.Flush()
+}
+
+// record walks v, recording the values of the named types of this package,
+// and returns the size of its XDR encoding.
+func (s *SchemaStats) record(v reflect.Value) int64 {
+	if v.Kind() == reflect.Ptr {
+		// optional values are encoded with a presence flag
+		if v.IsNil() {
+			return 4
+		}
+		return 4 + s.record(v.Elem())
+	}
+
+	var size int64
+	var arm string
+	switch {
+	case isOpaque(v.Type()):
+		size = int64(padded(v.Len()))
+		if v.Kind() == reflect.Slice {
+			size += 4
+		}
+	case v.Kind() == reflect.String:
+		size = 4 + int64(padded(v.Len()))
+	case v.Kind() == reflect.Int64 || v.Kind() == reflect.Uint64:
+		size = 8
+	case v.Kind() == reflect.Int32 || v.Kind() == reflect.Uint32 || v.Kind() == reflect.Bool:
+		size = 4
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Kind() == reflect.Slice {
+			size = 4
+		}
+		for i := 0; i < v.Len(); i++ {
+			size += s.record(v.Index(i))
+		}
+	case v.Kind() == reflect.Struct:
+		if u, ok := v.Interface().(xdrUnion); ok {
+			sw := v.FieldByName(u.SwitchFieldName())
+			size = s.record(sw)
+			arm = armName(sw)
+			if name, ok := u.ArmForSwitch(switchValue(sw)); ok && name != "" {
+				// the arms of unions are pointers but are not optional
+				if field := v.FieldByName(name); !field.IsNil() {
+					size += s.record(field.Elem())
+				}
+			}
+		} else {
+			for i := 0; i < v.NumField(); i++ {
+				size += s.record(v.Field(i))
+			}
+		}
+	}
+
+	if t := v.Type(); t.PkgPath() == schemaStatsPkgPath && t.Name() != "" {
+		stats, ok := s.types[t.Name()]
+		if !ok {
+			stats = &TypeStats{Name: t.Name()}
+			s.types[t.Name()] = stats
+		}
+		stats.Count++
+		stats.Bytes += size
+		if arm != "" {
+			if stats.Arms == nil {
+				stats.Arms = map[string]int64{}
+			}
+			stats.Arms[arm]++
+		}
+	}
+	return size
+}
+
+var schemaStatsPkgPath = reflect.TypeOf(SchemaStats{}).PkgPath()
+
+// armName returns the name of the discriminant of a union, the name of the
+// enum value if it is an enum.
+func armName(sw reflect.Value) string {
+	if stringer, ok := sw.Interface().(fmt.Stringer); ok && sw.Kind() == reflect.Int32 {
+		return stringer.String()
+	}
+	return strconv.FormatInt(int64(switchValue(sw)), 10)
+}
+
+// padded returns n rounded up to a multiple of 4, the size of opaque data
+// and strings of length n in XDR.
+func padded(n int) int {
+	return (n + 3) &^ 3
+}
@@ -0,0 +1,120 @@
+package xdr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/gxdr"
+	"github.com/stellar/go/randxdr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaStatsRecord(t *testing.T) {
+	text := "hello"
+	envelope := TransactionEnvelope{
+		Type: EnvelopeTypeEnvelopeTypeTx,
+		V1: &TransactionV1Envelope{
+			Tx: Transaction{
+				SourceAccount: MustMuxedAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+				Memo:          Memo{Type: MemoTypeMemoText, Text: &text},
+				Operations: []Operation{
+					{Body: OperationBody{Type: OperationTypeInflation}},
+					{Body: OperationBody{Type: OperationTypeInflation}},
+					{Body: OperationBody{Type: OperationTypeBumpSequence, BumpSequenceOp: &BumpSequenceOp{BumpTo: 5}}},
+				},
+			},
+		},
+	}
+	raw, err := envelope.MarshalBinary()
+	require.NoError(t, err)
+
+	stats := NewSchemaStats()
+	stats.Record(&envelope)
+	types := map[string]TypeStats{}
+	for _, stats := range stats.Types() {
+		types[stats.Name] = stats
+	}
+
+	assert.Equal(t, TypeStats{
+		Name:  "TransactionEnvelope",
+		Count: 1,
+		Bytes: int64(len(raw)),
+		Arms:  map[string]int64{"EnvelopeTypeEnvelopeTypeTx": 1},
+	}, types["TransactionEnvelope"])
+	assert.Equal(t, map[string]int64{
+		"OperationTypeInflation":    2,
+		"OperationTypeBumpSequence": 1,
+	}, types["OperationBody"].Arms)
+	assert.Equal(t, int64(3), types["Operation"].Count)
+	assert.Equal(t, int64(8), types["SequenceNumber"].Bytes/types["SequenceNumber"].Count)
+	assert.Equal(t, TypeStats{
+		Name:  "Memo",
+		Count: 1,
+		Bytes: 16,
+		Arms:  map[string]int64{"MemoTypeMemoText": 1},
+	}, types["Memo"])
+	assert.Equal(t, map[string]int64{"0": 1}, types["TransactionExt"].Arms)
+	assert.Nil(t, types["Transaction"].Arms)
+
+	var report bytes.Buffer
+	require.NoError(t, stats.WriteReport(&report))
+	lines := strings.Split(report.String(), "\n")
+	assert.Regexp(t, `^TYPE\s+COUNT\s+BYTES$`, lines[0])
+	assert.Contains(t, report.String(), "  OperationTypeBumpSequence")
+
+	// nil values are ignored
+	recorded := stats.Types()
+	stats.Record(nil)
+	stats.Record((*TransactionEnvelope)(nil))
+	assert.Equal(t, recorded, stats.Types())
+}
+
+func TestSchemaStatsSize(t *testing.T) {
+	gen := randxdr.NewGenerator()
+	for i := 0; i < 100; i++ {
+		shape := &gxdr.TransactionEnvelope{}
+		gen.Next(shape, []randxdr.Preset{})
+		var envelope TransactionEnvelope
+		require.NoError(t, gxdr.Convert(shape, &envelope))
+		raw, err := envelope.MarshalBinary()
+		require.NoError(t, err)
+
+		stats := NewSchemaStats()
+		stats.Record(envelope)
+		for _, typeStats := range stats.Types() {
+			if typeStats.Name == "TransactionEnvelope" {
+				assert.Equal(t, int64(len(raw)), typeStats.Bytes)
+			}
+		}
+	}
+}
+
+func TestStreamStats(t *testing.T) {
+	entries := streamTestEntries()
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		require.NoError(t, MarshalFramed(&buf, entry))
+	}
+
+	stream := NewStream(&buf)
+	stream.Stats = NewSchemaStats()
+	var entry BucketEntry
+	require.NoError(t, stream.ReadOne(&entry))
+	require.NoError(t, stream.Skip())
+	require.NoError(t, stream.ReadOne(&entry))
+
+	for _, stats := range stream.Stats.Types() {
+		if stats.Name == "BucketEntry" {
+			assert.Equal(t, int64(2), stats.Count)
+			assert.Equal(t, map[string]int64{"BucketEntryTypeLiveentry": 2}, stats.Arms)
+			first, err := entries[0].MarshalBinary()
+			require.NoError(t, err)
+			third, err := entries[2].MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(first)+len(third)), stats.Bytes)
+		}
+	}
+}
@@ -71,6 +71,48 @@ func (skey *SignerKey) Equals(other SignerKey) bool {
 	}
 }
 
+// Hint returns the signature hint of the signer key: the last 4 bytes of the
+// ed25519 public key, of the hash of the pre-authorized transaction or of the
+// hash of the hash(x) preimage. It panics if the SignerKey is of an unknown
+// type.
+func (skey *SignerKey) Hint() SignatureHint {
+	var key Uint256
+	switch skey.Type {
+	case SignerKeyTypeSignerKeyTypeEd25519:
+		key = skey.MustEd25519()
+	case SignerKeyTypeSignerKeyTypeHashX:
+		key = skey.MustHashX()
+	case SignerKeyTypeSignerKeyTypePreAuthTx:
+		key = skey.MustPreAuthTx()
+	default:
+		panic(fmt.Errorf("unknown signer key type: %v", skey.Type))
+	}
+
+	var hint SignatureHint
+	copy(hint[:], key[len(key)-len(hint):])
+	return hint
+}
+
+// MatchesHint returns true if the hint of the signature is the hint of the
+// signer key, i.e. if the signature may have been made by the signer. The
+// signature must still be verified.
+func (s DecoratedSignature) MatchesHint(skey SignerKey) bool {
+	return s.Hint == skey.Hint()
+}
+
+// SignaturesMatchingHint returns the signatures whose hint is the hint of the
+// signer key, in their order.
+func SignaturesMatchingHint(signatures []DecoratedSignature, skey SignerKey) []DecoratedSignature {
+	hint := skey.Hint()
+	var matching []DecoratedSignature
+	for _, signature := range signatures {
+		if signature.Hint == hint {
+			matching = append(matching, signature)
+		}
+	}
+	return matching
+}
+
 func MustSigner(address string) SignerKey {
 	aid := SignerKey{}
 	err := aid.SetAddress(address)
@@ -82,6 +124,12 @@ func MustSigner(address string) SignerKey {
 
 // SetAddress modifies the receiver, setting it's value to the SignerKey form
 // of the provided address.
+//
+// A muxed (M...) address is converted to the ed25519 signer key of its
+// underlying account, since muxed accounts sign with the key of the account
+// they share, so the memo ID of the address is dropped and Address() returns
+// the G... address of the underlying account. Use SignerKeyFromMuxedAddress
+// to get the memo ID as well.
 func (skey *SignerKey) SetAddress(address string) error {
 	if skey == nil {
 		return nil
@@ -95,6 +143,13 @@ func (skey *SignerKey) SetAddress(address string) error {
 	var keytype SignerKeyType
 
 	switch vb {
+	case strkey.VersionByteMuxedAccount:
+		signer, _, err := SignerKeyFromMuxedAddress(address)
+		if err != nil {
+			return err
+		}
+		*skey = signer
+		return nil
 	case strkey.VersionByteAccountID:
 		keytype = SignerKeyTypeSignerKeyTypeEd25519
 	case strkey.VersionByteHashX:
@@ -121,3 +176,43 @@ func (skey *SignerKey) SetAddress(address string) error {
 
 	return err
 }
+
+// SignerKeyFromMuxedAddress returns the ed25519 signer key of the account
+// underlying a muxed (M...) address, and the memo ID of the address. A G...
+// address is accepted too, its memo ID being 0.
+func SignerKeyFromMuxedAddress(address string) (SignerKey, uint64, error) {
+	muxed, err := AddressToMuxedAccount(address)
+	if err != nil {
+		return SignerKey{}, 0, errors.Wrap(err, "invalid muxed address")
+	}
+
+	var id uint64
+	if muxed.Type == CryptoKeyTypeKeyTypeMuxedEd25519 {
+		if id, err = muxed.GetId(); err != nil {
+			return SignerKey{}, 0, err
+		}
+	}
+	accountID := muxed.ToAccountId()
+	signer, err := NewSignerKey(SignerKeyTypeSignerKeyTypeEd25519, *accountID.Ed25519)
+	if err != nil {
+		return SignerKey{}, 0, err
+	}
+	return signer, id, nil
+}
+
+// MuxedAddressID returns the memo ID of a muxed (M...) address. It returns an
+// error if the address is not a muxed address.
+func MuxedAddressID(address string) (uint64, error) {
+	vb, err := strkey.Version(address)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to extract address version")
+	}
+	if vb != strkey.VersionByteMuxedAccount {
+		return 0, errors.Errorf("%s is not a muxed address", address)
+	}
+	muxed, err := AddressToMuxedAccount(address)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid muxed address")
+	}
+	return muxed.GetId()
+}
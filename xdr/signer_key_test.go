@@ -80,3 +80,76 @@ func TestSignerKey_SetAddress(t *testing.T) {
 	err := dest.SetAddress("SBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHOKR")
 	assert.Error(t, err)
 }
+
+func TestSignerKey_SetMuxedAddress(t *testing.T) {
+	var signer SignerKey
+	err := signer.SetAddress("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK")
+	assert.NoError(t, err)
+	assert.Equal(t, SignerKeyTypeSignerKeyTypeEd25519, signer.Type)
+	assert.Equal(t, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", signer.Address())
+	assert.True(t, signer.Equals(MustSigner("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")))
+
+	signer, id, err := SignerKeyFromMuxedAddress("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9223372036854775808), id)
+	assert.Equal(t, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", signer.Address())
+
+	signer, id, err = SignerKeyFromMuxedAddress("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), id)
+	assert.Equal(t, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", signer.Address())
+
+	_, _, err = SignerKeyFromMuxedAddress("XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG")
+	assert.Error(t, err)
+}
+
+func TestMuxedAddressID(t *testing.T) {
+	id, err := MuxedAddressID("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), id)
+	id, err = MuxedAddressID("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9223372036854775808), id)
+
+	_, err = MuxedAddressID("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+	assert.EqualError(t, err, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ is not a muxed address")
+}
+
+func TestSignerKey_Hint(t *testing.T) {
+	for _, address := range []string{
+		"GA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQHES5",
+		"TBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHXL7",
+		"XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
+	} {
+		signer := MustSigner(address)
+		var key Uint256
+		switch signer.Type {
+		case SignerKeyTypeSignerKeyTypeEd25519:
+			key = signer.MustEd25519()
+		case SignerKeyTypeSignerKeyTypeHashX:
+			key = signer.MustHashX()
+		case SignerKeyTypeSignerKeyTypePreAuthTx:
+			key = signer.MustPreAuthTx()
+		}
+		assert.Equal(t, SignatureHint{key[28], key[29], key[30], key[31]}, signer.Hint(), address)
+	}
+
+	unknown := SignerKey{Type: 100}
+	assert.Panics(t, func() { unknown.Hint() })
+}
+
+func TestSignaturesMatchingHint(t *testing.T) {
+	signer := MustSigner("GA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQHES5")
+	other := MustSigner("XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG")
+	signatures := []DecoratedSignature{
+		{Hint: other.Hint(), Signature: Signature{1}},
+		{Hint: signer.Hint(), Signature: Signature{2}},
+		{Hint: signer.Hint(), Signature: Signature{3}},
+	}
+
+	assert.False(t, signatures[0].MatchesHint(signer))
+	assert.True(t, signatures[1].MatchesHint(signer))
+	assert.Equal(t, signatures[1:], SignaturesMatchingHint(signatures, signer))
+	assert.Equal(t, signatures[:1], SignaturesMatchingHint(signatures, other))
+	assert.Empty(t, SignaturesMatchingHint(nil, signer))
+}
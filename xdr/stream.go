@@ -0,0 +1,152 @@
+package xdr
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultMaxFrameSize is the MaxFrameSize of a Stream whose MaxFrameSize is
+// not set. It is well above the size of any ledger entry or transaction set,
+// but prevents a corrupted frame header from allocating gigabytes.
+const DefaultMaxFrameSize = 64 << 20
+
+// Stream decodes a sequence of framed XDR values, as written by
+// MarshalFramed, from a reader: every value is preceded by a record mark
+// (RFC 5531), the 4 bytes big endian length of its fragment whose highest bit
+// is set on the last fragment of a record. This is the format of the bucket
+// and history files of history archives.
+//
+// Only one record is in memory at a time, in a buffer reused between records,
+// so that files much larger than the memory can be decoded.
+type Stream struct {
+	// MaxFrameSize is the maximum size of a record, DefaultMaxFrameSize if 0.
+	MaxFrameSize uint32
+	// Stats, if set, records the values decoded by ReadOne.
+	Stats *SchemaStats
+
+	reader    *bufio.Reader
+	buf       []byte
+	decoder   *BytesDecoder
+	bytesRead int64
+	records   int64
+}
+
+// NewStream returns a Stream decoding the records of r.
+func NewStream(r io.Reader) *Stream {
+	return &Stream{
+		reader:  bufio.NewReader(r),
+		decoder: NewBytesDecoder(),
+	}
+}
+
+// NewGzipStream returns a Stream decoding the records of r once
+// decompressed, as in .xdr.gz files.
+func NewGzipStream(r io.Reader) (*Stream, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating gzip reader")
+	}
+	return NewStream(gzipReader), nil
+}
+
+// ReadOne decodes the next record into v. It returns io.EOF, unwrapped, once
+// all the records are read, and io.ErrUnexpectedEOF if the stream ends in the
+// middle of a record.
+func (s *Stream) ReadOne(v DecoderFrom) error {
+	record, err := s.next()
+	if err != nil {
+		return err
+	}
+	n, err := s.decoder.DecodeBytes(v, record)
+	if err != nil {
+		return errors.Wrapf(err, "error decoding record %d", s.records)
+	}
+	if n != len(record) {
+		return fmt.Errorf("record %d not fully consumed. expected to read: %d, actual: %d", s.records, len(record), n)
+	}
+	if s.Stats != nil {
+		s.Stats.Record(v)
+	}
+	return nil
+}
+
+// Skip skips the next record without decoding it. Like ReadOne, it returns
+// io.EOF once all the records are read.
+func (s *Stream) Skip() error {
+	_, err := s.next()
+	return err
+}
+
+// BytesRead returns the number of bytes of the records read so far,
+// including their record marks.
+func (s *Stream) BytesRead() int64 {
+	return s.bytesRead
+}
+
+// RecordsRead returns the number of records read so far.
+func (s *Stream) RecordsRead() int64 {
+	return s.records
+}
+
+// next reads the fragments of the next record into the buffer.
+func (s *Stream) next() ([]byte, error) {
+	maxFrameSize := s.MaxFrameSize
+	if maxFrameSize == 0 {
+		maxFrameSize = DefaultMaxFrameSize
+	}
+
+	s.buf = s.buf[:0]
+	for first := true; ; first = false {
+		var mark [4]byte
+		if _, err := io.ReadFull(s.reader, mark[:]); err != nil {
+			if err == io.EOF && first {
+				// Do not wrap io.EOF
+				return nil, io.EOF
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		s.bytesRead += 4
+
+		header := binary.BigEndian.Uint32(mark[:])
+		last := header&0x80000000 != 0
+		length := header & 0x7fffffff
+		if uint64(len(s.buf))+uint64(length) > uint64(maxFrameSize) {
+			return nil, fmt.Errorf("record %d is larger than the maximum frame size of %d bytes", s.records+1, maxFrameSize)
+		}
+
+		start := len(s.buf)
+		s.buf = grow(s.buf, int(length))
+		n, err := io.ReadFull(s.reader, s.buf[start:])
+		s.bytesRead += int64(n)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if last {
+			s.records++
+			return s.buf, nil
+		}
+	}
+}
+
+// grow extends buf by n bytes, reallocating it only if its capacity is too
+// small.
+func grow(buf []byte, n int) []byte {
+	if cap(buf)-len(buf) < n {
+		grown := make([]byte, len(buf), len(buf)+n)
+		copy(grown, buf)
+		buf = grown
+	}
+	return buf[:len(buf)+n]
+}
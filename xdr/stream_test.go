@@ -0,0 +1,130 @@
+package xdr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func streamTestEntries() []BucketEntry {
+	var entries []BucketEntry
+	for i := 1; i <= 3; i++ {
+		entries = append(entries, BucketEntry{
+			Type: BucketEntryTypeLiveentry,
+			LiveEntry: &LedgerEntry{
+				LastModifiedLedgerSeq: Uint32(i),
+				Data: LedgerEntryData{
+					Type: LedgerEntryTypeAccount,
+					Account: &AccountEntry{
+						AccountId: MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+						Balance:   Int64(i),
+					},
+				},
+			},
+		})
+	}
+	return entries
+}
+
+func TestStream(t *testing.T) {
+	entries := streamTestEntries()
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		require.NoError(t, MarshalFramed(&buf, entry))
+	}
+	size := int64(buf.Len())
+
+	stream := NewStream(&buf)
+	var entry BucketEntry
+	require.NoError(t, stream.ReadOne(&entry))
+	assert.Equal(t, entries[0], entry)
+	require.NoError(t, stream.Skip())
+	require.NoError(t, stream.ReadOne(&entry))
+	assert.Equal(t, entries[2], entry)
+	assert.Equal(t, io.EOF, stream.ReadOne(&entry))
+	assert.Equal(t, size, stream.BytesRead())
+	assert.Equal(t, int64(3), stream.RecordsRead())
+}
+
+func TestGzipStream(t *testing.T) {
+	entries := streamTestEntries()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	for _, entry := range entries {
+		require.NoError(t, MarshalFramed(writer, entry))
+	}
+	require.NoError(t, writer.Close())
+
+	stream, err := NewGzipStream(&buf)
+	require.NoError(t, err)
+	var decoded []BucketEntry
+	for {
+		var entry BucketEntry
+		err = stream.ReadOne(&entry)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		decoded = append(decoded, entry)
+	}
+	assert.Equal(t, entries, decoded)
+
+	_, err = NewGzipStream(bytes.NewReader([]byte("not gzip")))
+	assert.Error(t, err)
+}
+
+func TestStreamFragments(t *testing.T) {
+	entry := streamTestEntries()[0]
+	raw, err := entry.MarshalBinary()
+	require.NoError(t, err)
+
+	// a record split in two fragments, the first one without the last
+	// fragment bit
+	var buf bytes.Buffer
+	half := len(raw) / 2
+	buf.Write([]byte{0, 0, 0, byte(half)})
+	buf.Write(raw[:half])
+	buf.Write([]byte{0x80, 0, 0, byte(len(raw) - half)})
+	buf.Write(raw[half:])
+
+	var decoded BucketEntry
+	stream := NewStream(&buf)
+	require.NoError(t, stream.ReadOne(&decoded))
+	assert.Equal(t, entry, decoded)
+	assert.Equal(t, io.EOF, stream.ReadOne(&decoded))
+}
+
+func TestStreamErrors(t *testing.T) {
+	entry := streamTestEntries()[0]
+	var buf bytes.Buffer
+	require.NoError(t, MarshalFramed(&buf, entry))
+	framed := buf.Bytes()
+
+	var decoded BucketEntry
+	stream := NewStream(bytes.NewReader(framed[:2]))
+	assert.Equal(t, io.ErrUnexpectedEOF, stream.ReadOne(&decoded))
+
+	stream = NewStream(bytes.NewReader(framed[:len(framed)-1]))
+	assert.Equal(t, io.ErrUnexpectedEOF, stream.ReadOne(&decoded))
+
+	stream = NewStream(bytes.NewReader(framed))
+	stream.MaxFrameSize = 8
+	assert.EqualError(t, stream.ReadOne(&decoded), "record 1 is larger than the maximum frame size of 8 bytes")
+
+	// the record has 4 bytes more than the entry
+	length := len(framed) - 4
+	padded := make([]byte, 4, len(framed)+4)
+	binary.BigEndian.PutUint32(padded, 0x80000000|uint32(length+4))
+	padded = append(padded, framed[4:]...)
+	padded = append(padded, 0, 0, 0, 0)
+	stream = NewStream(bytes.NewReader(padded))
+	assert.EqualError(t, stream.ReadOne(&decoded), fmt.Sprintf(
+		"record 1 not fully consumed. expected to read: %d, actual: %d", length+4, length,
+	))
+}
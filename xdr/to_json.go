@@ -0,0 +1,47 @@
+package xdr
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// ToJSON decodes b, the binary XDR encoding of a value of the named type,
+// e.g. "TransactionEnvelope", and returns its JSON encoding. The names are
+// the names of the Go types of the package, listed by TypeNames. The input
+// must be consumed entirely.
+func ToJSON(typeName string, b []byte) ([]byte, error) {
+	value, ok := NewValueOf(typeName)
+	if !ok {
+		return nil, errors.Errorf("unknown XDR type %s", typeName)
+	}
+	if err := SafeUnmarshal(b, value); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", typeName)
+	}
+	// marshalValue, rather than json.Marshal, so that the types without JSON
+	// methods, such as Hash, are encoded with the schema of the package
+	return marshalValue(reflect.ValueOf(value).Elem())
+}
+
+// NewValueOf returns a pointer to a new zero value of the named type, one of
+// TypeNames(), e.g. to decode a value whose type is only known by name. It
+// returns false if there is no such type.
+func NewValueOf(typeName string) (interface{}, bool) {
+	newValue, ok := xdrTypes[typeName]
+	if !ok {
+		return nil, false
+	}
+	return newValue(), true
+}
+
+// TypeNames returns the sorted names of the types accepted by ToJSON and
+// NewValueOf.
+func TypeNames() []string {
+	names := make([]string, 0, len(xdrTypes))
+	for name := range xdrTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+package xdr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSON(t *testing.T) {
+	asset := MustNewCreditAsset("USD", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+	raw, err := asset.MarshalBinary()
+	require.NoError(t, err)
+
+	rendered, err := ToJSON("Asset", raw)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "AssetTypeAssetTypeCreditAlphanum4",
+		"alpha_num4": {
+			"asset_code": "55534400",
+			"issuer": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+		}
+	}`, string(rendered))
+
+	// types without JSON methods follow the same schema
+	hash := Hash{0xab}
+	raw, err = hash.MarshalBinary()
+	require.NoError(t, err)
+	rendered, err = ToJSON("Hash", raw)
+	require.NoError(t, err)
+	assert.Equal(t, `"ab00000000000000000000000000000000000000000000000000000000000000"`, string(rendered))
+
+	raw, err = Int64(-5).MarshalBinary()
+	require.NoError(t, err)
+	rendered, err = ToJSON("Int64", raw)
+	require.NoError(t, err)
+	assert.Equal(t, `"-5"`, string(rendered))
+
+	_, err = ToJSON("Unknown", raw)
+	assert.EqualError(t, err, "unknown XDR type Unknown")
+	_, err = ToJSON("Asset", append(raw, 0))
+	assert.Error(t, err)
+}
+
+func TestTypeNames(t *testing.T) {
+	names := TypeNames()
+	assert.Contains(t, names, "TransactionEnvelope")
+	assert.Contains(t, names, "LedgerEntry")
+	assert.IsIncreasing(t, names)
+}
+
+func TestNewValueOf(t *testing.T) {
+	value, ok := NewValueOf("Asset")
+	assert.True(t, ok)
+	assert.Equal(t, &Asset{}, value)
+
+	for _, name := range TypeNames() {
+		value, ok := NewValueOf(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, name, reflect.TypeOf(value).Elem().Name())
+	}
+
+	_, ok = NewValueOf("Unknown")
+	assert.False(t, ok)
+}
@@ -1,5 +1,7 @@
 package xdr
 
+import "fmt"
+
 // IsFeeBump returns true if the transaction envelope is a fee bump transaction
 func (e TransactionEnvelope) IsFeeBump() bool {
 	return e.Type == EnvelopeTypeEnvelopeTypeTxFeeBump
@@ -134,3 +136,77 @@ func (e TransactionEnvelope) Memo() Memo {
 		panic("unsupported transaction type: " + e.Type.String())
 	}
 }
+
+// minBaseFee is the minimum fee per operation of the network, in stroops.
+const minBaseFee = 100
+
+// SetFeeBump wraps the transaction of the envelope in a fee bump transaction
+// paid by feeSource, which can spend up to fee stroops. Like in
+// txnbuild.NewFeeBumpTransaction, fee must cover a base fee, no lower than
+// the network minimum nor than the base fee of the inner transaction, for
+// each operation of the inner transaction and for the fee bump. The
+// signatures of the transaction are kept in the inner transaction and the fee
+// bump transaction has no signatures. Transactions with a v0 envelope are
+// upgraded to v1, which keeps their hash and signatures valid.
+func (e *TransactionEnvelope) SetFeeBump(feeSource MuxedAccount, fee int64) error {
+	var inner TransactionV1Envelope
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTx:
+		inner = *e.V1
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		inner = TransactionV1Envelope{
+			Tx: Transaction{
+				SourceAccount: e.SourceAccount(),
+				Fee:           e.V0.Tx.Fee,
+				SeqNum:        e.V0.Tx.SeqNum,
+				TimeBounds:    e.V0.Tx.TimeBounds,
+				Memo:          e.V0.Tx.Memo,
+				Operations:    e.V0.Tx.Operations,
+				Ext:           TransactionExt{V: e.V0.Tx.Ext.V},
+			},
+			Signatures: e.V0.Signatures,
+		}
+	default:
+		return fmt.Errorf("%s transactions cannot be fee bumped", e.Type)
+	}
+	ops := int64(len(inner.Tx.Operations))
+	baseFee := int64(inner.Tx.Fee)
+	if ops > 0 {
+		baseFee /= ops
+	}
+	if baseFee < minBaseFee {
+		baseFee = minBaseFee
+	}
+	if minFee := baseFee * (ops + 1); fee < minFee {
+		return fmt.Errorf(
+			"fee bump fee %d is lower than the minimum fee %d: a base fee of %d for %d operations and the fee bump",
+			fee, minFee, baseFee, ops,
+		)
+	}
+
+	*e = TransactionEnvelope{
+		Type: EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &FeeBumpTransactionEnvelope{
+			Tx: FeeBumpTransaction{
+				FeeSource: feeSource,
+				Fee:       Int64(fee),
+				InnerTx: FeeBumpTransactionInnerTx{
+					Type: EnvelopeTypeEnvelopeTypeTx,
+					V1:   &inner,
+				},
+			},
+		},
+	}
+	return nil
+}
+
+// InnerTransaction returns the v1 envelope of the transaction wrapped by a fee
+// bump transaction, with its signatures. It panics if the envelope is not a
+// fee bump transaction.
+func (e TransactionEnvelope) InnerTransaction() TransactionEnvelope {
+	inner := e.MustFeeBump().Tx.InnerTx.MustV1()
+	return TransactionEnvelope{
+		Type: EnvelopeTypeEnvelopeTypeTx,
+		V1:   &inner,
+	}
+}
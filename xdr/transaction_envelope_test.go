@@ -338,3 +338,55 @@ func TestMemo(t *testing.T) {
 		feeBumpTx.Memo(),
 	)
 }
+
+func TestSetFeeBump(t *testing.T) {
+	feeSource := MustMuxedAddress("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+
+	tx := createTx()
+	original := createTx()
+	assert.NoError(t, tx.SetFeeBump(feeSource, 300))
+	assert.True(t, tx.IsFeeBump())
+	assert.Equal(t, feeSource, tx.FeeBumpAccount())
+	assert.Equal(t, int64(300), tx.FeeBumpFee())
+	assert.Empty(t, tx.FeeBumpSignatures())
+	assert.Equal(t, original.Signatures(), tx.Signatures())
+	assert.Equal(t, original.Operations(), tx.Operations())
+	inner := tx.InnerTransaction()
+	assert.True(t, inner.Equals(original))
+
+	legacyTx := createLegacyTx()
+	assert.NoError(t, legacyTx.SetFeeBump(feeSource, 200))
+	inner = legacyTx.InnerTransaction()
+	assert.Equal(t, EnvelopeTypeEnvelopeTypeTx, inner.Type)
+	assert.Equal(t, createLegacyTx().SourceAccount(), inner.SourceAccount())
+	assert.Equal(t, createLegacyTx().Signatures(), inner.Signatures())
+	assert.Equal(t, createLegacyTx().SeqNum(), inner.SeqNum())
+
+	tx = createTx()
+	assert.EqualError(t, tx.SetFeeBump(feeSource, 199), "fee bump fee 199 is lower than the minimum fee 200: a base fee of 100 for 1 operations and the fee bump")
+	assert.False(t, tx.IsFeeBump())
+
+	// the fee bump pays at least the base fee of the inner transaction
+	tx.V1.Tx.Fee = 500
+	assert.EqualError(t, tx.SetFeeBump(feeSource, 999), "fee bump fee 999 is lower than the minimum fee 1000: a base fee of 500 for 1 operations and the fee bump")
+	assert.NoError(t, tx.SetFeeBump(feeSource, 1000))
+
+	feeBumpTx := createFeeBumpTx()
+	assert.EqualError(t, feeBumpTx.SetFeeBump(feeSource, 300), "EnvelopeTypeEnvelopeTypeTxFeeBump transactions cannot be fee bumped")
+}
+
+func TestInnerTransaction(t *testing.T) {
+	tx := createTx()
+	assert.Panics(t, func() {
+		tx.InnerTransaction()
+	})
+
+	feeBumpTx := createFeeBumpTx()
+	inner := feeBumpTx.InnerTransaction()
+	assert.Equal(t, EnvelopeTypeEnvelopeTypeTx, inner.Type)
+	assert.Equal(t, feeBumpTx.Signatures(), inner.Signatures())
+
+	// the inner envelope is a copy
+	inner.V1.Tx.Fee++
+	assert.NotEqual(t, inner.Fee(), feeBumpTx.Fee())
+}
@@ -0,0 +1,4510 @@
+// Code generated by equalsgen from xdr_generated.go. DO NOT EDIT.
+
+package xdr
+
+import "bytes"
+
+// Equals returns true if other is deeply equal to s.
+func (s Value) Equals(other Value) bool {
+	return bytes.Equal(s, other)
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpBallot) Equals(other ScpBallot) bool {
+	if s.Counter != other.Counter {
+		return false
+	}
+	if !s.Value.Equals(other.Value) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ScpStatementType) Equals(other ScpStatementType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpNomination) Equals(other ScpNomination) bool {
+	if s.QuorumSetHash != other.QuorumSetHash {
+		return false
+	}
+	if len(s.Votes) != len(other.Votes) {
+		return false
+	}
+	for i0 := range s.Votes {
+		if !s.Votes[i0].Equals(other.Votes[i0]) {
+			return false
+		}
+	}
+	if len(s.Accepted) != len(other.Accepted) {
+		return false
+	}
+	for i1 := range s.Accepted {
+		if !s.Accepted[i1].Equals(other.Accepted[i1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpStatementPrepare) Equals(other ScpStatementPrepare) bool {
+	if s.QuorumSetHash != other.QuorumSetHash {
+		return false
+	}
+	if !s.Ballot.Equals(other.Ballot) {
+		return false
+	}
+	if (s.Prepared == nil) != (other.Prepared == nil) {
+		return false
+	}
+	if s.Prepared != nil {
+		if !(*s.Prepared).Equals((*other.Prepared)) {
+			return false
+		}
+	}
+	if (s.PreparedPrime == nil) != (other.PreparedPrime == nil) {
+		return false
+	}
+	if s.PreparedPrime != nil {
+		if !(*s.PreparedPrime).Equals((*other.PreparedPrime)) {
+			return false
+		}
+	}
+	if s.NC != other.NC {
+		return false
+	}
+	if s.NH != other.NH {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpStatementConfirm) Equals(other ScpStatementConfirm) bool {
+	if !s.Ballot.Equals(other.Ballot) {
+		return false
+	}
+	if s.NPrepared != other.NPrepared {
+		return false
+	}
+	if s.NCommit != other.NCommit {
+		return false
+	}
+	if s.NH != other.NH {
+		return false
+	}
+	if s.QuorumSetHash != other.QuorumSetHash {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpStatementExternalize) Equals(other ScpStatementExternalize) bool {
+	if !s.Commit.Equals(other.Commit) {
+		return false
+	}
+	if s.NH != other.NH {
+		return false
+	}
+	if s.CommitQuorumSetHash != other.CommitQuorumSetHash {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpStatementPledges) Equals(other ScpStatementPledges) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Prepare":
+		if (s.Prepare == nil) != (other.Prepare == nil) {
+			return false
+		}
+		if s.Prepare != nil {
+			if !(*s.Prepare).Equals((*other.Prepare)) {
+				return false
+			}
+		}
+	case "Confirm":
+		if (s.Confirm == nil) != (other.Confirm == nil) {
+			return false
+		}
+		if s.Confirm != nil {
+			if !(*s.Confirm).Equals((*other.Confirm)) {
+				return false
+			}
+		}
+	case "Externalize":
+		if (s.Externalize == nil) != (other.Externalize == nil) {
+			return false
+		}
+		if s.Externalize != nil {
+			if !(*s.Externalize).Equals((*other.Externalize)) {
+				return false
+			}
+		}
+	case "Nominate":
+		if (s.Nominate == nil) != (other.Nominate == nil) {
+			return false
+		}
+		if s.Nominate != nil {
+			if !(*s.Nominate).Equals((*other.Nominate)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpStatement) Equals(other ScpStatement) bool {
+	if !s.NodeId.Equals(other.NodeId) {
+		return false
+	}
+	if s.SlotIndex != other.SlotIndex {
+		return false
+	}
+	if !s.Pledges.Equals(other.Pledges) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpEnvelope) Equals(other ScpEnvelope) bool {
+	if !s.Statement.Equals(other.Statement) {
+		return false
+	}
+	if !s.Signature.Equals(other.Signature) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpQuorumSet) Equals(other ScpQuorumSet) bool {
+	if s.Threshold != other.Threshold {
+		return false
+	}
+	if len(s.Validators) != len(other.Validators) {
+		return false
+	}
+	for i0 := range s.Validators {
+		if !s.Validators[i0].Equals(other.Validators[i0]) {
+			return false
+		}
+	}
+	if len(s.InnerSets) != len(other.InnerSets) {
+		return false
+	}
+	for i1 := range s.InnerSets {
+		if !s.InnerSets[i1].Equals(other.InnerSets[i1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Thresholds) Equals(other Thresholds) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s String32) Equals(other String32) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s String64) Equals(other String64) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s SequenceNumber) Equals(other SequenceNumber) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s TimePoint) Equals(other TimePoint) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s DataValue) Equals(other DataValue) bool {
+	return bytes.Equal(s, other)
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s PoolId) Equals(other PoolId) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s AssetCode4) Equals(other AssetCode4) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s AssetCode12) Equals(other AssetCode12) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s AssetType) Equals(other AssetType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AssetCode) Equals(other AssetCode) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "AssetCode4":
+		if (s.AssetCode4 == nil) != (other.AssetCode4 == nil) {
+			return false
+		}
+		if s.AssetCode4 != nil {
+			if (*s.AssetCode4) != (*other.AssetCode4) {
+				return false
+			}
+		}
+	case "AssetCode12":
+		if (s.AssetCode12 == nil) != (other.AssetCode12 == nil) {
+			return false
+		}
+		if s.AssetCode12 != nil {
+			if (*s.AssetCode12) != (*other.AssetCode12) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AlphaNum4) Equals(other AlphaNum4) bool {
+	if s.AssetCode != other.AssetCode {
+		return false
+	}
+	if !s.Issuer.Equals(other.Issuer) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AlphaNum12) Equals(other AlphaNum12) bool {
+	if s.AssetCode != other.AssetCode {
+		return false
+	}
+	if !s.Issuer.Equals(other.Issuer) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Price) Equals(other Price) bool {
+	if s.N != other.N {
+		return false
+	}
+	if s.D != other.D {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Liabilities) Equals(other Liabilities) bool {
+	if s.Buying != other.Buying {
+		return false
+	}
+	if s.Selling != other.Selling {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ThresholdIndexes) Equals(other ThresholdIndexes) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LedgerEntryType) Equals(other LedgerEntryType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Signer) Equals(other Signer) bool {
+	if !s.Key.Equals(other.Key) {
+		return false
+	}
+	if s.Weight != other.Weight {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s AccountFlags) Equals(other AccountFlags) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountEntryExtensionV2Ext) Equals(other AccountEntryExtensionV2Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountEntryExtensionV2) Equals(other AccountEntryExtensionV2) bool {
+	if s.NumSponsored != other.NumSponsored {
+		return false
+	}
+	if s.NumSponsoring != other.NumSponsoring {
+		return false
+	}
+	if len(s.SignerSponsoringIDs) != len(other.SignerSponsoringIDs) {
+		return false
+	}
+	for i0 := range s.SignerSponsoringIDs {
+		if (s.SignerSponsoringIDs[i0] == nil) != (other.SignerSponsoringIDs[i0] == nil) {
+			return false
+		}
+		if s.SignerSponsoringIDs[i0] != nil {
+			if !(*s.SignerSponsoringIDs[i0]).Equals((*other.SignerSponsoringIDs[i0])) {
+				return false
+			}
+		}
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountEntryExtensionV1Ext) Equals(other AccountEntryExtensionV1Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V2":
+		if (s.V2 == nil) != (other.V2 == nil) {
+			return false
+		}
+		if s.V2 != nil {
+			if !(*s.V2).Equals((*other.V2)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountEntryExtensionV1) Equals(other AccountEntryExtensionV1) bool {
+	if !s.Liabilities.Equals(other.Liabilities) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountEntryExt) Equals(other AccountEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountEntry) Equals(other AccountEntry) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	if s.Balance != other.Balance {
+		return false
+	}
+	if s.SeqNum != other.SeqNum {
+		return false
+	}
+	if s.NumSubEntries != other.NumSubEntries {
+		return false
+	}
+	if (s.InflationDest == nil) != (other.InflationDest == nil) {
+		return false
+	}
+	if s.InflationDest != nil {
+		if !(*s.InflationDest).Equals((*other.InflationDest)) {
+			return false
+		}
+	}
+	if s.Flags != other.Flags {
+		return false
+	}
+	if s.HomeDomain != other.HomeDomain {
+		return false
+	}
+	if s.Thresholds != other.Thresholds {
+		return false
+	}
+	if len(s.Signers) != len(other.Signers) {
+		return false
+	}
+	for i0 := range s.Signers {
+		if !s.Signers[i0].Equals(other.Signers[i0]) {
+			return false
+		}
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s TrustLineFlags) Equals(other TrustLineFlags) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LiquidityPoolType) Equals(other LiquidityPoolType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TrustLineEntryExtensionV2Ext) Equals(other TrustLineEntryExtensionV2Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TrustLineEntryExtensionV2) Equals(other TrustLineEntryExtensionV2) bool {
+	if s.LiquidityPoolUseCount != other.LiquidityPoolUseCount {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TrustLineEntryV1Ext) Equals(other TrustLineEntryV1Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V2":
+		if (s.V2 == nil) != (other.V2 == nil) {
+			return false
+		}
+		if s.V2 != nil {
+			if !(*s.V2).Equals((*other.V2)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TrustLineEntryV1) Equals(other TrustLineEntryV1) bool {
+	if !s.Liabilities.Equals(other.Liabilities) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TrustLineEntryExt) Equals(other TrustLineEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TrustLineEntry) Equals(other TrustLineEntry) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.Balance != other.Balance {
+		return false
+	}
+	if s.Limit != other.Limit {
+		return false
+	}
+	if s.Flags != other.Flags {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s OfferEntryFlags) Equals(other OfferEntryFlags) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *OfferEntryExt) Equals(other OfferEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *OfferEntry) Equals(other OfferEntry) bool {
+	if !s.SellerId.Equals(other.SellerId) {
+		return false
+	}
+	if s.OfferId != other.OfferId {
+		return false
+	}
+	if !s.Selling.Equals(other.Selling) {
+		return false
+	}
+	if !s.Buying.Equals(other.Buying) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	if !s.Price.Equals(other.Price) {
+		return false
+	}
+	if s.Flags != other.Flags {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *DataEntryExt) Equals(other DataEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *DataEntry) Equals(other DataEntry) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	if s.DataName != other.DataName {
+		return false
+	}
+	if !s.DataValue.Equals(other.DataValue) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClaimPredicateType) Equals(other ClaimPredicateType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimPredicate) Equals(other ClaimPredicate) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "AndPredicates":
+		if (s.AndPredicates == nil) != (other.AndPredicates == nil) {
+			return false
+		}
+		if s.AndPredicates != nil {
+			if len((*s.AndPredicates)) != len((*other.AndPredicates)) {
+				return false
+			}
+			for i0 := range *s.AndPredicates {
+				if !(*s.AndPredicates)[i0].Equals((*other.AndPredicates)[i0]) {
+					return false
+				}
+			}
+		}
+	case "OrPredicates":
+		if (s.OrPredicates == nil) != (other.OrPredicates == nil) {
+			return false
+		}
+		if s.OrPredicates != nil {
+			if len((*s.OrPredicates)) != len((*other.OrPredicates)) {
+				return false
+			}
+			for i1 := range *s.OrPredicates {
+				if !(*s.OrPredicates)[i1].Equals((*other.OrPredicates)[i1]) {
+					return false
+				}
+			}
+		}
+	case "NotPredicate":
+		if (s.NotPredicate == nil) != (other.NotPredicate == nil) {
+			return false
+		}
+		if s.NotPredicate != nil {
+			if ((*s.NotPredicate) == nil) != ((*other.NotPredicate) == nil) {
+				return false
+			}
+			if (*s.NotPredicate) != nil {
+				if !(*(*s.NotPredicate)).Equals((*(*other.NotPredicate))) {
+					return false
+				}
+			}
+		}
+	case "AbsBefore":
+		if (s.AbsBefore == nil) != (other.AbsBefore == nil) {
+			return false
+		}
+		if s.AbsBefore != nil {
+			if (*s.AbsBefore) != (*other.AbsBefore) {
+				return false
+			}
+		}
+	case "RelBefore":
+		if (s.RelBefore == nil) != (other.RelBefore == nil) {
+			return false
+		}
+		if s.RelBefore != nil {
+			if (*s.RelBefore) != (*other.RelBefore) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClaimantType) Equals(other ClaimantType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimantV0) Equals(other ClaimantV0) bool {
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if !s.Predicate.Equals(other.Predicate) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Claimant) Equals(other Claimant) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if !(*s.V0).Equals((*other.V0)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClaimableBalanceIdType) Equals(other ClaimableBalanceIdType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimableBalanceId) Equals(other ClaimableBalanceId) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if (*s.V0) != (*other.V0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClaimableBalanceFlags) Equals(other ClaimableBalanceFlags) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimableBalanceEntryExtensionV1Ext) Equals(other ClaimableBalanceEntryExtensionV1Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimableBalanceEntryExtensionV1) Equals(other ClaimableBalanceEntryExtensionV1) bool {
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	if s.Flags != other.Flags {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimableBalanceEntryExt) Equals(other ClaimableBalanceEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimableBalanceEntry) Equals(other ClaimableBalanceEntry) bool {
+	if !s.BalanceId.Equals(other.BalanceId) {
+		return false
+	}
+	if len(s.Claimants) != len(other.Claimants) {
+		return false
+	}
+	for i0 := range s.Claimants {
+		if !s.Claimants[i0].Equals(other.Claimants[i0]) {
+			return false
+		}
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolConstantProductParameters) Equals(other LiquidityPoolConstantProductParameters) bool {
+	if !s.AssetA.Equals(other.AssetA) {
+		return false
+	}
+	if !s.AssetB.Equals(other.AssetB) {
+		return false
+	}
+	if s.Fee != other.Fee {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolEntryConstantProduct) Equals(other LiquidityPoolEntryConstantProduct) bool {
+	if !s.Params.Equals(other.Params) {
+		return false
+	}
+	if s.ReserveA != other.ReserveA {
+		return false
+	}
+	if s.ReserveB != other.ReserveB {
+		return false
+	}
+	if s.TotalPoolShares != other.TotalPoolShares {
+		return false
+	}
+	if s.PoolSharesTrustLineCount != other.PoolSharesTrustLineCount {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolEntryBody) Equals(other LiquidityPoolEntryBody) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "ConstantProduct":
+		if (s.ConstantProduct == nil) != (other.ConstantProduct == nil) {
+			return false
+		}
+		if s.ConstantProduct != nil {
+			if !(*s.ConstantProduct).Equals((*other.ConstantProduct)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolEntry) Equals(other LiquidityPoolEntry) bool {
+	if s.LiquidityPoolId != other.LiquidityPoolId {
+		return false
+	}
+	if !s.Body.Equals(other.Body) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerEntryExtensionV1Ext) Equals(other LedgerEntryExtensionV1Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerEntryExtensionV1) Equals(other LedgerEntryExtensionV1) bool {
+	if (s.SponsoringId == nil) != (other.SponsoringId == nil) {
+		return false
+	}
+	if s.SponsoringId != nil {
+		if !(*s.SponsoringId).Equals((*other.SponsoringId)) {
+			return false
+		}
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerEntryData) Equals(other LedgerEntryData) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Account":
+		if (s.Account == nil) != (other.Account == nil) {
+			return false
+		}
+		if s.Account != nil {
+			if !(*s.Account).Equals((*other.Account)) {
+				return false
+			}
+		}
+	case "TrustLine":
+		if (s.TrustLine == nil) != (other.TrustLine == nil) {
+			return false
+		}
+		if s.TrustLine != nil {
+			if !(*s.TrustLine).Equals((*other.TrustLine)) {
+				return false
+			}
+		}
+	case "Offer":
+		if (s.Offer == nil) != (other.Offer == nil) {
+			return false
+		}
+		if s.Offer != nil {
+			if !(*s.Offer).Equals((*other.Offer)) {
+				return false
+			}
+		}
+	case "Data":
+		if (s.Data == nil) != (other.Data == nil) {
+			return false
+		}
+		if s.Data != nil {
+			if !(*s.Data).Equals((*other.Data)) {
+				return false
+			}
+		}
+	case "ClaimableBalance":
+		if (s.ClaimableBalance == nil) != (other.ClaimableBalance == nil) {
+			return false
+		}
+		if s.ClaimableBalance != nil {
+			if !(*s.ClaimableBalance).Equals((*other.ClaimableBalance)) {
+				return false
+			}
+		}
+	case "LiquidityPool":
+		if (s.LiquidityPool == nil) != (other.LiquidityPool == nil) {
+			return false
+		}
+		if s.LiquidityPool != nil {
+			if !(*s.LiquidityPool).Equals((*other.LiquidityPool)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerEntryExt) Equals(other LedgerEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerEntry) Equals(other LedgerEntry) bool {
+	if s.LastModifiedLedgerSeq != other.LastModifiedLedgerSeq {
+		return false
+	}
+	if !s.Data.Equals(other.Data) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerKeyAccount) Equals(other LedgerKeyAccount) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerKeyTrustLine) Equals(other LedgerKeyTrustLine) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerKeyOffer) Equals(other LedgerKeyOffer) bool {
+	if !s.SellerId.Equals(other.SellerId) {
+		return false
+	}
+	if s.OfferId != other.OfferId {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerKeyData) Equals(other LedgerKeyData) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	if s.DataName != other.DataName {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerKeyClaimableBalance) Equals(other LedgerKeyClaimableBalance) bool {
+	if !s.BalanceId.Equals(other.BalanceId) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerKeyLiquidityPool) Equals(other LedgerKeyLiquidityPool) bool {
+	if s.LiquidityPoolId != other.LiquidityPoolId {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s EnvelopeType) Equals(other EnvelopeType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s UpgradeType) Equals(other UpgradeType) bool {
+	return bytes.Equal(s, other)
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s StellarValueType) Equals(other StellarValueType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerCloseValueSignature) Equals(other LedgerCloseValueSignature) bool {
+	if !s.NodeId.Equals(other.NodeId) {
+		return false
+	}
+	if !s.Signature.Equals(other.Signature) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *StellarValueExt) Equals(other StellarValueExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "LcValueSignature":
+		if (s.LcValueSignature == nil) != (other.LcValueSignature == nil) {
+			return false
+		}
+		if s.LcValueSignature != nil {
+			if !(*s.LcValueSignature).Equals((*other.LcValueSignature)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *StellarValue) Equals(other StellarValue) bool {
+	if s.TxSetHash != other.TxSetHash {
+		return false
+	}
+	if s.CloseTime != other.CloseTime {
+		return false
+	}
+	if len(s.Upgrades) != len(other.Upgrades) {
+		return false
+	}
+	for i0 := range s.Upgrades {
+		if !s.Upgrades[i0].Equals(other.Upgrades[i0]) {
+			return false
+		}
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LedgerHeaderFlags) Equals(other LedgerHeaderFlags) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerHeaderExtensionV1Ext) Equals(other LedgerHeaderExtensionV1Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerHeaderExtensionV1) Equals(other LedgerHeaderExtensionV1) bool {
+	if s.Flags != other.Flags {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerHeaderExt) Equals(other LedgerHeaderExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerHeader) Equals(other LedgerHeader) bool {
+	if s.LedgerVersion != other.LedgerVersion {
+		return false
+	}
+	if s.PreviousLedgerHash != other.PreviousLedgerHash {
+		return false
+	}
+	if !s.ScpValue.Equals(other.ScpValue) {
+		return false
+	}
+	if s.TxSetResultHash != other.TxSetResultHash {
+		return false
+	}
+	if s.BucketListHash != other.BucketListHash {
+		return false
+	}
+	if s.LedgerSeq != other.LedgerSeq {
+		return false
+	}
+	if s.TotalCoins != other.TotalCoins {
+		return false
+	}
+	if s.FeePool != other.FeePool {
+		return false
+	}
+	if s.InflationSeq != other.InflationSeq {
+		return false
+	}
+	if s.IdPool != other.IdPool {
+		return false
+	}
+	if s.BaseFee != other.BaseFee {
+		return false
+	}
+	if s.BaseReserve != other.BaseReserve {
+		return false
+	}
+	if s.MaxTxSetSize != other.MaxTxSetSize {
+		return false
+	}
+	if s.SkipList != other.SkipList {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LedgerUpgradeType) Equals(other LedgerUpgradeType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerUpgrade) Equals(other LedgerUpgrade) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "NewLedgerVersion":
+		if (s.NewLedgerVersion == nil) != (other.NewLedgerVersion == nil) {
+			return false
+		}
+		if s.NewLedgerVersion != nil {
+			if (*s.NewLedgerVersion) != (*other.NewLedgerVersion) {
+				return false
+			}
+		}
+	case "NewBaseFee":
+		if (s.NewBaseFee == nil) != (other.NewBaseFee == nil) {
+			return false
+		}
+		if s.NewBaseFee != nil {
+			if (*s.NewBaseFee) != (*other.NewBaseFee) {
+				return false
+			}
+		}
+	case "NewMaxTxSetSize":
+		if (s.NewMaxTxSetSize == nil) != (other.NewMaxTxSetSize == nil) {
+			return false
+		}
+		if s.NewMaxTxSetSize != nil {
+			if (*s.NewMaxTxSetSize) != (*other.NewMaxTxSetSize) {
+				return false
+			}
+		}
+	case "NewBaseReserve":
+		if (s.NewBaseReserve == nil) != (other.NewBaseReserve == nil) {
+			return false
+		}
+		if s.NewBaseReserve != nil {
+			if (*s.NewBaseReserve) != (*other.NewBaseReserve) {
+				return false
+			}
+		}
+	case "NewFlags":
+		if (s.NewFlags == nil) != (other.NewFlags == nil) {
+			return false
+		}
+		if s.NewFlags != nil {
+			if (*s.NewFlags) != (*other.NewFlags) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s BucketEntryType) Equals(other BucketEntryType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BucketMetadataExt) Equals(other BucketMetadataExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BucketMetadata) Equals(other BucketMetadata) bool {
+	if s.LedgerVersion != other.LedgerVersion {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BucketEntry) Equals(other BucketEntry) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "LiveEntry":
+		if (s.LiveEntry == nil) != (other.LiveEntry == nil) {
+			return false
+		}
+		if s.LiveEntry != nil {
+			if !(*s.LiveEntry).Equals((*other.LiveEntry)) {
+				return false
+			}
+		}
+	case "DeadEntry":
+		if (s.DeadEntry == nil) != (other.DeadEntry == nil) {
+			return false
+		}
+		if s.DeadEntry != nil {
+			if !(*s.DeadEntry).Equals((*other.DeadEntry)) {
+				return false
+			}
+		}
+	case "MetaEntry":
+		if (s.MetaEntry == nil) != (other.MetaEntry == nil) {
+			return false
+		}
+		if s.MetaEntry != nil {
+			if !(*s.MetaEntry).Equals((*other.MetaEntry)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionSet) Equals(other TransactionSet) bool {
+	if s.PreviousLedgerHash != other.PreviousLedgerHash {
+		return false
+	}
+	if len(s.Txs) != len(other.Txs) {
+		return false
+	}
+	for i0 := range s.Txs {
+		if !s.Txs[i0].Equals(other.Txs[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionResultPair) Equals(other TransactionResultPair) bool {
+	if s.TransactionHash != other.TransactionHash {
+		return false
+	}
+	if !s.Result.Equals(other.Result) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionResultSet) Equals(other TransactionResultSet) bool {
+	if len(s.Results) != len(other.Results) {
+		return false
+	}
+	for i0 := range s.Results {
+		if !s.Results[i0].Equals(other.Results[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionHistoryEntryExt) Equals(other TransactionHistoryEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionHistoryEntry) Equals(other TransactionHistoryEntry) bool {
+	if s.LedgerSeq != other.LedgerSeq {
+		return false
+	}
+	if !s.TxSet.Equals(other.TxSet) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionHistoryResultEntryExt) Equals(other TransactionHistoryResultEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionHistoryResultEntry) Equals(other TransactionHistoryResultEntry) bool {
+	if s.LedgerSeq != other.LedgerSeq {
+		return false
+	}
+	if !s.TxResultSet.Equals(other.TxResultSet) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerHeaderHistoryEntryExt) Equals(other LedgerHeaderHistoryEntryExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerHeaderHistoryEntry) Equals(other LedgerHeaderHistoryEntry) bool {
+	if s.Hash != other.Hash {
+		return false
+	}
+	if !s.Header.Equals(other.Header) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerScpMessages) Equals(other LedgerScpMessages) bool {
+	if s.LedgerSeq != other.LedgerSeq {
+		return false
+	}
+	if len(s.Messages) != len(other.Messages) {
+		return false
+	}
+	for i0 := range s.Messages {
+		if !s.Messages[i0].Equals(other.Messages[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpHistoryEntryV0) Equals(other ScpHistoryEntryV0) bool {
+	if len(s.QuorumSets) != len(other.QuorumSets) {
+		return false
+	}
+	for i0 := range s.QuorumSets {
+		if !s.QuorumSets[i0].Equals(other.QuorumSets[i0]) {
+			return false
+		}
+	}
+	if !s.LedgerMessages.Equals(other.LedgerMessages) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ScpHistoryEntry) Equals(other ScpHistoryEntry) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if !(*s.V0).Equals((*other.V0)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LedgerEntryChangeType) Equals(other LedgerEntryChangeType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerEntryChange) Equals(other LedgerEntryChange) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Created":
+		if (s.Created == nil) != (other.Created == nil) {
+			return false
+		}
+		if s.Created != nil {
+			if !(*s.Created).Equals((*other.Created)) {
+				return false
+			}
+		}
+	case "Updated":
+		if (s.Updated == nil) != (other.Updated == nil) {
+			return false
+		}
+		if s.Updated != nil {
+			if !(*s.Updated).Equals((*other.Updated)) {
+				return false
+			}
+		}
+	case "Removed":
+		if (s.Removed == nil) != (other.Removed == nil) {
+			return false
+		}
+		if s.Removed != nil {
+			if !(*s.Removed).Equals((*other.Removed)) {
+				return false
+			}
+		}
+	case "State":
+		if (s.State == nil) != (other.State == nil) {
+			return false
+		}
+		if s.State != nil {
+			if !(*s.State).Equals((*other.State)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LedgerEntryChanges) Equals(other LedgerEntryChanges) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for i0 := range s {
+		if !s[i0].Equals(other[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *OperationMeta) Equals(other OperationMeta) bool {
+	if !s.Changes.Equals(other.Changes) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionMetaV1) Equals(other TransactionMetaV1) bool {
+	if !s.TxChanges.Equals(other.TxChanges) {
+		return false
+	}
+	if len(s.Operations) != len(other.Operations) {
+		return false
+	}
+	for i0 := range s.Operations {
+		if !s.Operations[i0].Equals(other.Operations[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionMetaV2) Equals(other TransactionMetaV2) bool {
+	if !s.TxChangesBefore.Equals(other.TxChangesBefore) {
+		return false
+	}
+	if len(s.Operations) != len(other.Operations) {
+		return false
+	}
+	for i0 := range s.Operations {
+		if !s.Operations[i0].Equals(other.Operations[i0]) {
+			return false
+		}
+	}
+	if !s.TxChangesAfter.Equals(other.TxChangesAfter) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionMeta) Equals(other TransactionMeta) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "Operations":
+		if (s.Operations == nil) != (other.Operations == nil) {
+			return false
+		}
+		if s.Operations != nil {
+			if len((*s.Operations)) != len((*other.Operations)) {
+				return false
+			}
+			for i0 := range *s.Operations {
+				if !(*s.Operations)[i0].Equals((*other.Operations)[i0]) {
+					return false
+				}
+			}
+		}
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	case "V2":
+		if (s.V2 == nil) != (other.V2 == nil) {
+			return false
+		}
+		if s.V2 != nil {
+			if !(*s.V2).Equals((*other.V2)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionResultMeta) Equals(other TransactionResultMeta) bool {
+	if !s.Result.Equals(other.Result) {
+		return false
+	}
+	if !s.FeeProcessing.Equals(other.FeeProcessing) {
+		return false
+	}
+	if !s.TxApplyProcessing.Equals(other.TxApplyProcessing) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *UpgradeEntryMeta) Equals(other UpgradeEntryMeta) bool {
+	if !s.Upgrade.Equals(other.Upgrade) {
+		return false
+	}
+	if !s.Changes.Equals(other.Changes) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerCloseMetaV0) Equals(other LedgerCloseMetaV0) bool {
+	if !s.LedgerHeader.Equals(other.LedgerHeader) {
+		return false
+	}
+	if !s.TxSet.Equals(other.TxSet) {
+		return false
+	}
+	if len(s.TxProcessing) != len(other.TxProcessing) {
+		return false
+	}
+	for i0 := range s.TxProcessing {
+		if !s.TxProcessing[i0].Equals(other.TxProcessing[i0]) {
+			return false
+		}
+	}
+	if len(s.UpgradesProcessing) != len(other.UpgradesProcessing) {
+		return false
+	}
+	for i1 := range s.UpgradesProcessing {
+		if !s.UpgradesProcessing[i1].Equals(other.UpgradesProcessing[i1]) {
+			return false
+		}
+	}
+	if len(s.ScpInfo) != len(other.ScpInfo) {
+		return false
+	}
+	for i2 := range s.ScpInfo {
+		if !s.ScpInfo[i2].Equals(other.ScpInfo[i2]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LedgerCloseMeta) Equals(other LedgerCloseMeta) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if !(*s.V0).Equals((*other.V0)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ErrorCode) Equals(other ErrorCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Error) Equals(other Error) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	if s.Msg != other.Msg {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AuthCert) Equals(other AuthCert) bool {
+	if !s.Pubkey.Equals(other.Pubkey) {
+		return false
+	}
+	if s.Expiration != other.Expiration {
+		return false
+	}
+	if !s.Sig.Equals(other.Sig) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Hello) Equals(other Hello) bool {
+	if s.LedgerVersion != other.LedgerVersion {
+		return false
+	}
+	if s.OverlayVersion != other.OverlayVersion {
+		return false
+	}
+	if s.OverlayMinVersion != other.OverlayMinVersion {
+		return false
+	}
+	if s.NetworkId != other.NetworkId {
+		return false
+	}
+	if s.VersionStr != other.VersionStr {
+		return false
+	}
+	if s.ListeningPort != other.ListeningPort {
+		return false
+	}
+	if !s.PeerId.Equals(other.PeerId) {
+		return false
+	}
+	if !s.Cert.Equals(other.Cert) {
+		return false
+	}
+	if s.Nonce != other.Nonce {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Auth) Equals(other Auth) bool {
+	if s.Unused != other.Unused {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s IpAddrType) Equals(other IpAddrType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PeerAddressIp) Equals(other PeerAddressIp) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Ipv4":
+		if (s.Ipv4 == nil) != (other.Ipv4 == nil) {
+			return false
+		}
+		if s.Ipv4 != nil {
+			if (*s.Ipv4) != (*other.Ipv4) {
+				return false
+			}
+		}
+	case "Ipv6":
+		if (s.Ipv6 == nil) != (other.Ipv6 == nil) {
+			return false
+		}
+		if s.Ipv6 != nil {
+			if (*s.Ipv6) != (*other.Ipv6) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PeerAddress) Equals(other PeerAddress) bool {
+	if !s.Ip.Equals(other.Ip) {
+		return false
+	}
+	if s.Port != other.Port {
+		return false
+	}
+	if s.NumFailures != other.NumFailures {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s MessageType) Equals(other MessageType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *DontHave) Equals(other DontHave) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	if s.ReqHash != other.ReqHash {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s SurveyMessageCommandType) Equals(other SurveyMessageCommandType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SurveyRequestMessage) Equals(other SurveyRequestMessage) bool {
+	if !s.SurveyorPeerId.Equals(other.SurveyorPeerId) {
+		return false
+	}
+	if !s.SurveyedPeerId.Equals(other.SurveyedPeerId) {
+		return false
+	}
+	if s.LedgerNum != other.LedgerNum {
+		return false
+	}
+	if !s.EncryptionKey.Equals(other.EncryptionKey) {
+		return false
+	}
+	if s.CommandType != other.CommandType {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SignedSurveyRequestMessage) Equals(other SignedSurveyRequestMessage) bool {
+	if !s.RequestSignature.Equals(other.RequestSignature) {
+		return false
+	}
+	if !s.Request.Equals(other.Request) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s EncryptedBody) Equals(other EncryptedBody) bool {
+	return bytes.Equal(s, other)
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SurveyResponseMessage) Equals(other SurveyResponseMessage) bool {
+	if !s.SurveyorPeerId.Equals(other.SurveyorPeerId) {
+		return false
+	}
+	if !s.SurveyedPeerId.Equals(other.SurveyedPeerId) {
+		return false
+	}
+	if s.LedgerNum != other.LedgerNum {
+		return false
+	}
+	if s.CommandType != other.CommandType {
+		return false
+	}
+	if !s.EncryptedBody.Equals(other.EncryptedBody) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SignedSurveyResponseMessage) Equals(other SignedSurveyResponseMessage) bool {
+	if !s.ResponseSignature.Equals(other.ResponseSignature) {
+		return false
+	}
+	if !s.Response.Equals(other.Response) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PeerStats) Equals(other PeerStats) bool {
+	if !s.Id.Equals(other.Id) {
+		return false
+	}
+	if s.VersionStr != other.VersionStr {
+		return false
+	}
+	if s.MessagesRead != other.MessagesRead {
+		return false
+	}
+	if s.MessagesWritten != other.MessagesWritten {
+		return false
+	}
+	if s.BytesRead != other.BytesRead {
+		return false
+	}
+	if s.BytesWritten != other.BytesWritten {
+		return false
+	}
+	if s.SecondsConnected != other.SecondsConnected {
+		return false
+	}
+	if s.UniqueFloodBytesRecv != other.UniqueFloodBytesRecv {
+		return false
+	}
+	if s.DuplicateFloodBytesRecv != other.DuplicateFloodBytesRecv {
+		return false
+	}
+	if s.UniqueFetchBytesRecv != other.UniqueFetchBytesRecv {
+		return false
+	}
+	if s.DuplicateFetchBytesRecv != other.DuplicateFetchBytesRecv {
+		return false
+	}
+	if s.UniqueFloodMessageRecv != other.UniqueFloodMessageRecv {
+		return false
+	}
+	if s.DuplicateFloodMessageRecv != other.DuplicateFloodMessageRecv {
+		return false
+	}
+	if s.UniqueFetchMessageRecv != other.UniqueFetchMessageRecv {
+		return false
+	}
+	if s.DuplicateFetchMessageRecv != other.DuplicateFetchMessageRecv {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s PeerStatList) Equals(other PeerStatList) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for i0 := range s {
+		if !s[i0].Equals(other[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TopologyResponseBody) Equals(other TopologyResponseBody) bool {
+	if !s.InboundPeers.Equals(other.InboundPeers) {
+		return false
+	}
+	if !s.OutboundPeers.Equals(other.OutboundPeers) {
+		return false
+	}
+	if s.TotalInboundPeerCount != other.TotalInboundPeerCount {
+		return false
+	}
+	if s.TotalOutboundPeerCount != other.TotalOutboundPeerCount {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SurveyResponseBody) Equals(other SurveyResponseBody) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "TopologyResponseBody":
+		if (s.TopologyResponseBody == nil) != (other.TopologyResponseBody == nil) {
+			return false
+		}
+		if s.TopologyResponseBody != nil {
+			if !(*s.TopologyResponseBody).Equals((*other.TopologyResponseBody)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *StellarMessage) Equals(other StellarMessage) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Error":
+		if (s.Error == nil) != (other.Error == nil) {
+			return false
+		}
+		if s.Error != nil {
+			if !(*s.Error).Equals((*other.Error)) {
+				return false
+			}
+		}
+	case "Hello":
+		if (s.Hello == nil) != (other.Hello == nil) {
+			return false
+		}
+		if s.Hello != nil {
+			if !(*s.Hello).Equals((*other.Hello)) {
+				return false
+			}
+		}
+	case "Auth":
+		if (s.Auth == nil) != (other.Auth == nil) {
+			return false
+		}
+		if s.Auth != nil {
+			if !(*s.Auth).Equals((*other.Auth)) {
+				return false
+			}
+		}
+	case "DontHave":
+		if (s.DontHave == nil) != (other.DontHave == nil) {
+			return false
+		}
+		if s.DontHave != nil {
+			if !(*s.DontHave).Equals((*other.DontHave)) {
+				return false
+			}
+		}
+	case "Peers":
+		if (s.Peers == nil) != (other.Peers == nil) {
+			return false
+		}
+		if s.Peers != nil {
+			if len((*s.Peers)) != len((*other.Peers)) {
+				return false
+			}
+			for i0 := range *s.Peers {
+				if !(*s.Peers)[i0].Equals((*other.Peers)[i0]) {
+					return false
+				}
+			}
+		}
+	case "TxSetHash":
+		if (s.TxSetHash == nil) != (other.TxSetHash == nil) {
+			return false
+		}
+		if s.TxSetHash != nil {
+			if (*s.TxSetHash) != (*other.TxSetHash) {
+				return false
+			}
+		}
+	case "TxSet":
+		if (s.TxSet == nil) != (other.TxSet == nil) {
+			return false
+		}
+		if s.TxSet != nil {
+			if !(*s.TxSet).Equals((*other.TxSet)) {
+				return false
+			}
+		}
+	case "Transaction":
+		if (s.Transaction == nil) != (other.Transaction == nil) {
+			return false
+		}
+		if s.Transaction != nil {
+			if !(*s.Transaction).Equals((*other.Transaction)) {
+				return false
+			}
+		}
+	case "SignedSurveyRequestMessage":
+		if (s.SignedSurveyRequestMessage == nil) != (other.SignedSurveyRequestMessage == nil) {
+			return false
+		}
+		if s.SignedSurveyRequestMessage != nil {
+			if !(*s.SignedSurveyRequestMessage).Equals((*other.SignedSurveyRequestMessage)) {
+				return false
+			}
+		}
+	case "SignedSurveyResponseMessage":
+		if (s.SignedSurveyResponseMessage == nil) != (other.SignedSurveyResponseMessage == nil) {
+			return false
+		}
+		if s.SignedSurveyResponseMessage != nil {
+			if !(*s.SignedSurveyResponseMessage).Equals((*other.SignedSurveyResponseMessage)) {
+				return false
+			}
+		}
+	case "QSetHash":
+		if (s.QSetHash == nil) != (other.QSetHash == nil) {
+			return false
+		}
+		if s.QSetHash != nil {
+			if (*s.QSetHash) != (*other.QSetHash) {
+				return false
+			}
+		}
+	case "QSet":
+		if (s.QSet == nil) != (other.QSet == nil) {
+			return false
+		}
+		if s.QSet != nil {
+			if !(*s.QSet).Equals((*other.QSet)) {
+				return false
+			}
+		}
+	case "Envelope":
+		if (s.Envelope == nil) != (other.Envelope == nil) {
+			return false
+		}
+		if s.Envelope != nil {
+			if !(*s.Envelope).Equals((*other.Envelope)) {
+				return false
+			}
+		}
+	case "GetScpLedgerSeq":
+		if (s.GetScpLedgerSeq == nil) != (other.GetScpLedgerSeq == nil) {
+			return false
+		}
+		if s.GetScpLedgerSeq != nil {
+			if (*s.GetScpLedgerSeq) != (*other.GetScpLedgerSeq) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AuthenticatedMessageV0) Equals(other AuthenticatedMessageV0) bool {
+	if s.Sequence != other.Sequence {
+		return false
+	}
+	if !s.Message.Equals(other.Message) {
+		return false
+	}
+	if !s.Mac.Equals(other.Mac) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AuthenticatedMessage) Equals(other AuthenticatedMessage) bool {
+	if s.V != other.V {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.V))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if !(*s.V0).Equals((*other.V0)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolParameters) Equals(other LiquidityPoolParameters) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "ConstantProduct":
+		if (s.ConstantProduct == nil) != (other.ConstantProduct == nil) {
+			return false
+		}
+		if s.ConstantProduct != nil {
+			if !(*s.ConstantProduct).Equals((*other.ConstantProduct)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *MuxedAccountMed25519) Equals(other MuxedAccountMed25519) bool {
+	if s.Id != other.Id {
+		return false
+	}
+	if s.Ed25519 != other.Ed25519 {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *MuxedAccount) Equals(other MuxedAccount) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Ed25519":
+		if (s.Ed25519 == nil) != (other.Ed25519 == nil) {
+			return false
+		}
+		if s.Ed25519 != nil {
+			if (*s.Ed25519) != (*other.Ed25519) {
+				return false
+			}
+		}
+	case "Med25519":
+		if (s.Med25519 == nil) != (other.Med25519 == nil) {
+			return false
+		}
+		if s.Med25519 != nil {
+			if !(*s.Med25519).Equals((*other.Med25519)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *DecoratedSignature) Equals(other DecoratedSignature) bool {
+	if s.Hint != other.Hint {
+		return false
+	}
+	if !s.Signature.Equals(other.Signature) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s OperationType) Equals(other OperationType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *CreateAccountOp) Equals(other CreateAccountOp) bool {
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if s.StartingBalance != other.StartingBalance {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PaymentOp) Equals(other PaymentOp) bool {
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PathPaymentStrictReceiveOp) Equals(other PathPaymentStrictReceiveOp) bool {
+	if !s.SendAsset.Equals(other.SendAsset) {
+		return false
+	}
+	if s.SendMax != other.SendMax {
+		return false
+	}
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if !s.DestAsset.Equals(other.DestAsset) {
+		return false
+	}
+	if s.DestAmount != other.DestAmount {
+		return false
+	}
+	if len(s.Path) != len(other.Path) {
+		return false
+	}
+	for i0 := range s.Path {
+		if !s.Path[i0].Equals(other.Path[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PathPaymentStrictSendOp) Equals(other PathPaymentStrictSendOp) bool {
+	if !s.SendAsset.Equals(other.SendAsset) {
+		return false
+	}
+	if s.SendAmount != other.SendAmount {
+		return false
+	}
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if !s.DestAsset.Equals(other.DestAsset) {
+		return false
+	}
+	if s.DestMin != other.DestMin {
+		return false
+	}
+	if len(s.Path) != len(other.Path) {
+		return false
+	}
+	for i0 := range s.Path {
+		if !s.Path[i0].Equals(other.Path[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageSellOfferOp) Equals(other ManageSellOfferOp) bool {
+	if !s.Selling.Equals(other.Selling) {
+		return false
+	}
+	if !s.Buying.Equals(other.Buying) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	if !s.Price.Equals(other.Price) {
+		return false
+	}
+	if s.OfferId != other.OfferId {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageBuyOfferOp) Equals(other ManageBuyOfferOp) bool {
+	if !s.Selling.Equals(other.Selling) {
+		return false
+	}
+	if !s.Buying.Equals(other.Buying) {
+		return false
+	}
+	if s.BuyAmount != other.BuyAmount {
+		return false
+	}
+	if !s.Price.Equals(other.Price) {
+		return false
+	}
+	if s.OfferId != other.OfferId {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *CreatePassiveSellOfferOp) Equals(other CreatePassiveSellOfferOp) bool {
+	if !s.Selling.Equals(other.Selling) {
+		return false
+	}
+	if !s.Buying.Equals(other.Buying) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	if !s.Price.Equals(other.Price) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SetOptionsOp) Equals(other SetOptionsOp) bool {
+	if (s.InflationDest == nil) != (other.InflationDest == nil) {
+		return false
+	}
+	if s.InflationDest != nil {
+		if !(*s.InflationDest).Equals((*other.InflationDest)) {
+			return false
+		}
+	}
+	if (s.ClearFlags == nil) != (other.ClearFlags == nil) {
+		return false
+	}
+	if s.ClearFlags != nil {
+		if (*s.ClearFlags) != (*other.ClearFlags) {
+			return false
+		}
+	}
+	if (s.SetFlags == nil) != (other.SetFlags == nil) {
+		return false
+	}
+	if s.SetFlags != nil {
+		if (*s.SetFlags) != (*other.SetFlags) {
+			return false
+		}
+	}
+	if (s.MasterWeight == nil) != (other.MasterWeight == nil) {
+		return false
+	}
+	if s.MasterWeight != nil {
+		if (*s.MasterWeight) != (*other.MasterWeight) {
+			return false
+		}
+	}
+	if (s.LowThreshold == nil) != (other.LowThreshold == nil) {
+		return false
+	}
+	if s.LowThreshold != nil {
+		if (*s.LowThreshold) != (*other.LowThreshold) {
+			return false
+		}
+	}
+	if (s.MedThreshold == nil) != (other.MedThreshold == nil) {
+		return false
+	}
+	if s.MedThreshold != nil {
+		if (*s.MedThreshold) != (*other.MedThreshold) {
+			return false
+		}
+	}
+	if (s.HighThreshold == nil) != (other.HighThreshold == nil) {
+		return false
+	}
+	if s.HighThreshold != nil {
+		if (*s.HighThreshold) != (*other.HighThreshold) {
+			return false
+		}
+	}
+	if (s.HomeDomain == nil) != (other.HomeDomain == nil) {
+		return false
+	}
+	if s.HomeDomain != nil {
+		if (*s.HomeDomain) != (*other.HomeDomain) {
+			return false
+		}
+	}
+	if (s.Signer == nil) != (other.Signer == nil) {
+		return false
+	}
+	if s.Signer != nil {
+		if !(*s.Signer).Equals((*other.Signer)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ChangeTrustAsset) Equals(other ChangeTrustAsset) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "AlphaNum4":
+		if (s.AlphaNum4 == nil) != (other.AlphaNum4 == nil) {
+			return false
+		}
+		if s.AlphaNum4 != nil {
+			if !(*s.AlphaNum4).Equals((*other.AlphaNum4)) {
+				return false
+			}
+		}
+	case "AlphaNum12":
+		if (s.AlphaNum12 == nil) != (other.AlphaNum12 == nil) {
+			return false
+		}
+		if s.AlphaNum12 != nil {
+			if !(*s.AlphaNum12).Equals((*other.AlphaNum12)) {
+				return false
+			}
+		}
+	case "LiquidityPool":
+		if (s.LiquidityPool == nil) != (other.LiquidityPool == nil) {
+			return false
+		}
+		if s.LiquidityPool != nil {
+			if !(*s.LiquidityPool).Equals((*other.LiquidityPool)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ChangeTrustOp) Equals(other ChangeTrustOp) bool {
+	if !s.Line.Equals(other.Line) {
+		return false
+	}
+	if s.Limit != other.Limit {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AllowTrustOp) Equals(other AllowTrustOp) bool {
+	if !s.Trustor.Equals(other.Trustor) {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.Authorize != other.Authorize {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageDataOp) Equals(other ManageDataOp) bool {
+	if s.DataName != other.DataName {
+		return false
+	}
+	if (s.DataValue == nil) != (other.DataValue == nil) {
+		return false
+	}
+	if s.DataValue != nil {
+		if !(*s.DataValue).Equals((*other.DataValue)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BumpSequenceOp) Equals(other BumpSequenceOp) bool {
+	if s.BumpTo != other.BumpTo {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *CreateClaimableBalanceOp) Equals(other CreateClaimableBalanceOp) bool {
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	if len(s.Claimants) != len(other.Claimants) {
+		return false
+	}
+	for i0 := range s.Claimants {
+		if !s.Claimants[i0].Equals(other.Claimants[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimClaimableBalanceOp) Equals(other ClaimClaimableBalanceOp) bool {
+	if !s.BalanceId.Equals(other.BalanceId) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BeginSponsoringFutureReservesOp) Equals(other BeginSponsoringFutureReservesOp) bool {
+	if !s.SponsoredId.Equals(other.SponsoredId) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s RevokeSponsorshipType) Equals(other RevokeSponsorshipType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *RevokeSponsorshipOpSigner) Equals(other RevokeSponsorshipOpSigner) bool {
+	if !s.AccountId.Equals(other.AccountId) {
+		return false
+	}
+	if !s.SignerKey.Equals(other.SignerKey) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *RevokeSponsorshipOp) Equals(other RevokeSponsorshipOp) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "LedgerKey":
+		if (s.LedgerKey == nil) != (other.LedgerKey == nil) {
+			return false
+		}
+		if s.LedgerKey != nil {
+			if !(*s.LedgerKey).Equals((*other.LedgerKey)) {
+				return false
+			}
+		}
+	case "Signer":
+		if (s.Signer == nil) != (other.Signer == nil) {
+			return false
+		}
+		if s.Signer != nil {
+			if !(*s.Signer).Equals((*other.Signer)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClawbackOp) Equals(other ClawbackOp) bool {
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if !s.From.Equals(other.From) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClawbackClaimableBalanceOp) Equals(other ClawbackClaimableBalanceOp) bool {
+	if !s.BalanceId.Equals(other.BalanceId) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SetTrustLineFlagsOp) Equals(other SetTrustLineFlagsOp) bool {
+	if !s.Trustor.Equals(other.Trustor) {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.ClearFlags != other.ClearFlags {
+		return false
+	}
+	if s.SetFlags != other.SetFlags {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolDepositOp) Equals(other LiquidityPoolDepositOp) bool {
+	if s.LiquidityPoolId != other.LiquidityPoolId {
+		return false
+	}
+	if s.MaxAmountA != other.MaxAmountA {
+		return false
+	}
+	if s.MaxAmountB != other.MaxAmountB {
+		return false
+	}
+	if !s.MinPrice.Equals(other.MinPrice) {
+		return false
+	}
+	if !s.MaxPrice.Equals(other.MaxPrice) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolWithdrawOp) Equals(other LiquidityPoolWithdrawOp) bool {
+	if s.LiquidityPoolId != other.LiquidityPoolId {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	if s.MinAmountA != other.MinAmountA {
+		return false
+	}
+	if s.MinAmountB != other.MinAmountB {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *OperationBody) Equals(other OperationBody) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "CreateAccountOp":
+		if (s.CreateAccountOp == nil) != (other.CreateAccountOp == nil) {
+			return false
+		}
+		if s.CreateAccountOp != nil {
+			if !(*s.CreateAccountOp).Equals((*other.CreateAccountOp)) {
+				return false
+			}
+		}
+	case "PaymentOp":
+		if (s.PaymentOp == nil) != (other.PaymentOp == nil) {
+			return false
+		}
+		if s.PaymentOp != nil {
+			if !(*s.PaymentOp).Equals((*other.PaymentOp)) {
+				return false
+			}
+		}
+	case "PathPaymentStrictReceiveOp":
+		if (s.PathPaymentStrictReceiveOp == nil) != (other.PathPaymentStrictReceiveOp == nil) {
+			return false
+		}
+		if s.PathPaymentStrictReceiveOp != nil {
+			if !(*s.PathPaymentStrictReceiveOp).Equals((*other.PathPaymentStrictReceiveOp)) {
+				return false
+			}
+		}
+	case "ManageSellOfferOp":
+		if (s.ManageSellOfferOp == nil) != (other.ManageSellOfferOp == nil) {
+			return false
+		}
+		if s.ManageSellOfferOp != nil {
+			if !(*s.ManageSellOfferOp).Equals((*other.ManageSellOfferOp)) {
+				return false
+			}
+		}
+	case "CreatePassiveSellOfferOp":
+		if (s.CreatePassiveSellOfferOp == nil) != (other.CreatePassiveSellOfferOp == nil) {
+			return false
+		}
+		if s.CreatePassiveSellOfferOp != nil {
+			if !(*s.CreatePassiveSellOfferOp).Equals((*other.CreatePassiveSellOfferOp)) {
+				return false
+			}
+		}
+	case "SetOptionsOp":
+		if (s.SetOptionsOp == nil) != (other.SetOptionsOp == nil) {
+			return false
+		}
+		if s.SetOptionsOp != nil {
+			if !(*s.SetOptionsOp).Equals((*other.SetOptionsOp)) {
+				return false
+			}
+		}
+	case "ChangeTrustOp":
+		if (s.ChangeTrustOp == nil) != (other.ChangeTrustOp == nil) {
+			return false
+		}
+		if s.ChangeTrustOp != nil {
+			if !(*s.ChangeTrustOp).Equals((*other.ChangeTrustOp)) {
+				return false
+			}
+		}
+	case "AllowTrustOp":
+		if (s.AllowTrustOp == nil) != (other.AllowTrustOp == nil) {
+			return false
+		}
+		if s.AllowTrustOp != nil {
+			if !(*s.AllowTrustOp).Equals((*other.AllowTrustOp)) {
+				return false
+			}
+		}
+	case "Destination":
+		if (s.Destination == nil) != (other.Destination == nil) {
+			return false
+		}
+		if s.Destination != nil {
+			if !(*s.Destination).Equals((*other.Destination)) {
+				return false
+			}
+		}
+	case "ManageDataOp":
+		if (s.ManageDataOp == nil) != (other.ManageDataOp == nil) {
+			return false
+		}
+		if s.ManageDataOp != nil {
+			if !(*s.ManageDataOp).Equals((*other.ManageDataOp)) {
+				return false
+			}
+		}
+	case "BumpSequenceOp":
+		if (s.BumpSequenceOp == nil) != (other.BumpSequenceOp == nil) {
+			return false
+		}
+		if s.BumpSequenceOp != nil {
+			if !(*s.BumpSequenceOp).Equals((*other.BumpSequenceOp)) {
+				return false
+			}
+		}
+	case "ManageBuyOfferOp":
+		if (s.ManageBuyOfferOp == nil) != (other.ManageBuyOfferOp == nil) {
+			return false
+		}
+		if s.ManageBuyOfferOp != nil {
+			if !(*s.ManageBuyOfferOp).Equals((*other.ManageBuyOfferOp)) {
+				return false
+			}
+		}
+	case "PathPaymentStrictSendOp":
+		if (s.PathPaymentStrictSendOp == nil) != (other.PathPaymentStrictSendOp == nil) {
+			return false
+		}
+		if s.PathPaymentStrictSendOp != nil {
+			if !(*s.PathPaymentStrictSendOp).Equals((*other.PathPaymentStrictSendOp)) {
+				return false
+			}
+		}
+	case "CreateClaimableBalanceOp":
+		if (s.CreateClaimableBalanceOp == nil) != (other.CreateClaimableBalanceOp == nil) {
+			return false
+		}
+		if s.CreateClaimableBalanceOp != nil {
+			if !(*s.CreateClaimableBalanceOp).Equals((*other.CreateClaimableBalanceOp)) {
+				return false
+			}
+		}
+	case "ClaimClaimableBalanceOp":
+		if (s.ClaimClaimableBalanceOp == nil) != (other.ClaimClaimableBalanceOp == nil) {
+			return false
+		}
+		if s.ClaimClaimableBalanceOp != nil {
+			if !(*s.ClaimClaimableBalanceOp).Equals((*other.ClaimClaimableBalanceOp)) {
+				return false
+			}
+		}
+	case "BeginSponsoringFutureReservesOp":
+		if (s.BeginSponsoringFutureReservesOp == nil) != (other.BeginSponsoringFutureReservesOp == nil) {
+			return false
+		}
+		if s.BeginSponsoringFutureReservesOp != nil {
+			if !(*s.BeginSponsoringFutureReservesOp).Equals((*other.BeginSponsoringFutureReservesOp)) {
+				return false
+			}
+		}
+	case "RevokeSponsorshipOp":
+		if (s.RevokeSponsorshipOp == nil) != (other.RevokeSponsorshipOp == nil) {
+			return false
+		}
+		if s.RevokeSponsorshipOp != nil {
+			if !(*s.RevokeSponsorshipOp).Equals((*other.RevokeSponsorshipOp)) {
+				return false
+			}
+		}
+	case "ClawbackOp":
+		if (s.ClawbackOp == nil) != (other.ClawbackOp == nil) {
+			return false
+		}
+		if s.ClawbackOp != nil {
+			if !(*s.ClawbackOp).Equals((*other.ClawbackOp)) {
+				return false
+			}
+		}
+	case "ClawbackClaimableBalanceOp":
+		if (s.ClawbackClaimableBalanceOp == nil) != (other.ClawbackClaimableBalanceOp == nil) {
+			return false
+		}
+		if s.ClawbackClaimableBalanceOp != nil {
+			if !(*s.ClawbackClaimableBalanceOp).Equals((*other.ClawbackClaimableBalanceOp)) {
+				return false
+			}
+		}
+	case "SetTrustLineFlagsOp":
+		if (s.SetTrustLineFlagsOp == nil) != (other.SetTrustLineFlagsOp == nil) {
+			return false
+		}
+		if s.SetTrustLineFlagsOp != nil {
+			if !(*s.SetTrustLineFlagsOp).Equals((*other.SetTrustLineFlagsOp)) {
+				return false
+			}
+		}
+	case "LiquidityPoolDepositOp":
+		if (s.LiquidityPoolDepositOp == nil) != (other.LiquidityPoolDepositOp == nil) {
+			return false
+		}
+		if s.LiquidityPoolDepositOp != nil {
+			if !(*s.LiquidityPoolDepositOp).Equals((*other.LiquidityPoolDepositOp)) {
+				return false
+			}
+		}
+	case "LiquidityPoolWithdrawOp":
+		if (s.LiquidityPoolWithdrawOp == nil) != (other.LiquidityPoolWithdrawOp == nil) {
+			return false
+		}
+		if s.LiquidityPoolWithdrawOp != nil {
+			if !(*s.LiquidityPoolWithdrawOp).Equals((*other.LiquidityPoolWithdrawOp)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Operation) Equals(other Operation) bool {
+	if (s.SourceAccount == nil) != (other.SourceAccount == nil) {
+		return false
+	}
+	if s.SourceAccount != nil {
+		if !(*s.SourceAccount).Equals((*other.SourceAccount)) {
+			return false
+		}
+	}
+	if !s.Body.Equals(other.Body) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *HashIdPreimageOperationId) Equals(other HashIdPreimageOperationId) bool {
+	if !s.SourceAccount.Equals(other.SourceAccount) {
+		return false
+	}
+	if s.SeqNum != other.SeqNum {
+		return false
+	}
+	if s.OpNum != other.OpNum {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *HashIdPreimageRevokeId) Equals(other HashIdPreimageRevokeId) bool {
+	if !s.SourceAccount.Equals(other.SourceAccount) {
+		return false
+	}
+	if s.SeqNum != other.SeqNum {
+		return false
+	}
+	if s.OpNum != other.OpNum {
+		return false
+	}
+	if s.LiquidityPoolId != other.LiquidityPoolId {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *HashIdPreimage) Equals(other HashIdPreimage) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "OperationId":
+		if (s.OperationId == nil) != (other.OperationId == nil) {
+			return false
+		}
+		if s.OperationId != nil {
+			if !(*s.OperationId).Equals((*other.OperationId)) {
+				return false
+			}
+		}
+	case "RevokeId":
+		if (s.RevokeId == nil) != (other.RevokeId == nil) {
+			return false
+		}
+		if s.RevokeId != nil {
+			if !(*s.RevokeId).Equals((*other.RevokeId)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s MemoType) Equals(other MemoType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Memo) Equals(other Memo) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Text":
+		if (s.Text == nil) != (other.Text == nil) {
+			return false
+		}
+		if s.Text != nil {
+			if (*s.Text) != (*other.Text) {
+				return false
+			}
+		}
+	case "Id":
+		if (s.Id == nil) != (other.Id == nil) {
+			return false
+		}
+		if s.Id != nil {
+			if (*s.Id) != (*other.Id) {
+				return false
+			}
+		}
+	case "Hash":
+		if (s.Hash == nil) != (other.Hash == nil) {
+			return false
+		}
+		if s.Hash != nil {
+			if (*s.Hash) != (*other.Hash) {
+				return false
+			}
+		}
+	case "RetHash":
+		if (s.RetHash == nil) != (other.RetHash == nil) {
+			return false
+		}
+		if s.RetHash != nil {
+			if (*s.RetHash) != (*other.RetHash) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TimeBounds) Equals(other TimeBounds) bool {
+	if s.MinTime != other.MinTime {
+		return false
+	}
+	if s.MaxTime != other.MaxTime {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionV0Ext) Equals(other TransactionV0Ext) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionV0) Equals(other TransactionV0) bool {
+	if s.SourceAccountEd25519 != other.SourceAccountEd25519 {
+		return false
+	}
+	if s.Fee != other.Fee {
+		return false
+	}
+	if s.SeqNum != other.SeqNum {
+		return false
+	}
+	if (s.TimeBounds == nil) != (other.TimeBounds == nil) {
+		return false
+	}
+	if s.TimeBounds != nil {
+		if !(*s.TimeBounds).Equals((*other.TimeBounds)) {
+			return false
+		}
+	}
+	if !s.Memo.Equals(other.Memo) {
+		return false
+	}
+	if len(s.Operations) != len(other.Operations) {
+		return false
+	}
+	for i0 := range s.Operations {
+		if !s.Operations[i0].Equals(other.Operations[i0]) {
+			return false
+		}
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionV0Envelope) Equals(other TransactionV0Envelope) bool {
+	if !s.Tx.Equals(other.Tx) {
+		return false
+	}
+	if len(s.Signatures) != len(other.Signatures) {
+		return false
+	}
+	for i0 := range s.Signatures {
+		if !s.Signatures[i0].Equals(other.Signatures[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionExt) Equals(other TransactionExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Transaction) Equals(other Transaction) bool {
+	if !s.SourceAccount.Equals(other.SourceAccount) {
+		return false
+	}
+	if s.Fee != other.Fee {
+		return false
+	}
+	if s.SeqNum != other.SeqNum {
+		return false
+	}
+	if (s.TimeBounds == nil) != (other.TimeBounds == nil) {
+		return false
+	}
+	if s.TimeBounds != nil {
+		if !(*s.TimeBounds).Equals((*other.TimeBounds)) {
+			return false
+		}
+	}
+	if !s.Memo.Equals(other.Memo) {
+		return false
+	}
+	if len(s.Operations) != len(other.Operations) {
+		return false
+	}
+	for i0 := range s.Operations {
+		if !s.Operations[i0].Equals(other.Operations[i0]) {
+			return false
+		}
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionV1Envelope) Equals(other TransactionV1Envelope) bool {
+	if !s.Tx.Equals(other.Tx) {
+		return false
+	}
+	if len(s.Signatures) != len(other.Signatures) {
+		return false
+	}
+	for i0 := range s.Signatures {
+		if !s.Signatures[i0].Equals(other.Signatures[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *FeeBumpTransactionInnerTx) Equals(other FeeBumpTransactionInnerTx) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *FeeBumpTransactionExt) Equals(other FeeBumpTransactionExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *FeeBumpTransaction) Equals(other FeeBumpTransaction) bool {
+	if !s.FeeSource.Equals(other.FeeSource) {
+		return false
+	}
+	if s.Fee != other.Fee {
+		return false
+	}
+	if !s.InnerTx.Equals(other.InnerTx) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *FeeBumpTransactionEnvelope) Equals(other FeeBumpTransactionEnvelope) bool {
+	if !s.Tx.Equals(other.Tx) {
+		return false
+	}
+	if len(s.Signatures) != len(other.Signatures) {
+		return false
+	}
+	for i0 := range s.Signatures {
+		if !s.Signatures[i0].Equals(other.Signatures[i0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionEnvelope) Equals(other TransactionEnvelope) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if !(*s.V0).Equals((*other.V0)) {
+				return false
+			}
+		}
+	case "V1":
+		if (s.V1 == nil) != (other.V1 == nil) {
+			return false
+		}
+		if s.V1 != nil {
+			if !(*s.V1).Equals((*other.V1)) {
+				return false
+			}
+		}
+	case "FeeBump":
+		if (s.FeeBump == nil) != (other.FeeBump == nil) {
+			return false
+		}
+		if s.FeeBump != nil {
+			if !(*s.FeeBump).Equals((*other.FeeBump)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionSignaturePayloadTaggedTransaction) Equals(other TransactionSignaturePayloadTaggedTransaction) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Tx":
+		if (s.Tx == nil) != (other.Tx == nil) {
+			return false
+		}
+		if s.Tx != nil {
+			if !(*s.Tx).Equals((*other.Tx)) {
+				return false
+			}
+		}
+	case "FeeBump":
+		if (s.FeeBump == nil) != (other.FeeBump == nil) {
+			return false
+		}
+		if s.FeeBump != nil {
+			if !(*s.FeeBump).Equals((*other.FeeBump)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionSignaturePayload) Equals(other TransactionSignaturePayload) bool {
+	if s.NetworkId != other.NetworkId {
+		return false
+	}
+	if !s.TaggedTransaction.Equals(other.TaggedTransaction) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClaimAtomType) Equals(other ClaimAtomType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimOfferAtomV0) Equals(other ClaimOfferAtomV0) bool {
+	if s.SellerEd25519 != other.SellerEd25519 {
+		return false
+	}
+	if s.OfferId != other.OfferId {
+		return false
+	}
+	if !s.AssetSold.Equals(other.AssetSold) {
+		return false
+	}
+	if s.AmountSold != other.AmountSold {
+		return false
+	}
+	if !s.AssetBought.Equals(other.AssetBought) {
+		return false
+	}
+	if s.AmountBought != other.AmountBought {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimOfferAtom) Equals(other ClaimOfferAtom) bool {
+	if !s.SellerId.Equals(other.SellerId) {
+		return false
+	}
+	if s.OfferId != other.OfferId {
+		return false
+	}
+	if !s.AssetSold.Equals(other.AssetSold) {
+		return false
+	}
+	if s.AmountSold != other.AmountSold {
+		return false
+	}
+	if !s.AssetBought.Equals(other.AssetBought) {
+		return false
+	}
+	if s.AmountBought != other.AmountBought {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimLiquidityAtom) Equals(other ClaimLiquidityAtom) bool {
+	if s.LiquidityPoolId != other.LiquidityPoolId {
+		return false
+	}
+	if !s.AssetSold.Equals(other.AssetSold) {
+		return false
+	}
+	if s.AmountSold != other.AmountSold {
+		return false
+	}
+	if !s.AssetBought.Equals(other.AssetBought) {
+		return false
+	}
+	if s.AmountBought != other.AmountBought {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimAtom) Equals(other ClaimAtom) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "V0":
+		if (s.V0 == nil) != (other.V0 == nil) {
+			return false
+		}
+		if s.V0 != nil {
+			if !(*s.V0).Equals((*other.V0)) {
+				return false
+			}
+		}
+	case "OrderBook":
+		if (s.OrderBook == nil) != (other.OrderBook == nil) {
+			return false
+		}
+		if s.OrderBook != nil {
+			if !(*s.OrderBook).Equals((*other.OrderBook)) {
+				return false
+			}
+		}
+	case "LiquidityPool":
+		if (s.LiquidityPool == nil) != (other.LiquidityPool == nil) {
+			return false
+		}
+		if s.LiquidityPool != nil {
+			if !(*s.LiquidityPool).Equals((*other.LiquidityPool)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s CreateAccountResultCode) Equals(other CreateAccountResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *CreateAccountResult) Equals(other CreateAccountResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s PaymentResultCode) Equals(other PaymentResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PaymentResult) Equals(other PaymentResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s PathPaymentStrictReceiveResultCode) Equals(other PathPaymentStrictReceiveResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SimplePaymentResult) Equals(other SimplePaymentResult) bool {
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if !s.Asset.Equals(other.Asset) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PathPaymentStrictReceiveResultSuccess) Equals(other PathPaymentStrictReceiveResultSuccess) bool {
+	if len(s.Offers) != len(other.Offers) {
+		return false
+	}
+	for i0 := range s.Offers {
+		if !s.Offers[i0].Equals(other.Offers[i0]) {
+			return false
+		}
+	}
+	if !s.Last.Equals(other.Last) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PathPaymentStrictReceiveResult) Equals(other PathPaymentStrictReceiveResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Success":
+		if (s.Success == nil) != (other.Success == nil) {
+			return false
+		}
+		if s.Success != nil {
+			if !(*s.Success).Equals((*other.Success)) {
+				return false
+			}
+		}
+	case "NoIssuer":
+		if (s.NoIssuer == nil) != (other.NoIssuer == nil) {
+			return false
+		}
+		if s.NoIssuer != nil {
+			if !(*s.NoIssuer).Equals((*other.NoIssuer)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s PathPaymentStrictSendResultCode) Equals(other PathPaymentStrictSendResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PathPaymentStrictSendResultSuccess) Equals(other PathPaymentStrictSendResultSuccess) bool {
+	if len(s.Offers) != len(other.Offers) {
+		return false
+	}
+	for i0 := range s.Offers {
+		if !s.Offers[i0].Equals(other.Offers[i0]) {
+			return false
+		}
+	}
+	if !s.Last.Equals(other.Last) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PathPaymentStrictSendResult) Equals(other PathPaymentStrictSendResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Success":
+		if (s.Success == nil) != (other.Success == nil) {
+			return false
+		}
+		if s.Success != nil {
+			if !(*s.Success).Equals((*other.Success)) {
+				return false
+			}
+		}
+	case "NoIssuer":
+		if (s.NoIssuer == nil) != (other.NoIssuer == nil) {
+			return false
+		}
+		if s.NoIssuer != nil {
+			if !(*s.NoIssuer).Equals((*other.NoIssuer)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ManageSellOfferResultCode) Equals(other ManageSellOfferResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ManageOfferEffect) Equals(other ManageOfferEffect) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageOfferSuccessResultOffer) Equals(other ManageOfferSuccessResultOffer) bool {
+	if s.Effect != other.Effect {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Effect))
+	switch arm {
+	case "Offer":
+		if (s.Offer == nil) != (other.Offer == nil) {
+			return false
+		}
+		if s.Offer != nil {
+			if !(*s.Offer).Equals((*other.Offer)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageOfferSuccessResult) Equals(other ManageOfferSuccessResult) bool {
+	if len(s.OffersClaimed) != len(other.OffersClaimed) {
+		return false
+	}
+	for i0 := range s.OffersClaimed {
+		if !s.OffersClaimed[i0].Equals(other.OffersClaimed[i0]) {
+			return false
+		}
+	}
+	if !s.Offer.Equals(other.Offer) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageSellOfferResult) Equals(other ManageSellOfferResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Success":
+		if (s.Success == nil) != (other.Success == nil) {
+			return false
+		}
+		if s.Success != nil {
+			if !(*s.Success).Equals((*other.Success)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ManageBuyOfferResultCode) Equals(other ManageBuyOfferResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageBuyOfferResult) Equals(other ManageBuyOfferResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Success":
+		if (s.Success == nil) != (other.Success == nil) {
+			return false
+		}
+		if s.Success != nil {
+			if !(*s.Success).Equals((*other.Success)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s SetOptionsResultCode) Equals(other SetOptionsResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SetOptionsResult) Equals(other SetOptionsResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ChangeTrustResultCode) Equals(other ChangeTrustResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ChangeTrustResult) Equals(other ChangeTrustResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s AllowTrustResultCode) Equals(other AllowTrustResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AllowTrustResult) Equals(other AllowTrustResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s AccountMergeResultCode) Equals(other AccountMergeResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *AccountMergeResult) Equals(other AccountMergeResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "SourceAccountBalance":
+		if (s.SourceAccountBalance == nil) != (other.SourceAccountBalance == nil) {
+			return false
+		}
+		if s.SourceAccountBalance != nil {
+			if (*s.SourceAccountBalance) != (*other.SourceAccountBalance) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s InflationResultCode) Equals(other InflationResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *InflationPayout) Equals(other InflationPayout) bool {
+	if !s.Destination.Equals(other.Destination) {
+		return false
+	}
+	if s.Amount != other.Amount {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *InflationResult) Equals(other InflationResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Payouts":
+		if (s.Payouts == nil) != (other.Payouts == nil) {
+			return false
+		}
+		if s.Payouts != nil {
+			if len((*s.Payouts)) != len((*other.Payouts)) {
+				return false
+			}
+			for i0 := range *s.Payouts {
+				if !(*s.Payouts)[i0].Equals((*other.Payouts)[i0]) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ManageDataResultCode) Equals(other ManageDataResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ManageDataResult) Equals(other ManageDataResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s BumpSequenceResultCode) Equals(other BumpSequenceResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BumpSequenceResult) Equals(other BumpSequenceResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s CreateClaimableBalanceResultCode) Equals(other CreateClaimableBalanceResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *CreateClaimableBalanceResult) Equals(other CreateClaimableBalanceResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "BalanceId":
+		if (s.BalanceId == nil) != (other.BalanceId == nil) {
+			return false
+		}
+		if s.BalanceId != nil {
+			if !(*s.BalanceId).Equals((*other.BalanceId)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClaimClaimableBalanceResultCode) Equals(other ClaimClaimableBalanceResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClaimClaimableBalanceResult) Equals(other ClaimClaimableBalanceResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s BeginSponsoringFutureReservesResultCode) Equals(other BeginSponsoringFutureReservesResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *BeginSponsoringFutureReservesResult) Equals(other BeginSponsoringFutureReservesResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s EndSponsoringFutureReservesResultCode) Equals(other EndSponsoringFutureReservesResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *EndSponsoringFutureReservesResult) Equals(other EndSponsoringFutureReservesResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s RevokeSponsorshipResultCode) Equals(other RevokeSponsorshipResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *RevokeSponsorshipResult) Equals(other RevokeSponsorshipResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClawbackResultCode) Equals(other ClawbackResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClawbackResult) Equals(other ClawbackResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s ClawbackClaimableBalanceResultCode) Equals(other ClawbackClaimableBalanceResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *ClawbackClaimableBalanceResult) Equals(other ClawbackClaimableBalanceResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s SetTrustLineFlagsResultCode) Equals(other SetTrustLineFlagsResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *SetTrustLineFlagsResult) Equals(other SetTrustLineFlagsResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LiquidityPoolDepositResultCode) Equals(other LiquidityPoolDepositResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolDepositResult) Equals(other LiquidityPoolDepositResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s LiquidityPoolWithdrawResultCode) Equals(other LiquidityPoolWithdrawResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *LiquidityPoolWithdrawResult) Equals(other LiquidityPoolWithdrawResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s OperationResultCode) Equals(other OperationResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *OperationResultTr) Equals(other OperationResultTr) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "CreateAccountResult":
+		if (s.CreateAccountResult == nil) != (other.CreateAccountResult == nil) {
+			return false
+		}
+		if s.CreateAccountResult != nil {
+			if !(*s.CreateAccountResult).Equals((*other.CreateAccountResult)) {
+				return false
+			}
+		}
+	case "PaymentResult":
+		if (s.PaymentResult == nil) != (other.PaymentResult == nil) {
+			return false
+		}
+		if s.PaymentResult != nil {
+			if !(*s.PaymentResult).Equals((*other.PaymentResult)) {
+				return false
+			}
+		}
+	case "PathPaymentStrictReceiveResult":
+		if (s.PathPaymentStrictReceiveResult == nil) != (other.PathPaymentStrictReceiveResult == nil) {
+			return false
+		}
+		if s.PathPaymentStrictReceiveResult != nil {
+			if !(*s.PathPaymentStrictReceiveResult).Equals((*other.PathPaymentStrictReceiveResult)) {
+				return false
+			}
+		}
+	case "ManageSellOfferResult":
+		if (s.ManageSellOfferResult == nil) != (other.ManageSellOfferResult == nil) {
+			return false
+		}
+		if s.ManageSellOfferResult != nil {
+			if !(*s.ManageSellOfferResult).Equals((*other.ManageSellOfferResult)) {
+				return false
+			}
+		}
+	case "CreatePassiveSellOfferResult":
+		if (s.CreatePassiveSellOfferResult == nil) != (other.CreatePassiveSellOfferResult == nil) {
+			return false
+		}
+		if s.CreatePassiveSellOfferResult != nil {
+			if !(*s.CreatePassiveSellOfferResult).Equals((*other.CreatePassiveSellOfferResult)) {
+				return false
+			}
+		}
+	case "SetOptionsResult":
+		if (s.SetOptionsResult == nil) != (other.SetOptionsResult == nil) {
+			return false
+		}
+		if s.SetOptionsResult != nil {
+			if !(*s.SetOptionsResult).Equals((*other.SetOptionsResult)) {
+				return false
+			}
+		}
+	case "ChangeTrustResult":
+		if (s.ChangeTrustResult == nil) != (other.ChangeTrustResult == nil) {
+			return false
+		}
+		if s.ChangeTrustResult != nil {
+			if !(*s.ChangeTrustResult).Equals((*other.ChangeTrustResult)) {
+				return false
+			}
+		}
+	case "AllowTrustResult":
+		if (s.AllowTrustResult == nil) != (other.AllowTrustResult == nil) {
+			return false
+		}
+		if s.AllowTrustResult != nil {
+			if !(*s.AllowTrustResult).Equals((*other.AllowTrustResult)) {
+				return false
+			}
+		}
+	case "AccountMergeResult":
+		if (s.AccountMergeResult == nil) != (other.AccountMergeResult == nil) {
+			return false
+		}
+		if s.AccountMergeResult != nil {
+			if !(*s.AccountMergeResult).Equals((*other.AccountMergeResult)) {
+				return false
+			}
+		}
+	case "InflationResult":
+		if (s.InflationResult == nil) != (other.InflationResult == nil) {
+			return false
+		}
+		if s.InflationResult != nil {
+			if !(*s.InflationResult).Equals((*other.InflationResult)) {
+				return false
+			}
+		}
+	case "ManageDataResult":
+		if (s.ManageDataResult == nil) != (other.ManageDataResult == nil) {
+			return false
+		}
+		if s.ManageDataResult != nil {
+			if !(*s.ManageDataResult).Equals((*other.ManageDataResult)) {
+				return false
+			}
+		}
+	case "BumpSeqResult":
+		if (s.BumpSeqResult == nil) != (other.BumpSeqResult == nil) {
+			return false
+		}
+		if s.BumpSeqResult != nil {
+			if !(*s.BumpSeqResult).Equals((*other.BumpSeqResult)) {
+				return false
+			}
+		}
+	case "ManageBuyOfferResult":
+		if (s.ManageBuyOfferResult == nil) != (other.ManageBuyOfferResult == nil) {
+			return false
+		}
+		if s.ManageBuyOfferResult != nil {
+			if !(*s.ManageBuyOfferResult).Equals((*other.ManageBuyOfferResult)) {
+				return false
+			}
+		}
+	case "PathPaymentStrictSendResult":
+		if (s.PathPaymentStrictSendResult == nil) != (other.PathPaymentStrictSendResult == nil) {
+			return false
+		}
+		if s.PathPaymentStrictSendResult != nil {
+			if !(*s.PathPaymentStrictSendResult).Equals((*other.PathPaymentStrictSendResult)) {
+				return false
+			}
+		}
+	case "CreateClaimableBalanceResult":
+		if (s.CreateClaimableBalanceResult == nil) != (other.CreateClaimableBalanceResult == nil) {
+			return false
+		}
+		if s.CreateClaimableBalanceResult != nil {
+			if !(*s.CreateClaimableBalanceResult).Equals((*other.CreateClaimableBalanceResult)) {
+				return false
+			}
+		}
+	case "ClaimClaimableBalanceResult":
+		if (s.ClaimClaimableBalanceResult == nil) != (other.ClaimClaimableBalanceResult == nil) {
+			return false
+		}
+		if s.ClaimClaimableBalanceResult != nil {
+			if !(*s.ClaimClaimableBalanceResult).Equals((*other.ClaimClaimableBalanceResult)) {
+				return false
+			}
+		}
+	case "BeginSponsoringFutureReservesResult":
+		if (s.BeginSponsoringFutureReservesResult == nil) != (other.BeginSponsoringFutureReservesResult == nil) {
+			return false
+		}
+		if s.BeginSponsoringFutureReservesResult != nil {
+			if !(*s.BeginSponsoringFutureReservesResult).Equals((*other.BeginSponsoringFutureReservesResult)) {
+				return false
+			}
+		}
+	case "EndSponsoringFutureReservesResult":
+		if (s.EndSponsoringFutureReservesResult == nil) != (other.EndSponsoringFutureReservesResult == nil) {
+			return false
+		}
+		if s.EndSponsoringFutureReservesResult != nil {
+			if !(*s.EndSponsoringFutureReservesResult).Equals((*other.EndSponsoringFutureReservesResult)) {
+				return false
+			}
+		}
+	case "RevokeSponsorshipResult":
+		if (s.RevokeSponsorshipResult == nil) != (other.RevokeSponsorshipResult == nil) {
+			return false
+		}
+		if s.RevokeSponsorshipResult != nil {
+			if !(*s.RevokeSponsorshipResult).Equals((*other.RevokeSponsorshipResult)) {
+				return false
+			}
+		}
+	case "ClawbackResult":
+		if (s.ClawbackResult == nil) != (other.ClawbackResult == nil) {
+			return false
+		}
+		if s.ClawbackResult != nil {
+			if !(*s.ClawbackResult).Equals((*other.ClawbackResult)) {
+				return false
+			}
+		}
+	case "ClawbackClaimableBalanceResult":
+		if (s.ClawbackClaimableBalanceResult == nil) != (other.ClawbackClaimableBalanceResult == nil) {
+			return false
+		}
+		if s.ClawbackClaimableBalanceResult != nil {
+			if !(*s.ClawbackClaimableBalanceResult).Equals((*other.ClawbackClaimableBalanceResult)) {
+				return false
+			}
+		}
+	case "SetTrustLineFlagsResult":
+		if (s.SetTrustLineFlagsResult == nil) != (other.SetTrustLineFlagsResult == nil) {
+			return false
+		}
+		if s.SetTrustLineFlagsResult != nil {
+			if !(*s.SetTrustLineFlagsResult).Equals((*other.SetTrustLineFlagsResult)) {
+				return false
+			}
+		}
+	case "LiquidityPoolDepositResult":
+		if (s.LiquidityPoolDepositResult == nil) != (other.LiquidityPoolDepositResult == nil) {
+			return false
+		}
+		if s.LiquidityPoolDepositResult != nil {
+			if !(*s.LiquidityPoolDepositResult).Equals((*other.LiquidityPoolDepositResult)) {
+				return false
+			}
+		}
+	case "LiquidityPoolWithdrawResult":
+		if (s.LiquidityPoolWithdrawResult == nil) != (other.LiquidityPoolWithdrawResult == nil) {
+			return false
+		}
+		if s.LiquidityPoolWithdrawResult != nil {
+			if !(*s.LiquidityPoolWithdrawResult).Equals((*other.LiquidityPoolWithdrawResult)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *OperationResult) Equals(other OperationResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Tr":
+		if (s.Tr == nil) != (other.Tr == nil) {
+			return false
+		}
+		if s.Tr != nil {
+			if !(*s.Tr).Equals((*other.Tr)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s TransactionResultCode) Equals(other TransactionResultCode) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *InnerTransactionResultResult) Equals(other InnerTransactionResultResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "Results":
+		if (s.Results == nil) != (other.Results == nil) {
+			return false
+		}
+		if s.Results != nil {
+			if len((*s.Results)) != len((*other.Results)) {
+				return false
+			}
+			for i0 := range *s.Results {
+				if !(*s.Results)[i0].Equals((*other.Results)[i0]) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *InnerTransactionResultExt) Equals(other InnerTransactionResultExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *InnerTransactionResult) Equals(other InnerTransactionResult) bool {
+	if s.FeeCharged != other.FeeCharged {
+		return false
+	}
+	if !s.Result.Equals(other.Result) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *InnerTransactionResultPair) Equals(other InnerTransactionResultPair) bool {
+	if s.TransactionHash != other.TransactionHash {
+		return false
+	}
+	if !s.Result.Equals(other.Result) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionResultResult) Equals(other TransactionResultResult) bool {
+	if s.Code != other.Code {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Code))
+	switch arm {
+	case "InnerResultPair":
+		if (s.InnerResultPair == nil) != (other.InnerResultPair == nil) {
+			return false
+		}
+		if s.InnerResultPair != nil {
+			if !(*s.InnerResultPair).Equals((*other.InnerResultPair)) {
+				return false
+			}
+		}
+	case "Results":
+		if (s.Results == nil) != (other.Results == nil) {
+			return false
+		}
+		if s.Results != nil {
+			if len((*s.Results)) != len((*other.Results)) {
+				return false
+			}
+			for i0 := range *s.Results {
+				if !(*s.Results)[i0].Equals((*other.Results)[i0]) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionResultExt) Equals(other TransactionResultExt) bool {
+	if s.V != other.V {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *TransactionResult) Equals(other TransactionResult) bool {
+	if s.FeeCharged != other.FeeCharged {
+		return false
+	}
+	if !s.Result.Equals(other.Result) {
+		return false
+	}
+	if !s.Ext.Equals(other.Ext) {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Hash) Equals(other Hash) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Uint256) Equals(other Uint256) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Uint32) Equals(other Uint32) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Int32) Equals(other Int32) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Uint64) Equals(other Uint64) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Int64) Equals(other Int64) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s CryptoKeyType) Equals(other CryptoKeyType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s PublicKeyType) Equals(other PublicKeyType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s SignerKeyType) Equals(other SignerKeyType) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *PublicKey) Equals(other PublicKey) bool {
+	if s.Type != other.Type {
+		return false
+	}
+	arm, _ := s.ArmForSwitch(int32(s.Type))
+	switch arm {
+	case "Ed25519":
+		if (s.Ed25519 == nil) != (other.Ed25519 == nil) {
+			return false
+		}
+		if s.Ed25519 != nil {
+			if (*s.Ed25519) != (*other.Ed25519) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s Signature) Equals(other Signature) bool {
+	return bytes.Equal(s, other)
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s SignatureHint) Equals(other SignatureHint) bool {
+	return s == other
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *NodeId) Equals(other NodeId) bool {
+	return (*PublicKey)(s).Equals(PublicKey(other))
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Curve25519Secret) Equals(other Curve25519Secret) bool {
+	if s.Key != other.Key {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *Curve25519Public) Equals(other Curve25519Public) bool {
+	if s.Key != other.Key {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *HmacSha256Key) Equals(other HmacSha256Key) bool {
+	if s.Key != other.Key {
+		return false
+	}
+	return true
+}
+
+// Equals returns true if other is deeply equal to s.
+func (s *HmacSha256Mac) Equals(other HmacSha256Mac) bool {
+	if s.Mac != other.Mac {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,2827 @@
+// Code generated by jsongen from xdr_generated.go. DO NOT EDIT.
+
+package xdr
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpBallot) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpBallot) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ScpStatementType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), scpStatementTypeMap, "ScpStatementType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ScpStatementType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), scpStatementTypeMap, "ScpStatementType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpNomination) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpNomination) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpStatementPrepare) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpStatementPrepare) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpStatementConfirm) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpStatementConfirm) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpStatementExternalize) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpStatementExternalize) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpStatementPledges) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpStatementPledges) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpStatement) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpEnvelope) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpEnvelope) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpQuorumSet) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpQuorumSet) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountId) MarshalJSON() ([]byte, error) {
+	return PublicKey(s).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountId) UnmarshalJSON(data []byte) error {
+	return (*PublicKey)(s).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e AssetType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), assetTypeMap, "AssetType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AssetType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), assetTypeMap, "AssetType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AssetCode) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AssetCode) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AlphaNum4) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AlphaNum4) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AlphaNum12) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AlphaNum12) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Asset) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Asset) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Price) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Price) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Liabilities) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Liabilities) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ThresholdIndexes) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), thresholdIndexesMap, "ThresholdIndexes")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ThresholdIndexes) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), thresholdIndexesMap, "ThresholdIndexes")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LedgerEntryType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), ledgerEntryTypeMap, "LedgerEntryType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LedgerEntryType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), ledgerEntryTypeMap, "LedgerEntryType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Signer) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Signer) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e AccountFlags) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), accountFlagsMap, "AccountFlags")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AccountFlags) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), accountFlagsMap, "AccountFlags")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountEntryExtensionV2Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountEntryExtensionV2Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountEntryExtensionV2) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountEntryExtensionV2) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountEntryExtensionV1Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountEntryExtensionV1Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountEntryExtensionV1) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountEntryExtensionV1) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e TrustLineFlags) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), trustLineFlagsMap, "TrustLineFlags")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *TrustLineFlags) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), trustLineFlagsMap, "TrustLineFlags")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LiquidityPoolType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), liquidityPoolTypeMap, "LiquidityPoolType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LiquidityPoolType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), liquidityPoolTypeMap, "LiquidityPoolType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineAsset) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineAsset) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineEntryExtensionV2Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineEntryExtensionV2Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineEntryExtensionV2) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineEntryExtensionV2) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineEntryV1Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineEntryV1Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineEntryV1) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineEntryV1) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TrustLineEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TrustLineEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e OfferEntryFlags) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), offerEntryFlagsMap, "OfferEntryFlags")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *OfferEntryFlags) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), offerEntryFlagsMap, "OfferEntryFlags")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s OfferEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OfferEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s OfferEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OfferEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s DataEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *DataEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s DataEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *DataEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClaimPredicateType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), claimPredicateTypeMap, "ClaimPredicateType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClaimPredicateType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), claimPredicateTypeMap, "ClaimPredicateType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClaimantType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), claimantTypeMap, "ClaimantType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClaimantType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), claimantTypeMap, "ClaimantType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimantV0) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimantV0) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Claimant) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Claimant) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClaimableBalanceIdType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), claimableBalanceIdTypeMap, "ClaimableBalanceIdType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClaimableBalanceIdType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), claimableBalanceIdTypeMap, "ClaimableBalanceIdType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimableBalanceId) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimableBalanceId) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClaimableBalanceFlags) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), claimableBalanceFlagsMap, "ClaimableBalanceFlags")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClaimableBalanceFlags) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), claimableBalanceFlagsMap, "ClaimableBalanceFlags")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimableBalanceEntryExtensionV1Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimableBalanceEntryExtensionV1Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimableBalanceEntryExtensionV1) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimableBalanceEntryExtensionV1) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimableBalanceEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimableBalanceEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimableBalanceEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimableBalanceEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolConstantProductParameters) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolConstantProductParameters) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolEntryConstantProduct) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolEntryConstantProduct) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolEntryBody) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolEntryBody) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerEntryExtensionV1Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerEntryExtensionV1Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerEntryExtensionV1) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerEntryExtensionV1) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerEntryData) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerEntryData) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKeyAccount) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKeyAccount) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKeyTrustLine) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKeyTrustLine) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKeyOffer) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKeyOffer) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKeyData) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKeyData) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKeyClaimableBalance) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKeyClaimableBalance) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKeyLiquidityPool) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKeyLiquidityPool) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerKey) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerKey) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e EnvelopeType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), envelopeTypeMap, "EnvelopeType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *EnvelopeType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), envelopeTypeMap, "EnvelopeType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e StellarValueType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), stellarValueTypeMap, "StellarValueType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *StellarValueType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), stellarValueTypeMap, "StellarValueType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerCloseValueSignature) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerCloseValueSignature) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s StellarValueExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StellarValueExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s StellarValue) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StellarValue) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LedgerHeaderFlags) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), ledgerHeaderFlagsMap, "LedgerHeaderFlags")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LedgerHeaderFlags) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), ledgerHeaderFlagsMap, "LedgerHeaderFlags")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerHeaderExtensionV1Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerHeaderExtensionV1Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerHeaderExtensionV1) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerHeaderExtensionV1) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerHeaderExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerHeaderExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerHeader) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerHeader) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LedgerUpgradeType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), ledgerUpgradeTypeMap, "LedgerUpgradeType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LedgerUpgradeType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), ledgerUpgradeTypeMap, "LedgerUpgradeType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerUpgrade) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerUpgrade) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e BucketEntryType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), bucketEntryTypeMap, "BucketEntryType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *BucketEntryType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), bucketEntryTypeMap, "BucketEntryType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BucketMetadataExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BucketMetadataExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BucketMetadata) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BucketMetadata) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BucketEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BucketEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionSet) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionSet) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionResultPair) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionResultPair) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionResultSet) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionResultSet) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionHistoryEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionHistoryEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionHistoryEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionHistoryEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionHistoryResultEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionHistoryResultEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionHistoryResultEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionHistoryResultEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerHeaderHistoryEntryExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerHeaderHistoryEntryExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerHeaderHistoryEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerHeaderHistoryEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerScpMessages) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerScpMessages) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpHistoryEntryV0) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpHistoryEntryV0) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ScpHistoryEntry) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScpHistoryEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LedgerEntryChangeType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), ledgerEntryChangeTypeMap, "LedgerEntryChangeType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LedgerEntryChangeType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), ledgerEntryChangeTypeMap, "LedgerEntryChangeType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerEntryChange) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerEntryChange) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s OperationMeta) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OperationMeta) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionMetaV1) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionMetaV1) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionMetaV2) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionMetaV2) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionMeta) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionMeta) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionResultMeta) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionResultMeta) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s UpgradeEntryMeta) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *UpgradeEntryMeta) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerCloseMetaV0) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerCloseMetaV0) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LedgerCloseMeta) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LedgerCloseMeta) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ErrorCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), errorCodeMap, "ErrorCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ErrorCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), errorCodeMap, "ErrorCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Error) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Error) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AuthCert) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AuthCert) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Hello) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Hello) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Auth) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Auth) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e IpAddrType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), ipAddrTypeMap, "IpAddrType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *IpAddrType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), ipAddrTypeMap, "IpAddrType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PeerAddressIp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PeerAddressIp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PeerAddress) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PeerAddress) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e MessageType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), messageTypeMap, "MessageType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *MessageType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), messageTypeMap, "MessageType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s DontHave) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *DontHave) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e SurveyMessageCommandType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), surveyMessageCommandTypeMap, "SurveyMessageCommandType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SurveyMessageCommandType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), surveyMessageCommandTypeMap, "SurveyMessageCommandType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SurveyRequestMessage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SurveyRequestMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SignedSurveyRequestMessage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SignedSurveyRequestMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SurveyResponseMessage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SurveyResponseMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SignedSurveyResponseMessage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SignedSurveyResponseMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PeerStats) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PeerStats) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TopologyResponseBody) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TopologyResponseBody) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SurveyResponseBody) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SurveyResponseBody) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s StellarMessage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StellarMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AuthenticatedMessageV0) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AuthenticatedMessageV0) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AuthenticatedMessage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AuthenticatedMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolParameters) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolParameters) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s MuxedAccountMed25519) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *MuxedAccountMed25519) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s DecoratedSignature) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *DecoratedSignature) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e OperationType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), operationTypeMap, "OperationType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *OperationType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), operationTypeMap, "OperationType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s CreateAccountOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *CreateAccountOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PaymentOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PaymentOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PathPaymentStrictReceiveOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PathPaymentStrictReceiveOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PathPaymentStrictSendOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PathPaymentStrictSendOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageSellOfferOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageSellOfferOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageBuyOfferOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageBuyOfferOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s CreatePassiveSellOfferOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *CreatePassiveSellOfferOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SetOptionsOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SetOptionsOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ChangeTrustAsset) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ChangeTrustAsset) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ChangeTrustOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ChangeTrustOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AllowTrustOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AllowTrustOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageDataOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageDataOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BumpSequenceOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BumpSequenceOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s CreateClaimableBalanceOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *CreateClaimableBalanceOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimClaimableBalanceOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimClaimableBalanceOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BeginSponsoringFutureReservesOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BeginSponsoringFutureReservesOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e RevokeSponsorshipType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), revokeSponsorshipTypeMap, "RevokeSponsorshipType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RevokeSponsorshipType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), revokeSponsorshipTypeMap, "RevokeSponsorshipType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s RevokeSponsorshipOpSigner) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *RevokeSponsorshipOpSigner) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s RevokeSponsorshipOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *RevokeSponsorshipOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClawbackOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClawbackOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClawbackClaimableBalanceOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClawbackClaimableBalanceOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SetTrustLineFlagsOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SetTrustLineFlagsOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolDepositOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolDepositOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolWithdrawOp) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolWithdrawOp) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s OperationBody) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OperationBody) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Operation) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Operation) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s HashIdPreimageOperationId) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *HashIdPreimageOperationId) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s HashIdPreimageRevokeId) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *HashIdPreimageRevokeId) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s HashIdPreimage) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *HashIdPreimage) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e MemoType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), memoTypeMap, "MemoType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *MemoType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), memoTypeMap, "MemoType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Memo) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Memo) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TimeBounds) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TimeBounds) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionV0Ext) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionV0Ext) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionV0) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionV0) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionV0Envelope) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionV0Envelope) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Transaction) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Transaction) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionV1Envelope) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionV1Envelope) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s FeeBumpTransactionInnerTx) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *FeeBumpTransactionInnerTx) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s FeeBumpTransactionExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *FeeBumpTransactionExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s FeeBumpTransaction) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *FeeBumpTransaction) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s FeeBumpTransactionEnvelope) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *FeeBumpTransactionEnvelope) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionEnvelope) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionEnvelope) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionSignaturePayloadTaggedTransaction) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionSignaturePayloadTaggedTransaction) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionSignaturePayload) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionSignaturePayload) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClaimAtomType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), claimAtomTypeMap, "ClaimAtomType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClaimAtomType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), claimAtomTypeMap, "ClaimAtomType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimOfferAtomV0) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimOfferAtomV0) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimOfferAtom) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimOfferAtom) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimLiquidityAtom) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimLiquidityAtom) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimAtom) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimAtom) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e CreateAccountResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), createAccountResultCodeMap, "CreateAccountResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *CreateAccountResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), createAccountResultCodeMap, "CreateAccountResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s CreateAccountResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *CreateAccountResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e PaymentResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), paymentResultCodeMap, "PaymentResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *PaymentResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), paymentResultCodeMap, "PaymentResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PaymentResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PaymentResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e PathPaymentStrictReceiveResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), pathPaymentStrictReceiveResultCodeMap, "PathPaymentStrictReceiveResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *PathPaymentStrictReceiveResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), pathPaymentStrictReceiveResultCodeMap, "PathPaymentStrictReceiveResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SimplePaymentResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SimplePaymentResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PathPaymentStrictReceiveResultSuccess) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PathPaymentStrictReceiveResultSuccess) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PathPaymentStrictReceiveResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PathPaymentStrictReceiveResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e PathPaymentStrictSendResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), pathPaymentStrictSendResultCodeMap, "PathPaymentStrictSendResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *PathPaymentStrictSendResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), pathPaymentStrictSendResultCodeMap, "PathPaymentStrictSendResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PathPaymentStrictSendResultSuccess) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PathPaymentStrictSendResultSuccess) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s PathPaymentStrictSendResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *PathPaymentStrictSendResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ManageSellOfferResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), manageSellOfferResultCodeMap, "ManageSellOfferResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ManageSellOfferResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), manageSellOfferResultCodeMap, "ManageSellOfferResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ManageOfferEffect) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), manageOfferEffectMap, "ManageOfferEffect")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ManageOfferEffect) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), manageOfferEffectMap, "ManageOfferEffect")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageOfferSuccessResultOffer) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageOfferSuccessResultOffer) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageOfferSuccessResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageOfferSuccessResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageSellOfferResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageSellOfferResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ManageBuyOfferResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), manageBuyOfferResultCodeMap, "ManageBuyOfferResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ManageBuyOfferResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), manageBuyOfferResultCodeMap, "ManageBuyOfferResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageBuyOfferResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageBuyOfferResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e SetOptionsResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), setOptionsResultCodeMap, "SetOptionsResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SetOptionsResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), setOptionsResultCodeMap, "SetOptionsResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SetOptionsResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SetOptionsResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ChangeTrustResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), changeTrustResultCodeMap, "ChangeTrustResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ChangeTrustResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), changeTrustResultCodeMap, "ChangeTrustResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ChangeTrustResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ChangeTrustResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e AllowTrustResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), allowTrustResultCodeMap, "AllowTrustResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AllowTrustResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), allowTrustResultCodeMap, "AllowTrustResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AllowTrustResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AllowTrustResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e AccountMergeResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), accountMergeResultCodeMap, "AccountMergeResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AccountMergeResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), accountMergeResultCodeMap, "AccountMergeResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s AccountMergeResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *AccountMergeResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e InflationResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), inflationResultCodeMap, "InflationResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *InflationResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), inflationResultCodeMap, "InflationResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InflationPayout) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *InflationPayout) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InflationResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *InflationResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ManageDataResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), manageDataResultCodeMap, "ManageDataResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ManageDataResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), manageDataResultCodeMap, "ManageDataResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ManageDataResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ManageDataResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e BumpSequenceResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), bumpSequenceResultCodeMap, "BumpSequenceResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *BumpSequenceResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), bumpSequenceResultCodeMap, "BumpSequenceResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BumpSequenceResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BumpSequenceResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e CreateClaimableBalanceResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), createClaimableBalanceResultCodeMap, "CreateClaimableBalanceResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *CreateClaimableBalanceResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), createClaimableBalanceResultCodeMap, "CreateClaimableBalanceResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s CreateClaimableBalanceResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *CreateClaimableBalanceResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClaimClaimableBalanceResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), claimClaimableBalanceResultCodeMap, "ClaimClaimableBalanceResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClaimClaimableBalanceResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), claimClaimableBalanceResultCodeMap, "ClaimClaimableBalanceResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClaimClaimableBalanceResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClaimClaimableBalanceResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e BeginSponsoringFutureReservesResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), beginSponsoringFutureReservesResultCodeMap, "BeginSponsoringFutureReservesResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *BeginSponsoringFutureReservesResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), beginSponsoringFutureReservesResultCodeMap, "BeginSponsoringFutureReservesResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BeginSponsoringFutureReservesResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BeginSponsoringFutureReservesResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e EndSponsoringFutureReservesResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), endSponsoringFutureReservesResultCodeMap, "EndSponsoringFutureReservesResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *EndSponsoringFutureReservesResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), endSponsoringFutureReservesResultCodeMap, "EndSponsoringFutureReservesResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s EndSponsoringFutureReservesResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *EndSponsoringFutureReservesResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e RevokeSponsorshipResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), revokeSponsorshipResultCodeMap, "RevokeSponsorshipResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RevokeSponsorshipResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), revokeSponsorshipResultCodeMap, "RevokeSponsorshipResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s RevokeSponsorshipResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *RevokeSponsorshipResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClawbackResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), clawbackResultCodeMap, "ClawbackResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClawbackResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), clawbackResultCodeMap, "ClawbackResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClawbackResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClawbackResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ClawbackClaimableBalanceResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), clawbackClaimableBalanceResultCodeMap, "ClawbackClaimableBalanceResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ClawbackClaimableBalanceResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), clawbackClaimableBalanceResultCodeMap, "ClawbackClaimableBalanceResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ClawbackClaimableBalanceResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ClawbackClaimableBalanceResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e SetTrustLineFlagsResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), setTrustLineFlagsResultCodeMap, "SetTrustLineFlagsResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SetTrustLineFlagsResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), setTrustLineFlagsResultCodeMap, "SetTrustLineFlagsResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SetTrustLineFlagsResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SetTrustLineFlagsResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LiquidityPoolDepositResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), liquidityPoolDepositResultCodeMap, "LiquidityPoolDepositResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LiquidityPoolDepositResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), liquidityPoolDepositResultCodeMap, "LiquidityPoolDepositResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolDepositResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolDepositResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e LiquidityPoolWithdrawResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), liquidityPoolWithdrawResultCodeMap, "LiquidityPoolWithdrawResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LiquidityPoolWithdrawResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), liquidityPoolWithdrawResultCodeMap, "LiquidityPoolWithdrawResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s LiquidityPoolWithdrawResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *LiquidityPoolWithdrawResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e OperationResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), operationResultCodeMap, "OperationResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *OperationResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), operationResultCodeMap, "OperationResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s OperationResultTr) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OperationResultTr) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s OperationResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OperationResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e TransactionResultCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), transactionResultCodeMap, "TransactionResultCode")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *TransactionResultCode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), transactionResultCodeMap, "TransactionResultCode")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InnerTransactionResultResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *InnerTransactionResultResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InnerTransactionResultExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *InnerTransactionResultExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InnerTransactionResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *InnerTransactionResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s InnerTransactionResultPair) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *InnerTransactionResultPair) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionResultResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionResultResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionResultExt) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionResultExt) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionResult) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionResult) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e CryptoKeyType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), cryptoKeyTypeMap, "CryptoKeyType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *CryptoKeyType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), cryptoKeyTypeMap, "CryptoKeyType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e PublicKeyType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), publicKeyTypeMap, "PublicKeyType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *PublicKeyType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), publicKeyTypeMap, "PublicKeyType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e SignerKeyType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(int32(e), signerKeyTypeMap, "SignerKeyType")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SignerKeyType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, (*int32)(e), signerKeyTypeMap, "SignerKeyType")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s NodeId) MarshalJSON() ([]byte, error) {
+	return PublicKey(s).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *NodeId) UnmarshalJSON(data []byte) error {
+	return (*PublicKey)(s).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Curve25519Secret) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Curve25519Secret) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Curve25519Public) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Curve25519Public) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s HmacSha256Key) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *HmacSha256Key) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s HmacSha256Mac) MarshalJSON() ([]byte, error) {
+	return marshalXDRJSON(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *HmacSha256Mac) UnmarshalJSON(data []byte) error {
+	return unmarshalXDRJSON(data, s)
+}
+
+// xdrTypes are the constructors of the XDR types by name.
+var xdrTypes = map[string]func() interface{}{
+	"Value":                                  func() interface{} { return new(Value) },
+	"ScpBallot":                              func() interface{} { return new(ScpBallot) },
+	"ScpStatementType":                       func() interface{} { return new(ScpStatementType) },
+	"ScpNomination":                          func() interface{} { return new(ScpNomination) },
+	"ScpStatementPrepare":                    func() interface{} { return new(ScpStatementPrepare) },
+	"ScpStatementConfirm":                    func() interface{} { return new(ScpStatementConfirm) },
+	"ScpStatementExternalize":                func() interface{} { return new(ScpStatementExternalize) },
+	"ScpStatementPledges":                    func() interface{} { return new(ScpStatementPledges) },
+	"ScpStatement":                           func() interface{} { return new(ScpStatement) },
+	"ScpEnvelope":                            func() interface{} { return new(ScpEnvelope) },
+	"ScpQuorumSet":                           func() interface{} { return new(ScpQuorumSet) },
+	"AccountId":                              func() interface{} { return new(AccountId) },
+	"Thresholds":                             func() interface{} { return new(Thresholds) },
+	"String32":                               func() interface{} { return new(String32) },
+	"String64":                               func() interface{} { return new(String64) },
+	"SequenceNumber":                         func() interface{} { return new(SequenceNumber) },
+	"TimePoint":                              func() interface{} { return new(TimePoint) },
+	"DataValue":                              func() interface{} { return new(DataValue) },
+	"PoolId":                                 func() interface{} { return new(PoolId) },
+	"AssetCode4":                             func() interface{} { return new(AssetCode4) },
+	"AssetCode12":                            func() interface{} { return new(AssetCode12) },
+	"AssetType":                              func() interface{} { return new(AssetType) },
+	"AssetCode":                              func() interface{} { return new(AssetCode) },
+	"AlphaNum4":                              func() interface{} { return new(AlphaNum4) },
+	"AlphaNum12":                             func() interface{} { return new(AlphaNum12) },
+	"Asset":                                  func() interface{} { return new(Asset) },
+	"Price":                                  func() interface{} { return new(Price) },
+	"Liabilities":                            func() interface{} { return new(Liabilities) },
+	"ThresholdIndexes":                       func() interface{} { return new(ThresholdIndexes) },
+	"LedgerEntryType":                        func() interface{} { return new(LedgerEntryType) },
+	"Signer":                                 func() interface{} { return new(Signer) },
+	"AccountFlags":                           func() interface{} { return new(AccountFlags) },
+	"AccountEntryExtensionV2Ext":             func() interface{} { return new(AccountEntryExtensionV2Ext) },
+	"AccountEntryExtensionV2":                func() interface{} { return new(AccountEntryExtensionV2) },
+	"AccountEntryExtensionV1Ext":             func() interface{} { return new(AccountEntryExtensionV1Ext) },
+	"AccountEntryExtensionV1":                func() interface{} { return new(AccountEntryExtensionV1) },
+	"AccountEntryExt":                        func() interface{} { return new(AccountEntryExt) },
+	"AccountEntry":                           func() interface{} { return new(AccountEntry) },
+	"TrustLineFlags":                         func() interface{} { return new(TrustLineFlags) },
+	"LiquidityPoolType":                      func() interface{} { return new(LiquidityPoolType) },
+	"TrustLineAsset":                         func() interface{} { return new(TrustLineAsset) },
+	"TrustLineEntryExtensionV2Ext":           func() interface{} { return new(TrustLineEntryExtensionV2Ext) },
+	"TrustLineEntryExtensionV2":              func() interface{} { return new(TrustLineEntryExtensionV2) },
+	"TrustLineEntryV1Ext":                    func() interface{} { return new(TrustLineEntryV1Ext) },
+	"TrustLineEntryV1":                       func() interface{} { return new(TrustLineEntryV1) },
+	"TrustLineEntryExt":                      func() interface{} { return new(TrustLineEntryExt) },
+	"TrustLineEntry":                         func() interface{} { return new(TrustLineEntry) },
+	"OfferEntryFlags":                        func() interface{} { return new(OfferEntryFlags) },
+	"OfferEntryExt":                          func() interface{} { return new(OfferEntryExt) },
+	"OfferEntry":                             func() interface{} { return new(OfferEntry) },
+	"DataEntryExt":                           func() interface{} { return new(DataEntryExt) },
+	"DataEntry":                              func() interface{} { return new(DataEntry) },
+	"ClaimPredicateType":                     func() interface{} { return new(ClaimPredicateType) },
+	"ClaimPredicate":                         func() interface{} { return new(ClaimPredicate) },
+	"ClaimantType":                           func() interface{} { return new(ClaimantType) },
+	"ClaimantV0":                             func() interface{} { return new(ClaimantV0) },
+	"Claimant":                               func() interface{} { return new(Claimant) },
+	"ClaimableBalanceIdType":                 func() interface{} { return new(ClaimableBalanceIdType) },
+	"ClaimableBalanceId":                     func() interface{} { return new(ClaimableBalanceId) },
+	"ClaimableBalanceFlags":                  func() interface{} { return new(ClaimableBalanceFlags) },
+	"ClaimableBalanceEntryExtensionV1Ext":    func() interface{} { return new(ClaimableBalanceEntryExtensionV1Ext) },
+	"ClaimableBalanceEntryExtensionV1":       func() interface{} { return new(ClaimableBalanceEntryExtensionV1) },
+	"ClaimableBalanceEntryExt":               func() interface{} { return new(ClaimableBalanceEntryExt) },
+	"ClaimableBalanceEntry":                  func() interface{} { return new(ClaimableBalanceEntry) },
+	"LiquidityPoolConstantProductParameters": func() interface{} { return new(LiquidityPoolConstantProductParameters) },
+	"LiquidityPoolEntryConstantProduct":      func() interface{} { return new(LiquidityPoolEntryConstantProduct) },
+	"LiquidityPoolEntryBody":                 func() interface{} { return new(LiquidityPoolEntryBody) },
+	"LiquidityPoolEntry":                     func() interface{} { return new(LiquidityPoolEntry) },
+	"LedgerEntryExtensionV1Ext":              func() interface{} { return new(LedgerEntryExtensionV1Ext) },
+	"LedgerEntryExtensionV1":                 func() interface{} { return new(LedgerEntryExtensionV1) },
+	"LedgerEntryData":                        func() interface{} { return new(LedgerEntryData) },
+	"LedgerEntryExt":                         func() interface{} { return new(LedgerEntryExt) },
+	"LedgerEntry":                            func() interface{} { return new(LedgerEntry) },
+	"LedgerKeyAccount":                       func() interface{} { return new(LedgerKeyAccount) },
+	"LedgerKeyTrustLine":                     func() interface{} { return new(LedgerKeyTrustLine) },
+	"LedgerKeyOffer":                         func() interface{} { return new(LedgerKeyOffer) },
+	"LedgerKeyData":                          func() interface{} { return new(LedgerKeyData) },
+	"LedgerKeyClaimableBalance":              func() interface{} { return new(LedgerKeyClaimableBalance) },
+	"LedgerKeyLiquidityPool":                 func() interface{} { return new(LedgerKeyLiquidityPool) },
+	"LedgerKey":                              func() interface{} { return new(LedgerKey) },
+	"EnvelopeType":                           func() interface{} { return new(EnvelopeType) },
+	"UpgradeType":                            func() interface{} { return new(UpgradeType) },
+	"StellarValueType":                       func() interface{} { return new(StellarValueType) },
+	"LedgerCloseValueSignature":              func() interface{} { return new(LedgerCloseValueSignature) },
+	"StellarValueExt":                        func() interface{} { return new(StellarValueExt) },
+	"StellarValue":                           func() interface{} { return new(StellarValue) },
+	"LedgerHeaderFlags":                      func() interface{} { return new(LedgerHeaderFlags) },
+	"LedgerHeaderExtensionV1Ext":             func() interface{} { return new(LedgerHeaderExtensionV1Ext) },
+	"LedgerHeaderExtensionV1":                func() interface{} { return new(LedgerHeaderExtensionV1) },
+	"LedgerHeaderExt":                        func() interface{} { return new(LedgerHeaderExt) },
+	"LedgerHeader":                           func() interface{} { return new(LedgerHeader) },
+	"LedgerUpgradeType":                      func() interface{} { return new(LedgerUpgradeType) },
+	"LedgerUpgrade":                          func() interface{} { return new(LedgerUpgrade) },
+	"BucketEntryType":                        func() interface{} { return new(BucketEntryType) },
+	"BucketMetadataExt":                      func() interface{} { return new(BucketMetadataExt) },
+	"BucketMetadata":                         func() interface{} { return new(BucketMetadata) },
+	"BucketEntry":                            func() interface{} { return new(BucketEntry) },
+	"TransactionSet":                         func() interface{} { return new(TransactionSet) },
+	"TransactionResultPair":                  func() interface{} { return new(TransactionResultPair) },
+	"TransactionResultSet":                   func() interface{} { return new(TransactionResultSet) },
+	"TransactionHistoryEntryExt":             func() interface{} { return new(TransactionHistoryEntryExt) },
+	"TransactionHistoryEntry":                func() interface{} { return new(TransactionHistoryEntry) },
+	"TransactionHistoryResultEntryExt":       func() interface{} { return new(TransactionHistoryResultEntryExt) },
+	"TransactionHistoryResultEntry":          func() interface{} { return new(TransactionHistoryResultEntry) },
+	"LedgerHeaderHistoryEntryExt":            func() interface{} { return new(LedgerHeaderHistoryEntryExt) },
+	"LedgerHeaderHistoryEntry":               func() interface{} { return new(LedgerHeaderHistoryEntry) },
+	"LedgerScpMessages":                      func() interface{} { return new(LedgerScpMessages) },
+	"ScpHistoryEntryV0":                      func() interface{} { return new(ScpHistoryEntryV0) },
+	"ScpHistoryEntry":                        func() interface{} { return new(ScpHistoryEntry) },
+	"LedgerEntryChangeType":                  func() interface{} { return new(LedgerEntryChangeType) },
+	"LedgerEntryChange":                      func() interface{} { return new(LedgerEntryChange) },
+	"LedgerEntryChanges":                     func() interface{} { return new(LedgerEntryChanges) },
+	"OperationMeta":                          func() interface{} { return new(OperationMeta) },
+	"TransactionMetaV1":                      func() interface{} { return new(TransactionMetaV1) },
+	"TransactionMetaV2":                      func() interface{} { return new(TransactionMetaV2) },
+	"TransactionMeta":                        func() interface{} { return new(TransactionMeta) },
+	"TransactionResultMeta":                  func() interface{} { return new(TransactionResultMeta) },
+	"UpgradeEntryMeta":                       func() interface{} { return new(UpgradeEntryMeta) },
+	"LedgerCloseMetaV0":                      func() interface{} { return new(LedgerCloseMetaV0) },
+	"LedgerCloseMeta":                        func() interface{} { return new(LedgerCloseMeta) },
+	"ErrorCode":                              func() interface{} { return new(ErrorCode) },
+	"Error":                                  func() interface{} { return new(Error) },
+	"AuthCert":                               func() interface{} { return new(AuthCert) },
+	"Hello":                                  func() interface{} { return new(Hello) },
+	"Auth":                                   func() interface{} { return new(Auth) },
+	"IpAddrType":                             func() interface{} { return new(IpAddrType) },
+	"PeerAddressIp":                          func() interface{} { return new(PeerAddressIp) },
+	"PeerAddress":                            func() interface{} { return new(PeerAddress) },
+	"MessageType":                            func() interface{} { return new(MessageType) },
+	"DontHave":                               func() interface{} { return new(DontHave) },
+	"SurveyMessageCommandType":               func() interface{} { return new(SurveyMessageCommandType) },
+	"SurveyRequestMessage":                   func() interface{} { return new(SurveyRequestMessage) },
+	"SignedSurveyRequestMessage":             func() interface{} { return new(SignedSurveyRequestMessage) },
+	"EncryptedBody":                          func() interface{} { return new(EncryptedBody) },
+	"SurveyResponseMessage":                  func() interface{} { return new(SurveyResponseMessage) },
+	"SignedSurveyResponseMessage":            func() interface{} { return new(SignedSurveyResponseMessage) },
+	"PeerStats":                              func() interface{} { return new(PeerStats) },
+	"PeerStatList":                           func() interface{} { return new(PeerStatList) },
+	"TopologyResponseBody":                   func() interface{} { return new(TopologyResponseBody) },
+	"SurveyResponseBody":                     func() interface{} { return new(SurveyResponseBody) },
+	"StellarMessage":                         func() interface{} { return new(StellarMessage) },
+	"AuthenticatedMessageV0":                 func() interface{} { return new(AuthenticatedMessageV0) },
+	"AuthenticatedMessage":                   func() interface{} { return new(AuthenticatedMessage) },
+	"LiquidityPoolParameters":                func() interface{} { return new(LiquidityPoolParameters) },
+	"MuxedAccountMed25519":                   func() interface{} { return new(MuxedAccountMed25519) },
+	"MuxedAccount":                           func() interface{} { return new(MuxedAccount) },
+	"DecoratedSignature":                     func() interface{} { return new(DecoratedSignature) },
+	"OperationType":                          func() interface{} { return new(OperationType) },
+	"CreateAccountOp":                        func() interface{} { return new(CreateAccountOp) },
+	"PaymentOp":                              func() interface{} { return new(PaymentOp) },
+	"PathPaymentStrictReceiveOp":             func() interface{} { return new(PathPaymentStrictReceiveOp) },
+	"PathPaymentStrictSendOp":                func() interface{} { return new(PathPaymentStrictSendOp) },
+	"ManageSellOfferOp":                      func() interface{} { return new(ManageSellOfferOp) },
+	"ManageBuyOfferOp":                       func() interface{} { return new(ManageBuyOfferOp) },
+	"CreatePassiveSellOfferOp":               func() interface{} { return new(CreatePassiveSellOfferOp) },
+	"SetOptionsOp":                           func() interface{} { return new(SetOptionsOp) },
+	"ChangeTrustAsset":                       func() interface{} { return new(ChangeTrustAsset) },
+	"ChangeTrustOp":                          func() interface{} { return new(ChangeTrustOp) },
+	"AllowTrustOp":                           func() interface{} { return new(AllowTrustOp) },
+	"ManageDataOp":                           func() interface{} { return new(ManageDataOp) },
+	"BumpSequenceOp":                         func() interface{} { return new(BumpSequenceOp) },
+	"CreateClaimableBalanceOp":               func() interface{} { return new(CreateClaimableBalanceOp) },
+	"ClaimClaimableBalanceOp":                func() interface{} { return new(ClaimClaimableBalanceOp) },
+	"BeginSponsoringFutureReservesOp":        func() interface{} { return new(BeginSponsoringFutureReservesOp) },
+	"RevokeSponsorshipType":                  func() interface{} { return new(RevokeSponsorshipType) },
+	"RevokeSponsorshipOpSigner":              func() interface{} { return new(RevokeSponsorshipOpSigner) },
+	"RevokeSponsorshipOp":                    func() interface{} { return new(RevokeSponsorshipOp) },
+	"ClawbackOp":                             func() interface{} { return new(ClawbackOp) },
+	"ClawbackClaimableBalanceOp":             func() interface{} { return new(ClawbackClaimableBalanceOp) },
+	"SetTrustLineFlagsOp":                    func() interface{} { return new(SetTrustLineFlagsOp) },
+	"LiquidityPoolDepositOp":                 func() interface{} { return new(LiquidityPoolDepositOp) },
+	"LiquidityPoolWithdrawOp":                func() interface{} { return new(LiquidityPoolWithdrawOp) },
+	"OperationBody":                          func() interface{} { return new(OperationBody) },
+	"Operation":                              func() interface{} { return new(Operation) },
+	"HashIdPreimageOperationId":              func() interface{} { return new(HashIdPreimageOperationId) },
+	"HashIdPreimageRevokeId":                 func() interface{} { return new(HashIdPreimageRevokeId) },
+	"HashIdPreimage":                         func() interface{} { return new(HashIdPreimage) },
+	"MemoType":                               func() interface{} { return new(MemoType) },
+	"Memo":                                   func() interface{} { return new(Memo) },
+	"TimeBounds":                             func() interface{} { return new(TimeBounds) },
+	"TransactionV0Ext":                       func() interface{} { return new(TransactionV0Ext) },
+	"TransactionV0":                          func() interface{} { return new(TransactionV0) },
+	"TransactionV0Envelope":                  func() interface{} { return new(TransactionV0Envelope) },
+	"TransactionExt":                         func() interface{} { return new(TransactionExt) },
+	"Transaction":                            func() interface{} { return new(Transaction) },
+	"TransactionV1Envelope":                  func() interface{} { return new(TransactionV1Envelope) },
+	"FeeBumpTransactionInnerTx":              func() interface{} { return new(FeeBumpTransactionInnerTx) },
+	"FeeBumpTransactionExt":                  func() interface{} { return new(FeeBumpTransactionExt) },
+	"FeeBumpTransaction":                     func() interface{} { return new(FeeBumpTransaction) },
+	"FeeBumpTransactionEnvelope":             func() interface{} { return new(FeeBumpTransactionEnvelope) },
+	"TransactionEnvelope":                    func() interface{} { return new(TransactionEnvelope) },
+	"TransactionSignaturePayloadTaggedTransaction": func() interface{} { return new(TransactionSignaturePayloadTaggedTransaction) },
+	"TransactionSignaturePayload":                  func() interface{} { return new(TransactionSignaturePayload) },
+	"ClaimAtomType":                                func() interface{} { return new(ClaimAtomType) },
+	"ClaimOfferAtomV0":                             func() interface{} { return new(ClaimOfferAtomV0) },
+	"ClaimOfferAtom":                               func() interface{} { return new(ClaimOfferAtom) },
+	"ClaimLiquidityAtom":                           func() interface{} { return new(ClaimLiquidityAtom) },
+	"ClaimAtom":                                    func() interface{} { return new(ClaimAtom) },
+	"CreateAccountResultCode":                      func() interface{} { return new(CreateAccountResultCode) },
+	"CreateAccountResult":                          func() interface{} { return new(CreateAccountResult) },
+	"PaymentResultCode":                            func() interface{} { return new(PaymentResultCode) },
+	"PaymentResult":                                func() interface{} { return new(PaymentResult) },
+	"PathPaymentStrictReceiveResultCode":           func() interface{} { return new(PathPaymentStrictReceiveResultCode) },
+	"SimplePaymentResult":                          func() interface{} { return new(SimplePaymentResult) },
+	"PathPaymentStrictReceiveResultSuccess":        func() interface{} { return new(PathPaymentStrictReceiveResultSuccess) },
+	"PathPaymentStrictReceiveResult":               func() interface{} { return new(PathPaymentStrictReceiveResult) },
+	"PathPaymentStrictSendResultCode":              func() interface{} { return new(PathPaymentStrictSendResultCode) },
+	"PathPaymentStrictSendResultSuccess":           func() interface{} { return new(PathPaymentStrictSendResultSuccess) },
+	"PathPaymentStrictSendResult":                  func() interface{} { return new(PathPaymentStrictSendResult) },
+	"ManageSellOfferResultCode":                    func() interface{} { return new(ManageSellOfferResultCode) },
+	"ManageOfferEffect":                            func() interface{} { return new(ManageOfferEffect) },
+	"ManageOfferSuccessResultOffer":                func() interface{} { return new(ManageOfferSuccessResultOffer) },
+	"ManageOfferSuccessResult":                     func() interface{} { return new(ManageOfferSuccessResult) },
+	"ManageSellOfferResult":                        func() interface{} { return new(ManageSellOfferResult) },
+	"ManageBuyOfferResultCode":                     func() interface{} { return new(ManageBuyOfferResultCode) },
+	"ManageBuyOfferResult":                         func() interface{} { return new(ManageBuyOfferResult) },
+	"SetOptionsResultCode":                         func() interface{} { return new(SetOptionsResultCode) },
+	"SetOptionsResult":                             func() interface{} { return new(SetOptionsResult) },
+	"ChangeTrustResultCode":                        func() interface{} { return new(ChangeTrustResultCode) },
+	"ChangeTrustResult":                            func() interface{} { return new(ChangeTrustResult) },
+	"AllowTrustResultCode":                         func() interface{} { return new(AllowTrustResultCode) },
+	"AllowTrustResult":                             func() interface{} { return new(AllowTrustResult) },
+	"AccountMergeResultCode":                       func() interface{} { return new(AccountMergeResultCode) },
+	"AccountMergeResult":                           func() interface{} { return new(AccountMergeResult) },
+	"InflationResultCode":                          func() interface{} { return new(InflationResultCode) },
+	"InflationPayout":                              func() interface{} { return new(InflationPayout) },
+	"InflationResult":                              func() interface{} { return new(InflationResult) },
+	"ManageDataResultCode":                         func() interface{} { return new(ManageDataResultCode) },
+	"ManageDataResult":                             func() interface{} { return new(ManageDataResult) },
+	"BumpSequenceResultCode":                       func() interface{} { return new(BumpSequenceResultCode) },
+	"BumpSequenceResult":                           func() interface{} { return new(BumpSequenceResult) },
+	"CreateClaimableBalanceResultCode":             func() interface{} { return new(CreateClaimableBalanceResultCode) },
+	"CreateClaimableBalanceResult":                 func() interface{} { return new(CreateClaimableBalanceResult) },
+	"ClaimClaimableBalanceResultCode":              func() interface{} { return new(ClaimClaimableBalanceResultCode) },
+	"ClaimClaimableBalanceResult":                  func() interface{} { return new(ClaimClaimableBalanceResult) },
+	"BeginSponsoringFutureReservesResultCode":      func() interface{} { return new(BeginSponsoringFutureReservesResultCode) },
+	"BeginSponsoringFutureReservesResult":          func() interface{} { return new(BeginSponsoringFutureReservesResult) },
+	"EndSponsoringFutureReservesResultCode":        func() interface{} { return new(EndSponsoringFutureReservesResultCode) },
+	"EndSponsoringFutureReservesResult":            func() interface{} { return new(EndSponsoringFutureReservesResult) },
+	"RevokeSponsorshipResultCode":                  func() interface{} { return new(RevokeSponsorshipResultCode) },
+	"RevokeSponsorshipResult":                      func() interface{} { return new(RevokeSponsorshipResult) },
+	"ClawbackResultCode":                           func() interface{} { return new(ClawbackResultCode) },
+	"ClawbackResult":                               func() interface{} { return new(ClawbackResult) },
+	"ClawbackClaimableBalanceResultCode":           func() interface{} { return new(ClawbackClaimableBalanceResultCode) },
+	"ClawbackClaimableBalanceResult":               func() interface{} { return new(ClawbackClaimableBalanceResult) },
+	"SetTrustLineFlagsResultCode":                  func() interface{} { return new(SetTrustLineFlagsResultCode) },
+	"SetTrustLineFlagsResult":                      func() interface{} { return new(SetTrustLineFlagsResult) },
+	"LiquidityPoolDepositResultCode":               func() interface{} { return new(LiquidityPoolDepositResultCode) },
+	"LiquidityPoolDepositResult":                   func() interface{} { return new(LiquidityPoolDepositResult) },
+	"LiquidityPoolWithdrawResultCode":              func() interface{} { return new(LiquidityPoolWithdrawResultCode) },
+	"LiquidityPoolWithdrawResult":                  func() interface{} { return new(LiquidityPoolWithdrawResult) },
+	"OperationResultCode":                          func() interface{} { return new(OperationResultCode) },
+	"OperationResultTr":                            func() interface{} { return new(OperationResultTr) },
+	"OperationResult":                              func() interface{} { return new(OperationResult) },
+	"TransactionResultCode":                        func() interface{} { return new(TransactionResultCode) },
+	"InnerTransactionResultResult":                 func() interface{} { return new(InnerTransactionResultResult) },
+	"InnerTransactionResultExt":                    func() interface{} { return new(InnerTransactionResultExt) },
+	"InnerTransactionResult":                       func() interface{} { return new(InnerTransactionResult) },
+	"InnerTransactionResultPair":                   func() interface{} { return new(InnerTransactionResultPair) },
+	"TransactionResultResult":                      func() interface{} { return new(TransactionResultResult) },
+	"TransactionResultExt":                         func() interface{} { return new(TransactionResultExt) },
+	"TransactionResult":                            func() interface{} { return new(TransactionResult) },
+	"Hash":                                         func() interface{} { return new(Hash) },
+	"Uint256":                                      func() interface{} { return new(Uint256) },
+	"Uint32":                                       func() interface{} { return new(Uint32) },
+	"Int32":                                        func() interface{} { return new(Int32) },
+	"Uint64":                                       func() interface{} { return new(Uint64) },
+	"Int64":                                        func() interface{} { return new(Int64) },
+	"CryptoKeyType":                                func() interface{} { return new(CryptoKeyType) },
+	"PublicKeyType":                                func() interface{} { return new(PublicKeyType) },
+	"SignerKeyType":                                func() interface{} { return new(SignerKeyType) },
+	"PublicKey":                                    func() interface{} { return new(PublicKey) },
+	"SignerKey":                                    func() interface{} { return new(SignerKey) },
+	"Signature":                                    func() interface{} { return new(Signature) },
+	"SignatureHint":                                func() interface{} { return new(SignatureHint) },
+	"NodeId":                                       func() interface{} { return new(NodeId) },
+	"Curve25519Secret":                             func() interface{} { return new(Curve25519Secret) },
+	"Curve25519Public":                             func() interface{} { return new(Curve25519Public) },
+	"HmacSha256Key":                                func() interface{} { return new(HmacSha256Key) },
+	"HmacSha256Mac":                                func() interface{} { return new(HmacSha256Mac) },
+}
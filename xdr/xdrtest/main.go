@@ -0,0 +1,138 @@
+// Package xdrtest exposes the corpus of XDR test vectors the SDK is tested
+// against, so that other encoders and parsers of Stellar XDR can check they
+// agree with the SDK.
+//
+// Every vector is a sample of the binary encoding of one XDR type. Valid
+// vectors must be decoded and re-encoded to the exact same bytes, and invalid
+// vectors (truncated input, trailing bytes, unknown union discriminants, ...)
+// must be rejected. Run the whole suite from a test with:
+//
+//	func TestConformance(t *testing.T) {
+//		xdrtest.Run(t, func(typeName string, data []byte) ([]byte, error) {
+//			// decode data as typeName with your parser and encode it again
+//		})
+//	}
+//
+// Encoders which cannot run Go tests can be checked against Canonicalize,
+// which is served over HTTP by Handler and the tools/xdr-conformance command.
+// Canonicalize accepts every XDR type of the SDK, listed by xdr.TypeNames,
+// not only the types sampled by the corpus.
+package xdrtest
+
+import (
+	_ "embed" // for the corpus
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stellar/go/support/errors"
+)
+
+//go:embed corpus.json
+var corpusJSON []byte
+
+var corpus = mustLoadCorpus(corpusJSON)
+
+// Vector is a sample of the XDR encoding of a type.
+type Vector struct {
+	// Type is the name of the XDR type, as in the .x files, e.g.
+	// "TransactionEnvelope".
+	Type string `json:"type"`
+	// Name describes the sample, e.g. "random 3" or "truncated".
+	Name string `json:"name"`
+	// XDR is the binary encoding of the sample (base64 encoded in JSON).
+	XDR []byte `json:"xdr"`
+	// Valid is true when XDR is a canonical encoding of a value of Type.
+	Valid bool `json:"valid"`
+}
+
+// RoundTripper decodes data as a value of the named XDR type and returns the
+// encoding of the decoded value. It must return an error when data is not a
+// valid encoding of that type.
+type RoundTripper func(typeName string, data []byte) ([]byte, error)
+
+func mustLoadCorpus(data []byte) []Vector {
+	var vectors []Vector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		panic(errors.Wrap(err, "invalid xdrtest corpus"))
+	}
+	return vectors
+}
+
+// Vectors returns all the vectors of the corpus. The returned slice can be
+// modified by the caller.
+func Vectors() []Vector {
+	vectors := make([]Vector, len(corpus))
+	for i, vector := range corpus {
+		vectors[i] = vector
+		vectors[i].XDR = append([]byte(nil), vector.XDR...)
+	}
+	return vectors
+}
+
+// VectorsOf returns the vectors of the corpus sampling the given type.
+func VectorsOf(typeName string) []Vector {
+	var vectors []Vector
+	for _, vector := range Vectors() {
+		if vector.Type == typeName {
+			vectors = append(vectors, vector)
+		}
+	}
+	return vectors
+}
+
+// Types returns the sorted names of the types sampled by the corpus.
+func Types() []string {
+	set := map[string]struct{}{}
+	for _, vector := range corpus {
+		set[vector.Type] = struct{}{}
+	}
+	types := make([]string, 0, len(set))
+	for typeName := range set {
+		types = append(types, typeName)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Check runs a single vector against rt, returning an error describing the
+// disagreement with the SDK, if any.
+func Check(vector Vector, rt RoundTripper) error {
+	encoded, err := rt(vector.Type, vector.XDR)
+	switch {
+	case !vector.Valid && err == nil:
+		return errors.Errorf("%s %q: invalid input was accepted", vector.Type, vector.Name)
+	case !vector.Valid:
+		return nil
+	case err != nil:
+		return errors.Wrapf(err, "%s %q: valid input was rejected", vector.Type, vector.Name)
+	case string(encoded) != string(vector.XDR):
+		return errors.Errorf("%s %q: round trip changed the encoding", vector.Type, vector.Name)
+	}
+	return nil
+}
+
+// Run runs every vector of the corpus against rt as a subtest of t, grouped by
+// type.
+func Run(t *testing.T, rt RoundTripper) {
+	for _, typeName := range Types() {
+		vectors := VectorsOf(typeName)
+		t.Run(typeName, func(t *testing.T) {
+			for i, vector := range vectors {
+				if err := Check(vector, rt); err != nil {
+					t.Errorf("vector %d: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+// SDKRoundTripper is the RoundTripper of the github.com/stellar/go/xdr
+// package, which the corpus is generated and tested with.
+func SDKRoundTripper(typeName string, data []byte) ([]byte, error) {
+	canonical, err := Canonicalize(typeName, data)
+	if err != nil {
+		return nil, err
+	}
+	return canonical.XDR, nil
+}
@@ -0,0 +1,158 @@
+package xdrtest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stellar/go/gxdr"
+	"github.com/stellar/go/randxdr"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goxdr "github.com/xdrpp/goxdr/xdr"
+)
+
+var update = flag.Bool("update", false, "regenerate corpus.json")
+
+// shapes contains the gxdr types used to generate random samples of the
+// types sampled by the corpus, which must be types of the SDK (see
+// xdr.TypeNames).
+var shapes = map[string]func() goxdr.XdrType{
+	"Asset":               func() goxdr.XdrType { return &gxdr.Asset{} },
+	"ClaimPredicate":      func() goxdr.XdrType { return &gxdr.ClaimPredicate{} },
+	"LedgerEntry":         func() goxdr.XdrType { return &gxdr.LedgerEntry{} },
+	"LedgerHeader":        func() goxdr.XdrType { return &gxdr.LedgerHeader{} },
+	"LedgerKey":           func() goxdr.XdrType { return &gxdr.LedgerKey{} },
+	"Memo":                func() goxdr.XdrType { return &gxdr.Memo{} },
+	"MuxedAccount":        func() goxdr.XdrType { return &gxdr.MuxedAccount{} },
+	"Operation":           func() goxdr.XdrType { return &gxdr.Operation{} },
+	"SignerKey":           func() goxdr.XdrType { return &gxdr.SignerKey{} },
+	"TransactionEnvelope": func() goxdr.XdrType { return &gxdr.TransactionEnvelope{} },
+	"TransactionMeta":     func() goxdr.XdrType { return &gxdr.TransactionMeta{} },
+	"TransactionResult":   func() goxdr.XdrType { return &gxdr.TransactionResult{} },
+}
+
+// startsWithDiscriminant contains the types whose encoding starts with the
+// discriminant of a union.
+var startsWithDiscriminant = map[string]bool{
+	"Asset":               true,
+	"ClaimPredicate":      true,
+	"LedgerKey":           true,
+	"Memo":                true,
+	"MuxedAccount":        true,
+	"SignerKey":           true,
+	"TransactionEnvelope": true,
+	"TransactionMeta":     true,
+}
+
+const randomSamplesPerType = 4
+
+func generateCorpus(t *testing.T) []Vector {
+	gen := randxdr.Generator{
+		MaxBytesSize: 16,
+		MaxVecLen:    2,
+		Source:       rand.NewSource(randxdr.DefaultSeed),
+	}
+
+	var vectors []Vector
+	for _, typeName := range sortedTypeNames() {
+		var samples [][]byte
+		for i := 0; i < randomSamplesPerType; i++ {
+			shape := shapes[typeName]()
+			gen.Next(shape, []randxdr.Preset{})
+			data := gxdr.Dump(shape)
+			require.NoError(t, Check(Vector{Type: typeName, XDR: data, Valid: true}, SDKRoundTripper))
+			samples = append(samples, data)
+			vectors = append(vectors, Vector{
+				Type:  typeName,
+				Name:  fmt.Sprintf("random %d", i),
+				XDR:   data,
+				Valid: true,
+			})
+		}
+
+		sample := samples[0]
+		invalid := []Vector{
+			{Name: "empty"},
+			{Name: "truncated", XDR: sample[:len(sample)-1]},
+			{Name: "trailing bytes", XDR: append(append([]byte{}, sample...), 0, 0, 0, 0)},
+		}
+		if startsWithDiscriminant[typeName] {
+			data := append([]byte{}, sample...)
+			binary.BigEndian.PutUint32(data, 0x7fffffff)
+			invalid = append(invalid, Vector{Name: "unknown discriminant", XDR: data})
+		}
+		for _, vector := range invalid {
+			vector.Type = typeName
+			require.NoError(t, Check(vector, SDKRoundTripper), vector.Name)
+			vectors = append(vectors, vector)
+		}
+	}
+	return vectors
+}
+
+func sortedTypeNames() []string {
+	names := make([]string, 0, len(shapes))
+	for typeName := range shapes {
+		names = append(names, typeName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCorpus(t *testing.T) {
+	generated := generateCorpus(t)
+	if *update {
+		data, err := json.MarshalIndent(generated, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile("corpus.json", append(data, '\n'), 0644))
+		return
+	}
+
+	// the corpus is a golden file, generating it again must not change it
+	assert.Equal(t, generated, Vectors(), "corpus.json is out of date, run the tests with -update")
+	assert.ElementsMatch(t, Types(), sortedTypeNames())
+	assert.Subset(t, xdr.TypeNames(), Types())
+}
+
+func TestSDKConformance(t *testing.T) {
+	Run(t, SDKRoundTripper)
+}
+
+func TestCheck(t *testing.T) {
+	vectors := VectorsOf("Asset")
+	require.NotEmpty(t, vectors)
+
+	acceptAll := func(typeName string, data []byte) ([]byte, error) {
+		return data, nil
+	}
+	for _, vector := range vectors {
+		err := Check(vector, acceptAll)
+		if vector.Valid {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, fmt.Sprintf("Asset %q: invalid input was accepted", vector.Name))
+		}
+	}
+
+	rejectAll := func(typeName string, data []byte) ([]byte, error) {
+		return nil, fmt.Errorf("unsupported")
+	}
+	for _, vector := range vectors {
+		err := Check(vector, rejectAll)
+		if vector.Valid {
+			assert.EqualError(t, err, fmt.Sprintf("Asset %q: valid input was rejected: unsupported", vector.Name))
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	_, err := SDKRoundTripper("Unknown", nil)
+	assert.EqualError(t, err, "unknown type Unknown")
+}
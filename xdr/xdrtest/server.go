@@ -0,0 +1,96 @@
+package xdrtest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Canonical is the canonical encoding of a value decoded by Canonicalize,
+// along with a JSON rendering of the decoded value for debugging.
+type Canonical struct {
+	Type string `json:"type"`
+	// XDR is the encoding of the decoded value (base64 encoded in JSON).
+	XDR []byte `json:"xdr"`
+	// Value is the JSON encoding of the Go value of the SDK.
+	Value json.RawMessage `json:"value"`
+	// Changed is true when XDR differs from the input, i.e. when the input was
+	// accepted by the SDK without being a canonical encoding.
+	Changed bool `json:"changed"`
+}
+
+// Canonicalize decodes data as a value of the named XDR type, one of
+// xdr.TypeNames(), with the SDK and returns the encoding of the decoded value.
+func Canonicalize(typeName string, data []byte) (Canonical, error) {
+	value, ok := xdr.NewValueOf(typeName)
+	if !ok {
+		return Canonical{}, errors.Errorf("unknown type %s", typeName)
+	}
+	if err := xdr.SafeUnmarshal(data, value); err != nil {
+		return Canonical{}, errors.Wrapf(err, "invalid %s", typeName)
+	}
+	encoded, err := value.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+	if err != nil {
+		return Canonical{}, errors.Wrapf(err, "could not encode %s", typeName)
+	}
+	rendered, err := json.Marshal(value)
+	if err != nil {
+		return Canonical{}, errors.Wrapf(err, "could not render %s", typeName)
+	}
+	return Canonical{
+		Type:    typeName,
+		XDR:     encoded,
+		Value:   rendered,
+		Changed: string(encoded) != string(data),
+	}, nil
+}
+
+// Request is the body of the requests to the Handler.
+type Request struct {
+	Type string `json:"type"`
+	// XDR is the encoding to canonicalize (base64 encoded in JSON).
+	XDR []byte `json:"xdr"`
+}
+
+// Handler is an http.Handler canonicalizing XDR with the SDK, so that the
+// encoders of other languages can be checked against it, e.g. in CI:
+//
+//	POST / {"type": "Asset", "xdr": "AAAAAA=="}
+//
+// responds with the Canonical rendering of the value, or with a 400 status
+// and an {"error": "..."} body if the XDR is invalid. GET /types responds
+// with the supported types, see xdr.TypeNames.
+type Handler struct{}
+
+func (Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/types":
+		writeJSON(w, http.StatusOK, xdr.TypeNames())
+	case r.Method == http.MethodPost && r.URL.Path == "/":
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request: " + err.Error()})
+			return
+		}
+		canonical, err := Canonicalize(request.Type, request.XDR)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, canonical)
+	default:
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "not found"})
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,78 @@
+package xdrtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	data, err := xdr.MustNewNativeAsset().MarshalBinary()
+	require.NoError(t, err)
+
+	canonical, err := Canonicalize("Asset", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Asset", canonical.Type)
+	assert.Equal(t, data, canonical.XDR)
+	assert.JSONEq(t, `{"type": "AssetTypeAssetTypeNative"}`, string(canonical.Value))
+	assert.False(t, canonical.Changed)
+
+	_, err = Canonicalize("Asset", data[:2])
+	assert.Contains(t, err.Error(), "invalid Asset: ")
+	// any XDR type of the SDK is supported, not only the ones of the corpus
+	price, err := xdr.Price{N: 1, D: 2}.MarshalBinary()
+	require.NoError(t, err)
+	canonical, err = Canonicalize("Price", price)
+	require.NoError(t, err)
+	assert.Equal(t, price, canonical.XDR)
+	assert.JSONEq(t, `{"n": 1, "d": 2}`, string(canonical.Value))
+
+	_, err = Canonicalize("Unknown", data)
+	assert.EqualError(t, err, "unknown type Unknown")
+}
+
+func serve(method, path, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	Handler{}.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestHandler(t *testing.T) {
+	for _, vector := range Vectors() {
+		body, err := json.Marshal(Request{Type: vector.Type, XDR: vector.XDR})
+		require.NoError(t, err)
+		response := serve(http.MethodPost, "/", string(body))
+		if !vector.Valid {
+			assert.Equal(t, http.StatusBadRequest, response.Code, "%s %s", vector.Type, vector.Name)
+			continue
+		}
+		require.Equal(t, http.StatusOK, response.Code, "%s %s", vector.Type, vector.Name)
+		var canonical Canonical
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &canonical))
+		assert.Equal(t, vector.XDR, canonical.XDR)
+		assert.False(t, canonical.Changed)
+	}
+
+	response := serve(http.MethodPost, "/", `{"type": "Unknown", "xdr": "AAAAAA=="}`)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(t, `{"error": "unknown type Unknown"}`, response.Body.String())
+
+	response = serve(http.MethodPost, "/", `not json`)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "invalid request: ")
+
+	response = serve(http.MethodGet, "/types", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	var types []string
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &types))
+	assert.Equal(t, xdr.TypeNames(), types)
+
+	response = serve(http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}